@@ -15,6 +15,14 @@ func main() {
 		&models.IssueScope{},
 		&models.Issue{},
 		&models.Link{},
+		&models.Label{},
+		&models.ExternalRef{},
+		&models.NamespaceSettings{},
+		&models.IssueWatch{},
+		&models.SavedView{},
+		&models.TriageRule{},
+		&models.TriageEvent{},
+		&models.WebhookEvent{},
 		&models.RelatedIssue{},
 	)
 
@@ -1,37 +1,53 @@
 package main
 
 import (
+	"flag"
 	"os"
 
-	"github.com/joho/godotenv"
 	"github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/seed"
 	"github.com/sirupsen/logrus"
 )
 
 func main() {
+	// Seeding --count synthetic issues rather than the sample data, for load testing
+	count := flag.Int("count", 0, "number of synthetic issues to generate, 0 to seed the sample data")
+	namespaces := flag.Int("namespaces", 10, "number of namespaces the synthetic issues are spread across")
+	days := flag.Int("days", 90, "number of days back the synthetic issues are detected")
+	reset := flag.Bool("reset", false, "delete all the issues before seeding")
+	upsert := flag.Bool("upsert", false, "refresh the sample issues already seeded rather than skipping seeding when the database has issues")
+	force := flag.Bool("i-know-what-im-doing", false, "allow seeding outside of the development environment, e.g. in staging")
+	flag.Parse()
+
 	// Initialize logger
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
 
 	// Check which environment we're in
 	env := os.Getenv("KITE_PROJECT_ENV")
-	if env == "production" {
-		logger.Fatal("Seeder can only be used in the development environment")
+	development := env == "" || env == "development"
+	if !development && !*force {
+		logger.WithField("environment", env).Fatal("Seeder can only be used in the development environment, pass --i-know-what-im-doing to seed anyway")
 	}
 
-	// Try to load ENV file
-	envFile, _ := config.GetEnvFileInCwd(".env.development")
-	if err := godotenv.Load(envFile); err != nil {
-		logger.WithError(err).Info("Could not load env file, using existing environment variables")
+	if development {
+		// Try to load ENV file
+		if err := config.LoadEnvFile(".env.development"); err != nil {
+			logger.WithError(err).Info("Could not load env file, using existing environment variables")
+		} else {
+			logger.Info("Loaded environment from .env.development")
+		}
 	} else {
-		logger.Info("Loaded environment from .env.development")
+		logger.WithField("environment", env).Warn("Seeding a non-development database")
 	}
 
 	logger.WithField("environment", env).Info("Starting database seeding")
 
 	// Initialize database
-	db, err := config.InitDatabase()
+	if err := config.LoadSecretFiles(); err != nil {
+		logger.WithError(err).Fatal("Failed to load secrets")
+	}
+	db, err := config.InitDatabase(config.GetDatabaseConfig())
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to initialize database")
 	}
@@ -48,8 +64,24 @@ func main() {
 		}
 	}()
 
+	if *reset {
+		if err := seed.Reset(db); err != nil {
+			logger.WithError(err).Fatal("Failed to reset database")
+		}
+	}
+
 	// Run seeding
-	if err := seed.SeedData(db); err != nil {
+	if *count > 0 {
+		logger.WithFields(logrus.Fields{
+			"count":      *count,
+			"namespaces": *namespaces,
+			"days":       *days,
+		}).Info("Seeding synthetic issues")
+		err = seed.SeedVolume(db, seed.VolumeOptions{Count: *count, Namespaces: *namespaces, Days: *days})
+	} else {
+		err = seed.SeedData(db, seed.Options{Upsert: *upsert})
+	}
+	if err != nil {
 		logger.WithError(err).Fatal("Failed to seed database")
 	}
 
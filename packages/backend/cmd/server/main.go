@@ -2,50 +2,56 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"log"
+	"maps"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/getsentry/sentry-go"
+
+	"github.com/konflux-ci/kite/internal/anomaly"
 	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/github"
 	handler_http "github.com/konflux-ci/kite/internal/handlers/http"
+	"github.com/konflux-ci/kite/internal/jira"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/startup"
+	"github.com/konflux-ci/kite/internal/version"
+	"github.com/konflux-ci/kite/internal/workers"
+	"github.com/konflux-ci/kite/migrations"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 func main() {
-	// Load environment variable
-	projectEnv := config.GetEnvOrDefault("KITE_PROJECT_ENV", "development")
-	fileName := fmt.Sprintf(".env.%s", projectEnv)
-	envFile, err := config.GetEnvFileInCwd(fileName)
-	if err != nil {
-		log.Printf("failed to get env file %s: %v", fileName, err)
-	}
-	if err := godotenv.Load(envFile); err != nil {
-		// It should be fine if the file doesn't exist
-		log.Printf("no %s file found, using system environment variables\n", envFile)
-	} else {
-		log.Printf("successfully loaded env file %s\n", envFile)
-	}
+	configFile := flag.String("config", config.GetEnvOrDefault("KITE_CONFIG_FILE", ""), "YAML or TOML configuration file, e.g. /etc/kite/config.yaml")
+	skipChecks := flag.Bool("skip-checks", false, "serve traffic right away, without checking the schema of the database and the services the server depends on")
+	flag.Parse()
 
-	// Load configuration
-	cfg, err := config.LoadConfig()
+	// Load configuration, from the environment, the configuration file and the .env.<KITE_PROJECT_ENV> file
+	cfg, err := config.Load(*configFile)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v\n", err)
 	}
 
 	// Initialize logger
-	logger := setupLogger()
+	logger := setupLogger(cfg.Logging)
 
+	build := version.Get()
 	logger.WithFields(logrus.Fields{
 		"environment": cfg.Server.Environment,
-		"version":     getVersion(),
-	})
+		"version":     build.Version,
+		"commit":      build.Commit,
+		"buildDate":   build.BuildDate,
+	}).Info("Loaded configuration")
 
 	// Initialize database
-	db, err := config.InitDatabase()
+	db, err := config.InitDatabase(cfg.Database)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to initialize database")
 	}
@@ -62,12 +68,47 @@ func main() {
 		}
 	}()
 
+	// Traffic is refused until the startup checks pass, unless they're skipped
+	var checker *startup.Checker
+	if *skipChecks {
+		logger.Warn("Skipping startup checks")
+	} else {
+		checker, err = newStartupChecker(db, cfg, logger)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to setup startup checks")
+		}
+	}
+
+	// Start the background workers, e.g. the connectors syncing issues with
+	// other trackers or the jobs of the admin endpoints, until the server shuts down
+	backgroundWorkers := workers.NewGroup(logger)
+
 	// Setup router
-	router, err := handler_http.SetupRouter(db, logger)
+	router, err := handler_http.SetupRouter(db, cfg, checker, backgroundWorkers, logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to setup router")
 	}
 
+	if checker != nil {
+		backgroundWorkers.Go("startup-checks", checker.Run)
+	}
+	issueRepo := repository.NewIssueRepository(db, logger, cfg.Database.QueryTimeout)
+	if cfg.Jira.Enabled {
+		escalator := jira.NewEscalator(issueRepo, jira.NewClient(cfg.Jira), cfg.Jira, logger)
+		backgroundWorkers.Go("jira", escalator.Run)
+		logger.WithField("namespaces", len(cfg.Jira.Projects)).Info("Started jira connector")
+	}
+	if cfg.GitHub.Enabled {
+		syncer := github.NewSyncer(issueRepo, github.NewClient(cfg.GitHub), cfg.GitHub, logger)
+		backgroundWorkers.Go("github", syncer.Run)
+		logger.WithField("repository", cfg.GitHub.Repository).Info("Started github connector")
+	}
+	if cfg.Anomaly.Enabled {
+		detector := anomaly.NewDetector(issueRepo, cfg.Anomaly, logger)
+		backgroundWorkers.Go("anomaly", detector.Run)
+		logger.WithField("window", cfg.Anomaly.Window).Info("Started anomaly detector")
+	}
+
 	// Setup HTTP server with configuration
 	server := &http.Server{
 		Addr:         cfg.GetServerAddress(),
@@ -91,6 +132,22 @@ func main() {
 		}
 	}()
 
+	// Serve the debug endpoints on their own address, so that they aren't exposed with the API
+	var debugServer *http.Server
+	if cfg.Debug.Enabled {
+		debugServer = &http.Server{
+			Addr:              cfg.Debug.Address,
+			Handler:           handler_http.NewDebugHandler(),
+			ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		}
+		go func() {
+			logger.WithField("address", cfg.Debug.Address).Info("Starting debug server")
+			if err := debugServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.WithError(err).Error("Failed to start debug server")
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown
 	// Create a channel that carries os.Signal values, buffer size 1
 	quit := make(chan os.Signal, 1)
@@ -101,6 +158,8 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	// The background workers complete their work in progress while the server shuts down
+	backgroundWorkers.Stop()
 
 	// Create a context with timeout for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
@@ -112,21 +171,65 @@ func main() {
 	} else {
 		logger.Info("Server shutdown gracefully")
 	}
+
+	// Drain the background workers within what's left of the shutdown timeout, before the database is closed
+	if err := backgroundWorkers.Shutdown(ctx); err != nil {
+		logger.WithError(err).Error("Background workers forced to shutdown")
+	} else {
+		logger.Info("Background workers shutdown gracefully")
+	}
+
+	if debugServer != nil {
+		if err := debugServer.Shutdown(ctx); err != nil {
+			logger.WithError(err).Warn("Debug server forced to shutdown")
+		}
+	}
+
+	// Send the errors not reported yet
+	if cfg.Sentry.DSN != "" && !sentry.Flush(2*time.Second) {
+		logger.Warn("Failed to send all errors to Sentry")
+	}
+}
+
+// newStartupChecker returns the checks the server runs before serving traffic:
+// the migrations are applied and their indexes exist, and the subsystems of the
+// health endpoint are reachable, only its critical components being required
+func newStartupChecker(db *gorm.DB, cfg *config.Config, logger *logrus.Logger) (*startup.Checker, error) {
+	expected, err := startup.ParseMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	checks := []startup.Check{
+		{Name: "schema", Critical: true, Run: startup.SchemaCheck(db, expected)},
+		{Name: "indexes", Critical: true, Run: startup.IndexCheck(db, expected)},
+	}
+
+	healthChecks, err := handler_http.NewHealthChecks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(healthChecks)) {
+		checks = append(checks, startup.Check{
+			Name:     name,
+			Critical: slices.Contains(cfg.Health.CriticalComponents, name),
+			Run:      healthChecks[name],
+		})
+	}
+	return startup.NewChecker(checks, cfg.Health.StartupCheckInterval, cfg.Health.CheckTimeout, logger), nil
 }
 
-func setupLogger() *logrus.Logger {
+func setupLogger(cfg config.LoggingConfig) *logrus.Logger {
 	logger := logrus.New()
 
 	// Set log level
-	logLevel := config.GetEnvOrDefault("KITE_LOG_LEVEL", "info")
-	level, err := logrus.ParseLevel(logLevel)
+	level, err := logrus.ParseLevel(cfg.Level)
 	if err != nil {
 		level = logrus.InfoLevel
 	}
 	logger.SetLevel(level)
 
 	// Set log format
-	if config.GetEnvOrDefault("KITE_PROJECT_ENV", "development") == "production" {
+	if cfg.Format == "json" {
 		logger.SetFormatter(&logrus.JSONFormatter{})
 	} else {
 		logger.SetFormatter(&logrus.TextFormatter{
@@ -137,11 +240,3 @@ func setupLogger() *logrus.Logger {
 
 	return logger
 }
-
-func getVersion() string {
-	// This should be set during build time
-	if version := os.Getenv("VERSION"); version != "" {
-		return version
-	}
-	return "dev"
-}
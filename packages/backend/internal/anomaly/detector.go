@@ -0,0 +1,254 @@
+// Package anomaly detects the spikes of issues created in a namespace or for a
+// resource, e.g. during cluster-wide outages, and opens a single incident
+// issue the issues of the spike are related to, rather than leaving teams to
+// triage each of them.
+package anomaly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// IncidentLabel labels the incident issues, with the value "spike"
+const IncidentLabel = "incident"
+
+// fingerprintPrefix prefixes the fingerprints of the incident issues, so that
+// each namespace and resource has one incident at a time
+const fingerprintPrefix = "incident/"
+
+// pageSize is how many issues are loaded per query when relating the issues of a spike
+const pageSize = 100
+
+// Detector analyzes the issues created to detect their spikes, see Detect
+type Detector struct {
+	issues repository.IssueRepository
+	config config.AnomalyConfig
+	logger *logrus.Logger
+}
+
+// NewDetector creates a new Detector
+//
+// Parameters:
+//   - issues: The issue repository
+//   - cfg: The anomaly configuration, providing the windows and thresholds of the spikes
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - *Detector
+func NewDetector(issues repository.IssueRepository, cfg config.AnomalyConfig, logger *logrus.Logger) *Detector {
+	return &Detector{
+		issues: issues,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// Run detects spikes every interval, until stop is closed. The detection in
+// progress then is completed, unless ctx is cancelled. See workers.Worker.
+//
+// Parameters:
+//   - ctx: Context for cancellation of the detections
+//   - stop: Closed to stop detecting
+func (d *Detector) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Detect(ctx); err != nil {
+			d.logger.WithError(err).Error("Failed to detect spikes of issues")
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// spike is a group of issues created at an abnormal rate
+type spike struct {
+	namespace string
+	// resourceType and resourceName are the resource of the issues, empty for
+	// spikes of a whole namespace
+	resourceType string
+	resourceName string
+	count        int64
+	expected     float64
+}
+
+// Detect counts the issues of each namespace created within the window,
+// comparing them to their rate over the baseline before it. Namespaces with a
+// spike get an incident issue the issues of the spike are related to. Other
+// namespaces are checked for the spikes of their resources the same way.
+// Detecting carries on past the failures of single namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - error: The errors of the namespaces that failed to be analyzed, or nil
+func (d *Detector) Detect(ctx context.Context) error {
+	windowStart := time.Now().Add(-d.config.Window)
+	counts, err := d.issues.CountGroupedBy(ctx, repository.IssueQueryFilters{CreatedSince: &windowStart}, repository.CountByNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to count issues created: %w", err)
+	}
+
+	var errs []error
+	for _, count := range counts {
+		// Counts are ordered from the largest
+		if count.Count < int64(d.config.MinIssues) {
+			break
+		}
+		if err := d.detectNamespace(ctx, count.Key, count.Count); err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", count.Key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// detectNamespace detects the spike of a namespace which had count issues
+// created within the window, else the spikes of its resources
+func (d *Detector) detectNamespace(ctx context.Context, namespace string, count int64) error {
+	s, err := d.detectSpike(ctx, spike{namespace: namespace, count: count})
+	if err != nil || s != nil {
+		return err
+	}
+
+	windowStart := time.Now().Add(-d.config.Window)
+	counts, err := d.issues.CountGroupedBy(ctx, repository.IssueQueryFilters{
+		Namespace:    namespace,
+		CreatedSince: &windowStart,
+	}, repository.CountByResource)
+	if err != nil {
+		return fmt.Errorf("failed to count issues created by resource: %w", err)
+	}
+	for _, count := range counts {
+		if count.Count < int64(d.config.MinIssues) {
+			break
+		}
+		resourceType, resourceName, _ := strings.Cut(count.Key, "/")
+		if _, err := d.detectSpike(ctx, spike{namespace: namespace, resourceType: resourceType, resourceName: resourceName, count: count.Count}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detectSpike compares the issues of s created within the window to their
+// baseline rate, opening the incident of s when they spike. It returns the
+// spike detected, nil when there's none.
+func (d *Detector) detectSpike(ctx context.Context, s spike) (*spike, error) {
+	windowStart := time.Now().Add(-d.config.Window)
+	baselineStart := windowStart.Add(-d.config.Baseline)
+	filters := s.filters()
+	filters.CreatedSince = &baselineStart
+	filters.CreatedBefore = &windowStart
+	baseline, err := d.issues.CountByFilters(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// The baseline rate scaled to the window, spikes of groups without any
+	// issues over the baseline only having to reach the minimum
+	s.expected = float64(baseline) * float64(d.config.Window) / float64(d.config.Baseline)
+	if float64(s.count) < float64(d.config.SpikeFactor)*s.expected {
+		return nil, nil
+	}
+	return &s, d.openIncident(ctx, s)
+}
+
+// openIncident creates the incident issue of a spike, or updates the one the
+// spike already has, and relates the issues of the spike to it
+func (d *Detector) openIncident(ctx context.Context, s spike) error {
+	windowStart := time.Now().Add(-d.config.Window)
+	filters := s.filters()
+	filters.CreatedSince = &windowStart
+	members, err := repository.FindAllPages(ctx, d.issues, filters, pageSize)
+	if err != nil {
+		return fmt.Errorf("failed to find the issues of the spike: %w", err)
+	}
+
+	fingerprint := s.fingerprint()
+	severity := models.SeverityInfo
+	for _, member := range members {
+		if member.Fingerprint != fingerprint && member.Severity.Rank() > severity.Rank() {
+			severity = member.Severity
+		}
+	}
+
+	subject := "namespace " + s.namespace
+	scope := dto.ScopeReqBody{ResourceType: "namespace", ResourceName: s.namespace, ResourceNamespace: s.namespace}
+	if s.resourceType != "" {
+		subject = s.resourceType + " " + s.resourceName
+		scope = dto.ScopeReqBody{ResourceType: s.resourceType, ResourceName: s.resourceName, ResourceNamespace: s.namespace}
+	}
+	incident, err := d.issues.CreateOrUpdate(ctx, dto.CreateIssueRequest{
+		Title: fmt.Sprintf("Spike of issues in %s", subject),
+		Description: fmt.Sprintf("%d issues were created in %s within %s, against %.1f expected from the previous %s. The issues are related to this incident.",
+			s.count, subject, d.config.Window, s.expected, d.config.Baseline),
+		Severity:    severity,
+		IssueType:   models.IssueType(d.config.IssueType),
+		Namespace:   s.namespace,
+		Scope:       scope,
+		Labels:      map[string]string{IncidentLabel: "spike"},
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open incident: %w", err)
+	}
+
+	related := 0
+	for _, member := range members {
+		if member.ID == incident.ID {
+			continue
+		}
+		err := d.issues.AddRelatedIssue(ctx, member.ID, incident.ID, models.RelationTypeCausedBy)
+		if errors.Is(err, repository.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to relate issue %s to incident: %w", member.ID, err)
+		}
+		related++
+	}
+
+	d.logger.WithFields(logrus.Fields{
+		"issue_id":  incident.ID,
+		"namespace": s.namespace,
+		"subject":   subject,
+		"issues":    s.count,
+		"expected":  s.expected,
+		"related":   related,
+	}).Warn("Detected spike of issues")
+	return nil
+}
+
+// filters returns the filters selecting the issues of s
+func (s spike) filters() repository.IssueQueryFilters {
+	return repository.IssueQueryFilters{
+		Namespace:    s.namespace,
+		ResourceType: s.resourceType,
+		ResourceName: s.resourceName,
+	}
+}
+
+// fingerprint returns the fingerprint of the incident issue of s
+func (s spike) fingerprint() string {
+	if s.resourceType == "" {
+		return fingerprintPrefix + "namespace"
+	}
+	return fingerprintPrefix + s.resourceType + "/" + s.resourceName
+}
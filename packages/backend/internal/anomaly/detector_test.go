@@ -0,0 +1,163 @@
+package anomaly
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupDetector sets up a detector of the spikes of at least 10 issues within
+// 15 minutes, 5 times more than over the previous hour
+func setupDetector(t *testing.T) (context.Context, *gorm.DB, repository.IssueRepository, *Detector) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	repo := repository.NewIssueRepository(db, logger, 0)
+	cfg := config.AnomalyConfig{
+		Interval:    time.Minute,
+		Window:      15 * time.Minute,
+		Baseline:    time.Hour,
+		MinIssues:   10,
+		SpikeFactor: 5,
+		IssueType:   string(models.IssueTypePipeline),
+	}
+	return context.Background(), db, repo, NewDetector(repo, cfg, logger)
+}
+
+// createIssues creates count issues of a resource created age ago
+func createIssues(t *testing.T, ctx context.Context, db *gorm.DB, repo repository.IssueRepository, namespace, resourceName string, severity models.Severity, count int, age time.Duration) []string {
+	var ids []string
+	for i := range count {
+		issue, err := repo.Create(ctx, dto.CreateIssueRequest{
+			Title:       "Pipeline run failed: " + resourceName,
+			Description: "The pipeline run failed",
+			Severity:    severity,
+			IssueType:   models.IssueTypePipeline,
+			Namespace:   namespace,
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "pipeline",
+				ResourceName:      resourceName,
+				ResourceNamespace: namespace,
+			},
+			Fingerprint: fmt.Sprintf("%s-%s-%d", resourceName, age, i),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		if err := db.Model(issue).UpdateColumn("created_at", time.Now().Add(-age)).Error; err != nil {
+			t.Fatalf("Failed to age issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+// findIncident returns the incident issue of a namespace with a fingerprint, nil if it has none
+func findIncident(t *testing.T, ctx context.Context, repo repository.IssueRepository, namespace, fingerprint string) *models.Issue {
+	issues, _, err := repo.FindAll(ctx, repository.IssueQueryFilters{Namespace: namespace, Fingerprint: fingerprint})
+	if err != nil {
+		t.Fatalf("Failed to find incidents: %v", err)
+	}
+	if len(issues) > 1 {
+		t.Fatalf("Expected a single incident, got %d", len(issues))
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	return &issues[0]
+}
+
+// countCaused counts the issues related to an incident as caused by it
+func countCaused(t *testing.T, db *gorm.DB, incident *models.Issue) int64 {
+	var count int64
+	err := db.Model(&models.RelatedIssue{}).
+		Where("target_id = ? AND type = ?", incident.ID, models.RelationTypeCausedBy).
+		Count(&count).Error
+	if err != nil {
+		t.Fatalf("Failed to count related issues: %v", err)
+	}
+	return count
+}
+
+func TestDetector_NamespaceSpike(t *testing.T) {
+	ctx, db, repo, detector := setupDetector(t)
+
+	// A spike of a namespace with a few issues over the baseline
+	createIssues(t, ctx, db, repo, "team-a", "frontend-build", models.SeverityMinor, 2, 30*time.Minute)
+	createIssues(t, ctx, db, repo, "team-a", "frontend-build", models.SeverityMajor, 6, time.Minute)
+	createIssues(t, ctx, db, repo, "team-a", "backend-build", models.SeverityMinor, 6, time.Minute)
+	// The usual rate of a busy namespace
+	createIssues(t, ctx, db, repo, "team-b", "frontend-build", models.SeverityMajor, 12, 30*time.Minute)
+	createIssues(t, ctx, db, repo, "team-b", "frontend-build", models.SeverityMajor, 10, time.Minute)
+	// Too few issues to be a spike
+	createIssues(t, ctx, db, repo, "team-c", "frontend-build", models.SeverityMajor, 9, time.Minute)
+
+	if err := detector.Detect(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	incident := findIncident(t, ctx, repo, "team-a", "incident/namespace")
+	if incident == nil {
+		t.Fatal("Expected an incident for the spike of team-a")
+	}
+	if incident.Severity != models.SeverityMajor || incident.IssueType != models.IssueTypePipeline {
+		t.Errorf("Expected a major pipeline incident, got %s %s", incident.Severity, incident.IssueType)
+	}
+	if count := countCaused(t, db, incident); count != 12 {
+		t.Errorf("Expected the 12 issues of the spike to be caused by the incident, got %d", count)
+	}
+	for _, namespace := range []string{"team-b", "team-c"} {
+		if incident := findIncident(t, ctx, repo, namespace, "incident/namespace"); incident != nil {
+			t.Errorf("Expected no incident for %s, got %s", namespace, incident.Title)
+		}
+	}
+
+	// The spike goes on, updating the same incident
+	createIssues(t, ctx, db, repo, "team-a", "release", models.SeverityCritical, 3, 0)
+	if err := detector.Detect(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	incident = findIncident(t, ctx, repo, "team-a", "incident/namespace")
+	if incident.Severity != models.SeverityCritical {
+		t.Errorf("Expected the incident to be escalated, got %s", incident.Severity)
+	}
+	if count := countCaused(t, db, incident); count != 15 {
+		t.Errorf("Expected the 15 issues of the spike to be caused by the incident, got %d", count)
+	}
+}
+
+func TestDetector_ResourceSpike(t *testing.T) {
+	ctx, db, repo, detector := setupDetector(t)
+
+	// The namespace keeps its usual rate, one of its pipelines spikes
+	createIssues(t, ctx, db, repo, "team-a", "frontend-build", models.SeverityMajor, 12, 30*time.Minute)
+	spike := createIssues(t, ctx, db, repo, "team-a", "backend-build", models.SeverityMajor, 10, time.Minute)
+
+	if err := detector.Detect(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if incident := findIncident(t, ctx, repo, "team-a", "incident/namespace"); incident != nil {
+		t.Errorf("Expected no incident for the namespace, got %s", incident.Title)
+	}
+	incident := findIncident(t, ctx, repo, "team-a", "incident/pipeline/backend-build")
+	if incident == nil {
+		t.Fatal("Expected an incident for the spike of backend-build")
+	}
+	if incident.Scope.ResourceType != "pipeline" || incident.Scope.ResourceName != "backend-build" {
+		t.Errorf("Expected the incident to be scoped to the pipeline, got %s/%s", incident.Scope.ResourceType, incident.Scope.ResourceName)
+	}
+	if count := countCaused(t, db, incident); count != int64(len(spike)) {
+		t.Errorf("Expected the %d issues of the spike to be caused by the incident, got %d", len(spike), count)
+	}
+}
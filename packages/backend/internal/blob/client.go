@@ -0,0 +1,237 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+const (
+	// signingAlgorithm is the algorithm of AWS Signature Version 4
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	// amzDateFormat is the format of the signing time
+	amzDateFormat = "20060102T150405Z"
+	// requestExpiry is how long the URLs signed for requests of the client are valid
+	requestExpiry = 5 * time.Minute
+)
+
+// Client stores objects in an S3-compatible object storage, e.g. AWS S3 or
+// MinIO. Requests are authenticated with presigned URLs (AWS Signature
+// Version 4), the URLs returned by the API and the requests of the client
+// being signed alike.
+type Client struct {
+	endpoint   *url.URL
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+	pathStyle  bool
+	urlExpiry  time.Duration
+	httpClient *http.Client
+}
+
+// NewClient creates a new blob storage client
+//
+// Parameters:
+//   - cfg: The blob storage configuration, providing the endpoint, bucket and credentials
+//
+// Returns:
+//   - *Client
+//   - error: The endpoint isn't a valid URL
+func NewClient(cfg config.BlobStorageConfig) (*Client, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid blob storage endpoint: %s", cfg.Endpoint)
+	}
+
+	return &Client{
+		endpoint:   endpoint,
+		region:     cfg.Region,
+		bucket:     cfg.Bucket,
+		accessKey:  cfg.AccessKeyID,
+		secretKey:  cfg.SecretAccessKey,
+		pathStyle:  cfg.PathStyle,
+		urlExpiry:  cfg.URLExpiry,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Put stores an object, replacing the object with the same key.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - key: The key of the object, e.g. webhook-events/team-a/<id>.json
+//   - data: The content of the object
+//   - contentType: The media type of the content
+//
+// Returns:
+//   - error: Storage error or nil
+func (c *Client) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.presign(http.MethodPut, key, time.Now(), requestExpiry), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if _, err := c.do(req); err != nil {
+		return fmt.Errorf("failed to store object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get fetches the content of an object.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - key: The key of the object
+//
+// Returns:
+//   - []byte: The content of the object
+//   - error: Storage error or nil
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.presign(http.MethodGet, key, time.Now(), requestExpiry), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch object %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// PresignGet returns a URL downloading an object without credentials, valid
+// for the URL expiry of the configuration
+func (c *Client) PresignGet(key string) string {
+	return c.presign(http.MethodGet, key, time.Now(), c.urlExpiry)
+}
+
+// Ping checks the bucket exists and the credentials can access it.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - error: Storage error or nil
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.presign(http.MethodHead, "", time.Now(), requestExpiry), nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.do(req); err != nil {
+		return fmt.Errorf("failed to access bucket %s: %w", c.bucket, err)
+	}
+	return nil
+}
+
+// do sends a request to the storage, returning the content of the response
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("blob storage responded with status %d: %s", resp.StatusCode, message)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// presign returns the URL of a request on an object, authenticated by its
+// query with AWS Signature Version 4. Only the host header is signed, and the
+// payload isn't, so that any client can send the request.
+func (c *Client) presign(method, key string, now time.Time, expiry time.Duration) string {
+	host := c.endpoint.Host
+	path := strings.TrimSuffix(c.endpoint.Path, "/") + "/"
+	if c.pathStyle {
+		path += uriEncode(c.bucket, true) + "/"
+	} else {
+		host = c.bucket + "." + host
+	}
+	path += uriEncode(key, false)
+
+	now = now.UTC()
+	date := now.Format("20060102")
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	query := map[string]string{
+		"X-Amz-Algorithm":     signingAlgorithm,
+		"X-Amz-Credential":    c.accessKey + "/" + scope,
+		"X-Amz-Date":          now.Format(amzDateFormat),
+		"X-Amz-Expires":       strconv.Itoa(int(expiry.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		params = append(params, uriEncode(name, true)+"="+uriEncode(query[name], true))
+	}
+	canonicalQuery := strings.Join(params, "&")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		path,
+		canonicalQuery,
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateFormat),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.secretKey), date)
+	signingKey = hmacSHA256(signingKey, c.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return c.endpoint.Scheme + "://" + host + path + "?" + canonicalQuery + "&X-Amz-Signature=" + signature
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncode escapes all the characters of s but the unreserved ones of RFC
+// 3986, and slashes unless encodeSlash is set, as AWS Signature Version 4 does
+func uriEncode(s string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
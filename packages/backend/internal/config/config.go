@@ -2,21 +2,42 @@ package config
 
 import (
 	"fmt"
+	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/joho/godotenv"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logging  LoggingConfig
-	Security SecurityConfig
-	Features FeatureFlags
+	Server      ServerConfig
+	Timeouts    TimeoutsConfig
+	Concurrency ConcurrencyConfig
+	Database    DatabaseConfig
+	Logging     LoggingConfig
+	Sentry      SentryConfig
+	Debug       DebugConfig
+	Security    SecurityConfig
+	// Public holds the namespaces anonymous requests may read
+	Public      PublicAccessConfig
+	Features    FeatureFlags
+	Limits      LimitsConfig
+	Resolution  ResolutionConfig
+	Jira        JiraConfig
+	GitHub      GitHubConfig
+	BlobStorage BlobStorageConfig
+	Health      HealthConfig
+	// Normalization holds the rules normalizing the noisy fields of webhooks
+	Normalization NormalizationConfig
+	Anomaly       AnomalyConfig
+	Links         LinksConfig
 }
 
 // ServerConfig holds all server-related configuration
@@ -30,17 +51,236 @@ type ServerConfig struct {
 	Environment     string
 }
 
+// TimeoutsConfig holds how long handlers may take before their request times
+// out, 0 for no limit. Reads are GET and HEAD requests, writes all others.
+type TimeoutsConfig struct {
+	Read  time.Duration
+	Write time.Duration
+	// Routes overrides the timeout of routes, keyed by method and path
+	// pattern, e.g. "GET /api/v1/issues/:id"
+	Routes map[string]time.Duration
+}
+
+// ConcurrencyConfig holds how many requests are served at once. Requests over
+// MaxInFlight wait in a queue of MaxQueued requests for up to QueueTimeout,
+// MaxInFlight of 0 serving all requests at once.
+type ConcurrencyConfig struct {
+	MaxInFlight  int
+	MaxQueued    int
+	QueueTimeout time.Duration
+}
+
 // LoggingConfig holds all logging configuration
 type LoggingConfig struct {
 	Level  string
 	Format string //json or text
 }
 
+// SentryConfig holds the configuration of the reporting of panics and server
+// errors to Sentry, disabled when DSN is empty
+type SentryConfig struct {
+	DSN string
+}
+
+// DebugConfig holds the configuration of the runtime debug endpoints, pprof
+// and expvar. They're served on their own address, which shouldn't be
+// reachable from outside the cluster, e.g. 127.0.0.1:6060 for port-forwarding.
+type DebugConfig struct {
+	Enabled bool
+	Address string
+}
+
 // SecurityConfig holds all security-related configuration
 type SecurityConfig struct {
-	EnableCORS     bool
+	EnableCORS bool
+	// AllowedOrigins are the origins allowed to call the API from browsers,
+	// e.g. https://kite.example.com, "*" allowing any origin
 	AllowedOrigins []string
-	RateLimitRPS   int
+	// AllowCredentials allows browsers to send cookies and authorization
+	// headers along with cross-origin requests, which requires explicit origins
+	AllowCredentials bool
+	RateLimitRPS     int
+	// UserHeader is the header identifying the user of requests, set by the
+	// authenticating proxy in front of the API, e.g. X-Forwarded-User
+	UserHeader string
+	// AdminUsers are the users allowed to call the admin endpoints, identified
+	// by UserHeader. Nobody can call them when empty.
+	AdminUsers []string
+	// WebhookSecret signs the payloads of webhooks, which are rejected when
+	// their signature is missing or invalid. Signatures aren't checked when empty.
+	WebhookSecret string
+	// StrictTenancy isolates the namespaces: the requests of the namespaced
+	// routes must be made by a user granted their namespace in
+	// NamespacesHeader, only admins querying across namespaces
+	StrictTenancy bool
+	// NamespacesHeader is the header listing the namespaces granted to the
+	// user of requests, comma separated, set by the authenticating proxy
+	NamespacesHeader string
+}
+
+// PublicAccessConfig holds the namespaces served to anonymous requests, e.g.
+// for public status pages. Anonymous requests may only read these namespaces
+// when there's any, and are served as any request otherwise.
+type PublicAccessConfig struct {
+	// Namespaces are the namespaces anonymous requests may read
+	Namespaces []string
+	// RedactedLinks matches the URLs of the links redacted from the responses
+	// to anonymous requests, e.g. of internal consoles. Nil to redact none.
+	RedactedLinks *regexp.Regexp
+}
+
+// ValidateCORS validates the allowed origins are "*" or scheme://host[:port]
+// origins, and don't include "*" when credentials are allowed
+func (s SecurityConfig) ValidateCORS() error {
+	if len(s.AllowedOrigins) == 0 {
+		return fmt.Errorf("allowed origins are required when CORS is enabled")
+	}
+	for _, origin := range s.AllowedOrigins {
+		if origin == "*" {
+			if s.AllowCredentials {
+				return fmt.Errorf("allowed origins cannot include \"*\" when credentials are allowed")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return fmt.Errorf("invalid allowed origin: %q (must be scheme://host[:port])", origin)
+		}
+	}
+	return nil
+}
+
+// LimitsConfig holds the maximum lengths, in characters, of issue fields, and
+// the maximum size of webhook batches
+type LimitsConfig struct {
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	MaxDetailsLength     int
+	// MaxWebhookBatchSize is how many webhooks a batch may hold, 0 for no limit
+	MaxWebhookBatchSize int
+}
+
+// ResolutionConfig holds the rules for resolving issues manually
+type ResolutionConfig struct {
+	// BlockingNamespaces lists the namespaces where an issue can't be resolved
+	// manually while issues caused by it are active. "*" matches all namespaces.
+	BlockingNamespaces []string
+}
+
+// BlocksResolution checks whether the issues of a namespace can't be resolved
+// manually while issues caused by them are active
+func (r ResolutionConfig) BlocksResolution(namespace string) bool {
+	return slices.Contains(r.BlockingNamespaces, namespace) || slices.Contains(r.BlockingNamespaces, "*")
+}
+
+// JiraConfig holds the configuration of the Jira connector, escalating issues
+// to Jira tickets. Only the issues of namespaces with a project are escalated.
+type JiraConfig struct {
+	Enabled   bool
+	URL       string
+	User      string // Basic authentication with Token when set, bearer authentication otherwise
+	Token     string
+	IssueType string
+	// Projects maps namespaces to the key of the Jira project of their tickets
+	Projects map[string]string
+	// Active issues at least as severe as MinSeverity get a ticket once they're older than MinAge
+	MinSeverity  string
+	MinAge       time.Duration
+	SyncInterval time.Duration
+}
+
+// GitHubConfig holds the configuration of the GitHub connector, mirroring
+// issues into the GitHub issues of a repository
+type GitHubConfig struct {
+	Enabled    bool
+	APIURL     string
+	Token      string
+	Repository string // As owner/name
+	// Active issues with all of Labels and at least as severe as MinSeverity are mirrored
+	Labels       map[string]string
+	MinSeverity  string
+	SyncInterval time.Duration
+}
+
+// BlobStorageConfig holds the configuration of the S3-compatible object
+// storage, e.g. AWS S3 or MinIO, holding the payloads too large for database
+// rows. It's disabled when Endpoint is empty.
+type BlobStorageConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle addresses buckets in the path of URLs rather than in their
+	// host, as MinIO expects
+	PathStyle bool
+	// URLExpiry is how long the presigned URLs of objects returned by the API are valid
+	URLExpiry time.Duration
+	// OffloadThreshold is the size in bytes over which payloads are stored as objects
+	OffloadThreshold int
+}
+
+// HealthComponents are the components checked by the health endpoint, the
+// database and the subsystems the API depends on when they're configured
+var HealthComponents = []string{"database", "blob_storage", "jira", "github"}
+
+// HealthConfig holds the configuration of the health checks
+type HealthConfig struct {
+	// CriticalComponents are the HealthComponents the API can't serve
+	// without: the API isn't ready while one of them is unhealthy, the others
+	// only degrading it
+	CriticalComponents []string
+	// CheckTimeout is how long the check of a component may take before it's
+	// considered unhealthy
+	CheckTimeout time.Duration
+	// StartupCheckInterval is how long the server waits before running its
+	// startup checks again while critical ones fail, see startup.Checker
+	StartupCheckInterval time.Duration
+}
+
+// NormalizationRule replaces the matches of Pattern with Replacement, which
+// may refer to the groups of Pattern, see regexp.Regexp.ReplaceAllString
+type NormalizationRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// NormalizationConfig holds the rules normalizing the pipeline names and
+// failure reasons of webhooks before they're deduplicated, stripping what's
+// specific to a run, e.g. the random suffix of frontend-build-x8f2k
+type NormalizationConfig struct {
+	// DefaultRules enables the rules built in the server, see normalize.DefaultRules
+	DefaultRules bool
+	// Rules are applied in order, after the default rules
+	Rules []NormalizationRule
+}
+
+// AnomalyConfig holds the configuration of the anomaly detector, opening an
+// incident issue for the spikes of issues created in a namespace or for a
+// resource, e.g. during cluster-wide outages
+type AnomalyConfig struct {
+	Enabled bool
+	// Interval is how often the issues created are analyzed
+	Interval time.Duration
+	// Window is how far back the issues of a spike are counted, and Baseline
+	// the trailing period before it their usual rate is measured over
+	Window   time.Duration
+	Baseline time.Duration
+	// A spike is at least MinIssues issues, SpikeFactor times more than the
+	// baseline rate
+	MinIssues   int
+	SpikeFactor int
+	// IssueType is the issue type of the incident issues
+	IssueType string
+}
+
+// LinksConfig holds the default link templates of the issues of all the
+// namespaces, see models.LinkTemplate
+type LinksConfig struct {
+	// PipelineLogsTemplate is the URL of the logs of the runs of the pipeline
+	// failures, e.g. https://konflux.dev/logs/pipelineruns/{{runId}}, none when empty
+	PipelineLogsTemplate string
 }
 
 // FeatureFlags holds feature flag configuration
@@ -49,40 +289,126 @@ type FeatureFlags struct {
 	EnableWebhooks          bool
 }
 
-// LoadConfig loads configuration from environment variables
+// defaultEnvironment is the default of KITE_PROJECT_ENV
+const defaultEnvironment = "development"
+
+// Load loads the configuration. Each setting takes the value of its KITE_
+// environment variable, else of the configuration file, else of the
+// .env.<KITE_PROJECT_ENV> file of the working directory, else its default.
+//
+// Parameters:
+//   - configFile: The YAML or TOML configuration file, see LoadFile. Empty for none
+func Load(configFile string) (*Config, error) {
+	if configFile != "" {
+		if err := LoadFile(configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	environment := GetEnvOrDefault("KITE_PROJECT_ENV", defaultEnvironment)
+	if err := LoadEnvFile(".env." + environment); err != nil {
+		// It should be fine if the file doesn't exist
+		log.Printf("No env file loaded, using system environment variables: %v", err)
+	}
+	return LoadConfig()
+}
+
+// LoadEnvFile loads the variables of an env file of the working directory into
+// the environment. Variables that are already set keep their value.
+func LoadEnvFile(filename string) error {
+	envFile, err := GetEnvFileInCwd(filename)
+	if err != nil {
+		return err
+	}
+	if err := godotenv.Load(envFile); err != nil {
+		return fmt.Errorf("failed to load %s: %w", envFile, err)
+	}
+	log.Printf("Loaded env file %s", envFile)
+	return nil
+}
+
+// LoadConfig loads configuration from environment variables, and from files
+// for secrets, see LoadSecretFiles
 func LoadConfig() (*Config, error) {
+	if err := LoadSecretFiles(); err != nil {
+		return nil, err
+	}
+
+	environment := GetEnvOrDefault("KITE_PROJECT_ENV", defaultEnvironment)
+	defaultLogFormat := "text"
+	if environment == "production" {
+		defaultLogFormat = "json"
+	}
+
 	cfg := &Config{
 		Server: ServerConfig{
 			Host:            GetEnvOrDefault("KITE_HOST", "0.0.0.0"),
-			Port:            getEnvOrDefault("KITE_PORT", "8080"),
+			Port:            GetEnvOrDefault("KITE_PORT", "8080"),
 			ReadTimeout:     GetEnvDurationOrDefault("KITE_READ_TIMEOUT", 30*time.Second),
 			WriteTimeout:    GetEnvDurationOrDefault("KITE_WRITE_TIMEOUT", 30*time.Second),
 			IdleTimeout:     GetEnvDurationOrDefault("KITE_IDLE_TIMEOUT", 60*time.Second),
 			ShutdownTimeout: GetEnvDurationOrDefault("KITE_SHUTDOWN_TIMEOUT", 10*time.Second),
-			Environment:     getEnvOrDefault("KITE_PROJECT_ENV", "production"),
-		},
-		Database: DatabaseConfig{
-			Host:     GetEnvOrDefault("KITE_DB_HOST", "localhost"),
-			Port:     GetEnvOrDefault("KITE_DB_PORT", "5432"),
-			User:     GetEnvOrDefault("KITE_DB_USER", "kite"),
-			Password: GetEnvOrDefault("KITE_DB_PASSWORD", "postgres"),
-			Name:     GetEnvOrDefault("KITE_DB_NAME", "issuesdb"),
-			SSLMode:  GetEnvOrDefault("KITE_DB_SSL_MODE", "disable"),
+			Environment:     environment,
 		},
+		Database: GetDatabaseConfig(),
 		Logging: LoggingConfig{
 			Level:  GetEnvOrDefault("KITE_LOG_LEVEL", "info"),
-			Format: GetEnvOrDefault("KITE_LOG_FORMAT", "json"),
+			Format: GetEnvOrDefault("KITE_LOG_FORMAT", defaultLogFormat),
+		},
+		Sentry: SentryConfig{
+			DSN: GetEnvOrDefault("KITE_SENTRY_DSN", ""),
 		},
-		Security: SecurityConfig{
-			EnableCORS:     GetEnvBoolOrDefault("KITE_ENABLE_CORS", true),
-			AllowedOrigins: GetEnvSliceOrDefault("KITE_ALLOWED_ORIGINS", []string{"*"}),
-			RateLimitRPS:   GetEnvIntOrDefault("KITE_RATE_LIMIT_RPS", 100),
+		Debug: DebugConfig{
+			Enabled: GetEnvBoolOrDefault("KITE_DEBUG_ENABLED", false),
+			Address: GetEnvOrDefault("KITE_DEBUG_ADDRESS", "127.0.0.1:6060"),
 		},
+		Security: GetSecurityConfig(),
 		Features: FeatureFlags{
 			EnableNamespaceChecking: GetEnvBoolOrDefault("KITE_FEATURE_NAMESPACE_CHECKING", true),
 			EnableWebhooks:          GetEnvBoolOrDefault("KITE_FEATURE_WEBHOOKS", true),
 		},
+		Concurrency: ConcurrencyConfig{
+			MaxInFlight:  GetEnvIntOrDefault("KITE_MAX_IN_FLIGHT_REQUESTS", 100),
+			MaxQueued:    GetEnvIntOrDefault("KITE_MAX_QUEUED_REQUESTS", 200),
+			QueueTimeout: GetEnvDurationOrDefault("KITE_QUEUE_TIMEOUT", 5*time.Second),
+		},
+		Limits:      GetLimitsConfig(),
+		Resolution:  GetResolutionConfig(),
+		BlobStorage: GetBlobStorageConfig(),
+		Health:      GetHealthConfig(),
+		Anomaly:     GetAnomalyConfig(),
+		Links:       GetLinksConfig(),
+	}
+
+	timeouts, err := GetTimeoutsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	cfg.Timeouts = timeouts
+
+	jira, err := GetJiraConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	cfg.Jira = jira
+
+	gitHub, err := GetGitHubConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
+	cfg.GitHub = gitHub
+
+	normalization, err := GetNormalizationConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	cfg.Normalization = normalization
+
+	public, err := GetPublicAccessConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	cfg.Public = public
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -112,6 +438,22 @@ func (c *Config) Validate() error {
 			c.Server.Environment, strings.Join(validEnvs, ", "))
 	}
 
+	// Validate timeouts configuration
+	if c.Timeouts.Read < 0 || c.Timeouts.Write < 0 {
+		return fmt.Errorf("invalid request timeouts: %s for reads, %s for writes", c.Timeouts.Read, c.Timeouts.Write)
+	}
+	for route, timeout := range c.Timeouts.Routes {
+		if timeout < 0 {
+			return fmt.Errorf("invalid request timeout of %s: %s", route, timeout)
+		}
+	}
+
+	// Validate concurrency configuration
+	if c.Concurrency.MaxInFlight < 0 || c.Concurrency.MaxQueued < 0 || c.Concurrency.QueueTimeout < 0 {
+		return fmt.Errorf("invalid concurrency limits: %d requests in flight, %d queued for %s",
+			c.Concurrency.MaxInFlight, c.Concurrency.MaxQueued, c.Concurrency.QueueTimeout)
+	}
+
 	// Validate database configuration
 	if c.Database.Host == "" {
 		return fmt.Errorf("database host is required")
@@ -122,6 +464,119 @@ func (c *Config) Validate() error {
 	if c.Database.Name == "" {
 		return fmt.Errorf("database name is requried")
 	}
+	if c.Database.QueryTimeout < 0 {
+		return fmt.Errorf("invalid database query timeout: %s", c.Database.QueryTimeout)
+	}
+	if c.Database.MaxRetries < 1 {
+		return fmt.Errorf("invalid database max retries: %d (must be at least 1)", c.Database.MaxRetries)
+	}
+
+	// Validate debug configuration
+	if c.Debug.Enabled {
+		if c.Debug.Address == "" {
+			return fmt.Errorf("debug address is required when the debug endpoints are enabled")
+		}
+		if c.Debug.Address == c.GetServerAddress() {
+			return fmt.Errorf("debug address must differ from the server address: %s", c.Debug.Address)
+		}
+	}
+
+	// Validate security configuration
+	if c.Security.EnableCORS {
+		if err := c.Security.ValidateCORS(); err != nil {
+			return err
+		}
+	}
+	if c.Security.StrictTenancy && (c.Security.UserHeader == "" || c.Security.NamespacesHeader == "") {
+		return fmt.Errorf("user and namespaces headers are required in strict tenancy")
+	}
+
+	// Validate limits configuration
+	if c.Limits.MaxTitleLength < 1 || c.Limits.MaxDescriptionLength < 1 || c.Limits.MaxDetailsLength < 1 {
+		return fmt.Errorf("maximum title, description and details lengths must be positive")
+	}
+
+	// Validate Jira configuration
+	if c.Jira.Enabled {
+		if c.Jira.URL == "" || c.Jira.Token == "" {
+			return fmt.Errorf("jira URL and token are required when the jira connector is enabled")
+		}
+		if len(c.Jira.Projects) == 0 {
+			return fmt.Errorf("jira projects are required when the jira connector is enabled")
+		}
+		validSeverities := []string{"info", "minor", "major", "critical"}
+		if !slices.Contains(validSeverities, c.Jira.MinSeverity) {
+			return fmt.Errorf("invalid jira minimum severity: %s (must be one of: %s)",
+				c.Jira.MinSeverity, strings.Join(validSeverities, ", "))
+		}
+		if c.Jira.SyncInterval <= 0 {
+			return fmt.Errorf("invalid jira sync interval: %s", c.Jira.SyncInterval)
+		}
+	}
+
+	// Validate GitHub configuration
+	if c.GitHub.Enabled {
+		if c.GitHub.Token == "" {
+			return fmt.Errorf("github token is required when the github connector is enabled")
+		}
+		if owner, name, ok := strings.Cut(c.GitHub.Repository, "/"); !ok || owner == "" || name == "" {
+			return fmt.Errorf("invalid github repository: %q (must be owner/name)", c.GitHub.Repository)
+		}
+		validSeverities := []string{"info", "minor", "major", "critical"}
+		if !slices.Contains(validSeverities, c.GitHub.MinSeverity) {
+			return fmt.Errorf("invalid github minimum severity: %s (must be one of: %s)",
+				c.GitHub.MinSeverity, strings.Join(validSeverities, ", "))
+		}
+		if c.GitHub.SyncInterval <= 0 {
+			return fmt.Errorf("invalid github sync interval: %s", c.GitHub.SyncInterval)
+		}
+	}
+
+	// Validate anomaly detection configuration
+	if c.Anomaly.Enabled {
+		if c.Anomaly.Interval <= 0 || c.Anomaly.Window <= 0 || c.Anomaly.Baseline <= 0 {
+			return fmt.Errorf("anomaly interval, window and baseline must be positive")
+		}
+		if c.Anomaly.MinIssues < 1 || c.Anomaly.SpikeFactor < 1 {
+			return fmt.Errorf("anomaly minimum issues and spike factor must be positive")
+		}
+		validIssueTypes := []string{"build", "test", "release", "dependency", "pipeline"}
+		if !slices.Contains(validIssueTypes, c.Anomaly.IssueType) {
+			return fmt.Errorf("invalid anomaly issue type: %s (must be one of: %s)",
+				c.Anomaly.IssueType, strings.Join(validIssueTypes, ", "))
+		}
+	}
+
+	// Validate blob storage configuration
+	if c.BlobStorage.Endpoint != "" {
+		if _, err := url.ParseRequestURI(c.BlobStorage.Endpoint); err != nil {
+			return fmt.Errorf("invalid blob storage endpoint: %s", c.BlobStorage.Endpoint)
+		}
+		if c.BlobStorage.Bucket == "" || c.BlobStorage.AccessKeyID == "" || c.BlobStorage.SecretAccessKey == "" {
+			return fmt.Errorf("blob storage bucket and credentials are required when the blob storage is enabled")
+		}
+		// Presigned URLs are valid for up to a week
+		if c.BlobStorage.URLExpiry < time.Second || c.BlobStorage.URLExpiry > 7*24*time.Hour {
+			return fmt.Errorf("invalid blob storage URL expiry: %s (must be between 1s and 168h)", c.BlobStorage.URLExpiry)
+		}
+		if c.BlobStorage.OffloadThreshold < 0 {
+			return fmt.Errorf("invalid blob storage offload threshold: %d", c.BlobStorage.OffloadThreshold)
+		}
+	}
+
+	// Validate health configuration
+	for _, component := range c.Health.CriticalComponents {
+		if !slices.Contains(HealthComponents, component) {
+			return fmt.Errorf("invalid critical health component: %s (must be one of: %s)",
+				component, strings.Join(HealthComponents, ", "))
+		}
+	}
+	if c.Health.CheckTimeout <= 0 {
+		return fmt.Errorf("invalid health check timeout: %s", c.Health.CheckTimeout)
+	}
+	if c.Health.StartupCheckInterval <= 0 {
+		return fmt.Errorf("invalid startup check interval: %s", c.Health.StartupCheckInterval)
+	}
 
 	// Validate logging configuration
 	validLogLevels := []string{"debug", "info", "warn", "error", "fatal", "panic"}
@@ -132,13 +587,231 @@ func (c *Config) Validate() error {
 
 	validLogFormats := []string{"json", "text"}
 	if !slices.Contains(validLogFormats, c.Logging.Format) {
-		return fmt.Errorf("invalid log level: %s (must be one of: %s)",
+		return fmt.Errorf("invalid log format: %s (must be one of: %s)",
 			c.Logging.Format, strings.Join(validLogFormats, ", "))
 	}
 
 	return nil
 }
 
+// GetSecurityConfig returns the security configuration using ENV variables, with defaults.
+// Allowed origins are set as a comma separated list in KITE_ALLOWED_ORIGINS,
+// and admin users in KITE_ADMIN_USERS.
+func GetSecurityConfig() SecurityConfig {
+	var origins []string
+	for _, origin := range GetEnvSliceOrDefault("KITE_ALLOWED_ORIGINS", []string{"*"}) {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	var admins []string
+	for _, admin := range GetEnvSliceOrDefault("KITE_ADMIN_USERS", nil) {
+		if admin = strings.TrimSpace(admin); admin != "" {
+			admins = append(admins, admin)
+		}
+	}
+
+	return SecurityConfig{
+		EnableCORS:       GetEnvBoolOrDefault("KITE_ENABLE_CORS", true),
+		AllowedOrigins:   origins,
+		AllowCredentials: GetEnvBoolOrDefault("KITE_CORS_ALLOW_CREDENTIALS", false),
+		RateLimitRPS:     GetEnvIntOrDefault("KITE_RATE_LIMIT_RPS", 100),
+		UserHeader:       GetEnvOrDefault("KITE_USER_HEADER", "X-Forwarded-User"),
+		AdminUsers:       admins,
+		WebhookSecret:    GetEnvOrDefault("KITE_WEBHOOK_SECRET", ""),
+		StrictTenancy:    GetEnvBoolOrDefault("KITE_STRICT_TENANCY", false),
+		NamespacesHeader: GetEnvOrDefault("KITE_NAMESPACES_HEADER", "X-Forwarded-Namespaces"),
+	}
+}
+
+// GetPublicAccessConfig returns the namespaces served to anonymous requests using
+// ENV variables: KITE_PUBLIC_NAMESPACES, a comma separated list, and
+// KITE_PUBLIC_REDACTED_LINKS, a regular expression of the URLs of the links to redact
+func GetPublicAccessConfig() (PublicAccessConfig, error) {
+	var namespaces []string
+	for _, namespace := range GetEnvSliceOrDefault("KITE_PUBLIC_NAMESPACES", nil) {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	var redactedLinks *regexp.Regexp
+	if pattern := strings.TrimSpace(os.Getenv("KITE_PUBLIC_REDACTED_LINKS")); pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return PublicAccessConfig{}, fmt.Errorf("invalid KITE_PUBLIC_REDACTED_LINKS pattern %q: %w", pattern, err)
+		}
+		redactedLinks = compiled
+	}
+
+	return PublicAccessConfig{
+		Namespaces:    namespaces,
+		RedactedLinks: redactedLinks,
+	}, nil
+}
+
+// GetLimitsConfig returns the maximum lengths of issue fields using ENV variables, with defaults
+func GetLimitsConfig() LimitsConfig {
+	return LimitsConfig{
+		MaxTitleLength:       GetEnvIntOrDefault("KITE_MAX_TITLE_LENGTH", 255),
+		MaxDescriptionLength: GetEnvIntOrDefault("KITE_MAX_DESCRIPTION_LENGTH", 4096),
+		MaxDetailsLength:     GetEnvIntOrDefault("KITE_MAX_DETAILS_LENGTH", 65536),
+		MaxWebhookBatchSize:  GetEnvIntOrDefault("KITE_MAX_WEBHOOK_BATCH_SIZE", 100),
+	}
+}
+
+// GetResolutionConfig returns the rules for resolving issues using ENV variables.
+// Blocking namespaces are set as a comma separated list in KITE_RESOLUTION_BLOCKING_NAMESPACES.
+func GetResolutionConfig() ResolutionConfig {
+	var namespaces []string
+	for _, namespace := range GetEnvSliceOrDefault("KITE_RESOLUTION_BLOCKING_NAMESPACES", nil) {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return ResolutionConfig{BlockingNamespaces: namespaces}
+}
+
+// GetJiraConfig returns the configuration of the Jira connector using ENV variables, with defaults.
+// Projects are set as comma separated namespace=PROJECT pairs in KITE_JIRA_PROJECTS.
+func GetJiraConfig() (JiraConfig, error) {
+	projects, err := GetEnvPairs("KITE_JIRA_PROJECTS")
+	if err != nil {
+		return JiraConfig{}, err
+	}
+
+	return JiraConfig{
+		Enabled:      GetEnvBoolOrDefault("KITE_JIRA_ENABLED", false),
+		URL:          strings.TrimSuffix(GetEnvOrDefault("KITE_JIRA_URL", ""), "/"),
+		User:         GetEnvOrDefault("KITE_JIRA_USER", ""),
+		Token:        GetEnvOrDefault("KITE_JIRA_TOKEN", ""),
+		IssueType:    GetEnvOrDefault("KITE_JIRA_ISSUE_TYPE", "Bug"),
+		Projects:     projects,
+		MinSeverity:  GetEnvOrDefault("KITE_JIRA_MIN_SEVERITY", "critical"),
+		MinAge:       GetEnvDurationOrDefault("KITE_JIRA_MIN_AGE", time.Hour),
+		SyncInterval: GetEnvDurationOrDefault("KITE_JIRA_SYNC_INTERVAL", 5*time.Minute),
+	}, nil
+}
+
+// GetAnomalyConfig returns the configuration of the anomaly detector using ENV variables, with defaults.
+func GetAnomalyConfig() AnomalyConfig {
+	return AnomalyConfig{
+		Enabled:     GetEnvBoolOrDefault("KITE_ANOMALY_ENABLED", false),
+		Interval:    GetEnvDurationOrDefault("KITE_ANOMALY_INTERVAL", 5*time.Minute),
+		Window:      GetEnvDurationOrDefault("KITE_ANOMALY_WINDOW", 15*time.Minute),
+		Baseline:    GetEnvDurationOrDefault("KITE_ANOMALY_BASELINE", 24*time.Hour),
+		MinIssues:   GetEnvIntOrDefault("KITE_ANOMALY_MIN_ISSUES", 10),
+		SpikeFactor: GetEnvIntOrDefault("KITE_ANOMALY_SPIKE_FACTOR", 5),
+		IssueType:   GetEnvOrDefault("KITE_ANOMALY_ISSUE_TYPE", "pipeline"),
+	}
+}
+
+// GetLinksConfig returns the default link templates using ENV variables, with defaults.
+// The template of the pipeline logs defaults to the logs endpoint of the cluster,
+// KITE_CLUSTER_URL followed by KITE_LOGS_ENDPOINT and the run ID.
+func GetLinksConfig() LinksConfig {
+	clusterLogs := GetEnvOrDefault("KITE_CLUSTER_URL", "https://konflux.dev") + GetEnvOrDefault("KITE_LOGS_ENDPOINT", "/logs/pipelineruns/") + "{{runId}}"
+	return LinksConfig{
+		PipelineLogsTemplate: GetEnvOrDefault("KITE_PIPELINE_LOGS_URL_TEMPLATE", clusterLogs),
+	}
+}
+
+// GetTimeoutsConfig returns the request timeouts using ENV variables, with defaults.
+// The timeouts of routes are set as comma separated "METHOD path=duration" pairs
+// in KITE_ROUTE_TIMEOUTS, e.g. "POST /api/v1/webhooks/pipeline-failure=1m".
+func GetTimeoutsConfig() (TimeoutsConfig, error) {
+	pairs, err := GetEnvPairs("KITE_ROUTE_TIMEOUTS")
+	if err != nil {
+		return TimeoutsConfig{}, err
+	}
+	routes := make(map[string]time.Duration, len(pairs))
+	for route, value := range pairs {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return TimeoutsConfig{}, fmt.Errorf("invalid KITE_ROUTE_TIMEOUTS timeout of %s: %w", route, err)
+		}
+		routes[route] = timeout
+	}
+
+	return TimeoutsConfig{
+		Read:   GetEnvDurationOrDefault("KITE_READ_REQUEST_TIMEOUT", 10*time.Second),
+		Write:  GetEnvDurationOrDefault("KITE_WRITE_REQUEST_TIMEOUT", 20*time.Second),
+		Routes: routes,
+	}, nil
+}
+
+// GetGitHubConfig returns the configuration of the GitHub connector using ENV variables, with defaults.
+// The labels of mirrored issues are set as comma separated key=value pairs in KITE_GITHUB_LABELS.
+func GetGitHubConfig() (GitHubConfig, error) {
+	labels, err := GetEnvPairs("KITE_GITHUB_LABELS")
+	if err != nil {
+		return GitHubConfig{}, err
+	}
+
+	return GitHubConfig{
+		Enabled:      GetEnvBoolOrDefault("KITE_GITHUB_ENABLED", false),
+		APIURL:       strings.TrimSuffix(GetEnvOrDefault("KITE_GITHUB_API_URL", "https://api.github.com"), "/"),
+		Token:        GetEnvOrDefault("KITE_GITHUB_TOKEN", ""),
+		Repository:   GetEnvOrDefault("KITE_GITHUB_REPOSITORY", ""),
+		Labels:       labels,
+		MinSeverity:  GetEnvOrDefault("KITE_GITHUB_MIN_SEVERITY", "major"),
+		SyncInterval: GetEnvDurationOrDefault("KITE_GITHUB_SYNC_INTERVAL", 5*time.Minute),
+	}, nil
+}
+
+// GetBlobStorageConfig returns the configuration of the blob storage using ENV variables, with defaults.
+func GetBlobStorageConfig() BlobStorageConfig {
+	return BlobStorageConfig{
+		Endpoint:         strings.TrimSuffix(GetEnvOrDefault("KITE_BLOB_ENDPOINT", ""), "/"),
+		Region:           GetEnvOrDefault("KITE_BLOB_REGION", "us-east-1"),
+		Bucket:           GetEnvOrDefault("KITE_BLOB_BUCKET", ""),
+		AccessKeyID:      GetEnvOrDefault("KITE_BLOB_ACCESS_KEY_ID", ""),
+		SecretAccessKey:  GetEnvOrDefault("KITE_BLOB_SECRET_ACCESS_KEY", ""),
+		PathStyle:        GetEnvBoolOrDefault("KITE_BLOB_PATH_STYLE", true),
+		URLExpiry:        GetEnvDurationOrDefault("KITE_BLOB_URL_EXPIRY", 15*time.Minute),
+		OffloadThreshold: GetEnvIntOrDefault("KITE_BLOB_OFFLOAD_THRESHOLD", 64*1024),
+	}
+}
+
+// GetHealthConfig returns the configuration of the health checks using ENV variables, with defaults.
+// Critical components are set as a comma separated list in KITE_HEALTH_CRITICAL_COMPONENTS.
+func GetHealthConfig() HealthConfig {
+	var components []string
+	for _, component := range GetEnvSliceOrDefault("KITE_HEALTH_CRITICAL_COMPONENTS", []string{"database"}) {
+		if component = strings.TrimSpace(component); component != "" {
+			components = append(components, component)
+		}
+	}
+	return HealthConfig{
+		CriticalComponents:   components,
+		CheckTimeout:         GetEnvDurationOrDefault("KITE_HEALTH_CHECK_TIMEOUT", 5*time.Second),
+		StartupCheckInterval: GetEnvDurationOrDefault("KITE_STARTUP_CHECK_INTERVAL", 10*time.Second),
+	}
+}
+
+// GetNormalizationConfig returns the normalization rules using ENV variables, with defaults.
+// Rules are set one per line in KITE_NORMALIZATION_RULES, as "pattern => replacement",
+// or as a pattern alone to remove its matches. Empty lines and lines starting with # are ignored.
+func GetNormalizationConfig() (NormalizationConfig, error) {
+	var rules []NormalizationRule
+	for _, line := range strings.Split(os.Getenv("KITE_NORMALIZATION_RULES"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern, replacement, _ := strings.Cut(line, " => ")
+		compiled, err := regexp.Compile(strings.TrimSpace(pattern))
+		if err != nil {
+			return NormalizationConfig{}, fmt.Errorf("invalid KITE_NORMALIZATION_RULES rule %q: %w", line, err)
+		}
+		rules = append(rules, NormalizationRule{Pattern: compiled, Replacement: strings.TrimSpace(replacement)})
+	}
+
+	return NormalizationConfig{
+		DefaultRules: GetEnvBoolOrDefault("KITE_NORMALIZATION_DEFAULT_RULES", true),
+		Rules:        rules,
+	}, nil
+}
+
 // Helper functions
 
 // IsDevelopment returns true if running in development mode
@@ -198,7 +871,7 @@ func GetEnvBoolOrDefault(key string, defaultValue bool) bool {
 // Defaults to the value passed.
 func GetEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
-		if timeValue, err := time.ParseDuration(value); err != nil {
+		if timeValue, err := time.ParseDuration(value); err == nil {
 			return timeValue
 		}
 	}
@@ -217,6 +890,23 @@ func GetEnvSliceOrDefault(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// Helper function to get an environment variable
+//
+// # If the value is found, it's parsed as comma separated key=value pairs
+//
+// Returns an empty map if the variable isn't set.
+func GetEnvPairs(key string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, entry := range GetEnvSliceOrDefault(key, nil) {
+		k, v, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("invalid %s entry %q, expected key=value", key, entry)
+		}
+		pairs[k] = v
+	}
+	return pairs, nil
+}
+
 // GetEnvFileInCwd returns the full path to the given filename in project root directory
 func GetEnvFileInCwd(filename string) (string, error) {
 	cwd, err := os.Getwd()
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -12,6 +11,14 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// defaultQueryTimeout is the default of KITE_DB_QUERY_TIMEOUT. It stays below
+// the default write timeout of the server, so clients get an error response
+// rather than a closed connection.
+const defaultQueryTimeout = 10 * time.Second
+
+// defaultSlowQueryThreshold is the default of KITE_DB_SLOW_QUERY_THRESHOLD
+const defaultSlowQueryThreshold = time.Second
+
 // Database configuration
 type DatabaseConfig struct {
 	Host     string
@@ -20,39 +27,71 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// QueryTimeout bounds how long a repository operation may take, 0 for no limit
+	QueryTimeout time.Duration
+	// SlowQueryThreshold is how long a repository operation may take before it's logged as slow, 0 to log none
+	SlowQueryThreshold time.Duration
+
+	// Connection settings, see InitDatabase
+	MaxRetries      int
+	RetryDelay      time.Duration
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime time.Duration
+	// LogQueries logs every query rather than only the failing ones, by default in development
+	LogQueries bool
+	// PrepareStatements caches the prepared statements of queries on each
+	// connection. Disable it behind poolers not supporting them, e.g.
+	// PgBouncer in transaction mode.
+	PrepareStatements bool
 }
 
 // Returns the database configuration using ENV variables. Uses defaults if ENV variables are not found.
-func GetDatabaseConfig() *DatabaseConfig {
-	return &DatabaseConfig{
-		Host:     getEnvOrDefault("KITE_DB_HOST", "localhost"),
-		Port:     getEnvOrDefault("KITE_DB_PORT", "5432"),
-		User:     getEnvOrDefault("KITE_DB_USER", "postgres"),
-		Password: getEnvOrDefault("KITE_DB_PASSWORD", "postgres"),
-		Name:     getEnvOrDefault("KITE_DB_NAME", "issuesdb"),
-		SSLMode:  getEnvOrDefault("KITE_DB_SSL_MODE", "disable"),
+func GetDatabaseConfig() DatabaseConfig {
+	return DatabaseConfig{
+		Host:     GetEnvOrDefault("KITE_DB_HOST", "localhost"),
+		Port:     GetEnvOrDefault("KITE_DB_PORT", "5432"),
+		User:     GetEnvOrDefault("KITE_DB_USER", "postgres"),
+		Password: GetEnvOrDefault("KITE_DB_PASSWORD", "postgres"),
+		Name:     GetEnvOrDefault("KITE_DB_NAME", "issuesdb"),
+		SSLMode:  GetEnvOrDefault("KITE_DB_SSL_MODE", "disable"),
+
+		QueryTimeout:       GetEnvDurationOrDefault("KITE_DB_QUERY_TIMEOUT", defaultQueryTimeout),
+		SlowQueryThreshold: GetEnvDurationOrDefault("KITE_DB_SLOW_QUERY_THRESHOLD", defaultSlowQueryThreshold),
+
+		MaxRetries:      GetEnvIntOrDefault("KITE_DB_MAX_RETRIES", 10),
+		RetryDelay:      GetEnvDurationOrDefault("KITE_DB_RETRY_DELAY", 5*time.Second),
+		MaxIdleConns:    GetEnvIntOrDefault("KITE_DB_MAX_IDLE_CONNS", 10),
+		MaxOpenConns:    GetEnvIntOrDefault("KITE_DB_MAX_OPEN_CONNS", 100),
+		ConnMaxLifetime: GetEnvDurationOrDefault("KITE_DB_CONN_MAX_LIFETIME", 1*time.Hour),
+		LogQueries:      GetEnvBoolOrDefault("KITE_DB_LOG_QUERIES", GetEnvOrDefault("KITE_PROJECT_ENV", defaultEnvironment) == "development"),
+
+		PrepareStatements: GetEnvBoolOrDefault("KITE_DB_PREPARE_STATEMENTS", true),
 	}
 }
 
 // Initializes the database.
-func InitDatabase() (*gorm.DB, error) {
-	config := GetDatabaseConfig()
-
+//
+// Parameters:
+//   - config: The database configuration, see LoadConfig or GetDatabaseConfig
+func InitDatabase(config DatabaseConfig) (*gorm.DB, error) {
 	connectionString := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
 		config.Host, config.User, config.Password, config.Name, config.Port, config.SSLMode)
 
 	var gormLogger logger.Interface
-	if os.Getenv("KITE_PROJECT_ENV") == "development" {
+	if config.LogQueries {
 		gormLogger = logger.Default.LogMode(logger.Info)
 	} else {
 		gormLogger = logger.Default.LogMode(logger.Error)
 	}
 
-	// DB connection timeout settings
-	maxRetries := GetEnvIntOrDefault("KITE_DB_MAX_RETRIES", 10)
-	delay := GetEnvDurationOrDefault("KITE_DB_RETRY_DELAY", 5*time.Second)
-
-	db, err := connectWithRetries(connectionString, gormLogger, maxRetries, delay)
+	gormConfig := func() *gorm.Config {
+		return &gorm.Config{
+			Logger:      gormLogger,
+			PrepareStmt: config.PrepareStatements,
+		}
+	}
+	db, err := connectWithRetries(connectionString, gormConfig, config.MaxRetries, config.RetryDelay)
 	if err != nil {
 		return nil, err
 	}
@@ -64,11 +103,11 @@ func InitDatabase() (*gorm.DB, error) {
 
 	// Set connection pool settings
 	// Keep x idle connections open
-	sqlDB.SetMaxIdleConns(GetEnvIntOrDefault("KITE_DB_MAX_IDLE_CONNS", 10))
+	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
 	// Max number of DB connections allowed to be open at the same time
-	sqlDB.SetMaxOpenConns(GetEnvIntOrDefault("KITE_DB_MAX_OPEN_CONNS", 100))
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
 	// Refresh the connection periodically
-	sqlDB.SetConnMaxLifetime(GetEnvDurationOrDefault("KITE_DB_CONN_MAX_LIFETIME", 1*time.Hour))
+	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 
 	log.Println("Database connection established successfully")
 	return db, nil
@@ -78,13 +117,13 @@ func InitDatabase() (*gorm.DB, error) {
 //
 // The delay strategy uses a linear backoff (delay × attempt number).
 // This helps reduce pressure on the DB and gives it time to recover on each retry.
-func connectWithRetries(connectionString string, gormLogger logger.Interface, maxRetries int, delay time.Duration) (*gorm.DB, error) {
+//
+// gormConfig returns the configuration of each attempt, GORM keeping state in it.
+func connectWithRetries(connectionString string, gormConfig func() *gorm.Config, maxRetries int, delay time.Duration) (*gorm.DB, error) {
 	var err error
 
 	for i := 0; i < maxRetries; i++ {
-		db, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{
-			Logger: gormLogger,
-		})
+		db, err := gorm.Open(postgres.Open(connectionString), gormConfig())
 		if err == nil {
 			sqlDB, err := db.DB()
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -104,14 +143,6 @@ func connectWithRetries(connectionString string, gormLogger logger.Interface, ma
 	return nil, fmt.Errorf("could not connect to database after %d attempts: %w", maxRetries, err)
 }
 
-// Gets an ENV variable, returns a defaultValue if not found.
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
 // Structured database health details
 type DatabaseHealthDetails struct {
 	ConnectionStatus string  `json:"connection_status"`
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile loads the settings of a YAML or TOML configuration file into the
+// environment. Settings whose variable is already set keep their value, so
+// that environment variables override the file.
+//
+// Each key of the file is the name of a KITE_ variable without its prefix,
+// nested keys being joined with underscores, e.g.
+//
+//	db:
+//	  host: postgres
+//	  max_retries: 5
+//	allowed_origins: [https://konflux.example.com]
+//
+// sets KITE_DB_HOST, KITE_DB_MAX_RETRIES and KITE_ALLOWED_ORIGINS. Lists are
+// joined with commas.
+//
+// Parameters:
+//   - path: The configuration file, its extension being .yaml, .yml or .toml
+//
+// Returns:
+//   - error: The file can't be read or parsed
+func LoadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	settings := map[string]any{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &settings)
+	case ".toml":
+		err = toml.Unmarshal(content, &settings)
+	default:
+		return fmt.Errorf("unsupported config file extension %q, expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	variables := map[string]string{}
+	if err := flattenSettings("KITE", settings, variables); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	// Sorted to set and report the variables in a stable order
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, set := os.LookupEnv(name); set {
+			continue
+		}
+		if err := os.Setenv(name, variables[name]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", name, err)
+		}
+	}
+	log.Printf("Loaded config file %s", path)
+	return nil
+}
+
+// flattenSettings turns the settings of a configuration file into the
+// environment variables they set, named after prefix and their keys
+func flattenSettings(prefix string, settings map[string]any, variables map[string]string) error {
+	for key, value := range settings {
+		name := prefix + "_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flattenSettings(name, value, variables); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, len(value))
+			for n, item := range value {
+				if _, ok := item.(map[string]any); ok {
+					return fmt.Errorf("%s: lists of objects aren't supported", name)
+				}
+				items[n] = fmt.Sprint(item)
+			}
+			variables[name] = strings.Join(items, ",")
+		case nil:
+			// Left unset, e.g. a key without value
+		default:
+			variables[name] = fmt.Sprint(value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes a configuration file into a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+// unsetEnv unsets environment variables until the test is done
+func unsetEnv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		// Restores the variable once the test is done
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+}
+
+func TestLoadFile_YAML(t *testing.T) {
+	unsetEnv(t, "KITE_DB_HOST", "KITE_DB_MAX_RETRIES", "KITE_ALLOWED_ORIGINS", "KITE_READ_TIMEOUT")
+	// Set in the environment, overriding the file
+	t.Setenv("KITE_DB_NAME", "from-env")
+
+	path := writeConfigFile(t, "config.yaml", `
+db:
+  host: postgres
+  name: from-file
+  max_retries: 3
+allowed_origins:
+  - https://a.example.com
+  - https://b.example.com
+read_timeout: 15s
+`)
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	db := GetDatabaseConfig()
+	if db.Host != "postgres" || db.MaxRetries != 3 {
+		t.Errorf("Expected the settings of the file, got host %q and max retries %d", db.Host, db.MaxRetries)
+	}
+	if db.Name != "from-env" {
+		t.Errorf("Expected the environment to override the file, got %q", db.Name)
+	}
+	if origins := GetSecurityConfig().AllowedOrigins; len(origins) != 2 || origins[1] != "https://b.example.com" {
+		t.Errorf("Expected the origins of the file, got %v", origins)
+	}
+	if timeout := GetEnvDurationOrDefault("KITE_READ_TIMEOUT", 0); timeout != 15*time.Second {
+		t.Errorf("Expected a read timeout of 15s, got %s", timeout)
+	}
+}
+
+func TestLoadFile_TOML(t *testing.T) {
+	unsetEnv(t, "KITE_JIRA_ENABLED", "KITE_JIRA_URL")
+
+	path := writeConfigFile(t, "config.toml", `
+[jira]
+enabled = true
+url = "https://issues.example.com"
+`)
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if !GetEnvBoolOrDefault("KITE_JIRA_ENABLED", false) || os.Getenv("KITE_JIRA_URL") != "https://issues.example.com" {
+		t.Errorf("Expected the settings of the file, got enabled %q and url %q",
+			os.Getenv("KITE_JIRA_ENABLED"), os.Getenv("KITE_JIRA_URL"))
+	}
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "unsupported extension", file: "config.json", content: `{"port": 8080}`},
+		{name: "invalid YAML", file: "config.yaml", content: "db: [host"},
+		{name: "list of objects", file: "config.yaml", content: "origins:\n  - url: https://a.example.com\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadFile(writeConfigFile(t, tt.file, tt.content)); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+
+	if err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing file, got nil")
+	}
+}
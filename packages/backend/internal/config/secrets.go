@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// secretVariables are the variables which may be read from a file, named after
+// the variable with a _FILE suffix, e.g. KITE_DB_PASSWORD_FILE for
+// KITE_DB_PASSWORD
+var secretVariables = []string{
+	"KITE_DB_PASSWORD",
+	"KITE_JIRA_TOKEN",
+	"KITE_GITHUB_TOKEN",
+	"KITE_SENTRY_DSN",
+	"KITE_WEBHOOK_SECRET",
+	"KITE_BLOB_SECRET_ACCESS_KEY",
+}
+
+// LoadSecretFiles loads the secrets set as files into the environment, so that
+// they can be mounted from Kubernetes Secrets rather than set in the pod spec.
+// The trailing newline of the files is removed.
+//
+// Returns:
+//   - error: A secret is set both as a variable and as a file, or its file can't be read
+func LoadSecretFiles() error {
+	for _, key := range secretVariables {
+		path := os.Getenv(key + "_FILE")
+		if path == "" {
+			continue
+		}
+		if os.Getenv(key) != "" {
+			return fmt.Errorf("both %s and %s_FILE are set, set only one of them", key, key)
+		}
+
+		secret, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s_FILE: %w", key, err)
+		}
+		if err := os.Setenv(key, strings.TrimRight(string(secret), "\r\n")); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
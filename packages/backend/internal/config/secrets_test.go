@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSecretFiles(t *testing.T) {
+	unsetEnv(t, "KITE_DB_PASSWORD", "KITE_JIRA_TOKEN", "KITE_JIRA_TOKEN_FILE", "KITE_GITHUB_TOKEN", "KITE_GITHUB_TOKEN_FILE")
+
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret: %v", err)
+	}
+	t.Setenv("KITE_DB_PASSWORD_FILE", path)
+
+	if err := LoadSecretFiles(); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if password := GetDatabaseConfig().Password; password != "s3cret" {
+		t.Errorf("Expected the password of the file without its newline, got %q", password)
+	}
+}
+
+func TestLoadSecretFiles_Invalid(t *testing.T) {
+	unsetEnv(t, "KITE_DB_PASSWORD", "KITE_DB_PASSWORD_FILE", "KITE_JIRA_TOKEN_FILE", "KITE_GITHUB_TOKEN", "KITE_GITHUB_TOKEN_FILE")
+
+	// Set both as a variable and as a file
+	t.Setenv("KITE_JIRA_TOKEN", "token")
+	t.Setenv("KITE_JIRA_TOKEN_FILE", filepath.Join(t.TempDir(), "token"))
+	if err := LoadSecretFiles(); err == nil {
+		t.Error("Expected an error for a secret set twice, got nil")
+	}
+
+	// Missing file
+	unsetEnv(t, "KITE_JIRA_TOKEN", "KITE_JIRA_TOKEN_FILE")
+	t.Setenv("KITE_GITHUB_TOKEN_FILE", filepath.Join(t.TempDir(), "missing"))
+	if err := LoadSecretFiles(); err == nil {
+		t.Error("Expected an error for a missing file, got nil")
+	}
+}
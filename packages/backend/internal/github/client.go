@@ -0,0 +1,149 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// IssueRequest holds the fields of a GitHub issue to create
+type IssueRequest struct {
+	Title  string
+	Body   string
+	Labels []string
+}
+
+// Issue is a GitHub issue
+type Issue struct {
+	Number int
+	URL    string
+}
+
+// Client calls the GitHub REST API for the issues of a repository
+type Client struct {
+	apiURL     string
+	repository string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a new GitHub client
+//
+// Parameters:
+//   - cfg: The GitHub configuration, providing the API URL, repository and token
+//
+// Returns:
+//   - *Client
+func NewClient(cfg config.GitHubConfig) *Client {
+	return &Client{
+		apiURL:     cfg.APIURL,
+		repository: cfg.Repository,
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreateIssue creates an issue in the repository.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - req: The fields of the issue
+//
+// Returns:
+//   - *Issue: The issue created
+//   - error: GitHub error or nil
+func (c *Client) CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error) {
+	body := map[string]any{
+		"title":  req.Title,
+		"body":   req.Body,
+		"labels": req.Labels,
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/repos/"+c.repository+"/issues", body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create github issue: %w", err)
+	}
+
+	return &Issue{Number: created.Number, URL: created.HTMLURL}, nil
+}
+
+// CloseIssue closes an issue of the repository as completed. Closing a
+// closed issue succeeds.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - number: The number of the issue
+//
+// Returns:
+//   - error: GitHub error or nil
+func (c *Client) CloseIssue(ctx context.Context, number int) error {
+	body := map[string]string{
+		"state":        "closed",
+		"state_reason": "completed",
+	}
+	path := "/repos/" + c.repository + "/issues/" + strconv.Itoa(number)
+	if err := c.do(ctx, http.MethodPatch, path, body, nil); err != nil {
+		return fmt.Errorf("failed to close github issue %d: %w", number, err)
+	}
+	return nil
+}
+
+// Ping checks GitHub is reachable and the token can access the repository.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - error: GitHub error or nil
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodGet, "/repos/"+c.repository, nil, nil); err != nil {
+		return fmt.Errorf("failed to access github repository %s: %w", c.repository, err)
+	}
+	return nil
+}
+
+// do sends a request to the GitHub API, encoding body and decoding the response into result
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("github responded with status %d: %s", resp.StatusCode, message)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
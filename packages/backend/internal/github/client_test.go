@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+func TestClient_CreateIssue(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/konflux-ci/kite/issues" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Expected bearer authentication, got %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 42, "html_url": "https://github.test/konflux-ci/kite/issues/42"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitHubConfig{APIURL: server.URL, Repository: "konflux-ci/kite", Token: "secret"})
+	issue, err := client.CreateIssue(context.Background(), IssueRequest{
+		Title:  "Pipeline run failed: build",
+		Labels: []string{"kite"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if issue.Number != 42 || issue.URL != "https://github.test/konflux-ci/kite/issues/42" {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if body["title"] != "Pipeline run failed: build" {
+		t.Errorf("Unexpected title %v", body["title"])
+	}
+}
+
+func TestClient_CloseIssue(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/repos/konflux-ci/kite/issues/42" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"number": 42, "state": "closed"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitHubConfig{APIURL: server.URL, Repository: "konflux-ci/kite", Token: "secret"})
+	if err := client.CloseIssue(context.Background(), 42); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if body["state"] != "closed" {
+		t.Errorf("Expected the issue to be closed, got %v", body)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.GitHubConfig{APIURL: server.URL, Repository: "konflux-ci/missing", Token: "secret"})
+	if err := client.CloseIssue(context.Background(), 42); err == nil {
+		t.Error("Expected an error, got nil")
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/repos/konflux-ci/kite" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"full_name": "konflux-ci/kite"}`))
+	}))
+	defer server.Close()
+
+	if err := NewClient(config.GitHubConfig{APIURL: server.URL, Repository: "konflux-ci/kite", Token: "secret"}).Ping(context.Background()); err != nil {
+		t.Errorf("Unexpected error, got %v", err)
+	}
+	if err := NewClient(config.GitHubConfig{APIURL: server.URL, Repository: "konflux-ci/missing", Token: "secret"}).Ping(context.Background()); err == nil {
+		t.Error("Expected an error for a missing repository")
+	}
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ExternalRefSystem is the system of the external references to GitHub issues
+const ExternalRefSystem = "github"
+
+// issueLabel labels the GitHub issues mirroring issues
+const issueLabel = "kite"
+
+// pageSize is how many issues are loaded per query when syncing
+const pageSize = 100
+
+// Tracker creates and closes GitHub issues, see Client
+type Tracker interface {
+	CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error)
+	CloseIssue(ctx context.Context, number int) error
+}
+
+// Syncer mirrors issues into GitHub issues, and closes the GitHub issues
+// once the issues they mirror are resolved.
+type Syncer struct {
+	issues  repository.IssueRepository
+	tracker Tracker
+	config  config.GitHubConfig
+	logger  *logrus.Logger
+
+	// closedSince is when the last sync closing all the GitHub issues of resolved issues started
+	closedSince *time.Time
+}
+
+// NewSyncer creates a new Syncer
+//
+// Parameters:
+//   - issues: The issue repository
+//   - tracker: Creates and closes GitHub issues
+//   - cfg: The GitHub configuration, providing the repository and the rule selecting issues
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - *Syncer
+func NewSyncer(issues repository.IssueRepository, tracker Tracker, cfg config.GitHubConfig, logger *logrus.Logger) *Syncer {
+	return &Syncer{
+		issues:  issues,
+		tracker: tracker,
+		config:  cfg,
+		logger:  logger,
+	}
+}
+
+// Run syncs issues with GitHub every sync interval, until stop is closed. The
+// sync in progress then is completed, unless ctx is cancelled. See workers.Worker.
+//
+// Parameters:
+//   - ctx: Context for cancellation of the syncs
+//   - stop: Closed to stop syncing
+func (s *Syncer) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.config.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sync(ctx); err != nil {
+			s.logger.WithError(err).Error("Failed to sync issues with github")
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync mirrors the active issues matching the labels and severity rule that
+// aren't mirrored yet, then closes the GitHub issues of the issues resolved
+// since the last sync. Syncing carries on past the failures of single issues.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - error: The errors of the issues that failed to sync, or nil
+func (s *Syncer) Sync(ctx context.Context) error {
+	startedAt := time.Now()
+	errs := s.mirrorActive(ctx)
+
+	closeErrs := s.closeResolved(ctx)
+	if len(closeErrs) == 0 {
+		s.closedSince = &startedAt
+	}
+
+	return errors.Join(append(errs, closeErrs...)...)
+}
+
+// mirrorActive creates the GitHub issues of the open issues matching the rule
+func (s *Syncer) mirrorActive(ctx context.Context) []error {
+	issues, err := repository.FindAllPages(ctx, s.issues, repository.IssueQueryFilters{
+		Unresolved: true,
+		Labels:     s.config.Labels,
+	}, pageSize)
+	if err != nil {
+		return []error{fmt.Errorf("failed to find active issues: %w", err)}
+	}
+
+	var errs []error
+	for _, issue := range issues {
+		if s.mirrorRef(issue) != nil || issue.Severity.Rank() < models.Severity(s.config.MinSeverity).Rank() {
+			continue
+		}
+		if err := s.mirror(ctx, issue); err != nil {
+			errs = append(errs, fmt.Errorf("issue %s: %w", issue.ID, err))
+		}
+	}
+	return errs
+}
+
+// mirror creates the GitHub issue of an issue and references it from the issue
+func (s *Syncer) mirror(ctx context.Context, issue models.Issue) error {
+	created, err := s.tracker.CreateIssue(ctx, IssueRequest{
+		Title: issue.Title,
+		Body: fmt.Sprintf("%s\n\n| | |\n|---|---|\n| Severity | %s |\n| Resource | %s/%s |\n| Namespace | %s |\n| Detected at | %s |\n| KITE issue | %s |",
+			issue.Description, issue.Severity, issue.Scope.ResourceType, issue.Scope.ResourceName,
+			issue.Namespace, issue.DetectedAt.UTC().Format(time.RFC3339), issue.ID),
+		Labels: []string{issueLabel, "severity/" + string(issue.Severity)},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The GitHub issue exists, failing to reference it would create another one on the next sync
+	key := fmt.Sprintf("%s#%d", s.config.Repository, created.Number)
+	if _, err := s.issues.AddExternalRef(ctx, issue.ID, models.ExternalRef{
+		System: ExternalRefSystem,
+		Key:    key,
+		URL:    created.URL,
+	}); err != nil {
+		return fmt.Errorf("failed to reference github issue %s: %w", key, err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"issue_id":     issue.ID,
+		"github_issue": key,
+	}).Info("Mirrored issue to github")
+	return nil
+}
+
+// closeResolved closes the GitHub issues of the issues resolved since the
+// last sync, or of all the resolved issues on the first sync
+func (s *Syncer) closeResolved(ctx context.Context) []error {
+	resolved := models.IssueStateResolved
+	hasExternalRef := true
+	issues, err := repository.FindAllPages(ctx, s.issues, repository.IssueQueryFilters{
+		State:          &resolved,
+		HasExternalRef: &hasExternalRef,
+		ResolvedSince:  s.closedSince,
+	}, pageSize)
+	if err != nil {
+		return []error{fmt.Errorf("failed to find resolved issues: %w", err)}
+	}
+
+	var errs []error
+	for _, issue := range issues {
+		ref := s.mirrorRef(issue)
+		if ref == nil {
+			continue
+		}
+		if err := s.closeMirror(ctx, ref.Key); err != nil {
+			errs = append(errs, fmt.Errorf("issue %s: %w", issue.ID, err))
+		}
+	}
+	return errs
+}
+
+// closeMirror closes the GitHub issue referenced by key
+func (s *Syncer) closeMirror(ctx context.Context, key string) error {
+	_, number, _ := strings.Cut(key, "#")
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return fmt.Errorf("invalid github issue reference %q", key)
+	}
+	return s.tracker.CloseIssue(ctx, n)
+}
+
+// mirrorRef returns the reference of an issue to the GitHub issue of the
+// repository mirroring it, nil if it has none
+func (s *Syncer) mirrorRef(issue models.Issue) *models.ExternalRef {
+	prefix := s.config.Repository + "#"
+	for n := range issue.ExternalRefs {
+		ref := &issue.ExternalRefs[n]
+		if ref.System == ExternalRefSystem && strings.HasPrefix(ref.Key, prefix) {
+			return ref
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeTracker records the GitHub issues created and closed
+type fakeTracker struct {
+	created  []IssueRequest
+	closed   []int
+	closeErr error
+}
+
+func (f *fakeTracker) CreateIssue(ctx context.Context, req IssueRequest) (*Issue, error) {
+	f.created = append(f.created, req)
+	return &Issue{Number: len(f.created), URL: "https://github.test/issues"}, nil
+}
+
+func (f *fakeTracker) CloseIssue(ctx context.Context, number int) error {
+	if f.closeErr != nil {
+		return f.closeErr
+	}
+	f.closed = append(f.closed, number)
+	return nil
+}
+
+// setupSyncer sets up a syncer mirroring the major issues of team=build
+func setupSyncer(t *testing.T) (context.Context, repository.IssueRepository, *fakeTracker, *Syncer) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	repo := repository.NewIssueRepository(db, logger, 0)
+	tracker := &fakeTracker{}
+	cfg := config.GitHubConfig{
+		Repository:   "konflux-ci/kite",
+		Labels:       map[string]string{"team": "build"},
+		MinSeverity:  string(models.SeverityMajor),
+		SyncInterval: time.Minute,
+	}
+	return context.Background(), repo, tracker, NewSyncer(repo, tracker, cfg, logger)
+}
+
+// createIssue creates an active issue with labels
+func createIssue(t *testing.T, ctx context.Context, repo repository.IssueRepository, name string, severity models.Severity, labels map[string]string) *models.Issue {
+	issue, err := repo.Create(ctx, dto.CreateIssueRequest{
+		Title:       "Build failed: " + name,
+		Description: "The build of " + name + " failed",
+		Severity:    severity,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-a",
+		Labels:      labels,
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      name,
+			ResourceNamespace: "team-a",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	return issue
+}
+
+func TestSyncer_Sync_Mirrors(t *testing.T) {
+	ctx, repo, tracker, syncer := setupSyncer(t)
+
+	mirrored := createIssue(t, ctx, repo, "api", models.SeverityCritical, map[string]string{"team": "build"})
+	createIssue(t, ctx, repo, "ui", models.SeverityMinor, map[string]string{"team": "build"})      // Not severe enough
+	createIssue(t, ctx, repo, "db", models.SeverityCritical, map[string]string{"team": "release"}) // Not labeled
+
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if len(tracker.created) != 1 || tracker.created[0].Title != mirrored.Title {
+		t.Fatalf("Expected a GitHub issue for %q only, got %+v", mirrored.Title, tracker.created)
+	}
+
+	issue, err := repo.FindByID(ctx, mirrored.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if ref := syncer.mirrorRef(*issue); ref == nil || ref.Key != "konflux-ci/kite#1" {
+		t.Errorf("Expected the issue to reference konflux-ci/kite#1, got %+v", issue.ExternalRefs)
+	}
+
+	// Mirrored issues aren't mirrored again
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(tracker.created) != 1 {
+		t.Errorf("Expected no new GitHub issue, got %d issues", len(tracker.created))
+	}
+}
+
+func TestSyncer_Sync_ClosesResolved(t *testing.T) {
+	ctx, repo, tracker, syncer := setupSyncer(t)
+
+	issue := createIssue(t, ctx, repo, "api", models.SeverityCritical, map[string]string{"team": "build"})
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Failed to resolve test issue: %v", err)
+	}
+
+	// Failing to close the GitHub issue, it's closed on the next sync
+	tracker.closeErr = errors.New("github unavailable")
+	if err := syncer.Sync(ctx); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	tracker.closeErr = nil
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(tracker.closed) != 1 || tracker.closed[0] != 1 {
+		t.Fatalf("Expected GitHub issue 1 to be closed, got %v", tracker.closed)
+	}
+
+	// Issues resolved before the last sync are left alone
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(tracker.closed) != 1 {
+		t.Errorf("Expected no GitHub issue to be closed again, got %v", tracker.closed)
+	}
+}
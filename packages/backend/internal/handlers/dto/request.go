@@ -54,15 +54,29 @@ func (s ScopeReqBodyOptional) AsOptional() ScopeReqBodyOptional {
 // CreateIssueRequest is the payload for creating a new issue.
 // Required Fields: Title, Description, Severity, IssueType, Namespace, Scope.
 // State is optional, defaults to "ACTIVE".
+// DetectedAt is optional, defaults to the time the request is received.
+// Details is optional, the full text of what the description summarizes,
+// e.g. a complete failure message.
+// Fingerprint is optional, grouping the reports of an issue instead of its
+// scope, see models.Issue.Fingerprint.
 type CreateIssueRequest struct {
 	Title       string              `json:"title" binding:"required"`
 	Description string              `json:"description" binding:"required"`
-	Severity    models.Severity     `json:"severity" binding:"required"`
-	IssueType   models.IssueType    `json:"issueType" binding:"required"`
-	State       models.IssueState   `json:"state"`
+	Details     string              `json:"details"`
+	Severity    models.Severity     `json:"severity" binding:"required,severity"`
+	Priority    models.Priority     `json:"priority" binding:"omitempty,priority"`
+	IssueType   models.IssueType    `json:"issueType" binding:"required,issuetype"`
+	State       models.IssueState   `json:"state" binding:"omitempty,issuestate"`
 	Namespace   string              `json:"namespace" binding:"required"`
 	Scope       ScopeReqBody        `json:"scope" binding:"required"`
 	Links       []CreateLinkRequest `json:"links"`
+	Labels      map[string]string   `json:"labels"`
+	Assignee    string              `json:"assignee"`
+	DetectedAt  time.Time           `json:"detectedAt"`
+	Fingerprint string              `json:"fingerprint" binding:"max=255"`
+	// LinkVariables are the variables of the link templates specific to the
+	// report, e.g. the runId of a pipeline failure, see models.LinkTemplate
+	LinkVariables map[string]string `json:"-"`
 }
 
 // CreateLinkRequest represents a link associated with an issue.
@@ -71,53 +85,167 @@ type CreateLinkRequest struct {
 	URL   string `json:"url" binding:"required"`
 }
 
+// CreateExternalRefRequest represents a reference to an issue tracked in
+// another system, e.g. system "jira" and key "KFLUXBUGS-1234".
+type CreateExternalRefRequest struct {
+	System string `json:"system" binding:"required"`
+	Key    string `json:"key" binding:"required"`
+	URL    string `json:"url" binding:"required,url"`
+}
+
+// Resolution explains why and by whom issues are resolved. Both fields are optional.
+type Resolution struct {
+	Reason     string `json:"reason"`
+	ResolvedBy string `json:"resolvedBy"`
+}
+
+// WebhookBatchItem is an operation of a batch of webhooks, either reporting
+// Issue or resolving the open issues of ResolveScope with Resolution. The
+// issues resolved are those of the namespace of the resource of the scope.
+type WebhookBatchItem struct {
+	Issue        *CreateIssueRequest
+	ResolveScope *ScopeReqBody
+	Resolution   Resolution
+}
+
+// Acknowledgement tells who acknowledges an issue. The field is optional for
+// requests identifying their user, who is recorded instead.
+type Acknowledgement struct {
+	AcknowledgedBy string `json:"acknowledgedBy"`
+}
+
+// Snooze tells until when and by whom an issue is snoozed. SnoozedBy is
+// optional for requests identifying their user, who is recorded instead.
+type Snooze struct {
+	Until     time.Time `json:"until"`
+	SnoozedBy string    `json:"snoozedBy"`
+}
+
+// UpdateNamespaceSettingsRequest is the payload replacing the settings of a
+// namespace. Omitted fields are reset to their defaults.
+type UpdateNamespaceSettingsRequest struct {
+	RetentionDays          int                         `json:"retentionDays" binding:"min=0"`
+	DedupWindowHours       int                         `json:"dedupWindowHours" binding:"min=0"`
+	SeverityEscalationOnly bool                        `json:"severityEscalationOnly"`
+	SLATargets             models.SLATargets           `json:"slaTargets"`
+	Notifications          models.NotificationDefaults `json:"notifications"`
+	Workflow               *models.Workflow            `json:"workflow"`
+	LinkTemplates          []models.LinkTemplate       `json:"linkTemplates"`
+}
+
+// SaveViewRequest is the payload saving a view, replacing its query if it exists
+type SaveViewRequest struct {
+	// Query holds the filters as the query string of GET /issues, e.g. severity=critical&issueType=build
+	Query string `json:"query" binding:"required"`
+}
+
+// SaveTriageRuleRequest is the payload saving a triage rule, replacing it if
+// it exists. Conditions left empty match any issue, and actions left empty
+// leave the issue unchanged.
+type SaveTriageRuleRequest struct {
+	Position int `json:"position"`
+
+	// Conditions
+	TitlePattern string             `json:"titlePattern"`
+	ResourceType string             `json:"resourceType"`
+	ResourceName string             `json:"resourceName"`
+	Source       models.IssueSource `json:"source" binding:"omitempty,issuesource"`
+
+	// Actions
+	Labels   map[string]string `json:"labels"`
+	Assignee string            `json:"assignee"`
+	Priority models.Priority   `json:"priority" binding:"omitempty,priority"`
+	Severity models.Severity   `json:"severity" binding:"omitempty,severity"`
+}
+
 // UpdateIssueRequest is the payload for updating an existing issue.
 // All fields are optional. Only provided fields will be updated.
 // If ResolvedAt is non-zero, the issue will be considered resolved by the service.
+// Labels replace all existing labels when provided.
+// Resolving an issue replaces its resolution with ResolutionReason and ResolvedBy.
 type UpdateIssueRequest struct {
 	Title       string               `json:"title"`
 	Description string               `json:"description"`
-	Severity    models.Severity      `json:"severity"`
-	IssueType   models.IssueType     `json:"issueType"`
-	State       models.IssueState    `json:"state"`
+	Details     string               `json:"details"`
+	Severity    models.Severity      `json:"severity" binding:"omitempty,severity"`
+	Priority    models.Priority      `json:"priority" binding:"omitempty,priority"`
+	IssueType   models.IssueType     `json:"issueType" binding:"omitempty,issuetype"`
+	State       models.IssueState    `json:"state" binding:"omitempty,issuestate"`
 	Namespace   string               `json:"namespace"`
 	Scope       ScopeReqBodyOptional `json:"scope"`
 	Links       []CreateLinkRequest  `json:"links"`
+	Labels      map[string]string    `json:"labels"`
+	Assignee    string               `json:"assignee"`
 	ResolvedAt  time.Time            `json:"resolvedAt"`
+
+	ResolutionReason string `json:"resolutionReason"`
+	ResolvedBy       string `json:"resolvedBy"`
 }
 
 // IssuePayload unifies CREATE and UPDATE payloads for issues so services can accept either.
 type IssuePayload interface {
 	GetTitle() string
 	GetDescription() string
+	GetDetails() string
 	GetSeverity() models.Severity
+	GetPriority() models.Priority
 	GetIssueType() models.IssueType
 	GetState() models.IssueState
 	GetLinks() []CreateLinkRequest
+	GetLabels() map[string]string
+	GetAssignee() string
 	GetResolvedAt() time.Time
+	GetResolution() Resolution
+	GetDetectedAt() time.Time
 	GetNamespace() string
 	GetScope() ScopePayload
+	GetFingerprint() string
 }
 
 func (c CreateIssueRequest) GetTitle() string               { return c.Title }
 func (c CreateIssueRequest) GetDescription() string         { return c.Description }
+func (c CreateIssueRequest) GetDetails() string             { return c.Details }
 func (c CreateIssueRequest) GetSeverity() models.Severity   { return c.Severity }
+func (c CreateIssueRequest) GetPriority() models.Priority   { return c.Priority }
 func (c CreateIssueRequest) GetIssueType() models.IssueType { return c.IssueType }
 func (c CreateIssueRequest) GetState() models.IssueState    { return c.State }
 func (c CreateIssueRequest) GetLinks() []CreateLinkRequest  { return c.Links }
+func (c CreateIssueRequest) GetLabels() map[string]string   { return c.Labels }
+func (c CreateIssueRequest) GetAssignee() string            { return c.Assignee }
 func (c CreateIssueRequest) GetScope() ScopePayload         { return c.Scope }
 func (c CreateIssueRequest) GetNamespace() string           { return c.Namespace }
+func (c CreateIssueRequest) GetDetectedAt() time.Time       { return c.DetectedAt }
+func (c CreateIssueRequest) GetFingerprint() string         { return c.Fingerprint }
 func (c CreateIssueRequest) GetResolvedAt() time.Time {
 	// CREATE requests do not set a resolved time. Return a zero time value.
 	return time.Time{}
 }
+func (c CreateIssueRequest) GetResolution() Resolution {
+	// CREATE requests do not resolve issues. Return an empty resolution.
+	return Resolution{}
+}
 
 func (u UpdateIssueRequest) GetTitle() string               { return u.Title }
 func (u UpdateIssueRequest) GetDescription() string         { return u.Description }
+func (u UpdateIssueRequest) GetDetails() string             { return u.Details }
 func (u UpdateIssueRequest) GetSeverity() models.Severity   { return u.Severity }
+func (u UpdateIssueRequest) GetPriority() models.Priority   { return u.Priority }
 func (u UpdateIssueRequest) GetIssueType() models.IssueType { return u.IssueType }
 func (u UpdateIssueRequest) GetState() models.IssueState    { return u.State }
 func (u UpdateIssueRequest) GetLinks() []CreateLinkRequest  { return u.Links }
+func (u UpdateIssueRequest) GetLabels() map[string]string   { return u.Labels }
+func (u UpdateIssueRequest) GetAssignee() string            { return u.Assignee }
 func (u UpdateIssueRequest) GetScope() ScopePayload         { return u.Scope }
 func (u UpdateIssueRequest) GetNamespace() string           { return u.Namespace }
 func (u UpdateIssueRequest) GetResolvedAt() time.Time       { return u.ResolvedAt }
+func (u UpdateIssueRequest) GetResolution() Resolution {
+	return Resolution{Reason: u.ResolutionReason, ResolvedBy: u.ResolvedBy}
+}
+func (u UpdateIssueRequest) GetDetectedAt() time.Time {
+	// UPDATE requests do not change when an issue was detected. Return a zero time value.
+	return time.Time{}
+}
+func (u UpdateIssueRequest) GetFingerprint() string {
+	// UPDATE requests do not change the fingerprint of an issue. Return an empty fingerprint.
+	return ""
+}
@@ -1,13 +1,329 @@
 package dto
 
-import "github.com/konflux-ci/kite/internal/models"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+)
 
 // DTOs (Data Transfer Objects)
 // These allow us to carry and format data between layers or services, without embedding any business logic.
 
+// IssueResponse is a page of issues. NextOffset is the offset of the next
+// page, only set when there is one. Total is only set when the issues are
+// counted, see repository.IssueQueryFilters.SkipTotal.
 type IssueResponse struct {
-	Data   []models.Issue `json:"data"`
-	Total  int64          `json:"total"`
-	Limit  int            `json:"limit"`
-	Offset int            `json:"offset"`
+	Data        []models.Issue `json:"data"`
+	Total       *int64         `json:"total,omitempty"`
+	Limit       int            `json:"limit"`
+	Offset      int            `json:"offset"`
+	HasNextPage bool           `json:"hasNextPage"`
+	NextOffset  *int           `json:"nextOffset,omitempty"`
+}
+
+// ProjectedIssueResponse is a page of issues holding only some of their
+// fields, by JSON name
+type ProjectedIssueResponse struct {
+	*IssueResponse
+	Data []map[string]json.RawMessage `json:"data"`
+}
+
+// IssueGroup is a set of issues sharing the same resource, type or severity
+type IssueGroup struct {
+	Key    string         `json:"key"`
+	Count  int            `json:"count"`
+	Issues []models.Issue `json:"issues"`
+}
+
+type GroupedIssuesResponse struct {
+	GroupBy string       `json:"groupBy"`
+	Groups  []IssueGroup `json:"groups"`
+	Total   int64        `json:"total"`
+	Limit   int          `json:"limit"`
+	Offset  int          `json:"offset"`
+}
+
+// DashboardResponse is the overview of the issues of a namespace shown on the
+// landing page of the console
+type DashboardResponse struct {
+	Namespace string `json:"namespace"`
+	// ActiveBySeverity counts the active issues of each severity
+	ActiveBySeverity map[models.Severity]int64 `json:"activeBySeverity"`
+	ActiveTotal      int64                     `json:"activeTotal"`
+	// RecentIssues are the most recently detected issues
+	RecentIssues []models.Issue `json:"recentIssues"`
+	// FlappingResources are the resources whose issues were reopened the most recently
+	FlappingResources []FlappingResource `json:"flappingResources"`
+	// SLABreaches are the active issues past the SLA target of their severity
+	SLABreaches      []SLABreach `json:"slaBreaches"`
+	SLABreachesTotal int64       `json:"slaBreachesTotal"`
+}
+
+// FlappingResource is a resource whose issues keep being reopened
+type FlappingResource struct {
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Reopens      int64  `json:"reopens"`
+}
+
+// SLABreach is an active issue past the SLA target of its severity
+type SLABreach struct {
+	Issue       models.Issue `json:"issue"`
+	TargetHours int          `json:"targetHours"`
+	BreachedAt  time.Time    `json:"breachedAt"`
+}
+
+// StatusResponse is the summary of the issues of a namespace backing external
+// status pages. It only holds what can be shown publicly, not the details of
+// the issues.
+type StatusResponse struct {
+	Namespace string `json:"namespace"`
+	// ActiveBySeverity counts the active issues of each severity
+	ActiveBySeverity map[models.Severity]int64 `json:"activeBySeverity"`
+	ActiveTotal      int64                     `json:"activeTotal"`
+	// RecentCritical are the most recently detected critical issues
+	RecentCritical []StatusIssue `json:"recentCritical"`
+	// SLAs are how the issues of the severities with an SLA target met it
+	// over the last SLAWindowDays days
+	SLAs          []SLAUptime `json:"slas"`
+	SLAWindowDays int         `json:"slaWindowDays"`
+	GeneratedAt   time.Time   `json:"generatedAt"`
+}
+
+// StatusIssue is an issue as shown on status pages
+type StatusIssue struct {
+	Title      string            `json:"title"`
+	State      models.IssueState `json:"state"`
+	DetectedAt time.Time         `json:"detectedAt"`
+	ResolvedAt *time.Time        `json:"resolvedAt,omitempty"`
+}
+
+// SLAUptime is how the issues of a severity met its SLA target: the issues
+// resolved within the target met it, those resolved later and the active
+// issues past the target missed it
+type SLAUptime struct {
+	Severity    models.Severity `json:"severity"`
+	TargetHours int             `json:"targetHours"`
+	Met         int64           `json:"met"`
+	Missed      int64           `json:"missed"`
+	// Uptime is the percentage of the issues that met the target, 100 without issues
+	Uptime float64 `json:"uptime"`
+}
+
+// WebhookBatchItemResult is the outcome of an operation of a batch of
+// webhooks, see WebhookBatchItem
+type WebhookBatchItemResult struct {
+	// Issue is the issue reported, nil for resolutions
+	Issue *models.Issue
+	// Resolved is how many issues were resolved
+	Resolved int64
+	// Action is what reporting Issue did, only set by dry runs
+	Action DryRunAction
+}
+
+// DryRunAction is what reporting an issue would do
+type DryRunAction string
+
+const (
+	// DryRunCreate creates a new issue
+	DryRunCreate DryRunAction = "create"
+	// DryRunUpdate updates the existing issue of the same namespace, type and scope
+	DryRunUpdate DryRunAction = "update"
+	// DryRunReopen updates and reopens the resolved issue of the same namespace, type and scope
+	DryRunReopen DryRunAction = "reopen"
+)
+
+// DryRunResult is what reporting an issue would do, nothing being saved
+type DryRunResult struct {
+	DryRun bool         `json:"dryRun"`
+	Action DryRunAction `json:"action"`
+	// Issue is the issue as it would be saved, triaged. The IDs of what would
+	// be created aren't kept.
+	Issue *models.Issue `json:"issue"`
+	// ExistingIssue is the duplicate that would be updated, as it is now
+	ExistingIssue *models.Issue `json:"existingIssue,omitempty"`
+	// TriageRules are the names of the triage rules that would fire on the
+	// issue created, their events only being recorded for new issues
+	TriageRules []string `json:"triageRules,omitempty"`
+}
+
+// ActivityType is the kind of an entry of the activity feed of an issue
+type ActivityType string
+
+const (
+	// ActivityDetected is the detection of the issue
+	ActivityDetected ActivityType = "detected"
+	// ActivityReported is a webhook reporting the issue, when it was detected or again later
+	ActivityReported ActivityType = "reported"
+	// ActivityTriaged is a triage rule changing the issue
+	ActivityTriaged ActivityType = "triaged"
+	// ActivityExternalRef is a reference to another tracker added to the issue
+	ActivityExternalRef ActivityType = "external-ref"
+	// ActivityAcknowledged is the acknowledgement of the issue
+	ActivityAcknowledged ActivityType = "acknowledged"
+	// ActivityResolved is the last resolution of the issue
+	ActivityResolved ActivityType = "resolved"
+	// ActivityReopened is the last time the issue was made active again
+	ActivityReopened ActivityType = "reopened"
+)
+
+// ActivityEntry is an entry of the activity feed of an issue
+type ActivityEntry struct {
+	Type      ActivityType `json:"type"`
+	Timestamp time.Time    `json:"timestamp"`
+	// Actor is who or what acted on the issue, e.g. the user resolving it or
+	// the triage rule firing, if known
+	Actor   string            `json:"actor,omitempty"`
+	Summary string            `json:"summary"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// RelationDirection tells whether an issue is the source or the target of a
+// relationship, see models.RelatedIssue
+type RelationDirection string
+
+const (
+	// RelationOutgoing relationships have the issue as their source, e.g. the
+	// issue is caused by the related issue
+	RelationOutgoing RelationDirection = "outgoing"
+	// RelationIncoming relationships have the issue as their target, e.g. the
+	// related issue is caused by the issue
+	RelationIncoming RelationDirection = "incoming"
+)
+
+// RelatedIssueEntry is a relationship of an issue, in either direction, with
+// a summary of the other issue of the relationship
+type RelatedIssueEntry struct {
+	RelationID string              `json:"relationId"`
+	Type       models.RelationType `json:"type"`
+	Direction  RelationDirection   `json:"direction"`
+	Issue      RelatedIssueSummary `json:"issue"`
+}
+
+// RelatedIssueSummary is the summary of the other issue of a relationship
+type RelatedIssueSummary struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Severity     models.Severity   `json:"severity"`
+	IssueType    models.IssueType  `json:"issueType"`
+	State        models.IssueState `json:"state"`
+	Namespace    string            `json:"namespace"`
+	ResourceType string            `json:"resourceType"`
+	ResourceName string            `json:"resourceName"`
+	DetectedAt   time.Time         `json:"detectedAt"`
+	ResolvedAt   *time.Time        `json:"resolvedAt,omitempty"`
+}
+
+// TimelineEvent is the detection, resolution or reopening of an issue of a
+// resource, see ScopeTimeline
+type TimelineEvent struct {
+	Type      ActivityType     `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	IssueID   string           `json:"issueId"`
+	Title     string           `json:"title"`
+	IssueType models.IssueType `json:"issueType"`
+	Severity  models.Severity  `json:"severity"`
+}
+
+// ScopeTimeline is the timeline of the issues of a resource since a time,
+// the oldest event first
+type ScopeTimeline struct {
+	Scope  models.IssueScope `json:"scope"`
+	Since  time.Time         `json:"since"`
+	Events []TimelineEvent   `json:"data"`
+	Total  int               `json:"total"`
+}
+
+// OffboardingMode is what happens to the issues of an offboarded namespace
+type OffboardingMode string
+
+const (
+	// OffboardingModePurge deletes the issues, and the webhook events of the namespace
+	OffboardingModePurge OffboardingMode = "purge"
+	// OffboardingModeArchive resolves the active issues, keeping the issues
+	// and webhook events of the namespace for reference
+	OffboardingModeArchive OffboardingMode = "archive"
+)
+
+// OffboardingStatus is the status of an OffboardingJob
+type OffboardingStatus string
+
+const (
+	OffboardingStatusRunning   OffboardingStatus = "running"
+	OffboardingStatusSucceeded OffboardingStatus = "succeeded"
+	OffboardingStatusFailed    OffboardingStatus = "failed"
+)
+
+// NamespaceData counts the records of a namespace deleted with it, besides its issues
+type NamespaceData struct {
+	Settings      int64 `json:"settings"`
+	SavedViews    int64 `json:"savedViews"`
+	TriageRules   int64 `json:"triageRules"`
+	WebhookEvents int64 `json:"webhookEvents"`
+}
+
+// OffboardingJob is the progress of offboarding a namespace. Issues is how
+// many issues are deleted, or resolved when archiving, IssuesProcessed how many
+// of them already are. Deleted is set once the rest of the namespace is deleted.
+type OffboardingJob struct {
+	ID              string            `json:"id"`
+	Namespace       string            `json:"namespace"`
+	Mode            OffboardingMode   `json:"mode"`
+	Status          OffboardingStatus `json:"status"`
+	RequestedBy     string            `json:"requestedBy,omitempty"`
+	Issues          int64             `json:"issues"`
+	IssuesProcessed int64             `json:"issuesProcessed"`
+	Deleted         *NamespaceData    `json:"deleted,omitempty"`
+	Error           string            `json:"error,omitempty"`
+	StartedAt       time.Time         `json:"startedAt"`
+	FinishedAt      *time.Time        `json:"finishedAt,omitempty"`
+}
+
+// MaintenanceTask is a database maintenance task, recovering from drift
+// without direct access to the database
+type MaintenanceTask string
+
+const (
+	// MaintenanceTaskReindex rebuilds the indexes of the tables, e.g. bloated
+	// ones or ones left invalid by a failed migration
+	MaintenanceTaskReindex MaintenanceTask = "reindex"
+	// MaintenanceTaskVacuum reclaims the space of the deleted rows of the
+	// tables, and refreshes their statistics
+	MaintenanceTaskVacuum MaintenanceTask = "vacuum"
+	// MaintenanceTaskAnalyze refreshes the statistics the query planner keeps
+	// on the tables
+	MaintenanceTaskAnalyze MaintenanceTask = "analyze"
+	// MaintenanceTaskRecompute recomputes the denormalized columns of issues
+	// from the records they're derived from, e.g. their deduplication key from
+	// their scope
+	MaintenanceTaskRecompute MaintenanceTask = "recompute"
+)
+
+// MaintenanceTasks are the valid MaintenanceTask values
+var MaintenanceTasks = []MaintenanceTask{MaintenanceTaskReindex, MaintenanceTaskVacuum, MaintenanceTaskAnalyze, MaintenanceTaskRecompute}
+
+// MaintenanceStatus is the status of a MaintenanceJob
+type MaintenanceStatus string
+
+const (
+	MaintenanceStatusRunning   MaintenanceStatus = "running"
+	MaintenanceStatusSucceeded MaintenanceStatus = "succeeded"
+	MaintenanceStatusFailed    MaintenanceStatus = "failed"
+)
+
+// MaintenanceJob is the progress of a maintenance task. Tables are the tables
+// the task processes, TablesProcessed how many of them it already did.
+// Recomputed counts the issues whose denormalized columns were out of date.
+type MaintenanceJob struct {
+	ID              string            `json:"id"`
+	Task            MaintenanceTask   `json:"task"`
+	Status          MaintenanceStatus `json:"status"`
+	RequestedBy     string            `json:"requestedBy,omitempty"`
+	Tables          []string          `json:"tables,omitempty"`
+	TablesProcessed int               `json:"tablesProcessed"`
+	Recomputed      int64             `json:"recomputed"`
+	Error           string            `json:"error,omitempty"`
+	StartedAt       time.Time         `json:"startedAt"`
+	FinishedAt      *time.Time        `json:"finishedAt,omitempty"`
 }
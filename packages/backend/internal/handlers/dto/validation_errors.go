@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is a field of a request failing validation
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. scope.resourceName or links[0].url
+	Field string `json:"field"`
+	// Value is the rejected value. It's omitted for missing fields, and for
+	// values too long, which aren't sent back.
+	Value any `json:"value,omitempty"`
+	// Constraint is the constraint the value fails, e.g. required, max or severity
+	Constraint string `json:"constraint"`
+	// Param is the parameter of the constraint, if any, e.g. the maximum length
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors are all the fields of a request failing validation
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Has checks whether field already fails validation
+func (e ValidationErrors) Has(field string) bool {
+	for _, fieldErr := range e {
+		if fieldErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxLength adds an error when value is longer than limit characters, as
+// counted by length
+func (e *ValidationErrors) MaxLength(field string, length, limit int) {
+	if length > limit {
+		*e = append(*e, FieldError{
+			Field:      field,
+			Constraint: "max",
+			Param:      fmt.Sprint(limit),
+			Message:    fmt.Sprintf("%s cannot be longer than %d characters", field, limit),
+		})
+	}
+}
+
+// BindingErrors converts the error of binding a request into the fields
+// failing validation: the fields failing their binding tags, or the field of
+// the wrong JSON type. Returns false for other errors, e.g. invalid JSON.
+func BindingErrors(err error) (ValidationErrors, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		kind := jsonKind(typeErr.Type)
+		return ValidationErrors{{
+			Field:      typeErr.Field,
+			Constraint: "type",
+			Param:      kind,
+			Message:    fmt.Sprintf("%s must be %s, got %s", typeErr.Field, withArticle(kind), withArticle(typeErr.Value)),
+		}}, true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, false
+	}
+	errs := make(ValidationErrors, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		// The namespace starts with the name of the request type
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		fieldErr := FieldError{
+			Field:      field,
+			Value:      fe.Value(),
+			Constraint: fe.Tag(),
+			Param:      fe.Param(),
+		}
+		switch fe.Tag() {
+		case "required":
+			fieldErr.Value = nil
+			fieldErr.Message = fmt.Sprintf("%s is required", field)
+		case "url":
+			fieldErr.Message = fmt.Sprintf("%s must be a URL", field)
+		case "min":
+			fieldErr.Message = fmt.Sprintf("%s must be at least %s", field, fe.Param())
+		case "max":
+			fieldErr.Message = fmt.Sprintf("%s must be at most %s", field, fe.Param())
+		default:
+			if _, ok := enumValidators[fe.Tag()]; ok {
+				fieldErr.Message = fmt.Sprintf("invalid %s %q", fe.Tag(), fe.Value())
+			} else {
+				fieldErr.Message = fmt.Sprintf("%s fails the %s constraint", field, fe.Tag())
+			}
+		}
+		errs = append(errs, fieldErr)
+	}
+	return errs, true
+}
+
+// jsonKind returns the JSON type decoded into t
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func withArticle(kind string) string {
+	if kind != "" && strings.ContainsAny(kind[:1], "aeiou") {
+		return "an " + kind
+	}
+	return "a " + kind
+}
+
+// jsonName is the name of a field in validation errors, its JSON name
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
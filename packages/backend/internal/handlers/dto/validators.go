@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+// enumValidators validate the enums of the models in binding tags, e.g.
+// binding:"required,severity". Combine them with omitempty for optional fields.
+var enumValidators = map[string]validator.Func{
+	"severity": func(fl validator.FieldLevel) bool {
+		return models.Severity(fl.Field().String()).Valid()
+	},
+	"priority": func(fl validator.FieldLevel) bool {
+		return models.Priority(fl.Field().String()).Valid()
+	},
+	"issuetype": func(fl validator.FieldLevel) bool {
+		return models.IssueType(fl.Field().String()).Valid()
+	},
+	"issuestate": func(fl validator.FieldLevel) bool {
+		return models.IssueState(fl.Field().String()).Valid()
+	},
+	"issuesource": func(fl validator.FieldLevel) bool {
+		return models.IssueSource(fl.Field().String()).Valid()
+	},
+}
+
+// The validators are registered with the validator of gin before any request
+// is bound, binding a field tagged with an unregistered validator panics
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		panic("unexpected binding validator engine")
+	}
+	// Validation errors name the fields as in JSON, see BindingErrors
+	v.RegisterTagNameFunc(jsonName)
+	for tag, fn := range enumValidators {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+}
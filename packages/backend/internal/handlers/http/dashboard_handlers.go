@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// statusCacheControl lets the status pages cache the summaries of namespaces
+// for a minute, shared caches only caching those of public namespaces
+const statusCacheControl = "max-age=60"
+
+type DashboardHandler struct {
+	dashboardService services.DashboardServiceInterface
+	logger           *logrus.Logger
+}
+
+func NewDashboardHandler(dashboardService services.DashboardServiceInterface, logger *logrus.Logger) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+		logger:           logger,
+	}
+}
+
+// GetDashboard handles GET /dashboard, returning the overview of the issues of
+// the namespace the console shows on its landing page
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing namespace"})
+		return
+	}
+
+	dashboard, err := h.dashboardService.GetDashboard(c.Request.Context(), namespace)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to fetch dashboard")
+		respondWithServerError(c, err, "Failed to fetch dashboard")
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// GetStatus handles GET /status/:namespace, returning the summary of the
+// issues of the namespace backing its external status page, see
+// services.DashboardService.GetStatus. Responses may be cached for a minute.
+func (h *DashboardHandler) GetStatus(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	status, err := h.dashboardService.GetStatus(c.Request.Context(), namespace)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to fetch status")
+		respondWithServerError(c, err, "Failed to fetch status")
+		return
+	}
+
+	if middleware.IsPublic(c) {
+		c.Header("Cache-Control", "public, "+statusCacheControl)
+	} else {
+		c.Header("Cache-Control", "private, "+statusCacheControl)
+	}
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func setupTestDashboardRouter(mockService *MockDashboardService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewDashboardHandler(mockService, logger)
+
+	router := gin.New()
+	router.GET("/api/v1/dashboard", handler.GetDashboard)
+	router.GET("/api/v1/status/:namespace", handler.GetStatus)
+	return router
+}
+
+func TestDashboardHandler_GetDashboard(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		serviceError   error
+		expectedStatus int
+	}{
+		{
+			name:           "dashboard of the namespace",
+			path:           "/api/v1/dashboard?namespace=team-alpha",
+			expectedStatus: net_http.StatusOK,
+		},
+		{
+			name:           "missing namespace",
+			path:           "/api/v1/dashboard",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			path:           "/api/v1/dashboard?namespace=team-alpha",
+			serviceError:   errors.New("database unavailable"),
+			expectedStatus: net_http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := setupTestDashboardRouter(&MockDashboardService{
+				dashboard: &dto.DashboardResponse{
+					ActiveBySeverity: map[models.Severity]int64{models.SeverityCritical: 2},
+					ActiveTotal:      2,
+				},
+				err: tc.serviceError,
+			})
+
+			req, _ := net_http.NewRequest("GET", tc.path, nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+			var dashboard dto.DashboardResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &dashboard); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if dashboard.Namespace != "team-alpha" || dashboard.ActiveBySeverity[models.SeverityCritical] != 2 {
+				t.Errorf("Unexpected dashboard %+v", dashboard)
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetStatus(t *testing.T) {
+	router := setupTestDashboardRouter(&MockDashboardService{
+		status: &dto.StatusResponse{
+			ActiveBySeverity: map[models.Severity]int64{models.SeverityCritical: 1},
+			ActiveTotal:      1,
+			SLAs:             []dto.SLAUptime{{Severity: models.SeverityCritical, TargetHours: 4, Met: 3, Missed: 1, Uptime: 75}},
+		},
+	})
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/status/team-alpha", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if cacheControl := w.Header().Get("Cache-Control"); cacheControl != "private, max-age=60" {
+		t.Errorf("Expected the status to be cached privately, got %q", cacheControl)
+	}
+	var status dto.StatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if status.Namespace != "team-alpha" || status.ActiveTotal != 1 || len(status.SLAs) != 1 || status.SLAs[0].Uptime != 75 {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	router = setupTestDashboardRouter(&MockDashboardService{err: errors.New("database unavailable")})
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
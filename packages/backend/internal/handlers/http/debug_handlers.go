@@ -0,0 +1,22 @@
+package http
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+)
+
+// NewDebugHandler returns the handler of the runtime debug endpoints, the
+// pprof profiles under /debug/pprof/ and the expvar variables at /debug/vars.
+// They expose the internals of the server, and are meant to be served on an
+// internal address only, see config.DebugConfig.
+func NewDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
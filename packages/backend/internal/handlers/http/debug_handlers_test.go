@@ -0,0 +1,34 @@
+package http
+
+import (
+	"strings"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+)
+
+func TestDebugHandler(t *testing.T) {
+	handler := NewDebugHandler()
+
+	tests := []struct {
+		path     string
+		contains string
+	}{
+		{path: "/debug/pprof/", contains: "goroutine"},
+		{path: "/debug/pprof/heap?debug=1", contains: "heap profile"},
+		{path: "/debug/vars", contains: "memstats"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, _ := net_http.NewRequest("GET", tt.path, nil)
+			w := net_httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusOK || !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("Expected status 200 with %q, got %d", tt.contains, w.Code)
+			}
+		})
+	}
+}
@@ -1,12 +1,21 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/blob"
 	kiteConf "github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/github"
+	"github.com/konflux-ci/kite/internal/jira"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/startup"
+	"github.com/konflux-ci/kite/internal/version"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -22,9 +31,60 @@ type ComponentHealth struct {
 	Status  string      `json:"status"`
 	Message string      `json:"message,omitempty"`
 	Details interface{} `json:"details,omitempty"`
+	// Critical components make the API unavailable when they're unhealthy,
+	// see kiteConf.HealthConfig
+	Critical bool `json:"critical,omitempty"`
+	// LatencySeconds is how long the check of the component took
+	LatencySeconds float64 `json:"latencySeconds,omitempty"`
 }
 
-func NewHealthHandler(db *gorm.DB, logger *logrus.Logger) gin.HandlerFunc {
+// HealthCheck checks a subsystem the API depends on, e.g. blob.Client.Ping,
+// returning why it's unhealthy
+type HealthCheck func(ctx context.Context) error
+
+// NewHealthChecks returns the checks of the subsystems that are configured, by
+// name, see kiteConf.HealthComponents
+//
+// Parameters:
+//   - cfg: The configuration, see kiteConf.Load
+//
+// Returns:
+//   - map[string]HealthCheck
+//   - error: The configuration is invalid
+func NewHealthChecks(cfg *kiteConf.Config) (map[string]HealthCheck, error) {
+	checks := map[string]HealthCheck{}
+	if cfg.Jira.Enabled {
+		checks["jira"] = jira.NewClient(cfg.Jira).Ping
+	}
+	if cfg.GitHub.Enabled {
+		checks["github"] = github.NewClient(cfg.GitHub).Ping
+	}
+	if cfg.BlobStorage.Endpoint != "" {
+		blobClient, err := blob.NewClient(cfg.BlobStorage)
+		if err != nil {
+			return nil, err
+		}
+		checks["blob_storage"] = blobClient.Ping
+	}
+	return checks, nil
+}
+
+// NewHealthHandler returns the handler checking the health of the database
+// and of the subsystems the API depends on, concurrently. The API is ready,
+// responding 200, while its critical components are healthy. Other unhealthy
+// components only degrade it. The API isn't ready either until its startup
+// checks passed.
+//
+// Parameters:
+//   - db: The database
+//   - checks: The checks of the subsystems, by name, see NewHealthChecks
+//   - checker: The startup checks, nil when they're skipped
+//   - cfg: The critical components and the timeout of the checks
+//   - logger: The logger
+//
+// Returns:
+//   - gin.HandlerFunc
+func NewHealthHandler(db *gorm.DB, checks map[string]HealthCheck, checker *startup.Checker, cfg kiteConf.HealthConfig, logger *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
 
@@ -34,14 +94,51 @@ func NewHealthHandler(db *gorm.DB, logger *logrus.Logger) gin.HandlerFunc {
 			Components: make(map[string]ComponentHealth),
 		}
 
-		// Track overall health
-		overallHealthy := true
+		// Check the database and the subsystems concurrently, so that the
+		// slowest check bounds the response time
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		record := func(name string, componentHealth ComponentHealth) {
+			mu.Lock()
+			defer mu.Unlock()
+			health.Components[name] = componentHealth
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record("database", checkDatabaseHealth(c.Request.Context(), db, logger))
+		}()
+		for name, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				record(name, checkSubsystemHealth(c.Request.Context(), name, check, cfg.CheckTimeout, logger))
+			}()
+		}
+		wg.Wait()
+
+		// Track overall health, only critical components making the API unavailable
+		criticalHealthy, allHealthy := true, true
+		for name, componentHealth := range health.Components {
+			componentHealth.Critical = slices.Contains(cfg.CriticalComponents, name)
+			health.Components[name] = componentHealth
+			if componentHealth.Status != "UP" {
+				allHealthy = false
+				if componentHealth.Critical {
+					criticalHealthy = false
+				}
+			}
+		}
 
-		// Check database health
-		dbHealth := checkDatabaseHealth(db, logger)
-		health.Components["database"] = dbHealth
-		if dbHealth.Status != "UP" {
-			overallHealthy = false
+		// The API isn't ready while starting
+		if checker != nil && !checker.Ready() {
+			health.Components["startup"] = ComponentHealth{
+				Status:   "DOWN",
+				Message:  "Startup checks haven't passed yet",
+				Details:  checker.Results(),
+				Critical: true,
+			}
+			allHealthy, criticalHealthy = false, false
 		}
 
 		// Check API health
@@ -58,25 +155,30 @@ func NewHealthHandler(db *gorm.DB, logger *logrus.Logger) gin.HandlerFunc {
 			},
 		}
 
-		if overallHealthy {
+		switch {
+		case allHealthy:
 			health.Status = "UP"
 			health.Message = "All systems operational"
 			c.JSON(http.StatusOK, health)
-		} else {
+		case criticalHealthy:
+			health.Status = "DEGRADED"
+			health.Message = "One or more non-critical components are unhealthy"
+			c.JSON(http.StatusOK, health)
+		default:
 			health.Status = "DOWN"
-			health.Message = "One or more components are unhealthy"
+			health.Message = "One or more critical components are unhealthy"
 			c.JSON(http.StatusServiceUnavailable, health)
 		}
 	}
 }
 
 // checkDatabaseHealth performs a real-time database health check
-func checkDatabaseHealth(db *gorm.DB, logger *logrus.Logger) ComponentHealth {
+func checkDatabaseHealth(ctx context.Context, db *gorm.DB, logger *logrus.Logger) ComponentHealth {
 	start := time.Now()
 	dbHealth, err := kiteConf.CheckDatabaseHealth(db)
 	duration := time.Since(start)
 	if err != nil {
-		logger.WithError(err).Error("Database health check failed")
+		logging.FromContext(ctx, logger).WithError(err).Error("Database health check failed")
 		return ComponentHealth{
 			Status:  "DOWN",
 			Message: err.Error(),
@@ -84,13 +186,40 @@ func checkDatabaseHealth(db *gorm.DB, logger *logrus.Logger) ComponentHealth {
 				"check_duration_seconds": duration.Seconds(),
 				"cause_of_failure":       fmt.Sprintf("Database ping failed: %v", err),
 			},
+			LatencySeconds: duration.Seconds(),
 		}
 	}
 
 	return ComponentHealth{
-		Status:  "UP",
-		Message: "Database connection successful",
-		Details: dbHealth,
+		Status:         "UP",
+		Message:        "Database connection successful",
+		Details:        dbHealth,
+		LatencySeconds: duration.Seconds(),
+	}
+}
+
+// checkSubsystemHealth runs the health check of a subsystem, which is unhealthy
+// when the check fails or takes longer than timeout
+func checkSubsystemHealth(ctx context.Context, name string, check HealthCheck, timeout time.Duration, logger *logrus.Logger) ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	duration := time.Since(start)
+	if err != nil {
+		logging.FromContext(ctx, logger).WithError(err).WithField("component", name).Error("Health check failed")
+		return ComponentHealth{
+			Status:         "DOWN",
+			Message:        err.Error(),
+			LatencySeconds: duration.Seconds(),
+		}
+	}
+
+	return ComponentHealth{
+		Status:         "UP",
+		Message:        "Subsystem reachable",
+		LatencySeconds: duration.Seconds(),
 	}
 }
 
@@ -99,7 +228,7 @@ func checkAPIHealth() ComponentHealth {
 		Status:  "UP",
 		Message: "API server is responding",
 		Details: map[string]interface{}{
-			"version": kiteConf.GetEnvOrDefault("KITE_VERSION", "0.0.1"),
+			"version": version.Version,
 		},
 	}
 }
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	kiteConf "github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/startup"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+func TestHealthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+	db := testhelpers.SetupTestDB(t)
+
+	healthy := func(ctx context.Context) error { return nil }
+	unhealthy := func(ctx context.Context) error { return errors.New("bucket not found") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name           string
+		checks         map[string]HealthCheck
+		critical       []string
+		expectedCode   int
+		expectedStatus string
+		expectedDown   []string
+	}{
+		{
+			name:           "all healthy",
+			checks:         map[string]HealthCheck{"blob_storage": healthy, "jira": healthy},
+			critical:       []string{"database"},
+			expectedCode:   net_http.StatusOK,
+			expectedStatus: "UP",
+		},
+		{
+			name:           "non-critical unhealthy",
+			checks:         map[string]HealthCheck{"blob_storage": unhealthy, "jira": slow},
+			critical:       []string{"database"},
+			expectedCode:   net_http.StatusOK,
+			expectedStatus: "DEGRADED",
+			expectedDown:   []string{"blob_storage", "jira"},
+		},
+		{
+			name:           "critical unhealthy",
+			checks:         map[string]HealthCheck{"blob_storage": unhealthy},
+			critical:       []string{"database", "blob_storage"},
+			expectedCode:   net_http.StatusServiceUnavailable,
+			expectedStatus: "DOWN",
+			expectedDown:   []string{"blob_storage"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			cfg := kiteConf.HealthConfig{CriticalComponents: tt.critical, CheckTimeout: 10 * time.Millisecond}
+			router.GET("/api/v1/health", NewHealthHandler(db, tt.checks, nil, cfg, logger))
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, net_httptest.NewRequest(net_http.MethodGet, "/api/v1/health", nil))
+
+			if w.Code != tt.expectedCode {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedCode, w.Code, w.Body.String())
+			}
+			var response HealthStatus
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, response.Status)
+			}
+			for name := range tt.checks {
+				component, ok := response.Components[name]
+				if !ok {
+					t.Fatalf("Expected the component %s, got %v", name, response.Components)
+				}
+				down := component.Status == "DOWN"
+				if expectedDown := slices.Contains(tt.expectedDown, name); down != expectedDown {
+					t.Errorf("Expected %s down to be %v, got %+v", name, expectedDown, component)
+				}
+				if component.Critical != slices.Contains(tt.critical, name) {
+					t.Errorf("Expected %s critical to be %v, got %+v", name, !component.Critical, component)
+				}
+			}
+			if database := response.Components["database"]; database.Status != "UP" || !database.Critical || database.LatencySeconds <= 0 {
+				t.Errorf("Expected the database to be up and critical with its latency, got %+v", database)
+			}
+		})
+	}
+}
+
+func TestHealthHandler_Starting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	checker := startup.NewChecker([]startup.Check{
+		{Name: "schema", Critical: true, Run: func(ctx context.Context) error { return errors.New("pending migrations") }},
+	}, time.Hour, time.Second, logger)
+	router := gin.New()
+	cfg := kiteConf.HealthConfig{CriticalComponents: []string{"database"}, CheckTimeout: time.Second}
+	router.GET("/api/v1/health", NewHealthHandler(testhelpers.SetupTestDB(t), nil, checker, cfg, logger))
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, net_httptest.NewRequest(net_http.MethodGet, "/api/v1/health", nil))
+	if w.Code != net_http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503 while starting, got %d: %s", w.Code, w.Body.String())
+	}
+	var response HealthStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Status != "DOWN" || response.Components["startup"].Status != "DOWN" {
+		t.Errorf("Expected the startup component to be down, got %+v", response)
+	}
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+type IssueActivityHandler struct {
+	activityService services.IssueActivityServiceInterface
+	logger          *logrus.Logger
+}
+
+func NewIssueActivityHandler(activityService services.IssueActivityServiceInterface, logger *logrus.Logger) *IssueActivityHandler {
+	return &IssueActivityHandler{
+		activityService: activityService,
+		logger:          logger,
+	}
+}
+
+// GetActivity handles GET /issues/:id/activity, returning the activity feed
+// of an issue the oldest entry first
+func (h *IssueActivityHandler) GetActivity(c *gin.Context) {
+	id := c.Param("id")
+
+	activity, err := h.activityService.GetActivity(c.Request.Context(), id, c.Query("namespace"))
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to fetch issue activity")
+			respondWithServerError(c, err, "Failed to fetch issue activity")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": activity, "total": len(activity)})
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/sirupsen/logrus"
+)
+
+func TestIssueActivityHandler_GetActivity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	detectedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	handler := NewIssueActivityHandler(&MockIssueActivityService{
+		namespace: "team-alpha",
+		activity: map[string][]dto.ActivityEntry{"issue-1": {
+			{Type: dto.ActivityDetected, Timestamp: detectedAt, Summary: "Issue detected"},
+			{Type: dto.ActivityTriaged, Timestamp: detectedAt, Actor: "timeouts", Summary: "Triaged by rule timeouts"},
+		}},
+	}, logger)
+	router := gin.New()
+	router.GET("/api/v1/issues/:id/activity", handler.GetActivity)
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "activity of the issue", path: "/api/v1/issues/issue-1/activity?namespace=team-alpha", expectedStatus: net_http.StatusOK},
+		{name: "unknown issue", path: "/api/v1/issues/issue-2/activity", expectedStatus: net_http.StatusNotFound},
+		{name: "other namespace", path: "/api/v1/issues/issue-1/activity?namespace=team-beta", expectedStatus: net_http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := net_http.NewRequest("GET", tc.path, nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+			var response struct {
+				Data  []dto.ActivityEntry `json:"data"`
+				Total int                 `json:"total"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Total != 2 || response.Data[1].Type != dto.ActivityTriaged || response.Data[1].Actor != "timeouts" {
+				t.Errorf("Unexpected activity %+v", response)
+			}
+		})
+	}
+}
@@ -1,15 +1,28 @@
 package http
 
 import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/middleware"
 	"github.com/konflux-ci/kite/internal/models"
 	"github.com/konflux-ci/kite/internal/repository"
 	"github.com/konflux-ci/kite/internal/services"
@@ -18,62 +31,248 @@ import (
 
 type IssueHandler struct {
 	issueService services.IssueServiceInterface
+	limits       config.LimitsConfig
+	resolution   config.ResolutionConfig
 	logger       *logrus.Logger
 }
 
-func NewIssueHandler(issueService services.IssueServiceInterface, logger *logrus.Logger) *IssueHandler {
+func NewIssueHandler(issueService services.IssueServiceInterface, limits config.LimitsConfig, resolution config.ResolutionConfig, logger *logrus.Logger) *IssueHandler {
 	return &IssueHandler{
 		issueService: issueService,
+		limits:       limits,
+		resolution:   resolution,
 		logger:       logger,
 	}
 }
 
 // GetIssues handles GET /issues
 func (h *IssueHandler) GetIssues(c *gin.Context) {
-	// Esxtract query params
-	filters := repository.IssueQueryFilters{
-		Namespace:    c.Query("namespace"),
-		ResourceType: c.Query("resourceType"),
-		ResourceName: c.Query("resourceName"),
-		Search:       c.Query("search"),
+	filters, err := parseIssueQueryFilters(c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filters.Fields, err = parseIssueFields(c.Query("fields"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if c.NegotiateFormat(gin.MIMEJSON, mimeNDJSON) == mimeNDJSON {
+		h.streamIssues(c, filters)
+		return
+	}
+	if includeTotal := c.Query("includeTotal"); includeTotal != "" {
+		include, err := strconv.ParseBool(includeTotal)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid includeTotal %q, must be true or false", includeTotal)})
+			return
+		}
+		filters.SkipTotal = !include
 	}
 
-	// Parse optional enum params
-	if severity := c.Query("severity"); severity != "" {
-		// Convert to custom type, then assign
-		sev := models.Severity(severity)
-		filters.Severity = &sev
+	result, err := h.issueService.FindIssues(c.Request.Context(), filters)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("failed to fetch issues")
+		respondWithServerError(c, err, "Failed to fetch issues")
+		return
 	}
-	if issueType := c.Query("issueType"); issueType != "" {
-		it := models.IssueType(issueType)
-		filters.IssueType = &it
+
+	setPaginationLinks(c, result)
+	if len(filters.Fields) == 0 {
+		c.JSON(http.StatusOK, result)
+		return
 	}
-	if state := c.Query("state"); state != "" {
-		st := models.IssueState(state)
-		filters.State = &st
+
+	projected, err := projectIssues(result.Data, filters.Fields)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("failed to project issues")
+		respondWithServerError(c, err, "Failed to fetch issues")
+		return
 	}
+	c.JSON(http.StatusOK, dto.ProjectedIssueResponse{IssueResponse: result, Data: projected})
+}
 
-	// Parse pagination parameters
-	if limit := c.Query("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			filters.Limit = l
+// mimeNDJSON is the content type of newline delimited JSON, one JSON value per line
+const mimeNDJSON = "application/x-ndjson"
+
+// streamWriteTimeout is how long writing a batch of streamed issues may take
+// before the client is considered gone
+const streamWriteTimeout = time.Minute
+
+// streamIssues streams all the issues matching filters as NDJSON, one issue per
+// line, for GET /issues requests accepting application/x-ndjson, e.g. exports.
+// Issues are written batch by batch as they're loaded, compressed with gzip when
+// the client accepts it. The stream isn't bounded by the timeout of the route,
+// only each of its queries and writes are. Failures end the stream with an
+// {"error": ...} line, the status having been sent with the first issues.
+func (h *IssueHandler) streamIssues(c *gin.Context, filters repository.IssueQueryFilters) {
+	if filters.SortBy != "" && filters.SortBy != repository.SortByDetectedAt {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid sort %q, streamed issues are sorted by %s", filters.SortBy, repository.SortByDetectedAt)})
+		return
+	}
+
+	c.Header("Content-Type", mimeNDJSON)
+	c.Header("Vary", "Accept, Accept-Encoding")
+	var w io.Writer = c.Writer
+	var gz *gzip.Writer
+	if acceptsGzip(c.GetHeader("Accept-Encoding")) {
+		c.Header("Content-Encoding", "gzip")
+		gz = gzip.NewWriter(c.Writer)
+		defer gz.Close()
+		w = gz
+	}
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	controller := http.NewResponseController(c.Writer)
+	streamed := 0
+	err := h.issueService.StreamIssues(middleware.UntimedContext(c), filters, func(issues []models.Issue) error {
+		if err := controller.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
 		}
+		var projected []map[string]json.RawMessage
+		if len(filters.Fields) > 0 {
+			var err error
+			if projected, err = projectIssues(issues, filters.Fields); err != nil {
+				return err
+			}
+		}
+		for n := range issues {
+			var row any = issues[n]
+			if projected != nil {
+				row = projected[n]
+			}
+			if err := encoder.Encode(row); err != nil {
+				return err
+			}
+		}
+		streamed += len(issues)
+		if gz != nil {
+			if err := gz.Flush(); err != nil {
+				return err
+			}
+		}
+		return controller.Flush()
+	})
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("streamed", streamed).Error("failed to stream issues")
+		_ = encoder.Encode(gin.H{"error": "Failed to stream issues"})
 	}
-	if offset := c.Query("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			filters.Offset = o
+}
+
+// acceptsGzip tells whether an Accept-Encoding header accepts gzip, unless
+// with a quality of 0
+func acceptsGzip(acceptEncoding string) bool {
+	for _, encoding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			continue
 		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			quality, err := strconv.ParseFloat(q, 64)
+			return err == nil && quality > 0
+		}
+		return true
 	}
+	return false
+}
 
-	// Default limit
-	if filters.Limit == 0 {
-		filters.Limit = 50
+// parseIssueFields parses the comma separated fields of issues selected by
+// GET /issues, see repository.IssueFields. The ID is always selected.
+func parseIssueFields(param string) ([]string, error) {
+	if param == "" {
+		return nil, nil
+	}
+	fields := []string{"id"}
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := repository.IssueFields[field]; !ok {
+			valid := slices.Sorted(maps.Keys(repository.IssueFields))
+			return nil, fmt.Errorf("invalid field %q, must be among: %s", field, strings.Join(valid, ", "))
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
 	}
+	return fields, nil
+}
 
-	result, err := h.issueService.FindIssues(c.Request.Context(), filters)
+// projectIssues keeps the selected fields of issues, as named in JSON. The
+// other fields aren't loaded from the database, and would be empty.
+func projectIssues(issues []models.Issue, fields []string) ([]map[string]json.RawMessage, error) {
+	projected := make([]map[string]json.RawMessage, len(issues))
+	for i, issue := range issues {
+		data, err := json.Marshal(issue)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		projected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			// Fields omitted when empty stay omitted
+			if value, ok := all[field]; ok {
+				projected[i][field] = value
+			}
+		}
+	}
+	return projected, nil
+}
+
+// setPaginationLinks sets the Link header (RFC 8288) of a page of issues,
+// linking to the first, previous, next and last pages. The links keep the
+// query parameters of the request, only changing the offset.
+func setPaginationLinks(c *gin.Context, page *dto.IssueResponse) {
+	if page.Limit <= 0 {
+		return
+	}
+
+	pageURL := func(offset int) string {
+		query := c.Request.URL.Query()
+		query.Set("limit", strconv.Itoa(page.Limit))
+		query.Set("offset", strconv.Itoa(offset))
+		return c.Request.URL.Path + "?" + query.Encode()
+	}
+
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, pageURL(0))}
+	if page.Offset > 0 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(max(page.Offset-page.Limit, 0))))
+	}
+	if page.HasNextPage && page.NextOffset != nil {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(*page.NextOffset)))
+	}
+	// The last page is only known when the issues are counted
+	if page.Total != nil && *page.Total > 0 {
+		last := int((*page.Total - 1) / int64(page.Limit) * int64(page.Limit))
+		links = append(links, fmt.Sprintf(`<%s>; rel="last"`, pageURL(last)))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// GetIssuesGrouped handles GET /issues/grouped
+func (h *IssueHandler) GetIssuesGrouped(c *gin.Context) {
+	query := c.Request.URL.Query()
+	groupBy := query.Get("groupBy")
+	if groupBy == "" {
+		groupBy = services.GroupByResource
+	}
+	if !slices.Contains(services.ValidGroupBy, groupBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid groupBy value, must be one of: resource, severity, type"})
+		return
+	}
+
+	filters, err := parseIssueQueryFilters(query)
 	if err != nil {
-		h.logger.WithError(err).Error("failed to fetch issues")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch issues"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.issueService.FindIssuesGrouped(c.Request.Context(), filters, groupBy)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("failed to fetch grouped issues")
+		respondWithServerError(c, err, "Failed to fetch issues")
 		return
 	}
 
@@ -87,8 +286,8 @@ func (h *IssueHandler) GetIssue(c *gin.Context) {
 
 	issue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch issue"})
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to fetch issue")
+		respondWithServerError(c, err, "failed to fetch issue")
 		return
 	}
 
@@ -105,45 +304,125 @@ func (h *IssueHandler) GetIssue(c *gin.Context) {
 	c.JSON(http.StatusOK, issue)
 }
 
-// CreateIssue handles POST /issues
+// Number of similar issues returned by default, and at most
+const (
+	defaultSimilarIssuesLimit = 10
+	maxSimilarIssuesLimit     = 50
+)
+
+// GetSimilarIssues handles GET /issues/:id/similar, ranking the issues of the
+// namespace of the issue similar to it, e.g. duplicates of other components
+func (h *IssueHandler) GetSimilarIssues(c *gin.Context) {
+	id := c.Param("id")
+	namespace := c.Query("namespace")
+
+	limit := defaultSimilarIssuesLimit
+	if value := c.Query("limit"); value != "" {
+		l, err := strconv.Atoi(value)
+		if err != nil || l < 1 || l > maxSimilarIssuesLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid limit, must be between 1 and %d", maxSimilarIssuesLimit)})
+			return
+		}
+		limit = l
+	}
+
+	issue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to fetch issue")
+		respondWithServerError(c, err, "Failed to find similar issues")
+		return
+	}
+	if issue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	similar, err := h.issueService.FindSimilarIssues(c.Request.Context(), *issue, limit)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to find similar issues")
+		respondWithServerError(c, err, "Failed to find similar issues")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": similar, "total": len(similar)})
+}
+
+// CreateIssue handles POST /issues. With ?dryRun=true, nothing is saved and
+// the response tells what would be done, see services.IssueService.DryRunIssue.
 func (h *IssueHandler) CreateIssue(c *gin.Context) {
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
 	var req dto.CreateIssueRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+	if !bindRequest(c, &req, func() dto.ValidationErrors { return validateCreateIssueRequest(h.limits, req) }) {
+		return
+	}
+	if !checkBodyNamespace(c, req.Namespace) {
 		return
 	}
 
-	if err := h.validateCreateIssueRequest(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+	if dryRun {
+		result, err := h.issueService.DryRunIssue(c.Request.Context(), req, models.IssueSourceAPI)
+		if err != nil {
+			requestLogger(c, h.logger).WithError(err).Error("Failed to dry run issue creation")
+			respondWithServerError(c, err, "Failed to create issue")
+			return
+		}
+		c.JSON(http.StatusOK, result)
 		return
 	}
 
 	issue, err := h.issueService.CreateIssue(c.Request.Context(), req)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to create issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue"})
+		requestLogger(c, h.logger).WithError(err).Error("Failed to create issue")
+		respondWithServerError(c, err, "Failed to create issue")
 		return
 	}
 
 	c.JSON(http.StatusCreated, issue)
 }
 
+// CheckDuplicate handles POST /issues/check-duplicate, telling whether
+// creating the issue of the body would merge it into an existing issue
+func (h *IssueHandler) CheckDuplicate(c *gin.Context) {
+	var req dto.CreateIssueRequest
+	if !bindRequest(c, &req, func() dto.ValidationErrors { return validateCreateIssueRequest(h.limits, req) }) {
+		return
+	}
+	if !checkBodyNamespace(c, req.Namespace) {
+		return
+	}
+
+	result, err := h.issueService.CheckDuplicateIssue(c.Request.Context(), req)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("Failed to check for duplicate issues")
+		respondWithServerError(c, err, "Failed to check for duplicate issues")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // UpdateIssue handles PUT /issues/:id
 func (h *IssueHandler) UpdateIssue(c *gin.Context) {
 	id := c.Param("id")
 	namespace := c.Query("namespace")
 
 	var req dto.UpdateIssueRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+	if !bindRequest(c, &req, func() dto.ValidationErrors { return validateUpdateIssueRequest(h.limits, req) }) {
 		return
 	}
 
 	// Check if issue exists and verify namespace exists
 	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to find issue for update")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update issue"})
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to find issue for update")
+		respondWithServerError(c, err, "Failed to update issue")
 		return
 	}
 	if existingIssue == nil {
@@ -157,10 +436,17 @@ func (h *IssueHandler) UpdateIssue(c *gin.Context) {
 		return
 	}
 
+	if req.State == models.IssueStateResolved && existingIssue.State != models.IssueStateResolved && h.resolutionBlocked(c, existingIssue) {
+		return
+	}
+
 	updatedIssue, err := h.issueService.UpdateIssue(c.Request.Context(), id, req)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to update issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update issue"})
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to update issue")
+		respondWithServerError(c, err, "Failed to update issue")
 		return
 	}
 
@@ -174,8 +460,8 @@ func (h *IssueHandler) DeleteIssue(c *gin.Context) {
 
 	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to find issue for deletion")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue"})
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to find issue for deletion")
+		respondWithServerError(c, err, "Failed to delete issue")
 		return
 	}
 	if existingIssue == nil {
@@ -190,23 +476,36 @@ func (h *IssueHandler) DeleteIssue(c *gin.Context) {
 	}
 
 	if err := h.issueService.DeleteIssue(c.Request.Context(), id); err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to delete issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue"})
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to delete issue")
+		respondWithServerError(c, err, "Failed to delete issue")
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// ResolveIssue handles POST /issues/:id/resolve
+// ResolveIssue handles POST /issues/:id/resolve. The body, a dto.Resolution
+// explaining why and by whom the issue is resolved, is optional.
 func (h *IssueHandler) ResolveIssue(c *gin.Context) {
 	id := c.Param("id")
 	namespace := c.Query("namespace")
 
+	var resolution dto.Resolution
+	payload, ok := optionalBody(c)
+	if !ok {
+		return
+	}
+	if !bindJSON(c, payload, &resolution, func() dto.ValidationErrors { return validateResolution(h.limits, "reason", resolution) }) {
+		return
+	}
+
 	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("failed to find issue for resolution")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve issue"})
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("failed to find issue for resolution")
+		respondWithServerError(c, err, "failed to resolve issue")
 		return
 	}
 
@@ -221,99 +520,553 @@ func (h *IssueHandler) ResolveIssue(c *gin.Context) {
 		return
 	}
 
+	if h.resolutionBlocked(c, existingIssue) {
+		return
+	}
+
 	now := time.Now()
 	state := models.IssueStateResolved
 	req := dto.UpdateIssueRequest{
-		State:      state,
-		ResolvedAt: now,
+		State:            state,
+		ResolvedAt:       now,
+		ResolutionReason: resolution.Reason,
+		ResolvedBy:       resolution.ResolvedBy,
 	}
 
 	updatedIssue, err := h.issueService.UpdateIssue(c.Request.Context(), id, req)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to mark issue resolved")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve issue"})
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to mark issue resolved")
+		respondWithServerError(c, err, "Failed to resolve issue")
 		return
 	}
 
 	c.JSON(http.StatusOK, updatedIssue)
 }
 
-// AddRelatedIssue handles POST /issues/:id/related
+// AcknowledgeIssue handles POST /issues/:id/ack. The body, a
+// dto.Acknowledgement, is optional for requests identifying their user.
+func (h *IssueHandler) AcknowledgeIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	var ack dto.Acknowledgement
+	payload, ok := optionalBody(c)
+	if !ok {
+		return
+	}
+	user := middleware.User(c)
+	if !bindJSON(c, payload, &ack, func() dto.ValidationErrors { return validateAcknowledgement(h.limits, ack, user) }) {
+		return
+	}
+
+	issue, err := h.issueService.AcknowledgeIssue(c.Request.Context(), id, c.Query("namespace"), cmp.Or(ack.AcknowledgedBy, user))
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to acknowledge issue")
+			respondWithServerError(c, err, "Failed to acknowledge issue")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, issue)
+}
+
+// SnoozeIssue handles POST /issues/:id/snooze, snoozing an open issue until
+// the time of the dto.Snooze body
+func (h *IssueHandler) SnoozeIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	var snooze dto.Snooze
+	user := middleware.User(c)
+	if !bindRequest(c, &snooze, func() dto.ValidationErrors { return validateSnooze(h.limits, snooze, user) }) {
+		return
+	}
+
+	until := snooze.Until.UTC()
+	issue, err := h.issueService.SnoozeIssue(c.Request.Context(), id, c.Query("namespace"), &until, cmp.Or(snooze.SnoozedBy, user))
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to snooze issue")
+			respondWithServerError(c, err, "Failed to snooze issue")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, issue)
+}
+
+// WakeIssue handles DELETE /issues/:id/snooze, ending the snooze of an issue
+func (h *IssueHandler) WakeIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	issue, err := h.issueService.SnoozeIssue(c.Request.Context(), id, c.Query("namespace"), nil, "")
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to wake issue")
+			respondWithServerError(c, err, "Failed to wake issue")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, issue)
+}
+
+// optionalBody reads the body of requests whose body is optional, an empty
+// JSON object when there's none. Unreadable bodies get a 400.
+func optionalBody(c *gin.Context) ([]byte, bool) {
+	payload := []byte("{}")
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		body, err := c.GetRawData()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+			return nil, false
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			payload = body
+		}
+	}
+	return payload, true
+}
+
+// parseDryRun checks whether a request asks for a dry run with ?dryRun=true,
+// validating and deduplicating what it reports without saving it. Values
+// that aren't booleans get a 400.
+func parseDryRun(c *gin.Context) (bool, bool) {
+	param := c.Query("dryRun")
+	if param == "" {
+		return false, true
+	}
+	dryRun, err := strconv.ParseBool(param)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid dryRun %q, must be true or false", param)})
+		return false, false
+	}
+	return dryRun, true
+}
+
+// respondWithTransitionError responds with 409 Conflict and the states the
+// issue may move to when err is a services.TransitionError
+func respondWithTransitionError(c *gin.Context, err error) bool {
+	var transitionErr *services.TransitionError
+	if !errors.As(err, &transitionErr) {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   "State transition not allowed",
+		"details": transitionErr.Error(),
+		"allowed": transitionErr.Allowed,
+	})
+	return true
+}
+
+// resolutionBlocked checks whether an issue can't be resolved manually because
+// issues it causes are still active, in namespaces blocking such resolutions.
+// Blocked resolutions are answered with 409 Conflict and the IDs of the
+// active issues, failures with an error response.
+func (h *IssueHandler) resolutionBlocked(c *gin.Context, issue *models.Issue) bool {
+	if !h.resolution.BlocksResolution(issue.Namespace) {
+		return false
+	}
+
+	blockingIDs, err := h.issueService.FindActiveEffects(c.Request.Context(), issue.ID)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", issue.ID).Error("Failed to find the issues caused by issue")
+		respondWithServerError(c, err, "Failed to resolve issue")
+		return true
+	}
+	if len(blockingIDs) == 0 {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, gin.H{
+		"error":          "Issue causes active issues, resolve them first",
+		"blockingIssues": blockingIDs,
+	})
+	return true
+}
+
+// AddRelatedIssue handles POST /issues/:id/related. The optional type of the
+// relationship defaults to related, caused-by relates the issue to the
+// issue causing it. Both issues must be in the namespace of the query, if any.
 func (h *IssueHandler) AddRelatedIssue(c *gin.Context) {
 	id := c.Param("id")
+	if !h.checkIssueNamespace(c, id) {
+		return
+	}
 
 	var req struct {
-		RelatedID string `json:"relatedId" binding:"required"`
+		RelatedID string              `json:"relatedId" binding:"required"`
+		Type      models.RelationType `json:"type"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing relatdId field"})
+	validate := func() dto.ValidationErrors {
+		if req.RelatedID == "" || middleware.IsValidID(req.RelatedID) {
+			return nil
+		}
+		return dto.ValidationErrors{{
+			Field:      "relatedId",
+			Value:      req.RelatedID,
+			Constraint: "uuid",
+			Message:    fmt.Sprintf("relatedId must be a UUID, got %q", req.RelatedID),
+		}}
+	}
+	if !bindRequest(c, &req, validate) {
+		return
+	}
+	// The related issue must be in the namespace too, not to reveal the
+	// issues of other tenants in the relationships of the issue
+	if !h.checkIssueNamespace(c, req.RelatedID) {
 		return
 	}
 
-	if err := h.issueService.AddRelatedIssue(c.Request.Context(), id, req.RelatedID); err != nil {
-		if err.Error() == "one or both issues not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		if err.Error() == "relationship already exists" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	if req.Type == "" {
+		req.Type = models.RelationTypeRelated
+	}
+	if req.Type != models.RelationTypeRelated && req.Type != models.RelationTypeCausedBy {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid type value, expected related or caused-by"})
+		return
+	}
+
+	if err := h.issueService.AddRelatedIssue(c.Request.Context(), id, req.RelatedID, req.Type); err != nil {
+		if respondWithClientError(c, err) {
 			return
 		}
-		h.logger.WithError(err).Error("Failed to add related issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue relationship"})
+		requestLogger(c, h.logger).WithError(err).Error("Failed to add related issue")
+		respondWithServerError(c, err, "Failed to create issue relationship")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Relationship created"})
 }
 
+// GetRelatedIssues handles GET /issues/:id/related, listing the relationships
+// of an issue in both directions, see services.IssueService.FindRelatedIssues
+func (h *IssueHandler) GetRelatedIssues(c *gin.Context) {
+	id := c.Param("id")
+
+	related, err := h.issueService.FindRelatedIssues(c.Request.Context(), id, c.Query("namespace"))
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to fetch related issues")
+			respondWithServerError(c, err, "Failed to fetch related issues")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": related, "total": len(related)})
+}
+
 // RemoveRelatedIssue handles DELETE /issues/:id/related/:relatedId
 func (h *IssueHandler) RemoveRelatedIssue(c *gin.Context) {
 	id := c.Param("id")
+	if !h.checkIssueNamespace(c, id) {
+		return
+	}
 	relatedID := c.Param("relatedId")
 
 	if err := h.issueService.RemoveRelatedIssue(c.Request.Context(), id, relatedID); err != nil {
-		if err.Error() == "relationship not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		if respondWithClientError(c, err) {
 			return
 		}
-		h.logger.WithError(err).Error("Failed to remove related issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue relationship"})
+		requestLogger(c, h.logger).WithError(err).Error("Failed to remove related issue")
+		respondWithServerError(c, err, "Failed to delete issue relationship")
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// Helper function for validation issue creation
-func (h *IssueHandler) validateCreateIssueRequest(req dto.CreateIssueRequest) error {
-	// Validate severity
-	validSeverities := []models.Severity{
-		models.SeverityInfo, models.SeverityMinor,
-		models.SeverityMajor, models.SeverityCritical,
+// AddExternalRef handles POST /issues/:id/external-refs
+func (h *IssueHandler) AddExternalRef(c *gin.Context) {
+	id := c.Param("id")
+	if !h.checkIssueNamespace(c, id) {
+		return
 	}
 
-	if !slices.Contains(validSeverities, req.Severity) {
-		return errors.New("invalid severity value")
+	var req dto.CreateExternalRefRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
 	}
 
-	// Validate issue type
-	validTypes := []models.IssueType{
-		models.IssueTypeBuild, models.IssueTypeTest,
-		models.IssueTypeRelease, models.IssueTypeDependency,
-		models.IssueTypePipeline,
+	ref, err := h.issueService.AddExternalRef(c.Request.Context(), id, req)
+	if err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).Error("Failed to add external reference")
+		respondWithServerError(c, err, "Failed to add external reference")
+		return
 	}
-	if !slices.Contains(validTypes, req.IssueType) {
-		return errors.New("invalid issueType value")
+
+	c.JSON(http.StatusCreated, ref)
+}
+
+// RemoveExternalRef handles DELETE /issues/:id/external-refs/:refId
+func (h *IssueHandler) RemoveExternalRef(c *gin.Context) {
+	id := c.Param("id")
+	if !h.checkIssueNamespace(c, id) {
+		return
 	}
+	refID := c.Param("refId")
 
-	// validate state if provided
-	if req.State != "" {
-		validStates := []models.IssueState{models.IssueStateActive, models.IssueStateResolved}
-		if !slices.Contains(validStates, req.State) {
-			return errors.New("invalid state value")
+	if err := h.issueService.RemoveExternalRef(c.Request.Context(), id, refID); err != nil {
+		if respondWithClientError(c, err) {
+			return
 		}
+		requestLogger(c, h.logger).WithError(err).Error("Failed to remove external reference")
+		respondWithServerError(c, err, "Failed to remove external reference")
+		return
 	}
 
-	return nil
+	c.Status(http.StatusNoContent)
+}
+
+// checkIssueNamespace checks the issue of a request is in the namespace of its
+// query, if any, as the handlers of the issues do, responding with a 404 or a
+// 403 otherwise. It returns whether the request may be served.
+func (h *IssueHandler) checkIssueNamespace(c *gin.Context, id string) bool {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return true
+	}
+	issue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to find issue")
+		respondWithServerError(c, err, "Failed to find issue")
+		return false
+	}
+	if issue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return false
+	}
+	if issue.Namespace != namespace {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return false
+	}
+	return true
+}
+
+// checkBodyNamespace checks the namespace of the body of a request is the
+// namespace of its query, if any, which is the namespace the request was
+// granted, responding with a 403 otherwise. It returns whether the request
+// may be served.
+func checkBodyNamespace(c *gin.Context, namespace string) bool {
+	if query := c.Query("namespace"); query != "" && query != namespace {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return false
+	}
+	return true
+}
+
+// parseIssueQueryFilters extracts the issue filters and pagination from the query parameters.
+// Labels are passed as repeated "label=key=value" parameters. The parameters
+// are read from the URL rather than with c.Query, which caches them before
+// ExpandView adds the filters of views.
+func parseIssueQueryFilters(query url.Values) (repository.IssueQueryFilters, error) {
+	// Esxtract query params
+	filters := repository.IssueQueryFilters{
+		Namespace:         query.Get("namespace"),
+		ResourceType:      query.Get("resourceType"),
+		ResourceName:      query.Get("resourceName"),
+		ResourceNamespace: query.Get("resourceNamespace"),
+		Search:            query.Get("search"),
+		Assignee:          query.Get("assignee"),
+		Fingerprint:       query.Get("fingerprint"),
+		SortBy:            query.Get("sort"),
+	}
+
+	if filters.SortBy != "" && !slices.Contains(repository.ValidSortBy, filters.SortBy) {
+		return filters, fmt.Errorf("invalid sort %q, must be one of: %s", filters.SortBy, strings.Join(repository.ValidSortBy, ", "))
+	}
+
+	for _, label := range query["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return filters, fmt.Errorf("invalid label %q, expected key=value", label)
+		}
+		if filters.Labels == nil {
+			filters.Labels = make(map[string]string)
+		}
+		filters.Labels[key] = value
+	}
+
+	if acknowledged := query.Get("acknowledged"); acknowledged != "" {
+		ack, err := strconv.ParseBool(acknowledged)
+		if err != nil {
+			return filters, fmt.Errorf("invalid acknowledged %q, must be true or false", acknowledged)
+		}
+		filters.Acknowledged = &ack
+	}
+
+	if snoozed := query.Get("snoozed"); snoozed != "" {
+		snooze, err := strconv.ParseBool(snoozed)
+		if err != nil {
+			return filters, fmt.Errorf("invalid snoozed %q, must be true or false", snoozed)
+		}
+		filters.Snoozed = &snooze
+	}
+
+	if hasExternalRef := query.Get("hasExternalRef"); hasExternalRef != "" {
+		has, err := strconv.ParseBool(hasExternalRef)
+		if err != nil {
+			return filters, fmt.Errorf("invalid hasExternalRef %q, must be true or false", hasExternalRef)
+		}
+		filters.HasExternalRef = &has
+	}
+
+	// Parse optional date ranges, from inclusive to exclusive
+	ranges := []struct {
+		afterParam, beforeParam string
+		since, before           **time.Time
+	}{
+		{"detectedAfter", "detectedBefore", &filters.DetectedSince, &filters.DetectedBefore},
+		{"resolvedAfter", "resolvedBefore", &filters.ResolvedSince, &filters.ResolvedBefore},
+	}
+	for _, r := range ranges {
+		var err error
+		if *r.since, err = parseTimeParam(query, r.afterParam); err != nil {
+			return filters, err
+		}
+		if *r.before, err = parseTimeParam(query, r.beforeParam); err != nil {
+			return filters, err
+		}
+		if *r.since != nil && *r.before != nil && !(*r.since).Before(**r.before) {
+			return filters, fmt.Errorf("invalid %s, must be before %s", r.afterParam, r.beforeParam)
+		}
+	}
+
+	// Parse optional enum params, rejecting invalid values
+	if severity := query.Get("severity"); severity != "" {
+		// Convert to custom type, then assign
+		sev := models.Severity(severity)
+		if !sev.Valid() {
+			return filters, fmt.Errorf("invalid severity %q, must be one of: %s", severity, joinEnum(models.Severities))
+		}
+		filters.Severity = &sev
+	}
+	if priority := query.Get("priority"); priority != "" {
+		p := models.Priority(priority)
+		if !p.Valid() {
+			return filters, fmt.Errorf("invalid priority %q, must be one of: %s", priority, joinEnum(models.Priorities))
+		}
+		filters.Priority = &p
+	}
+	if issueType := query.Get("issueType"); issueType != "" {
+		it := models.IssueType(issueType)
+		if !it.Valid() {
+			return filters, fmt.Errorf("invalid issueType %q, must be one of: %s", issueType, joinEnum(models.IssueTypes))
+		}
+		filters.IssueType = &it
+	}
+	if state := query.Get("state"); state != "" {
+		st := models.IssueState(state)
+		if !st.Valid() {
+			return filters, fmt.Errorf("invalid state %q, must be one of: %s", state, joinEnum(models.IssueStates))
+		}
+		filters.State = &st
+	}
+
+	// Parse pagination parameters
+	if limit := query.Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+	if offset := query.Get("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	// Default limit
+	if filters.Limit == 0 {
+		filters.Limit = 50
+	}
+
+	return filters, nil
+}
+
+// parseTimeParam parses a query parameter holding an RFC 3339 timestamp, e.g.
+// 2025-01-31T12:00:00Z, or a date, e.g. 2025-01-31 for its midnight UTC. It
+// returns nil when the parameter is omitted.
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid %s %q, must be an RFC 3339 timestamp or a date, e.g. 2025-01-31T12:00:00Z or 2025-01-31", name, value)
+}
+
+// joinEnum joins the values of an enum of the models with commas
+func joinEnum[E ~string](values []E) string {
+	names := make([]string, len(values))
+	for i, value := range values {
+		names[i] = string(value)
+	}
+	return strings.Join(names, ", ")
+}
+
+// statusClientClosedRequest is the non-standard status logged for requests
+// whose client disconnected before a response was written
+const statusClientClosedRequest = 499
+
+// requestLogger returns the logger of a request, logging the fields
+// identifying it, see logging.FromContext
+func requestLogger(c *gin.Context, logger *logrus.Logger) *logrus.Entry {
+	return logging.FromContext(c.Request.Context(), logger)
+}
+
+// errorStatuses are the statuses of the responses to requests failing with
+// errors of a kind, see repository.ErrNotFound
+var errorStatuses = []struct {
+	kind   error
+	status int
+}{
+	{repository.ErrNotFound, http.StatusNotFound},
+	{repository.ErrConflict, http.StatusConflict},
+	{repository.ErrForbidden, http.StatusForbidden},
+}
+
+// respondWithClientError responds to requests failing with errors of a kind
+// with the status of the kind and the message of the error, and returns
+// whether it did. Transition errors list the states the issue may move to,
+// see respondWithTransitionError. The other errors are left to the caller,
+// logging them before responding with respondWithServerError.
+func respondWithClientError(c *gin.Context, err error) bool {
+	if respondWithTransitionError(c, err) {
+		return true
+	}
+	for _, kind := range errorStatuses {
+		if errors.Is(err, kind.kind) {
+			message := err.Error()
+			c.JSON(kind.status, gin.H{"error": strings.ToUpper(message[:1]) + message[1:]})
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithServerError responds to a request that failed on the server
+// side. Requests that timed out get a 503, and database operations that timed
+// out a 504, so clients can tell them apart from other failures, which get a
+// 500 with message.
+func respondWithServerError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) && middleware.TimedOut(c):
+		middleware.RespondTimedOut(c)
+	case errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Timed out waiting for the database"})
+	case errors.Is(err, context.Canceled):
+		// Nobody is left to read the response
+		c.AbortWithStatus(statusClientClosedRequest)
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
 }
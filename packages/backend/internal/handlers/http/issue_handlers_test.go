@@ -2,15 +2,29 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	net_http "net/http"
 	net_httptest "net/http/httptest"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/konflux-ci/kite/internal/testhelpers"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,9 +32,11 @@ import (
 func setupTestIssueHandler(mockService *MockIssueService) *IssueHandler {
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
-	return NewIssueHandler(mockService, logger)
+	return NewIssueHandler(mockService, config.GetLimitsConfig(), config.ResolutionConfig{}, logger)
 }
 
+func int64Ptr(v int64) *int64 { return &v }
+
 // setupTestIssueRouter creates a test router with HTTP tests
 func setupTestIssueRouter(handler *IssueHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
@@ -31,11 +47,22 @@ func setupTestIssueRouter(handler *IssueHandler) *gin.Engine {
 	v1 := router.Group("/api/v1")
 	{
 		v1.GET("/issues", handler.GetIssues)
+		v1.GET("/issues/grouped", handler.GetIssuesGrouped)
 		v1.POST("/issues", handler.CreateIssue)
+		v1.POST("/issues/check-duplicate", handler.CheckDuplicate)
 		v1.GET("/issues/:id", handler.GetIssue)
+		v1.GET("/issues/:id/similar", handler.GetSimilarIssues)
 		v1.PUT("/issues/:id", handler.UpdateIssue)
 		v1.DELETE("/issues/:id", handler.DeleteIssue)
 		v1.POST("/issues/:id/resolve", handler.ResolveIssue)
+		v1.POST("/issues/:id/ack", middleware.IdentifyUser("X-Forwarded-User"), handler.AcknowledgeIssue)
+		v1.POST("/issues/:id/snooze", middleware.IdentifyUser("X-Forwarded-User"), handler.SnoozeIssue)
+		v1.DELETE("/issues/:id/snooze", handler.WakeIssue)
+		v1.GET("/issues/:id/related", handler.GetRelatedIssues)
+		v1.POST("/issues/:id/related", handler.AddRelatedIssue)
+		v1.DELETE("/issues/:id/related/:relatedId", handler.RemoveRelatedIssue)
+		v1.POST("/issues/:id/external-refs", handler.AddExternalRef)
+		v1.DELETE("/issues/:id/external-refs/:refId", handler.RemoveExternalRef)
 	}
 
 	return router
@@ -60,7 +87,7 @@ func TestIssueHandler_GetIssues(t *testing.T) {
 	mockService := &MockIssueService{
 		findIssueResults: &dto.IssueResponse{
 			Data:   mockIssues,
-			Total:  2,
+			Total:  int64Ptr(2),
 			Limit:  50,
 			Offset: 0,
 		},
@@ -97,8 +124,369 @@ func TestIssueHandler_GetIssues(t *testing.T) {
 		t.Errorf("expected 2 issues, got %d", len(response.Data))
 	}
 
-	if response.Total != 2 {
-		t.Errorf("expected total 2, got %d", response.Total)
+	if response.Total == nil || *response.Total != 2 {
+		t.Errorf("expected total 2, got %v", response.Total)
+	}
+}
+
+func TestIssueHandler_GetIssues_Fields(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueResults: &dto.IssueResponse{
+			Data:  []models.Issue{{ID: "abc-1", Title: "Test Issue 1", Severity: models.SeverityMajor}},
+			Total: int64Ptr(1),
+			Limit: 50,
+		},
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/issues?fields=title,severity", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data  []map[string]any `json:"data"`
+		Total int64            `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expected := []map[string]any{{"id": "abc-1", "title": "Test Issue 1", "severity": "major"}}
+	if !reflect.DeepEqual(response.Data, expected) {
+		t.Errorf("Expected only the selected fields %v, got %v", expected, response.Data)
+	}
+	if response.Total != 1 {
+		t.Errorf("Expected the pagination to be kept, got total %d", response.Total)
+	}
+
+	req, _ = net_http.NewRequest("GET", "/api/v1/issues?fields=title,password", nil)
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown field, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_NDJSON(t *testing.T) {
+	mockService := &MockIssueService{
+		streamIssuesBatches: [][]models.Issue{
+			{{ID: "abc-1", Title: "Test Issue 1", Severity: models.SeverityMajor}, {ID: "abc-2", Title: "Test Issue 2", Severity: models.SeverityMinor}},
+			{{ID: "abc-3", Title: "Test Issue 3", Severity: models.SeverityInfo}},
+		},
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	stream := func(path, acceptEncoding string) (*net_httptest.ResponseRecorder, []map[string]any) {
+		req, _ := net_http.NewRequest("GET", path, nil)
+		req.Header.Set("Accept", "application/x-ndjson")
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body io.Reader = w.Body
+		if w.Header().Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(w.Body)
+			if err != nil {
+				t.Fatalf("Failed to decompress response: %v", err)
+			}
+			body = gz
+		}
+		var rows []map[string]any
+		decoder := json.NewDecoder(body)
+		for decoder.More() {
+			var row map[string]any
+			if err := decoder.Decode(&row); err != nil {
+				t.Fatalf("Failed to parse line: %v", err)
+			}
+			rows = append(rows, row)
+		}
+		return w, rows
+	}
+
+	w, rows := stream("/api/v1/issues?namespace=team-alpha&fields=title", "")
+	if w.Code != net_http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("Expected a 200 NDJSON response, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	expected := []map[string]any{{"id": "abc-1", "title": "Test Issue 1"}, {"id": "abc-2", "title": "Test Issue 2"}, {"id": "abc-3", "title": "Test Issue 3"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected one line per issue %v, got %v", expected, rows)
+	}
+
+	w, rows = stream("/api/v1/issues?namespace=team-alpha", "br, gzip;q=0.5")
+	if w.Header().Get("Content-Encoding") != "gzip" || len(rows) != 3 || rows[2]["severity"] != "info" {
+		t.Errorf("Expected the 3 issues compressed with gzip, got %q %v", w.Header().Get("Content-Encoding"), rows)
+	}
+	if _, rows = stream("/api/v1/issues?namespace=team-alpha", "gzip;q=0"); len(rows) != 3 {
+		t.Errorf("Expected the 3 issues uncompressed, got %v", rows)
+	}
+
+	// Failures end the stream with an error line
+	mockService.streamIssuesError = errors.New("connection reset")
+	_, rows = stream("/api/v1/issues?namespace=team-alpha", "")
+	if len(rows) != 4 || rows[3]["error"] == nil {
+		t.Errorf("Expected the stream to end with an error, got %v", rows)
+	}
+
+	w, _ = stream("/api/v1/issues?namespace=team-alpha&sort=priority", "")
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400 sorting the stream by priority, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_PaginationLinks(t *testing.T) {
+	nextOffset := 20
+	mockService := &MockIssueService{
+		findIssueResults: &dto.IssueResponse{
+			Data:        []models.Issue{{ID: "abc-1", Title: "Test Issue 1", Namespace: "team-alpha"}},
+			Total:       int64Ptr(25),
+			Limit:       10,
+			Offset:      10,
+			HasNextPage: true,
+			NextOffset:  &nextOffset,
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&limit=10&offset=10", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	expected := `</api/v1/issues?limit=10&namespace=team-alpha&offset=0>; rel="first", ` +
+		`</api/v1/issues?limit=10&namespace=team-alpha&offset=0>; rel="prev", ` +
+		`</api/v1/issues?limit=10&namespace=team-alpha&offset=20>; rel="next", ` +
+		`</api/v1/issues?limit=10&namespace=team-alpha&offset=20>; rel="last"`
+	if link := w.Header().Get("Link"); link != expected {
+		t.Errorf("Expected Link header %q, got %q", expected, link)
+	}
+
+	var response dto.IssueResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !response.HasNextPage || response.NextOffset == nil || *response.NextOffset != 20 {
+		t.Errorf("Expected a next page at offset 20, got hasNextPage=%v nextOffset=%v", response.HasNextPage, response.NextOffset)
+	}
+}
+
+func TestIssueHandler_GetIssues_InvalidLabel(t *testing.T) {
+	mockService := &MockIssueService{}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&label=team", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_InvalidHasExternalRef(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&hasExternalRef=maybe", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_InvalidAcknowledged(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&acknowledged=nobody", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_InvalidSort(t *testing.T) {
+	mockService := &MockIssueService{}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&sort=title", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestParseIssueQueryFilters_DateRanges(t *testing.T) {
+	filters, err := parseIssueQueryFilters(url.Values{
+		"detectedAfter":  {"2025-01-01"},
+		"detectedBefore": {"2025-02-01T12:30:00+01:00"},
+		"resolvedAfter":  {"2025-01-15T00:00:00Z"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if filters.DetectedSince == nil || !filters.DetectedSince.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected detectedAfter to be midnight UTC, got %v", filters.DetectedSince)
+	}
+	if filters.DetectedBefore == nil || !filters.DetectedBefore.Equal(time.Date(2025, 2, 1, 11, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected detectedBefore to keep its offset, got %v", filters.DetectedBefore)
+	}
+	if filters.ResolvedSince == nil || filters.ResolvedBefore != nil {
+		t.Errorf("Expected only resolvedAfter to be set, got %v and %v", filters.ResolvedSince, filters.ResolvedBefore)
+	}
+
+	invalid := []url.Values{
+		{"detectedAfter": {"yesterday"}},
+		{"resolvedBefore": {"2025-13-01"}},
+		{"resolvedAfter": {"2025-02-01"}, "resolvedBefore": {"2025-01-01"}},
+	}
+	for _, query := range invalid {
+		if _, err := parseIssueQueryFilters(query); err == nil {
+			t.Errorf("Expected %v to be rejected", query)
+		}
+	}
+}
+
+func TestIssueHandler_GetIssues_DatabaseErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "timeout", err: fmt.Errorf("failed to find issues: %w", context.DeadlineExceeded), expectedStatus: net_http.StatusGatewayTimeout},
+		{name: "client disconnected", err: fmt.Errorf("failed to find issues: %w", context.Canceled), expectedStatus: statusClientClosedRequest},
+		{name: "other failure", err: errors.New("connection refused"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := setupTestIssueHandler(&MockIssueService{findIssuesError: tt.err})
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssuesGrouped(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssuesGroupedResult: &dto.GroupedIssuesResponse{
+			GroupBy: "severity",
+			Groups: []dto.IssueGroup{
+				{
+					Key:   "major",
+					Count: 1,
+					Issues: []models.Issue{
+						{
+							ID:        "abc-1",
+							Title:     "Test Issue 1",
+							Namespace: "team-alpha",
+							Severity:  models.SeverityMajor,
+						},
+					},
+				},
+			},
+			Total:  1,
+			Limit:  50,
+			Offset: 0,
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	// Create test request
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/grouped?namespace=team-alpha&groupBy=severity", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	// Parse response body
+	var response dto.GroupedIssuesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.Groups) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(response.Groups))
+	}
+
+	if response.Groups[0].Key != "major" || len(response.Groups[0].Issues) != 1 {
+		t.Errorf("expected 1 issue in group major, got %d in %s", len(response.Groups[0].Issues), response.Groups[0].Key)
+	}
+}
+
+func TestIssueHandler_GetIssuesGrouped_InvalidGroupBy(t *testing.T) {
+	mockService := &MockIssueService{}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/grouped?namespace=team-alpha&groupBy=color", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
 	}
 }
 
@@ -179,6 +567,125 @@ func TestIssueHandler_GetIssue_NotFound(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_GetSimilarIssues(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedLimit  int
+	}{
+		{
+			name:           "default limit",
+			path:           "/api/v1/issues/test-issue-abc/similar?namespace=team-alpha",
+			expectedStatus: net_http.StatusOK,
+			expectedLimit:  defaultSimilarIssuesLimit,
+		},
+		{
+			name:           "given limit",
+			path:           "/api/v1/issues/test-issue-abc/similar?namespace=team-alpha&limit=3",
+			expectedStatus: net_http.StatusOK,
+			expectedLimit:  3,
+		},
+		{
+			name:           "limit too large",
+			path:           "/api/v1/issues/test-issue-abc/similar?limit=500",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "issue of another namespace",
+			path:           "/api/v1/issues/test-issue-abc/similar?namespace=team-beta",
+			expectedStatus: net_http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "test-issue-abc", Title: "Build timeout", Namespace: "team-alpha"},
+				findSimilarIssuesResult: []repository.SimilarIssue{
+					{Issue: models.Issue{ID: "test-issue-def", Title: "Build timeout", Namespace: "team-beta"}, Score: 0.9},
+				},
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, _ := net_http.NewRequest("GET", tc.path, nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+			if mockService.findSimilarIssuesLimit != tc.expectedLimit {
+				t.Errorf("Expected limit %d, got %d", tc.expectedLimit, mockService.findSimilarIssuesLimit)
+			}
+			var response struct {
+				Data  []repository.SimilarIssue `json:"data"`
+				Total int                       `json:"total"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Total != 1 || response.Data[0].Issue.ID != "test-issue-def" || response.Data[0].Score != 0.9 {
+				t.Errorf("Unexpected similar issues %+v", response)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetSimilarIssues_OtherNamespaces(t *testing.T) {
+	router := setupTestRouterWithConfig(t, &config.Config{Limits: config.GetLimitsConfig()})
+
+	create := func(namespace, resource string) string {
+		body, _ := json.Marshal(dto.CreateIssueRequest{
+			Title:       "Build failed: go mod download timeout",
+			Description: "Fetching modules timed out",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   namespace,
+			Scope:       dto.ScopeReqBody{ResourceType: "component", ResourceName: resource},
+		})
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, net_httptest.NewRequest(net_http.MethodPost, "/api/v1/issues?namespace="+namespace, bytes.NewReader(body)))
+		if w.Code != net_http.StatusCreated {
+			t.Fatalf("Failed to create test issue: %d %s", w.Code, w.Body.String())
+		}
+		var issue models.Issue
+		if err := json.Unmarshal(w.Body.Bytes(), &issue); err != nil {
+			t.Fatalf("Failed to parse issue: %v", err)
+		}
+		return issue.ID
+	}
+
+	id := create("team-alpha", "api")
+	duplicate := create("team-alpha", "frontend")
+	// The same failure in other namespaces, which may belong to other tenants
+	create("team-beta", "api")
+	create("team-gamma", "frontend")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, net_httptest.NewRequest(net_http.MethodGet, "/api/v1/issues/"+id+"/similar?namespace=team-alpha", nil))
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data []repository.SimilarIssue `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	for _, similar := range response.Data {
+		if similar.Issue.Namespace != "team-alpha" {
+			t.Errorf("Expected no issue of another namespace, got %s of %s", similar.Issue.ID, similar.Issue.Namespace)
+		}
+	}
+	if len(response.Data) != 1 || response.Data[0].Issue.ID != duplicate {
+		t.Errorf("Expected the issue of the same namespace only, got %+v", response.Data)
+	}
+}
+
 func TestIssueHandler_CreateIssue_Success(t *testing.T) {
 	createRequest := dto.CreateIssueRequest{
 		Title:       "New Test Issue",
@@ -244,6 +751,102 @@ func TestIssueHandler_CreateIssue_Success(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_CreateIssue_DryRun(t *testing.T) {
+	mockService := &MockIssueService{
+		dryRunIssueResult: &dto.DryRunResult{
+			DryRun:        true,
+			Action:        dto.DryRunUpdate,
+			Issue:         &models.Issue{ID: "existing-issue"},
+			ExistingIssue: &models.Issue{ID: "existing-issue"},
+		},
+	}
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	body := `{"title": "New Test Issue", "description": "This is a test issue", "severity": "major", "issueType": "build", "namespace": "team-gamma",
+		"scope": {"resourceType": "component", "resourceName": "test-component", "resourceNamespace": "team-gamma"}}`
+	req, err := net_http.NewRequest("POST", "/api/v1/issues?dryRun=true", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if mockService.dryRunIssueSource != models.IssueSourceAPI {
+		t.Errorf("Expected a dry run of an API issue, got %q", mockService.dryRunIssueSource)
+	}
+	var response dto.DryRunResult
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !response.DryRun || response.Action != dto.DryRunUpdate || response.ExistingIssue == nil || response.ExistingIssue.ID != "existing-issue" {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	req, err = net_http.NewRequest("POST", "/api/v1/issues?dryRun=maybe", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid dryRun, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_CheckDuplicate(t *testing.T) {
+	body := `{"title": "New Test Issue", "description": "This is a test issue", "severity": "major", "issueType": "build", "namespace": "team-gamma",
+		"scope": {"resourceType": "component", "resourceName": "test-component", "resourceNamespace": "team-gamma"}}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		existing       *models.Issue
+		expectedStatus int
+		expectedResult bool
+	}{
+		{"duplicate", body, &models.Issue{ID: "existing-issue"}, net_http.StatusOK, true},
+		{"no duplicate", body, nil, net_http.StatusOK, false},
+		{"invalid", `{"title": "New Test Issue"}`, nil, net_http.StatusBadRequest, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := setupTestIssueHandler(&MockIssueService{findDuplicateIssueResult: tc.existing})
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/check-duplicate", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != net_http.StatusOK {
+				return
+			}
+			var response services.DuplicateCheckResult
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.IsDuplicate != tc.expectedResult || (tc.existing != nil && response.ExistingIssue.ID != tc.existing.ID) {
+				t.Errorf("Unexpected response %+v", response)
+			}
+		})
+	}
+}
+
 func TestIssueHandler_CreateIssue_InvalidRequest(t *testing.T) {
 	mockService := &MockIssueService{}
 	handler := setupTestIssueHandler(mockService)
@@ -284,6 +887,231 @@ func TestIssueHandler_CreateIssue_InvalidRequest(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_CreateIssue_DetectedAt(t *testing.T) {
+	tests := []struct {
+		name           string
+		detectedAt     time.Time
+		expectedStatus int
+	}{
+		{name: "in the past", detectedAt: time.Now().Add(-10 * time.Minute), expectedStatus: net_http.StatusCreated},
+		{name: "within the clock skew", detectedAt: time.Now().Add(time.Minute), expectedStatus: net_http.StatusCreated},
+		{name: "in the future", detectedAt: time.Now().Add(time.Hour), expectedStatus: net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createIssueResult: &models.Issue{ID: "issue-1"}}
+			handler := setupTestIssueHandler(mockService)
+			router := setupTestIssueRouter(handler)
+
+			createRequest := dto.CreateIssueRequest{
+				Title:       "Test Issue",
+				Description: "Test description",
+				Severity:    models.SeverityMajor,
+				IssueType:   models.IssueTypeBuild,
+				Namespace:   "team-alpha",
+				Scope: dto.ScopeReqBody{
+					ResourceType: "component",
+					ResourceName: "frontend",
+				},
+				DetectedAt: tt.detectedAt,
+			}
+
+			reqBody, err := json.Marshal(createRequest)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_CreateIssue_Lengths(t *testing.T) {
+	tests := []struct {
+		name           string
+		title          string
+		description    string
+		expectedStatus int
+	}{
+		{name: "within the limits", title: "Test Issue", description: "Test description", expectedStatus: net_http.StatusCreated},
+		{name: "limits count characters", title: strings.Repeat("é", 20), description: "Test description", expectedStatus: net_http.StatusCreated},
+		{name: "title too long", title: strings.Repeat("a", 21), description: "Test description", expectedStatus: net_http.StatusBadRequest},
+		{name: "description too long", title: "Test Issue", description: strings.Repeat("a", 101), expectedStatus: net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createIssueResult: &models.Issue{ID: "issue-1"}}
+			handler := setupTestIssueHandler(mockService)
+			handler.limits = config.LimitsConfig{MaxTitleLength: 20, MaxDescriptionLength: 100, MaxDetailsLength: 1000}
+			router := setupTestIssueRouter(handler)
+
+			createRequest := dto.CreateIssueRequest{
+				Title:       tt.title,
+				Description: tt.description,
+				Severity:    models.SeverityMajor,
+				IssueType:   models.IssueTypeBuild,
+				Namespace:   "team-alpha",
+				Scope: dto.ScopeReqBody{
+					ResourceType: "component",
+					ResourceName: "frontend",
+				},
+			}
+
+			reqBody, err := json.Marshal(createRequest)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_CreateIssue_InvalidPriority(t *testing.T) {
+	mockService := &MockIssueService{}
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	createRequest := dto.CreateIssueRequest{
+		Title:       "Test Issue",
+		Description: "Test description",
+		Severity:    models.SeverityMajor,
+		Priority:    "P0",
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-alpha",
+		Scope: dto.ScopeReqBody{
+			ResourceType: "component",
+			ResourceName: "frontend",
+		},
+	}
+
+	reqBody, err := json.Marshal(createRequest)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_CreateIssue_ValidationErrors(t *testing.T) {
+	mockService := &MockIssueService{}
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	// All the fields failing validation are reported, not only the first one
+	reqBody := fmt.Sprintf(`{
+		"title": %q,
+		"severity": "urgent",
+		"issueType": "build",
+		"namespace": 42,
+		"scope": {"resourceType": "component"}
+	}`, strings.Repeat("a", config.GetLimitsConfig().MaxTitleLength+1))
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues", strings.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Error   string           `json:"error"`
+		Details []dto.FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "Validation failed" {
+		t.Errorf("expected error 'Validation failed', got %q", response.Error)
+	}
+
+	expected := map[string]string{
+		"namespace":          "type",
+		"description":        "required",
+		"severity":           "severity",
+		"scope.resourceName": "required",
+		"title":              "max",
+	}
+	constraints := map[string]string{}
+	for _, fieldErr := range response.Details {
+		constraints[fieldErr.Field] = fieldErr.Constraint
+		if fieldErr.Message == "" {
+			t.Errorf("expected a message for field %s", fieldErr.Field)
+		}
+	}
+	if !reflect.DeepEqual(constraints, expected) {
+		t.Errorf("expected the failed fields %v, got %v", expected, constraints)
+	}
+	for _, fieldErr := range response.Details {
+		if fieldErr.Field == "severity" && fieldErr.Value != "urgent" {
+			t.Errorf("expected the rejected severity, got %v", fieldErr.Value)
+		}
+	}
+}
+
+func TestIssueHandler_CreateIssue_InvalidJSON(t *testing.T) {
+	mockService := &MockIssueService{}
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues", strings.NewReader(`{"title": `))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Invalid request body") {
+		t.Errorf("expected an invalid request body error, got %s", w.Body.String())
+	}
+}
+
 func TestIssueHandler_DeleteIssue_Success(t *testing.T) {
 	mockIssue := &models.Issue{
 		ID:        "delete-test-abc",
@@ -364,3 +1192,626 @@ func TestIssueHandler_ResolveIssue(t *testing.T) {
 		t.Errorf("expeted state 'RESOLVED', got '%s'", response.State)
 	}
 }
+
+func TestIssueHandler_UpdateIssue_TransitionNotAllowed(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueByIDResult: &models.Issue{ID: "issue-1", Namespace: "team-alpha", State: models.IssueStateResolved},
+		updateIssueError: &services.TransitionError{
+			From:    models.IssueStateResolved,
+			To:      models.IssueStateInProgress,
+			Allowed: []models.IssueState{models.IssueStateActive},
+		},
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, _ := net_http.NewRequest("PUT", "/api/v1/issues/issue-1", strings.NewReader(`{"state": "IN_PROGRESS"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusConflict {
+		t.Fatalf("Expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Error   string              `json:"error"`
+		Allowed []models.IssueState `json:"allowed"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "State transition not allowed" || len(response.Allowed) != 1 || response.Allowed[0] != models.IssueStateActive {
+		t.Errorf("Expected the allowed states, got %+v", response)
+	}
+}
+
+func TestIssueHandler_ResolveIssue_Resolution(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedReason string
+	}{
+		{name: "with reason", body: `{"reason": "Rolled back the faulty commit", "resolvedBy": "alice"}`, expectedStatus: net_http.StatusOK, expectedReason: "Rolled back the faulty commit"},
+		{name: "empty body", body: "", expectedStatus: net_http.StatusOK},
+		{name: "invalid body", body: `{"reason": 42}`, expectedStatus: net_http.StatusBadRequest},
+		{name: "reason too long", body: `{"reason": "` + strings.Repeat("a", 101) + `"}`, expectedStatus: net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "issue-1", State: models.IssueStateActive, Namespace: "team-alpha"},
+				updateIssueResult:   &models.Issue{ID: "issue-1", State: models.IssueStateResolved, Namespace: "team-alpha"},
+			}
+			handler := setupTestIssueHandler(mockService)
+			handler.limits = config.LimitsConfig{MaxTitleLength: 100, MaxDescriptionLength: 100, MaxDetailsLength: 100}
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/issue-1/resolve", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == net_http.StatusOK && mockService.updateIssueRequest.ResolutionReason != tt.expectedReason {
+				t.Errorf("expected the reason %q, got %q", tt.expectedReason, mockService.updateIssueRequest.ResolutionReason)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_AcknowledgeIssue(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		user           string
+		serviceError   error
+		expectedStatus int
+		expectedBy     string
+	}{
+		{name: "user of the request", user: "alice", expectedStatus: net_http.StatusOK, expectedBy: "alice"},
+		{name: "acknowledged by", body: `{"acknowledgedBy": "bob"}`, user: "alice", expectedStatus: net_http.StatusOK, expectedBy: "bob"},
+		{name: "nobody", body: `{}`, expectedStatus: net_http.StatusBadRequest},
+		{name: "invalid body", body: `{"acknowledgedBy": 42}`, user: "alice", expectedStatus: net_http.StatusBadRequest},
+		{name: "not found", user: "alice", serviceError: repository.NotFoundError("issue not found"), expectedStatus: net_http.StatusNotFound},
+		{name: "other namespace", user: "alice", serviceError: repository.ForbiddenError("access denied to this namespace"), expectedStatus: net_http.StatusForbidden},
+		{name: "resolved", user: "alice", serviceError: repository.ConflictError("issue is resolved"), expectedStatus: net_http.StatusConflict},
+		{name: "database error", user: "alice", serviceError: errors.New("connection refused"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				acknowledgeIssueResult: &models.Issue{ID: "issue-1", State: models.IssueStateAcknowledged, Namespace: "team-alpha"},
+				acknowledgeIssueError:  tt.serviceError,
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/issue-1/ack?namespace=team-alpha", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if tt.user != "" {
+				req.Header.Set("X-Forwarded-User", tt.user)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusOK && mockService.acknowledgedBy != tt.expectedBy {
+				t.Errorf("expected the issue acknowledged by %q, got %q", tt.expectedBy, mockService.acknowledgedBy)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_SnoozeIssue(t *testing.T) {
+	until := time.Now().Add(4 * time.Hour).UTC().Truncate(time.Second)
+	body := func(until time.Time, snoozedBy string) string {
+		return fmt.Sprintf(`{"until": %q, "snoozedBy": %q}`, until.Format(time.RFC3339), snoozedBy)
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		user           string
+		serviceError   error
+		expectedStatus int
+		expectedBy     string
+	}{
+		{name: "user of the request", body: body(until, ""), user: "alice", expectedStatus: net_http.StatusOK, expectedBy: "alice"},
+		{name: "snoozed by", body: body(until, "bob"), user: "alice", expectedStatus: net_http.StatusOK, expectedBy: "bob"},
+		{name: "nobody", body: body(until, ""), expectedStatus: net_http.StatusBadRequest},
+		{name: "no end", body: `{}`, user: "alice", expectedStatus: net_http.StatusBadRequest},
+		{name: "past end", body: body(time.Now().Add(-time.Hour), ""), user: "alice", expectedStatus: net_http.StatusBadRequest},
+		{name: "too long", body: body(time.Now().Add(maxSnooze+time.Hour), ""), user: "alice", expectedStatus: net_http.StatusBadRequest},
+		{name: "invalid end", body: `{"until": "4h"}`, user: "alice", expectedStatus: net_http.StatusBadRequest},
+		{name: "not found", body: body(until, ""), user: "alice", serviceError: repository.NotFoundError("issue not found"), expectedStatus: net_http.StatusNotFound},
+		{name: "other namespace", body: body(until, ""), user: "alice", serviceError: repository.ForbiddenError("access denied to this namespace"), expectedStatus: net_http.StatusForbidden},
+		{name: "resolved", body: body(until, ""), user: "alice", serviceError: repository.ConflictError("issue is resolved"), expectedStatus: net_http.StatusConflict},
+		{name: "database error", body: body(until, ""), user: "alice", serviceError: errors.New("connection refused"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				snoozeIssueResult: &models.Issue{ID: "issue-1", State: models.IssueStateActive, Namespace: "team-alpha"},
+				snoozeIssueError:  tt.serviceError,
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/issue-1/snooze?namespace=team-alpha", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if tt.user != "" {
+				req.Header.Set("X-Forwarded-User", tt.user)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+			if mockService.snoozedBy != tt.expectedBy {
+				t.Errorf("expected the issue snoozed by %q, got %q", tt.expectedBy, mockService.snoozedBy)
+			}
+			if mockService.snoozedUntil == nil || !mockService.snoozedUntil.Equal(until) {
+				t.Errorf("expected the issue snoozed until %v, got %v", until, mockService.snoozedUntil)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_WakeIssue(t *testing.T) {
+	snoozedUntil := time.Now().Add(time.Hour)
+	mockService := &MockIssueService{
+		snoozeIssueResult: &models.Issue{ID: "issue-1", State: models.IssueStateActive, Namespace: "team-alpha"},
+		snoozedUntil:      &snoozedUntil,
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, err := net_http.NewRequest("DELETE", "/api/v1/issues/issue-1/snooze?namespace=team-alpha", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if mockService.snoozedUntil != nil {
+		t.Errorf("expected the issue to be woken, got a snooze until %v", mockService.snoozedUntil)
+	}
+}
+
+func TestIssueHandler_GetIssues_InvalidSnoozed(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&snoozed=later", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_ResolveIssue_BlockedByEffects(t *testing.T) {
+	tests := []struct {
+		name           string
+		namespaces     []string
+		effects        []string
+		effectsError   error
+		expectedStatus int
+	}{
+		{name: "blocked", namespaces: []string{"team-alpha"}, effects: []string{"effect-1"}, expectedStatus: net_http.StatusConflict},
+		{name: "blocked in all namespaces", namespaces: []string{"*"}, effects: []string{"effect-1"}, expectedStatus: net_http.StatusConflict},
+		{name: "no active effects", namespaces: []string{"team-alpha"}, expectedStatus: net_http.StatusOK},
+		{name: "namespace not blocking", namespaces: []string{"team-beta"}, effects: []string{"effect-1"}, expectedStatus: net_http.StatusOK},
+		{name: "database error", namespaces: []string{"team-alpha"}, effectsError: errors.New("connection refused"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult:     &models.Issue{ID: "cause-1", State: models.IssueStateActive, Namespace: "team-alpha"},
+				updateIssueResult:       &models.Issue{ID: "cause-1", State: models.IssueStateResolved, Namespace: "team-alpha"},
+				findActiveEffectsResult: tt.effects,
+				findActiveEffectsError:  tt.effectsError,
+			}
+			handler := setupTestIssueHandler(mockService)
+			handler.resolution = config.ResolutionConfig{BlockingNamespaces: tt.namespaces}
+			router := setupTestIssueRouter(handler)
+
+			for _, req := range []*net_http.Request{
+				net_httptest.NewRequest("POST", "/api/v1/issues/cause-1/resolve", nil),
+				net_httptest.NewRequest("PUT", "/api/v1/issues/cause-1", strings.NewReader(`{"state": "RESOLVED"}`)),
+			} {
+				req.Header.Set("Content-Type", "application/json")
+				w := net_httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != tt.expectedStatus {
+					t.Fatalf("%s %s: expected status %d, got %d", req.Method, req.URL.Path, tt.expectedStatus, w.Code)
+				}
+				if tt.expectedStatus != net_http.StatusConflict {
+					continue
+				}
+
+				var response struct {
+					BlockingIssues []string `json:"blockingIssues"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to parse response: %v", err)
+				}
+				if len(response.BlockingIssues) != 1 || response.BlockingIssues[0] != "effect-1" {
+					t.Errorf("expected effect-1 to block the resolution, got %v", response.BlockingIssues)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueHandler_AddRelatedIssue_Type(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedType   models.RelationType
+	}{
+		{name: "default type", body: `{"relatedId": "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f"}`, expectedStatus: net_http.StatusCreated, expectedType: models.RelationTypeRelated},
+		{name: "caused by", body: `{"relatedId": "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f", "type": "caused-by"}`, expectedStatus: net_http.StatusCreated, expectedType: models.RelationTypeCausedBy},
+		{name: "invalid type", body: `{"relatedId": "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f", "type": "blocks"}`, expectedStatus: net_http.StatusBadRequest},
+		{name: "related ID not a UUID", body: `{"relatedId": "issue-2"}`, expectedStatus: net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{}
+			handler := setupTestIssueHandler(mockService)
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/issue-1/related", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if mockService.addRelatedIssueType != tt.expectedType {
+				t.Errorf("expected the type %q, got %q", tt.expectedType, mockService.addRelatedIssueType)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetRelatedIssues(t *testing.T) {
+	related := []dto.RelatedIssueEntry{{
+		RelationID: "relation-1",
+		Type:       models.RelationTypeCausedBy,
+		Direction:  dto.RelationOutgoing,
+		Issue:      dto.RelatedIssueSummary{ID: "issue-2", Title: "Cluster outage", State: models.IssueStateActive},
+	}}
+
+	tests := []struct {
+		name           string
+		serviceError   error
+		expectedStatus int
+	}{
+		{name: "related issues", expectedStatus: net_http.StatusOK},
+		{name: "issue not found", serviceError: repository.NotFoundError("issue not found"), expectedStatus: net_http.StatusNotFound},
+		{name: "other namespace", serviceError: repository.ForbiddenError("access denied to this namespace"), expectedStatus: net_http.StatusForbidden},
+		{name: "database error", serviceError: errors.New("connection refused"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findRelatedIssuesResult: related, findRelatedIssuesError: tt.serviceError}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, _ := net_http.NewRequest("GET", "/api/v1/issues/issue-1/related?namespace=team-alpha", nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.serviceError != nil {
+				return
+			}
+			var response struct {
+				Data  []dto.RelatedIssueEntry `json:"data"`
+				Total int                     `json:"total"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Total != 1 || !reflect.DeepEqual(response.Data, related) {
+				t.Errorf("Expected the related issues %+v, got %+v", related, response.Data)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_AddExternalRef(t *testing.T) {
+	validBody := `{"system": "jira", "key": "KFLUXBUGS-1", "url": "https://issues.test/browse/KFLUXBUGS-1"}`
+
+	tests := []struct {
+		name           string
+		body           string
+		serviceError   error
+		expectedStatus int
+	}{
+		{name: "created", body: validBody, expectedStatus: net_http.StatusCreated},
+		{name: "missing key", body: `{"system": "jira", "url": "https://issues.test/browse/KFLUXBUGS-1"}`, expectedStatus: net_http.StatusBadRequest},
+		{name: "invalid url", body: `{"system": "jira", "key": "KFLUXBUGS-1", "url": "KFLUXBUGS-1"}`, expectedStatus: net_http.StatusBadRequest},
+		{name: "issue not found", body: validBody, serviceError: repository.NotFoundError("issue not found"), expectedStatus: net_http.StatusNotFound},
+		{name: "already referenced", body: validBody, serviceError: repository.ConflictError("external reference already exists"), expectedStatus: net_http.StatusConflict},
+		{name: "database error", body: validBody, serviceError: errors.New("connection refused"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				addExternalRefResult: &models.ExternalRef{ID: "ref-1", IssueID: "issue-1", System: "jira", Key: "KFLUXBUGS-1"},
+				addExternalRefError:  tt.serviceError,
+			}
+			handler := setupTestIssueHandler(mockService)
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/issue-1/external-refs", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_RemoveExternalRef(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceError   error
+		expectedStatus int
+	}{
+		{name: "removed", expectedStatus: net_http.StatusNoContent},
+		{name: "not found", serviceError: repository.NotFoundError("external reference not found"), expectedStatus: net_http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := setupTestIssueHandler(&MockIssueService{removeExternalRefError: tt.serviceError})
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("DELETE", "/api/v1/issues/issue-1/external-refs/ref-1", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_IssueOfAnotherNamespace(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "add related issue", method: "POST", path: "/api/v1/issues/issue-1/related", body: `{"relatedId": "issue-2"}`},
+		{name: "remove related issue", method: "DELETE", path: "/api/v1/issues/issue-1/related/issue-2"},
+		{name: "add external reference", method: "POST", path: "/api/v1/issues/issue-1/external-refs",
+			body: `{"system": "jira", "key": "KFLUXBUGS-1", "url": "https://issues.test/browse/KFLUXBUGS-1"}`},
+		{name: "remove external reference", method: "DELETE", path: "/api/v1/issues/issue-1/external-refs/ref-1"},
+		{name: "create issue", method: "POST", path: "/api/v1/issues",
+			body: `{"title": "Build failed", "description": "Build failed", "severity": "major", "issueType": "build", "namespace": "team-alpha",
+				"scope": {"resourceType": "component", "resourceName": "api", "resourceNamespace": "team-alpha"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := setupTestIssueHandler(&MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "issue-1", Namespace: "team-alpha"},
+			})
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest(tt.method, tt.path+"?namespace=team-beta", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusForbidden {
+				t.Errorf("expected status 403, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIssueHandler_InvalidEnums(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "filter by invalid severity", method: "GET", path: "/api/v1/issues?namespace=team-alpha&severity=medium"},
+		{name: "filter by invalid state", method: "GET", path: "/api/v1/issues?namespace=team-alpha&state=resolved"},
+		{
+			name:   "create with invalid issue type",
+			method: "POST",
+			path:   "/api/v1/issues",
+			body:   `{"title": "t", "description": "d", "severity": "major", "issueType": "deploy", "namespace": "team-alpha", "scope": {"resourceType": "component", "resourceName": "api"}}`,
+		},
+		{name: "update with invalid severity", method: "PUT", path: "/api/v1/issues/issue-1", body: `{"severity": "medium"}`},
+		{name: "update with invalid state", method: "PUT", path: "/api/v1/issues/issue-1", body: `{"state": "closed"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueByIDResult: &models.Issue{ID: "issue-1", Namespace: "team-alpha"}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, _ := net_http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRespondWithClientError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name            string
+		err             error
+		expectedHandled bool
+		expectedStatus  int
+		expectedError   string
+	}{
+		{"not found", repository.NotFoundError("issue not found"), true, net_http.StatusNotFound, "Issue not found"},
+		{"wrapped conflict", fmt.Errorf("failed to process item 2: %w", repository.ConflictError("issue is resolved")), true, net_http.StatusConflict, "Failed to process item 2: issue is resolved"},
+		{"forbidden", repository.ForbiddenError("access denied to this namespace"), true, net_http.StatusForbidden, "Access denied to this namespace"},
+		{"transition", &services.TransitionError{From: models.IssueStateResolved, To: models.IssueStateActive}, true, net_http.StatusConflict, "State transition not allowed"},
+		{"other", errors.New("connection refused"), false, 0, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := net_httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			if handled := respondWithClientError(c, tc.err); handled != tc.expectedHandled {
+				t.Fatalf("Expected handled %v, got %v", tc.expectedHandled, handled)
+			}
+			if !tc.expectedHandled {
+				return
+			}
+			if w.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, w.Code)
+			}
+			var response map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response["error"] != tc.expectedError {
+				t.Errorf("Expected error %q, got %v", tc.expectedError, response["error"])
+			}
+		})
+	}
+}
+
+// deletingIssueService is the issue service with the issues deleted right
+// after being found, as by a concurrent request
+type deletingIssueService struct {
+	*services.IssueService
+	repo repository.IssueRepository
+}
+
+func (s *deletingIssueService) FindIssueByID(ctx context.Context, id string) (*models.Issue, error) {
+	issue, err := s.IssueService.FindIssueByID(ctx, id)
+	if err != nil || issue == nil {
+		return issue, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+func TestIssueHandler_DeletedBetweenLookupAndWrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "update", method: "PUT", path: "", body: `{"title": "Renamed"}`},
+		{name: "update state", method: "PUT", path: "", body: `{"state": "RESOLVED"}`},
+		{name: "resolve", method: "POST", path: "/resolve", body: ""},
+		{name: "delete", method: "DELETE", path: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			db := testhelpers.SetupTestDB(t)
+			repo := repository.NewIssueRepository(db, logger, 0)
+			service := services.NewIssueService(repo, repository.NewUnitOfWork(db, logger, 0), repository.NewNamespaceSettingsRepository(db, logger, 0), nil, logger)
+			issue, err := repo.Create(context.Background(), dto.CreateIssueRequest{
+				Title:       "Build failed",
+				Description: "The build of the component failed",
+				Severity:    models.SeverityMajor,
+				IssueType:   models.IssueTypeBuild,
+				Namespace:   "team-alpha",
+				Scope:       dto.ScopeReqBody{ResourceType: "component", ResourceName: "frontend", ResourceNamespace: "team-alpha"},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create issue: %v", err)
+			}
+
+			handler := NewIssueHandler(&deletingIssueService{IssueService: service, repo: repo}, config.GetLimitsConfig(), config.ResolutionConfig{}, logger)
+			router := setupTestIssueRouter(handler)
+
+			req, _ := net_http.NewRequest(tt.method, "/api/v1/issues/"+issue.ID+tt.path+"?namespace=team-alpha", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusNotFound {
+				t.Errorf("Expected status 404, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+type IssueWatchHandler struct {
+	watchService services.IssueWatchServiceInterface
+	logger       *logrus.Logger
+}
+
+func NewIssueWatchHandler(watchService services.IssueWatchServiceInterface, logger *logrus.Logger) *IssueWatchHandler {
+	return &IssueWatchHandler{
+		watchService: watchService,
+		logger:       logger,
+	}
+}
+
+// WatchIssue handles POST /issues/:id/watch, making the user follow the issue
+func (h *IssueWatchHandler) WatchIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	watch, err := h.watchService.WatchIssue(c.Request.Context(), id, c.Query("namespace"), middleware.User(c))
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to watch issue")
+			respondWithServerError(c, err, "Failed to watch issue")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, watch)
+}
+
+// UnwatchIssue handles DELETE /issues/:id/watch
+func (h *IssueWatchHandler) UnwatchIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.watchService.UnwatchIssue(c.Request.Context(), id, middleware.User(c)); err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to unwatch issue")
+		respondWithServerError(c, err, "Failed to unwatch issue")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWatchedIssues handles GET /me/watched, listing the issues the user
+// follows, the most recently updated first
+func (h *IssueWatchHandler) GetWatchedIssues(c *gin.Context) {
+	issues, err := h.watchService.FindWatchedIssues(c.Request.Context(), middleware.User(c))
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("Failed to fetch watched issues")
+		respondWithServerError(c, err, "Failed to fetch watched issues")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": issues, "total": len(issues)})
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTestIssueWatchRouter creates a test router serving the issue watches of a mock service
+func setupTestIssueWatchRouter(mockService *MockIssueWatchService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewIssueWatchHandler(mockService, logger)
+
+	router := gin.New()
+	v1 := router.Group("/api/v1", middleware.RequireUser("X-Forwarded-User"))
+	{
+		v1.POST("/issues/:id/watch", handler.WatchIssue)
+		v1.DELETE("/issues/:id/watch", handler.UnwatchIssue)
+		v1.GET("/me/watched", handler.GetWatchedIssues)
+	}
+	return router
+}
+
+func watchRequest(router *gin.Engine, method, path, user string) *net_httptest.ResponseRecorder {
+	req, _ := net_http.NewRequest(method, path, nil)
+	if user != "" {
+		req.Header.Set("X-Forwarded-User", user)
+	}
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIssueWatchHandler_WatchIssue(t *testing.T) {
+	testCases := []struct {
+		name           string
+		user           string
+		serviceError   error
+		expectedStatus int
+	}{
+		{name: "watched", user: "alice", expectedStatus: net_http.StatusOK},
+		{name: "missing user", expectedStatus: net_http.StatusUnauthorized},
+		{name: "issue not found", user: "alice", serviceError: repository.NotFoundError("issue not found"), expectedStatus: net_http.StatusNotFound},
+		{name: "other namespace", user: "alice", serviceError: repository.ForbiddenError("access denied to this namespace"), expectedStatus: net_http.StatusForbidden},
+		{name: "database error", user: "alice", serviceError: errors.New("database error"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockIssueWatchService{watchError: tc.serviceError}
+			router := setupTestIssueWatchRouter(mockService)
+
+			w := watchRequest(router, "POST", "/api/v1/issues/issue-1/watch?namespace=team-alpha", tc.user)
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var watch models.IssueWatch
+			if err := json.Unmarshal(w.Body.Bytes(), &watch); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if watch.IssueID != "issue-1" || watch.User != "alice" {
+				t.Errorf("Unexpected watch %+v", watch)
+			}
+		})
+	}
+}
+
+func TestIssueWatchHandler_UnwatchIssue(t *testing.T) {
+	mockService := &MockIssueWatchService{}
+	router := setupTestIssueWatchRouter(mockService)
+
+	if w := watchRequest(router, "DELETE", "/api/v1/issues/issue-1/watch", "alice"); w.Code != net_http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+	if mockService.lastUser != "alice" {
+		t.Errorf("Expected the issue to be unwatched by alice, got %q", mockService.lastUser)
+	}
+
+	mockService.unwatchError = repository.NotFoundError("issue watch not found")
+	if w := watchRequest(router, "DELETE", "/api/v1/issues/issue-1/watch", "alice"); w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestIssueWatchHandler_GetWatchedIssues(t *testing.T) {
+	mockService := &MockIssueWatchService{
+		watchedIssues: []models.Issue{{ID: "issue-1"}, {ID: "issue-2"}},
+	}
+	router := setupTestIssueWatchRouter(mockService)
+
+	w := watchRequest(router, "GET", "/api/v1/me/watched", "alice")
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data  []models.Issue `json:"data"`
+		Total int            `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Total != 2 || len(response.Data) != 2 || mockService.lastUser != "alice" {
+		t.Errorf("Expected the 2 issues watched by alice, got %+v for %q", response, mockService.lastUser)
+	}
+
+	mockService.findWatchedErr = errors.New("database error")
+	if w := watchRequest(router, "GET", "/api/v1/me/watched", "alice"); w.Code != net_http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+type MaintenanceHandler struct {
+	maintenanceService services.MaintenanceServiceInterface
+	logger             *logrus.Logger
+}
+
+func NewMaintenanceHandler(maintenanceService services.MaintenanceServiceInterface, logger *logrus.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceService: maintenanceService,
+		logger:             logger,
+	}
+}
+
+// StartMaintenance handles POST /admin/maintenance-jobs?task=..., starting a
+// database maintenance task in the background. Responds with 202 and the job,
+// whose progress is polled at the URL of the Location header.
+func (h *MaintenanceHandler) StartMaintenance(c *gin.Context) {
+	task := dto.MaintenanceTask(c.Query("task"))
+	if !slices.Contains(dto.MaintenanceTasks, task) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid task %q, must be one of: %s", task, joinEnum(dto.MaintenanceTasks))})
+		return
+	}
+
+	job, err := h.maintenanceService.StartMaintenance(c.Request.Context(), task, middleware.User(c))
+	if err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("task", task).Error("Failed to start maintenance")
+		respondWithServerError(c, err, "Failed to start maintenance")
+		return
+	}
+
+	c.Header("Location", "/api/"+APIVersion+"/admin/maintenance-jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetMaintenanceJob handles GET /admin/maintenance-jobs/:id, returning the
+// progress of a maintenance task
+func (h *MaintenanceHandler) GetMaintenanceJob(c *gin.Context) {
+	id := c.Param("id")
+
+	job, err := h.maintenanceService.GetMaintenanceJob(c.Request.Context(), id)
+	if err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("job_id", id).Error("Failed to fetch maintenance job")
+		respondWithServerError(c, err, "Failed to fetch maintenance job")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTestMaintenanceRouter creates a test router running maintenance tasks with a mock service, admin being the admin
+func setupTestMaintenanceRouter(mockService *MockMaintenanceService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewMaintenanceHandler(mockService, logger)
+	requireAdmin := middleware.RequireAdmin("X-Forwarded-User", []string{"admin"})
+
+	router := gin.New()
+	admin := router.Group("/api/v1/admin", requireAdmin)
+	{
+		admin.POST("/maintenance-jobs", handler.StartMaintenance)
+		admin.GET("/maintenance-jobs/:id", middleware.ValidateID(), handler.GetMaintenanceJob)
+	}
+	return router
+}
+
+func TestMaintenanceHandler_StartMaintenance(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		user           string
+		startError     error
+		expectedStatus int
+		expectedTask   dto.MaintenanceTask
+	}{
+		{
+			name:           "reindex",
+			path:           "/api/v1/admin/maintenance-jobs?task=reindex",
+			user:           "admin",
+			expectedStatus: net_http.StatusAccepted,
+			expectedTask:   dto.MaintenanceTaskReindex,
+		},
+		{
+			name:           "recompute",
+			path:           "/api/v1/admin/maintenance-jobs?task=recompute",
+			user:           "admin",
+			expectedStatus: net_http.StatusAccepted,
+			expectedTask:   dto.MaintenanceTaskRecompute,
+		},
+		{
+			name:           "missing task",
+			path:           "/api/v1/admin/maintenance-jobs",
+			user:           "admin",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid task",
+			path:           "/api/v1/admin/maintenance-jobs?task=truncate",
+			user:           "admin",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "not an admin",
+			path:           "/api/v1/admin/maintenance-jobs?task=vacuum",
+			user:           "alice",
+			expectedStatus: net_http.StatusForbidden,
+		},
+		{
+			name:           "already in progress",
+			path:           "/api/v1/admin/maintenance-jobs?task=vacuum",
+			user:           "admin",
+			startError:     repository.ConflictError("maintenance job already in progress"),
+			expectedStatus: net_http.StatusConflict,
+		},
+		{
+			name:           "database error",
+			path:           "/api/v1/admin/maintenance-jobs?task=analyze",
+			user:           "admin",
+			startError:     errors.New("database error"),
+			expectedStatus: net_http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockMaintenanceService{startError: tc.startError}
+			router := setupTestMaintenanceRouter(mockService)
+
+			req, _ := net_http.NewRequest("POST", tc.path, nil)
+			req.Header.Set("X-Forwarded-User", tc.user)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusAccepted {
+				return
+			}
+			if mockService.lastTask != tc.expectedTask || mockService.lastUser != "admin" {
+				t.Errorf("Expected a %s by admin, got a %s by %q", tc.expectedTask, mockService.lastTask, mockService.lastUser)
+			}
+			var job dto.MaintenanceJob
+			if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if job.Task != tc.expectedTask || w.Header().Get("Location") != "/api/v1/admin/maintenance-jobs/"+job.ID {
+				t.Errorf("Expected the %s job and its location, got %+v at %q", tc.expectedTask, job, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestMaintenanceHandler_GetMaintenanceJob(t *testing.T) {
+	mockService := &MockMaintenanceService{
+		getResult: &dto.MaintenanceJob{
+			ID:              "3f2a9c4e-8b1d-4e6f-a7c2-5d9e0b1f2a3c",
+			Task:            dto.MaintenanceTaskVacuum,
+			Status:          dto.MaintenanceStatusRunning,
+			Tables:          []string{"issue_scopes", "issues", "links"},
+			TablesProcessed: 2,
+		},
+	}
+	router := setupTestMaintenanceRouter(mockService)
+	get := func(id string) *net_httptest.ResponseRecorder {
+		req, _ := net_http.NewRequest("GET", "/api/v1/admin/maintenance-jobs/"+id, nil)
+		req.Header.Set("X-Forwarded-User", "admin")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("3f2a9c4e-8b1d-4e6f-a7c2-5d9e0b1f2a3c")
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var job dto.MaintenanceJob
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(job.Tables) != 3 || job.TablesProcessed != 2 {
+		t.Errorf("Expected the progress of the job, got %+v", job)
+	}
+
+	if w := get("not-a-uuid"); w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	mockService.getError = repository.NotFoundError("maintenance job not found")
+	if w := get("3f2a9c4e-8b1d-4e6f-a7c2-5d9e0b1f2a3c"); w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/metrics"
+)
+
+// metricsContentType is the content type of the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// NewMetricsHandler returns the handler exporting the metrics of the server to
+// Prometheus, see the metrics package
+func NewMetricsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", metricsContentType)
+		c.Status(http.StatusOK)
+		if err := metrics.WriteText(c.Writer); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
+// NewDedupStatsHandler returns the handler summarizing how the issues reported
+// since the server started were deduplicated, to tune the deduplication of
+// issues on real data
+func NewDedupStatsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, metrics.GetDedupStats())
+	}
+}
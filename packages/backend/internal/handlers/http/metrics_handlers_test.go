@@ -0,0 +1,58 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/metrics"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/metrics", NewMetricsHandler())
+
+	metrics.IssueReports.Inc("build", string(metrics.DedupMerged))
+
+	req, _ := net_http.NewRequest("GET", "/metrics", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != metricsContentType {
+		t.Errorf("Expected the Prometheus content type, got %q", w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Body.String(), `kite_issue_reports_total{issue_type="build",outcome="merged"}`) {
+		t.Errorf("Expected the issue reports to be exported, got %s", w.Body.String())
+	}
+}
+
+func TestDedupStatsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/admin/dedup-stats", NewDedupStatsHandler())
+
+	metrics.IssueReports.Inc("release", string(metrics.DedupCreated))
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/admin/dedup-stats", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response metrics.DedupStats
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Reports == 0 || response.ByIssueType["release"].Created == 0 || response.Since.IsZero() {
+		t.Errorf("Expected the reports since the start of the server, got %+v", response)
+	}
+}
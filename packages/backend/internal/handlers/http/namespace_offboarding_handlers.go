@@ -0,0 +1,68 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+type NamespaceOffboardingHandler struct {
+	offboardingService services.NamespaceOffboardingServiceInterface
+	logger             *logrus.Logger
+}
+
+func NewNamespaceOffboardingHandler(offboardingService services.NamespaceOffboardingServiceInterface, logger *logrus.Logger) *NamespaceOffboardingHandler {
+	return &NamespaceOffboardingHandler{
+		offboardingService: offboardingService,
+		logger:             logger,
+	}
+}
+
+// OffboardNamespace handles DELETE /namespaces/:namespace/issues, starting to
+// purge or archive the namespace in the background. Responds with 202 and the
+// job, whose progress is polled at the URL of the Location header.
+func (h *NamespaceOffboardingHandler) OffboardNamespace(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	mode := dto.OffboardingMode(c.DefaultQuery("mode", string(dto.OffboardingModePurge)))
+	if mode != dto.OffboardingModePurge && mode != dto.OffboardingModeArchive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid mode %q, must be purge or archive", mode)})
+		return
+	}
+
+	job, err := h.offboardingService.StartOffboarding(c.Request.Context(), namespace, mode, middleware.User(c))
+	if err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to start namespace offboarding")
+		respondWithServerError(c, err, "Failed to start namespace offboarding")
+		return
+	}
+
+	c.Header("Location", "/api/"+APIVersion+"/admin/offboarding-jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetOffboardingJob handles GET /admin/offboarding-jobs/:id, returning the
+// progress of a namespace offboarding
+func (h *NamespaceOffboardingHandler) GetOffboardingJob(c *gin.Context) {
+	id := c.Param("id")
+
+	job, err := h.offboardingService.GetOffboardingJob(c.Request.Context(), id)
+	if err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("job_id", id).Error("Failed to fetch offboarding job")
+		respondWithServerError(c, err, "Failed to fetch offboarding job")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTestNamespaceOffboardingRouter creates a test router offboarding namespaces with a mock service, admin being the admin
+func setupTestNamespaceOffboardingRouter(mockService *MockNamespaceOffboardingService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewNamespaceOffboardingHandler(mockService, logger)
+	requireAdmin := middleware.RequireAdmin("X-Forwarded-User", []string{"admin"})
+
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	{
+		v1.DELETE("/namespaces/:namespace/issues", requireAdmin, handler.OffboardNamespace)
+		v1.GET("/admin/offboarding-jobs/:id", requireAdmin, middleware.ValidateID(), handler.GetOffboardingJob)
+	}
+	return router
+}
+
+func TestNamespaceOffboardingHandler_OffboardNamespace(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		user           string
+		startError     error
+		expectedStatus int
+		expectedMode   dto.OffboardingMode
+	}{
+		{
+			name:           "purge by default",
+			path:           "/api/v1/namespaces/team-alpha/issues",
+			user:           "admin",
+			expectedStatus: net_http.StatusAccepted,
+			expectedMode:   dto.OffboardingModePurge,
+		},
+		{
+			name:           "archive",
+			path:           "/api/v1/namespaces/team-alpha/issues?mode=archive",
+			user:           "admin",
+			expectedStatus: net_http.StatusAccepted,
+			expectedMode:   dto.OffboardingModeArchive,
+		},
+		{
+			name:           "invalid mode",
+			path:           "/api/v1/namespaces/team-alpha/issues?mode=export",
+			user:           "admin",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "not an admin",
+			path:           "/api/v1/namespaces/team-alpha/issues",
+			user:           "alice",
+			expectedStatus: net_http.StatusForbidden,
+		},
+		{
+			name:           "already in progress",
+			path:           "/api/v1/namespaces/team-alpha/issues",
+			user:           "admin",
+			startError:     repository.ConflictError("namespace offboarding already in progress"),
+			expectedStatus: net_http.StatusConflict,
+		},
+		{
+			name:           "database error",
+			path:           "/api/v1/namespaces/team-alpha/issues",
+			user:           "admin",
+			startError:     errors.New("database error"),
+			expectedStatus: net_http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockNamespaceOffboardingService{startError: tc.startError}
+			router := setupTestNamespaceOffboardingRouter(mockService)
+
+			req, _ := net_http.NewRequest("DELETE", tc.path, nil)
+			req.Header.Set("X-Forwarded-User", tc.user)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusAccepted {
+				return
+			}
+			if mockService.lastMode != tc.expectedMode || mockService.lastUser != "admin" {
+				t.Errorf("Expected a %s by admin, got a %s by %q", tc.expectedMode, mockService.lastMode, mockService.lastUser)
+			}
+			var job dto.OffboardingJob
+			if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if job.Namespace != "team-alpha" || w.Header().Get("Location") != "/api/v1/admin/offboarding-jobs/"+job.ID {
+				t.Errorf("Expected the job of team-alpha and its location, got %+v at %q", job, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestNamespaceOffboardingHandler_GetOffboardingJob(t *testing.T) {
+	mockService := &MockNamespaceOffboardingService{
+		getResult: &dto.OffboardingJob{
+			ID:              "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f",
+			Namespace:       "team-alpha",
+			Status:          dto.OffboardingStatusRunning,
+			Issues:          1200,
+			IssuesProcessed: 500,
+		},
+	}
+	router := setupTestNamespaceOffboardingRouter(mockService)
+	get := func(id string) *net_httptest.ResponseRecorder {
+		req, _ := net_http.NewRequest("GET", "/api/v1/admin/offboarding-jobs/"+id, nil)
+		req.Header.Set("X-Forwarded-User", "admin")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f")
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var job dto.OffboardingJob
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if job.Issues != 1200 || job.IssuesProcessed != 500 {
+		t.Errorf("Expected the progress of the job, got %+v", job)
+	}
+
+	if w := get("not-a-uuid"); w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	mockService.getError = repository.NotFoundError("offboarding job not found")
+	if w := get("7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f"); w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+type NamespaceSettingsHandler struct {
+	settingsService services.NamespaceSettingsServiceInterface
+	logger          *logrus.Logger
+}
+
+func NewNamespaceSettingsHandler(settingsService services.NamespaceSettingsServiceInterface, logger *logrus.Logger) *NamespaceSettingsHandler {
+	return &NamespaceSettingsHandler{
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// GetSettings handles GET /namespaces/:namespace/settings, returning the
+// defaults for namespaces without settings
+func (h *NamespaceSettingsHandler) GetSettings(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	settings, err := h.settingsService.GetSettings(c.Request.Context(), namespace)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to fetch namespace settings")
+		respondWithServerError(c, err, "Failed to fetch namespace settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /namespaces/:namespace/settings
+func (h *NamespaceSettingsHandler) UpdateSettings(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	var req dto.UpdateNamespaceSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if err := validateNamespaceSettings(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.settingsService.UpdateSettings(c.Request.Context(), namespace, req)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to update namespace settings")
+		respondWithServerError(c, err, "Failed to update namespace settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// ResetSettings handles DELETE /namespaces/:namespace/settings, resetting the
+// settings of the namespace to the defaults
+func (h *NamespaceSettingsHandler) ResetSettings(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	if err := h.settingsService.ResetSettings(c.Request.Context(), namespace); err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to reset namespace settings")
+		respondWithServerError(c, err, "Failed to reset namespace settings")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// validateNamespaceSettings validates the SLA targets aren't negative, the
+// notification severity is a valid severity, if any, the workflow is valid,
+// if any, and so are the link templates
+func validateNamespaceSettings(req dto.UpdateNamespaceSettingsRequest) error {
+	sla := req.SLATargets
+	if sla.CriticalHours < 0 || sla.MajorHours < 0 || sla.MinorHours < 0 || sla.InfoHours < 0 {
+		return errors.New("SLA targets cannot be negative")
+	}
+
+	if req.Notifications.MinSeverity != "" && !req.Notifications.MinSeverity.Valid() {
+		return errors.New("invalid notification severity value")
+	}
+
+	if req.Workflow != nil {
+		if err := req.Workflow.Validate(); err != nil {
+			return fmt.Errorf("invalid workflow: %w", err)
+		}
+	}
+
+	for _, template := range req.LinkTemplates {
+		if err := template.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTestNamespaceSettingsRouter creates a test router serving the namespace settings of a mock service
+func setupTestNamespaceSettingsRouter(mockService *MockNamespaceSettingsService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewNamespaceSettingsHandler(mockService, logger)
+
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	{
+		v1.GET("/namespaces/:namespace/settings", handler.GetSettings)
+		v1.PUT("/namespaces/:namespace/settings", handler.UpdateSettings)
+		v1.DELETE("/namespaces/:namespace/settings", handler.ResetSettings)
+	}
+	return router
+}
+
+func TestNamespaceSettingsHandler_GetSettings(t *testing.T) {
+	mockService := &MockNamespaceSettingsService{
+		getSettingsResult: &models.NamespaceSettings{Namespace: "team-alpha", RetentionDays: 30},
+	}
+	router := setupTestNamespaceSettingsRouter(mockService)
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/namespaces/team-alpha/settings", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var settings models.NamespaceSettings
+	if err := json.Unmarshal(w.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if settings.Namespace != "team-alpha" || settings.RetentionDays != 30 {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+
+	mockService.getSettingsError = errors.New("database error")
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
+
+func TestNamespaceSettingsHandler_UpdateSettings(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "valid settings",
+			body:           `{"retentionDays": 30, "dedupWindowHours": 24, "slaTargets": {"criticalHours": 4}, "notifications": {"channel": "#alerts", "minSeverity": "major"}}`,
+			expectedStatus: net_http.StatusOK,
+		},
+		{
+			name:           "empty settings",
+			body:           `{}`,
+			expectedStatus: net_http.StatusOK,
+		},
+		{
+			name:           "negative retention",
+			body:           `{"retentionDays": -1}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "negative SLA target",
+			body:           `{"slaTargets": {"majorHours": -4}}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid notification severity",
+			body:           `{"notifications": {"minSeverity": "urgent"}}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "custom workflow",
+			body:           `{"workflow": {"states": ["TRIAGED"], "transitions": {"ACTIVE": ["TRIAGED"], "TRIAGED": ["RESOLVED"]}}}`,
+			expectedStatus: net_http.StatusOK,
+		},
+		{
+			name:           "workflow with unknown state",
+			body:           `{"workflow": {"states": ["TRIAGED"], "transitions": {"ACTIVE": ["CLOSED"]}}}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"retentionDays": "forever"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockNamespaceSettingsService{}
+			router := setupTestNamespaceSettingsRouter(mockService)
+
+			req, _ := net_http.NewRequest("PUT", "/api/v1/namespaces/team-alpha/settings", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK && mockService.updateSettingsRequest != nil {
+				t.Error("Expected invalid settings not to be saved")
+			}
+		})
+	}
+}
+
+func TestNamespaceSettingsHandler_ResetSettings(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "reset", expectedStatus: net_http.StatusNoContent},
+		{name: "no settings", err: repository.NotFoundError("namespace settings not found"), expectedStatus: net_http.StatusNotFound},
+		{name: "database error", err: errors.New("database error"), expectedStatus: net_http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := setupTestNamespaceSettingsRouter(&MockNamespaceSettingsService{resetSettingsError: tc.err})
+
+			req, _ := net_http.NewRequest("DELETE", "/api/v1/namespaces/team-alpha/settings", nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, w.Code)
+			}
+		})
+	}
+}
@@ -1,16 +1,42 @@
 package http
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/blob"
 	kiteConf "github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/normalize"
 	"github.com/konflux-ci/kite/internal/repository"
 	"github.com/konflux-ci/kite/internal/services"
+	"github.com/konflux-ci/kite/internal/startup"
+	"github.com/konflux-ci/kite/internal/version"
+	"github.com/konflux-ci/kite/internal/workers"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-func SetupRouter(db *gorm.DB, logger *logrus.Logger) (*gin.Engine, error) {
+// APIVersion is the version of the API served by this router. Clients use it
+// to detect servers they aren't compatible with.
+const APIVersion = "v1"
+
+// SetupRouter sets up the routes of the API, along with their middleware,
+// handlers, services and repositories.
+//
+// Parameters:
+//   - db: The database connection
+//   - cfg: The configuration, see kiteConf.Load
+//   - checker: The startup checks, requests being refused until they pass. Nil to skip them.
+//   - backgroundWorkers: The background workers running the jobs of the admin endpoints
+//   - logger: The logger
+//
+// Returns:
+//   - *gin.Engine
+//   - error: The configuration is invalid
+func SetupRouter(db *gorm.DB, cfg *kiteConf.Config, checker *startup.Checker, backgroundWorkers *workers.Group, logger *logrus.Logger) (*gin.Engine, error) {
 	// Set Gin mode based on environment
 	if gin.Mode() == gin.DebugMode {
 		gin.SetMode(gin.DebugMode)
@@ -22,41 +48,150 @@ func SetupRouter(db *gorm.DB, logger *logrus.Logger) (*gin.Engine, error) {
 
 	// Setup middleware
 	router.Use(middleware.Logger(logger))
-	router.Use(middleware.ErrorHandler(logger))
-	router.Use(middleware.CORS())
-	router.Use(gin.Recovery())
+	// Panics and server errors are reported to Sentry when it's configured
+	var reporter middleware.ErrorReporter
+	if cfg.Sentry.DSN != "" {
+		sentryReporter, err := middleware.NewSentryReporter(cfg.Sentry, cfg.Server.Environment, version.Version)
+		if err != nil {
+			return nil, err
+		}
+		reporter = sentryReporter
+	}
+	router.Use(middleware.ErrorHandler(logger, reporter))
+	if cfg.Security.EnableCORS {
+		cors, err := middleware.CORS(cfg.Security)
+		if err != nil {
+			return nil, err
+		}
+		router.Use(cors)
+	}
+	// Health checks and scrapes are served while starting, and never queued.
+	// Requests wait for their turn before their timeout starts.
+	systemPaths := []string{"/api/" + APIVersion + "/health", "/api/" + APIVersion + "/health/", "/api/" + APIVersion + "/version", "/api/" + APIVersion + "/version/", "/metrics"}
+	if checker != nil {
+		router.Use(middleware.RequireReady(checker.Ready, systemPaths...))
+	}
+	router.Use(middleware.LimitConcurrency(cfg.Concurrency, systemPaths...))
+	router.Use(middleware.Timeout(cfg.Timeouts))
 
-	// Initialize repository
-	issueRepo := repository.NewIssueRepository(db, logger)
+	// Initialize repository, decorators add cross-cutting concerns to all its calls
+	dbConf := cfg.Database
+	decorators := []repository.IssueRepositoryDecorator{
+		repository.WithInterceptors(repository.LogSlowCalls(logger, dbConf.SlowQueryThreshold)),
+	}
+	issueRepo := repository.DecorateIssueRepository(repository.NewIssueRepository(db, logger, dbConf.QueryTimeout), decorators...)
+	unitOfWork := repository.NewUnitOfWork(db, logger, dbConf.QueryTimeout, decorators...)
 	// Initialize services
-	issueService := services.NewIssueService(issueRepo, logger)
+	linkTemplates, err := defaultLinkTemplates(cfg.Links)
+	if err != nil {
+		return nil, err
+	}
+	issueService := services.NewIssueService(issueRepo, unitOfWork, repository.NewNamespaceSettingsRepository(db, logger, dbConf.QueryTimeout), linkTemplates, logger)
+	settingsService := services.NewNamespaceSettingsService(repository.NewNamespaceSettingsRepository(db, logger, dbConf.QueryTimeout), logger)
+	watchService := services.NewIssueWatchService(issueRepo, repository.NewIssueWatchRepository(db, logger, dbConf.QueryTimeout), logger)
+	viewService := services.NewSavedViewService(repository.NewSavedViewRepository(db, logger, dbConf.QueryTimeout), logger)
+	triageService := services.NewTriageRuleService(issueRepo, repository.NewTriageRuleRepository(db, logger, dbConf.QueryTimeout), logger)
+	// The subsystems the API depends on are checked by the health endpoint when they're configured
+	healthChecks, err := NewHealthChecks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Large webhook payloads are stored as objects when the blob storage is configured
+	var payloadStorage services.PayloadStorage
+	if cfg.BlobStorage.Endpoint != "" {
+		blobClient, err := blob.NewClient(cfg.BlobStorage)
+		if err != nil {
+			return nil, err
+		}
+		payloadStorage = blobClient
+	}
+	eventService := services.NewWebhookEventService(repository.NewWebhookEventRepository(db, logger, dbConf.QueryTimeout),
+		payloadStorage, cfg.BlobStorage.OffloadThreshold, logger)
+	activityService := services.NewIssueActivityService(issueRepo, repository.NewTriageRuleRepository(db, logger, dbConf.QueryTimeout),
+		repository.NewWebhookEventRepository(db, logger, dbConf.QueryTimeout), logger)
+	timelineService := services.NewScopeTimelineService(issueRepo, logger)
+	offboardingService := services.NewNamespaceOffboardingService(repository.NewNamespaceRepository(db, logger, dbConf.QueryTimeout), backgroundWorkers, logger)
+	maintenanceService := services.NewMaintenanceService(repository.NewMaintenanceRepository(db, logger), backgroundWorkers, logger)
+	dashboardService := services.NewDashboardService(issueRepo, repository.NewNamespaceSettingsRepository(db, logger, dbConf.QueryTimeout), logger)
 
 	// Initialize handlers
-	issueHandler := NewIssueHandler(issueService, logger)
-	webhookHandler := NewWebhookHandler(issueService, logger)
+	issueHandler := NewIssueHandler(issueService, cfg.Limits, cfg.Resolution, logger)
+	webhookHandler := NewWebhookHandler(issueService, eventService, cfg.Limits, cfg.Security.WebhookSecret,
+		normalize.NewNormalizer(cfg.Normalization), logger)
+	settingsHandler := NewNamespaceSettingsHandler(settingsService, logger)
+	watchHandler := NewIssueWatchHandler(watchService, logger)
+	viewHandler := NewSavedViewHandler(viewService, logger)
+	triageHandler := NewTriageRuleHandler(triageService, logger)
+	dashboardHandler := NewDashboardHandler(dashboardService, logger)
+	activityHandler := NewIssueActivityHandler(activityService, logger)
+	timelineHandler := NewScopeTimelineHandler(timelineService, logger)
+	offboardingHandler := NewNamespaceOffboardingHandler(offboardingService, logger)
+	maintenanceHandler := NewMaintenanceHandler(maintenanceService, logger)
+	identifyUser := middleware.IdentifyUser(cfg.Security.UserHeader)
+	requireUser := middleware.RequireUser(cfg.Security.UserHeader)
+	requireAdmin := middleware.RequireAdmin(cfg.Security.UserHeader, cfg.Security.AdminUsers)
 
 	// Initialize namespace checker
 	namespaceChecker, err := middleware.NewNamespaceChecker(logger)
 	if err != nil {
 		logger.WithError(err).Warn("Failed to initialize namespace checker")
 	}
+	// The namespaced routes serve the public namespaces to anonymous requests when
+	// there's any, and the namespaces granted to their users in strict tenancy
+	var namespaced []gin.HandlerFunc
+	if len(cfg.Public.Namespaces) > 0 {
+		namespaced = append(namespaced, middleware.PublicReadOnly(cfg.Security.UserHeader, cfg.Public.Namespaces, cfg.Public.RedactedLinks))
+	}
+	if cfg.Security.StrictTenancy {
+		namespaced = append(namespaced, middleware.RequireNamespaceClaim(cfg.Security.UserHeader, cfg.Security.NamespacesHeader, cfg.Security.AdminUsers))
+	}
+	if namespaceChecker != nil {
+		namespaced = append(namespaced, namespaceChecker.CheckNamespacessAccess())
+	}
+
 	// API v1 routes
-	v1 := router.Group("/api/v1")
+	v1 := router.Group("/api/" + APIVersion)
 
 	// Issues routes with namespace checking
-	issuesGroup := v1.Group("/issues")
-	if namespaceChecker != nil {
-		issuesGroup.Use(namespaceChecker.CheckNamespacessAccess())
-	}
+	issuesGroup := v1.Group("/issues", namespaced...)
 	{
-		issuesGroup.GET("/", issueHandler.GetIssues)
-		issuesGroup.POST("/", issueHandler.CreateIssue)
+		// Personal views are only found for identified users
+		handleRoot(issuesGroup, http.MethodGet, identifyUser, viewHandler.ExpandView, issueHandler.GetIssues)
+		issuesGroup.GET("/grouped", identifyUser, viewHandler.ExpandView, issueHandler.GetIssuesGrouped)
+		handleRoot(issuesGroup, http.MethodPost, issueHandler.CreateIssue)
+		issuesGroup.POST("/check-duplicate", issueHandler.CheckDuplicate)
 		issuesGroup.GET("/:id", middleware.ValidateID(), issueHandler.GetIssue)
+		issuesGroup.GET("/:id/similar", middleware.ValidateID(), issueHandler.GetSimilarIssues)
 		issuesGroup.PUT("/:id", middleware.ValidateID(), issueHandler.UpdateIssue)
 		issuesGroup.DELETE("/:id", middleware.ValidateID(), issueHandler.DeleteIssue)
 		issuesGroup.POST("/:id/resolve", middleware.ValidateID(), issueHandler.ResolveIssue)
+		issuesGroup.POST("/:id/ack", middleware.ValidateID(), identifyUser, issueHandler.AcknowledgeIssue)
+		issuesGroup.POST("/:id/snooze", middleware.ValidateID(), identifyUser, issueHandler.SnoozeIssue)
+		issuesGroup.DELETE("/:id/snooze", middleware.ValidateID(), issueHandler.WakeIssue)
+		issuesGroup.GET("/:id/related", middleware.ValidateID(), issueHandler.GetRelatedIssues)
 		issuesGroup.POST("/:id/related", middleware.ValidateID(), issueHandler.AddRelatedIssue)
 		issuesGroup.DELETE("/:id/related/:relatedId", middleware.ValidateID(), issueHandler.RemoveRelatedIssue)
+		issuesGroup.POST("/:id/external-refs", middleware.ValidateID(), issueHandler.AddExternalRef)
+		issuesGroup.DELETE("/:id/external-refs/:refId", middleware.ValidateID(), issueHandler.RemoveExternalRef)
+		issuesGroup.POST("/:id/watch", middleware.ValidateID(), requireUser, watchHandler.WatchIssue)
+		issuesGroup.DELETE("/:id/watch", middleware.ValidateID(), requireUser, watchHandler.UnwatchIssue)
+		issuesGroup.GET("/:id/triage-events", middleware.ValidateID(), triageHandler.GetEvents)
+		issuesGroup.GET("/:id/activity", middleware.ValidateID(), activityHandler.GetActivity)
+	}
+
+	// Scope routes with namespace checking
+	scopesGroup := v1.Group("/scopes", namespaced...)
+	{
+		scopesGroup.GET("/:id/timeline", middleware.ValidateID(), timelineHandler.GetTimeline)
+	}
+
+	// Routes of the user of the request
+	meGroup := v1.Group("/me", requireUser)
+	{
+		meGroup.GET("/watched", watchHandler.GetWatchedIssues)
+		meGroup.GET("/views", viewHandler.GetViews)
+		meGroup.PUT("/views/:name", viewHandler.SaveView)
+		meGroup.DELETE("/views/:name", viewHandler.DeleteView)
 	}
 
 	// Webhook routes with namespace checking
@@ -67,20 +202,88 @@ func SetupRouter(db *gorm.DB, logger *logrus.Logger) (*gin.Engine, error) {
 	{
 		webhooksGroup.POST("/pipeline-failure", webhookHandler.PipelineFailure)
 		webhooksGroup.POST("/pipeline-success", webhookHandler.PipelineSuccess)
+		webhooksGroup.POST("/batch", webhookHandler.PipelineBatch)
+	}
+
+	// Namespace routes with namespace checking
+	namespacesGroup := v1.Group("/namespaces", namespaced...)
+	{
+		namespacesGroup.GET("/:namespace/settings", settingsHandler.GetSettings)
+		namespacesGroup.PUT("/:namespace/settings", settingsHandler.UpdateSettings)
+		namespacesGroup.DELETE("/:namespace/settings", settingsHandler.ResetSettings)
+		namespacesGroup.GET("/:namespace/views", viewHandler.GetViews)
+		namespacesGroup.PUT("/:namespace/views/:name", viewHandler.SaveView)
+		namespacesGroup.DELETE("/:namespace/views/:name", viewHandler.DeleteView)
+		namespacesGroup.GET("/:namespace/triage-rules", triageHandler.GetRules)
+		namespacesGroup.PUT("/:namespace/triage-rules/:name", triageHandler.SaveRule)
+		namespacesGroup.DELETE("/:namespace/triage-rules/:name", triageHandler.DeleteRule)
+		// Offboarding a namespace removes all its issues, only admins may
+		namespacesGroup.DELETE("/:namespace/issues", requireAdmin, offboardingHandler.OffboardNamespace)
+	}
+
+	// Dashboard routes with namespace checking
+	dashboardGroup := v1.Group("/dashboard", namespaced...)
+	{
+		handleRoot(dashboardGroup, http.MethodGet, dashboardHandler.GetDashboard)
+	}
+
+	// Status routes, backing the external status pages of namespaces
+	statusGroup := v1.Group("/status", namespaced...)
+	{
+		statusGroup.GET("/:namespace", dashboardHandler.GetStatus)
+	}
+
+	// Admin routes, across namespaces
+	adminGroup := v1.Group("/admin", requireAdmin)
+	{
+		adminGroup.GET("/webhook-events", webhookHandler.GetEvents)
+		adminGroup.GET("/webhook-events/:id", middleware.ValidateID(), webhookHandler.GetEvent)
+		adminGroup.POST("/webhook-events/:id/replay", middleware.ValidateID(), webhookHandler.ReplayEvent)
+		adminGroup.GET("/offboarding-jobs/:id", middleware.ValidateID(), offboardingHandler.GetOffboardingJob)
+		adminGroup.GET("/dedup-stats", NewDedupStatsHandler())
+		adminGroup.POST("/maintenance-jobs", maintenanceHandler.StartMaintenance)
+		adminGroup.GET("/maintenance-jobs/:id", middleware.ValidateID(), maintenanceHandler.GetMaintenanceJob)
 	}
 
 	// Health and version endpoints
 	healthGroup := v1.Group("/health")
-	healthGroup.GET("/", NewHealthHandler(db, logger))
+	handleRoot(healthGroup, http.MethodGet, NewHealthHandler(db, healthChecks, checker, cfg.Health, logger))
 
 	versionGroup := v1.Group("/version")
-	versionGroup.GET("/", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"name":        "Konflux Issues Dashboard API",
-			"description": "The backend service that powers the Konflux Issues Dashboard",
-			"version":     kiteConf.GetEnvOrDefault("KITE_VERSION", "0.0.1"),
-		})
-	})
+	handleRoot(versionGroup, http.MethodGet, NewVersionHandler())
+
+	// Prometheus metrics, at the path Prometheus scrapes by default
+	router.GET("/metrics", NewMetricsHandler())
 
 	return router, nil
 }
+
+// handleRoot registers the root route of a group both without and with a
+// trailing slash, e.g. /api/v1/issues and /api/v1/issues/, so that clients
+// calling either form are served rather than redirected. Gin redirects GET
+// requests with a 301, which clients follow, but other requests with a 307,
+// which some clients don't.
+func handleRoot(group *gin.RouterGroup, method string, handlers ...gin.HandlerFunc) {
+	group.Handle(method, "", handlers...)
+	group.Handle(method, "/", handlers...)
+}
+
+// defaultLinkTemplates returns the link templates of the issues of all the
+// namespaces, e.g. the logs of the runs of pipeline failures
+func defaultLinkTemplates(cfg kiteConf.LinksConfig) ([]models.LinkTemplate, error) {
+	var templates []models.LinkTemplate
+	if cfg.PipelineLogsTemplate != "" {
+		templates = append(templates, models.LinkTemplate{
+			Title:        "Pipeline Run Logs",
+			URL:          cfg.PipelineLogsTemplate,
+			IssueType:    models.IssueTypePipeline,
+			ResourceType: "pipelinerun",
+		})
+	}
+	for _, template := range templates {
+		if err := template.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid default link template: %w", err)
+		}
+	}
+	return templates, nil
+}
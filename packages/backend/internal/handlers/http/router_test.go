@@ -0,0 +1,282 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	kiteConf "github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/konflux-ci/kite/internal/workers"
+	"github.com/sirupsen/logrus"
+)
+
+func setupTestRouter(t *testing.T) *gin.Engine {
+	return setupTestRouterWithConfig(t, &kiteConf.Config{})
+}
+
+// setupTestRouterWithConfig sets up the router of the API, with its services
+// and repositories over a test database
+func setupTestRouterWithConfig(t *testing.T, cfg *kiteConf.Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	router, err := SetupRouter(testhelpers.SetupTestDB(t), cfg, nil, workers.NewGroup(logger), logger)
+	if err != nil {
+		t.Fatalf("Failed to set up router: %v", err)
+	}
+	return router
+}
+
+func TestSetupRouter_TrailingSlash(t *testing.T) {
+	router := setupTestRouter(t)
+
+	// Root routes are served with and without trailing slash, the CLI calling them without
+	routes := router.Routes()
+	for _, route := range []string{
+		"GET /api/v1/issues",
+		"POST /api/v1/issues",
+		"GET /api/v1/dashboard",
+		"GET /api/v1/health",
+		"GET /api/v1/version",
+	} {
+		for _, path := range []string{route, route + "/"} {
+			if !slices.ContainsFunc(routes, func(info gin.RouteInfo) bool { return info.Method+" "+info.Path == path }) {
+				t.Errorf("Expected the route %s", path)
+			}
+		}
+	}
+
+	for _, path := range []string{"/api/v1/version", "/api/v1/version/"} {
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, net_httptest.NewRequest(net_http.MethodGet, path, nil))
+		if w.Code != net_http.StatusOK {
+			t.Errorf("Expected %s to be served, got %d", path, w.Code)
+		}
+	}
+
+	// Other routes are redirected to their registered form
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, net_httptest.NewRequest(net_http.MethodGet, "/api/v1/admin/webhook-events/", nil))
+	if w.Code != net_http.StatusMovedPermanently || w.Header().Get("Location") != "/api/v1/admin/webhook-events" {
+		t.Errorf("Expected a redirection to /api/v1/admin/webhook-events, got %d to %q", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestSetupRouter_StrictTenancy(t *testing.T) {
+	router := setupTestRouterWithConfig(t, &kiteConf.Config{
+		Limits: kiteConf.GetLimitsConfig(),
+		Security: kiteConf.SecurityConfig{
+			StrictTenancy:    true,
+			UserHeader:       "X-Forwarded-User",
+			NamespacesHeader: "X-Forwarded-Namespaces",
+			AdminUsers:       []string{"admin"},
+		},
+	})
+
+	// Users and the namespaces granted to them
+	users := map[string]string{"alice": "team-alpha", "mallory": "team-beta"}
+	serve := func(method, path, user, body string) *net_httptest.ResponseRecorder {
+		req := net_httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if user != "" {
+			req.Header.Set("X-Forwarded-User", user)
+			req.Header.Set("X-Forwarded-Namespaces", users[user])
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	mustServe := func(method, path, user, body string, target any) {
+		t.Helper()
+		w := serve(method, path, user, body)
+		if w.Code >= 300 {
+			t.Fatalf("%s %s: expected success, got %d: %s", method, path, w.Code, w.Body.String())
+		}
+		if target != nil {
+			if err := json.Unmarshal(w.Body.Bytes(), target); err != nil {
+				t.Fatalf("%s %s: failed to parse response: %v", method, path, err)
+			}
+		}
+	}
+	create := func(user, resource string) models.Issue {
+		namespace := users[user]
+		body := fmt.Sprintf(`{"title": "Build failed", "description": "Compilation failed", "severity": "major",
+			"issueType": "build", "namespace": %q, "scope": {"resourceType": "component", "resourceName": %q}}`, namespace, resource)
+		var issue models.Issue
+		mustServe(net_http.MethodPost, "/api/v1/issues?namespace="+namespace, user, body, &issue)
+		return issue
+	}
+
+	victim := create("alice", "api")
+	cause := create("alice", "database")
+	mustServe(net_http.MethodPost, "/api/v1/issues/"+victim.ID+"/related?namespace=team-alpha", "alice",
+		fmt.Sprintf(`{"relatedId": %q, "type": "caused-by"}`, cause.ID), nil)
+	var ref models.ExternalRef
+	mustServe(net_http.MethodPost, "/api/v1/issues/"+victim.ID+"/external-refs?namespace=team-alpha", "alice",
+		`{"system": "jira", "key": "KFLUX-1", "url": "https://issues.example.com/browse/KFLUX-1"}`, &ref)
+	own := create("mallory", "api")
+
+	// Mallory reaches the issues of team-alpha by their ID, from her namespace
+	for _, route := range []struct{ method, path, body string }{
+		{net_http.MethodGet, "/issues/" + victim.ID, ""},
+		{net_http.MethodGet, "/issues/" + victim.ID + "/similar", ""},
+		{net_http.MethodPut, "/issues/" + victim.ID, `{"title": "Pwned"}`},
+		{net_http.MethodPost, "/issues/" + victim.ID + "/resolve", `{"reason": "Pwned"}`},
+		{net_http.MethodPost, "/issues/" + victim.ID + "/ack", ""},
+		{net_http.MethodPost, "/issues/" + victim.ID + "/snooze", fmt.Sprintf(`{"until": %q}`, time.Now().Add(time.Hour).Format(time.RFC3339))},
+		{net_http.MethodDelete, "/issues/" + victim.ID + "/snooze", ""},
+		{net_http.MethodGet, "/issues/" + victim.ID + "/related", ""},
+		{net_http.MethodPost, "/issues/" + victim.ID + "/related", fmt.Sprintf(`{"relatedId": %q}`, own.ID)},
+		{net_http.MethodPost, "/issues/" + own.ID + "/related", fmt.Sprintf(`{"relatedId": %q}`, victim.ID)},
+		{net_http.MethodDelete, "/issues/" + victim.ID + "/related/" + cause.ID, ""},
+		{net_http.MethodPost, "/issues/" + victim.ID + "/external-refs", `{"system": "jira", "key": "PWN-1", "url": "https://evil.example.com"}`},
+		{net_http.MethodDelete, "/issues/" + victim.ID + "/external-refs/" + ref.ID, ""},
+		{net_http.MethodPost, "/issues/" + victim.ID + "/watch", ""},
+		{net_http.MethodDelete, "/issues/" + victim.ID + "/watch", ""},
+		{net_http.MethodGet, "/issues/" + victim.ID + "/triage-events", ""},
+		{net_http.MethodGet, "/issues/" + victim.ID + "/activity", ""},
+		{net_http.MethodGet, "/scopes/" + victim.ScopeID + "/timeline", ""},
+		{net_http.MethodDelete, "/issues/" + victim.ID, ""},
+	} {
+		path := "/api/v1" + route.path
+		if w := serve(route.method, path+"?namespace=team-beta", "mallory", route.body); w.Code != net_http.StatusForbidden && w.Code != net_http.StatusNotFound {
+			t.Errorf("%s %s from team-beta: expected 403 or 404, got %d: %s", route.method, route.path, w.Code, w.Body.String())
+		}
+		if w := serve(route.method, path+"?namespace=team-alpha", "mallory", route.body); w.Code != net_http.StatusForbidden {
+			t.Errorf("%s %s claiming team-alpha: expected 403, got %d", route.method, route.path, w.Code)
+		}
+		if w := serve(route.method, path, "mallory", route.body); w.Code != net_http.StatusBadRequest {
+			t.Errorf("%s %s without namespace: expected 400, got %d", route.method, route.path, w.Code)
+		}
+		if w := serve(route.method, path+"?namespace=team-alpha", "", route.body); w.Code != net_http.StatusUnauthorized {
+			t.Errorf("%s %s without user: expected 401, got %d", route.method, route.path, w.Code)
+		}
+	}
+
+	// Listings only serve the issues of the namespace granted
+	var list struct {
+		Data []models.Issue `json:"data"`
+	}
+	mustServe(net_http.MethodGet, "/api/v1/issues?namespace=team-beta", "mallory", "", &list)
+	if len(list.Data) != 1 || list.Data[0].ID != own.ID {
+		t.Errorf("Expected only the issue of team-beta, got %+v", list.Data)
+	}
+	if w := serve(net_http.MethodGet, "/api/v1/issues", "mallory", ""); w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected listings without namespace to be refused, got %d", w.Code)
+	}
+
+	// The issue of team-alpha is left untouched
+	var issue models.Issue
+	mustServe(net_http.MethodGet, "/api/v1/issues/"+victim.ID+"?namespace=team-alpha", "alice", "", &issue)
+	if issue.Title != victim.Title || issue.State != models.IssueStateActive || issue.AcknowledgedAt != nil || issue.SnoozedUntil != nil {
+		t.Errorf("Expected the issue of team-alpha unchanged, got %+v", issue)
+	}
+	if len(issue.ExternalRefs) != 1 || issue.ExternalRefs[0].ID != ref.ID {
+		t.Errorf("Expected the external reference of team-alpha only, got %+v", issue.ExternalRefs)
+	}
+	var related struct {
+		Data []dto.RelatedIssueEntry `json:"data"`
+	}
+	mustServe(net_http.MethodGet, "/api/v1/issues/"+victim.ID+"/related?namespace=team-alpha", "alice", "", &related)
+	if len(related.Data) != 1 {
+		t.Errorf("Expected the relationship of team-alpha only, got %+v", related.Data)
+	}
+	mustServe(net_http.MethodGet, "/api/v1/issues/"+own.ID+"/related?namespace=team-beta", "mallory", "", &related)
+	if len(related.Data) != 0 {
+		t.Errorf("Expected no relationship to team-alpha, got %+v", related.Data)
+	}
+
+	// Admins query any namespace
+	mustServe(net_http.MethodGet, "/api/v1/issues/"+victim.ID+"?namespace=team-alpha", "admin", "", nil)
+}
+
+func TestSetupRouter_PublicNamespacesInStrictTenancy(t *testing.T) {
+	router := setupTestRouterWithConfig(t, &kiteConf.Config{
+		Limits: kiteConf.GetLimitsConfig(),
+		Security: kiteConf.SecurityConfig{
+			StrictTenancy:    true,
+			UserHeader:       "X-Forwarded-User",
+			NamespacesHeader: "X-Forwarded-Namespaces",
+			AdminUsers:       []string{"admin"},
+		},
+		Public: kiteConf.PublicAccessConfig{
+			Namespaces:    []string{"status"},
+			RedactedLinks: regexp.MustCompile(`^https://console\.internal/`),
+		},
+	})
+
+	serve := func(method, path, user, namespaces, accept string) *net_httptest.ResponseRecorder {
+		req := net_httptest.NewRequest(method, path, strings.NewReader(`{"title": "Pwned"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if user != "" {
+			req.Header.Set("X-Forwarded-User", user)
+			req.Header.Set("X-Forwarded-Namespaces", namespaces)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"title": "Build failed", "description": "Compilation failed", "severity": "major", "issueType": "build",
+		"namespace": "status", "scope": {"resourceType": "component", "resourceName": "api"},
+		"links": [{"title": "Logs", "url": "https://console.internal/logs/1"}, {"title": "Docs", "url": "https://docs.example.com"}]}`
+	req := net_httptest.NewRequest(net_http.MethodPost, "/api/v1/issues?namespace=status", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-User", "admin")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Failed to create issue, got %d: %s", w.Code, w.Body.String())
+	}
+	var issue models.Issue
+	if err := json.Unmarshal(w.Body.Bytes(), &issue); err != nil {
+		t.Fatalf("Failed to parse issue: %v", err)
+	}
+
+	// Anonymous requests read the public namespace without claiming it
+	for _, path := range []string{"/api/v1/issues?namespace=status", "/api/v1/issues/" + issue.ID + "?namespace=status"} {
+		w := serve(net_http.MethodGet, path, "", "", "")
+		if w.Code != net_http.StatusOK {
+			t.Errorf("GET %s anonymously: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		if strings.Contains(w.Body.String(), "console.internal") || !strings.Contains(w.Body.String(), "docs.example.com") {
+			t.Errorf("GET %s anonymously: expected the internal links only to be redacted, got %s", path, w.Body.String())
+		}
+	}
+	w = serve(net_http.MethodGet, "/api/v1/issues?namespace=status", "", "", "application/x-ndjson")
+	if w.Code != net_http.StatusOK || strings.Contains(w.Body.String(), "console.internal") || !strings.Contains(w.Body.String(), issue.ID) {
+		t.Errorf("Expected the stream of the public namespace redacted, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// They don't write it, nor read other namespaces
+	if w := serve(net_http.MethodPut, "/api/v1/issues/"+issue.ID+"?namespace=status", "", "", ""); w.Code != net_http.StatusForbidden {
+		t.Errorf("Expected anonymous updates to be refused with 403, got %d", w.Code)
+	}
+	if w := serve(net_http.MethodGet, "/api/v1/issues?namespace=team-alpha", "", "", ""); w.Code != net_http.StatusUnauthorized {
+		t.Errorf("Expected anonymous reads of other namespaces to be refused with 401, got %d", w.Code)
+	}
+
+	// Users read the public namespace only when granted to them
+	if w := serve(net_http.MethodGet, "/api/v1/issues?namespace=status", "alice", "team-alpha", ""); w.Code != net_http.StatusForbidden {
+		t.Errorf("Expected users without claim to be refused with 403, got %d", w.Code)
+	}
+	w = serve(net_http.MethodGet, "/api/v1/issues/"+issue.ID+"?namespace=status", "bob", "status", "")
+	if w.Code != net_http.StatusOK || !strings.Contains(w.Body.String(), "console.internal") {
+		t.Errorf("Expected users granted the namespace to read it unredacted, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// maxViewNameLength is the maximum length of the name of a view
+const maxViewNameLength = 100
+
+// viewFilters lists the query parameters views may set. The namespace and
+// offset are those of the request.
+var viewFilters = []string{
+	"severity", "priority", "issueType", "state", "resourceType", "resourceName",
+	"resourceNamespace", "search", "assignee", "label", "hasExternalRef",
+	"acknowledged", "snoozed", "sort", "limit", "groupBy",
+}
+
+type SavedViewHandler struct {
+	viewService services.SavedViewServiceInterface
+	logger      *logrus.Logger
+}
+
+func NewSavedViewHandler(viewService services.SavedViewServiceInterface, logger *logrus.Logger) *SavedViewHandler {
+	return &SavedViewHandler{
+		viewService: viewService,
+		logger:      logger,
+	}
+}
+
+// viewScope returns the scope of the views of a request, the namespace of
+// /namespaces/:namespace/views, or the user of /me/views
+func viewScope(c *gin.Context) (namespace, user string) {
+	if namespace := c.Param("namespace"); namespace != "" {
+		return namespace, ""
+	}
+	return "", middleware.User(c)
+}
+
+// GetViews handles GET /namespaces/:namespace/views and GET /me/views
+func (h *SavedViewHandler) GetViews(c *gin.Context) {
+	namespace, user := viewScope(c)
+
+	views, err := h.viewService.GetViews(c.Request.Context(), namespace, user)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to fetch views")
+		respondWithServerError(c, err, "Failed to fetch views")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": views, "total": len(views)})
+}
+
+// SaveView handles PUT /namespaces/:namespace/views/:name and PUT
+// /me/views/:name, creating the view or replacing its query
+func (h *SavedViewHandler) SaveView(c *gin.Context) {
+	namespace, user := viewScope(c)
+	name := c.Param("name")
+
+	var req dto.SaveViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if utf8.RuneCountInString(name) > maxViewNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("view name exceeds maximum length of %d characters", maxViewNameLength)})
+		return
+	}
+	query, err := normalizeViewQuery(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view, err := h.viewService.SaveView(c.Request.Context(), namespace, user, name, query)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("view", name).Error("Failed to save view")
+		respondWithServerError(c, err, "Failed to save view")
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteView handles DELETE /namespaces/:namespace/views/:name and DELETE /me/views/:name
+func (h *SavedViewHandler) DeleteView(c *gin.Context) {
+	namespace, user := viewScope(c)
+	name := c.Param("name")
+
+	if err := h.viewService.DeleteView(c.Request.Context(), namespace, user, name); err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("view", name).Error("Failed to delete view")
+		respondWithServerError(c, err, "Failed to delete view")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ExpandView middleware, adding the filters of the view named by the view
+// query parameter to the query of the request. The personal views of the user
+// take precedence over the views of the namespace, and the parameters of the
+// request over the filters of the view.
+func (h *SavedViewHandler) ExpandView(c *gin.Context) {
+	query := c.Request.URL.Query()
+	name := query.Get("view")
+	if name == "" {
+		c.Next()
+		return
+	}
+
+	view, err := h.viewService.ResolveView(c.Request.Context(), name, query.Get("namespace"), middleware.User(c))
+	if err != nil {
+		if respondWithClientError(c, err) {
+			c.Abort()
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("view", name).Error("Failed to fetch view")
+		respondWithServerError(c, err, "Failed to fetch view")
+		c.Abort()
+		return
+	}
+
+	// Views are validated when saved
+	filters, _ := url.ParseQuery(view.Query)
+	for key, values := range filters {
+		if !query.Has(key) {
+			query[key] = values
+		}
+	}
+	c.Request.URL.RawQuery = query.Encode()
+	c.Next()
+}
+
+// normalizeViewQuery validates the query of a view holds valid filters of GET
+// /issues, and encodes it in a canonical order
+func normalizeViewQuery(query string) (string, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("invalid view query: %v", err)
+	}
+	for key := range values {
+		if !slices.Contains(viewFilters, key) {
+			return "", fmt.Errorf("unsupported view filter %q", key)
+		}
+	}
+	if _, err := parseIssueQueryFilters(values); err != nil {
+		return "", err
+	}
+	if groupBy := values.Get("groupBy"); groupBy != "" && !slices.Contains(services.ValidGroupBy, groupBy) {
+		return "", errors.New("invalid groupBy value, must be one of: resource, severity, type")
+	}
+	return values.Encode(), nil
+}
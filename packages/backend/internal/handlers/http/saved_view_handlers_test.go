@@ -0,0 +1,191 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTestSavedViewRouter creates a test router serving the views of a mock
+// service, and an issues route recording the filters it's passed
+func setupTestSavedViewRouter(mockService *MockSavedViewService, filters *repository.IssueQueryFilters) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewSavedViewHandler(mockService, logger)
+
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	{
+		v1.GET("/namespaces/:namespace/views", handler.GetViews)
+		v1.PUT("/namespaces/:namespace/views/:name", handler.SaveView)
+		v1.DELETE("/namespaces/:namespace/views/:name", handler.DeleteView)
+		v1.PUT("/me/views/:name", middleware.RequireUser("X-Forwarded-User"), handler.SaveView)
+		v1.GET("/issues", middleware.IdentifyUser("X-Forwarded-User"), func(c *gin.Context) {
+			// Reads the query before it's expanded, as the namespace checker does
+			_ = c.Query("namespace")
+			c.Next()
+		}, handler.ExpandView, func(c *gin.Context) {
+			*filters, _ = parseIssueQueryFilters(c.Request.URL.Query())
+			c.Status(net_http.StatusOK)
+		})
+	}
+	return router
+}
+
+func TestSavedViewHandler_SaveView(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedQuery  string
+	}{
+		{
+			name:           "valid view",
+			body:           `{"query": "severity=critical&issueType=build&label=team=build"}`,
+			expectedStatus: net_http.StatusOK,
+			expectedQuery:  "issueType=build&label=team%3Dbuild&severity=critical",
+		},
+		{
+			name:           "missing query",
+			body:           `{}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported filter",
+			body:           `{"query": "namespace=team-beta"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid filter",
+			body:           `{"query": "sort=title"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := setupTestSavedViewRouter(&MockSavedViewService{}, &repository.IssueQueryFilters{})
+
+			req, _ := net_http.NewRequest("PUT", "/api/v1/namespaces/team-alpha/views/critical-builds", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+			var view models.SavedView
+			if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if view.Namespace != "team-alpha" || view.Name != "critical-builds" || view.Query != tc.expectedQuery {
+				t.Errorf("Unexpected view %+v", view)
+			}
+		})
+	}
+}
+
+func TestSavedViewHandler_PersonalViews(t *testing.T) {
+	mockService := &MockSavedViewService{}
+	router := setupTestSavedViewRouter(mockService, &repository.IssueQueryFilters{})
+
+	req, _ := net_http.NewRequest("PUT", "/api/v1/me/views/mine", bytes.NewBufferString(`{"query": "assignee=alice"}`))
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without user, got %d", w.Code)
+	}
+
+	req, _ = net_http.NewRequest("PUT", "/api/v1/me/views/mine", bytes.NewBufferString(`{"query": "assignee=alice"}`))
+	req.Header.Set("X-Forwarded-User", "alice")
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(mockService.views) != 1 || mockService.views[0].User != "alice" || mockService.views[0].Namespace != "" {
+		t.Errorf("Expected a personal view of alice, got %+v", mockService.views)
+	}
+}
+
+func TestSavedViewHandler_GetAndDeleteViews(t *testing.T) {
+	mockService := &MockSavedViewService{views: []models.SavedView{
+		{Namespace: "team-alpha", Name: "critical", Query: "severity=critical"},
+		{Namespace: "team-beta", Name: "critical", Query: "severity=critical"},
+	}}
+	router := setupTestSavedViewRouter(mockService, &repository.IssueQueryFilters{})
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/namespaces/team-alpha/views", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"total":1`)) {
+		t.Errorf("Expected the view of team-alpha, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req, _ = net_http.NewRequest("DELETE", "/api/v1/namespaces/team-alpha/views/critical", nil)
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestSavedViewHandler_ExpandView(t *testing.T) {
+	mockService := &MockSavedViewService{views: []models.SavedView{
+		{Namespace: "team-alpha", Name: "critical", Query: "severity=critical&issueType=build&limit=10"},
+		{User: "alice", Name: "critical", Query: "severity=critical&assignee=alice"},
+	}}
+	var filters repository.IssueQueryFilters
+	router := setupTestSavedViewRouter(mockService, &filters)
+
+	request := func(path, user string) int {
+		filters = repository.IssueQueryFilters{}
+		req, _ := net_http.NewRequest("GET", path, nil)
+		if user != "" {
+			req.Header.Set("X-Forwarded-User", user)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// The parameters of the request take precedence over the view
+	if code := request("/api/v1/issues?namespace=team-alpha&view=critical&limit=20", ""); code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if filters.Namespace != "team-alpha" || filters.Severity == nil || *filters.Severity != models.SeverityCritical ||
+		filters.IssueType == nil || *filters.IssueType != models.IssueTypeBuild || filters.Limit != 20 {
+		t.Errorf("Expected the filters of the view of team-alpha, got %+v", filters)
+	}
+
+	// The personal view of the user takes precedence over the view of the namespace
+	if code := request("/api/v1/issues?namespace=team-alpha&view=critical", "alice"); code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if filters.Assignee != "alice" || filters.IssueType != nil {
+		t.Errorf("Expected the filters of the view of alice, got %+v", filters)
+	}
+
+	if code := request("/api/v1/issues?namespace=team-beta&view=critical", ""); code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404 for a view of another namespace, got %d", code)
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultTimelineDays is how many days scope timelines cover by default
+	defaultTimelineDays = 90
+	// maxTimelineDays is how many days scope timelines may cover
+	maxTimelineDays = 365
+)
+
+type ScopeTimelineHandler struct {
+	timelineService services.ScopeTimelineServiceInterface
+	logger          *logrus.Logger
+}
+
+func NewScopeTimelineHandler(timelineService services.ScopeTimelineServiceInterface, logger *logrus.Logger) *ScopeTimelineHandler {
+	return &ScopeTimelineHandler{
+		timelineService: timelineService,
+		logger:          logger,
+	}
+}
+
+// GetTimeline handles GET /scopes/:id/timeline, returning the detections and
+// resolutions of the issues of the resource of a scope over the last days
+// days, 90 by default
+func (h *ScopeTimelineHandler) GetTimeline(c *gin.Context) {
+	id := c.Param("id")
+
+	days := defaultTimelineDays
+	if param := c.Query("days"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil || parsed < 1 || parsed > maxTimelineDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a number between 1 and " + strconv.Itoa(maxTimelineDays)})
+			return
+		}
+		days = parsed
+	}
+	since := time.Now().UTC().AddDate(0, 0, -days)
+
+	timeline, err := h.timelineService.GetTimeline(c.Request.Context(), id, c.Query("namespace"), since)
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("scope_id", id).Error("Failed to fetch scope timeline")
+			respondWithServerError(c, err, "Failed to fetch scope timeline")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/sirupsen/logrus"
+)
+
+func TestScopeTimelineHandler_GetTimeline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	detectedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &MockScopeTimelineService{
+		namespace: "team-alpha",
+		events: map[string][]dto.TimelineEvent{"scope-1": {
+			{Type: dto.ActivityDetected, Timestamp: detectedAt, IssueID: "issue-1"},
+			{Type: dto.ActivityResolved, Timestamp: detectedAt.Add(time.Hour), IssueID: "issue-1"},
+		}},
+	}
+	router := gin.New()
+	router.GET("/api/v1/scopes/:id/timeline", NewScopeTimelineHandler(service, logger).GetTimeline)
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedDays   int
+	}{
+		{name: "last 90 days by default", path: "/api/v1/scopes/scope-1/timeline?namespace=team-alpha", expectedStatus: net_http.StatusOK, expectedDays: 90},
+		{name: "last days", path: "/api/v1/scopes/scope-1/timeline?namespace=team-alpha&days=30", expectedStatus: net_http.StatusOK, expectedDays: 30},
+		{name: "invalid days", path: "/api/v1/scopes/scope-1/timeline?days=1000", expectedStatus: net_http.StatusBadRequest},
+		{name: "unknown scope", path: "/api/v1/scopes/scope-2/timeline", expectedStatus: net_http.StatusNotFound},
+		{name: "other namespace", path: "/api/v1/scopes/scope-1/timeline?namespace=team-beta", expectedStatus: net_http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := net_http.NewRequest("GET", tc.path, nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+			var response dto.ScopeTimeline
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Total != 2 || response.Events[1].Type != dto.ActivityResolved {
+				t.Errorf("Unexpected timeline %+v", response)
+			}
+			if days := time.Since(service.lastSince).Hours() / 24; days < float64(tc.expectedDays)-0.01 || days > float64(tc.expectedDays)+0.01 {
+				t.Errorf("Expected the timeline of the last %d days, got %.2f days", tc.expectedDays, days)
+			}
+		})
+	}
+}
@@ -2,16 +2,23 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
 	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/services"
 )
 
 // MockIssueService is a mock implementation for testing handlers
 type MockIssueService struct {
 	findIssueResults              *dto.IssueResponse
 	findIssuesError               error
+	findIssuesGroupedResult       *dto.GroupedIssuesResponse
+	findIssuesGroupedError        error
 	findIssueByIDResult           *models.Issue
 	findIssueByIDError            error
 	createIssueResult             *models.Issue
@@ -25,12 +32,64 @@ type MockIssueService struct {
 	resolveIssuesByScopeError     error
 	createOrUpdateIssueResult     *models.Issue
 	createOrUpdateIssueError      error
+	addExternalRefResult          *models.ExternalRef
+	addExternalRefError           error
+	removeExternalRefError        error
+	findActiveEffectsResult       []string
+	findActiveEffectsError        error
+	findSimilarIssuesResult       []repository.SimilarIssue
+	acknowledgeIssueResult        *models.Issue
+	acknowledgeIssueError         error
+	snoozeIssueResult             *models.Issue
+	snoozeIssueError              error
+	processWebhookBatchResult     []dto.WebhookBatchItemResult
+	processWebhookBatchError      error
+	dryRunIssueResult             *dto.DryRunResult
+	dryRunWebhookBatchResult      []dto.WebhookBatchItemResult
+	// The last items passed to ProcessWebhookBatch
+	processWebhookBatchItems []dto.WebhookBatchItem
+	// The source passed to DryRunIssue
+	dryRunIssueSource models.IssueSource
+	// The user passed to AcknowledgeIssue
+	acknowledgedBy string
+	// The end of the snooze and the user passed to SnoozeIssue
+	snoozedUntil *time.Time
+	snoozedBy    string
+	// The limit passed to FindSimilarIssues
+	findSimilarIssuesLimit int
+	// The last request passed to CreateOrUpdateIssue
+	createOrUpdateIssueRequest dto.CreateIssueRequest
+	// The last resource name and resolution passed to ResolveIssuesByScope
+	resolveIssuesByScopeName       string
+	resolveIssuesByScopeResolution dto.Resolution
+	// The last request passed to UpdateIssue
+	updateIssueRequest dto.UpdateIssueRequest
+	// The type of the last relationship passed to AddRelatedIssue
+	addRelatedIssueType     models.RelationType
+	findRelatedIssuesResult []dto.RelatedIssueEntry
+	findRelatedIssuesError  error
+	// The batches StreamIssues passes, before failing with streamIssuesError
+	streamIssuesBatches [][]models.Issue
+	streamIssuesError   error
 }
 
 func (m *MockIssueService) FindIssues(ctx context.Context, filters repository.IssueQueryFilters) (*dto.IssueResponse, error) {
 	return m.findIssueResults, m.findIssuesError
 }
 
+func (m *MockIssueService) FindIssuesGrouped(ctx context.Context, filters repository.IssueQueryFilters, groupBy string) (*dto.GroupedIssuesResponse, error) {
+	return m.findIssuesGroupedResult, m.findIssuesGroupedError
+}
+
+func (m *MockIssueService) StreamIssues(ctx context.Context, filters repository.IssueQueryFilters, fn func(issues []models.Issue) error) error {
+	for _, batch := range m.streamIssuesBatches {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return m.streamIssuesError
+}
+
 func (m *MockIssueService) FindIssueByID(ctx context.Context, id string) (*models.Issue, error) {
 	return m.findIssueByIDResult, m.findIssueByIDError
 }
@@ -40,6 +99,7 @@ func (m *MockIssueService) CreateIssue(ctx context.Context, req dto.CreateIssueR
 }
 
 func (m *MockIssueService) UpdateIssue(ctx context.Context, id string, req dto.UpdateIssueRequest) (*models.Issue, error) {
+	m.updateIssueRequest = req
 	return m.updateIssueResult, m.updateIssueError
 }
 
@@ -51,18 +111,408 @@ func (m *MockIssueService) FindDuplicateIssue(ctx context.Context, req dto.Creat
 	return m.findDuplicateIssueResult, m.findDuplicateIssueResultError
 }
 
+func (m *MockIssueService) CheckDuplicateIssue(ctx context.Context, req dto.CreateIssueRequest) (*services.DuplicateCheckResult, error) {
+	if m.findDuplicateIssueResultError != nil {
+		return nil, m.findDuplicateIssueResultError
+	}
+	return &services.DuplicateCheckResult{IsDuplicate: m.findDuplicateIssueResult != nil, ExistingIssue: m.findDuplicateIssueResult}, nil
+}
+
 func (m *MockIssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
+	m.createOrUpdateIssueRequest = req
 	return m.createOrUpdateIssueResult, m.findDuplicateIssueResultError
 }
 
-func (m *MockIssueService) ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error) {
+func (m *MockIssueService) ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string, resolution dto.Resolution) (int64, error) {
+	m.resolveIssuesByScopeName = resourceName
+	m.resolveIssuesByScopeResolution = resolution
 	return m.resolveIssuesByScopeResult, m.resolveIssuesByScopeError
 }
 
-func (m *MockIssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID string) error {
+func (m *MockIssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID string, relationType models.RelationType) error {
+	m.addRelatedIssueType = relationType
 	return nil
 }
 
+func (m *MockIssueService) FindSimilarIssues(ctx context.Context, issue models.Issue, limit int) ([]repository.SimilarIssue, error) {
+	m.findSimilarIssuesLimit = limit
+	return m.findSimilarIssuesResult, nil
+}
+
+func (m *MockIssueService) FindActiveEffects(ctx context.Context, id string) ([]string, error) {
+	return m.findActiveEffectsResult, m.findActiveEffectsError
+}
+
 func (m *MockIssueService) RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error {
 	return nil
 }
+
+func (m *MockIssueService) FindRelatedIssues(ctx context.Context, id, namespace string) ([]dto.RelatedIssueEntry, error) {
+	return m.findRelatedIssuesResult, m.findRelatedIssuesError
+}
+
+func (m *MockIssueService) AddExternalRef(ctx context.Context, issueID string, req dto.CreateExternalRefRequest) (*models.ExternalRef, error) {
+	return m.addExternalRefResult, m.addExternalRefError
+}
+
+func (m *MockIssueService) RemoveExternalRef(ctx context.Context, issueID, refID string) error {
+	return m.removeExternalRefError
+}
+
+func (m *MockIssueService) ProcessWebhookBatch(ctx context.Context, items []dto.WebhookBatchItem) ([]dto.WebhookBatchItemResult, error) {
+	m.processWebhookBatchItems = items
+	return m.processWebhookBatchResult, m.processWebhookBatchError
+}
+
+func (m *MockIssueService) DryRunIssue(ctx context.Context, req dto.CreateIssueRequest, source models.IssueSource) (*dto.DryRunResult, error) {
+	m.dryRunIssueSource = source
+	return m.dryRunIssueResult, nil
+}
+
+func (m *MockIssueService) DryRunWebhookBatch(ctx context.Context, items []dto.WebhookBatchItem) ([]dto.WebhookBatchItemResult, error) {
+	m.processWebhookBatchItems = items
+	return m.dryRunWebhookBatchResult, nil
+}
+
+func (m *MockIssueService) AcknowledgeIssue(ctx context.Context, id, namespace, acknowledgedBy string) (*models.Issue, error) {
+	m.acknowledgedBy = acknowledgedBy
+	return m.acknowledgeIssueResult, m.acknowledgeIssueError
+}
+
+func (m *MockIssueService) SnoozeIssue(ctx context.Context, id, namespace string, until *time.Time, snoozedBy string) (*models.Issue, error) {
+	m.snoozedUntil = until
+	m.snoozedBy = snoozedBy
+	return m.snoozeIssueResult, m.snoozeIssueError
+}
+
+// MockNamespaceSettingsService implements NamespaceSettingsServiceInterface
+type MockNamespaceSettingsService struct {
+	getSettingsResult   *models.NamespaceSettings
+	getSettingsError    error
+	updateSettingsError error
+	resetSettingsError  error
+	// The last request passed to UpdateSettings
+	updateSettingsRequest *dto.UpdateNamespaceSettingsRequest
+}
+
+func (m *MockNamespaceSettingsService) GetSettings(ctx context.Context, namespace string) (*models.NamespaceSettings, error) {
+	return m.getSettingsResult, m.getSettingsError
+}
+
+func (m *MockNamespaceSettingsService) UpdateSettings(ctx context.Context, namespace string, req dto.UpdateNamespaceSettingsRequest) (*models.NamespaceSettings, error) {
+	m.updateSettingsRequest = &req
+	if m.updateSettingsError != nil {
+		return nil, m.updateSettingsError
+	}
+	return &models.NamespaceSettings{
+		Namespace:              namespace,
+		RetentionDays:          req.RetentionDays,
+		DedupWindowHours:       req.DedupWindowHours,
+		SeverityEscalationOnly: req.SeverityEscalationOnly,
+		SLATargets:             req.SLATargets,
+		Notifications:          req.Notifications,
+		Workflow:               req.Workflow,
+		LinkTemplates:          req.LinkTemplates,
+	}, nil
+}
+
+func (m *MockNamespaceSettingsService) ResetSettings(ctx context.Context, namespace string) error {
+	return m.resetSettingsError
+}
+
+// MockIssueWatchService implements IssueWatchServiceInterface
+type MockIssueWatchService struct {
+	watchError     error
+	unwatchError   error
+	watchedIssues  []models.Issue
+	findWatchedErr error
+	// The last user passed to the service
+	lastUser string
+}
+
+func (m *MockIssueWatchService) WatchIssue(ctx context.Context, issueID, namespace, user string) (*models.IssueWatch, error) {
+	m.lastUser = user
+	if m.watchError != nil {
+		return nil, m.watchError
+	}
+	return &models.IssueWatch{IssueID: issueID, User: user}, nil
+}
+
+func (m *MockIssueWatchService) UnwatchIssue(ctx context.Context, issueID, user string) error {
+	m.lastUser = user
+	return m.unwatchError
+}
+
+func (m *MockIssueWatchService) FindWatchedIssues(ctx context.Context, user string) ([]models.Issue, error) {
+	m.lastUser = user
+	return m.watchedIssues, m.findWatchedErr
+}
+
+// MockSavedViewService implements SavedViewServiceInterface, keeping the views in memory
+type MockSavedViewService struct {
+	views     []models.SavedView
+	saveError error
+}
+
+func (m *MockSavedViewService) GetViews(ctx context.Context, namespace, user string) ([]models.SavedView, error) {
+	var views []models.SavedView
+	for _, view := range m.views {
+		if view.Namespace == namespace && view.User == user {
+			views = append(views, view)
+		}
+	}
+	return views, nil
+}
+
+func (m *MockSavedViewService) SaveView(ctx context.Context, namespace, user, name, query string) (*models.SavedView, error) {
+	if m.saveError != nil {
+		return nil, m.saveError
+	}
+	view := models.SavedView{Namespace: namespace, User: user, Name: name, Query: query}
+	m.views = append(m.views, view)
+	return &view, nil
+}
+
+func (m *MockSavedViewService) DeleteView(ctx context.Context, namespace, user, name string) error {
+	for i, view := range m.views {
+		if view.Namespace == namespace && view.User == user && view.Name == name {
+			m.views = append(m.views[:i], m.views[i+1:]...)
+			return nil
+		}
+	}
+	return repository.NotFoundError("saved view not found")
+}
+
+func (m *MockSavedViewService) ResolveView(ctx context.Context, name, namespace, user string) (*models.SavedView, error) {
+	find := func(namespace, user string) *models.SavedView {
+		for _, view := range m.views {
+			if view.Namespace == namespace && view.User == user && view.Name == name {
+				return &view
+			}
+		}
+		return nil
+	}
+	if view := find("", user); user != "" && view != nil {
+		return view, nil
+	}
+	if view := find(namespace, ""); namespace != "" && view != nil {
+		return view, nil
+	}
+	return nil, repository.NotFoundError("saved view not found")
+}
+
+// MockDashboardService implements DashboardServiceInterface
+type MockDashboardService struct {
+	dashboard *dto.DashboardResponse
+	status    *dto.StatusResponse
+	err       error
+}
+
+func (m *MockDashboardService) GetDashboard(ctx context.Context, namespace string) (*dto.DashboardResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	dashboard := *m.dashboard
+	dashboard.Namespace = namespace
+	return &dashboard, nil
+}
+
+func (m *MockDashboardService) GetStatus(ctx context.Context, namespace string) (*dto.StatusResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	status := *m.status
+	status.Namespace = namespace
+	return &status, nil
+}
+
+// MockIssueActivityService implements IssueActivityServiceInterface, serving
+// the activity of the issues of a namespace
+type MockIssueActivityService struct {
+	namespace string
+	activity  map[string][]dto.ActivityEntry
+}
+
+func (m *MockIssueActivityService) GetActivity(ctx context.Context, issueID, namespace string) ([]dto.ActivityEntry, error) {
+	activity, ok := m.activity[issueID]
+	if !ok {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && namespace != m.namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+	return activity, nil
+}
+
+// MockScopeTimelineService implements ScopeTimelineServiceInterface, serving
+// the timelines of the scopes of a namespace
+type MockScopeTimelineService struct {
+	namespace string
+	events    map[string][]dto.TimelineEvent
+	lastSince time.Time
+}
+
+func (m *MockScopeTimelineService) GetTimeline(ctx context.Context, scopeID, namespace string, since time.Time) (*dto.ScopeTimeline, error) {
+	events, ok := m.events[scopeID]
+	if !ok {
+		return nil, repository.NotFoundError("scope not found")
+	}
+	if namespace != "" && namespace != m.namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+	m.lastSince = since
+	return &dto.ScopeTimeline{Scope: models.IssueScope{ID: scopeID}, Since: since, Events: events, Total: len(events)}, nil
+}
+
+// MockTriageRuleService implements TriageRuleServiceInterface, keeping the rules in memory
+type MockTriageRuleService struct {
+	rules  []models.TriageRule
+	events map[string][]models.TriageEvent
+}
+
+func (m *MockTriageRuleService) GetRules(ctx context.Context, namespace string) ([]models.TriageRule, error) {
+	var rules []models.TriageRule
+	for _, rule := range m.rules {
+		if rule.Namespace == namespace {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *MockTriageRuleService) SaveRule(ctx context.Context, namespace, name string, req dto.SaveTriageRuleRequest) (*models.TriageRule, error) {
+	rule := models.TriageRule{
+		Namespace:    namespace,
+		Name:         name,
+		Position:     req.Position,
+		TitlePattern: req.TitlePattern,
+		Source:       req.Source,
+		Labels:       req.Labels,
+		Assignee:     req.Assignee,
+		Priority:     req.Priority,
+		Severity:     req.Severity,
+	}
+	m.rules = append(m.rules, rule)
+	return &rule, nil
+}
+
+func (m *MockTriageRuleService) DeleteRule(ctx context.Context, namespace, name string) error {
+	for i, rule := range m.rules {
+		if rule.Namespace == namespace && rule.Name == name {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return repository.NotFoundError("triage rule not found")
+}
+
+func (m *MockTriageRuleService) FindEvents(ctx context.Context, issueID, namespace string) ([]models.TriageEvent, error) {
+	events, ok := m.events[issueID]
+	if !ok {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	return events, nil
+}
+
+// MockWebhookEventService implements WebhookEventServiceInterface, keeping the events in memory
+type MockWebhookEventService struct {
+	events []models.WebhookEvent
+}
+
+func (m *MockWebhookEventService) RecordEvent(ctx context.Context, source, namespace string, payload []byte, signature models.WebhookSignatureStatus, issueID *string) (*models.WebhookEvent, error) {
+	event := models.WebhookEvent{
+		ID:              fmt.Sprintf("event-%d", len(m.events)+1),
+		Source:          source,
+		Namespace:       namespace,
+		Payload:         json.RawMessage(payload),
+		SignatureStatus: signature,
+		IssueID:         issueID,
+		ReceivedAt:      time.Now(),
+	}
+	m.events = append(m.events, event)
+	return &event, nil
+}
+
+func (m *MockWebhookEventService) GetEvents(ctx context.Context, filters repository.WebhookEventFilters) ([]models.WebhookEvent, error) {
+	var events []models.WebhookEvent
+	for _, event := range slices.Backward(m.events) {
+		if filters.Source != "" && event.Source != filters.Source {
+			continue
+		}
+		if filters.Namespace != "" && event.Namespace != filters.Namespace {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (m *MockWebhookEventService) GetEvent(ctx context.Context, id string) (*models.WebhookEvent, error) {
+	for _, event := range m.events {
+		if event.ID == id {
+			return &event, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookEventService) GetPayload(ctx context.Context, event *models.WebhookEvent) ([]byte, error) {
+	return event.Payload, nil
+}
+
+// MockNamespaceOffboardingService implements NamespaceOffboardingServiceInterface
+type MockNamespaceOffboardingService struct {
+	startError error
+	getResult  *dto.OffboardingJob
+	getError   error
+	// The last mode and user passed to StartOffboarding
+	lastMode dto.OffboardingMode
+	lastUser string
+}
+
+func (m *MockNamespaceOffboardingService) StartOffboarding(ctx context.Context, namespace string, mode dto.OffboardingMode, user string) (*dto.OffboardingJob, error) {
+	m.lastMode = mode
+	m.lastUser = user
+	if m.startError != nil {
+		return nil, m.startError
+	}
+	return &dto.OffboardingJob{
+		ID:          "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f",
+		Namespace:   namespace,
+		Mode:        mode,
+		Status:      dto.OffboardingStatusRunning,
+		RequestedBy: user,
+	}, nil
+}
+
+func (m *MockNamespaceOffboardingService) GetOffboardingJob(ctx context.Context, id string) (*dto.OffboardingJob, error) {
+	return m.getResult, m.getError
+}
+
+// MockMaintenanceService implements MaintenanceServiceInterface
+type MockMaintenanceService struct {
+	startError error
+	getResult  *dto.MaintenanceJob
+	getError   error
+	// The last task and user passed to StartMaintenance
+	lastTask dto.MaintenanceTask
+	lastUser string
+}
+
+func (m *MockMaintenanceService) StartMaintenance(ctx context.Context, task dto.MaintenanceTask, user string) (*dto.MaintenanceJob, error) {
+	m.lastTask = task
+	m.lastUser = user
+	if m.startError != nil {
+		return nil, m.startError
+	}
+	return &dto.MaintenanceJob{
+		ID:          "3f2a9c4e-8b1d-4e6f-a7c2-5d9e0b1f2a3c",
+		Task:        task,
+		Status:      dto.MaintenanceStatusRunning,
+		RequestedBy: user,
+	}, nil
+}
+
+func (m *MockMaintenanceService) GetMaintenanceJob(ctx context.Context, id string) (*dto.MaintenanceJob, error) {
+	return m.getResult, m.getError
+}
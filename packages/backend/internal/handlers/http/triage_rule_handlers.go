@@ -0,0 +1,129 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// maxTriageRuleNameLength is the maximum length of the name of a triage rule
+const maxTriageRuleNameLength = 100
+
+type TriageRuleHandler struct {
+	triageService services.TriageRuleServiceInterface
+	logger        *logrus.Logger
+}
+
+func NewTriageRuleHandler(triageService services.TriageRuleServiceInterface, logger *logrus.Logger) *TriageRuleHandler {
+	return &TriageRuleHandler{
+		triageService: triageService,
+		logger:        logger,
+	}
+}
+
+// GetRules handles GET /namespaces/:namespace/triage-rules, listing the rules
+// in the order they're evaluated
+func (h *TriageRuleHandler) GetRules(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	rules, err := h.triageService.GetRules(c.Request.Context(), namespace)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("namespace", namespace).Error("Failed to fetch triage rules")
+		respondWithServerError(c, err, "Failed to fetch triage rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules, "total": len(rules)})
+}
+
+// SaveRule handles PUT /namespaces/:namespace/triage-rules/:name, creating the
+// rule or replacing it
+func (h *TriageRuleHandler) SaveRule(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	var req dto.SaveTriageRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if utf8.RuneCountInString(name) > maxTriageRuleNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("triage rule name exceeds maximum length of %d characters", maxTriageRuleNameLength)})
+		return
+	}
+	if err := validateTriageRule(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	rule, err := h.triageService.SaveRule(c.Request.Context(), namespace, name, req)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("rule", name).Error("Failed to save triage rule")
+		respondWithServerError(c, err, "Failed to save triage rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule handles DELETE /namespaces/:namespace/triage-rules/:name
+func (h *TriageRuleHandler) DeleteRule(c *gin.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	if err := h.triageService.DeleteRule(c.Request.Context(), namespace, name); err != nil {
+		if respondWithClientError(c, err) {
+			return
+		}
+		requestLogger(c, h.logger).WithError(err).WithField("rule", name).Error("Failed to delete triage rule")
+		respondWithServerError(c, err, "Failed to delete triage rule")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEvents handles GET /issues/:id/triage-events, listing the triage rules
+// that fired on the issue when it was created
+func (h *TriageRuleHandler) GetEvents(c *gin.Context) {
+	id := c.Param("id")
+
+	events, err := h.triageService.FindEvents(c.Request.Context(), id, c.Query("namespace"))
+	if err != nil {
+		if !respondWithClientError(c, err) {
+			requestLogger(c, h.logger).WithError(err).WithField("issue_id", id).Error("Failed to fetch triage events")
+			respondWithServerError(c, err, "Failed to fetch triage events")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": events, "total": len(events)})
+}
+
+// validateTriageRule validates the title pattern and labels of a triage rule,
+// which must have at least one action. The enums are validated when the
+// request is bound, see dto.SaveTriageRuleRequest.
+func validateTriageRule(req dto.SaveTriageRuleRequest) error {
+	if req.TitlePattern != "" {
+		if _, err := regexp.Compile(req.TitlePattern); err != nil {
+			return fmt.Errorf("invalid titlePattern: %v", err)
+		}
+	}
+
+	if len(req.Labels) == 0 && req.Assignee == "" && req.Priority == "" && req.Severity == "" {
+		return errors.New("triage rule must set labels, assignee, priority or severity")
+	}
+	for key := range req.Labels {
+		if key == "" {
+			return errors.New("label keys cannot be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func setupTestTriageRuleRouter(mockService *MockTriageRuleService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewTriageRuleHandler(mockService, logger)
+
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	{
+		v1.GET("/namespaces/:namespace/triage-rules", handler.GetRules)
+		v1.PUT("/namespaces/:namespace/triage-rules/:name", handler.SaveRule)
+		v1.DELETE("/namespaces/:namespace/triage-rules/:name", handler.DeleteRule)
+		v1.GET("/issues/:id/triage-events", handler.GetEvents)
+	}
+	return router
+}
+
+func TestTriageRuleHandler_SaveRule(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "valid rule",
+			body:           `{"titlePattern": "(?i)timeout", "source": "webhook", "labels": {"kind": "flaky"}, "priority": "P3"}`,
+			expectedStatus: net_http.StatusOK,
+		},
+		{
+			name:           "no actions",
+			body:           `{"titlePattern": "(?i)timeout"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid title pattern",
+			body:           `{"titlePattern": "(timeout", "assignee": "alice"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid source",
+			body:           `{"source": "email", "assignee": "alice"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid severity",
+			body:           `{"severity": "urgent"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid priority",
+			body:           `{"priority": "P0"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "empty label key",
+			body:           `{"labels": {"": "flaky"}}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := setupTestTriageRuleRouter(&MockTriageRuleService{})
+
+			req, _ := net_http.NewRequest("PUT", "/api/v1/namespaces/team-alpha/triage-rules/timeouts", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != net_http.StatusOK {
+				return
+			}
+			var rule models.TriageRule
+			if err := json.Unmarshal(w.Body.Bytes(), &rule); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if rule.Namespace != "team-alpha" || rule.Name != "timeouts" || rule.Labels["kind"] != "flaky" {
+				t.Errorf("Unexpected rule %+v", rule)
+			}
+		})
+	}
+}
+
+func TestTriageRuleHandler_GetAndDeleteRules(t *testing.T) {
+	mockService := &MockTriageRuleService{rules: []models.TriageRule{
+		{Namespace: "team-alpha", Name: "timeouts", Assignee: "alice"},
+		{Namespace: "team-beta", Name: "timeouts", Assignee: "bob"},
+	}}
+	router := setupTestTriageRuleRouter(mockService)
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/namespaces/team-alpha/triage-rules", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"total":1`)) {
+		t.Errorf("Expected the rule of team-alpha, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req, _ = net_http.NewRequest("DELETE", "/api/v1/namespaces/team-alpha/triage-rules/timeouts", nil)
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestTriageRuleHandler_GetEvents(t *testing.T) {
+	router := setupTestTriageRuleRouter(&MockTriageRuleService{events: map[string][]models.TriageEvent{
+		"issue-1": {{IssueID: "issue-1", RuleName: "timeouts", Changes: map[string]string{"assignee": "alice"}}},
+	}})
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/issues/issue-1/triage-events", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"ruleName":"timeouts"`)) {
+		t.Errorf("Expected the events of the issue, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req, _ = net_http.NewRequest("GET", "/api/v1/issues/issue-2/triage-events", nil)
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
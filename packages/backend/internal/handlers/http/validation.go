@@ -0,0 +1,203 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+// bindRequest binds the JSON body of a request to req and validates it, see bindJSON
+func bindRequest(c *gin.Context, req any, validate func() dto.ValidationErrors) bool {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return false
+	}
+	return bindJSON(c, payload, req, validate)
+}
+
+// bindJSON binds a JSON payload to req and validates it, with the binding tags
+// of req and then with validate, if not nil. All the fields failing validation
+// are reported at once, responding with 400 "Validation failed" and the list
+// of dto.FieldError in details. Payloads that aren't JSON objects get a 400
+// "Invalid request body".
+func bindJSON(c *gin.Context, payload []byte, req any, validate func() dto.ValidationErrors) bool {
+	errs, err := decodeJSON(payload, req, validate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return false
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": errs})
+		return false
+	}
+	return true
+}
+
+// decodeJSON decodes a JSON payload to req and validates it as bindJSON does,
+// returning the fields failing validation, or an error for payloads that
+// aren't JSON objects
+func decodeJSON(payload []byte, req any, validate func() dto.ValidationErrors) (dto.ValidationErrors, error) {
+	var errs dto.ValidationErrors
+	// Values of the wrong type don't stop the decoding of the other fields
+	if err := json.Unmarshal(payload, req); err != nil {
+		typeErrs, ok := dto.BindingErrors(err)
+		if !ok {
+			return nil, err
+		}
+		errs = typeErrs
+	}
+
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		tagErrs, ok := dto.BindingErrors(err)
+		if !ok {
+			return nil, err
+		}
+		for _, fieldErr := range tagErrs {
+			// Values of the wrong type are left empty, they aren't also missing
+			if !errs.Has(fieldErr.Field) {
+				errs = append(errs, fieldErr)
+			}
+		}
+	}
+	if validate != nil {
+		errs = append(errs, validate()...)
+	}
+	return errs, nil
+}
+
+// validateCreateIssueRequest validates the fields of issue creations the
+// binding tags can't. The enums are validated when the request is bound, see
+// dto.CreateIssueRequest.
+func validateCreateIssueRequest(limits config.LimitsConfig, req dto.CreateIssueRequest) dto.ValidationErrors {
+	errs := validateDetectedAt(req.DetectedAt)
+	return append(errs, validateLengths(limits, req.Title, req.Description, req.Details)...)
+}
+
+// validateUpdateIssueRequest validates the fields of issue updates the
+// binding tags can't
+func validateUpdateIssueRequest(limits config.LimitsConfig, req dto.UpdateIssueRequest) dto.ValidationErrors {
+	errs := validateLengths(limits, req.Title, req.Description, req.Details)
+	return append(errs, validateResolution(limits, "resolutionReason", req.GetResolution())...)
+}
+
+// validateLengths validates the title, description and details are within
+// the configured limits. Lengths are counted in characters, not bytes.
+func validateLengths(limits config.LimitsConfig, title, description, details string) dto.ValidationErrors {
+	var errs dto.ValidationErrors
+	errs.MaxLength("title", utf8.RuneCountInString(title), limits.MaxTitleLength)
+	errs.MaxLength("description", utf8.RuneCountInString(description), limits.MaxDescriptionLength)
+	errs.MaxLength("details", utf8.RuneCountInString(details), limits.MaxDetailsLength)
+	return errs
+}
+
+// validateResolution validates the reason of a resolution is within the
+// description length limit, and who resolved it within the title length
+// limit. reasonField is the name of the reason in the request.
+func validateResolution(limits config.LimitsConfig, reasonField string, resolution dto.Resolution) dto.ValidationErrors {
+	var errs dto.ValidationErrors
+	errs.MaxLength(reasonField, utf8.RuneCountInString(resolution.Reason), limits.MaxDescriptionLength)
+	errs.MaxLength("resolvedBy", utf8.RuneCountInString(resolution.ResolvedBy), limits.MaxTitleLength)
+	return errs
+}
+
+// validateAcknowledgement validates an acknowledgement, which must tell who
+// acknowledges the issue unless the request identifies its user
+func validateAcknowledgement(limits config.LimitsConfig, ack dto.Acknowledgement, user string) dto.ValidationErrors {
+	var errs dto.ValidationErrors
+	if ack.AcknowledgedBy == "" && user == "" {
+		errs = append(errs, dto.FieldError{
+			Field:      "acknowledgedBy",
+			Constraint: "required",
+			Message:    "acknowledgedBy is required when the request doesn't identify its user",
+		})
+	}
+	errs.MaxLength("acknowledgedBy", utf8.RuneCountInString(ack.AcknowledgedBy), limits.MaxTitleLength)
+	return errs
+}
+
+// maxSnooze is how long issues may be snoozed for, longer snoozes hiding
+// issues which should rather be resolved
+const maxSnooze = 30 * 24 * time.Hour
+
+// validateSnooze validates a snooze, which must end in the future, within
+// maxSnooze, and tell who snoozes the issue unless the request identifies its
+// user
+func validateSnooze(limits config.LimitsConfig, snooze dto.Snooze, user string) dto.ValidationErrors {
+	var errs dto.ValidationErrors
+	now := time.Now()
+	switch {
+	case snooze.Until.IsZero():
+		errs = append(errs, dto.FieldError{
+			Field:      "until",
+			Constraint: "required",
+			Message:    "until is required",
+		})
+	case !snooze.Until.After(now):
+		errs = append(errs, dto.FieldError{
+			Field:      "until",
+			Value:      snooze.Until,
+			Constraint: "future",
+			Message:    "until must be in the future",
+		})
+	case snooze.Until.After(now.Add(maxSnooze)):
+		errs = append(errs, dto.FieldError{
+			Field:      "until",
+			Value:      snooze.Until,
+			Constraint: "max",
+			Param:      maxSnooze.String(),
+			Message:    fmt.Sprintf("issues cannot be snoozed for more than %d days", int(maxSnooze/(24*time.Hour))),
+		})
+	}
+	if snooze.SnoozedBy == "" && user == "" {
+		errs = append(errs, dto.FieldError{
+			Field:      "snoozedBy",
+			Constraint: "required",
+			Message:    "snoozedBy is required when the request doesn't identify its user",
+		})
+	}
+	errs.MaxLength("snoozedBy", utf8.RuneCountInString(snooze.SnoozedBy), limits.MaxTitleLength)
+	return errs
+}
+
+// maxClockSkew is how far in the future a reported detection time may be,
+// tolerating reporters whose clock is ahead of the server's
+const maxClockSkew = 5 * time.Minute
+
+// validateDetectedAt validates an optional detection time
+func validateDetectedAt(detectedAt time.Time) dto.ValidationErrors {
+	if !detectedAt.IsZero() && detectedAt.After(time.Now().Add(maxClockSkew)) {
+		return dto.ValidationErrors{{
+			Field:      "detectedAt",
+			Value:      detectedAt,
+			Constraint: "past",
+			Message:    "detectedAt cannot be in the future",
+		}}
+	}
+	return nil
+}
+
+// validateSeverityAlias validates an optional severity of a webhook, which may
+// be an alias of another severity scale, see models.ParseSeverity
+func validateSeverityAlias(severity string) dto.ValidationErrors {
+	if severity == "" {
+		return nil
+	}
+	if _, ok := models.ParseSeverity(severity); !ok {
+		return dto.ValidationErrors{{
+			Field:      "severity",
+			Value:      severity,
+			Constraint: "severity",
+			Message:    fmt.Sprintf("invalid severity %q", severity),
+		}}
+	}
+	return nil
+}
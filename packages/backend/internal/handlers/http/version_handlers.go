@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/version"
+)
+
+// VersionResponse describes the API and the build of the server serving it
+type VersionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	APIVersion  string `json:"apiVersion"`
+	version.Info
+}
+
+// NewVersionHandler returns the handler reporting the version of the API, and
+// the version, commit, build date, Go runtime and uptime of the server, so
+// that operators can tell exactly what's deployed.
+func NewVersionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, VersionResponse{
+			Name:        "Konflux Issues Dashboard API",
+			Description: "The backend service that powers the Konflux Issues Dashboard",
+			APIVersion:  APIVersion,
+			Info:        version.Get(),
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/version"
+)
+
+func TestVersionHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/version/", NewVersionHandler())
+
+	previous := version.Version
+	version.Version = "1.2.3"
+	t.Cleanup(func() { version.Version = previous })
+
+	req, _ := net_http.NewRequest("GET", "/api/v1/version/", nil)
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response VersionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Version != "1.2.3" || response.APIVersion != APIVersion {
+		t.Errorf("Expected version 1.2.3 of API %s, got %+v", APIVersion, response)
+	}
+	if response.GoVersion != runtime.Version() || response.Commit == "" || response.BuildDate == "" {
+		t.Errorf("Expected the build and runtime information, got %+v", response)
+	}
+	if response.StartedAt.IsZero() || response.Uptime < 0 {
+		t.Errorf("Expected the start time and uptime, got %+v", response)
+	}
+}
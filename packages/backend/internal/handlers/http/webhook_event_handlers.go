@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+const (
+	// defaultWebhookEventsLimit is how many webhook events are listed by default
+	defaultWebhookEventsLimit = 50
+	// maxWebhookEventsLimit is the maximum number of webhook events listed
+	maxWebhookEventsLimit = 200
+)
+
+// GetEvents handles GET /admin/webhook-events, listing the accepted webhooks
+// the most recent first, filtered by source, namespace and issueId
+func (h *WebhookHandler) GetEvents(c *gin.Context) {
+	filters := repository.WebhookEventFilters{
+		Source:    c.Query("source"),
+		Namespace: c.Query("namespace"),
+		IssueID:   c.Query("issueId"),
+		Limit:     defaultWebhookEventsLimit,
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxWebhookEventsLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid limit, must be between 1 and %d", maxWebhookEventsLimit)})
+			return
+		}
+		filters.Limit = limit
+	}
+
+	events, err := h.eventService.GetEvents(c.Request.Context(), filters)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("Failed to fetch webhook events")
+		respondWithServerError(c, err, "Failed to fetch webhook events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": events, "total": len(events)})
+}
+
+// GetEvent handles GET /admin/webhook-events/:id
+func (h *WebhookHandler) GetEvent(c *gin.Context) {
+	id := c.Param("id")
+
+	event, err := h.eventService.GetEvent(c.Request.Context(), id)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("event_id", id).Error("Failed to fetch webhook event")
+		respondWithServerError(c, err, "Failed to fetch webhook event")
+		return
+	}
+	if event == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook event not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// ReplayEvent handles POST /admin/webhook-events/:id/replay, processing the
+// payload of an accepted webhook again, with the current code. It responds as
+// the webhook would, and isn't recorded as a new event.
+func (h *WebhookHandler) ReplayEvent(c *gin.Context) {
+	id := c.Param("id")
+
+	event, err := h.eventService.GetEvent(c.Request.Context(), id)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("event_id", id).Error("Failed to fetch webhook event")
+		respondWithServerError(c, err, "Failed to fetch webhook event")
+		return
+	}
+	if event == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook event not found"})
+		return
+	}
+
+	payload, err := h.eventService.GetPayload(c.Request.Context(), event)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("event_id", id).Error("Failed to fetch webhook event payload")
+		respondWithServerError(c, err, "Failed to fetch webhook event payload")
+		return
+	}
+
+	requestLogger(c, h.logger).WithField("event_id", id).Info("Replaying webhook event")
+	c.Request.Body = io.NopCloser(bytes.NewReader(payload))
+	c.Set(replayKey, true)
+	switch event.Source {
+	case webhookSourcePipelineFailure:
+		h.PipelineFailure(c)
+	case webhookSourcePipelineSuccess:
+		h.PipelineSuccess(c)
+	default:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": fmt.Sprintf("Webhook source %s can't be replayed", event.Source)})
+	}
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+func setupTestWebhookEventRouter(handler *WebhookHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/webhooks/pipeline-failure", handler.PipelineFailure)
+	admin := router.Group("/admin", middleware.RequireAdmin("X-Forwarded-User", []string{"alice"}))
+	{
+		admin.GET("/webhook-events", handler.GetEvents)
+		admin.GET("/webhook-events/:id", handler.GetEvent)
+		admin.POST("/webhook-events/:id/replay", handler.ReplayEvent)
+	}
+	return router
+}
+
+func serveAdmin(router *gin.Engine, method, path, user string) *net_httptest.ResponseRecorder {
+	req, _ := net_http.NewRequest(method, path, nil)
+	if user != "" {
+		req.Header.Set("X-Forwarded-User", user)
+	}
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWebhookHandler_GetEvents(t *testing.T) {
+	events := &MockWebhookEventService{}
+	handler := setupTestWebhookHandler(&MockIssueService{})
+	handler.eventService = events
+	router := setupTestWebhookEventRouter(handler)
+	events.RecordEvent(t.Context(), "pipeline-failure", "team-alpha", []byte(`{"pipelineName": "build"}`), models.WebhookSignatureUnchecked, nil)
+	events.RecordEvent(t.Context(), "pipeline-success", "team-alpha", []byte(`{"pipelineName": "build"}`), models.WebhookSignatureUnchecked, nil)
+
+	if w := serveAdmin(router, "GET", "/admin/webhook-events", ""); w.Code != net_http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without user, got %d", w.Code)
+	}
+	if w := serveAdmin(router, "GET", "/admin/webhook-events", "bob"); w.Code != net_http.StatusForbidden {
+		t.Errorf("Expected status 403 for users who aren't admins, got %d", w.Code)
+	}
+	if w := serveAdmin(router, "GET", "/admin/webhook-events?limit=1000", "alice"); w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid limit, got %d", w.Code)
+	}
+
+	w := serveAdmin(router, "GET", "/admin/webhook-events?source=pipeline-failure", "alice")
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data  []models.WebhookEvent `json:"data"`
+		Total int                   `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Total != 1 || response.Data[0].ID != "event-1" || string(response.Data[0].Payload) != `{"pipelineName":"build"}` {
+		t.Errorf("Expected the pipeline failure, got %+v", response)
+	}
+
+	if w := serveAdmin(router, "GET", "/admin/webhook-events/event-2", "alice"); w.Code != net_http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if w := serveAdmin(router, "GET", "/admin/webhook-events/event-3", "alice"); w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_ReplayEvent(t *testing.T) {
+	issues := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	events := &MockWebhookEventService{}
+	handler := setupTestWebhookHandler(issues)
+	handler.eventService = events
+	router := setupTestWebhookEventRouter(handler)
+
+	body := `{"pipelineName": "pipeline-xyz", "namespace": "team-failed-pr", "failureReason": "OOMKilled"}`
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(net_httptest.NewRecorder(), req)
+	if len(events.events) != 1 {
+		t.Fatalf("Expected the webhook to be recorded, got %+v", events.events)
+	}
+	issues.createOrUpdateIssueRequest.Title = ""
+
+	w := serveAdmin(router, "POST", "/admin/webhook-events/event-1/replay", "alice")
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected the response of the webhook, got %d: %s", w.Code, w.Body.String())
+	}
+	if issues.createOrUpdateIssueRequest.Title != "Pipeline run failed: pipeline-xyz" {
+		t.Errorf("Expected the payload to be processed again, got %+v", issues.createOrUpdateIssueRequest)
+	}
+	if len(events.events) != 1 {
+		t.Errorf("Expected the replay not to be recorded, got %+v", events.events)
+	}
+
+	// Replays aren't signed, their signature was checked when they were received
+	handler.secret = "webhook-secret"
+	if w := serveAdmin(router, "POST", "/admin/webhook-events/event-1/replay", "alice"); w.Code != net_http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", w.Code)
+	}
+
+	events.RecordEvent(t.Context(), "unknown", "team-alpha", []byte(`{}`), models.WebhookSignatureUnchecked, nil)
+	if w := serveAdmin(router, "POST", "/admin/webhook-events/event-2/replay", "alice"); w.Code != net_http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422 for unknown sources, got %d", w.Code)
+	}
+	if w := serveAdmin(router, "POST", "/admin/webhook-events/event-3/replay", "alice"); w.Code != net_http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+	if w := serveAdmin(router, "POST", "/admin/webhook-events/event-1/replay", "bob"); w.Code != net_http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+}
@@ -1,47 +1,164 @@
 package http
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/normalize"
 	"github.com/konflux-ci/kite/internal/services"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// webhookSourcePipelineFailure is the source of the events of PipelineFailure
+	webhookSourcePipelineFailure = "pipeline-failure"
+	// webhookSourcePipelineSuccess is the source of the events of PipelineSuccess
+	webhookSourcePipelineSuccess = "pipeline-success"
+	// signatureHeader is the header signing the payloads of webhooks, as
+	// sha256=<hex encoded HMAC-SHA256 of the payload with the webhook secret>
+	signatureHeader = "X-Kite-Signature-256"
+	// replayKey is the key marking replayed webhooks in their context, see ReplayEvent
+	replayKey = "webhookReplay"
+)
+
 // WebhookHandler handles incoming webhook requests for pipeline events.
 type WebhookHandler struct {
-	issueService services.IssueServiceInterface // Issue service for managing issues
-	logger       *logrus.Logger                 // Logger for structured logging
+	issueService services.IssueServiceInterface        // Issue service for managing issues
+	eventService services.WebhookEventServiceInterface // Event service recording the accepted webhooks
+	limits       config.LimitsConfig                   // Maximum lengths of issue fields
+	secret       string                                // Secret signing the payloads, signatures aren't checked when empty
+	normalizer   *normalize.Normalizer                 // Normalizer of pipeline names and failure reasons, nil for none
+	logger       *logrus.Logger                        // Logger for structured logging
 }
 
 // NewWebhookHandler returns a new handler for the webhooks router
-func NewWebhookHandler(issueService services.IssueServiceInterface, logger *logrus.Logger) *WebhookHandler {
+func NewWebhookHandler(issueService services.IssueServiceInterface, eventService services.WebhookEventServiceInterface, limits config.LimitsConfig, secret string, normalizer *normalize.Normalizer, logger *logrus.Logger) *WebhookHandler {
 	return &WebhookHandler{
 		issueService: issueService,
+		eventService: eventService,
+		limits:       limits,
+		secret:       secret,
+		normalizer:   normalizer,
 		logger:       logger,
 	}
 }
 
+// readPayload reads the payload of a webhook, checking its signature when a
+// webhook secret is configured. Replayed payloads aren't checked again.
+// Responds with 401 when the signature is missing or invalid.
+func (h *WebhookHandler) readPayload(c *gin.Context) ([]byte, models.WebhookSignatureStatus, bool) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+		return nil, "", false
+	}
+	if h.secret == "" || c.GetBool(replayKey) {
+		return payload, models.WebhookSignatureUnchecked, true
+	}
+	if !validSignature(h.secret, payload, c.GetHeader(signatureHeader)) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid signature"})
+		return nil, "", false
+	}
+	return payload, models.WebhookSignatureValid, true
+}
+
+// validSignature checks whether a signature, as set in signatureHeader, signs
+// a payload with a secret
+func validSignature(secret string, payload []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// recordEvent records an accepted webhook, unless it's replayed. The webhook
+// was processed already, failing to record it doesn't fail the request.
+func (h *WebhookHandler) recordEvent(c *gin.Context, source, namespace string, payload []byte, signature models.WebhookSignatureStatus, issueID *string) {
+	if c.GetBool(replayKey) {
+		return
+	}
+	if _, err := h.eventService.RecordEvent(c.Request.Context(), source, namespace, payload, signature, issueID); err != nil {
+		requestLogger(c, h.logger).WithError(err).WithField("source", source).Warn("Failed to record webhook event")
+	}
+}
+
+// truncationMarker ends texts shortened to fit a length limit
+const truncationMarker = "… (truncated)"
+
+// truncate shortens s to at most maxLength characters, cutting on a character
+// boundary and ending it with truncationMarker when it's too long.
+func truncate(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	markerLength := utf8.RuneCountInString(truncationMarker)
+	if maxLength <= markerLength {
+		return string(runes[:maxLength])
+	}
+	return string(runes[:maxLength-markerLength]) + truncationMarker
+}
+
 // PipelineFailureRequest represents the payload for a pipeline failure webhook.
 //
 // Fields:
-//   - pipelineName:  (string, required) - Name of the failed pipeline.
-//   - namespace:     (string, required) - Kubernetes namespace where the pipeline ran.
-//   - failureReason: (string, required) - Why the pipeline failed. (required)
-//   - severity:      (string. optional, - defaults to "major") Issue severity.
-//   - runId:         (string, optional) - Pipeline run identifier.
-//   - logsUrl:       (string, optional) - Direct URL to logs.
+//   - pipelineName:   (string, required) - Name of the failed pipeline.
+//   - namespace:      (string, required) - Kubernetes namespace where the pipeline ran.
+//   - failureReason:  (string, required) - Why the pipeline failed. (required)
+//   - severity:       (string. optional, - defaults to "major") Issue severity, or an alias, see models.ParseSeverity.
+//   - runId:          (string, optional) - Pipeline run identifier.
+//   - logsUrl:        (string, optional) - Direct URL to logs.
+//   - detectedAt:     (RFC 3339 time, optional) - When the pipeline failed, defaults to when the webhook is received.
+//   - fingerprint:    (string, optional) - Groups the failures into an issue instead of the pipeline, see models.Issue.Fingerprint.
+//   - repoUrl:        (string, optional) - URL of the git repository the pipeline ran for, linked without commitUrl.
+//   - revision:       (string, optional) - Commit the pipeline ran for.
+//   - pullRequest:    (string, optional) - Number of the pull request the pipeline ran for.
+//   - commitUrl:      (string, optional) - URL of the commit, linked from the issue.
+//   - pullRequestUrl: (string, optional) - URL of the pull request, linked from the issue.
+//   - imageUrl:       (string, optional) - Image built by the pipeline, without digest.
+//   - imageDigest:    (string, optional) - Digest of the image built by the pipeline.
+//   - sbomUrl:        (string, optional) - Reference of the SBOM of the image.
+//   - artifacts:      (map, optional) - Other artifacts built by the pipeline, by result name.
 type PipelineFailureRequest struct {
-	PipelineName  string `json:"pipelineName" binding:"required"`
-	Namespace     string `json:"namespace" binding:"required"`
-	Severity      string `json:"severity"`
-	FailureReason string `json:"failureReason" binding:"required"`
-	RunID         string `json:"runId"`
-	LogsURL       string `json:"logsUrl"`
+	PipelineName  string    `json:"pipelineName" binding:"required"`
+	Namespace     string    `json:"namespace" binding:"required"`
+	Severity      string    `json:"severity"`
+	FailureReason string    `json:"failureReason" binding:"required"`
+	RunID         string    `json:"runId"`
+	LogsURL       string    `json:"logsUrl"`
+	DetectedAt    time.Time `json:"detectedAt"`
+	Fingerprint   string    `json:"fingerprint" binding:"max=255"`
+	// The git metadata of the run, e.g. from the annotations of Pipelines as Code
+	RepoURL        string `json:"repoUrl"`
+	Revision       string `json:"revision"`
+	PullRequest    string `json:"pullRequest"`
+	CommitURL      string `json:"commitUrl"`
+	PullRequestURL string `json:"pullRequestUrl"`
+	// The artifacts built by the run, e.g. from the results of a build pipeline
+	ImageURL    string            `json:"imageUrl"`
+	ImageDigest string            `json:"imageDigest"`
+	SBOMURL     string            `json:"sbomUrl"`
+	Artifacts   map[string]string `json:"artifacts"`
 }
 
 // PipelineSuccessRequest represents the payload for a pipeline success webhook.
@@ -49,9 +166,13 @@ type PipelineFailureRequest struct {
 // Fields:
 //   - pipelineName: (string, required) - Name of the successful pipeline.
 //   - namespace:    (string, required) - Kubernetes namespace where the pipeline ran.
+//   - reason:       (string, optional) - Why the issues are resolved, defaults to the pipeline succeeding.
+//   - resolvedBy:   (string, optional) - Who or what resolved the issues, defaults to "pipeline-success-webhook".
 type PipelineSuccessRequest struct {
 	PipelineName string `json:"pipelineName" binding:"required"`
 	Namespace    string `json:"namespace" binding:"required"`
+	Reason       string `json:"reason"`
+	ResolvedBy   string `json:"resolvedBy"`
 }
 
 // PipelineFailure handles pipeline failure webhooks with idempotent behavior.
@@ -61,14 +182,31 @@ type PipelineSuccessRequest struct {
 //   - pipelineName:   (string, required) - Name of the failed pipeline.
 //   - namespace:      (string, required) - Namespace where the pipeline ran.
 //   - failureReason:  (string, required) - Description of why the pipeline failed.
-//   - severity:       (string, optional, default: "major") - Issue severity level.
+//   - severity:       (string, optional, default: "major") - Issue severity level, "low", "medium" and "high" mapping to info, minor and major.
 //   - runId:          (string, optional) - Pipeline run identifier for log URLs.
-//   - logsUrl:        (string, optional) - Direct URL to logs. Generated if omitted.
+//   - logsUrl:        (string, optional) - Direct URL to logs. Generated from the link templates if omitted.
+//   - detectedAt:     (RFC 3339 time, optional) - When the pipeline failed. Defaults to now, can't be in the future.
+//   - fingerprint:    (string, optional) - Groups the failures into an issue instead of the pipeline, up to 255 characters.
+//   - repoUrl:        (string, optional) - Link to the git repository, unless commitUrl is set.
+//   - revision:       (string, optional) - Commit of the run, {{revision}} in the link templates.
+//   - pullRequest:    (string, optional) - Pull request of the run, {{pullRequest}} in the link templates.
+//   - commitUrl:      (string, optional) - Link to the commit the run failed for.
+//   - pullRequestUrl: (string, optional) - Link to the pull request the run failed for.
+//   - imageUrl:       (string, optional) - Image built by the run, in the details and {{imageUrl}} in the link templates.
+//   - imageDigest:    (string, optional) - Digest of that image, in the details and {{imageDigest}} in the link templates.
+//   - sbomUrl:        (string, optional) - SBOM of that image, in the details and {{sbomUrl}} in the link templates.
+//   - artifacts:      (map, optional) - Other artifacts built by the run, by name, in the details.
+//
+// Query Parameters:
+//   - dryRun: (bool, optional) - Validate and deduplicate the issue without saving it, see DryRunIssue.
 //
 // Response:
+//   - 200 OK: Dry run, telling whether the issue would be created, updated or reopened
 //   - 201 Created: Issue was created or updated successfully
-//   - 400 Bad Request: Missing required fields
+//   - 400 Bad Request: Validation failed, listing the fields missing or invalid, e.g. a detectedAt in the future
+//   - 401 Unauthorized: Missing or invalid signature, when a webhook secret is configured
 //   - 500 Internal Server Error: Database or processing error
+//   - 504 Gateway Timeout: The database didn't respond in time
 //
 // Example:
 //
@@ -80,59 +218,136 @@ type PipelineSuccessRequest struct {
 //		  "failureReason": "Docker build failed"
 //		}
 func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+	payload, signature, ok := h.readPayload(c)
+	if !ok {
+		return
+	}
+
 	var req PipelineFailureRequest
-	// Check if the request binds to proper JSON, in the format specified
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindJSON(c, payload, &req, func() dto.ValidationErrors { return validatePipelineFailure(req) }) {
 		return
 	}
 
-	// Format issue data
-	logsURL := req.LogsURL
-	if logsURL == "" {
-		baseURL := config.GetEnvOrDefault("KITE_CLUSTER_URL", "https://konflux.dev")
-		logsEndpoint := config.GetEnvOrDefault("KITE_LOGS_ENDPOINT", "/logs/pipelineruns/")
-		logsURL = fmt.Sprintf("%s%s%s", baseURL, logsEndpoint, req.RunID)
+	// Dry runs aren't recorded as webhook events, as they change nothing
+	if dryRun {
+		result, err := h.issueService.DryRunIssue(c.Request.Context(), h.failureIssue(req), models.IssueSourceWebhook)
+		if err != nil {
+			requestLogger(c, h.logger).WithError(err).Error("Failed to dry run pipeline failure webhook")
+			respondWithServerError(c, err, "Failed to process webhook")
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	// Create or update the issue
+	issue, err := h.issueService.CreateOrUpdateIssue(c.Request.Context(), h.failureIssue(req))
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("Failed to create or update pipeline issue")
+		respondWithServerError(c, err, "Failed to process webhook")
+		return
 	}
 
+	requestLogger(c, h.logger).WithField("issue_id", issue.ID).Info("Processed pipeline failure webhook")
+	h.recordEvent(c, webhookSourcePipelineFailure, req.Namespace, payload, signature, &issue.ID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"issue":  issue,
+	})
+}
+
+// validatePipelineFailure validates the fields of pipeline failures the
+// binding tags can't
+func validatePipelineFailure(req PipelineFailureRequest) dto.ValidationErrors {
+	return append(validateDetectedAt(req.DetectedAt), validateSeverityAlias(req.Severity)...)
+}
+
+// failureIssue returns the issue reported by a pipeline failure
+func (h *WebhookHandler) failureIssue(req PipelineFailureRequest) dto.CreateIssueRequest {
+	// Reporters may use the aliases of other severity scales, see models.ParseSeverity
 	severity := models.SeverityMajor
 	if req.Severity != "" {
-		severity = models.Severity(req.Severity)
+		severity, _ = models.ParseSeverity(req.Severity)
 	}
 
-	issueData := dto.CreateIssueRequest{
-		Title:       fmt.Sprintf("Pipeline run failed: %s", req.PipelineName),
-		Description: fmt.Sprintf("The pipeline run %s failed with reason: %s", req.PipelineName, req.FailureReason),
+	// The runs of a pipeline are named after it with a random suffix, and
+	// their failure reasons hold timestamps and IDs: the issue is scoped to the
+	// normalized name, so that the failures of all the runs are deduplicated.
+	pipelineName := h.normalizer.Normalize(req.PipelineName)
+
+	// Failure reasons, e.g. Tekton condition messages, can be enormous. The
+	// description gets a shortened version and the details keep the full text.
+	issue := dto.CreateIssueRequest{
+		Title:       truncate(fmt.Sprintf("Pipeline run failed: %s", pipelineName), h.limits.MaxTitleLength),
+		Description: truncate(fmt.Sprintf("The pipeline run %s failed with reason: %s", req.PipelineName, h.normalizer.Normalize(req.FailureReason)), h.limits.MaxDescriptionLength),
+		Details:     truncate(artifactDetails(req)+req.FailureReason, h.limits.MaxDetailsLength),
 		Severity:    severity,
 		IssueType:   models.IssueTypePipeline,
 		Namespace:   req.Namespace,
+		DetectedAt:  req.DetectedAt,
+		Fingerprint: req.Fingerprint,
 		Scope: dto.ScopeReqBody{
 			ResourceType:      "pipelinerun",
-			ResourceName:      req.PipelineName,
+			ResourceName:      pipelineName,
 			ResourceNamespace: req.Namespace,
 		},
-		Links: []dto.CreateLinkRequest{
-			{
-				Title: "Pipeline Run Logs",
-				URL:   logsURL,
-			},
+		LinkVariables: map[string]string{
+			"runId":        req.RunID,
+			"pipelineName": req.PipelineName,
+			"revision":     req.Revision,
+			"pullRequest":  req.PullRequest,
+			"imageUrl":     req.ImageURL,
+			"imageDigest":  req.ImageDigest,
+			"sbomUrl":      req.SBOMURL,
 		},
 	}
-
-	// Create or update the issue
-	issue, err := h.issueService.CreateOrUpdateIssue(c, issueData)
-	if err != nil {
-		h.logger.WithError(err).Error("Failed to create or update pipeline issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
-		return
+	// Without the logs URL of the run, the issue links to the logs of the
+	// link templates, see config.LinksConfig
+	if req.LogsURL != "" {
+		issue.Links = append(issue.Links, dto.CreateLinkRequest{Title: "Pipeline Run Logs", URL: req.LogsURL})
 	}
+	// The issue links to the change which broke the pipeline, else to its repository
+	if req.CommitURL != "" {
+		issue.Links = append(issue.Links, dto.CreateLinkRequest{Title: "Commit", URL: req.CommitURL})
+	} else if req.RepoURL != "" {
+		issue.Links = append(issue.Links, dto.CreateLinkRequest{Title: "Repository", URL: req.RepoURL})
+	}
+	if req.PullRequestURL != "" {
+		issue.Links = append(issue.Links, dto.CreateLinkRequest{Title: "Pull Request", URL: req.PullRequestURL})
+	}
+	return issue
+}
 
-	h.logger.WithField("issue_id", issue.ID).Info("Processed pipeline failure webhook")
-
-	c.JSON(http.StatusCreated, gin.H{
-		"status": "success",
-		"issue":  issue,
-	})
+// artifactDetails returns the artifacts built by a failed run, heading the
+// details of its issue so that they aren't truncated, for the issues to be
+// correlated with the images they were built for, empty without artifacts
+func artifactDetails(req PipelineFailureRequest) string {
+	var details strings.Builder
+	if req.ImageURL != "" {
+		image := req.ImageURL
+		if req.ImageDigest != "" {
+			image += "@" + req.ImageDigest
+		}
+		fmt.Fprintf(&details, "Image: %s\n", image)
+	}
+	if req.SBOMURL != "" {
+		fmt.Fprintf(&details, "SBOM: %s\n", req.SBOMURL)
+	}
+	if len(req.Artifacts) > 0 {
+		details.WriteString("Artifacts:\n")
+		for _, name := range slices.Sorted(maps.Keys(req.Artifacts)) {
+			fmt.Fprintf(&details, "  - %s: %s\n", name, req.Artifacts[name])
+		}
+	}
+	if details.Len() == 0 {
+		return ""
+	}
+	return details.String() + "\n"
 }
 
 // PipelineSuccess handles pipeline success webhooks.
@@ -140,11 +355,18 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 // Request Body:
 //   - pipelineName: (string, required) - Name of the successful pipeline
 //   - namespace:    (string, required) -  Namespace where the pipeline ran
+//   - reason:       (string, optional) - Why the issues are resolved
+//   - resolvedBy:   (string, optional) - Who or what resolved the issues
+//
+// Query Parameters:
+//   - dryRun: (bool, optional) - Count the issues that would be resolved without resolving them.
 //
 // Response:
-//   - 200 OK: Issues related to the pipeline are resolved
-//   - 400 Bad Request: Missing required fields
+//   - 200 OK: Issues related to the pipeline are resolved, or would be for dry runs
+//   - 400 Bad Request: Validation failed, listing the fields missing or invalid, e.g. a reason too long
+//   - 401 Unauthorized: Missing or invalid signature, when a webhook secret is configured
 //   - 500 Internal Server Error: Database or processing error
+//   - 504 Gateway Timeout: The database didn't respond in time
 //
 // Issues that match the pipeline name and namespace will be marked as resolved using
 // the scope:
@@ -161,30 +383,304 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 //			   "namespace": "team-alpha"
 //			 }
 func (h *WebhookHandler) PipelineSuccess(c *gin.Context) {
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+	payload, signature, ok := h.readPayload(c)
+	if !ok {
+		return
+	}
+
 	var req PipelineSuccessRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindJSON(c, payload, &req, func() dto.ValidationErrors { return h.validatePipelineSuccess(req) }) {
+		return
+	}
+
+	if dryRun {
+		results, err := h.issueService.DryRunWebhookBatch(c.Request.Context(), []dto.WebhookBatchItem{h.successItem(req)})
+		if err != nil {
+			requestLogger(c, h.logger).WithError(err).Error("Failed to dry run pipeline success webhook")
+			respondWithServerError(c, err, "Failed to resolve pipeline issues")
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":   "success",
+			"dryRun":   true,
+			"resolved": results[0].Resolved,
+			"message":  fmt.Sprintf("Would resolve %d issue(s) for pipeline %s", results[0].Resolved, req.PipelineName),
+		})
 		return
 	}
 
 	// Resolve any active issues for this pipeline
-	resolved, err := h.issueService.ResolveIssuesByScope(c.Request.Context(), "pipelinerun", req.PipelineName, req.Namespace)
+	resolved, err := h.issueService.ResolveIssuesByScope(c.Request.Context(), "pipelinerun", h.normalizer.Normalize(req.PipelineName), req.Namespace, successResolution(req))
 	if err != nil {
-		h.logger.WithError(err).Errorf("failed to resolve issues for pipeline run %s : %v", req.PipelineName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to resolve pipeline issues",
-		})
+		requestLogger(c, h.logger).WithError(err).Errorf("failed to resolve issues for pipeline run %s : %v", req.PipelineName, err)
+		respondWithServerError(c, err, "Failed to resolve pipeline issues")
 		return
 	}
 
-	h.logger.WithFields(logrus.Fields{
+	requestLogger(c, h.logger).WithFields(logrus.Fields{
 		"pipeline":  req.PipelineName,
 		"namespace": req.Namespace,
 		"resolved":  resolved,
 	}).Info("Pipeline success webhook processed")
+	h.recordEvent(c, webhookSourcePipelineSuccess, req.Namespace, payload, signature, nil)
 
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "success",
 		"message": fmt.Sprintf("Resolved %d issue(s) for pipeline %s", resolved, req.PipelineName),
 	})
 }
+
+// validatePipelineSuccess validates the fields of pipeline successes the
+// binding tags can't
+func (h *WebhookHandler) validatePipelineSuccess(req PipelineSuccessRequest) dto.ValidationErrors {
+	return validateResolution(h.limits, "reason", dto.Resolution{Reason: req.Reason, ResolvedBy: req.ResolvedBy})
+}
+
+// successResolution returns the resolution of the issues of a pipeline succeeding
+func successResolution(req PipelineSuccessRequest) dto.Resolution {
+	resolution := dto.Resolution{Reason: req.Reason, ResolvedBy: req.ResolvedBy}
+	if resolution.Reason == "" {
+		resolution.Reason = fmt.Sprintf("Pipeline %s succeeded", req.PipelineName)
+	}
+	if resolution.ResolvedBy == "" {
+		resolution.ResolvedBy = "pipeline-success-webhook"
+	}
+	return resolution
+}
+
+// successItem returns the operation resolving the issues of a pipeline
+// succeeding, scoped to its normalized name as its failures are
+func (h *WebhookHandler) successItem(req PipelineSuccessRequest) dto.WebhookBatchItem {
+	return dto.WebhookBatchItem{
+		ResolveScope: &dto.ScopeReqBody{
+			ResourceType:      "pipelinerun",
+			ResourceName:      h.normalizer.Normalize(req.PipelineName),
+			ResourceNamespace: req.Namespace,
+		},
+		Resolution: successResolution(req),
+	}
+}
+
+// WebhookBatchItemRequest is a webhook of a batch: its type, the endpoint it
+// would be sent to on its own, and its payload.
+//
+// Fields:
+//   - type:    (string, required) - "pipeline-failure" or "pipeline-success".
+//   - payload: (object, required) - A PipelineFailureRequest or PipelineSuccessRequest.
+type WebhookBatchItemRequest struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// webhookBatchItemResult is the outcome of a webhook of a batch
+type webhookBatchItemResult struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	// Status is success, invalid, or skipped for valid webhooks of batches
+	// holding invalid ones
+	Status string `json:"status"`
+	// IssueID is the issue reported by pipeline failures
+	IssueID string `json:"issueId,omitempty"`
+	// Resolved is how many issues pipeline successes resolved
+	Resolved *int64 `json:"resolved,omitempty"`
+	// Action is what pipeline failures would do to their issue, for dry runs
+	Action dto.DryRunAction `json:"action,omitempty"`
+	// Details lists the fields of invalid webhooks failing validation
+	Details dto.ValidationErrors `json:"details,omitempty"`
+}
+
+// PipelineBatch handles batches of pipeline failure and success webhooks,
+// processing them in order in one transaction, so that reporters buffering
+// webhooks send fewer requests. Either all the webhooks of a batch are
+// processed, or none is. Each webhook is recorded as a webhook event of its
+// own, replayed as if it were sent on its own.
+//
+// Request Body: an array of WebhookBatchItemRequest, at most
+// KITE_MAX_WEBHOOK_BATCH_SIZE of them. Their payloads must be in the
+// namespace of the request.
+//
+// Query Parameters:
+//   - dryRun: (bool, optional) - Process the batch without saving anything, the results telling
+//     what each webhook would do. The issues pipeline failures would create have no issueId.
+//
+// Response:
+//   - 200 OK: All the webhooks were processed, or would be for dry runs, with the result of each
+//   - 400 Bad Request: The batch is empty, too large, or holds invalid webhooks, none being processed
+//   - 401 Unauthorized: Missing or invalid signature of the whole batch, when a webhook secret is configured
+//   - 500 Internal Server Error: Database or processing error, none of the webhooks being processed
+//   - 504 Gateway Timeout: The database didn't respond in time
+//
+// Example:
+//
+//	 POST /api/v1/webhooks/batch?namespace=team-alpha
+//	 Content-Type: application/json
+//		[
+//		  {"type": "pipeline-failure", "payload": {"pipelineName": "frontend-build", "namespace": "team-alpha", "failureReason": "Docker build failed"}},
+//		  {"type": "pipeline-success", "payload": {"pipelineName": "backend-build", "namespace": "team-alpha"}}
+//		]
+func (h *WebhookHandler) PipelineBatch(c *gin.Context) {
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+	payload, signature, ok := h.readPayload(c)
+	if !ok {
+		return
+	}
+
+	var requests []WebhookBatchItemRequest
+	if err := json.Unmarshal(payload, &requests); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if len(requests) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Empty batch"})
+		return
+	}
+	if maxSize := h.limits.MaxWebhookBatchSize; maxSize > 0 && len(requests) > maxSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Batch too large", "details": fmt.Sprintf("a batch holds at most %d webhooks", maxSize)})
+		return
+	}
+
+	namespace := c.Query("namespace")
+	items := make([]dto.WebhookBatchItem, len(requests))
+	namespaces := make([]string, len(requests))
+	results := make([]webhookBatchItemResult, len(requests))
+	valid := true
+	for i, req := range requests {
+		var errs dto.ValidationErrors
+		items[i], namespaces[i], errs = h.batchItem(req)
+		if namespace != "" && namespaces[i] != "" && namespaces[i] != namespace {
+			errs = append(errs, dto.FieldError{
+				Field:      "payload.namespace",
+				Value:      namespaces[i],
+				Constraint: "namespace",
+				Param:      namespace,
+				Message:    fmt.Sprintf("namespace must be the namespace of the request, %s", namespace),
+			})
+		}
+		results[i] = webhookBatchItemResult{Index: i, Type: req.Type, Status: "success", Details: errs}
+		if len(errs) > 0 {
+			results[i].Status = "invalid"
+			valid = false
+		}
+	}
+	if !valid {
+		for i := range results {
+			if results[i].Status == "success" {
+				results[i].Status = "skipped"
+			}
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "results": results})
+		return
+	}
+
+	if dryRun {
+		h.dryRunBatch(c, items, results)
+		return
+	}
+
+	processed, err := h.issueService.ProcessWebhookBatch(c.Request.Context(), items)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("Failed to process webhook batch")
+		respondWithServerError(c, err, "Failed to process webhook batch")
+		return
+	}
+
+	for i, req := range requests {
+		var issueID *string
+		if issue := processed[i].Issue; issue != nil {
+			issueID = &issue.ID
+			results[i].IssueID = issue.ID
+		} else {
+			results[i].Resolved = &processed[i].Resolved
+		}
+		h.recordEvent(c, req.Type, namespaces[i], req.Payload, signature, issueID)
+	}
+
+	requestLogger(c, h.logger).WithFields(logrus.Fields{
+		"namespace": namespace,
+		"webhooks":  len(requests),
+	}).Info("Processed webhook batch")
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": results,
+	})
+}
+
+// dryRunBatch responds with what the operations of a valid batch of webhooks
+// would do, without recording them as webhook events
+func (h *WebhookHandler) dryRunBatch(c *gin.Context, items []dto.WebhookBatchItem, results []webhookBatchItemResult) {
+	processed, err := h.issueService.DryRunWebhookBatch(c.Request.Context(), items)
+	if err != nil {
+		requestLogger(c, h.logger).WithError(err).Error("Failed to dry run webhook batch")
+		respondWithServerError(c, err, "Failed to process webhook batch")
+		return
+	}
+
+	for i := range results {
+		if issue := processed[i].Issue; issue != nil {
+			results[i].Action = processed[i].Action
+			// The issues created are rolled back
+			if processed[i].Action != dto.DryRunCreate {
+				results[i].IssueID = issue.ID
+			}
+		} else {
+			results[i].Resolved = &processed[i].Resolved
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"dryRun":  true,
+		"results": results,
+	})
+}
+
+// batchItem converts a webhook of a batch into the operation it stands for,
+// returning the namespace of its payload and the fields failing validation,
+// prefixed with payload
+func (h *WebhookHandler) batchItem(req WebhookBatchItemRequest) (dto.WebhookBatchItem, string, dto.ValidationErrors) {
+	var item dto.WebhookBatchItem
+	var namespace string
+	var errs dto.ValidationErrors
+	var err error
+	switch req.Type {
+	case webhookSourcePipelineFailure:
+		var failure PipelineFailureRequest
+		errs, err = decodeJSON(req.Payload, &failure, func() dto.ValidationErrors { return validatePipelineFailure(failure) })
+		issue := h.failureIssue(failure)
+		item.Issue = &issue
+		namespace = failure.Namespace
+	case webhookSourcePipelineSuccess:
+		var success PipelineSuccessRequest
+		errs, err = decodeJSON(req.Payload, &success, func() dto.ValidationErrors { return h.validatePipelineSuccess(success) })
+		item = h.successItem(success)
+		namespace = success.Namespace
+	default:
+		return item, "", dto.ValidationErrors{{
+			Field:      "type",
+			Value:      req.Type,
+			Constraint: "oneof",
+			Param:      webhookSourcePipelineFailure + " " + webhookSourcePipelineSuccess,
+			Message:    fmt.Sprintf("type must be one of: %s, %s", webhookSourcePipelineFailure, webhookSourcePipelineSuccess),
+		}}
+	}
+	if err != nil {
+		return item, namespace, dto.ValidationErrors{{
+			Field:      "payload",
+			Constraint: "object",
+			Message:    "payload must be a JSON object",
+		}}
+	}
+
+	for i := range errs {
+		errs[i].Field = "payload." + errs[i].Field
+	}
+	return item, namespace, errs
+}
@@ -2,14 +2,25 @@ package http
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	net_http "net/http"
 	net_httptest "net/http/httptest"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/normalize"
 	"github.com/konflux-ci/kite/internal/testhelpers"
 	"github.com/sirupsen/logrus"
 )
@@ -17,7 +28,8 @@ import (
 func setupTestWebhookHandler(mockService *MockIssueService) *WebhookHandler {
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
-	return NewWebhookHandler(mockService, logger)
+	normalizer := normalize.NewNormalizer(config.NormalizationConfig{DefaultRules: true})
+	return NewWebhookHandler(mockService, &MockWebhookEventService{}, config.GetLimitsConfig(), "", normalizer, logger)
 }
 
 func setupTestWebhookRouter(handler *WebhookHandler) *gin.Engine {
@@ -29,6 +41,7 @@ func setupTestWebhookRouter(handler *WebhookHandler) *gin.Engine {
 	{
 		v1.POST("/pipeline-failure", handler.PipelineFailure)
 		v1.POST("/pipeline-success", handler.PipelineSuccess)
+		v1.POST("/batch", handler.PipelineBatch)
 	}
 
 	return router
@@ -126,6 +139,267 @@ func TestWebhookHandler_PipelineFailure(t *testing.T) {
 	}
 }
 
+func TestWebhookHandler_PipelineFailure_FutureDetectedAt(t *testing.T) {
+	handler := setupTestWebhookHandler(&MockIssueService{})
+	router := setupTestWebhookRouter(handler)
+
+	reqBody, err := json.Marshal(PipelineFailureRequest{
+		PipelineName:  "pipeline-xyz",
+		Namespace:     "team-failed-pr",
+		FailureReason: "task run timed out",
+		DetectedAt:    time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_LongFailureReason(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	handler := setupTestWebhookHandler(mockService)
+	handler.limits = config.LimitsConfig{MaxTitleLength: 20, MaxDescriptionLength: 100, MaxDetailsLength: 1000}
+	router := setupTestWebhookRouter(handler)
+
+	failureReason := strings.Repeat("step-build exited with code 1: ", 20)
+	reqBody, err := json.Marshal(PipelineFailureRequest{
+		PipelineName:  "pipeline-with-a-long-name",
+		Namespace:     "team-failed-pr",
+		FailureReason: failureReason,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+
+	created := mockService.createOrUpdateIssueRequest
+	if n := utf8.RuneCountInString(created.Title); n != 20 || !strings.HasSuffix(created.Title, truncationMarker) {
+		t.Errorf("Expected a truncated title of 20 characters, got %q", created.Title)
+	}
+	if n := utf8.RuneCountInString(created.Description); n != 100 || !strings.HasSuffix(created.Description, truncationMarker) {
+		t.Errorf("Expected a truncated description of 100 characters, got %q", created.Description)
+	}
+	if created.Details != failureReason {
+		t.Errorf("Expected the details to keep the full failure reason, got %q", created.Details)
+	}
+}
+
+func TestWebhookHandler_Normalization(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+	post := func(path string, body any) {
+		t.Helper()
+		reqBody, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req, _ := net_http.NewRequest("POST", path, bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != net_http.StatusCreated && w.Code != net_http.StatusOK {
+			t.Fatalf("Expected the webhook to succeed, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// The runs of a pipeline report the same issue
+	failureReason := "Step build failed at 2025-01-01T12:00:00Z in TaskRun 3f2a9c4e-8b1d-4e6f-a7c2-5d9e0b1f2a3c"
+	post("/webhooks/pipeline-failure", PipelineFailureRequest{
+		PipelineName:  "frontend-build-x8f2k",
+		Namespace:     "team-alpha",
+		FailureReason: failureReason,
+	})
+	created := mockService.createOrUpdateIssueRequest
+	if created.Scope.ResourceName != "frontend-build" || created.Title != "Pipeline run failed: frontend-build" {
+		t.Errorf("Expected the issue of frontend-build, got %q scoped to %q", created.Title, created.Scope.ResourceName)
+	}
+	expectedDescription := "The pipeline run frontend-build-x8f2k failed with reason: Step build failed at <timestamp> in TaskRun <id>"
+	if created.Description != expectedDescription {
+		t.Errorf("Expected the description %q, got %q", expectedDescription, created.Description)
+	}
+	if created.Details != failureReason {
+		t.Errorf("Expected the details to keep the failure reason, got %q", created.Details)
+	}
+
+	// A later run succeeding resolves it
+	post("/webhooks/pipeline-success", PipelineSuccessRequest{PipelineName: "frontend-build-9bq4z", Namespace: "team-alpha"})
+	if mockService.resolveIssuesByScopeName != "frontend-build" {
+		t.Errorf("Expected the issues of frontend-build to be resolved, got %q", mockService.resolveIssuesByScopeName)
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_Fingerprint(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+	post := func(fingerprint string) *net_httptest.ResponseRecorder {
+		reqBody, _ := json.Marshal(PipelineFailureRequest{
+			PipelineName:  "frontend-tests-x8f2k",
+			Namespace:     "team-alpha",
+			FailureReason: "TestLogin is flaky",
+			Fingerprint:   fingerprint,
+		})
+		req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := post("flaky/TestLogin"); w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if fingerprint := mockService.createOrUpdateIssueRequest.Fingerprint; fingerprint != "flaky/TestLogin" {
+		t.Errorf("Expected the fingerprint of the payload, got %q", fingerprint)
+	}
+
+	w := post(strings.Repeat("x", 256))
+	if w.Code != net_http.StatusBadRequest || !strings.Contains(w.Body.String(), "fingerprint must be at most 255") {
+		t.Errorf("Expected a fingerprint too long to be refused, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_GitMetadata(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+	reqBody, _ := json.Marshal(PipelineFailureRequest{
+		PipelineName:   "frontend-build-x8f2k",
+		Namespace:      "team-alpha",
+		FailureReason:  "Docker build failed",
+		RepoURL:        "https://github.com/org/frontend",
+		Revision:       "4f3a9c1",
+		PullRequest:    "42",
+		CommitURL:      "https://github.com/org/frontend/commit/4f3a9c1",
+		PullRequestURL: "https://github.com/org/frontend/pull/42",
+	})
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	issue := mockService.createOrUpdateIssueRequest
+	expected := []dto.CreateLinkRequest{
+		{Title: "Commit", URL: "https://github.com/org/frontend/commit/4f3a9c1"},
+		{Title: "Pull Request", URL: "https://github.com/org/frontend/pull/42"},
+	}
+	if !reflect.DeepEqual(issue.Links, expected) {
+		t.Errorf("Expected links %v, got %v", expected, issue.Links)
+	}
+	if issue.LinkVariables["revision"] != "4f3a9c1" || issue.LinkVariables["pullRequest"] != "42" {
+		t.Errorf("Expected the git metadata in the link variables, got %v", issue.LinkVariables)
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_Artifacts(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+	reqBody, _ := json.Marshal(PipelineFailureRequest{
+		PipelineName:  "frontend-build-x8f2k",
+		Namespace:     "team-alpha",
+		FailureReason: "clair-scan failed",
+		ImageURL:      "quay.io/org/frontend:4f3a9c1",
+		ImageDigest:   "sha256:abc123",
+		SBOMURL:       "quay.io/org/frontend@sha256:def456",
+		Artifacts: map[string]string{
+			"SOURCE_ARTIFACT":  "oci:quay.io/org/frontend@sha256:0a1b",
+			"CACHI2_ARTIFACT":  "oci:quay.io/org/frontend@sha256:2c3d",
+			"unrelated_result": "value",
+		},
+	})
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	issue := mockService.createOrUpdateIssueRequest
+	expected := "Image: quay.io/org/frontend:4f3a9c1@sha256:abc123\n" +
+		"SBOM: quay.io/org/frontend@sha256:def456\n" +
+		"Artifacts:\n" +
+		"  - CACHI2_ARTIFACT: oci:quay.io/org/frontend@sha256:2c3d\n" +
+		"  - SOURCE_ARTIFACT: oci:quay.io/org/frontend@sha256:0a1b\n" +
+		"  - unrelated_result: value\n" +
+		"\n" +
+		"clair-scan failed"
+	if issue.Details != expected {
+		t.Errorf("Expected details %q, got %q", expected, issue.Details)
+	}
+	if issue.LinkVariables["imageUrl"] != "quay.io/org/frontend:4f3a9c1" || issue.LinkVariables["imageDigest"] != "sha256:abc123" {
+		t.Errorf("Expected the image in the link variables, got %v", issue.LinkVariables)
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_NoArtifacts(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+	reqBody, _ := json.Marshal(PipelineFailureRequest{
+		PipelineName:  "frontend-build-x8f2k",
+		Namespace:     "team-alpha",
+		FailureReason: "Docker build failed",
+	})
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if details := mockService.createOrUpdateIssueRequest.Details; details != "Docker build failed" {
+		t.Errorf("Expected the failure reason as details, got %q", details)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		expected  string
+	}{
+		{name: "short enough", input: "build failed", maxLength: 12, expected: "build failed"},
+		{name: "too long", input: "the build of the component failed", maxLength: 20, expected: "the bui" + truncationMarker},
+		{name: "multi-byte characters", input: "ビルドが失敗しました。ログを確認してください", maxLength: 15, expected: "ビル" + truncationMarker},
+		{name: "limit shorter than the marker", input: "build failed", maxLength: 5, expected: "build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.input, tt.maxLength); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestWebhookHandler_PipelineSuccess(t *testing.T) {
 	// What gets sent to the webhook endpoint
 	pipelineSuccessRequest := PipelineSuccessRequest{
@@ -182,4 +456,388 @@ func TestWebhookHandler_PipelineSuccess(t *testing.T) {
 	if response["message"] != expectedMessage {
 		t.Errorf("expected response with message '%s', got '%s'", expectedMessage, response["message"])
 	}
+
+	// Without a reason, the resolution explains the pipeline succeeded
+	expectedResolution := dto.Resolution{Reason: "Pipeline pipeline-xyz succeeded", ResolvedBy: "pipeline-success-webhook"}
+	if mockService.resolveIssuesByScopeResolution != expectedResolution {
+		t.Errorf("expected the resolution %+v, got %+v", expectedResolution, mockService.resolveIssuesByScopeResolution)
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_RecordsEvent(t *testing.T) {
+	handler := setupTestWebhookHandler(&MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}})
+	events := &MockWebhookEventService{}
+	handler.eventService = events
+	router := setupTestWebhookRouter(handler)
+
+	body := `{"pipelineName": "pipeline-xyz", "namespace": "team-failed-pr", "failureReason": "OOMKilled"}`
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+	if len(events.events) != 1 {
+		t.Fatalf("Expected the webhook to be recorded, got %+v", events.events)
+	}
+	event := events.events[0]
+	if event.Source != "pipeline-failure" || event.Namespace != "team-failed-pr" || string(event.Payload) != body ||
+		event.IssueID == nil || *event.IssueID != "issue-1" || event.SignatureStatus != models.WebhookSignatureUnchecked {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	// Rejected webhooks aren't recorded
+	req, _ = net_http.NewRequest("POST", "/webhooks/pipeline-failure", strings.NewReader(`{"pipelineName": "pipeline-xyz"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest || len(events.events) != 1 {
+		t.Errorf("Expected the webhook to be rejected without being recorded, got %d and %d events", w.Code, len(events.events))
+	}
+}
+
+func TestWebhookHandler_Signature(t *testing.T) {
+	body := `{"pipelineName": "pipeline-xyz", "namespace": "team-failed-pr"}`
+	mac := hmac.New(sha256.New, []byte("webhook-secret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	testCases := []struct {
+		name           string
+		signature      string
+		expectedStatus int
+	}{
+		{name: "valid signature", signature: signature, expectedStatus: net_http.StatusOK},
+		{name: "missing signature", signature: "", expectedStatus: net_http.StatusUnauthorized},
+		{name: "signature of another payload", signature: "sha256=" + strings.Repeat("0", 64), expectedStatus: net_http.StatusUnauthorized},
+		{name: "invalid signature", signature: "sha1=abc", expectedStatus: net_http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := setupTestWebhookHandler(&MockIssueService{resolveIssuesByScopeResult: 1})
+			handler.secret = "webhook-secret"
+			events := &MockWebhookEventService{}
+			handler.eventService = events
+			router := setupTestWebhookRouter(handler)
+
+			req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-success", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.signature != "" {
+				req.Header.Set("X-Kite-Signature-256", tc.signature)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus == net_http.StatusOK {
+				if len(events.events) != 1 || events.events[0].SignatureStatus != models.WebhookSignatureValid {
+					t.Errorf("Expected the webhook to be recorded with a valid signature, got %+v", events.events)
+				}
+			} else if len(events.events) != 0 {
+				t.Errorf("Expected no events, got %+v", events.events)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_InvalidSeverity(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+	body := `{"pipelineName": "pipeline-xyz", "namespace": "team-failed-pr", "failureReason": "OOMKilled", "severity": "urgent"}`
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	if mockService.createOrUpdateIssueRequest.Title != "" {
+		t.Errorf("Expected no issue to be created, got %+v", mockService.createOrUpdateIssueRequest)
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_ValidationErrors(t *testing.T) {
+	router := setupTestWebhookRouter(setupTestWebhookHandler(&MockIssueService{}))
+
+	body := `{"namespace": "team-failed-pr", "severity": "urgent", "detectedAt": "2999-01-01T00:00:00Z"}`
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+	var response struct {
+		Details []dto.FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	var fields []string
+	for _, fieldErr := range response.Details {
+		fields = append(fields, fieldErr.Field+":"+fieldErr.Constraint)
+	}
+	expected := "pipelineName:required,failureReason:required,detectedAt:past,severity:severity"
+	if strings.Join(fields, ",") != expected {
+		t.Errorf("Expected the failed fields %s, got %s", expected, strings.Join(fields, ","))
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_SeverityAlias(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-1"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+	// The severity of older operators
+	body := `{"pipelineName": "pipeline-xyz", "namespace": "team-failed-pr", "failureReason": "OOMKilled", "severity": "medium"}`
+	req, _ := net_http.NewRequest("POST", "/webhooks/pipeline-failure", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+	if mockService.createOrUpdateIssueRequest.Severity != models.SeverityMinor {
+		t.Errorf("Expected the minor severity, got %q", mockService.createOrUpdateIssueRequest.Severity)
+	}
+}
+
+func TestWebhookHandler_PipelineBatch(t *testing.T) {
+	mockService := &MockIssueService{
+		processWebhookBatchResult: []dto.WebhookBatchItemResult{
+			{Issue: &models.Issue{ID: "issue-1"}},
+			{Resolved: 2},
+		},
+	}
+	handler := setupTestWebhookHandler(mockService)
+	events := &MockWebhookEventService{}
+	handler.eventService = events
+	router := setupTestWebhookRouter(handler)
+
+	failure := `{"pipelineName": "frontend-build", "namespace": "team-alpha", "failureReason": "OOMKilled", "severity": "high"}`
+	success := `{"pipelineName": "backend-build", "namespace": "team-alpha"}`
+	body := `[{"type": "pipeline-failure", "payload": ` + failure + `}, {"type": "pipeline-success", "payload": ` + success + `}]`
+	req, _ := net_http.NewRequest("POST", "/webhooks/batch?namespace=team-alpha", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	items := mockService.processWebhookBatchItems
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %+v", items)
+	}
+	if items[0].Issue == nil || items[0].Issue.Title != "Pipeline run failed: frontend-build" || items[0].Issue.Severity != models.SeverityMajor {
+		t.Errorf("Expected the failure to report an issue, got %+v", items[0])
+	}
+	if items[1].ResolveScope == nil || items[1].ResolveScope.ResourceName != "backend-build" || items[1].Resolution.ResolvedBy != "pipeline-success-webhook" {
+		t.Errorf("Expected the success to resolve the issues of the pipeline, got %+v", items[1])
+	}
+
+	var response struct {
+		Results []webhookBatchItemResult `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Results) != 2 || response.Results[0].IssueID != "issue-1" || response.Results[1].Resolved == nil || *response.Results[1].Resolved != 2 {
+		t.Errorf("Unexpected results %+v", response.Results)
+	}
+
+	// Each webhook is recorded on its own, to be replayed on its own
+	if len(events.events) != 2 || events.events[0].Source != "pipeline-failure" || string(events.events[0].Payload) != failure ||
+		events.events[1].Source != "pipeline-success" || string(events.events[1].Payload) != success {
+		t.Errorf("Unexpected events %+v", events.events)
+	}
+}
+
+func TestWebhookHandler_DryRun(t *testing.T) {
+	mockService := &MockIssueService{
+		dryRunIssueResult: &dto.DryRunResult{DryRun: true, Action: dto.DryRunCreate, Issue: &models.Issue{ID: "rolled-back"}},
+		dryRunWebhookBatchResult: []dto.WebhookBatchItemResult{
+			{Issue: &models.Issue{ID: "rolled-back"}, Action: dto.DryRunCreate},
+			{Issue: &models.Issue{ID: "issue-1"}, Action: dto.DryRunReopen},
+			{Resolved: 2},
+		},
+	}
+	handler := setupTestWebhookHandler(mockService)
+	events := &MockWebhookEventService{}
+	handler.eventService = events
+	router := setupTestWebhookRouter(handler)
+
+	send := func(path, body string) *net_httptest.ResponseRecorder {
+		req, _ := net_http.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	failure := `{"pipelineName": "frontend-build", "namespace": "team-alpha", "failureReason": "OOMKilled"}`
+	w := send("/webhooks/pipeline-failure?dryRun=true", failure)
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if mockService.dryRunIssueSource != models.IssueSourceWebhook {
+		t.Errorf("Expected a dry run of a webhook issue, got %q", mockService.dryRunIssueSource)
+	}
+	if mockService.createOrUpdateIssueRequest.Title != "" {
+		t.Error("Expected the dry run not to save the issue")
+	}
+
+	success := `{"pipelineName": "backend-build", "namespace": "team-alpha"}`
+	w = send("/webhooks/pipeline-success?dryRun=true", success)
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	items := mockService.processWebhookBatchItems
+	if len(items) != 1 || items[0].ResolveScope == nil || items[0].ResolveScope.ResourceName != "backend-build" {
+		t.Errorf("Expected a dry run resolving the issues of the pipeline, got %+v", items)
+	}
+
+	body := `[{"type": "pipeline-failure", "payload": ` + failure + `}, {"type": "pipeline-failure", "payload": ` + failure + `}, {"type": "pipeline-success", "payload": ` + success + `}]`
+	w = send("/webhooks/batch?namespace=team-alpha&dryRun=true", body)
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		DryRun  bool                     `json:"dryRun"`
+		Results []webhookBatchItemResult `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	results := response.Results
+	if !response.DryRun || len(results) != 3 ||
+		results[0].Action != dto.DryRunCreate || results[0].IssueID != "" ||
+		results[1].Action != dto.DryRunReopen || results[1].IssueID != "issue-1" ||
+		results[2].Resolved == nil || *results[2].Resolved != 2 {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	// Dry runs change nothing, they aren't recorded
+	if len(events.events) != 0 {
+		t.Errorf("Expected no webhook events, got %+v", events.events)
+	}
+
+	w = send("/webhooks/pipeline-success?dryRun=maybe", success)
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid dryRun, got %d", w.Code)
+	}
+}
+
+func TestWebhookHandler_PipelineBatch_Invalid(t *testing.T) {
+	failure := `{"type": "pipeline-failure", "payload": {"pipelineName": "frontend-build", "namespace": "team-alpha", "failureReason": "OOMKilled"}}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedError  string
+		expectedStatus []string
+		expectedFields []string
+	}{
+		{name: "not an array", body: `{"type": "pipeline-failure"}`, expectedError: "Invalid request body"},
+		{name: "empty batch", body: `[]`, expectedError: "Empty batch"},
+		{name: "too large", body: "[" + strings.Repeat(failure+",", 3) + failure + "]", expectedError: "Batch too large"},
+		{
+			name:           "invalid webhooks",
+			body:           "[" + failure + `, {"type": "pipeline-success", "payload": {"namespace": "team-alpha"}}, {"type": "build-failure", "payload": {}}]`,
+			expectedError:  "Validation failed",
+			expectedStatus: []string{"skipped", "invalid", "invalid"},
+			expectedFields: []string{"payload.pipelineName", "type"},
+		},
+		{
+			name:           "payload not an object",
+			body:           `[{"type": "pipeline-success", "payload": "backend-build"}]`,
+			expectedError:  "Validation failed",
+			expectedStatus: []string{"invalid"},
+			expectedFields: []string{"payload"},
+		},
+		{
+			name:           "other namespace",
+			body:           `[{"type": "pipeline-success", "payload": {"pipelineName": "backend-build", "namespace": "team-beta"}}]`,
+			expectedError:  "Validation failed",
+			expectedStatus: []string{"invalid"},
+			expectedFields: []string{"payload.namespace"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockIssueService{}
+			handler := setupTestWebhookHandler(mockService)
+			handler.limits.MaxWebhookBatchSize = 3
+			events := &MockWebhookEventService{}
+			handler.eventService = events
+			router := setupTestWebhookRouter(handler)
+
+			req, _ := net_http.NewRequest("POST", "/webhooks/batch?namespace=team-alpha", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusBadRequest {
+				t.Fatalf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if mockService.processWebhookBatchItems != nil || len(events.events) != 0 {
+				t.Errorf("Expected no webhook to be processed, got %+v", mockService.processWebhookBatchItems)
+			}
+
+			var response struct {
+				Error   string                   `json:"error"`
+				Results []webhookBatchItemResult `json:"results"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error != tc.expectedError {
+				t.Errorf("Expected the error %q, got %q", tc.expectedError, response.Error)
+			}
+			var statuses, fields []string
+			for _, result := range response.Results {
+				statuses = append(statuses, result.Status)
+				for _, fieldErr := range result.Details {
+					fields = append(fields, fieldErr.Field)
+				}
+			}
+			if strings.Join(statuses, ",") != strings.Join(tc.expectedStatus, ",") {
+				t.Errorf("Expected the statuses %v, got %v", tc.expectedStatus, statuses)
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.expectedFields, ",") {
+				t.Errorf("Expected the failed fields %v, got %v", tc.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_PipelineBatch_Failure(t *testing.T) {
+	handler := setupTestWebhookHandler(&MockIssueService{processWebhookBatchError: errors.New("connection refused")})
+	events := &MockWebhookEventService{}
+	handler.eventService = events
+	router := setupTestWebhookRouter(handler)
+
+	body := `[{"type": "pipeline-success", "payload": {"pipelineName": "backend-build", "namespace": "team-alpha"}}]`
+	req, _ := net_http.NewRequest("POST", "/webhooks/batch?namespace=team-alpha", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected the webhooks of failed batches not to be recorded, got %+v", events.events)
+	}
 }
@@ -0,0 +1,176 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// TicketRequest holds the fields of a Jira ticket to create
+type TicketRequest struct {
+	Project     string
+	IssueType   string
+	Summary     string
+	Description string
+	Labels      []string
+}
+
+// Ticket is a Jira ticket
+type Ticket struct {
+	Key string
+	URL string
+}
+
+// Client calls the Jira REST API (version 2, supported by Jira Cloud and Data Center)
+type Client struct {
+	baseURL    string
+	user       string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Jira client
+//
+// Parameters:
+//   - cfg: The Jira configuration, providing the URL and credentials
+//
+// Returns:
+//   - *Client
+func NewClient(cfg config.JiraConfig) *Client {
+	return &Client{
+		baseURL:    cfg.URL,
+		user:       cfg.User,
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreateTicket creates a Jira ticket.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - req: The fields of the ticket
+//
+// Returns:
+//   - *Ticket: The ticket created
+//   - error: Jira error or nil
+func (c *Client) CreateTicket(ctx context.Context, req TicketRequest) (*Ticket, error) {
+	body := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": req.Project},
+			"issuetype":   map[string]string{"name": req.IssueType},
+			"summary":     req.Summary,
+			"description": req.Description,
+			"labels":      req.Labels,
+		},
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create jira ticket: %w", err)
+	}
+
+	return &Ticket{Key: created.Key, URL: c.browseURL(created.Key)}, nil
+}
+
+// IsResolved checks whether a Jira ticket is resolved, its status being in
+// the "done" category whatever the workflow of the project.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - key: The key of the ticket, e.g. KFLUXBUGS-1234
+//
+// Returns:
+//   - bool: Whether the ticket is resolved
+//   - error: Jira error or nil
+func (c *Client) IsResolved(ctx context.Context, key string) (bool, error) {
+	var ticket struct {
+		Fields struct {
+			Status struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "?fields=status"
+	if err := c.do(ctx, http.MethodGet, path, nil, &ticket); err != nil {
+		return false, fmt.Errorf("failed to get jira ticket %s: %w", key, err)
+	}
+
+	return ticket.Fields.Status.StatusCategory.Key == "done", nil
+}
+
+// Ping checks Jira is reachable and the credentials are valid, getting the
+// user they authenticate.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - error: Jira error or nil
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/myself", nil, nil); err != nil {
+		return fmt.Errorf("failed to authenticate to jira: %w", err)
+	}
+	return nil
+}
+
+// browseURL returns the URL of the page of a ticket
+func (c *Client) browseURL(key string) string {
+	return c.baseURL + "/browse/" + key
+}
+
+// do sends a request to the Jira API, encoding body and decoding the response into result
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("jira responded with status %d: %s", resp.StatusCode, message)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
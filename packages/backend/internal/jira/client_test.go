@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+func TestClient_CreateTicket(t *testing.T) {
+	var fields map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Expected bearer authentication, got %q", auth)
+		}
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		fields = body.Fields
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "10001", "key": "KFLUX-1", "self": "https://jira.test/rest/api/2/issue/10001"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.JiraConfig{URL: server.URL, Token: "secret"})
+	ticket, err := client.CreateTicket(context.Background(), TicketRequest{
+		Project:   "KFLUX",
+		IssueType: "Bug",
+		Summary:   "Pipeline run failed: build",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if ticket.Key != "KFLUX-1" || ticket.URL != server.URL+"/browse/KFLUX-1" {
+		t.Errorf("Unexpected ticket %+v", ticket)
+	}
+	if project, _ := fields["project"].(map[string]any); project["key"] != "KFLUX" {
+		t.Errorf("Expected ticket in project KFLUX, got %v", fields["project"])
+	}
+	if fields["summary"] != "Pipeline run failed: build" {
+		t.Errorf("Unexpected summary %v", fields["summary"])
+	}
+}
+
+func TestClient_CreateTicket_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors": {"project": "project is required"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(config.JiraConfig{URL: server.URL, Token: "secret"})
+	if _, err := client.CreateTicket(context.Background(), TicketRequest{}); err == nil {
+		t.Error("Expected an error, got nil")
+	}
+}
+
+func TestClient_IsResolved(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		expected bool
+	}{
+		{name: "done", category: "done", expected: true},
+		{name: "in progress", category: "indeterminate", expected: false},
+		{name: "to do", category: "new", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/2/issue/KFLUX-1" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				if user, token, ok := r.BasicAuth(); !ok || user != "kite" || token != "secret" {
+					t.Errorf("Expected basic authentication, got %q", r.Header.Get("Authorization"))
+				}
+				_, _ = w.Write([]byte(`{"key": "KFLUX-1", "fields": {"status": {"name": "Whatever", "statusCategory": {"key": "` + tt.category + `"}}}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(config.JiraConfig{URL: server.URL, User: "kite", Token: "secret"})
+			resolved, err := client.IsResolved(context.Background(), "KFLUX-1")
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if resolved != tt.expected {
+				t.Errorf("Expected resolved %v, got %v", tt.expected, resolved)
+			}
+		})
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rest/api/2/myself" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "kite"}`))
+	}))
+	defer server.Close()
+
+	if err := NewClient(config.JiraConfig{URL: server.URL, Token: "secret"}).Ping(context.Background()); err != nil {
+		t.Errorf("Unexpected error, got %v", err)
+	}
+	if err := NewClient(config.JiraConfig{URL: server.URL, Token: "invalid"}).Ping(context.Background()); err == nil {
+		t.Error("Expected an error for invalid credentials")
+	}
+}
@@ -0,0 +1,196 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ExternalRefSystem is the system of the external references to Jira tickets
+const ExternalRefSystem = "jira"
+
+// ticketLabel labels the tickets created for issues
+const ticketLabel = "kite"
+
+// pageSize is how many issues are loaded per query when syncing a namespace
+const pageSize = 100
+
+// Tracker creates Jira tickets and checks their resolution, see Client
+type Tracker interface {
+	CreateTicket(ctx context.Context, req TicketRequest) (*Ticket, error)
+	IsResolved(ctx context.Context, key string) (bool, error)
+}
+
+// Escalator escalates issues to Jira tickets and resolves issues whose
+// ticket was resolved.
+type Escalator struct {
+	issues  repository.IssueRepository
+	tracker Tracker
+	config  config.JiraConfig
+	logger  *logrus.Logger
+}
+
+// NewEscalator creates a new Escalator
+//
+// Parameters:
+//   - issues: The issue repository
+//   - tracker: Creates and checks Jira tickets
+//   - cfg: The Jira configuration, providing the projects and escalation thresholds
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - *Escalator
+func NewEscalator(issues repository.IssueRepository, tracker Tracker, cfg config.JiraConfig, logger *logrus.Logger) *Escalator {
+	return &Escalator{
+		issues:  issues,
+		tracker: tracker,
+		config:  cfg,
+		logger:  logger,
+	}
+}
+
+// Run syncs issues with Jira every sync interval, until stop is closed. The
+// sync in progress then is completed, unless ctx is cancelled. See workers.Worker.
+//
+// Parameters:
+//   - ctx: Context for cancellation of the syncs
+//   - stop: Closed to stop syncing
+func (e *Escalator) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(e.config.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Sync(ctx); err != nil {
+			e.logger.WithError(err).Error("Failed to sync issues with jira")
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync goes through the active issues of the namespaces with a Jira project.
+// Issues referencing a ticket are resolved once it's resolved, other issues
+// get a ticket once they cross the severity and age thresholds. Syncing
+// carries on past the failures of single issues.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - error: The errors of the issues that failed to sync, or nil
+func (e *Escalator) Sync(ctx context.Context) error {
+	var errs []error
+	for namespace, project := range e.config.Projects {
+		issues, err := e.activeIssues(ctx, namespace)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, issue := range issues {
+			if ref := ticketRef(issue); ref != nil {
+				err = e.syncResolution(ctx, issue, ref.Key)
+			} else if e.shouldEscalate(issue) {
+				err = e.escalate(ctx, issue, project)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("issue %s: %w", issue.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// activeIssues loads all the open issues of a namespace, acknowledged or not
+func (e *Escalator) activeIssues(ctx context.Context, namespace string) ([]models.Issue, error) {
+	issues, err := repository.FindAllPages(ctx, e.issues, repository.IssueQueryFilters{
+		Namespace:  namespace,
+		Unresolved: true,
+	}, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active issues of namespace %s: %w", namespace, err)
+	}
+	return issues, nil
+}
+
+// shouldEscalate checks whether an issue crossed the severity and age thresholds
+func (e *Escalator) shouldEscalate(issue models.Issue) bool {
+	return issue.Severity.Rank() >= models.Severity(e.config.MinSeverity).Rank() &&
+		time.Since(issue.DetectedAt) >= e.config.MinAge
+}
+
+// escalate creates the ticket of an issue and references it from the issue
+func (e *Escalator) escalate(ctx context.Context, issue models.Issue, project string) error {
+	ticket, err := e.tracker.CreateTicket(ctx, TicketRequest{
+		Project:   project,
+		IssueType: e.config.IssueType,
+		Summary:   issue.Title,
+		Description: fmt.Sprintf("%s\n\nSeverity: %s\nResource: %s/%s in namespace %s\nDetected at: %s\nKITE issue: %s",
+			issue.Description, issue.Severity, issue.Scope.ResourceType, issue.Scope.ResourceName,
+			issue.Namespace, issue.DetectedAt.UTC().Format(time.RFC3339), issue.ID),
+		Labels: []string{ticketLabel},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The ticket exists, failing to reference it would create another one on the next sync
+	if _, err := e.issues.AddExternalRef(ctx, issue.ID, models.ExternalRef{
+		System: ExternalRefSystem,
+		Key:    ticket.Key,
+		URL:    ticket.URL,
+	}); err != nil {
+		return fmt.Errorf("failed to reference jira ticket %s: %w", ticket.Key, err)
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"issue_id": issue.ID,
+		"ticket":   ticket.Key,
+	}).Info("Escalated issue to jira")
+	return nil
+}
+
+// syncResolution resolves an issue once its ticket is resolved
+func (e *Escalator) syncResolution(ctx context.Context, issue models.Issue, key string) error {
+	resolved, err := e.tracker.IsResolved(ctx, key)
+	if err != nil || !resolved {
+		return err
+	}
+
+	if _, err := e.issues.Update(ctx, issue.ID, dto.UpdateIssueRequest{
+		State:            models.IssueStateResolved,
+		ResolutionReason: fmt.Sprintf("Jira ticket %s was resolved", key),
+		ResolvedBy:       ExternalRefSystem,
+	}); err != nil {
+		return fmt.Errorf("failed to resolve issue: %w", err)
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"issue_id": issue.ID,
+		"ticket":   key,
+	}).Info("Resolved issue along with its jira ticket")
+	return nil
+}
+
+// ticketRef returns the reference of an issue to its Jira ticket, nil if it has none
+func ticketRef(issue models.Issue) *models.ExternalRef {
+	for n := range issue.ExternalRefs {
+		if issue.ExternalRefs[n].System == ExternalRefSystem {
+			return &issue.ExternalRefs[n]
+		}
+	}
+	return nil
+}
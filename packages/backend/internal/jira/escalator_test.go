@@ -0,0 +1,154 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeTracker records the tickets created, resolving the keys in resolved
+type fakeTracker struct {
+	created   []TicketRequest
+	resolved  map[string]bool
+	createErr error
+}
+
+func (f *fakeTracker) CreateTicket(ctx context.Context, req TicketRequest) (*Ticket, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created = append(f.created, req)
+	key := fmt.Sprintf("%s-%d", req.Project, len(f.created))
+	return &Ticket{Key: key, URL: "https://jira.test/browse/" + key}, nil
+}
+
+func (f *fakeTracker) IsResolved(ctx context.Context, key string) (bool, error) {
+	return f.resolved[key], nil
+}
+
+// setupEscalator sets up an escalator for the team-a namespace, escalating
+// major issues older than an hour
+func setupEscalator(t *testing.T) (context.Context, repository.IssueRepository, *fakeTracker, *Escalator) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	repo := repository.NewIssueRepository(db, logger, 0)
+	tracker := &fakeTracker{resolved: map[string]bool{}}
+	cfg := config.JiraConfig{
+		IssueType:    "Bug",
+		Projects:     map[string]string{"team-a": "KFLUXA"},
+		MinSeverity:  string(models.SeverityMajor),
+		MinAge:       time.Hour,
+		SyncInterval: time.Minute,
+	}
+	return context.Background(), repo, tracker, NewEscalator(repo, tracker, cfg, logger)
+}
+
+// createIssue creates an active issue detected age ago
+func createIssue(t *testing.T, ctx context.Context, repo repository.IssueRepository, name, namespace string, severity models.Severity, age time.Duration) *models.Issue {
+	issue, err := repo.Create(ctx, dto.CreateIssueRequest{
+		Title:       "Build failed: " + name,
+		Description: "The build of " + name + " failed",
+		Severity:    severity,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   namespace,
+		DetectedAt:  time.Now().Add(-age),
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      name,
+			ResourceNamespace: namespace,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	return issue
+}
+
+func TestEscalator_Sync_Escalates(t *testing.T) {
+	ctx, repo, tracker, escalator := setupEscalator(t)
+
+	escalated := createIssue(t, ctx, repo, "api", "team-a", models.SeverityCritical, 2*time.Hour)
+	createIssue(t, ctx, repo, "ui", "team-a", models.SeverityMinor, 2*time.Hour)     // Not severe enough
+	createIssue(t, ctx, repo, "db", "team-a", models.SeverityMajor, time.Minute)     // Too recent
+	createIssue(t, ctx, repo, "api", "team-b", models.SeverityCritical, 2*time.Hour) // Namespace without a project
+
+	if err := escalator.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if len(tracker.created) != 1 {
+		t.Fatalf("Expected 1 ticket, got %d", len(tracker.created))
+	}
+	if ticket := tracker.created[0]; ticket.Project != "KFLUXA" || ticket.Summary != escalated.Title {
+		t.Errorf("Unexpected ticket %+v", ticket)
+	}
+
+	issue, err := repo.FindByID(ctx, escalated.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if ref := ticketRef(*issue); ref == nil || ref.Key != "KFLUXA-1" {
+		t.Errorf("Expected the issue to reference KFLUXA-1, got %+v", issue.ExternalRefs)
+	}
+
+	// Escalated issues don't get another ticket
+	if err := escalator.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(tracker.created) != 1 {
+		t.Errorf("Expected no new ticket, got %d tickets", len(tracker.created))
+	}
+}
+
+func TestEscalator_Sync_ResolvesWithTicket(t *testing.T) {
+	ctx, repo, tracker, escalator := setupEscalator(t)
+
+	issue := createIssue(t, ctx, repo, "api", "team-a", models.SeverityCritical, 2*time.Hour)
+	if err := escalator.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	tracker.resolved["KFLUXA-1"] = true
+	if err := escalator.Sync(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	resolved, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.State != models.IssueStateResolved || resolved.ResolvedAt == nil {
+		t.Errorf("Expected the issue to be resolved, got state %s", resolved.State)
+	}
+	if resolved.ResolutionReason != "Jira ticket KFLUXA-1 was resolved" || resolved.ResolvedBy != ExternalRefSystem {
+		t.Errorf("Unexpected resolution, got reason %q by %q", resolved.ResolutionReason, resolved.ResolvedBy)
+	}
+}
+
+func TestEscalator_Sync_ContinuesPastFailures(t *testing.T) {
+	ctx, repo, tracker, escalator := setupEscalator(t)
+
+	createIssue(t, ctx, repo, "api", "team-a", models.SeverityCritical, 2*time.Hour)
+	createIssue(t, ctx, repo, "ui", "team-a", models.SeverityCritical, 2*time.Hour)
+
+	errJira := errors.New("jira unavailable")
+	tracker.createErr = errJira
+	err := escalator.Sync(ctx)
+	if !errors.Is(err, errJira) {
+		t.Fatalf("Expected the jira error, got %v", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("Expected an error per issue, got %v", err)
+	}
+}
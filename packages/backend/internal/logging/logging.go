@@ -0,0 +1,38 @@
+// Package logging threads the logger of a request through its context, so that
+// the handlers, services and repositories serving it log the fields
+// identifying the request, e.g. its ID and user, with every line.
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// entryKey is the key of the logger of a request in its context
+type entryKey struct{}
+
+// NewContext returns a copy of ctx carrying entry, the logger of the request
+// of ctx
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// FromContext returns the logger of the request of ctx, or logger outside of
+// requests, e.g. in background jobs started at startup
+func FromContext(ctx context.Context, logger *logrus.Logger) *logrus.Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+	return logrus.NewEntry(logger)
+}
+
+// WithFields returns a copy of ctx whose logger logs fields too, when ctx
+// carries a logger
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	entry, ok := ctx.Value(entryKey{}).(*logrus.Entry)
+	if !ok {
+		return ctx
+	}
+	return NewContext(ctx, entry.WithFields(fields))
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestFromContext(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+
+	// Outside of requests, the logger is used as is
+	FromContext(context.Background(), logger).Info("startup")
+	if strings.Contains(out.String(), "request_id") {
+		t.Errorf("Expected no request fields, got %s", out.String())
+	}
+	if ctx := WithFields(context.Background(), logrus.Fields{"user": "alice"}); ctx.Value(entryKey{}) != nil {
+		t.Error("Expected no logger to be added to contexts without one")
+	}
+
+	out.Reset()
+	ctx := NewContext(context.Background(), logger.WithField("request_id", "abc"))
+	ctx = WithFields(ctx, logrus.Fields{"user": "alice"})
+	FromContext(ctx, logrus.New()).WithField("issue_id", "123").Error("failed")
+	for _, field := range []string{"request_id=abc", "user=alice", "issue_id=123"} {
+		if !strings.Contains(out.String(), field) {
+			t.Errorf("Expected %s to be logged, got %s", field, out.String())
+		}
+	}
+}
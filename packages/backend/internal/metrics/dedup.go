@@ -0,0 +1,68 @@
+package metrics
+
+import "time"
+
+// DedupOutcome is what reporting an issue did, depending on whether an issue
+// of the same namespace, type and scope existed
+type DedupOutcome string
+
+const (
+	// DedupCreated reports created a new issue
+	DedupCreated DedupOutcome = "created"
+	// DedupMerged reports were merged into the existing issue, updating it
+	DedupMerged DedupOutcome = "merged"
+	// DedupReopened reports were merged into the existing issue, which was
+	// resolved, reopening it
+	DedupReopened DedupOutcome = "reopened"
+)
+
+// IssueReports counts the issues reported, created with POST /issues or by
+// webhooks, by issue type and DedupOutcome
+var IssueReports = NewCounter("kite_issue_reports_total",
+	"Issues reported, by issue type and deduplication outcome: created, merged into an existing issue, or reopened.",
+	"issue_type", "outcome")
+
+// DedupCounts counts the issue reports by DedupOutcome
+type DedupCounts struct {
+	Reports  uint64 `json:"reports"`
+	Created  uint64 `json:"created"`
+	Merged   uint64 `json:"merged"`
+	Reopened uint64 `json:"reopened"`
+	// MergeRate is the share of the reports merged into an existing issue,
+	// reopening it or not, 0 without reports
+	MergeRate float64 `json:"mergeRate"`
+}
+
+// add counts n reports with outcome
+func (c *DedupCounts) add(outcome DedupOutcome, n uint64) {
+	switch outcome {
+	case DedupCreated:
+		c.Created += n
+	case DedupMerged:
+		c.Merged += n
+	case DedupReopened:
+		c.Reopened += n
+	}
+	c.Reports += n
+	c.MergeRate = float64(c.Merged+c.Reopened) / float64(c.Reports)
+}
+
+// DedupStats summarizes how the issues reported since Since were deduplicated
+type DedupStats struct {
+	Since time.Time `json:"since"`
+	DedupCounts
+	ByIssueType map[string]DedupCounts `json:"byIssueType"`
+}
+
+// GetDedupStats summarizes IssueReports
+func GetDedupStats() DedupStats {
+	stats := DedupStats{Since: StartTime, ByIssueType: map[string]DedupCounts{}}
+	IssueReports.Series(func(values []string, count uint64) {
+		issueType, outcome := values[0], DedupOutcome(values[1])
+		stats.add(outcome, count)
+		counts := stats.ByIssueType[issueType]
+		counts.add(outcome, count)
+		stats.ByIssueType[issueType] = counts
+	})
+	return stats
+}
@@ -0,0 +1,161 @@
+// Package metrics counts what the API does, e.g. how reported issues are
+// deduplicated, and exposes the counts in the Prometheus text format.
+//
+// Counts are kept in memory since the server started, Prometheus keeping
+// their history across restarts.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// labelSeparator joins the label values of series in Counter.values, it can't
+// appear in valid UTF-8 label values
+const labelSeparator = "\xff"
+
+// StartTime is when the counts started
+var StartTime = time.Now()
+
+var (
+	registryMu sync.Mutex
+	// registry holds the counters written by WriteText, in registration order
+	registry []*Counter
+)
+
+// Counter counts events, by the values of its labels
+type Counter struct {
+	name   string
+	help   string
+	labels []string
+
+	mu sync.Mutex
+	// values are the counts by label values, joined with labelSeparator
+	values map[string]uint64
+}
+
+// NewCounter returns a new counter, exported by WriteText
+//
+// Parameters:
+//   - name: The name of the counter, e.g. kite_issue_reports_total
+//   - help: What the counter counts
+//   - labels: The names of the labels of the counter
+//
+// Returns:
+//   - *Counter
+func NewCounter(name, help string, labels ...string) *Counter {
+	counter := &Counter{name: name, help: help, labels: labels, values: map[string]uint64{}}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, counter)
+	return counter
+}
+
+// Inc counts an event with the label values given, in the order of the labels
+// of the counter
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add counts n events with the label values given, see Inc
+func (c *Counter) Add(n uint64, values ...string) {
+	if len(values) != len(c.labels) {
+		panic(fmt.Sprintf("metrics: counter %s has %d labels, got %d values", c.name, len(c.labels), len(values)))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[strings.Join(values, labelSeparator)] += n
+}
+
+// Value returns the count of the events with the label values given
+func (c *Counter) Value(values ...string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[strings.Join(values, labelSeparator)]
+}
+
+// Series calls fn with the label values and count of each series of the
+// counter, sorted by label values
+func (c *Counter) Series(fn func(values []string, count uint64)) {
+	c.mu.Lock()
+	counts := maps.Clone(c.values)
+	c.mu.Unlock()
+
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		var values []string
+		if len(c.labels) > 0 {
+			values = strings.Split(key, labelSeparator)
+		}
+		fn(values, counts[key])
+	}
+}
+
+// WriteText writes all the counters in the Prometheus text exposition format
+func WriteText(w io.Writer) error {
+	registryMu.Lock()
+	counters := slices.Clone(registry)
+	registryMu.Unlock()
+
+	var b strings.Builder
+	for _, counter := range counters {
+		fmt.Fprintf(&b, "# HELP %s %s\n", counter.name, escapeHelp(counter.help))
+		fmt.Fprintf(&b, "# TYPE %s counter\n", counter.name)
+		counter.Series(func(values []string, count uint64) {
+			b.WriteString(counter.name)
+			if len(values) > 0 {
+				pairs := make([]string, len(values))
+				for i, value := range values {
+					pairs[i] = fmt.Sprintf(`%s="%s"`, counter.labels[i], escapeLabel(value))
+				}
+				b.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			fmt.Fprintf(&b, " %d\n", count)
+		})
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeHelp escapes the backslashes and line feeds of help texts
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// escapeLabel escapes the backslashes, double quotes and line feeds of label values
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// Deferred holds the events counted in a database transaction until it
+// commits, so that transactions rolled back, e.g. dry runs, aren't counted
+type Deferred struct {
+	mu     sync.Mutex
+	events []deferredEvent
+}
+
+type deferredEvent struct {
+	counter *Counter
+	values  []string
+}
+
+// Inc counts an event of counter once the transaction commits, see Counter.Inc
+func (d *Deferred) Inc(counter *Counter, values ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, deferredEvent{counter: counter, values: values})
+}
+
+// Commit counts the events held
+func (d *Deferred) Commit() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, event := range d.events {
+		event.counter.Inc(event.values...)
+	}
+	d.events = nil
+}
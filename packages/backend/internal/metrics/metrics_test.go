@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	counter := NewCounter("kite_test_events_total", "Test events.\nBy kind.", "kind")
+	counter.Inc("b")
+	counter.Add(2, `a"quoted"`)
+
+	var b strings.Builder
+	if err := WriteText(&b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `# HELP kite_test_events_total Test events.\nBy kind.
+# TYPE kite_test_events_total counter
+kite_test_events_total{kind="a\"quoted\""} 2
+kite_test_events_total{kind="b"} 1
+`
+	if !strings.Contains(b.String(), expected) {
+		t.Errorf("Expected the counter to be written as\n%s\ngot\n%s", expected, b.String())
+	}
+}
+
+func TestCounter_PanicsOnLabelMismatch(t *testing.T) {
+	counter := NewCounter("kite_test_mismatch_total", "Test events.", "kind")
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic counting without the values of all labels")
+		}
+	}()
+	counter.Inc()
+}
+
+func TestDeferred(t *testing.T) {
+	counter := NewCounter("kite_test_deferred_total", "Test events.")
+	var deferred Deferred
+	deferred.Inc(counter)
+	if counter.Value() != 0 {
+		t.Errorf("Expected nothing to be counted before committing, got %d", counter.Value())
+	}
+	deferred.Commit()
+	deferred.Commit()
+	if counter.Value() != 1 {
+		t.Errorf("Expected 1 event once committed, got %d", counter.Value())
+	}
+}
+
+func TestGetDedupStats(t *testing.T) {
+	IssueReports.Add(3, "test", string(DedupCreated))
+	IssueReports.Add(2, "test", string(DedupMerged))
+	IssueReports.Inc("test", string(DedupReopened))
+
+	stats := GetDedupStats()
+	counts := stats.ByIssueType["test"]
+	if counts.Reports != 6 || counts.Created != 3 || counts.Merged != 2 || counts.Reopened != 1 || counts.MergeRate != 0.5 {
+		t.Errorf("Expected 6 reports with a merge rate of 0.5, got %+v", counts)
+	}
+	if stats.Reports < counts.Reports || stats.Since != StartTime {
+		t.Errorf("Expected the totals since the start, got %+v", stats)
+	}
+}
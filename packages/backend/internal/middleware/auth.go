@@ -72,24 +72,7 @@ func NewNamespaceChecker(logger *logrus.Logger) (*NamespaceChecker, error) {
 
 func (nc *NamespaceChecker) CheckNamespacessAccess() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get namespaces from params, body or query
-		namespace := c.Param("namespace")
-		if namespace == "" {
-			namespace = c.Query("namespace")
-		}
-		if namespace == "" {
-			// Try to get from request body
-			if c.Request.Method == "POST" || c.Request.Method == "PUT" {
-				if body, exists := c.Get("requestBody"); exists {
-					if bodyMap, ok := body.(map[string]interface{}); ok {
-						if ns, ok := bodyMap["namespace"].(string); ok {
-							namespace = ns
-						}
-					}
-				}
-			}
-		}
-
+		namespace := requestNamespace(c)
 		if namespace == "" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing namespace"})
 			c.Abort()
@@ -116,6 +99,28 @@ func (nc *NamespaceChecker) CheckNamespacessAccess() gin.HandlerFunc {
 	}
 }
 
+// requestNamespace returns the namespace of a request, from its path, else its
+// query, else the body of POST and PUT requests. It's empty when missing.
+func requestNamespace(c *gin.Context) string {
+	namespace := c.Param("namespace")
+	if namespace == "" {
+		namespace = c.Query("namespace")
+	}
+	if namespace == "" {
+		// Try to get from request body
+		if c.Request.Method == "POST" || c.Request.Method == "PUT" {
+			if body, exists := c.Get("requestBody"); exists {
+				if bodyMap, ok := body.(map[string]interface{}); ok {
+					if ns, ok := bodyMap["namespace"].(string); ok {
+						namespace = ns
+					}
+				}
+			}
+		}
+	}
+	return namespace
+}
+
 func (nc *NamespaceChecker) checkPodAccess(namespace string) error {
 	if nc.client == nil {
 		return nil // Skip check if client is not available
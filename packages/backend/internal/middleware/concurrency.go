@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// LimitConcurrency middleware, shedding load when too many requests are served
+// at once. Requests over the in-flight limit wait in a bounded queue for their
+// turn, so that a storm of webhooks queues up in front of the handlers rather
+// than contending for database connections. Requests finding the queue full
+// get a 429, and requests still queued after the queue timeout a 503, both
+// with a Retry-After header.
+//
+// Parameters:
+//   - cfg: The limits of requests in flight and queued
+//   - skipPaths: The path patterns of routes served regardless of the load, e.g. health checks
+//
+// Returns:
+//   - gin.HandlerFunc
+func LimitConcurrency(cfg config.ConcurrencyConfig, skipPaths ...string) gin.HandlerFunc {
+	if cfg.MaxInFlight <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	inFlight := make(chan struct{}, cfg.MaxInFlight)
+	queued := make(chan struct{}, cfg.MaxQueued)
+	// Clients retry once queued requests had their chance
+	retryAfter := strconv.Itoa(max(1, int(math.Ceil(cfg.QueueTimeout.Seconds()))))
+
+	return func(c *gin.Context) {
+		if slices.Contains(skipPaths, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			select {
+			case queued <- struct{}{}:
+			default:
+				c.Header("Retry-After", retryAfter)
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+				return
+			}
+
+			timer := time.NewTimer(cfg.QueueTimeout)
+			select {
+			case inFlight <- struct{}{}:
+				timer.Stop()
+				<-queued
+			case <-timer.C:
+				<-queued
+				c.Header("Retry-After", retryAfter)
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Server overloaded"})
+				return
+			case <-c.Request.Context().Done():
+				// The client went away while queued
+				timer.Stop()
+				<-queued
+				c.AbortWithStatus(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		defer func() { <-inFlight }()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// setupConcurrencyRouter creates a test router behind the concurrency limiter,
+// serving GET /slow until release is closed, and GET /health right away. The
+// returned channel receives a value when each slow request starts being served.
+func setupConcurrencyRouter(cfg config.ConcurrencyConfig, release chan struct{}) (*gin.Engine, chan struct{}) {
+	gin.SetMode(gin.TestMode)
+	started := make(chan struct{}, 10)
+	router := gin.New()
+	router.Use(LimitConcurrency(cfg, "/health"))
+	router.GET("/slow", func(c *gin.Context) {
+		started <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router, started
+}
+
+func serveAsync(router *gin.Engine, path string) chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		done <- w
+	}()
+	return done
+}
+
+func TestLimitConcurrency_QueueFull(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	router, started := setupConcurrencyRouter(config.ConcurrencyConfig{MaxInFlight: 1, QueueTimeout: 2 * time.Second}, release)
+
+	serveAsync(router, "/slow")
+	<-started
+
+	w := <-serveAsync(router, "/slow")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "2" {
+		t.Errorf("Expected to retry after the queue timeout, got %q", w.Header().Get("Retry-After"))
+	}
+
+	// Health checks are served regardless of the load
+	if w := <-serveAsync(router, "/health"); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+}
+
+func TestLimitConcurrency_QueueTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	router, started := setupConcurrencyRouter(config.ConcurrencyConfig{MaxInFlight: 1, MaxQueued: 1, QueueTimeout: 10 * time.Millisecond}, release)
+
+	serveAsync(router, "/slow")
+	<-started
+
+	w := <-serveAsync(router, "/slow")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" {
+		t.Errorf("Expected to retry after a second at least, got %q", w.Header().Get("Retry-After"))
+	}
+}
+
+func TestLimitConcurrency_Queued(t *testing.T) {
+	release := make(chan struct{})
+	router, started := setupConcurrencyRouter(config.ConcurrencyConfig{MaxInFlight: 1, MaxQueued: 1, QueueTimeout: time.Minute}, release)
+
+	first := serveAsync(router, "/slow")
+	<-started
+	second := serveAsync(router, "/slow")
+	close(release)
+
+	for _, done := range []chan *httptest.ResponseRecorder{first, second} {
+		if w := <-done; w.Code != http.StatusOK {
+			t.Errorf("Expected queued requests to be served, got %d", w.Code)
+		}
+	}
+}
+
+func TestLimitConcurrency_Disabled(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	router, _ := setupConcurrencyRouter(config.ConcurrencyConfig{}, release)
+
+	if w := <-serveAsync(router, "/slow"); w.Code != http.StatusOK {
+		t.Errorf("Expected requests without limit, got %d", w.Code)
+	}
+}
@@ -2,21 +2,76 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
 )
 
-// CORS middlware
-func CORS() gin.HandlerFunc {
+const (
+	corsAllowedMethods = "GET,POST,PUT,DELETE,OPTIONS"
+	corsAllowedHeaders = "Origin,Content-Type,Accept,Authorization,X-Request-ID"
+)
+
+// CORS middleware, allowing the configured origins to call the API from
+// browsers. Requests from other origins get no CORS headers, and their
+// preflight requests are rejected.
+//
+// Parameters:
+//   - cfg: The security configuration, providing the allowed origins and whether credentials are allowed
+//
+// Returns:
+//   - gin.HandlerFunc
+//   - error: The allowed origins are invalid, see config.SecurityConfig.ValidateCORS
+func CORS(cfg config.SecurityConfig) (gin.HandlerFunc, error) {
+	if err := cfg.ValidateCORS(); err != nil {
+		return nil, err
+	}
+
+	anyOrigin := false
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			anyOrigin = true
+		}
+		origins[strings.ToLower(origin)] = true
+	}
+
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Conrol-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin,Content-Type,Accept,Authorization")
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			// Not a cross-origin request
+			c.Next()
+			return
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !anyOrigin && !origins[strings.ToLower(origin)] {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusOK)
+		if anyOrigin {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			// The response depends on the origin, caches must not share it across origins
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", corsAllowedMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowedHeaders)
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 		c.Next()
-	}
+	}, nil
 }
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// setupCORSRouter creates a test router serving GET /issues behind the CORS middleware
+func setupCORSRouter(t *testing.T, cfg config.SecurityConfig) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cors, err := CORS(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	router := gin.New()
+	router.Use(cors)
+	router.GET("/issues", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func serve(router *gin.Engine, method, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/issues", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	router := setupCORSRouter(t, config.SecurityConfig{AllowedOrigins: []string{"*"}})
+
+	w := serve(router, http.MethodGet, "https://kite.example.com")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("Expected any origin to be allowed, got %d %v", w.Code, w.Header())
+	}
+
+	w = serve(router, http.MethodOptions, "https://kite.example.com")
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Methods") != corsAllowedMethods {
+		t.Errorf("Expected the preflight request to succeed, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestCORS_AllowedOrigins(t *testing.T) {
+	router := setupCORSRouter(t, config.SecurityConfig{
+		AllowedOrigins:   []string{"https://kite.example.com"},
+		AllowCredentials: true,
+	})
+
+	w := serve(router, http.MethodGet, "https://kite.example.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://kite.example.com" {
+		t.Errorf("Expected the origin to be allowed, got %q", got)
+	}
+	if w.Header().Get("Access-Control-Allow-Credentials") != "true" || w.Header().Get("Vary") != "Origin" {
+		t.Errorf("Expected credentials to be allowed, varying by origin, got %v", w.Header())
+	}
+
+	// Other origins get no CORS headers, and their preflight requests are rejected
+	w = serve(router, http.MethodGet, "https://evil.example.com")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected no CORS headers, got %d %v", w.Code, w.Header())
+	}
+	if w = serve(router, http.MethodOptions, "https://evil.example.com"); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+
+	// Same-origin requests are left alone
+	if w = serve(router, http.MethodGet, ""); w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected no CORS headers, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestCORS_InvalidOrigins(t *testing.T) {
+	testCases := []config.SecurityConfig{
+		{AllowedOrigins: nil},
+		{AllowedOrigins: []string{"kite.example.com"}},
+		{AllowedOrigins: []string{"https://kite.example.com/dashboard"}},
+		{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+	}
+
+	for _, cfg := range testCases {
+		if _, err := CORS(cfg); err == nil {
+			t.Errorf("Expected an error for %+v, got nil", cfg)
+		}
+	}
+}
@@ -1,24 +1,65 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/logging"
 	"github.com/sirupsen/logrus"
 )
 
-// ErrorHandler middleware for handling panics and errors
-func ErrorHandler(logger *logrus.Logger) gin.HandlerFunc {
+// ErrorReporter reports the panics and server errors of requests, e.g. to Sentry
+type ErrorReporter interface {
+	// ReportPanic reports a panic recovered while handling a request
+	ReportPanic(c *gin.Context, recovered any)
+	// ReportError reports a request which got a 5xx response
+	ReportError(c *gin.Context, status int)
+}
+
+// ErrorHandler middleware for handling panics and errors. Panics are logged
+// along with their stack trace and request, with the logger of the request
+// when there's one, see Logger, and get a 500 response.
+//
+// Parameters:
+//   - logger: The logger of the panics
+//   - reporter: Reports the panics and 5xx responses, nil to report none
+//
+// Returns:
+//   - gin.HandlerFunc
+func ErrorHandler(logger *logrus.Logger, reporter ErrorReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
-			if err := recover(); err != nil {
-				logger.WithField("error", err).Error("Panic recovered")
-				c.JSON(http.StatusInternalServerError, gin.H{
+			if recovered := recover(); recovered != nil {
+				if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					// Aborts the response on purpose, see net/http
+					panic(recovered)
+				}
+
+				logging.FromContext(c.Request.Context(), logger).WithFields(logrus.Fields{
+					"error":      recovered,
+					"stack":      string(debug.Stack()),
+					"method":     c.Request.Method,
+					"path":       c.Request.URL.Path,
+					"route":      c.FullPath(),
+					"query":      c.Request.URL.RawQuery,
+					"ip":         c.ClientIP(),
+					"user_agent": c.Request.UserAgent(),
+				}).Error("Panic recovered")
+				if reporter != nil {
+					reporter.ReportPanic(c, recovered)
+				}
+
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 					"error": "Internal server error",
 				})
-				c.Abort()
 			}
 		}()
 		c.Next()
+
+		if status := c.Writer.Status(); status >= http.StatusInternalServerError && reporter != nil {
+			reporter.ReportError(c, status)
+		}
 	}
 }
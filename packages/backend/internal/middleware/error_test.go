@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+// recordingReporter records the panics and errors it's reported
+type recordingReporter struct {
+	panics   []any
+	statuses []int
+}
+
+func (r *recordingReporter) ReportPanic(c *gin.Context, recovered any) {
+	r.panics = append(r.panics, recovered)
+}
+
+func (r *recordingReporter) ReportError(c *gin.Context, status int) {
+	r.statuses = append(r.statuses, status)
+}
+
+// setupErrorRouter creates a test router serving a panicking, a failing and a succeeding route
+func setupErrorRouter(logger *logrus.Logger, reporter ErrorReporter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(logger, reporter))
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+	router.GET("/error", func(c *gin.Context) { c.Status(http.StatusBadGateway) })
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func get(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestErrorHandler_Panic(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	reporter := &recordingReporter{}
+	router := setupErrorRouter(logger, reporter)
+
+	w := get(router, "/panic?id=1")
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "Internal server error") {
+		t.Errorf("Expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.ErrorLevel {
+		t.Fatalf("Expected the panic to be logged, got %+v", entry)
+	}
+	if entry.Data["error"] != "boom" || entry.Data["route"] != "/panic" || entry.Data["query"] != "id=1" {
+		t.Errorf("Expected the panic to be logged with its request, got %+v", entry.Data)
+	}
+	if stack, _ := entry.Data["stack"].(string); !strings.Contains(stack, "setupErrorRouter") {
+		t.Errorf("Expected the stack trace of the panic, got %q", stack)
+	}
+
+	if len(reporter.panics) != 1 || reporter.panics[0] != "boom" {
+		t.Errorf("Expected the panic to be reported, got %v", reporter.panics)
+	}
+	if len(reporter.statuses) != 0 {
+		t.Errorf("Expected the panic not to be reported again as an error, got %v", reporter.statuses)
+	}
+}
+
+func TestErrorHandler_Errors(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	reporter := &recordingReporter{}
+	router := setupErrorRouter(logger, reporter)
+
+	get(router, "/error")
+	get(router, "/ok")
+	if len(reporter.panics) != 0 || len(reporter.statuses) != 1 || reporter.statuses[0] != http.StatusBadGateway {
+		t.Errorf("Expected only the 502 to be reported, got panics %v and statuses %v", reporter.panics, reporter.statuses)
+	}
+
+	// Without reporter
+	router = setupErrorRouter(logger, nil)
+	if w := get(router, "/panic"); w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
@@ -1,19 +1,43 @@
 package middleware
 
 import (
+	"cmp"
+	"regexp"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/konflux-ci/kite/internal/logging"
 	"github.com/sirupsen/logrus"
 )
 
-// Logger middleware for request logging
+// RequestIDHeader is the header identifying requests, set on every response.
+// IDs sent by clients or proxies are kept when they're valid, see validRequestID.
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID matches the request IDs kept from requests
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// Logger middleware for request logging. Requests get a logger in their
+// context logging their ID and namespace, along with their user once
+// identified, see logging.FromContext, and logging the request once served.
 func Logger(logger *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
 		method := c.Request.Method
 
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+		fields := logrus.Fields{"request_id": requestID}
+		if namespace := cmp.Or(c.Param("namespace"), c.Query("namespace")); namespace != "" {
+			fields["namespace"] = namespace
+		}
+		c.Request = c.Request.WithContext(logging.NewContext(c.Request.Context(), logger.WithFields(fields)))
+
 		// Process request
 		c.Next()
 
@@ -21,7 +45,7 @@ func Logger(logger *logrus.Logger) gin.HandlerFunc {
 		duration := time.Since(start)
 		statusCode := c.Writer.Status()
 
-		logEntry := logger.WithFields(logrus.Fields{
+		logEntry := logging.FromContext(c.Request.Context(), logger).WithFields(logrus.Fields{
 			"method":     method,
 			"path":       path,
 			"status":     statusCode,
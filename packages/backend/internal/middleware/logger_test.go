@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/sirupsen/logrus"
+)
+
+func TestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+
+	router := gin.New()
+	router.Use(Logger(logger))
+	router.GET("/issues", IdentifyUser("X-Forwarded-User"), func(c *gin.Context) {
+		// Handlers, services and repositories log with the logger of the request
+		logging.FromContext(c.Request.Context(), logrus.New()).Error("Failed to fetch issues")
+		c.Status(http.StatusInternalServerError)
+	})
+
+	testCases := []struct {
+		name       string
+		requestID  string
+		expectedID string
+	}{
+		{name: "request ID kept", requestID: "abc-123", expectedID: "abc-123"},
+		{name: "request ID generated"},
+		{name: "invalid request ID replaced", requestID: "abc 123\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/issues?namespace=team-alpha", nil)
+			req.Header.Set("X-Forwarded-User", "alice")
+			if tc.requestID != "" {
+				req.Header.Set(RequestIDHeader, tc.requestID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(RequestIDHeader)
+			if tc.expectedID != "" && requestID != tc.expectedID {
+				t.Fatalf("Expected request ID %q, got %q", tc.expectedID, requestID)
+			}
+			if tc.expectedID == "" && (requestID == "" || requestID == tc.requestID) {
+				t.Fatalf("Expected a new request ID, got %q", requestID)
+			}
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("Expected the error and the request to be logged, got %s", out.String())
+			}
+			for _, line := range lines {
+				for _, field := range []string{"request_id=" + requestID, "user=alice", "namespace=team-alpha"} {
+					if !strings.Contains(line, field) {
+						t.Errorf("Expected %s to be logged, got %s", field, line)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// publicKey marks the anonymous requests served by PublicReadOnly in their context
+const publicKey = "public"
+
+// PublicReadOnly middleware, serving the public namespaces to anonymous
+// requests, those without user in the header identifying it, e.g. for public
+// status pages. Anonymous requests get a 401 for other namespaces, and a 403
+// when they don't read, e.g. POST or DELETE requests. The links of their
+// responses matching redactedLinks are removed. Requests of users are served
+// as usual.
+//
+// Parameters:
+//   - header: The header identifying the user, see config.SecurityConfig
+//   - namespaces: The public namespaces, see config.PublicAccessConfig
+//   - redactedLinks: Matches the URLs of the links to redact, nil to redact none
+//
+// Returns:
+//   - gin.HandlerFunc
+func PublicReadOnly(header string, namespaces []string, redactedLinks *regexp.Regexp) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.TrimSpace(c.GetHeader(header)) != "" {
+			c.Next()
+			return
+		}
+		if namespace := requestNamespace(c); namespace == "" || !slices.Contains(namespaces, namespace) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing user"})
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Anonymous access is read-only"})
+			return
+		}
+		c.Set(publicKey, true)
+		if redactedLinks == nil {
+			c.Next()
+			return
+		}
+
+		// Responses are redacted as they are written, so they aren't compressed
+		c.Request.Header.Del("Accept-Encoding")
+		writer := &redactingWriter{ResponseWriter: c.Writer, redactedLinks: redactedLinks}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+		writer.finish()
+	}
+}
+
+// IsPublic returns whether a request is an anonymous request served by PublicReadOnly
+func IsPublic(c *gin.Context) bool {
+	return c.GetBool(publicKey)
+}
+
+// redactingWriter removes the links matching redactedLinks from the body of
+// a response as it is written. JSON documents are buffered to be redacted
+// whole, see finish. NDJSON ones are redacted line by line, each line being
+// written once complete, so that streams reach the client as they go
+// without being held in memory. Other bodies are written as is.
+type redactingWriter struct {
+	gin.ResponseWriter
+	redactedLinks *regexp.Regexp
+	// pending is the JSON document, or the incomplete line of NDJSON
+	pending bytes.Buffer
+}
+
+func (w *redactingWriter) Write(data []byte) (int, error) {
+	contentType := w.Header().Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-ndjson"):
+		w.pending.Write(data)
+		if err := w.writeLines(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	case strings.HasPrefix(contentType, gin.MIMEJSON):
+		return w.pending.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *redactingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Unwrap returns the writer of the response, see http.ResponseController
+func (w *redactingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writeLines writes the complete lines of NDJSON pending, redacted
+func (w *redactingWriter) writeLines() error {
+	end := bytes.LastIndexByte(w.pending.Bytes(), '\n')
+	if end < 0 {
+		return nil
+	}
+	var redacted []byte
+	for _, line := range bytes.SplitAfter(w.pending.Next(end+1), []byte("\n")) {
+		if trimmed := bytes.TrimSuffix(line, []byte("\n")); len(trimmed) > 0 {
+			redacted = append(redacted, redactJSON(trimmed, w.redactedLinks)...)
+		}
+		if len(line) > 0 {
+			redacted = append(redacted, '\n')
+		}
+	}
+	_, err := w.ResponseWriter.Write(redacted)
+	return err
+}
+
+// finish writes what is left of the body once the response is complete, the
+// JSON document or the last line of NDJSON without newline, redacted
+func (w *redactingWriter) finish() {
+	if w.pending.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(redactJSON(w.pending.Bytes(), w.redactedLinks))
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// redactJSON removes the links matching redactedLinks from a JSON document,
+// the objects of the "links" arrays whose "url" matches. Documents that can't
+// be parsed are returned as is.
+func redactJSON(document []byte, redactedLinks *regexp.Regexp) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return document
+	}
+	redacted, err := json.Marshal(redactLinks(value, redactedLinks))
+	if err != nil {
+		return document
+	}
+	return redacted
+}
+
+// redactLinks removes the links matching redactedLinks from a decoded JSON value
+func redactLinks(value any, redactedLinks *regexp.Regexp) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if links, ok := field.([]any); ok && key == "links" {
+				field = slices.DeleteFunc(links, func(link any) bool {
+					object, ok := link.(map[string]any)
+					if !ok {
+						return false
+					}
+					url, ok := object["url"].(string)
+					return ok && redactedLinks.MatchString(url)
+				})
+			}
+			value[key] = redactLinks(field, redactedLinks)
+		}
+	case []any:
+		for i, element := range value {
+			value[i] = redactLinks(element, redactedLinks)
+		}
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPublicRouter creates a test router serving issues with links behind the
+// PublicReadOnly and RequireNamespaceClaim middleware
+func setupPublicRouter(redactedLinks *regexp.Regexp) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	issue := gin.H{"id": "issue-1", "links": []gin.H{
+		{"title": "Logs", "url": "https://console.internal/logs/run-1"},
+		{"title": "Docs", "url": "https://docs.example.com/runbook"},
+	}}
+
+	router := gin.New()
+	router.Use(PublicReadOnly("X-Forwarded-User", []string{"status"}, redactedLinks))
+	router.Use(RequireNamespaceClaim("X-Forwarded-User", "X-Forwarded-Namespaces", nil))
+	router.GET("/issues", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": []gin.H{issue}, "total": 1})
+	})
+	router.GET("/issues/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		for range 2 {
+			line, _ := json.Marshal(issue)
+			_, _ = c.Writer.Write(append(line, '\n'))
+		}
+	})
+	router.POST("/issues", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	return router
+}
+
+func TestPublicReadOnly_Access(t *testing.T) {
+	router := setupPublicRouter(nil)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		user       string
+		wantStatus int
+	}{
+		{name: "anonymous read of public namespace", method: http.MethodGet, path: "/issues?namespace=status", wantStatus: http.StatusOK},
+		{name: "anonymous write of public namespace", method: http.MethodPost, path: "/issues?namespace=status", wantStatus: http.StatusForbidden},
+		{name: "anonymous read of other namespace", method: http.MethodGet, path: "/issues?namespace=team-a", wantStatus: http.StatusUnauthorized},
+		{name: "anonymous read without namespace", method: http.MethodGet, path: "/issues", wantStatus: http.StatusUnauthorized},
+		{name: "user write of granted namespace", method: http.MethodPost, path: "/issues?namespace=team-a", user: "alice", wantStatus: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.user != "" {
+				req.Header.Set("X-Forwarded-User", tt.user)
+				req.Header.Set("X-Forwarded-Namespaces", "team-a")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPublicReadOnly_RedactsLinks(t *testing.T) {
+	router := setupPublicRouter(regexp.MustCompile(`^https://console\.internal/`))
+
+	req := httptest.NewRequest(http.MethodGet, "/issues?namespace=status", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "console.internal") || !strings.Contains(w.Body.String(), "docs.example.com") {
+		t.Errorf("Expected the internal links only to be redacted, got %s", w.Body.String())
+	}
+	var response struct {
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Total != 1 {
+		t.Errorf("Expected the rest of the response to be kept, got %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/issues/stream?namespace=status", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if len(lines) != 2 || strings.Contains(w.Body.String(), "console.internal") {
+		t.Errorf("Expected both lines to be redacted, got %q", w.Body.String())
+	}
+
+	// Users see all the links
+	req = httptest.NewRequest(http.MethodGet, "/issues?namespace=status", nil)
+	req.Header.Set("X-Forwarded-User", "alice")
+	req.Header.Set("X-Forwarded-Namespaces", "status")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "console.internal") {
+		t.Errorf("Expected the links of users not to be redacted, got %s", w.Body.String())
+	}
+}
+
+func TestPublicReadOnly_RedactsStreamsAsWritten(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	line := `{"id":"issue-1","links":[{"url":"https://console.internal/logs/run-1"},{"url":"https://docs.example.com/runbook"}]}`
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	router.Use(PublicReadOnly("X-Forwarded-User", []string{"status"}, regexp.MustCompile(`^https://console\.internal/`)))
+	router.GET("/issues/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+
+		// Incomplete lines are held back until complete
+		_, _ = c.Writer.WriteString(line[:20])
+		c.Writer.Flush()
+		if w.Body.Len() != 0 {
+			t.Errorf("Expected the incomplete line to be held back, got %q", w.Body.String())
+		}
+		_, _ = c.Writer.WriteString(line[20:] + "\n\n")
+		c.Writer.Flush()
+		if body := w.Body.String(); !strings.HasSuffix(body, "}\n\n") || strings.Contains(body, "console.internal") {
+			t.Errorf("Expected the first line redacted before the stream ends, got %q", body)
+		}
+
+		// The last line doesn't need a newline
+		_, _ = c.Writer.WriteString(line)
+	})
+
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/issues/stream?namespace=status", nil))
+
+	lines := strings.Split(w.Body.String(), "\n")
+	if len(lines) != 3 || lines[1] != "" || lines[0] != lines[2] {
+		t.Fatalf("Expected 2 lines separated by an empty one, got %q", w.Body.String())
+	}
+	if strings.Contains(lines[2], "console.internal") || !strings.Contains(lines[2], "docs.example.com") {
+		t.Errorf("Expected the internal links only to be redacted, got %q", lines[2])
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startupRetryAfter is the Retry-After, in seconds, of the requests refused
+// while the server is starting
+const startupRetryAfter = "5"
+
+// RequireReady middleware, refusing requests with a 503 and a Retry-After
+// header until the server is ready, e.g. until its startup checks passed
+//
+// Parameters:
+//   - ready: Reports whether the server is ready
+//   - skipPaths: The path patterns of routes served regardless, e.g. health checks
+//
+// Returns:
+//   - gin.HandlerFunc
+func RequireReady(ready func() bool, skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ready() || slices.Contains(skipPaths, c.FullPath()) {
+			c.Next()
+			return
+		}
+		c.Header("Retry-After", startupRetryAfter)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Server starting"})
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var ready atomic.Bool
+	router := gin.New()
+	router.Use(RequireReady(ready.Load, "/health"))
+	router.GET("/issues", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	if w := serve("/issues"); w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected a 503 with a Retry-After header while starting, got %d", w.Code)
+	}
+	if w := serve("/health"); w.Code != http.StatusOK {
+		t.Errorf("Expected health checks to be served while starting, got %d", w.Code)
+	}
+
+	ready.Store(true)
+	if w := serve("/issues"); w.Code != http.StatusOK {
+		t.Errorf("Expected requests to be served once ready, got %d", w.Code)
+	}
+}
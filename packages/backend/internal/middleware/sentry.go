@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// SentryReporter reports the panics and server errors of requests to Sentry
+type SentryReporter struct{}
+
+// NewSentryReporter sets up the Sentry client reporting the errors of the server.
+//
+// Parameters:
+//   - cfg: The configuration of Sentry, see config.SentryConfig
+//   - environment: The environment of the server, e.g. production
+//   - release: The version of the server
+//
+// Returns:
+//   - *SentryReporter
+//   - error: The DSN is invalid
+func NewSentryReporter(cfg config.SentryConfig, environment, release string) (*SentryReporter, error) {
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: environment,
+		Release:     release,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to initialize sentry: %w", err)
+	}
+	return &SentryReporter{}, nil
+}
+
+// ReportPanic reports a panic, with the stack trace of the goroutine which panicked
+func (r *SentryReporter) ReportPanic(c *gin.Context, recovered any) {
+	r.hub(c).RecoverWithContext(c.Request.Context(), recovered)
+}
+
+// ReportError reports a 5xx response, along with the errors attached to the request
+func (r *SentryReporter) ReportError(c *gin.Context, status int) {
+	hub := r.hub(c)
+	hub.Scope().SetTag("status", fmt.Sprint(status))
+	if len(c.Errors) == 0 {
+		hub.CaptureMessage(fmt.Sprintf("%s %s responded %d", c.Request.Method, c.FullPath(), status))
+		return
+	}
+	for _, err := range c.Errors {
+		hub.CaptureException(err.Err)
+	}
+}
+
+// Flush waits for the reports to be sent, until the timeout
+func (r *SentryReporter) Flush(timeout time.Duration) bool {
+	return sentry.Flush(timeout)
+}
+
+// hub returns a hub whose reports describe the request
+func (r *SentryReporter) hub(c *gin.Context) *sentry.Hub {
+	hub := sentry.CurrentHub().Clone()
+	hub.Scope().SetRequest(c.Request)
+	if route := c.FullPath(); route != "" {
+		hub.Scope().SetTag("route", route)
+	}
+	return hub
+}
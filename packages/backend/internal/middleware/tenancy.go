@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireNamespaceClaim middleware, isolating the namespaces of the API in
+// strict tenancy. Requests must be made by a user identified as RequireUser
+// does, for a namespace listed in the namespaces header set by the
+// authenticating proxy, comma separated. The namespace of requests is taken
+// from their path, else their query. Requests without user get a 401, those
+// without namespace a 400, and those for namespaces the user isn't granted a
+// 403, unless the user is an admin, admins querying any namespace. The
+// anonymous requests of public namespaces are let through, see PublicReadOnly.
+//
+// Parameters:
+//   - header: The header identifying the user, see config.SecurityConfig
+//   - namespacesHeader: The header listing the namespaces granted to the user
+//   - admins: The admin users, nobody is an admin when empty
+//
+// Returns:
+//   - gin.HandlerFunc
+func RequireNamespaceClaim(header, namespacesHeader string, admins []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsPublic(c) {
+			c.Next()
+			return
+		}
+		user := strings.TrimSpace(c.GetHeader(header))
+		if user == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing user"})
+			return
+		}
+		namespace := requestNamespace(c)
+		if namespace == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing namespace"})
+			return
+		}
+		if !slices.Contains(admins, user) && !slices.Contains(claimedNamespaces(c.GetHeader(namespacesHeader)), namespace) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+			return
+		}
+		setUser(c, user)
+		c.Next()
+	}
+}
+
+// claimedNamespaces parses the comma separated namespaces of a header
+func claimedNamespaces(header string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(header, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
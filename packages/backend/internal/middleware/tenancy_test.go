@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireNamespaceClaim(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequireNamespaceClaim("X-Forwarded-User", "X-Forwarded-Namespaces", []string{"admin"}))
+	router.GET("/issues", func(c *gin.Context) { c.String(http.StatusOK, User(c)) })
+	router.GET("/namespaces/:namespace/settings", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		path       string
+		user       string
+		namespaces string
+		wantStatus int
+	}{
+		{name: "granted namespace", path: "/issues?namespace=team-b", user: "alice", namespaces: "team-a, team-b", wantStatus: http.StatusOK},
+		{name: "granted path namespace", path: "/namespaces/team-a/settings", user: "alice", namespaces: "team-a", wantStatus: http.StatusOK},
+		{name: "missing user", path: "/issues?namespace=team-a", namespaces: "team-a", wantStatus: http.StatusUnauthorized},
+		{name: "missing namespace", path: "/issues", user: "alice", namespaces: "team-a", wantStatus: http.StatusBadRequest},
+		{name: "missing namespace of admin", path: "/issues", user: "admin", wantStatus: http.StatusBadRequest},
+		{name: "other namespace", path: "/issues?namespace=team-c", user: "alice", namespaces: "team-a,team-b", wantStatus: http.StatusForbidden},
+		{name: "other path namespace", path: "/namespaces/team-c/settings", user: "alice", namespaces: "team-a", wantStatus: http.StatusForbidden},
+		{name: "no namespace granted", path: "/issues?namespace=team-a", user: "alice", wantStatus: http.StatusForbidden},
+		{name: "admin across namespaces", path: "/issues?namespace=team-c", user: "admin", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.user != "" {
+				req.Header.Set("X-Forwarded-User", tt.user)
+			}
+			if tt.namespaces != "" {
+				req.Header.Set("X-Forwarded-Namespaces", tt.namespaces)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code == http.StatusOK && tt.path == "/issues?namespace=team-b" && w.Body.String() != tt.user {
+				t.Errorf("Expected the user to be identified, got %q", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// Timeout middleware, bounding how long handlers may take. The context of the
+// request gets a deadline, cancelling the database queries still running when
+// it passes. Requests whose handler didn't respond in time get a 503, so that
+// one slow query can't hold a connection until the server write timeout.
+// Handlers streaming their response opt out with UntimedContext.
+//
+// Parameters:
+//   - cfg: The timeouts of reads, writes and specific routes
+//
+// Returns:
+//   - gin.HandlerFunc
+func Timeout(cfg config.TimeoutsConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := cfg.Routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = cfg.Write
+			if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+				timeout = cfg.Read
+			}
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		c.Set(untimedContextKey, c.Request.Context())
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			RespondTimedOut(c)
+		}
+	}
+}
+
+// untimedContextKey keys the context of a request before Timeout bounded it
+const untimedContextKey = "untimedContext"
+
+// UntimedContext returns the context of a request without the deadline set by
+// Timeout, for the handlers streaming their response, e.g. issues as NDJSON,
+// which bound each of their queries instead. It's still cancelled when the
+// client disconnects.
+func UntimedContext(c *gin.Context) context.Context {
+	if ctx, ok := c.Get(untimedContextKey); ok {
+		return ctx.(context.Context)
+	}
+	return c.Request.Context()
+}
+
+// RespondTimedOut responds to a request which timed out, see Timeout
+func RespondTimedOut(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Request timed out"})
+}
+
+// TimedOut checks whether a request timed out, see Timeout
+func TimedOut(c *gin.Context) bool {
+	return errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+}
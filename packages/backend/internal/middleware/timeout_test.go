@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// waitForDeadline is a handler waiting for its request to time out, as a slow
+// query would, and responding 200 when the request has no deadline
+func waitForDeadline(c *gin.Context) {
+	if _, ok := c.Request.Context().Deadline(); !ok {
+		c.Status(http.StatusOK)
+		return
+	}
+	<-c.Request.Context().Done()
+}
+
+// setupTimeoutRouter creates a test router serving GET and POST /issues/:id behind the timeout middleware
+func setupTimeoutRouter(cfg config.TimeoutsConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(cfg))
+	router.GET("/issues/:id", waitForDeadline)
+	router.POST("/issues/:id", waitForDeadline)
+	return router
+}
+
+func timeRequest(router *gin.Engine, method string) (*httptest.ResponseRecorder, time.Duration) {
+	start := time.Now()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/issues/1", nil))
+	return w, time.Since(start)
+}
+
+func TestTimeout(t *testing.T) {
+	router := setupTimeoutRouter(config.TimeoutsConfig{Read: 10 * time.Millisecond, Write: time.Minute})
+
+	w, elapsed := timeRequest(router, http.MethodGet)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected the request to time out after the read timeout, took %s", elapsed)
+	}
+}
+
+func TestTimeout_Routes(t *testing.T) {
+	router := setupTimeoutRouter(config.TimeoutsConfig{
+		Read:   time.Minute,
+		Write:  time.Minute,
+		Routes: map[string]time.Duration{"POST /issues/:id": 10 * time.Millisecond},
+	})
+
+	w, elapsed := timeRequest(router, http.MethodPost)
+	if w.Code != http.StatusServiceUnavailable || elapsed > time.Second {
+		t.Errorf("Expected the request to time out after the timeout of its route, got %d after %s", w.Code, elapsed)
+	}
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	router := setupTimeoutRouter(config.TimeoutsConfig{})
+
+	if w, _ := timeRequest(router, http.MethodGet); w.Code != http.StatusOK {
+		t.Errorf("Expected requests without timeout, got %d", w.Code)
+	}
+}
+
+func TestTimeout_UntimedContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(config.TimeoutsConfig{Read: 10 * time.Millisecond}))
+	router.GET("/issues", func(c *gin.Context) {
+		if _, ok := c.Request.Context().Deadline(); !ok {
+			t.Error("Expected the request to have a deadline")
+		}
+		if _, ok := UntimedContext(c).Deadline(); ok {
+			t.Error("Expected the untimed context to have no deadline")
+		}
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/issues", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+}
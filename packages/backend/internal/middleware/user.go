@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// userKey is the key of the user of a request in its context, see User
+const userKey = "user"
+
+// IdentifyUser middleware, identifying the user of requests from a header set
+// by the authenticating proxy in front of the API, e.g. the X-Forwarded-User
+// header of oauth2-proxy. Requests without user are served anonymously.
+//
+// Parameters:
+//   - header: The header identifying the user, see config.SecurityConfig
+//
+// Returns:
+//   - gin.HandlerFunc
+func IdentifyUser(header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user := strings.TrimSpace(c.GetHeader(header)); user != "" {
+			setUser(c, user)
+		}
+		c.Next()
+	}
+}
+
+// RequireUser middleware, identifying the user of requests as IdentifyUser
+// does. Requests without user get a 401.
+//
+// Parameters:
+//   - header: The header identifying the user, see config.SecurityConfig
+//
+// Returns:
+//   - gin.HandlerFunc
+func RequireUser(header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := strings.TrimSpace(c.GetHeader(header))
+		if user == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing user"})
+			return
+		}
+		setUser(c, user)
+		c.Next()
+	}
+}
+
+// RequireAdmin middleware, identifying the user of requests as RequireUser
+// does. Requests of users who aren't admins get a 403.
+//
+// Parameters:
+//   - header: The header identifying the user, see config.SecurityConfig
+//   - admins: The admin users, nobody is an admin when empty
+//
+// Returns:
+//   - gin.HandlerFunc
+func RequireAdmin(header string, admins []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := strings.TrimSpace(c.GetHeader(header))
+		if user == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing user"})
+			return
+		}
+		if !slices.Contains(admins, user) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			return
+		}
+		setUser(c, user)
+		c.Next()
+	}
+}
+
+// setUser records the user of a request, logged by its logger, see Logger
+func setUser(c *gin.Context, user string) {
+	c.Set(userKey, user)
+	c.Request = c.Request.WithContext(logging.WithFields(c.Request.Context(), logrus.Fields{"user": user}))
+}
+
+// User returns the user of a request, identified by RequireUser
+func User(c *gin.Context) string {
+	return c.GetString(userKey)
+}
@@ -1,20 +1,42 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-// Validation middleware for request validation
+// idParams are the path parameters holding the ID of a record
+var idParams = []string{"id", "relatedId", "refId"}
+
+// ValidateID middleware, checking that the IDs in the path of the request,
+// the id, relatedId and refId parameters of the route, are UUIDs. Requests
+// with another ID get a 400 naming the parameter, rather than reaching the
+// database with it.
 func ValidateID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id := c.Param("id")
-		if id == "" || len(id) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID parameter"})
-			c.Abort()
-			return
+		for _, name := range idParams {
+			value, ok := c.Params.Get(name)
+			if !ok {
+				continue
+			}
+			if !IsValidID(value) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error":   "Invalid ID parameter",
+					"param":   name,
+					"details": fmt.Sprintf("%s must be a UUID, got %q", name, value),
+				})
+				return
+			}
 		}
 		c.Next()
 	}
 }
+
+// IsValidID checks whether id is a UUID in its canonical form, e.g.
+// 7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f, as the IDs of all records are
+func IsValidID(id string) bool {
+	return len(id) == 36 && uuid.Validate(id) == nil
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidateID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/issues/:id", ValidateID(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.DELETE("/issues/:id/related/:relatedId", ValidateID(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	const id = "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f"
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedParam  string
+	}{
+		{name: "UUID", method: http.MethodGet, path: "/issues/" + id, expectedStatus: http.StatusOK},
+		{name: "upper case UUID", method: http.MethodGet, path: "/issues/7D5B8E5A-5C1E-4B8A-9F0E-2D6A1C3B4E5F", expectedStatus: http.StatusOK},
+		{name: "not a UUID", method: http.MethodGet, path: "/issues/issue-1", expectedStatus: http.StatusBadRequest, expectedParam: "id"},
+		{name: "UUID without hyphens", method: http.MethodGet, path: "/issues/7d5b8e5a5c1e4b8a9f0e2d6a1c3b4e5f", expectedStatus: http.StatusBadRequest, expectedParam: "id"},
+		{name: "UUID in braces", method: http.MethodGet, path: "/issues/%7B" + id + "%7D", expectedStatus: http.StatusBadRequest, expectedParam: "id"},
+		{name: "related UUIDs", method: http.MethodDelete, path: "/issues/" + id + "/related/" + id, expectedStatus: http.StatusOK},
+		{name: "related ID not a UUID", method: http.MethodDelete, path: "/issues/" + id + "/related/issue-2", expectedStatus: http.StatusBadRequest, expectedParam: "relatedId"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedParam == "" {
+				return
+			}
+			var response map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response["error"] != "Invalid ID parameter" || response["param"] != tc.expectedParam {
+				t.Errorf("Expected the invalid %s parameter, got %v", tc.expectedParam, response)
+			}
+		})
+	}
+}
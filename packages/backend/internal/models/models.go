@@ -1,6 +1,12 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +23,73 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// Severities are the valid severities, from least to most severe
+var Severities = []Severity{SeverityInfo, SeverityMinor, SeverityMajor, SeverityCritical}
+
+// Valid checks whether s is one of Severities
+func (s Severity) Valid() bool {
+	return slices.Contains(Severities, s)
+}
+
+// severityAliases map the severities of other scales, such as the "medium"
+// severity of older operators, to Severities
+var severityAliases = map[string]Severity{
+	"low":    SeverityInfo,
+	"medium": SeverityMinor,
+	"high":   SeverityMajor,
+}
+
+// ParseSeverity parses a severity reported by a webhook, in any case, and
+// mapping the aliases of other scales, e.g. "Medium" to SeverityMinor
+//
+// Returns:
+//   - Severity: The severity
+//   - bool: Whether value is a severity or one of its aliases
+func ParseSeverity(value string) (Severity, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if severity := Severity(value); severity.Valid() {
+		return severity, true
+	}
+	severity, ok := severityAliases[value]
+	return severity, ok
+}
+
+// Rank orders severities from least to most severe, unknown severities first
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 4
+	case SeverityMajor:
+		return 3
+	case SeverityMinor:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Priority captures the triage decision for an issue, P1 being the most urgent.
+// Unlike severity, which describes the impact of an issue, it's set by the
+// team owning the issue.
+type Priority string
+
+const (
+	PriorityP1 Priority = "P1"
+	PriorityP2 Priority = "P2"
+	PriorityP3 Priority = "P3"
+	PriorityP4 Priority = "P4"
+)
+
+// Priorities are the valid priorities, from most to least urgent
+var Priorities = []Priority{PriorityP1, PriorityP2, PriorityP3, PriorityP4}
+
+// Valid checks whether p is one of Priorities
+func (p Priority) Valid() bool {
+	return slices.Contains(Priorities, p)
+}
+
 type IssueType string
 
 const (
@@ -27,39 +100,194 @@ const (
 	IssueTypePipeline   IssueType = "pipeline"
 )
 
+// IssueTypes are the valid issue types
+var IssueTypes = []IssueType{IssueTypeBuild, IssueTypeTest, IssueTypeRelease, IssueTypeDependency, IssueTypePipeline}
+
+// Valid checks whether t is one of IssueTypes
+func (t IssueType) Valid() bool {
+	return slices.Contains(IssueTypes, t)
+}
+
+// IssueState is the state of an issue in the workflow of its namespace. Issues
+// are reported ACTIVE and end RESOLVED, going through the intermediate states
+// of the workflow in between, see Workflow.
 type IssueState string
 
 const (
-	IssueStateActive   IssueState = "ACTIVE"
-	IssueStateResolved IssueState = "RESOLVED"
+	IssueStateActive       IssueState = "ACTIVE"
+	IssueStateAcknowledged IssueState = "ACKNOWLEDGED"
+	IssueStateInProgress   IssueState = "IN_PROGRESS"
+	IssueStateResolved     IssueState = "RESOLVED"
 )
 
-// Issue represents an issue in the cluster
+// IssueStates are the built-in issue states, namespaces may add their own
+var IssueStates = []IssueState{IssueStateActive, IssueStateAcknowledged, IssueStateInProgress, IssueStateResolved}
+
+// maxIssueStateLength is the length of the state column
+const maxIssueStateLength = 20
+
+// Valid checks whether s is a well-formed state: one of IssueStates, or a
+// custom state of up to 20 upper case letters, digits and underscores
+// starting with a letter, e.g. WAITING_ON_VENDOR. Whether a namespace uses
+// the state is checked against its Workflow.
+func (s IssueState) Valid() bool {
+	if slices.Contains(IssueStates, s) {
+		return true
+	}
+	if s == "" || len(s) > maxIssueStateLength || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Open checks whether issues in the state still need attention, in any state but RESOLVED
+func (s IssueState) Open() bool {
+	return s != IssueStateResolved
+}
+
+// Workflow holds the intermediate states issues of a namespace go through
+// between ACTIVE and RESOLVED, and the transitions allowed between states.
+// The workflow applies to the states set through the API; reporters still
+// reopen resolved issues and resolve the issues of their resources, whatever
+// their state.
+type Workflow struct {
+	// States are the intermediate states, in the order of the workflow
+	States []IssueState `json:"states"`
+	// Transitions maps each state to the states issues may move to from it
+	Transitions map[IssueState][]IssueState `json:"transitions"`
+}
+
+// DefaultWorkflow is the workflow of namespaces without one: issues are
+// acknowledged, worked on and resolved, may skip states, and may be reopened
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		States: []IssueState{IssueStateAcknowledged, IssueStateInProgress},
+		Transitions: map[IssueState][]IssueState{
+			IssueStateActive:       {IssueStateAcknowledged, IssueStateInProgress, IssueStateResolved},
+			IssueStateAcknowledged: {IssueStateActive, IssueStateInProgress, IssueStateResolved},
+			IssueStateInProgress:   {IssueStateActive, IssueStateAcknowledged, IssueStateResolved},
+			IssueStateResolved:     {IssueStateActive},
+		},
+	}
+}
+
+// HasState checks whether s is a state of the workflow, ACTIVE and RESOLVED always are
+func (w Workflow) HasState(s IssueState) bool {
+	return s == IssueStateActive || s == IssueStateResolved || slices.Contains(w.States, s)
+}
+
+// Allows checks whether issues may move from a state to another. Staying in
+// the same state always is.
+func (w Workflow) Allows(from, to IssueState) bool {
+	return from == to || slices.Contains(w.Transitions[from], to)
+}
+
+// Validate checks the states of the workflow are well-formed and unique, and
+// its transitions are between its states
+func (w Workflow) Validate() error {
+	for i, state := range w.States {
+		if !state.Valid() {
+			return fmt.Errorf("invalid state %q, states must be up to %d upper case letters, digits and underscores", state, maxIssueStateLength)
+		}
+		if state == IssueStateActive || state == IssueStateResolved {
+			return fmt.Errorf("%s can't be an intermediate state", state)
+		}
+		if slices.Contains(w.States[:i], state) {
+			return fmt.Errorf("duplicate state %q", state)
+		}
+	}
+	for from, targets := range w.Transitions {
+		if !w.HasState(from) {
+			return fmt.Errorf("transition from unknown state %q", from)
+		}
+		for _, to := range targets {
+			if !w.HasState(to) {
+				return fmt.Errorf("transition from %s to unknown state %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// Issue represents an issue in the cluster.
+//
+// idx_issues_namespace_state_detected serves the dashboard listing, the
+// issues of a namespace in a state ordered by detection time.
 type Issue struct {
 	ID          string     `gorm:"type:uuid;primaryKey;" json:"id"`
 	Title       string     `gorm:"not null" json:"title"`
 	Description string     `gorm:"not null" json:"description"`
-	Severity    Severity   `gorm:"type:varchar(20);not null" json:"severity"`
-	IssueType   IssueType  `gorm:"type:varchar(20);not null" json:"issueType"`
-	State       IssueState `gorm:"type:varchar(20);default:ACTIVE" json:"state"`
-	DetectedAt  time.Time  `gorm:"not null" json:"detectedAt"`
-	ResolvedAt  *time.Time `json:"resolvedAt"`
-	Namespace   string     `gorm:"not null" json:"namespace"`
+	Details     string     `gorm:"not null;default:''" json:"details,omitempty"`
+	Severity    Severity   `gorm:"type:varchar(20);not null;index;check:chk_issues_severity,severity IN ('info', 'minor', 'major', 'critical')" json:"severity"`
+	Priority    Priority   `gorm:"type:varchar(2);index;check:chk_issues_priority,priority IN ('', 'P1', 'P2', 'P3', 'P4')" json:"priority"`
+	IssueType   IssueType  `gorm:"type:varchar(20);not null;index;uniqueIndex:idx_issues_active_dedup,priority:2;check:chk_issues_issue_type,issue_type IN ('build', 'test', 'release', 'dependency', 'pipeline')" json:"issueType"`
+	State       IssueState `gorm:"type:varchar(20);default:ACTIVE;index:idx_issues_namespace_state_detected,priority:2" json:"state"`
+	DetectedAt  time.Time  `gorm:"not null;index:idx_issues_namespace_state_detected,priority:3;index:idx_issues_detected_at" json:"detectedAt"`
+	ResolvedAt  *time.Time `gorm:"index" json:"resolvedAt"`
+	Namespace   string     `gorm:"not null;index:idx_issues_namespace_state_detected,priority:1;uniqueIndex:idx_issues_active_dedup,priority:1,where:state <> 'RESOLVED';index:idx_issues_namespace_fingerprint,priority:1" json:"namespace"`
+	Assignee    string     `gorm:"index" json:"assignee"`
+
+	// Why and by whom the issue was last resolved, both optional
+	ResolutionReason string `gorm:"not null;default:''" json:"resolutionReason,omitempty"`
+	ResolvedBy       string `gorm:"not null;default:''" json:"resolvedBy,omitempty"`
+
+	// When and by whom the issue was acknowledged, cleared when it's reopened
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy string     `gorm:"not null;default:''" json:"acknowledgedBy,omitempty"`
+
+	// Until when and by whom the issue was snoozed, cleared when it's reopened.
+	// Snoozed issues are left out of the listings asking for snoozed=false.
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
+	SnoozedBy    string     `gorm:"not null;default:''" json:"snoozedBy,omitempty"`
+
+	// How many times the issue was made active again after being resolved, and when it last was
+	ReopenCount int        `gorm:"not null;default:0" json:"reopenCount"`
+	ReopenedAt  *time.Time `json:"reopenedAt,omitempty"`
+
+	// Fingerprint groups the reports of the issue instead of its scope when
+	// the reporter sets it, e.g. a security scanner identifying a vulnerability
+	Fingerprint string `gorm:"not null;default:'';index:idx_issues_namespace_fingerprint,priority:2,where:fingerprint <> ''" json:"fingerprint,omitempty"`
+
+	// DedupKey identifies the resource of the issue scope, see ScopeDedupKey,
+	// or its fingerprint, see FingerprintDedupKey. The database allows one open
+	// issue per namespace, issue type and DedupKey.
+	DedupKey string `gorm:"not null;default:'';uniqueIndex:idx_issues_active_dedup,priority:3" json:"-"`
 
 	// Foreign key to IssueScope
 	ScopeID string     `gorm:"type:uuid;not null;unique" json:"scopeId"`
 	Scope   IssueScope `gorm:"foreignKey:ScopeID" json:"scope"`
 
-	// Relationships
-	Links       []Link         `gorm:"foreignKey:IssueID" json:"links"`
-	RelatedFrom []RelatedIssue `gorm:"foreignKey:SourceID" json:"relatedFrom"`
-	RelatedTo   []RelatedIssue `gorm:"foreignKey:TargetID" json:"relatedTo"`
+	// Relationships, deleted along with the issue. The related issues are
+	// only loaded for single issues, lists count them in RelatedCount.
+	Links        []Link         `gorm:"foreignKey:IssueID;constraint:OnDelete:CASCADE" json:"links"`
+	Labels       []Label        `gorm:"foreignKey:IssueID;constraint:OnDelete:CASCADE" json:"labels"`
+	ExternalRefs []ExternalRef  `gorm:"foreignKey:IssueID;constraint:OnDelete:CASCADE" json:"externalRefs"`
+	RelatedFrom  []RelatedIssue `gorm:"foreignKey:SourceID;constraint:OnDelete:CASCADE" json:"relatedFrom,omitempty"`
+	RelatedTo    []RelatedIssue `gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE" json:"relatedTo,omitempty"`
+
+	// RelatedCount is how many relationships involve the issue, only set in issue lists
+	RelatedCount int64 `gorm:"-" json:"relatedCount,omitempty"`
 
 	// Timestamps
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// ScopeDedupKey returns the Issue.DedupKey of issues scoped to a resource
+func ScopeDedupKey(resourceType, resourceName string) string {
+	return resourceType + "/" + resourceName
+}
+
+// FingerprintDedupKey returns the Issue.DedupKey of issues with a fingerprint
+func FingerprintDedupKey(fingerprint string) string {
+	return "fingerprint:" + fingerprint
+}
+
 // BeforeCreate hook to set UUID if not provided
 func (i *Issue) BeforeCreate(tx *gorm.DB) error {
 	if i.ID == "" {
@@ -75,8 +303,8 @@ type IssueScope struct {
 	ResourceName      string `gorm:"not null" json:"resourceName"`
 	ResourceNamespace string `gorm:"not null" json:"resourceNamespace"`
 
-	// Relationship - one issue scope has one issue
-	Issue *Issue `gorm:"foreignKey:ScopeID" json:"issue,omitempty"`
+	// Relationship - one issue scope has one issue, deleting the scope deletes the issue
+	Issue *Issue `gorm:"foreignKey:ScopeID;constraint:OnDelete:CASCADE" json:"issue,omitempty"`
 }
 
 // BeforeCreate hook to set UUID if not provided
@@ -87,11 +315,22 @@ func (s *IssueScope) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// RelationType is the type of a relationship between issues
+type RelationType string
+
+const (
+	// RelationTypeRelated relates issues without any further meaning
+	RelationTypeRelated RelationType = "related"
+	// RelationTypeCausedBy relates an issue, the source, to the issue causing it, the target
+	RelationTypeCausedBy RelationType = "caused-by"
+)
+
 // RelatedIssue represents relationships between issues
 type RelatedIssue struct {
-	ID       string `gorm:"type:uuid;primaryKey" json:"id"`
-	SourceID string `gorm:"type:uuid;not null" json:"sourceId"`
-	TargetID string `gorm:"type:uuid;not null" json:"targetId"`
+	ID       string       `gorm:"type:uuid;primaryKey" json:"id"`
+	SourceID string       `gorm:"type:uuid;not null" json:"sourceId"`
+	TargetID string       `gorm:"type:uuid;not null" json:"targetId"`
+	Type     RelationType `gorm:"type:varchar(20);not null;default:related" json:"type"`
 
 	// Relationships
 	Source Issue `gorm:"foreignKey:SourceID" json:"source,omitempty"`
@@ -116,6 +355,66 @@ type Link struct {
 	Issue Issue `gorm:"foreignKey:IssueID" json:"-"`
 }
 
+// linkVariablePattern matches the variables of link templates, e.g. {{runId}},
+// capturing their name
+var linkVariablePattern = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
+// LinkTemplate is a link attached to the issues of a namespace when they're
+// reported, e.g. to the logs of a pipeline run. Its URL holds variables, e.g.
+// https://console.example.com/ns/{{namespace}}/pipelineruns/{{runId}}/logs,
+// replaced by the fields of the issue: namespace, issueType, severity,
+// resourceType, resourceName and resourceNamespace, its labels as
+// label.<key>, and the variables of the report, e.g. runId.
+type LinkTemplate struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	// IssueType and ResourceType restrict the template to the issues of a
+	// type and of a type of resource, any when empty
+	IssueType    IssueType `json:"issueType,omitempty"`
+	ResourceType string    `json:"resourceType,omitempty"`
+}
+
+// Validate checks the template has a title and an absolute URL once its
+// variables are replaced, and a valid issue type if any
+func (t LinkTemplate) Validate() error {
+	if t.Title == "" {
+		return fmt.Errorf("link template title is required")
+	}
+	if t.IssueType != "" && !t.IssueType.Valid() {
+		return fmt.Errorf("invalid issue type %q of link template %s", t.IssueType, t.Title)
+	}
+	parsed, err := url.Parse(linkVariablePattern.ReplaceAllString(t.URL, "x"))
+	if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+		return fmt.Errorf("invalid URL %q of link template %s, must be an absolute URL", t.URL, t.Title)
+	}
+	return nil
+}
+
+// Matches checks whether the template applies to the issues of a type and
+// of a type of resource
+func (t LinkTemplate) Matches(issueType IssueType, resourceType string) bool {
+	return (t.IssueType == "" || t.IssueType == issueType) &&
+		(t.ResourceType == "" || t.ResourceType == resourceType)
+}
+
+// Expand replaces the variables of the URL of the template by their values,
+// escaped
+//
+// Returns:
+//   - string: The URL
+//   - bool: Whether all the variables have a value, the URL can't be built otherwise
+func (t LinkTemplate) Expand(variables map[string]string) (string, bool) {
+	expanded := true
+	link := linkVariablePattern.ReplaceAllStringFunc(t.URL, func(variable string) string {
+		value := variables[linkVariablePattern.FindStringSubmatch(variable)[1]]
+		if value == "" {
+			expanded = false
+		}
+		return url.PathEscape(value)
+	})
+	return link, expanded
+}
+
 // BeforeCreate hook to set UUID if not provided
 func (l *Link) BeforeCreate(tx *gorm.DB) error {
 	if l.ID == "" {
@@ -123,3 +422,217 @@ func (l *Link) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// Label represents a key/value label attached to an issue, e.g. team=build-infra
+type Label struct {
+	ID      string `gorm:"type:uuid;primaryKey" json:"id"`
+	Key     string `gorm:"not null;uniqueIndex:idx_labels_issue_key" json:"key"`
+	Value   string `gorm:"not null" json:"value"`
+	IssueID string `gorm:"type:uuid;not null;uniqueIndex:idx_labels_issue_key" json:"issueId"`
+	// Omit field when converting to JSON or deconverting from JSON
+	Issue Issue `gorm:"foreignKey:IssueID" json:"-"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (l *Label) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ExternalRef references an issue tracked in another system, e.g. the Jira
+// ticket or GitHub issue where the remediation work happens
+type ExternalRef struct {
+	ID      string `gorm:"type:uuid;primaryKey" json:"id"`
+	System  string `gorm:"not null;uniqueIndex:idx_external_refs_issue_system_key,priority:2" json:"system"`
+	Key     string `gorm:"not null;uniqueIndex:idx_external_refs_issue_system_key,priority:3" json:"key"`
+	URL     string `gorm:"not null" json:"url"`
+	IssueID string `gorm:"type:uuid;not null;uniqueIndex:idx_external_refs_issue_system_key,priority:1" json:"issueId"`
+	// Omit field when converting to JSON or deconverting from JSON
+	Issue Issue `gorm:"foreignKey:IssueID" json:"-"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (e *ExternalRef) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IssueWatch records that a user follows an issue, to be notified of its
+// changes regardless of the notification rules of its namespace
+type IssueWatch struct {
+	IssueID string `gorm:"type:uuid;primaryKey" json:"issueId"`
+	User    string `gorm:"primaryKey;index" json:"user"`
+	// Omit field when converting to JSON, deleting the issue deletes its watches
+	Issue Issue `gorm:"foreignKey:IssueID;constraint:OnDelete:CASCADE" json:"-"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SavedView is a named set of issue filters, shared in a namespace or
+// personal to a user, so that they can be applied to GET /issues with ?view=
+// rather than typed again
+type SavedView struct {
+	// Namespace sharing the view, empty for personal views
+	Namespace string `gorm:"primaryKey" json:"namespace,omitempty"`
+	// User owning the view, empty for views shared in a namespace
+	User string `gorm:"primaryKey" json:"user,omitempty"`
+	Name string `gorm:"primaryKey" json:"name"`
+	// Query holds the filters as the query string of GET /issues, e.g. severity=critical&issueType=build
+	Query string `gorm:"not null" json:"query"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NamespaceSettings holds the settings of a namespace, configuring how its
+// issues are handled. Namespaces without settings use the defaults, the zero
+// values of the settings.
+type NamespaceSettings struct {
+	Namespace string `gorm:"primaryKey" json:"namespace"`
+	// RetentionDays is how many days resolved issues are kept, 0 to keep them forever
+	RetentionDays int `gorm:"not null;default:0" json:"retentionDays"`
+	// DedupWindowHours is how many hours after being resolved an issue is
+	// reopened when reported again, rather than a new issue created. 0 always reopens it.
+	DedupWindowHours int `gorm:"not null;default:0" json:"dedupWindowHours"`
+	// SeverityEscalationOnly keeps webhooks from lowering the severity of the
+	// issues they report again, e.g. of an issue a human marked critical
+	SeverityEscalationOnly bool `gorm:"not null;default:false" json:"severityEscalationOnly"`
+
+	SLATargets    SLATargets           `gorm:"embedded;embeddedPrefix:sla_" json:"slaTargets"`
+	Notifications NotificationDefaults `gorm:"embedded;embeddedPrefix:notify_" json:"notifications"`
+	// Workflow is the workflow of the issues of the namespace, DefaultWorkflow when nil
+	Workflow *Workflow `gorm:"type:jsonb;serializer:json" json:"workflow,omitempty"`
+	// LinkTemplates are the links attached to the issues reported, along
+	// with the default templates of the server whose title they don't take
+	LinkTemplates []LinkTemplate `gorm:"type:jsonb;serializer:json" json:"linkTemplates,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SLATargets holds how many hours issues of each severity may stay active, 0 for no target
+type SLATargets struct {
+	CriticalHours int `gorm:"not null;default:0" json:"criticalHours"`
+	MajorHours    int `gorm:"not null;default:0" json:"majorHours"`
+	MinorHours    int `gorm:"not null;default:0" json:"minorHours"`
+	InfoHours     int `gorm:"not null;default:0" json:"infoHours"`
+}
+
+// NotificationDefaults holds where the notifications about the issues of a
+// namespace are sent, and from which severity on
+type NotificationDefaults struct {
+	Channel     string   `gorm:"not null;default:''" json:"channel"`
+	MinSeverity Severity `gorm:"type:varchar(20);not null;default:''" json:"minSeverity"`
+}
+
+// IssueSource is how an issue was reported
+type IssueSource string
+
+const (
+	// IssueSourceAPI issues are created with POST /issues
+	IssueSourceAPI IssueSource = "api"
+	// IssueSourceWebhook issues are reported by webhooks, e.g. pipeline failures
+	IssueSourceWebhook IssueSource = "webhook"
+)
+
+// IssueSources are the valid issue sources
+var IssueSources = []IssueSource{IssueSourceAPI, IssueSourceWebhook}
+
+// Valid checks whether s is one of IssueSources
+func (s IssueSource) Valid() bool {
+	return slices.Contains(IssueSources, s)
+}
+
+// TriageRule sets fields of the issues created in a namespace it matches.
+// The rules of a namespace are evaluated in order of position, then name, all
+// matching rules firing and later rules overriding the fields set by earlier ones.
+type TriageRule struct {
+	Namespace string `gorm:"primaryKey" json:"namespace"`
+	Name      string `gorm:"primaryKey" json:"name"`
+	Position  int    `gorm:"not null;default:0" json:"position"`
+
+	// Conditions, all of those set must match the issue
+	TitlePattern string      `gorm:"not null;default:''" json:"titlePattern,omitempty"`
+	ResourceType string      `gorm:"not null;default:''" json:"resourceType,omitempty"`
+	ResourceName string      `gorm:"not null;default:''" json:"resourceName,omitempty"`
+	Source       IssueSource `gorm:"type:varchar(20);not null;default:'';check:chk_triage_rules_source,source IN ('', 'api', 'webhook')" json:"source,omitempty"`
+
+	// Actions, applied to the issue when set
+	Labels   map[string]string `gorm:"type:jsonb;serializer:json" json:"labels,omitempty"`
+	Assignee string            `gorm:"not null;default:''" json:"assignee,omitempty"`
+	Priority Priority          `gorm:"type:varchar(2);not null;default:'';check:chk_triage_rules_priority,priority IN ('', 'P1', 'P2', 'P3', 'P4')" json:"priority,omitempty"`
+	Severity Severity          `gorm:"type:varchar(20);not null;default:'';check:chk_triage_rules_severity,severity IN ('', 'info', 'minor', 'major', 'critical')" json:"severity,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TriageEvent records that a triage rule fired on an issue, and the fields it
+// changed. Events keep the name of their rule when it's deleted.
+type TriageEvent struct {
+	ID       string `gorm:"type:uuid;primaryKey" json:"id"`
+	IssueID  string `gorm:"type:uuid;not null;index" json:"issueId"`
+	RuleName string `gorm:"not null" json:"ruleName"`
+	// Changes maps the changed fields to their new value, labels as label.<key>
+	Changes map[string]string `gorm:"type:jsonb;serializer:json" json:"changes"`
+	// Omit field when converting to JSON, deleting the issue deletes its events
+	Issue Issue `gorm:"foreignKey:IssueID;constraint:OnDelete:CASCADE" json:"-"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (e *TriageEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// WebhookSignatureStatus is the outcome of checking the signature of a webhook
+type WebhookSignatureStatus string
+
+const (
+	// WebhookSignatureValid webhooks were signed with the webhook secret
+	WebhookSignatureValid WebhookSignatureStatus = "valid"
+	// WebhookSignatureUnchecked webhooks were received without a webhook
+	// secret configured, their signature isn't checked
+	WebhookSignatureUnchecked WebhookSignatureStatus = "unchecked"
+)
+
+// WebhookEvent records a webhook accepted by the API, with its JSON payload,
+// so that it can be inspected and replayed
+type WebhookEvent struct {
+	ID string `gorm:"type:uuid;primaryKey" json:"id"`
+	// Source is the webhook receiving the payload, e.g. pipeline-failure
+	Source    string `gorm:"type:varchar(50);not null;index" json:"source"`
+	Namespace string `gorm:"not null;index" json:"namespace"`
+	// Payload is empty when it was too large for the row, and stored in the
+	// blob storage under PayloadKey instead
+	Payload    json.RawMessage `gorm:"type:jsonb;serializer:json" json:"payload,omitempty"`
+	PayloadKey string          `gorm:"type:varchar(255)" json:"payloadKey,omitempty"`
+	// PayloadURL is a presigned URL downloading the payload stored in the blob storage
+	PayloadURL      string                 `gorm:"-" json:"payloadUrl,omitempty"`
+	SignatureStatus WebhookSignatureStatus `gorm:"type:varchar(20);not null" json:"signatureStatus"`
+	// IssueID is the issue created or updated by the webhook, if any. The
+	// event is kept when the issue is deleted.
+	IssueID    *string   `gorm:"type:uuid;index" json:"issueId,omitempty"`
+	ReceivedAt time.Time `gorm:"not null;index" json:"receivedAt"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (e *WebhookEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -1,6 +1,12 @@
 package models
 
 import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strings"
 	"testing"
 	"time"
 
@@ -143,3 +149,175 @@ func TestLinkStructInit(t *testing.T) {
 		t.Errorf("Expected URL '%s', got '%s'", expectedLinkUrl, link.URL)
 	}
 }
+
+func TestEnumsValid(t *testing.T) {
+	if !SeverityCritical.Valid() || Severity("medium").Valid() || Severity("").Valid() {
+		t.Error("Expected only the severity constants to be valid")
+	}
+	if !PriorityP4.Valid() || Priority("P0").Valid() {
+		t.Error("Expected only the priority constants to be valid")
+	}
+	if !IssueTypePipeline.Valid() || IssueType("deploy").Valid() {
+		t.Error("Expected only the issue type constants to be valid")
+	}
+	if !IssueStateResolved.Valid() || IssueState("resolved").Valid() {
+		t.Error("Expected only the issue state constants to be valid")
+	}
+	// Namespaces may define states of their own, in the same format
+	for state, valid := range map[IssueState]bool{
+		"WAITING_ON_VENDOR":     true,
+		"QA2":                   true,
+		"":                      false,
+		"2ND_LOOK":              false,
+		"WAITING ON VENDOR":     false,
+		"WAITING_ON_THE_VENDOR": false,
+	} {
+		if state.Valid() != valid {
+			t.Errorf("Expected %q to be valid: %t", state, valid)
+		}
+	}
+	if !IssueSourceWebhook.Valid() || IssueSource("email").Valid() {
+		t.Error("Expected only the issue source constants to be valid")
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected Severity
+		ok       bool
+	}{
+		{"critical", SeverityCritical, true},
+		{" Major ", SeverityMajor, true},
+		{"medium", SeverityMinor, true},
+		{"HIGH", SeverityMajor, true},
+		{"low", SeverityInfo, true},
+		{"urgent", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		if severity, ok := ParseSeverity(tc.value); severity != tc.expected || ok != tc.ok {
+			t.Errorf("Expected %q to parse as %q, %t, got %q, %t", tc.value, tc.expected, tc.ok, severity, ok)
+		}
+	}
+}
+
+// TestOperatorSeverities checks the severities reported by the operator, the
+// Severity constants of its KITE client, are severities of the backend
+func TestOperatorSeverities(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../../../operator/internal/clients/kite.go", nil, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("The operator isn't checked out along with the backend")
+	}
+	if err != nil {
+		t.Fatalf("Failed to parse the client of the operator: %v", err)
+	}
+
+	found := 0
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				literal, ok := value.Values[i].(*ast.BasicLit)
+				if !strings.HasPrefix(name.Name, "Severity") || !ok {
+					continue
+				}
+				found++
+				if severity := Severity(strings.Trim(literal.Value, `"`)); !severity.Valid() {
+					t.Errorf("The operator reports %s = %s, which isn't a severity of the backend", name.Name, literal.Value)
+				}
+			}
+		}
+	}
+	if found != len(Severities) {
+		t.Errorf("Expected the operator to declare the %d severities, found %d", len(Severities), found)
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	workflow := DefaultWorkflow()
+	if err := workflow.Validate(); err != nil {
+		t.Fatalf("Expected the default workflow to be valid, got %v", err)
+	}
+	if !workflow.HasState(IssueStateInProgress) || workflow.HasState("WAITING_ON_VENDOR") {
+		t.Error("Expected the default workflow to have the built-in states only")
+	}
+	if !workflow.Allows(IssueStateActive, IssueStateAcknowledged) || !workflow.Allows(IssueStateResolved, IssueStateActive) {
+		t.Error("Expected issues to be acknowledged and reopened")
+	}
+	if workflow.Allows(IssueStateResolved, IssueStateInProgress) {
+		t.Error("Expected resolved issues to be reopened as ACTIVE only")
+	}
+	if !workflow.Allows(IssueStateResolved, IssueStateResolved) {
+		t.Error("Expected staying in the same state to be allowed")
+	}
+
+	testCases := []struct {
+		name     string
+		workflow Workflow
+		valid    bool
+	}{
+		{
+			name: "custom states",
+			workflow: Workflow{
+				States: []IssueState{"WAITING_ON_VENDOR"},
+				Transitions: map[IssueState][]IssueState{
+					IssueStateActive:    {"WAITING_ON_VENDOR", IssueStateResolved},
+					"WAITING_ON_VENDOR": {IssueStateResolved},
+				},
+			},
+			valid: true,
+		},
+		{name: "no intermediate states", workflow: Workflow{}, valid: true},
+		{name: "malformed state", workflow: Workflow{States: []IssueState{"waiting"}}},
+		{name: "built-in end state", workflow: Workflow{States: []IssueState{IssueStateResolved}}},
+		{name: "duplicate state", workflow: Workflow{States: []IssueState{"QA", "QA"}}},
+		{name: "transition from unknown state", workflow: Workflow{Transitions: map[IssueState][]IssueState{"QA": {IssueStateResolved}}}},
+		{name: "transition to unknown state", workflow: Workflow{Transitions: map[IssueState][]IssueState{IssueStateActive: {"QA"}}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.workflow.Validate(); (err == nil) != tc.valid {
+				t.Errorf("Expected valid: %t, got %v", tc.valid, err)
+			}
+		})
+	}
+}
+
+func TestLinkTemplate(t *testing.T) {
+	template := LinkTemplate{
+		Title:        "Pipeline Run Logs",
+		URL:          "https://console.test/ns/{{namespace}}/pipelineruns/{{ runId }}/logs",
+		IssueType:    IssueTypePipeline,
+		ResourceType: "pipelinerun",
+	}
+	if err := template.Validate(); err != nil {
+		t.Fatalf("Expected the template to be valid, got %v", err)
+	}
+	if !template.Matches(IssueTypePipeline, "pipelinerun") || template.Matches(IssueTypeBuild, "pipelinerun") || template.Matches(IssueTypePipeline, "component") {
+		t.Error("Expected the template to match pipeline issues of pipeline runs only")
+	}
+
+	link, ok := template.Expand(map[string]string{"namespace": "team-alpha", "runId": "build/x8f2k"})
+	if !ok || link != "https://console.test/ns/team-alpha/pipelineruns/build%2Fx8f2k/logs" {
+		t.Errorf("Expected the variables to be replaced and escaped, got %q", link)
+	}
+	if _, ok := template.Expand(map[string]string{"namespace": "team-alpha"}); ok {
+		t.Error("Expected templates missing a variable not to expand")
+	}
+
+	for _, invalid := range []LinkTemplate{
+		{URL: "https://console.test/{{runId}}"},
+		{Title: "Logs", URL: "/logs/{{runId}}"},
+		{Title: "Logs", URL: "https://console.test/{{runId}}", IssueType: "incident"},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
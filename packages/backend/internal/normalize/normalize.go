@@ -0,0 +1,78 @@
+// Package normalize strips what's specific to a pipeline run from the fields
+// of webhooks, e.g. random name suffixes, timestamps and IDs, so that the
+// failures of the runs of a pipeline are deduplicated into one issue.
+package normalize
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+// DefaultRules are the rules built in the server, applied before the rules of
+// the configuration unless they're disabled with KITE_NORMALIZATION_DEFAULT_RULES
+var DefaultRules = []config.NormalizationRule{
+	// RFC 3339 and similar timestamps, e.g. 2025-01-01T12:00:00.123Z
+	{
+		Pattern:     regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`),
+		Replacement: "<timestamp>",
+	},
+	// UUIDs, e.g. the UIDs of Kubernetes objects
+	{
+		Pattern:     regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`),
+		Replacement: "<id>",
+	},
+	// Long hexadecimal IDs, e.g. commit SHAs and image digests
+	{
+		Pattern:     regexp.MustCompile(`(?i)\b[0-9a-f]{12,}\b`),
+		Replacement: "<id>",
+	},
+	// The random suffixes of the names Kubernetes generates, e.g. the x8f2k of
+	// frontend-build-x8f2k, made of 5 characters without vowels, nor the digits
+	// looking like them, so that they don't spell words
+	{
+		Pattern:     regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}\b`),
+		Replacement: "",
+	},
+}
+
+// repeatedSpaces matches the runs of spaces left by the rules removing text
+var repeatedSpaces = regexp.MustCompile(`[ \t]{2,}`)
+
+// Normalizer normalizes texts with rules applied in order
+type Normalizer struct {
+	rules []config.NormalizationRule
+}
+
+// NewNormalizer creates a new Normalizer
+//
+// Parameters:
+//   - cfg: The rules, and whether DefaultRules apply before them
+//
+// Returns:
+//   - *Normalizer
+func NewNormalizer(cfg config.NormalizationConfig) *Normalizer {
+	var rules []config.NormalizationRule
+	if cfg.DefaultRules {
+		rules = append(rules, DefaultRules...)
+	}
+	return &Normalizer{rules: append(rules, cfg.Rules...)}
+}
+
+// Normalize applies the rules to a text. Texts the rules would empty are kept
+// as they are. A nil Normalizer returns texts unchanged.
+func (n *Normalizer) Normalize(text string) string {
+	if n == nil {
+		return text
+	}
+	normalized := text
+	for _, rule := range n.rules {
+		normalized = rule.Pattern.ReplaceAllString(normalized, rule.Replacement)
+	}
+	normalized = strings.TrimSpace(repeatedSpaces.ReplaceAllString(normalized, " "))
+	if normalized == "" {
+		return text
+	}
+	return normalized
+}
@@ -0,0 +1,67 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/config"
+)
+
+func TestNormalizer_Normalize_DefaultRules(t *testing.T) {
+	normalizer := NewNormalizer(config.NormalizationConfig{DefaultRules: true})
+
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{"frontend-build-x8f2k", "frontend-build"},
+		{"frontend-on-push-9bq4z", "frontend-on-push"},
+		{"pipeline frontend-build-x8f2k failed", "pipeline frontend-build failed"},
+		// Words and other suffixes are kept
+		{"frontend-build", "frontend-build"},
+		{"backend-tests", "backend-tests"},
+		{"build-ppc64le", "build-ppc64le"},
+		{"Step build failed at 2025-01-01T12:00:00.123Z with exit code 1", "Step build failed at <timestamp> with exit code 1"},
+		{"TaskRun 3f2a9c4e-8b1d-4e6f-a7c2-5d9e0b1f2a3c timed out", "TaskRun <id> timed out"},
+		{"Image quay.io/org/app@sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 not found", "Image quay.io/org/app@sha256:<id> not found"},
+		// Texts the rules empty are kept
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		if normalized := normalizer.Normalize(tc.text); normalized != tc.expected {
+			t.Errorf("Expected %q normalized to %q, got %q", tc.text, tc.expected, normalized)
+		}
+	}
+}
+
+func TestNormalizer_Normalize_Rules(t *testing.T) {
+	t.Setenv("KITE_NORMALIZATION_RULES", "# Build numbers\n  build #\\d+ => build #<n>\n\n attempt \\d+ of \\d+")
+	cfg, err := config.GetNormalizationConfig()
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(cfg.Rules) != 2 || !cfg.DefaultRules {
+		t.Fatalf("Expected 2 rules after the default ones, got %+v", cfg)
+	}
+
+	normalizer := NewNormalizer(cfg)
+	if normalized := normalizer.Normalize("build #42 of frontend-build-x8f2k failed, attempt 2 of 3 "); normalized != "build #<n> of frontend-build failed," {
+		t.Errorf("Expected the rules to apply after the default ones, got %q", normalized)
+	}
+
+	// Without the default rules
+	cfg.DefaultRules = false
+	if normalized := NewNormalizer(cfg).Normalize("build #42 of frontend-build-x8f2k"); normalized != "build #<n> of frontend-build-x8f2k" {
+		t.Errorf("Expected only the rules to apply, got %q", normalized)
+	}
+
+	// A nil normalizer changes nothing
+	var none *Normalizer
+	if normalized := none.Normalize("frontend-build-x8f2k"); normalized != "frontend-build-x8f2k" {
+		t.Errorf("Expected the text unchanged, got %q", normalized)
+	}
+
+	t.Setenv("KITE_NORMALIZATION_RULES", "build-(\\d+ => build")
+	if _, err := config.GetNormalizationConfig(); err == nil {
+		t.Error("Expected an invalid pattern to be refused")
+	}
+}
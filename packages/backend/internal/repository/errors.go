@@ -0,0 +1,46 @@
+package repository
+
+import "errors"
+
+// The kinds of the errors of the repositories and services, telling what went
+// wrong whatever their message. Errors of a kind are created with
+// NotFoundError, ConflictError and ForbiddenError, and matched with errors.Is.
+var (
+	// ErrNotFound is the kind of errors of records that don't exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is the kind of errors of operations conflicting with the
+	// state of records, e.g. creating a record that exists already
+	ErrConflict = errors.New("conflict")
+	// ErrForbidden is the kind of errors of operations the caller isn't
+	// allowed to do, e.g. on the issues of another namespace
+	ErrForbidden = errors.New("forbidden")
+)
+
+// kindError is an error of a kind, with a message of its own
+type kindError struct {
+	kind    error
+	message string
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// NotFoundError returns an error of the ErrNotFound kind with a message
+func NotFoundError(message string) error {
+	return &kindError{kind: ErrNotFound, message: message}
+}
+
+// ConflictError returns an error of the ErrConflict kind with a message
+func ConflictError(message string) error {
+	return &kindError{kind: ErrConflict, message: message}
+}
+
+// ForbiddenError returns an error of the ErrForbidden kind with a message
+func ForbiddenError(message string) error {
+	return &kindError{kind: ErrForbidden, message: message}
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// IssueRepositoryDecorator wraps an IssueRepository to add a cross-cutting
+// concern, e.g. caching, without changing the repository itself.
+type IssueRepositoryDecorator func(IssueRepository) IssueRepository
+
+// Interceptor runs around every call of an IssueRepository method, e.g. to
+// time, trace or count the calls. It must call next with the context the call
+// runs with, and return the error of next, or its own.
+type Interceptor func(ctx context.Context, method string, next func(ctx context.Context) error) error
+
+// DecorateIssueRepository wraps a repository with decorators.
+//
+// Parameters:
+//   - repo: The repository to decorate
+//   - decorators: The decorators, the first one being the outermost
+//
+// Returns:
+//   - IssueRepository: The decorated repository
+func DecorateIssueRepository(repo IssueRepository, decorators ...IssueRepositoryDecorator) IssueRepository {
+	for n := len(decorators) - 1; n >= 0; n-- {
+		repo = decorators[n](repo)
+	}
+	return repo
+}
+
+// WithInterceptors returns a decorator running interceptors around every call
+// of the repository, the first interceptor being the outermost.
+func WithInterceptors(interceptors ...Interceptor) IssueRepositoryDecorator {
+	return func(repo IssueRepository) IssueRepository {
+		return &interceptedIssueRepository{next: repo, interceptors: interceptors}
+	}
+}
+
+// LogSlowCalls returns an interceptor logging the repository calls taking
+// longer than threshold, along with their error. A threshold of 0 logs no call.
+func LogSlowCalls(logger *logrus.Logger, threshold time.Duration) Interceptor {
+	return func(ctx context.Context, method string, next func(ctx context.Context) error) error {
+		startedAt := time.Now()
+		err := next(ctx)
+
+		if elapsed := time.Since(startedAt); threshold > 0 && elapsed >= threshold {
+			entry := logger.WithFields(logrus.Fields{
+				"method":   method,
+				"duration": elapsed.String(),
+			})
+			if err != nil {
+				entry = entry.WithError(err)
+			}
+			entry.Warn("Slow repository call")
+		}
+		return err
+	}
+}
+
+// interceptedIssueRepository runs interceptors around the calls of the
+// repository it wraps
+type interceptedIssueRepository struct {
+	next         IssueRepository
+	interceptors []Interceptor
+}
+
+// intercept runs call through the interceptors
+func (r *interceptedIssueRepository) intercept(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	next := call
+	for n := len(r.interceptors) - 1; n >= 0; n-- {
+		interceptor, inner := r.interceptors[n], next
+		next = func(ctx context.Context) error {
+			return interceptor(ctx, method, inner)
+		}
+	}
+	return next(ctx)
+}
+
+func (r *interceptedIssueRepository) Create(ctx context.Context, req dto.IssuePayload) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "Create", func(ctx context.Context) error {
+		issue, err = r.next.Create(ctx, req)
+		return err
+	})
+	return issue, err
+}
+
+func (r *interceptedIssueRepository) FindByID(ctx context.Context, id string) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "FindByID", func(ctx context.Context) error {
+		issue, err = r.next.FindByID(ctx, id)
+		return err
+	})
+	return issue, err
+}
+
+func (r *interceptedIssueRepository) FindByIDs(ctx context.Context, ids []string, opts ...FindOption) (issues []models.Issue, err error) {
+	err = r.intercept(ctx, "FindByIDs", func(ctx context.Context) error {
+		issues, err = r.next.FindByIDs(ctx, ids, opts...)
+		return err
+	})
+	return issues, err
+}
+
+func (r *interceptedIssueRepository) Update(ctx context.Context, id string, updates dto.IssuePayload) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "Update", func(ctx context.Context) error {
+		issue, err = r.next.Update(ctx, id, updates)
+		return err
+	})
+	return issue, err
+}
+
+func (r *interceptedIssueRepository) Delete(ctx context.Context, id string) error {
+	return r.intercept(ctx, "Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+func (r *interceptedIssueRepository) FindAll(ctx context.Context, filters IssueQueryFilters) (issues []models.Issue, total int64, err error) {
+	err = r.intercept(ctx, "FindAll", func(ctx context.Context) error {
+		issues, total, err = r.next.FindAll(ctx, filters)
+		return err
+	})
+	return issues, total, err
+}
+
+func (r *interceptedIssueRepository) CountByFilters(ctx context.Context, filters IssueQueryFilters) (count int64, err error) {
+	err = r.intercept(ctx, "CountByFilters", func(ctx context.Context) error {
+		count, err = r.next.CountByFilters(ctx, filters)
+		return err
+	})
+	return count, err
+}
+
+func (r *interceptedIssueRepository) CountGroupedBy(ctx context.Context, filters IssueQueryFilters, field string) (counts []GroupCount, err error) {
+	err = r.intercept(ctx, "CountGroupedBy", func(ctx context.Context) error {
+		counts, err = r.next.CountGroupedBy(ctx, filters, field)
+		return err
+	})
+	return counts, err
+}
+
+func (r *interceptedIssueRepository) ResolutionTimes(ctx context.Context, filters IssueQueryFilters) (stats *ResolutionStats, err error) {
+	err = r.intercept(ctx, "ResolutionTimes", func(ctx context.Context) error {
+		stats, err = r.next.ResolutionTimes(ctx, filters)
+		return err
+	})
+	return stats, err
+}
+
+func (r *interceptedIssueRepository) CountReopensByResource(ctx context.Context, filters IssueQueryFilters, limit int) (reopens []ResourceReopens, err error) {
+	err = r.intercept(ctx, "CountReopensByResource", func(ctx context.Context) error {
+		reopens, err = r.next.CountReopensByResource(ctx, filters, limit)
+		return err
+	})
+	return reopens, err
+}
+
+func (r *interceptedIssueRepository) FindScope(ctx context.Context, id string) (scope *models.IssueScope, err error) {
+	err = r.intercept(ctx, "FindScope", func(ctx context.Context) error {
+		scope, err = r.next.FindScope(ctx, id)
+		return err
+	})
+	return scope, err
+}
+
+func (r *interceptedIssueRepository) FindByResource(ctx context.Context, namespace string, scope models.IssueScope, since time.Time, limit int) (issues []models.Issue, err error) {
+	err = r.intercept(ctx, "FindByResource", func(ctx context.Context) error {
+		issues, err = r.next.FindByResource(ctx, namespace, scope, since, limit)
+		return err
+	})
+	return issues, err
+}
+
+func (r *interceptedIssueRepository) FindSimilar(ctx context.Context, issue models.Issue, limit int) (similar []SimilarIssue, err error) {
+	err = r.intercept(ctx, "FindSimilar", func(ctx context.Context) error {
+		similar, err = r.next.FindSimilar(ctx, issue, limit)
+		return err
+	})
+	return similar, err
+}
+
+func (r *interceptedIssueRepository) FindDuplicate(ctx context.Context, req dto.IssuePayload) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "FindDuplicate", func(ctx context.Context) error {
+		issue, err = r.next.FindDuplicate(ctx, req)
+		return err
+	})
+	return issue, err
+}
+
+func (r *interceptedIssueRepository) ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string, resolution dto.Resolution) (count int64, err error) {
+	err = r.intercept(ctx, "ResolveByScope", func(ctx context.Context) error {
+		count, err = r.next.ResolveByScope(ctx, resourceType, resourceName, namespace, resolution)
+		return err
+	})
+	return count, err
+}
+
+func (r *interceptedIssueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetID string, relationType models.RelationType) error {
+	return r.intercept(ctx, "AddRelatedIssue", func(ctx context.Context) error {
+		return r.next.AddRelatedIssue(ctx, sourceID, targetID, relationType)
+	})
+}
+
+func (r *interceptedIssueRepository) RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error {
+	return r.intercept(ctx, "RemoveRelatedIssue", func(ctx context.Context) error {
+		return r.next.RemoveRelatedIssue(ctx, sourceID, targetID)
+	})
+}
+
+func (r *interceptedIssueRepository) FindActiveEffects(ctx context.Context, id string) (ids []string, err error) {
+	err = r.intercept(ctx, "FindActiveEffects", func(ctx context.Context) error {
+		ids, err = r.next.FindActiveEffects(ctx, id)
+		return err
+	})
+	return ids, err
+}
+
+func (r *interceptedIssueRepository) AddExternalRef(ctx context.Context, issueID string, ref models.ExternalRef) (created *models.ExternalRef, err error) {
+	err = r.intercept(ctx, "AddExternalRef", func(ctx context.Context) error {
+		created, err = r.next.AddExternalRef(ctx, issueID, ref)
+		return err
+	})
+	return created, err
+}
+
+func (r *interceptedIssueRepository) RemoveExternalRef(ctx context.Context, issueID, refID string) error {
+	return r.intercept(ctx, "RemoveExternalRef", func(ctx context.Context) error {
+		return r.next.RemoveExternalRef(ctx, issueID, refID)
+	})
+}
+
+func (r *interceptedIssueRepository) Acknowledge(ctx context.Context, id, acknowledgedBy string, state models.IssueState) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "Acknowledge", func(ctx context.Context) error {
+		issue, err = r.next.Acknowledge(ctx, id, acknowledgedBy, state)
+		return err
+	})
+	return issue, err
+}
+
+func (r *interceptedIssueRepository) Snooze(ctx context.Context, id string, until *time.Time, snoozedBy string) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "Snooze", func(ctx context.Context) error {
+		issue, err = r.next.Snooze(ctx, id, until, snoozedBy)
+		return err
+	})
+	return issue, err
+}
+
+func (r *interceptedIssueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (issue *models.Issue, err error) {
+	err = r.intercept(ctx, "CreateOrUpdate", func(ctx context.Context) error {
+		issue, err = r.next.CreateOrUpdate(ctx, req)
+		return err
+	})
+	return issue, err
+}
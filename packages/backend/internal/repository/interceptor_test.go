@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+// recordCalls returns an interceptor recording the calls it intercepts into calls
+func recordCalls(name string, calls *[]string) Interceptor {
+	return func(ctx context.Context, method string, next func(ctx context.Context) error) error {
+		*calls = append(*calls, name+":"+method)
+		return next(ctx)
+	}
+}
+
+func TestWithInterceptors(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	var calls []string
+	decorated := DecorateIssueRepository(repo,
+		WithInterceptors(recordCalls("outer", &calls), recordCalls("inner", &calls)),
+	)
+
+	created, err := decorated.Create(ctx, createTestIssue("Intercepted Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	found, err := decorated.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found.Title != "Intercepted Issue" {
+		t.Errorf("Expected the results of the repository, got %+v", found)
+	}
+
+	expected := []string{"outer:Create", "inner:Create", "outer:FindByID", "inner:FindByID"}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+
+	// Errors of the repository go through the interceptors
+	if err := decorated.Delete(ctx, "non-existent-id"); err == nil {
+		t.Error("Expected an error, got nil")
+	}
+}
+
+func TestWithInterceptors_ShortCircuit(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	unavailable := errors.New("repository unavailable")
+	decorated := DecorateIssueRepository(repo, WithInterceptors(
+		func(ctx context.Context, method string, next func(ctx context.Context) error) error {
+			return unavailable
+		},
+	))
+
+	if _, _, err := decorated.FindAll(ctx, IssueQueryFilters{Namespace: "test-namespace"}); !errors.Is(err, unavailable) {
+		t.Errorf("Expected the error of the interceptor, got %v", err)
+	}
+}
+
+func TestLogSlowCalls(t *testing.T) {
+	ctx := context.Background()
+	logger, hook := test.NewNullLogger()
+
+	call := func(duration time.Duration) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			time.Sleep(duration)
+			return nil
+		}
+	}
+
+	interceptor := LogSlowCalls(logger, 20*time.Millisecond)
+	if err := interceptor(ctx, "FindAll", call(0)); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(hook.AllEntries()) != 0 {
+		t.Errorf("Expected fast calls not to be logged, got %d entries", len(hook.AllEntries()))
+	}
+
+	if err := interceptor(ctx, "FindAll", call(30*time.Millisecond)); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.WarnLevel || entry.Data["method"] != "FindAll" {
+		t.Errorf("Expected a warning about the slow call, got %+v", entry)
+	}
+}
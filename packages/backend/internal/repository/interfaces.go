@@ -2,26 +2,92 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"gorm.io/gorm"
 )
 
 type IssueRepository interface {
 	Create(ctx context.Context, req dto.IssuePayload) (*models.Issue, error)
 	FindByID(ctx context.Context, id string) (*models.Issue, error)
+	FindByIDs(ctx context.Context, ids []string, opts ...FindOption) ([]models.Issue, error)
 	Update(ctx context.Context, id string, updates dto.IssuePayload) (*models.Issue, error)
 	Delete(ctx context.Context, id string) error
 	// TODO - move IssueQueryFilters somewhere else
 	FindAll(ctx context.Context, filters IssueQueryFilters) ([]models.Issue, int64, error)
+	CountByFilters(ctx context.Context, filters IssueQueryFilters) (int64, error)
+	CountGroupedBy(ctx context.Context, filters IssueQueryFilters, field string) ([]GroupCount, error)
+	ResolutionTimes(ctx context.Context, filters IssueQueryFilters) (*ResolutionStats, error)
+	CountReopensByResource(ctx context.Context, filters IssueQueryFilters, limit int) ([]ResourceReopens, error)
+	FindSimilar(ctx context.Context, issue models.Issue, limit int) ([]SimilarIssue, error)
 	FindDuplicate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error)
-	ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error)
-	AddRelatedIssue(ctx context.Context, sourceID, targetID string) error
+	ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string, resolution dto.Resolution) (int64, error)
+	AddRelatedIssue(ctx context.Context, sourceID, targetID string, relationType models.RelationType) error
 	RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error
+	FindActiveEffects(ctx context.Context, id string) ([]string, error)
+	AddExternalRef(ctx context.Context, issueID string, ref models.ExternalRef) (*models.ExternalRef, error)
+	RemoveExternalRef(ctx context.Context, issueID, refID string) error
+	Acknowledge(ctx context.Context, id, acknowledgedBy string, state models.IssueState) (*models.Issue, error)
+	Snooze(ctx context.Context, id string, until *time.Time, snoozedBy string) (*models.Issue, error)
 	CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error)
+	FindScope(ctx context.Context, id string) (*models.IssueScope, error)
+	FindByResource(ctx context.Context, namespace string, scope models.IssueScope, since time.Time, limit int) ([]models.Issue, error)
 }
 
 type LinkRepository interface {
+	WithTx(tx *gorm.DB) LinkRepository
 	CreateBatch(ctx context.Context, issueID string, links []models.Link) error
 	DeleteByIssueID(ctx context.Context, issueID string) error
+	FindByIssueID(ctx context.Context, issueID string) ([]models.Link, error)
+}
+
+type NamespaceSettingsRepository interface {
+	Find(ctx context.Context, namespace string) (*models.NamespaceSettings, error)
+	Save(ctx context.Context, settings models.NamespaceSettings) (*models.NamespaceSettings, error)
+	Delete(ctx context.Context, namespace string) error
+}
+
+type IssueWatchRepository interface {
+	Watch(ctx context.Context, issueID, user string) (*models.IssueWatch, error)
+	Unwatch(ctx context.Context, issueID, user string) error
+	FindWatchedIssues(ctx context.Context, user string) ([]models.Issue, error)
+	FindWatchers(ctx context.Context, issueID string) ([]string, error)
+}
+
+type SavedViewRepository interface {
+	Find(ctx context.Context, namespace, user, name string) (*models.SavedView, error)
+	FindAll(ctx context.Context, namespace, user string) ([]models.SavedView, error)
+	Save(ctx context.Context, view models.SavedView) (*models.SavedView, error)
+	Delete(ctx context.Context, namespace, user, name string) error
+}
+
+type TriageRuleRepository interface {
+	FindAll(ctx context.Context, namespace string) ([]models.TriageRule, error)
+	Save(ctx context.Context, rule models.TriageRule) (*models.TriageRule, error)
+	Delete(ctx context.Context, namespace, name string) error
+	RecordEvents(ctx context.Context, events []models.TriageEvent) error
+	FindEvents(ctx context.Context, issueID string) ([]models.TriageEvent, error)
+}
+
+type WebhookEventRepository interface {
+	Create(ctx context.Context, event models.WebhookEvent) (*models.WebhookEvent, error)
+	FindAll(ctx context.Context, filters WebhookEventFilters) ([]models.WebhookEvent, error)
+	FindByID(ctx context.Context, id string) (*models.WebhookEvent, error)
+}
+
+type NamespaceRepository interface {
+	CountIssues(ctx context.Context, namespace string, activeOnly bool) (int64, error)
+	DeleteIssues(ctx context.Context, namespace string, limit int) (int64, error)
+	ResolveIssues(ctx context.Context, namespace string, resolution dto.Resolution, limit int) (int64, error)
+	DeleteData(ctx context.Context, namespace string, withEvents bool) (*dto.NamespaceData, error)
+}
+
+type MaintenanceRepository interface {
+	Tables() ([]string, error)
+	Reindex(ctx context.Context, table string) error
+	Vacuum(ctx context.Context, table string) error
+	Analyze(ctx context.Context, table string) error
+	RecomputeDedupKeys(ctx context.Context) (int64, error)
 }
@@ -4,17 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/metrics"
 	"github.com/konflux-ci/kite/internal/models"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type issueRepository struct {
-	db     *gorm.DB
-	logger *logrus.Logger
+	db           *gorm.DB
+	links        LinkRepository
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+	// deferred holds the metrics of the unit of work of the repository until
+	// it commits, nil outside units of work
+	deferred *metrics.Deferred
 }
 
 // NewIssueRepository creates a new Issue repository
@@ -22,19 +31,33 @@ type issueRepository struct {
 // Parameters:
 //   - db: Pointer to a database (gorm.DB)
 //   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
 //
 // Returns:
 //   - IssueRepository
-func NewIssueRepository(db *gorm.DB, logger *logrus.Logger) IssueRepository {
+func NewIssueRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) IssueRepository {
 	return &issueRepository{
-		db:     db,
-		logger: logger,
+		db:           db,
+		links:        NewLinkRepository(db, logger, queryTimeout),
+		logger:       logger,
+		queryTimeout: queryTimeout,
 	}
 }
 
+// withQueryTimeout bounds a repository operation by the query timeout. The
+// operation is also cancelled along with ctx, e.g. when the client of the
+// request disconnects.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // CreateOrUpdate atomically creates a new issue or updates an existing duplicate.
 // This method ensures that concurrent requests for the same issue will not create
-// duplicates by using database-level locking within a single transaction.
+// duplicates by using database-level locking within a single transaction, and
+// an upsert on the unique index allowing a single ACTIVE issue per scope.
 //
 // Behavior:
 //   - If no duplicate exists: Creates a new issue with all provided data
@@ -53,8 +76,12 @@ func NewIssueRepository(db *gorm.DB, logger *logrus.Logger) IssueRepository {
 //   - *models.Issue: The created or updated issue with all associations loaded
 //   - error: Database error, validation failure or nil
 func (i *issueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	var issue *models.Issue
 	var isUpdate bool
+	var outcome metrics.DedupOutcome
 
 	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var existingIssue *models.Issue
@@ -66,29 +93,33 @@ func (i *issueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePaylo
 
 		// Create a new one
 		if existingIssue == nil {
-			newIssue, err := i.createNewIssueInTx(tx, req)
+			newIssue, created, err := i.createNewIssueInTx(tx, req)
 			if err != nil {
 				return fmt.Errorf("failed to create issue: %w", err)
 			}
 			issue = newIssue
+			isUpdate = !created
+			outcome = dedupOutcome(nil, req, created)
 			return nil
 		}
 
 		// If no error, an existing issue should be found
 		isUpdate = true
 		issue = existingIssue
+		outcome = dedupOutcome(existingIssue, req, false)
 		return i.updateIssueInTx(tx, existingIssue, req)
 	})
 
 	if err != nil {
-		i.logger.WithError(err).Error("Failed to create or update issue")
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to create or update issue")
 		return nil, err
 	}
+	i.countReport(req.GetIssueType(), outcome)
 
 	if isUpdate {
-		i.logger.WithField("issue_id", issue.ID).Info("Updated existing issue")
+		logging.FromContext(ctx, i.logger).WithField("issue_id", issue.ID).Info("Updated existing issue")
 	} else {
-		i.logger.WithField("issue_id", issue.ID).Info("Created new issue")
+		logging.FromContext(ctx, i.logger).WithField("issue_id", issue.ID).Info("Created new issue")
 	}
 
 	// Reload all associations
@@ -107,15 +138,18 @@ func (i *issueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePaylo
 //   - *models.Issue: The existing issue if found, nil if no duplicates are found.
 //   - error: Database error or nil
 func (i *issueRepository) FindDuplicate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	var issue *models.Issue
 	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		existingIssue, err := i.findDuplicateInTx(tx, req)
 		if err != nil {
-			i.logger.WithError(err).Error("Failed to check for duplicate issues")
+			logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to check for duplicate issues")
 			return err
 		}
 		if existingIssue != nil {
-			i.logger.WithField("existing_issue_id", existingIssue.ID).Info("Found duplicate issue")
+			logging.FromContext(ctx, i.logger).WithField("existing_issue_id", existingIssue.ID).Info("Found duplicate issue")
 			issue = existingIssue
 		}
 
@@ -140,9 +174,15 @@ func (i *issueRepository) FindDuplicate(ctx context.Context, req dto.IssuePayloa
 // The function considers an issue a duplicate if ALL of the following match:
 //   - Same namespace
 //   - Same issue type
-//   - Issue is in ACTIVE state
 //   - Same resource scope (type, name, namespace)
 //
+// Issues with a fingerprint are grouped by their fingerprint instead of their
+// scope, so that a duplicate has the same namespace, issue type and
+// fingerprint, and issues without one are never duplicates of them.
+//
+// The issue may be in any state, resolved issues being reopened. The open
+// issue is preferred to resolved ones, then the most recently detected one.
+//
 // Parameters:
 //   - tx: The database transaction to execute within
 //   - req: The issue payload containing the criteria to match.
@@ -163,14 +203,21 @@ func (i *issueRepository) findDuplicateInTx(tx *gorm.DB, req dto.IssuePayload) (
 	// Lock any matching rows with "FOR UPDATE" to prevent other transactions
 	// from reading or modifying them until the transaction completes.
 	// Doc: https://www.postgresql.org/docs/current/explicit-locking.html#LOCKING-ROWS
-	err := tx.Preload("Links").
-		Joins("JOIN issue_scopes on issues.scope_id = issue_scopes.id").
-		Where("issues.namespace = ? AND issues.issue_type = ? AND issues.state IN ?",
-			req.GetNamespace(), req.GetIssueType(), []models.IssueState{models.IssueStateActive, models.IssueStateResolved}).
-		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ? AND issue_scopes.resource_namespace = ?",
-			req.GetScope().GetResourceType(), req.GetScope().GetResourceName(), req.GetNamespace()).
-		Set("gorm:query_option", "FOR UPDATE").
-		First(&existingIssue).Error
+	query := tx.Preload("Links").
+		Where("issues.namespace = ? AND issues.issue_type = ?", req.GetNamespace(), req.GetIssueType())
+	if fingerprint := req.GetFingerprint(); fingerprint != "" {
+		query = query.Where("issues.fingerprint = ?", fingerprint)
+	} else {
+		query = query.Joins("JOIN issue_scopes on issues.scope_id = issue_scopes.id").
+			Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ? AND issue_scopes.resource_namespace = ?",
+				req.GetScope().GetResourceType(), req.GetScope().GetResourceName(), req.GetNamespace()).
+			Where("issues.fingerprint = ''")
+	}
+	// Resolved duplicates may remain next to the open issue, e.g. those the
+	// dedup key migration left, so the open issue comes first, then the most
+	// recently detected one
+	err := query.Order("issues.state = 'RESOLVED'").Order("issues.detected_at DESC").
+		Set("gorm:query_option", "FOR UPDATE").First(&existingIssue).Error
 
 	if err != nil {
 		// Not finding a record is expected behavior (no duplicate exists)
@@ -185,18 +232,113 @@ func (i *issueRepository) findDuplicateInTx(tx *gorm.DB, req dto.IssuePayload) (
 }
 
 type IssueQueryFilters struct {
-	Namespace    string
-	Severity     *models.Severity
-	IssueType    *models.IssueType
-	State        *models.IssueState
+	Namespace string
+	Severity  *models.Severity
+	Priority  *models.Priority
+	IssueType *models.IssueType
+	State     *models.IssueState
+	// Unresolved keeps the open issues, in any state but RESOLVED
+	Unresolved   bool
 	ResourceType string
 	ResourceName string
-	Search       string
-	Limit        int
-	Offset       int
+	// ResourceNamespace is the namespace of the resource, which may differ from the issue's
+	ResourceNamespace string
+	Search            string
+	Assignee          string
+	Labels            map[string]string
+	// Fingerprint keeps the issues reported with the fingerprint, see models.Issue.Fingerprint
+	Fingerprint string
+	// HasExternalRef keeps issues with (true) or without (false) external references
+	HasExternalRef *bool
+	// Acknowledged keeps acknowledged (true) or unacknowledged (false) issues
+	Acknowledged *bool
+	// Snoozed keeps the open issues snoozed until a later time (true), or all
+	// the other issues (false)
+	Snoozed *bool
+	// ResolvedSince and ResolvedBefore keep issues resolved at or after, and
+	// before, the times
+	ResolvedSince  *time.Time
+	ResolvedBefore *time.Time
+	// DetectedSince and DetectedBefore keep issues detected at or after, and
+	// before, the times
+	DetectedSince  *time.Time
+	DetectedBefore *time.Time
+	// ReopenedSince keeps issues reopened at or after the time
+	ReopenedSince *time.Time
+	// CreatedSince and CreatedBefore keep issues created at or after, and
+	// before, the times
+	CreatedSince  *time.Time
+	CreatedBefore *time.Time
+	// After keeps the issues after the cursor, ordering them by detection and
+	// ID, see StreamAll
+	After  *IssueCursor
+	SortBy string
+	Limit  int
+	Offset int
+	// Fields selects the fields of the issues loaded by their JSON names, see
+	// IssueFields. All the fields are loaded when empty, the ID always is.
+	Fields []string
+	// SkipTotal skips counting the issues matching, FindAll returning a total
+	// of -1, and one more issue than the limit when there's a next page
+	SkipTotal bool
+}
+
+// IssueCursor is the position of an issue in the lists of issues, the most
+// recently detected first, see IssueQueryFilters.After
+type IssueCursor struct {
+	DetectedAt time.Time
+	ID         string
+}
+
+// IssueFields maps the fields of issues FindAll can select to their column,
+// empty for the associations, which are loaded with a query of their own
+var IssueFields = map[string]string{
+	"id":               "id",
+	"title":            "title",
+	"description":      "description",
+	"details":          "details",
+	"severity":         "severity",
+	"priority":         "priority",
+	"issueType":        "issue_type",
+	"state":            "state",
+	"detectedAt":       "detected_at",
+	"resolvedAt":       "resolved_at",
+	"namespace":        "namespace",
+	"assignee":         "assignee",
+	"resolutionReason": "resolution_reason",
+	"resolvedBy":       "resolved_by",
+	"acknowledgedAt":   "acknowledged_at",
+	"acknowledgedBy":   "acknowledged_by",
+	"snoozedUntil":     "snoozed_until",
+	"snoozedBy":        "snoozed_by",
+	"reopenCount":      "reopen_count",
+	"reopenedAt":       "reopened_at",
+	"fingerprint":      "fingerprint",
+	"scopeId":          "scope_id",
+	"createdAt":        "created_at",
+	"updatedAt":        "updated_at",
+	"scope":            "",
+	"links":            "",
+	"labels":           "",
+	"externalRefs":     "",
+	"relatedCount":     "",
 }
 
+// Orders in which FindAll can return issues
+const (
+	// SortByDetectedAt returns the most recently detected issues first
+	SortByDetectedAt = "detectedAt"
+	// SortByPriority returns the most urgent issues first, followed by issues without a priority
+	SortByPriority = "priority"
+)
+
+// ValidSortBy lists the supported values of IssueQueryFilters.SortBy
+var ValidSortBy = []string{SortByDetectedAt, SortByPriority}
+
 // FindAll finds any issues matching the query filters passed.
+// Issues must have every label in filters.Labels to match.
+// The related issues aren't loaded, only counted in Issue.RelatedCount; see
+// FindByID for them.
 //
 // Parameters:
 //   - ctx: Context for cancellations and timeouts
@@ -204,35 +346,238 @@ type IssueQueryFilters struct {
 //
 // Returns:
 //   - []models.Issue: All issues found that match the filter query
-//   - int64: The number of issues found
+//   - int64: The number of issues found, -1 when filters.SkipTotal is set
 //   - error: Database error or nil
 func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters) ([]models.Issue, int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	var issues []models.Issue
 	var total int64
 
 	// Build base query
-	// Preload any associations
-	query := i.db.WithContext(ctx).Model(&models.Issue{}).
-		Preload("Scope").
-		Preload("Links").
-		Preload("RelatedFrom.Target.Scope").
-		Preload("RelatedTo.Source.Scope")
+	// Preload any associations but the related issues, which lists only count.
+	// Loading their graph, with the scopes of the issues on the other side,
+	// took most of the time of listing issues.
+	selected := func(field string) bool {
+		return len(filters.Fields) == 0 || slices.Contains(filters.Fields, field)
+	}
+	query := i.db.WithContext(ctx).Model(&models.Issue{})
+	if selected("scope") {
+		query = query.Preload("Scope")
+	}
+	if selected("links") {
+		query = query.Preload("Links")
+	}
+	if selected("labels") {
+		query = query.Preload("Labels", orderLabels)
+	}
+	if selected("externalRefs") {
+		query = query.Preload("ExternalRefs")
+	}
+
+	query = applyIssueFilters(query, filters)
+
+	// Get total count for pagination
+	if filters.SkipTotal {
+		total = -1
+	} else if err := query.Count(&total).Error; err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to count issues")
+		return nil, 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+
+	// Apply pagination and ordering
+	if filters.Limit == 0 {
+		filters.Limit = 50
+	}
+	limit := filters.Limit
+	if filters.SkipTotal {
+		// The extra issue tells there's a next page
+		limit++
+	}
+
+	if filters.SortBy == SortByPriority {
+		// Priorities sort alphabetically, issues without one come last
+		query = query.Order("CASE WHEN priority IS NULL OR priority = '' THEN 1 ELSE 0 END").
+			Order("priority ASC")
+	}
+
+	if len(filters.Fields) > 0 {
+		query = query.Select(selectIssueColumns(filters.Fields))
+	}
+
+	// Issues detected at the same time are ordered by ID, for the cursor of
+	// the next page to follow the order of the first one
+	query = query.Order("detected_at DESC").Order("issues.id DESC")
+	if err := query.
+		Offset(filters.Offset).
+		Limit(limit).
+		Find(&issues).
+		Error; err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to find issues")
+		return nil, 0, fmt.Errorf("failed to find issues: %w", err)
+	}
+	if selected("relatedCount") {
+		if err := i.countRelated(ctx, issues); err != nil {
+			logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to count related issues")
+			return nil, 0, fmt.Errorf("failed to count related issues: %w", err)
+		}
+	}
 
-	// Apply filters to the database query
+	return issues, total, nil
+}
+
+// selectIssueColumns returns the columns of the issues table selecting fields,
+// see IssueFields. The ID is always selected, and the scope ID along with the
+// scope, to load the associations.
+func selectIssueColumns(fields []string) []string {
+	columns := []string{"issues.id"}
+	for _, field := range fields {
+		column := IssueFields[field]
+		if field == "scope" {
+			column = "scope_id"
+		}
+		if column != "" && !slices.Contains(columns, "issues."+column) {
+			columns = append(columns, "issues."+column)
+		}
+	}
+	return columns
+}
+
+// countRelated sets the RelatedCount of issues, in a single query
+func (i *issueRepository) countRelated(ctx context.Context, issues []models.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for n, issue := range issues {
+		ids[n] = issue.ID
+	}
+
+	var counts []struct {
+		IssueID string
+		Count   int64
+	}
+	err := i.db.WithContext(ctx).Raw(`SELECT issue_id, COUNT(*) AS count FROM (
+		SELECT source_id AS issue_id FROM related_issues WHERE source_id IN ?
+		UNION ALL
+		SELECT target_id AS issue_id FROM related_issues WHERE target_id IN ?
+	) AS related GROUP BY issue_id`, ids, ids).Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		byID[count.IssueID] = count.Count
+	}
+	for n := range issues {
+		issues[n].RelatedCount = byID[issues[n].ID]
+	}
+	return nil
+}
+
+// FindAllPages finds all the issues matching the query filters, one page of
+// pageSize issues at a time. filters.Limit and filters.Offset are ignored.
+// Meant for background jobs, which would shift the pages updating issues
+// while going through them.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - repo: The issue repository
+//   - filters: IssueQueryFilters used for querying and filtering
+//   - pageSize: How many issues are loaded per query
+//
+// Returns:
+//   - []models.Issue: All issues found that match the filter query
+//   - error: Database error or nil
+func FindAllPages(ctx context.Context, repo IssueRepository, filters IssueQueryFilters, pageSize int) ([]models.Issue, error) {
+	filters.Limit = pageSize
+	filters.Offset = 0
+	filters.SkipTotal = true
+
+	var issues []models.Issue
+	for {
+		page, _, err := repo.FindAll(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) <= pageSize {
+			return append(issues, page...), nil
+		}
+		issues = append(issues, page[:pageSize]...)
+		filters.Offset += pageSize
+	}
+}
+
+// StreamAll finds all the issues matching the query filters, the most recently
+// detected first, passing them to fn one batch of batchSize issues at a time.
+// Each batch is loaded once fn returned for the previous one, continuing from
+// its last issue rather than from an offset, so that the queries of the last
+// batches of large namespaces are as fast as the first one.
+// filters.Limit, filters.Offset and filters.SortBy are ignored.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - repo: The issue repository
+//   - filters: IssueQueryFilters used for querying and filtering
+//   - batchSize: How many issues are loaded per query
+//   - fn: Called with each batch, stopping the stream when it fails
+//
+// Returns:
+//   - error: Database error, the error of fn, or nil
+func StreamAll(ctx context.Context, repo IssueRepository, filters IssueQueryFilters, batchSize int, fn func(issues []models.Issue) error) error {
+	filters.Limit = batchSize
+	filters.Offset = 0
+	filters.SortBy = ""
+	filters.SkipTotal = true
+	if len(filters.Fields) > 0 && !slices.Contains(filters.Fields, "detectedAt") {
+		// The cursor is made of the detection time and ID of the last issue
+		filters.Fields = append(slices.Clone(filters.Fields), "detectedAt")
+	}
+
+	for {
+		batch, _, err := repo.FindAll(ctx, filters)
+		if err != nil {
+			return err
+		}
+		more := len(batch) > batchSize
+		batch = batch[:min(len(batch), batchSize)]
+		if len(batch) > 0 {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+		if !more {
+			return nil
+		}
+		last := batch[len(batch)-1]
+		filters.After = &IssueCursor{DetectedAt: last.DetectedAt, ID: last.ID}
+	}
+}
+
+// applyIssueFilters adds the WHERE clauses selecting the issues matching filters
+func applyIssueFilters(query *gorm.DB, filters IssueQueryFilters) *gorm.DB {
 	if filters.Namespace != "" {
 		query = query.Where("namespace = ?", filters.Namespace)
 	}
 	if filters.Severity != nil {
 		query = query.Where("severity = ?", *filters.Severity)
 	}
+	if filters.Priority != nil {
+		query = query.Where("priority = ?", *filters.Priority)
+	}
 	if filters.IssueType != nil {
 		query = query.Where("issue_type = ?", *filters.IssueType)
 	}
 	if filters.State != nil {
 		query = query.Where("state = ?", *filters.State)
 	}
+	if filters.Unresolved {
+		query = query.Where("state <> ?", models.IssueStateResolved)
+	}
 	// Join issue_scopes once if any scope-related filter is present, then stack WHEREs
-	if filters.ResourceType != "" || filters.ResourceName != "" {
+	if filters.ResourceType != "" || filters.ResourceName != "" || filters.ResourceNamespace != "" {
 		query = query.Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id")
 		if filters.ResourceType != "" {
 			query = query.Where("issue_scopes.resource_type = ?", filters.ResourceType)
@@ -240,6 +585,66 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 		if filters.ResourceName != "" {
 			query = query.Where("issue_scopes.resource_name = ?", filters.ResourceName)
 		}
+		if filters.ResourceNamespace != "" {
+			query = query.Where("issue_scopes.resource_namespace = ?", filters.ResourceNamespace)
+		}
+	}
+	if filters.Assignee != "" {
+		query = query.Where("assignee = ?", filters.Assignee)
+	}
+	if filters.Fingerprint != "" {
+		query = query.Where("issues.fingerprint = ?", filters.Fingerprint)
+	}
+	for key, value := range filters.Labels {
+		query = query.Where("EXISTS (SELECT 1 FROM labels WHERE labels.issue_id = issues.id AND labels.key = ? AND labels.value = ?)", key, value)
+	}
+	if filters.ResolvedSince != nil {
+		query = query.Where("resolved_at >= ?", *filters.ResolvedSince)
+	}
+	if filters.ResolvedBefore != nil {
+		query = query.Where("resolved_at < ?", *filters.ResolvedBefore)
+	}
+	if filters.DetectedSince != nil {
+		query = query.Where("detected_at >= ?", *filters.DetectedSince)
+	}
+	if filters.DetectedBefore != nil {
+		query = query.Where("detected_at < ?", *filters.DetectedBefore)
+	}
+	if filters.ReopenedSince != nil {
+		query = query.Where("reopened_at >= ?", *filters.ReopenedSince)
+	}
+	if filters.CreatedSince != nil {
+		query = query.Where("issues.created_at >= ?", *filters.CreatedSince)
+	}
+	if filters.After != nil {
+		query = query.Where("(issues.detected_at < ? OR (issues.detected_at = ? AND issues.id < ?))",
+			filters.After.DetectedAt, filters.After.DetectedAt, filters.After.ID)
+	}
+	if filters.CreatedBefore != nil {
+		query = query.Where("issues.created_at < ?", *filters.CreatedBefore)
+	}
+	if filters.HasExternalRef != nil {
+		hasExternalRef := "EXISTS (SELECT 1 FROM external_refs WHERE external_refs.issue_id = issues.id)"
+		if *filters.HasExternalRef {
+			query = query.Where(hasExternalRef)
+		} else {
+			query = query.Where("NOT " + hasExternalRef)
+		}
+	}
+	if filters.Acknowledged != nil {
+		if *filters.Acknowledged {
+			query = query.Where("acknowledged_at IS NOT NULL")
+		} else {
+			query = query.Where("acknowledged_at IS NULL")
+		}
+	}
+	if filters.Snoozed != nil {
+		snoozed := "(issues.snoozed_until > ? AND issues.state <> ?)"
+		if *filters.Snoozed {
+			query = query.Where(snoozed, time.Now(), models.IssueStateResolved)
+		} else {
+			query = query.Where("(issues.snoozed_until IS NULL OR NOT "+snoozed+")", time.Now(), models.IssueStateResolved)
+		}
 	}
 	if filters.Search != "" {
 		searchPattern := "%" + filters.Search + "%"
@@ -247,28 +652,7 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 		// Use LOWER to prevent any case sensitivity issues
 		query = query.Where("LOWER(title) LIKE LOWER(?) OR LOWER(description) LIKE LOWER(?)", searchPattern, searchPattern)
 	}
-
-	// Get total count for pagination
-	if err := query.Count(&total).Error; err != nil {
-		i.logger.WithError(err).Error("Failed to count issues")
-		return nil, 0, fmt.Errorf("failed to count issues: %w", err)
-	}
-
-	// Apply pagination and ordering
-	if filters.Limit == 0 {
-		filters.Limit = 50
-	}
-
-	if err := query.Order("detected_at DESC").
-		Offset(filters.Offset).
-		Limit(filters.Limit).
-		Find(&issues).
-		Error; err != nil {
-		i.logger.WithError(err).Error("Failed to find issues")
-		return nil, 0, fmt.Errorf("failed to find issues: %w", err)
-	}
-
-	return issues, total, nil
+	return query
 }
 
 // FindByID finds an issue using its ID.
@@ -281,12 +665,18 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 //   - *models.Issue: The issue if found, nil if not
 //   - error: Database error or nil
 func (i *issueRepository) FindByID(ctx context.Context, id string) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	var issue models.Issue
 
 	// Find issue, load associations
 	err := i.db.
 		WithContext(ctx).
 		Preload("Scope").
+		Preload("Links").
+		Preload("Labels", orderLabels).
+		Preload("ExternalRefs").
 		Preload("RelatedFrom.Target.Scope").
 		Preload("RelatedTo.Source.Scope").
 		First(&issue, "id = ?", id).Error
@@ -296,12 +686,92 @@ func (i *issueRepository) FindByID(ctx context.Context, id string) (*models.Issu
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		i.logger.WithError(err).WithField("issue_id", id).Error("failed to find issue by ID")
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("issue_id", id).Error("failed to find issue by ID")
 		return nil, fmt.Errorf("failed to find issue: %w", err)
 	}
 	return &issue, nil
 }
 
+// FindOption selects the associations loaded by FindByIDs
+type FindOption func(*findOptions)
+
+type findOptions struct {
+	links   bool
+	labels  bool
+	related bool
+}
+
+// WithLinks loads the links of the issues
+func WithLinks() FindOption {
+	return func(o *findOptions) { o.links = true }
+}
+
+// WithLabels loads the labels of the issues
+func WithLabels() FindOption {
+	return func(o *findOptions) { o.labels = true }
+}
+
+// WithRelated loads the related issues of the issues, along with their scopes
+func WithRelated() FindOption {
+	return func(o *findOptions) { o.related = true }
+}
+
+// FindByIDs finds several issues using their IDs in a single query per
+// loaded association. Only the scope is loaded unless other associations
+// are selected with options.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - ids: The IDs of the issues to be found
+//   - opts: The associations to load
+//
+// Returns:
+//   - []models.Issue: The issues found, in the order of ids. IDs without an issue are skipped.
+//   - error: Database error or nil
+func (i *issueRepository) FindByIDs(ctx context.Context, ids []string, opts ...FindOption) ([]models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var options findOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	query := i.db.WithContext(ctx).Preload("Scope")
+	if options.links {
+		query = query.Preload("Links")
+	}
+	if options.labels {
+		query = query.Preload("Labels", orderLabels)
+	}
+	if options.related {
+		query = query.Preload("RelatedFrom.Target.Scope").Preload("RelatedTo.Source.Scope")
+	}
+
+	var found []models.Issue
+	if err := query.Where("id IN ?", ids).Find(&found).Error; err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("count", len(ids)).Error("failed to find issues by ID")
+		return nil, fmt.Errorf("failed to find issues: %w", err)
+	}
+
+	byID := make(map[string]models.Issue, len(found))
+	for _, issue := range found {
+		byID[issue.ID] = issue
+	}
+	issues := make([]models.Issue, 0, len(found))
+	for _, id := range ids {
+		if issue, ok := byID[id]; ok {
+			issues = append(issues, issue)
+			delete(byID, id)
+		}
+	}
+	return issues, nil
+}
+
 // Create creates an Issue record and automatically updates an existing duplicate.
 // if one is found instead of creating a new issue.
 //
@@ -317,9 +787,13 @@ func (i *issueRepository) FindByID(ctx context.Context, id string) (*models.Issu
 //   - *models.Issue: The created issue
 //   - error: Database error or nil
 func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	var issue *models.Issue
 	// Check if the issue is being updated.
 	updatedIssue := false
+	var outcome metrics.DedupOutcome
 	// check for duplicates before creating.
 	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		existingIssue, err := i.findDuplicateInTx(tx, req)
@@ -334,21 +808,27 @@ func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*mo
 				Title:       req.GetTitle(),
 				Description: req.GetDescription(),
 				Severity:    req.GetSeverity(),
+				Priority:    req.GetPriority(),
 				IssueType:   req.GetIssueType(),
 				Scope:       req.GetScope().AsOptional(),
 				Namespace:   req.GetNamespace(),
 				State:       req.GetState(),
+				Labels:      req.GetLabels(),
+				Assignee:    req.GetAssignee(),
 			}
 			issue = existingIssue
+			outcome = dedupOutcome(existingIssue, req, false)
 			return i.updateIssueInTx(tx, existingIssue, updateReq)
 		}
 
-		newIssue, err := i.createNewIssueInTx(tx, req)
+		newIssue, created, err := i.createNewIssueInTx(tx, req)
 		if err != nil {
 			return err
 		}
 
 		issue = newIssue
+		updatedIssue = !created
+		outcome = dedupOutcome(nil, req, created)
 		return nil
 	})
 
@@ -357,32 +837,77 @@ func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*mo
 	}
 
 	if issue == nil {
-		i.logger.WithField("request", req).Error("Failed to create an issue: no issue returned")
+		logging.FromContext(ctx, i.logger).WithField("request", req).Error("Failed to create an issue: no issue returned")
 		return nil, errors.New("issue creation failed: no issue returned")
 	}
+	i.countReport(req.GetIssueType(), outcome)
 
 	if updatedIssue {
-		i.logger.WithField("issue_id", issue.ID).Info("Existing issue has been updated")
+		logging.FromContext(ctx, i.logger).WithField("issue_id", issue.ID).Info("Existing issue has been updated")
 		// Reload with associations
 		return i.FindByID(ctx, issue.ID)
 	}
 
-	i.logger.WithField("issue_id", issue.ID).Info("Created new issue")
+	logging.FromContext(ctx, i.logger).WithField("issue_id", issue.ID).Info("Created new issue")
 	// Reload with associations
 	return i.FindByID(ctx, issue.ID)
 }
 
+// dedupOutcome tells what reporting an issue did
+//
+// Parameters:
+//   - existingIssue: The duplicate of the issue found by findDuplicateInTx, nil if none
+//   - req: The issue payload reported
+//   - created: Whether createNewIssueInTx created a new issue, when there was no duplicate
+//
+// Returns:
+//   - metrics.DedupOutcome
+func dedupOutcome(existingIssue *models.Issue, req dto.IssuePayload, created bool) metrics.DedupOutcome {
+	if existingIssue == nil {
+		if created {
+			return metrics.DedupCreated
+		}
+		// A concurrent transaction created the issue first
+		return metrics.DedupMerged
+	}
+	// See updateIssueInTx
+	if req.GetState() != "" && req.GetState().Open() && existingIssue.State == models.IssueStateResolved {
+		return metrics.DedupReopened
+	}
+	return metrics.DedupMerged
+}
+
+// countReport counts an issue report in metrics.IssueReports, once the unit of
+// work of the repository commits if any
+func (i *issueRepository) countReport(issueType models.IssueType, outcome metrics.DedupOutcome) {
+	if i.deferred != nil {
+		i.deferred.Inc(metrics.IssueReports, string(issueType), string(outcome))
+		return
+	}
+	metrics.IssueReports.Inc(string(issueType), string(outcome))
+}
+
 // createNewIssueInTx creates an issue within a database transaction.
 //
+// The issue is inserted with an upsert on the partial unique index allowing a
+// single open issue per namespace, issue type and scope or fingerprint, see IssueState.Open. When a concurrent
+// transaction created the same issue after findDuplicateInTx ran, that issue
+// is updated with the payload instead of creating a duplicate.
+//
 // Parameters:
 //   - tx: The database transaction to execute within
 //   - req: The issue payload for creating the issue
 //
 // Returns:
-//   - *models.Issue: The created issue, nil if not created
+//   - *models.Issue: The created or updated issue, nil on error
+//   - bool: Whether a new issue was created
 //   - error: Database error or nil
-func (i *issueRepository) createNewIssueInTx(tx *gorm.DB, req dto.IssuePayload) (*models.Issue, error) {
-	now := time.Now()
+func (i *issueRepository) createNewIssueInTx(tx *gorm.DB, req dto.IssuePayload) (*models.Issue, bool, error) {
+	// Reporters may know when the issue actually happened, e.g. when retrying
+	detectedAt := req.GetDetectedAt()
+	if detectedAt.IsZero() {
+		detectedAt = time.Now()
+	}
 	state := req.GetState()
 	if state == "" {
 		state = models.IssueStateActive
@@ -393,34 +918,86 @@ func (i *issueRepository) createNewIssueInTx(tx *gorm.DB, req dto.IssuePayload)
 		resourceNamespace = req.GetNamespace()
 	}
 
+	scope := models.IssueScope{
+		ResourceType:      req.GetScope().GetResourceType(),
+		ResourceName:      req.GetScope().GetResourceName(),
+		ResourceNamespace: resourceNamespace,
+	}
+	if err := tx.Create(&scope).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to create issue scope: %w", err)
+	}
+
 	newIssue := &models.Issue{
 		Title:       req.GetTitle(),
 		Description: req.GetDescription(),
+		Details:     req.GetDetails(),
 		Severity:    req.GetSeverity(),
+		Priority:    req.GetPriority(),
 		IssueType:   req.GetIssueType(),
 		State:       state,
-		DetectedAt:  now,
+		DetectedAt:  detectedAt,
 		Namespace:   req.GetNamespace(),
-		Scope: models.IssueScope{
-			ResourceType:      req.GetScope().GetResourceType(),
-			ResourceName:      req.GetScope().GetResourceName(),
-			ResourceNamespace: resourceNamespace,
-		},
+		Assignee:    req.GetAssignee(),
+		Fingerprint: req.GetFingerprint(),
+		DedupKey:    models.ScopeDedupKey(scope.ResourceType, scope.ResourceName),
+		ScopeID:     scope.ID,
+	}
+	if newIssue.Fingerprint != "" {
+		newIssue.DedupKey = models.FingerprintDedupKey(newIssue.Fingerprint)
 	}
 
-	// Convert links
-	for _, linkReq := range req.GetLinks() {
-		newIssue.Links = append(newIssue.Links, models.Link{
-			Title: linkReq.Title,
-			URL:   linkReq.URL,
-		})
+	// Only the issue columns are upserted, associations are created once the
+	// issue that was actually written is known.
+	err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "namespace"}, {Name: "issue_type"}, {Name: "dedup_key"}},
+		// Must match the predicate of the partial index for it to be used as the conflict target
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "state <> 'RESOLVED'"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"title", "description", "details", "severity", "updated_at"}),
+	}).Create(newIssue).Error
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	if state.Open() {
+		var activeIssue models.Issue
+		err := tx.Where("namespace = ? AND issue_type = ? AND dedup_key = ? AND state <> ?",
+			newIssue.Namespace, newIssue.IssueType, newIssue.DedupKey, models.IssueStateResolved).
+			First(&activeIssue).Error
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find upserted issue: %w", err)
+		}
+
+		// A concurrent request created the issue first, the upsert updated it instead
+		if activeIssue.ID != newIssue.ID {
+			logging.FromContext(tx.Statement.Context, i.logger).WithField("issue_id", activeIssue.ID).Info("Issue was created concurrently, updating it")
+			if err := tx.Delete(&models.IssueScope{}, "id = ?", scope.ID).Error; err != nil {
+				return nil, false, fmt.Errorf("failed to delete unused issue scope: %w", err)
+			}
+			if err := i.updateIssueInTx(tx, &activeIssue, req); err != nil {
+				return nil, false, err
+			}
+			return &activeIssue, false, nil
+		}
+	}
+
+	// Create links
+	if err := i.links.WithTx(tx).CreateBatch(tx.Statement.Context, newIssue.ID, linksFromRequest(req.GetLinks())); err != nil {
+		return nil, false, err
 	}
 
-	if err := tx.Create(&newIssue).Error; err != nil {
-		return nil, fmt.Errorf("failed to create issue: %w", err)
+	// Create labels
+	for key, value := range req.GetLabels() {
+		label := models.Label{
+			Key:     key,
+			Value:   value,
+			IssueID: newIssue.ID,
+		}
+		if err := tx.Create(&label).Error; err != nil {
+			return nil, false, fmt.Errorf("failed to create label: %w", err)
+		}
 	}
 
-	return newIssue, nil
+	return newIssue, true, nil
 }
 
 // Update performs an update operation on an existing issue record.
@@ -434,13 +1011,16 @@ func (i *issueRepository) createNewIssueInTx(tx *gorm.DB, req dto.IssuePayload)
 //   - *models.Issue: The updated issue or nil
 //   - error: Database error or nil
 func (i *issueRepository) Update(ctx context.Context, id string, req dto.IssuePayload) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	// Find existing issue
 	existingIssue, err := i.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if existingIssue == nil {
-		return nil, fmt.Errorf("issue with ID %s not found", id)
+		return nil, NotFoundError("issue not found")
 	}
 
 	err = i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -448,13 +1028,21 @@ func (i *issueRepository) Update(ctx context.Context, id string, req dto.IssuePa
 	})
 
 	if err != nil {
-		i.logger.WithError(err).WithField("issue_id", id).Error("Failed to update issue")
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("issue_id", id).Error("Failed to update issue")
 		return nil, err
 	}
 
-	i.logger.WithField("issue_id", id).Info("Updated issue")
+	logging.FromContext(ctx, i.logger).WithField("issue_id", id).Info("Updated issue")
 
-	return i.FindByID(ctx, id)
+	issue, err := i.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		// Deleted right after the update
+		return nil, NotFoundError("issue not found")
+	}
+	return issue, nil
 }
 
 // updateIssueInTx updates an issue within a database transaction.
@@ -476,19 +1064,39 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 	if desc := req.GetDescription(); desc != "" {
 		updates["description"] = desc
 	}
+	if details := req.GetDetails(); details != "" {
+		updates["details"] = details
+	}
 	if severity := req.GetSeverity(); severity != "" {
 		updates["severity"] = severity
 	}
+	if priority := req.GetPriority(); priority != "" {
+		updates["priority"] = priority
+	}
 	if issueType := req.GetIssueType(); issueType != "" {
 		updates["issue_type"] = issueType
 	}
 	if namespace := req.GetNamespace(); namespace != "" {
 		updates["namespace"] = namespace
 	}
+	if assignee := req.GetAssignee(); assignee != "" {
+		updates["assignee"] = assignee
+	}
 
 	// Always update the timestamp
 	updates["updated_at"] = time.Now()
 
+	// Moving a resolved issue to an open state reopens it, to be acknowledged
+	// again, and wakes it
+	if req.GetState() != "" && req.GetState().Open() && existingIssue.State == models.IssueStateResolved {
+		updates["reopen_count"] = gorm.Expr("reopen_count + 1")
+		updates["reopened_at"] = time.Now()
+		updates["acknowledged_at"] = nil
+		updates["acknowledged_by"] = ""
+		updates["snoozed_until"] = nil
+		updates["snoozed_by"] = ""
+	}
+
 	if req.GetState() != "" {
 		updates["state"] = req.GetState()
 		if req.GetState() == models.IssueStateResolved && existingIssue.State != models.IssueStateResolved {
@@ -496,11 +1104,30 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 		} else if ra := req.GetResolvedAt(); !ra.IsZero() {
 			updates["resolved_at"] = ra
 		}
+
+		// Resolving replaces the resolution, so the reason of a previous resolution
+		// isn't kept. Resolved issues only have the fields provided updated.
+		resolution := req.GetResolution()
+		if req.GetState() == models.IssueStateResolved && existingIssue.State != models.IssueStateResolved {
+			updates["resolution_reason"] = resolution.Reason
+			updates["resolved_by"] = resolution.ResolvedBy
+		} else if req.GetState() == models.IssueStateResolved {
+			if resolution.Reason != "" {
+				updates["resolution_reason"] = resolution.Reason
+			}
+			if resolution.ResolvedBy != "" {
+				updates["resolved_by"] = resolution.ResolvedBy
+			}
+		}
 	}
 
-	// Update the issue
-	if err := tx.Model(existingIssue).Updates(updates).Error; err != nil {
-		return fmt.Errorf("failed to update issue: %w", err)
+	// Update the issue, unless it was deleted since it was found
+	result := tx.Model(existingIssue).Omit(clause.Associations).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update issue: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError("issue not found")
 	}
 
 	// Handle link updates if provided
@@ -509,7 +1136,16 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 		if err != nil {
 			return fmt.Errorf("failed to replace links for issue: %w", err)
 		}
-		i.logger.WithField("issue_id", existingIssue.ID).Info("Updated links")
+		logging.FromContext(tx.Statement.Context, i.logger).WithField("issue_id", existingIssue.ID).Info("Updated links")
+	}
+
+	// Handle label updates if provided. An empty, non-nil map removes all labels.
+	if labels := req.GetLabels(); labels != nil {
+		err := i.replaceIssueLabels(tx, existingIssue.ID, labels)
+		if err != nil {
+			return fmt.Errorf("failed to replace labels for issue: %w", err)
+		}
+		logging.FromContext(tx.Statement.Context, i.logger).WithField("issue_id", existingIssue.ID).Info("Updated labels")
 	}
 
 	// Get scope data, make sure it's not empty
@@ -517,10 +1153,23 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 		err := i.updateIssueScopeInTx(tx, existingIssue.ScopeID, scope.AsOptional())
 
 		if err != nil {
-			i.logger.WithField("scopeID", existingIssue.ScopeID).Error("failed to update issue scope")
+			logging.FromContext(tx.Statement.Context, i.logger).WithField("scopeID", existingIssue.ScopeID).Error("failed to update issue scope")
 			return err
 		}
-		i.logger.WithField("issue_id", existingIssue.ID).Info("Updated scope")
+
+		// Keep the deduplication key in sync with the scope, unless the issue
+		// is grouped by its fingerprint
+		if existingIssue.Fingerprint == "" {
+			var updatedScope models.IssueScope
+			if err := tx.First(&updatedScope, "id = ?", existingIssue.ScopeID).Error; err != nil {
+				return fmt.Errorf("failed to reload issue scope: %w", err)
+			}
+			dedupKey := models.ScopeDedupKey(updatedScope.ResourceType, updatedScope.ResourceName)
+			if err := tx.Model(existingIssue).Omit(clause.Associations).Update("dedup_key", dedupKey).Error; err != nil {
+				return fmt.Errorf("failed to update issue deduplication key: %w", err)
+			}
+		}
+		logging.FromContext(tx.Statement.Context, i.logger).WithField("issue_id", existingIssue.ID).Info("Updated scope")
 	}
 
 	return nil
@@ -536,25 +1185,64 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 // Returns:
 //   - error: Database error or nil
 func (i *issueRepository) replaceIssueLinks(tx *gorm.DB, issueID string, links []dto.CreateLinkRequest) error {
-	// Delete old links
-	if err := tx.Where("issue_id = ?", issueID).Delete(&models.Link{}).Error; err != nil {
-		return fmt.Errorf("failed to delete old links: %w", err)
+	linkRepo := i.links.WithTx(tx)
+	if err := linkRepo.DeleteByIssueID(tx.Statement.Context, issueID); err != nil {
+		return err
 	}
+	return linkRepo.CreateBatch(tx.Statement.Context, issueID, linksFromRequest(links))
+}
 
-	// Create new links
+// linksFromRequest converts the links of a request to models
+func linksFromRequest(links []dto.CreateLinkRequest) []models.Link {
+	result := make([]models.Link, 0, len(links))
 	for _, linkReq := range links {
-		link := models.Link{
-			Title:   linkReq.Title,
-			URL:     linkReq.URL,
+		result = append(result, models.Link{
+			Title: linkReq.Title,
+			URL:   linkReq.URL,
+		})
+	}
+	return result
+}
+
+// replaceIssueLabels updates the labels for an issue within a database transaction.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the issue
+//   - labels: The new key/value labels of the issue
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) replaceIssueLabels(tx *gorm.DB, issueID string, labels map[string]string) error {
+	// Delete old labels
+	if err := tx.Where("issue_id = ?", issueID).Delete(&models.Label{}).Error; err != nil {
+		return fmt.Errorf("failed to delete old labels: %w", err)
+	}
+
+	// Create new labels
+	for key, value := range labels {
+		label := models.Label{
+			Key:     key,
+			Value:   value,
 			IssueID: issueID,
 		}
-		if err := tx.Create(&link).Error; err != nil {
-			return fmt.Errorf("failed to create link: %w", err)
+		if err := tx.Create(&label).Error; err != nil {
+			return fmt.Errorf("failed to create label: %w", err)
 		}
 	}
 	return nil
 }
 
+// containsIssue reports whether issues contains the issue with the given ID
+func containsIssue(issues []models.Issue, id string) bool {
+	return slices.ContainsFunc(issues, func(issue models.Issue) bool { return issue.ID == id })
+}
+
+// orderLabels sorts preloaded labels by key so they're returned in a stable order
+func orderLabels(db *gorm.DB) *gorm.DB {
+	return db.Order("key")
+}
+
 // updateIssueScopeInTx updates the scope for an issue within a database transaction
 //
 // Parameters:
@@ -569,13 +1257,17 @@ func (i *issueRepository) updateIssueScopeInTx(tx *gorm.DB, scopeID string, req
 		Where("id = ?", scopeID).
 		Updates(req).Error
 	if err != nil {
-		return fmt.Errorf("failed to update issue scope")
+		return fmt.Errorf("failed to update issue scope: %w", err)
 	}
 	return nil
 }
 
 // Delete will delete an issue record.
 //
+// The issue is deleted by deleting its scope. The foreign keys cascade the
+// delete to the issue, and from the issue to its links, labels and related
+// issue relationships.
+//
 // Parameters:
 //   - ctx: Context for cancellations and timeouts
 //   - id: ID of the issue
@@ -583,46 +1275,21 @@ func (i *issueRepository) updateIssueScopeInTx(tx *gorm.DB, scopeID string, req
 // Returns:
 //   - error: Database error or nil
 func (i *issueRepository) Delete(ctx context.Context, id string) error {
-	// Find the issue to get scope ID
-	issue, err := i.FindByID(ctx, id)
-	if err != nil {
-		return err
-	}
-	if issue == nil {
-		return fmt.Errorf("issue with ID %s not found", id)
-	}
-
-	// Delete in transaction so we have control of the order
-	err = i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Delete related issue relationships first using issue id
-		if err := tx.Where("source_id = ? OR target_id = ?", id, id).Delete(&models.RelatedIssue{}).Error; err != nil {
-			return fmt.Errorf("failed to delete related issues: %w", err)
-		}
-
-		// Delete links by issue id
-		if err := tx.Where("issue_id = ?", id).Delete(&models.Link{}).Error; err != nil {
-			return fmt.Errorf("failed to delete links: %w", err)
-		}
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
 
-		// Delete the issue by id
-		if err := tx.Delete(&models.Issue{}, "id = ?", id).Error; err != nil {
-			return fmt.Errorf("failed to delete issue: %w", err)
-		}
-
-		// Delete the issue scope by scope id
-		if err := tx.Delete(&models.IssueScope{}, "id = ?", issue.ScopeID).Error; err != nil {
-			return fmt.Errorf("failed to delete issue scope: %w", err)
-		}
-
-		return nil
-	})
+	scopeID := i.db.Model(&models.Issue{}).Select("scope_id").Where("id = ?", id)
+	result := i.db.WithContext(ctx).Where("id = (?)", scopeID).Delete(&models.IssueScope{})
 
-	if err != nil {
-		i.logger.WithError(err).WithField("issue_id", id).Error("failed to delete issue")
-		return err
+	if result.Error != nil {
+		logging.FromContext(ctx, i.logger).WithError(result.Error).WithField("issue_id", id).Error("failed to delete issue")
+		return fmt.Errorf("failed to delete issue: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError("issue not found")
 	}
 
-	i.logger.WithField("issue_id", id).Info("Deleted issue")
+	logging.FromContext(ctx, i.logger).WithField("issue_id", id).Info("Deleted issue")
 	return nil
 }
 
@@ -639,18 +1306,22 @@ func (i *issueRepository) Delete(ctx context.Context, id string) error {
 //   - resourceType: The type of resource
 //   - resourceName: The name of that resource
 //   - namespace: The namespace of that resource
+//   - resolution: Why and by whom the issues are resolved
 //
 // Returns:
 //   - int64: The number of issues resolved in that scope
 //   - error: Database errors or nil
-func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error) {
+func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string, resolution dto.Resolution) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	now := time.Now()
 
 	// Get the IDs of all issues meeting this criteria
 	var ids []string
 	query := i.db.WithContext(ctx).Model(&models.Issue{}).
 		Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
-		Where("issues.state = ? AND issues.namespace = ?", models.IssueStateActive, namespace).
+		Where("issues.state <> ? AND issues.namespace = ?", models.IssueStateResolved, namespace).
 		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ?", resourceType, resourceName).
 		Pluck("issues.id", &ids)
 
@@ -661,7 +1332,7 @@ func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, reso
 
 	// Check if any issues were found
 	if len(ids) == 0 {
-		i.logger.WithFields(logrus.Fields{
+		logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
 			"resource_type": resourceType,
 			"resource_name": resourceName,
 			"namespace":     namespace,
@@ -675,18 +1346,20 @@ func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, reso
 		Model(&models.Issue{}).
 		Where("id IN ?", ids).
 		Updates(map[string]any{
-			"state":       models.IssueStateResolved,
-			"resolved_at": &now,
-			"updated_at":  now,
+			"state":             models.IssueStateResolved,
+			"resolved_at":       &now,
+			"resolution_reason": resolution.Reason,
+			"resolved_by":       resolution.ResolvedBy,
+			"updated_at":        now,
 		})
 
 	if result.Error != nil {
-		i.logger.WithError(result.Error).Error("Failed to resolve issues by scope")
+		logging.FromContext(ctx, i.logger).WithError(result.Error).Error("Failed to resolve issues by scope")
 		return 0, fmt.Errorf("failed to resolve issues: %w", result.Error)
 	}
 
 	count := result.RowsAffected
-	i.logger.WithFields(logrus.Fields{
+	logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
 		"resource_type": resourceType,
 		"resource_name": resourceName,
 		"namespace":     namespace,
@@ -702,21 +1375,21 @@ func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, reso
 //   - ctx: Context for cancellations and timeouts
 //   - sourceID: The parent issue
 //   - targetID: The child issue
+//   - relationType: The type of the relationship, see models.RelationType
 //
 // Returns:
 //   - error: Database error or nil
-func (i *issueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetID string) error {
+func (i *issueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetID string, relationType models.RelationType) error {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	// Check if both issues exist
-	source, err := i.FindByID(ctx, sourceID)
-	if err != nil {
-		return err
-	}
-	target, err := i.FindByID(ctx, targetID)
+	issues, err := i.FindByIDs(ctx, []string{sourceID, targetID})
 	if err != nil {
 		return err
 	}
-	if source == nil || target == nil {
-		return errors.New("one or both issues not found")
+	if !containsIssue(issues, sourceID) || !containsIssue(issues, targetID) {
+		return NotFoundError("one or both issues not found")
 	}
 
 	// Check if relationship already exists
@@ -725,7 +1398,7 @@ func (i *issueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetI
 		sourceID, targetID, targetID, sourceID).First(&existingRelation).Error
 
 	if err == nil {
-		return errors.New("relationship already exists")
+		return ConflictError("relationship already exists")
 	}
 	// Check if we get any other error besides Record Not Found
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -736,16 +1409,18 @@ func (i *issueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetI
 	relation := models.RelatedIssue{
 		SourceID: sourceID,
 		TargetID: targetID,
+		Type:     relationType,
 	}
 
 	if err := i.db.WithContext(ctx).Create(&relation).Error; err != nil {
-		i.logger.WithError(err).Error("Failed to add related issue")
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to add related issue")
 		return fmt.Errorf("failed to create relationship: %w", err)
 	}
 
-	i.logger.WithFields(logrus.Fields{
+	logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
 		"source_id": sourceID,
 		"target_id": targetID,
+		"type":      relationType,
 	}).Info("Added related issue")
 	return nil
 }
@@ -760,22 +1435,221 @@ func (i *issueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetI
 // Returns:
 //   - error: Database error or nil
 func (i *issueRepository) RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
 	result := i.db.WithContext(ctx).Where("(source_id = ? AND target_id = ?) OR (source_id = ? AND target_id = ?)",
 		sourceID, targetID, targetID, sourceID).Delete(&models.RelatedIssue{})
 
 	if result.Error != nil {
-		i.logger.WithError(result.Error).Error("failed to remove related issue")
+		logging.FromContext(ctx, i.logger).WithError(result.Error).Error("failed to remove related issue")
 		return fmt.Errorf("failed to remove relationship: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("relationship not found")
+		return NotFoundError("relationship not found")
 	}
 
-	i.logger.WithFields(logrus.Fields{
+	logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
 		"source_id": sourceID,
 		"target_id": targetID,
 	}).Info("Removed related issue")
 
 	return nil
 }
+
+// FindActiveEffects finds the open issues caused by an issue, through
+// caused-by relationships, see models.IssueState.Open.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the issue causing the issues
+//
+// Returns:
+//   - []string: The IDs of the active issues caused by the issue, ordered
+//   - error: Database error or nil
+func (i *issueRepository) FindActiveEffects(ctx context.Context, id string) ([]string, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	var ids []string
+	err := i.db.WithContext(ctx).Model(&models.RelatedIssue{}).
+		Joins("JOIN issues ON issues.id = related_issues.source_id").
+		Where("related_issues.target_id = ? AND related_issues.type = ?", id, models.RelationTypeCausedBy).
+		Where("issues.state <> ?", models.IssueStateResolved).
+		Order("issues.id").
+		Pluck("issues.id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the issues caused by issue %s: %w", id, err)
+	}
+	return ids, nil
+}
+
+// AddExternalRef references an issue tracked in another system from an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - ref: The external reference, its IssueID is set to issueID
+//
+// Returns:
+//   - *models.ExternalRef: The external reference created
+//   - error: Database error or nil
+func (i *issueRepository) AddExternalRef(ctx context.Context, issueID string, ref models.ExternalRef) (*models.ExternalRef, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	issues, err := i.FindByIDs(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	if !containsIssue(issues, issueID) {
+		return nil, NotFoundError("issue not found")
+	}
+
+	// Check if the issue already references it
+	var existingRef models.ExternalRef
+	err = i.db.WithContext(ctx).Where("issue_id = ? AND system = ? AND key = ?", issueID, ref.System, ref.Key).
+		First(&existingRef).Error
+	if err == nil {
+		return nil, ConflictError("external reference already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing external reference: %w", err)
+	}
+
+	ref.IssueID = issueID
+	if err := i.db.WithContext(ctx).Omit(clause.Associations).Create(&ref).Error; err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("issue_id", issueID).Error("Failed to add external reference")
+		return nil, fmt.Errorf("failed to create external reference: %w", err)
+	}
+
+	logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
+		"issue_id": issueID,
+		"system":   ref.System,
+		"key":      ref.Key,
+	}).Info("Added external reference")
+	return &ref, nil
+}
+
+// RemoveExternalRef removes an external reference from an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - refID: The ID of the external reference
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) RemoveExternalRef(ctx context.Context, issueID, refID string) error {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	result := i.db.WithContext(ctx).Where("id = ? AND issue_id = ?", refID, issueID).Delete(&models.ExternalRef{})
+	if result.Error != nil {
+		logging.FromContext(ctx, i.logger).WithError(result.Error).Error("failed to remove external reference")
+		return fmt.Errorf("failed to remove external reference: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return NotFoundError("external reference not found")
+	}
+
+	logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
+		"issue_id": issueID,
+		"ref_id":   refID,
+	}).Info("Removed external reference")
+
+	return nil
+}
+
+// Acknowledge records that an open issue was acknowledged, unless it already
+// was. Issues acknowledged or resolved in the meantime are left unchanged.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the issue
+//   - acknowledgedBy: Who acknowledged the issue
+//   - state: The state to move the issue to, empty to keep its state
+//
+// Returns:
+//   - *models.Issue: The issue, nil if it doesn't exist
+//   - error: Database error or nil
+func (i *issueRepository) Acknowledge(ctx context.Context, id, acknowledgedBy string, state models.IssueState) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	updates := map[string]any{
+		"acknowledged_at": &now,
+		"acknowledged_by": acknowledgedBy,
+		"updated_at":      now,
+	}
+	if state != "" {
+		updates["state"] = state
+	}
+	result := i.db.WithContext(ctx).
+		Model(&models.Issue{}).
+		Where("id = ? AND acknowledged_at IS NULL AND state <> ?", id, models.IssueStateResolved).
+		Updates(updates)
+	if result.Error != nil {
+		logging.FromContext(ctx, i.logger).WithError(result.Error).WithField("issue_id", id).Error("Failed to acknowledge issue")
+		return nil, fmt.Errorf("failed to acknowledge issue: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
+			"issue_id":        id,
+			"acknowledged_by": acknowledgedBy,
+		}).Info("Acknowledged issue")
+	}
+
+	return i.FindByID(ctx, id)
+}
+
+// Snooze records until when an open issue is snoozed and by whom, or wakes it
+// when until is nil. Issues resolved in the meantime are left unchanged.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the issue
+//   - until: When the snooze ends, nil to wake the issue
+//   - snoozedBy: Who snoozed the issue, ignored when waking it
+//
+// Returns:
+//   - *models.Issue: The issue, nil if it doesn't exist
+//   - error: Database error or nil
+func (i *issueRepository) Snooze(ctx context.Context, id string, until *time.Time, snoozedBy string) (*models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	if until == nil {
+		snoozedBy = ""
+	}
+	result := i.db.WithContext(ctx).
+		Model(&models.Issue{}).
+		Where("id = ? AND state <> ?", id, models.IssueStateResolved).
+		Updates(map[string]any{
+			"snoozed_until": until,
+			"snoozed_by":    snoozedBy,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		logging.FromContext(ctx, i.logger).WithError(result.Error).WithField("issue_id", id).Error("Failed to snooze issue")
+		return nil, fmt.Errorf("failed to snooze issue: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		if until == nil {
+			logging.FromContext(ctx, i.logger).WithField("issue_id", id).Info("Woke issue")
+		} else {
+			logging.FromContext(ctx, i.logger).WithFields(logrus.Fields{
+				"issue_id":      id,
+				"snoozed_until": until,
+				"snoozed_by":    snoozedBy,
+			}).Info("Snoozed issue")
+		}
+	}
+
+	return i.FindByID(ctx, id)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// The tests of this file run against Postgres, see testhelpers.SetupPostgresTestDB
+
+func TestIssueRepository_Postgres_CreateOrUpdate_NoDuplicates(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{UsePostgres: true})
+	req := createTestIssue("CreateOrUpdate Test", "test-namespace")
+
+	// Unlike SQLite, Postgres runs the upserts concurrently, relying on the
+	// partial unique index to deduplicate them
+	numGoroutines := 10
+	var wg sync.WaitGroup
+	ids := make([]string, numGoroutines)
+	errs := make([]error, numGoroutines)
+	for n := range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			issue, err := repo.CreateOrUpdate(ctx, req)
+			if issue != nil {
+				ids[n] = issue.ID
+			}
+			errs[n] = err
+		}()
+	}
+	wg.Wait()
+
+	for n := range numGoroutines {
+		if errs[n] != nil {
+			t.Fatalf("Unexpected error, got %v", errs[n])
+		}
+		if ids[n] != ids[0] {
+			t.Errorf("Expected all the upserts to return issue %s, got %s", ids[0], ids[n])
+		}
+	}
+
+	var count int64
+	db.Model(&models.Issue{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 issue, got %d", count)
+	}
+}
+
+func TestIssueRepository_Postgres_ActiveDedupIndex(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{UsePostgres: true})
+	req := createTestIssue("Dedup Test", "test-namespace")
+
+	first, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// The index allows a single active issue per resource
+	if _, err := repo.Create(ctx, req); err == nil {
+		t.Fatal("Expected a duplicate active issue to be rejected, got nil")
+	}
+
+	// Resolved issues are outside of the index
+	if _, err := repo.Update(ctx, first.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+	second, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected a new active issue once the first one is resolved, got %v", err)
+	}
+	if second.ID == first.ID {
+		t.Error("Expected a new issue")
+	}
+}
+
+func TestIssueRepository_Postgres_Search(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{UsePostgres: true})
+
+	if _, err := repo.Create(ctx, createTestIssue("Build FAILED on ARM", "test-namespace")); err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "test-namespace", Search: "build failed"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 {
+		t.Errorf("Expected the search to ignore case, got %d issues", total)
+	}
+}
+
+func TestNamespaceSettingsRepository_Postgres_Save(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{UsePostgres: true})
+	repo := NewNamespaceSettingsRepository(db, logrus.New(), 0)
+
+	created, err := repo.Save(ctx, models.NamespaceSettings{Namespace: "test-namespace", RetentionDays: 30})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	updated, err := repo.Save(ctx, models.NamespaceSettings{Namespace: "test-namespace", RetentionDays: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.RetentionDays != 7 || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("Expected the settings to be replaced and their creation time kept, got %+v", updated)
+	}
+}
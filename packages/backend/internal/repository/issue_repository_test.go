@@ -2,11 +2,17 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/metrics"
 	"github.com/konflux-ci/kite/internal/models"
 	"github.com/konflux-ci/kite/internal/testhelpers"
 	"github.com/sirupsen/logrus"
@@ -15,18 +21,21 @@ import (
 
 type SetupOptions struct {
 	UseConcurrentDatabase bool // Use a concurrent database setup
+	UsePostgres           bool // Use a Postgres database, skipping the test unless KITE_TEST_POSTGRES is true
 }
 
 // setupTestScenario sets up a context and repository for test scenarios
 func setupTestScenario(t *testing.T, options SetupOptions) (context.Context, *gorm.DB, IssueRepository) {
 	var db *gorm.DB
-	if options.UseConcurrentDatabase {
+	if options.UsePostgres {
+		db = testhelpers.SetupPostgresTestDB(t)
+	} else if options.UseConcurrentDatabase {
 		db = testhelpers.SetupConcurrentTestDB(t)
 	} else {
 		db = testhelpers.SetupTestDB(t)
 	}
 	logger := logrus.New()
-	repo := NewIssueRepository(db, logger)
+	repo := NewIssueRepository(db, logger, 0)
 	ctx := context.Background()
 
 	return ctx, db, repo
@@ -82,6 +91,57 @@ func TestIssueRepository_Create(t *testing.T) {
 	}
 }
 
+func TestIssueRepository_Create_DetectedAt(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// Reported late, the issue keeps the time it was detected at
+	detectedAt := time.Now().Add(-30 * time.Minute).UTC().Truncate(time.Second)
+	req := createTestIssue("Reported Late", "test-namespace")
+	req.DetectedAt = detectedAt
+	issue, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	if !issue.DetectedAt.Equal(detectedAt) {
+		t.Errorf("Expected detectedAt %s, got %s", detectedAt, issue.DetectedAt)
+	}
+
+	// Without a detection time, the issue is detected when created
+	before := time.Now()
+	req = createTestIssue("Reported Now", "test-namespace")
+	req.Scope.ResourceName = "other-component"
+	issue, err = repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	if issue.DetectedAt.Before(before) {
+		t.Errorf("Expected detectedAt after %s, got %s", before, issue.DetectedAt)
+	}
+}
+
+func TestIssueRepository_Details(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Details Test", "test-namespace")
+	req.Details = "step-build exited with code 1"
+	issue, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	if issue.Details != req.Details {
+		t.Errorf("Expected details %q, got %q", req.Details, issue.Details)
+	}
+
+	// Updating without details keeps them
+	updated, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Title: "Details Test Updated"})
+	if err != nil {
+		t.Fatalf("Failed to update test issue: %v", err)
+	}
+	if updated.Details != req.Details {
+		t.Errorf("Expected details %q after the update, got %q", req.Details, updated.Details)
+	}
+}
+
 func TestIssueRepository_FindByID(t *testing.T) {
 	// Setup
 	ctx, _, repo := setupTestScenario(t, SetupOptions{})
@@ -111,6 +171,11 @@ func TestIssueRepository_FindByID(t *testing.T) {
 	if err != nil {
 		t.Errorf("unexpected error, got: %v", err)
 	}
+
+	// Links should be loaded with the issue
+	if len(foundIssue.Links) != len(req.Links) {
+		t.Errorf("Expected %d links, got %d", len(req.Links), len(foundIssue.Links))
+	}
 }
 
 func TestIssueRepository_FindByID_NotFound(t *testing.T) {
@@ -129,6 +194,93 @@ func TestIssueRepository_FindByID_NotFound(t *testing.T) {
 	}
 }
 
+func TestIssueRepository_FindByIDs(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	var ids []string
+	for _, namespace := range []string{"team-a", "team-b", "team-c"} {
+		req := createTestIssue("Batch Test", namespace)
+		req.Labels = map[string]string{"team": namespace}
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := repo.AddRelatedIssue(ctx, ids[0], ids[1], models.RelationTypeRelated); err != nil {
+		t.Fatalf("Failed to relate issues: %v", err)
+	}
+
+	// Missing IDs are skipped and the requested order is kept
+	issues, err := repo.FindByIDs(ctx, []string{ids[2], "does-not-exist", ids[0]})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 2 || issues[0].ID != ids[2] || issues[1].ID != ids[0] {
+		t.Fatalf("Expected issues %s and %s, got %+v", ids[2], ids[0], issues)
+	}
+
+	// Only the scope is loaded by default
+	if issues[1].Scope.ResourceName != "test-component" {
+		t.Errorf("Expected the scope to be loaded, got %+v", issues[1].Scope)
+	}
+	if len(issues[1].Links) != 0 || len(issues[1].Labels) != 0 || len(issues[1].RelatedFrom) != 0 {
+		t.Errorf("Expected no other associations to be loaded, got %+v", issues[1])
+	}
+
+	issues, err = repo.FindByIDs(ctx, ids[:1], WithLinks(), WithLabels(), WithRelated())
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if len(issues[0].Links) != 1 {
+		t.Errorf("Expected 1 link, got %d", len(issues[0].Links))
+	}
+	if len(issues[0].Labels) != 1 {
+		t.Errorf("Expected 1 label, got %d", len(issues[0].Labels))
+	}
+	if len(issues[0].RelatedFrom) != 1 || issues[0].RelatedFrom[0].Target.Scope.ResourceNamespace != "team-b" {
+		t.Errorf("Expected the related issue with its scope, got %+v", issues[0].RelatedFrom)
+	}
+}
+
+func TestIssueRepository_FindByIDs_Empty(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issues, err := repo.FindByIDs(ctx, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %d", len(issues))
+	}
+}
+
+func TestIssueRepository_QueryTimeout(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	// The deadline passes before the first query runs
+	repo := NewIssueRepository(db, logrus.New(), time.Nanosecond)
+
+	_, _, err := repo.FindAll(context.Background(), IssueQueryFilters{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline exceeded error, got %v", err)
+	}
+}
+
+func TestIssueRepository_CancelledContext(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := repo.Create(ctx, createTestIssue("Cancelled", "test-namespace"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled error, got %v", err)
+	}
+}
+
 func TestIssueRepository_FindAll_WithFilters(t *testing.T) {
 	// Setup
 	ctx, _, repo := setupTestScenario(t, SetupOptions{})
@@ -188,6 +340,195 @@ func TestIssueRepository_FindAll_WithFilters(t *testing.T) {
 	}
 }
 
+func TestIssueRepository_FindAll_RelatedCount(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	var ids []string
+	for _, namespace := range []string{"team-a", "team-b", "team-c"} {
+		issue, err := repo.Create(ctx, createTestIssue("Related Test", namespace))
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	for _, target := range ids[1:] {
+		if err := repo.AddRelatedIssue(ctx, ids[0], target, models.RelationTypeRelated); err != nil {
+			t.Fatalf("Failed to relate issues: %v", err)
+		}
+	}
+
+	issues, _, err := repo.FindAll(ctx, IssueQueryFilters{})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expected := map[string]int64{ids[0]: 2, ids[1]: 1, ids[2]: 1}
+	for _, issue := range issues {
+		if issue.RelatedCount != expected[issue.ID] {
+			t.Errorf("Expected %d relationships for issue %s, got %d", expected[issue.ID], issue.Namespace, issue.RelatedCount)
+		}
+		// Lists don't load the related issues
+		if len(issue.RelatedFrom) != 0 || len(issue.RelatedTo) != 0 {
+			t.Errorf("Expected no related issues to be loaded, got %+v", issue)
+		}
+	}
+
+	found, err := repo.FindByID(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(found.RelatedFrom) != 2 {
+		t.Errorf("Expected the related issues of a single issue to be loaded, got %+v", found.RelatedFrom)
+	}
+}
+
+func TestIssueRepository_FindAll_Fields(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Projected Issue", "team-a")
+	req.Labels = map[string]string{"team": "a"}
+	if _, err := repo.Create(ctx, req); err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Fields: []string{"title", "scope"}})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d of %d", len(issues), total)
+	}
+	issue := issues[0]
+	if issue.ID == "" || issue.Title != "Projected Issue" || issue.Scope.ResourceName != "test-component" {
+		t.Errorf("Expected the ID, title and scope to be loaded, got %+v", issue)
+	}
+	if issue.Description != "" || issue.Namespace != "" || len(issue.Links) != 0 || len(issue.Labels) != 0 {
+		t.Errorf("Expected the other fields not to be loaded, got %+v", issue)
+	}
+}
+
+func TestIssueRepository_FindAll_ResourceNamespace(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// The issue of team-a is about a resource homed in the shared namespace
+	shared := createTestIssue("Shared Resource Issue", "team-a")
+	shared.Scope.ResourceNamespace = "shared-infra"
+	sharedIssue, err := repo.Create(ctx, shared)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	local := createTestIssue("Local Resource Issue", "team-a")
+	local.Scope.ResourceName = "local-component"
+	if _, err := repo.Create(ctx, local); err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{ResourceNamespace: "shared-infra"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != sharedIssue.ID {
+		t.Errorf("Expected only the issue of the shared resource, got %d issues", total)
+	}
+}
+
+func TestIssueRepository_FindAll_LabelsAndAssignee(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	uiIssue := createTestIssue("UI Issue", "team-test")
+	uiIssue.Labels = map[string]string{"team": "ui", "tier": "frontend"}
+	uiIssue.Assignee = "alice"
+
+	infraIssue := createTestIssue("Infra Issue", "team-test")
+	infraIssue.Scope.ResourceName = "infra-component"
+	infraIssue.Labels = map[string]string{"team": "infra"}
+	infraIssue.Assignee = "bob"
+
+	for _, req := range []dto.CreateIssueRequest{uiIssue, infraIssue} {
+		if _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		filters       IssueQueryFilters
+		expectedTitle string
+		expectedTotal int64
+	}{
+		{"single label", IssueQueryFilters{Labels: map[string]string{"team": "ui"}}, "UI Issue", 1},
+		{"all labels must match", IssueQueryFilters{Labels: map[string]string{"team": "infra", "tier": "frontend"}}, "", 0},
+		{"assignee", IssueQueryFilters{Assignee: "bob"}, "Infra Issue", 1},
+		{"label and assignee", IssueQueryFilters{Labels: map[string]string{"tier": "frontend"}, Assignee: "alice"}, "UI Issue", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			foundIssues, total, err := repo.FindAll(ctx, tt.filters)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if total != tt.expectedTotal {
+				t.Fatalf("Expected %d issues, got %d", tt.expectedTotal, total)
+			}
+			if total > 0 && foundIssues[0].Title != tt.expectedTitle {
+				t.Errorf("Expected issue '%s', got '%s'", tt.expectedTitle, foundIssues[0].Title)
+			}
+		})
+	}
+
+	// Labels are loaded with the issues, ordered by key
+	foundIssues, _, err := repo.FindAll(ctx, IssueQueryFilters{Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	labels := foundIssues[0].Labels
+	if len(labels) != 2 || labels[0].Key != "team" || labels[1].Key != "tier" {
+		t.Errorf("Expected labels team and tier, got %+v", labels)
+	}
+}
+
+func TestIssueRepository_FindAll_Priority(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// Create issues in detection order, one without a priority
+	for i, priority := range []models.Priority{models.PriorityP3, "", models.PriorityP1, models.PriorityP3} {
+		req := createTestIssue(fmt.Sprintf("Issue %d", i), "team-test")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", i)
+		req.Priority = priority
+		if _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		// Make sure detection times differ
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Check: filter by priority
+	priority := models.PriorityP3
+	foundIssues, total, err := repo.FindAll(ctx, IssueQueryFilters{Priority: &priority})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 2 {
+		t.Errorf("Expected 2 P3 issues, got %d", total)
+	}
+
+	// Check: sort by priority, then most recently detected first
+	foundIssues, _, err = repo.FindAll(ctx, IssueQueryFilters{SortBy: SortByPriority})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var titles []string
+	for _, issue := range foundIssues {
+		titles = append(titles, issue.Title)
+	}
+	expected := []string{"Issue 2", "Issue 3", "Issue 0", "Issue 1"}
+	if !slices.Equal(titles, expected) {
+		t.Errorf("Expected order %v, got %v", expected, titles)
+	}
+}
+
 func TestIssueRepository_CheckDuplicate(t *testing.T) {
 	// Setup
 	ctx, _, repo := setupTestScenario(t, SetupOptions{})
@@ -212,6 +553,86 @@ func TestIssueRepository_CheckDuplicate(t *testing.T) {
 	}
 }
 
+func TestIssueRepository_CreateNewIssueInTx_UpsertsConcurrentDuplicate(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Original Issue", "test-namespace")
+	original, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Simulate a concurrent request that didn't see the original issue when
+	// checking for duplicates, and goes straight to creating the issue
+	req.Title = "Concurrent Issue"
+	var issue *models.Issue
+	var created bool
+	err = db.Transaction(func(tx *gorm.DB) error {
+		issue, created, err = repo.(*issueRepository).createNewIssueInTx(tx, req)
+		return err
+	})
+
+	// Verify
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if created {
+		t.Error("Expected the existing issue to be updated")
+	}
+	if issue.ID != original.ID {
+		t.Errorf("Expected issue %s to be updated, got %s", original.ID, issue.ID)
+	}
+
+	var issueCount, scopeCount int64
+	db.Model(&models.Issue{}).Count(&issueCount)
+	db.Model(&models.IssueScope{}).Count(&scopeCount)
+	if issueCount != 1 || scopeCount != 1 {
+		t.Errorf("Expected 1 issue and 1 scope, got %d issues and %d scopes", issueCount, scopeCount)
+	}
+
+	updated, err := repo.FindByID(ctx, original.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.Title != "Concurrent Issue" {
+		t.Errorf("Expected title 'Concurrent Issue', got '%s'", updated.Title)
+	}
+}
+
+func TestIssueRepository_ActiveDuplicatesRejectedByDatabase(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	original, err := repo.Create(ctx, createTestIssue("Original Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	duplicate := models.Issue{
+		Title:       "Duplicate Issue",
+		Description: "Inserted without the repository",
+		Severity:    original.Severity,
+		IssueType:   original.IssueType,
+		State:       models.IssueStateActive,
+		DetectedAt:  time.Now(),
+		Namespace:   original.Namespace,
+		DedupKey:    original.DedupKey,
+		Scope:       models.IssueScope{ResourceType: "component", ResourceName: "test-component", ResourceNamespace: "test-namespace"},
+	}
+	if err := db.Create(&duplicate).Error; err == nil {
+		t.Fatal("Expected a second ACTIVE issue for the same scope to be rejected")
+	}
+
+	// Resolved issues don't count towards the limit
+	duplicate.ID = ""
+	duplicate.Scope.ID = ""
+	duplicate.State = models.IssueStateResolved
+	if err := db.Create(&duplicate).Error; err != nil {
+		t.Errorf("Expected a RESOLVED duplicate to be accepted, got %v", err)
+	}
+}
+
 func TestIssueRepository_Update(t *testing.T) {
 	// Setup
 	ctx, _, repo := setupTestScenario(t, SetupOptions{})
@@ -251,6 +672,92 @@ func TestIssueRepository_Update(t *testing.T) {
 	}
 }
 
+func TestIssueRepository_Update_LabelsAndAssignee(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Labelled Issue", "test-namespace")
+	req.Labels = map[string]string{"team": "ui"}
+	issue, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Updates without labels keep the existing ones
+	updatedIssue, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updatedIssue.Assignee != "alice" {
+		t.Errorf("Wrong assignee, got '%s', expected 'alice'", updatedIssue.Assignee)
+	}
+	if len(updatedIssue.Labels) != 1 {
+		t.Errorf("Expected labels to be kept, got %+v", updatedIssue.Labels)
+	}
+
+	// Provided labels replace the existing ones
+	updatedIssue, err = repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{
+		Labels: map[string]string{"team": "infra", "area": "ci"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(updatedIssue.Labels) != 2 || updatedIssue.Labels[1].Value != "infra" {
+		t.Errorf("Expected labels area=ci and team=infra, got %+v", updatedIssue.Labels)
+	}
+
+	// An empty map removes all labels
+	updatedIssue, err = repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Labels: map[string]string{}})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(updatedIssue.Labels) != 0 {
+		t.Errorf("Expected labels to be removed, got %+v", updatedIssue.Labels)
+	}
+}
+
+func TestIssueRepository_Update_Resolution(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, err := repo.Create(ctx, createTestIssue("Resolved Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	resolved, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{
+		State:            models.IssueStateResolved,
+		ResolutionReason: "Fixed by bumping the base image",
+		ResolvedBy:       "alice",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.ResolutionReason != "Fixed by bumping the base image" || resolved.ResolvedBy != "alice" {
+		t.Errorf("Unexpected resolution, got reason %q by %q", resolved.ResolutionReason, resolved.ResolvedBy)
+	}
+
+	// Updating the resolved issue keeps its resolution
+	resolved, err = repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved, Title: "Renamed Issue"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.ResolutionReason != "Fixed by bumping the base image" {
+		t.Errorf("Expected the resolution reason to be kept, got %q", resolved.ResolutionReason)
+	}
+
+	// Resolving the issue again replaces its resolution
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateActive}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	resolved, err = repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.ResolutionReason != "" || resolved.ResolvedBy != "" {
+		t.Errorf("Expected the previous resolution to be replaced, got reason %q by %q", resolved.ResolutionReason, resolved.ResolvedBy)
+	}
+}
+
 func TestIssueRepository_Delete(t *testing.T) {
 	ctx, db, repo := setupTestScenario(t, SetupOptions{})
 
@@ -302,11 +809,478 @@ func TestIssueRepository_Delete(t *testing.T) {
 	}
 }
 
-func TestIssueRepository_CreateOrUpdate_NoDuplicates(t *testing.T) {
-	// Setup
-	ctx, _, repo := setupTestScenario(t, SetupOptions{
-		UseConcurrentDatabase: true,
-	})
+func TestIssueRepository_Delete_CascadesRelations(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Cascade Test", "test-namespace")
+	req.Labels = map[string]string{"team": "build"}
+	issue, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	otherReq := createTestIssue("Related Test", "test-namespace")
+	otherReq.Scope.ResourceName = "other-component"
+	other, err := repo.Create(ctx, otherReq)
+	if err != nil {
+		t.Fatalf("Failed to create related issue: %v", err)
+	}
+	if err := repo.AddRelatedIssue(ctx, issue.ID, other.ID, models.RelationTypeRelated); err != nil {
+		t.Fatalf("Failed to relate issues: %v", err)
+	}
+	if _, err := repo.AddExternalRef(ctx, issue.ID, models.ExternalRef{System: "jira", Key: "KFLUXBUGS-1", URL: "https://issues.test/browse/KFLUXBUGS-1"}); err != nil {
+		t.Fatalf("Failed to add external reference: %v", err)
+	}
+
+	if err := repo.Delete(ctx, issue.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	counts := map[string]int64{}
+	var count int64
+	db.Model(&models.IssueScope{}).Count(&count)
+	counts["scopes"] = count
+	db.Model(&models.Link{}).Where("issue_id = ?", issue.ID).Count(&count)
+	counts["links"] = count
+	db.Model(&models.Label{}).Count(&count)
+	counts["labels"] = count
+	db.Model(&models.RelatedIssue{}).Count(&count)
+	counts["related issues"] = count
+	db.Model(&models.ExternalRef{}).Count(&count)
+	counts["external references"] = count
+
+	expected := map[string]int64{"scopes": 1, "links": 0, "labels": 0, "related issues": 0, "external references": 0}
+	for name, want := range expected {
+		if counts[name] != want {
+			t.Errorf("Expected %d %s after delete, got %d", want, name, counts[name])
+		}
+	}
+
+	if _, err := repo.FindByID(ctx, other.ID); err != nil {
+		t.Errorf("Expected the related issue to survive the delete, got %v", err)
+	}
+}
+
+func TestIssueRepository_FindActiveEffects(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	createIssue := func(name string) *models.Issue {
+		req := createTestIssue("Build failed: "+name, "test-namespace")
+		req.Scope.ResourceName = name
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		return issue
+	}
+	cause := createIssue("base-image")
+	activeEffect := createIssue("api")
+	resolvedEffect := createIssue("ui")
+	related := createIssue("db")
+
+	for _, relation := range []struct {
+		source       *models.Issue
+		relationType models.RelationType
+	}{
+		{activeEffect, models.RelationTypeCausedBy},
+		{resolvedEffect, models.RelationTypeCausedBy},
+		{related, models.RelationTypeRelated},
+	} {
+		if err := repo.AddRelatedIssue(ctx, relation.source.ID, cause.ID, relation.relationType); err != nil {
+			t.Fatalf("Failed to relate issues: %v", err)
+		}
+	}
+	if _, err := repo.Update(ctx, resolvedEffect.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Failed to resolve test issue: %v", err)
+	}
+
+	ids, err := repo.FindActiveEffects(ctx, cause.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !slices.Equal(ids, []string{activeEffect.ID}) {
+		t.Errorf("Expected only the active issue caused by the issue, got %v", ids)
+	}
+
+	// Effects don't work backwards
+	ids, err = repo.FindActiveEffects(ctx, activeEffect.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("Expected no issue caused by the effect, got %v", ids)
+	}
+}
+
+func TestIssueRepository_Delete_NotFound(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	err := repo.Delete(ctx, "non-existent-id")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestIssueRepository_Update_NotFound(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	_, err := repo.Update(ctx, "non-existent-id", dto.UpdateIssueRequest{Title: "Renamed"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestIssueRepository_ExternalRefs(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, err := repo.Create(ctx, createTestIssue("External Ref Test", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	jira := models.ExternalRef{System: "jira", Key: "KFLUXBUGS-1", URL: "https://issues.test/browse/KFLUXBUGS-1"}
+	ref, err := repo.AddExternalRef(ctx, issue.ID, jira)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if ref.ID == "" || ref.IssueID != issue.ID {
+		t.Errorf("Expected an external reference with an ID for issue %s, got %+v", issue.ID, ref)
+	}
+
+	if _, err := repo.AddExternalRef(ctx, issue.ID, jira); err == nil || err.Error() != "external reference already exists" || !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected already exists error, got %v", err)
+	}
+	if _, err := repo.AddExternalRef(ctx, "non-existent-id", jira); err == nil || err.Error() != "issue not found" || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected issue not found error, got %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(found.ExternalRefs) != 1 || found.ExternalRefs[0].Key != jira.Key {
+		t.Errorf("Expected the issue to reference %s, got %+v", jira.Key, found.ExternalRefs)
+	}
+
+	if err := repo.RemoveExternalRef(ctx, issue.ID, ref.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := repo.RemoveExternalRef(ctx, issue.ID, ref.ID); err == nil || err.Error() != "external reference not found" || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestIssueRepository_FindAll_HasExternalRef(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	tracked, err := repo.Create(ctx, createTestIssue("Tracked", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	untrackedReq := createTestIssue("Untracked", "test-namespace")
+	untrackedReq.Scope.ResourceName = "other-component"
+	untracked, err := repo.Create(ctx, untrackedReq)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	if _, err := repo.AddExternalRef(ctx, tracked.ID, models.ExternalRef{System: "github", Key: "konflux-ci/kite#1", URL: "https://github.test/konflux-ci/kite/issues/1"}); err != nil {
+		t.Fatalf("Failed to add external reference: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		hasExternalRef bool
+		expectedID     string
+	}{
+		{name: "with external references", hasExternalRef: true, expectedID: tracked.ID},
+		{name: "without external references", hasExternalRef: false, expectedID: untracked.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, total, err := repo.FindAll(ctx, IssueQueryFilters{HasExternalRef: &tt.hasExternalRef})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if total != 1 || len(issues) != 1 || issues[0].ID != tt.expectedID {
+				t.Errorf("Expected only issue %s, got %d issues", tt.expectedID, total)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_FindAll_Acknowledged(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	seen, err := repo.Create(ctx, createTestIssue("Seen", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	unseenReq := createTestIssue("Unseen", "test-namespace")
+	unseenReq.Scope.ResourceName = "other-component"
+	unseen, err := repo.Create(ctx, unseenReq)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	acknowledged, err := repo.Acknowledge(ctx, seen.ID, "alice", models.IssueStateAcknowledged)
+	if err != nil {
+		t.Fatalf("Failed to acknowledge issue: %v", err)
+	}
+	if acknowledged.State != models.IssueStateAcknowledged || acknowledged.AcknowledgedBy != "alice" || acknowledged.AcknowledgedAt == nil {
+		t.Fatalf("Expected the issue acknowledged by alice, got %+v", acknowledged)
+	}
+
+	tests := []struct {
+		name         string
+		acknowledged bool
+		expectedID   string
+	}{
+		{name: "acknowledged", acknowledged: true, expectedID: seen.ID},
+		{name: "unacknowledged", acknowledged: false, expectedID: unseen.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Acknowledged: &tt.acknowledged})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if total != 1 || len(issues) != 1 || issues[0].ID != tt.expectedID {
+				t.Errorf("Expected only issue %s, got %d issues", tt.expectedID, total)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_FindAll_Snoozed(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	create := func(resourceName string) *models.Issue {
+		req := createTestIssue("Issue of "+resourceName, "test-namespace")
+		req.Scope.ResourceName = resourceName
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		return issue
+	}
+	snoozed := create("snoozed")
+	expired := create("expired")
+	woken := create("woken")
+	awake := create("awake")
+
+	later := time.Now().Add(time.Hour)
+	issue, err := repo.Snooze(ctx, snoozed.ID, &later, "alice")
+	if err != nil {
+		t.Fatalf("Failed to snooze issue: %v", err)
+	}
+	if issue.SnoozedUntil == nil || !issue.SnoozedUntil.Equal(later) || issue.SnoozedBy != "alice" {
+		t.Fatalf("Expected the issue snoozed by alice until %v, got %+v", later, issue)
+	}
+	earlier := time.Now().Add(-time.Hour)
+	if _, err := repo.Snooze(ctx, expired.ID, &earlier, "alice"); err != nil {
+		t.Fatalf("Failed to snooze issue: %v", err)
+	}
+	if _, err := repo.Snooze(ctx, woken.ID, &later, "alice"); err != nil {
+		t.Fatalf("Failed to snooze issue: %v", err)
+	}
+	issue, err = repo.Snooze(ctx, woken.ID, nil, "bob")
+	if err != nil {
+		t.Fatalf("Failed to wake issue: %v", err)
+	}
+	if issue.SnoozedUntil != nil || issue.SnoozedBy != "" {
+		t.Fatalf("Expected the issue to be woken, got %q until %v", issue.SnoozedBy, issue.SnoozedUntil)
+	}
+
+	tests := []struct {
+		name        string
+		snoozed     bool
+		expectedIDs []string
+	}{
+		{name: "snoozed", snoozed: true, expectedIDs: []string{snoozed.ID}},
+		{name: "not snoozed", snoozed: false, expectedIDs: []string{expired.ID, woken.ID, awake.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Snoozed: &tt.snoozed})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			var ids []string
+			for _, issue := range issues {
+				ids = append(ids, issue.ID)
+			}
+			slices.Sort(ids)
+			slices.Sort(tt.expectedIDs)
+			if total != int64(len(tt.expectedIDs)) || !slices.Equal(ids, tt.expectedIDs) {
+				t.Errorf("Expected issues %v, got %v", tt.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_FindAll_DateRanges(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	now := time.Now()
+	day := 24 * time.Hour
+	ids := map[string]string{}
+	for name, times := range map[string]struct{ detected, resolved time.Duration }{
+		"old":    {detected: 10 * day, resolved: 8 * day},
+		"recent": {detected: 3 * day, resolved: day},
+		"active": {detected: 2 * day},
+	} {
+		req := createTestIssue(name, "test-namespace")
+		req.Scope.ResourceName = name
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		updates := map[string]any{"detected_at": now.Add(-times.detected)}
+		if times.resolved != 0 {
+			updates["state"] = models.IssueStateResolved
+			updates["resolved_at"] = now.Add(-times.resolved)
+		}
+		if err := db.Model(&models.Issue{}).Where("id = ?", issue.ID).Updates(updates).Error; err != nil {
+			t.Fatalf("Failed to date test issue: %v", err)
+		}
+		ids[name] = issue.ID
+	}
+
+	at := func(age time.Duration) *time.Time {
+		t := now.Add(-age)
+		return &t
+	}
+	tests := []struct {
+		name     string
+		filters  IssueQueryFilters
+		expected []string
+	}{
+		{name: "detected after", filters: IssueQueryFilters{DetectedSince: at(5 * day)}, expected: []string{"active", "recent"}},
+		{name: "detected before", filters: IssueQueryFilters{DetectedBefore: at(5 * day)}, expected: []string{"old"}},
+		{name: "detected within", filters: IssueQueryFilters{DetectedSince: at(5 * day), DetectedBefore: at(2*day + time.Hour)}, expected: []string{"recent"}},
+		{name: "resolved after", filters: IssueQueryFilters{ResolvedSince: at(2 * day)}, expected: []string{"recent"}},
+		{name: "resolved before", filters: IssueQueryFilters{ResolvedBefore: at(2 * day)}, expected: []string{"old"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, _, err := repo.FindAll(ctx, tt.filters)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.Title)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected issues %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestStreamAll(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	// Issues detected at the same time are streamed once each, across batches
+	detectedAt := time.Now().Add(-time.Hour).UTC()
+	var expected []string
+	for n := range 7 {
+		req := createTestIssue(fmt.Sprintf("Issue %d", n), "team-stream")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", n)
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		if n%2 == 0 {
+			db.Model(&models.Issue{}).Where("id = ?", issue.ID).Update("detected_at", detectedAt)
+		}
+		expected = append(expected, issue.ID)
+	}
+	if _, err := repo.Create(ctx, createTestIssue("Other namespace", "team-other")); err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	var streamed []models.Issue
+	var batches []int
+	err := StreamAll(ctx, repo, IssueQueryFilters{Namespace: "team-stream", Fields: []string{"title"}, Limit: 1, Offset: 3}, 3, func(issues []models.Issue) error {
+		streamed = append(streamed, issues...)
+		batches = append(batches, len(issues))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !slices.Equal(batches, []int{3, 3, 1}) {
+		t.Errorf("Expected batches of 3 issues, got %v", batches)
+	}
+	var ids []string
+	for n, issue := range streamed {
+		ids = append(ids, issue.ID)
+		if n > 0 && issue.DetectedAt.After(streamed[n-1].DetectedAt) {
+			t.Errorf("Expected the most recently detected issues first, got %s after %s", issue.DetectedAt, streamed[n-1].DetectedAt)
+		}
+	}
+	slices.Sort(ids)
+	slices.Sort(expected)
+	if !slices.Equal(ids, expected) {
+		t.Errorf("Expected each issue of the namespace once, got %v", ids)
+	}
+
+	// The errors of the callback stop the stream
+	stop := errors.New("client gone")
+	calls := 0
+	err = StreamAll(ctx, repo, IssueQueryFilters{Namespace: "team-stream"}, 3, func(issues []models.Issue) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Expected the stream to stop after the first batch, got %v after %d batches", err, calls)
+	}
+}
+
+func TestStreamAll_TiedDetection(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	// Issues all detected at the same time span several batches
+	detectedAt := time.Now().Add(-time.Hour).UTC()
+	expected := map[string]int{}
+	for n := range 20 {
+		req := createTestIssue(fmt.Sprintf("Tied issue %d", n), "team-tied")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", n)
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		expected[issue.ID] = 1
+	}
+	db.Model(&models.Issue{}).Where("namespace = ?", "team-tied").Update("detected_at", detectedAt)
+
+	for _, fields := range [][]string{nil, {"title"}} {
+		streamed := map[string]int{}
+		err := StreamAll(ctx, repo, IssueQueryFilters{Namespace: "team-tied", Fields: fields}, 3, func(issues []models.Issue) error {
+			for _, issue := range issues {
+				streamed[issue.ID]++
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if !maps.Equal(streamed, expected) {
+			t.Errorf("Expected each of the %d issues once with fields %v, got %v", len(expected), fields, streamed)
+		}
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_NoDuplicates(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		UseConcurrentDatabase: true,
+	})
 
 	// Create issue
 	req := createTestIssue("CreateOrUpdate Test", "test-namespace")
@@ -363,3 +1337,330 @@ func TestIssueRepository_CreateOrUpdate_NoDuplicates(t *testing.T) {
 		}
 	}
 }
+
+func TestIssueRepository_CreateOrUpdate_PrefersOpenIssue(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	active, err := repo.Create(ctx, createTestIssue("Active issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// A resolved duplicate of the same scope, as the dedup key migration
+	// leaves them, sorting before the active issue by ID
+	scope := models.IssueScope{ResourceType: "component", ResourceName: "test-component", ResourceNamespace: "test-namespace"}
+	if err := db.Create(&scope).Error; err != nil {
+		t.Fatalf("Failed to create scope: %v", err)
+	}
+	resolvedAt := time.Now().Add(-time.Hour)
+	resolved := models.Issue{
+		ID:          "00000000-0000-0000-0000-000000000001",
+		Title:       "Resolved duplicate",
+		Description: "Test description",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		State:       models.IssueStateResolved,
+		DetectedAt:  time.Now().Add(-2 * time.Hour),
+		ResolvedAt:  &resolvedAt,
+		Namespace:   "test-namespace",
+		ScopeID:     scope.ID,
+		DedupKey:    active.DedupKey,
+	}
+	if err := db.Create(&resolved).Error; err != nil {
+		t.Fatalf("Failed to create resolved duplicate: %v", err)
+	}
+
+	reported, err := repo.CreateOrUpdate(ctx, createTestIssue("Reported again", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if reported.ID != active.ID || reported.Title != "Reported again" {
+		t.Errorf("Expected the active issue to be updated, got %+v", reported)
+	}
+
+	var stillResolved models.Issue
+	if err := db.First(&stillResolved, "id = ?", resolved.ID).Error; err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if stillResolved.State != models.IssueStateResolved {
+		t.Errorf("Expected the resolved duplicate to stay resolved, got %s", stillResolved.State)
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_Fingerprint(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	// Reports with the same fingerprint are grouped, whatever their scope
+	req := createTestIssue("CVE-2025-1234 in openssl", "team-alpha")
+	req.Fingerprint = "CVE-2025-1234/openssl"
+	first, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if first.Fingerprint != req.Fingerprint {
+		t.Errorf("Expected the fingerprint %q, got %q", req.Fingerprint, first.Fingerprint)
+	}
+	req.Scope.ResourceName = "other-component"
+	second, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("Expected the reports with the same fingerprint to be grouped, got %s and %s", first.ID, second.ID)
+	}
+
+	// Fingerprinted issues aren't duplicates of the others, sharing their scope
+	unfingerprinted, err := repo.CreateOrUpdate(ctx, createTestIssue("Build failed", "team-alpha"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	otherReq := createTestIssue("CVE-2025-5678 in openssl", "team-alpha")
+	otherReq.Fingerprint = "CVE-2025-5678/openssl"
+	other, err := repo.CreateOrUpdate(ctx, otherReq)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if unfingerprinted.ID == first.ID || other.ID == first.ID || other.ID == unfingerprinted.ID {
+		t.Errorf("Expected 3 distinct issues, got %s, %s and %s", first.ID, unfingerprinted.ID, other.ID)
+	}
+
+	// Merging the report of another scope kept the issue grouped by its fingerprint
+	var dedupKey string
+	db.Model(&models.Issue{}).Select("dedup_key").Where("id = ?", first.ID).Scan(&dedupKey)
+	if dedupKey != models.FingerprintDedupKey(req.Fingerprint) {
+		t.Errorf("Expected the deduplication key of the fingerprint, got %q", dedupKey)
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-alpha", Fingerprint: req.Fingerprint})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || issues[0].ID != first.ID {
+		t.Errorf("Expected the issue of the fingerprint, got %d issues", total)
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_ReplacesLinks(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Pipeline failed", "team-alpha")
+	req.Links = []dto.CreateLinkRequest{{Title: "Logs", URL: "https://logs.test/run-1"}}
+	first, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	req.Links = []dto.CreateLinkRequest{{Title: "Logs", URL: "https://logs.test/run-2"}}
+	second, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if second.ID != first.ID {
+		t.Fatalf("Expected the report to update the issue, got %s and %s", first.ID, second.ID)
+	}
+	if len(second.Links) != 1 || second.Links[0].URL != "https://logs.test/run-2" {
+		t.Errorf("Expected the links of the last report, got %+v", second.Links)
+	}
+}
+
+// queryPlan returns the SQLite query plan of the FindAll query for filters
+func queryPlan(t *testing.T, db *gorm.DB, filters IssueQueryFilters) string {
+	t.Helper()
+
+	query := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return applyIssueFilters(tx.Model(&models.Issue{}), filters).
+			Order("detected_at DESC").
+			Limit(50).
+			Find(&[]models.Issue{})
+	})
+
+	rows, err := db.Raw("EXPLAIN QUERY PLAN " + query).Rows()
+	if err != nil {
+		t.Fatalf("Failed to explain %q: %v", query, err)
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			t.Fatalf("Failed to read query plan: %v", err)
+		}
+		plan = append(plan, detail)
+	}
+	return strings.Join(plan, "\n")
+}
+
+func TestIssueRepository_FindAll_UsesIndexes(t *testing.T) {
+	_, db, _ := setupTestScenario(t, SetupOptions{})
+
+	active := models.IssueStateActive
+	severity := models.SeverityMajor
+	issueType := models.IssueTypeBuild
+	since := time.Now().Add(-24 * time.Hour)
+	before := time.Now()
+
+	tests := []struct {
+		name    string
+		filters IssueQueryFilters
+		index   string
+	}{
+		{
+			name:    "namespace",
+			filters: IssueQueryFilters{Namespace: "team-a"},
+			index:   "idx_issues_namespace_state_detected",
+		},
+		{
+			name:    "namespace and state",
+			filters: IssueQueryFilters{Namespace: "team-a", State: &active},
+			index:   "idx_issues_namespace_state_detected",
+		},
+		{
+			name:    "severity",
+			filters: IssueQueryFilters{Severity: &severity},
+			index:   "idx_issues_severity",
+		},
+		{
+			name:    "issue type",
+			filters: IssueQueryFilters{IssueType: &issueType},
+			index:   "idx_issues_issue_type",
+		},
+		{
+			name:    "detected range",
+			filters: IssueQueryFilters{DetectedSince: &since, DetectedBefore: &before},
+			index:   "idx_issues_detected_at",
+		},
+		{
+			name:    "resolved range",
+			filters: IssueQueryFilters{ResolvedSince: &since, ResolvedBefore: &before},
+			index:   "idx_issues_resolved_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := queryPlan(t, db, tt.filters)
+			if !strings.Contains(plan, "INDEX "+tt.index) {
+				t.Errorf("Expected the query to use %s, got plan:\n%s", tt.index, plan)
+			}
+			if strings.Contains(plan, "SCAN issues") && !strings.Contains(plan, "USING") {
+				t.Errorf("Expected no sequential scan of issues, got plan:\n%s", plan)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_FindAll_DashboardQueryNeedsNoSort(t *testing.T) {
+	_, db, _ := setupTestScenario(t, SetupOptions{})
+
+	active := models.IssueStateActive
+	plan := queryPlan(t, db, IssueQueryFilters{Namespace: "team-a", State: &active})
+
+	// The index orders the rows by detected_at, so no temporary sort is needed
+	if strings.Contains(plan, "USE TEMP B-TREE FOR ORDER BY") {
+		t.Errorf("Expected the index to provide the ordering, got plan:\n%s", plan)
+	}
+}
+
+func TestIssueRepository_Create_InvalidEnums(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// The database rejects invalid values which bypassed the validation of the API
+	req := createTestIssue("Invalid severity", "test-namespace")
+	req.Severity = "medium"
+	if _, err := repo.Create(ctx, req); err == nil {
+		t.Error("Expected the invalid severity to be rejected")
+	}
+
+	issue, err := repo.Create(ctx, createTestIssue("Valid issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Priority: "P0"}); err == nil {
+		t.Error("Expected the invalid priority to be rejected")
+	}
+
+	// States aren't checked by the database, namespaces defining states of their own
+	updated, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: "WAITING_ON_VENDOR"})
+	if err != nil || updated.State != "WAITING_ON_VENDOR" {
+		t.Errorf("Expected the custom state to be stored, got %v", err)
+	}
+}
+
+func TestIssueRepository_Create_OpenStates(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, err := repo.Create(ctx, createTestIssue("Acknowledged issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateAcknowledged}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Reports of an acknowledged issue update it, keeping its state
+	reported, err := repo.CreateOrUpdate(ctx, createTestIssue("Reported again", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if reported.ID != issue.ID || reported.State != models.IssueStateAcknowledged || reported.Title != "Reported again" {
+		t.Errorf("Expected the acknowledged issue to be updated, got %+v", reported)
+	}
+
+	// Issues in every open state are resolved with their scope
+	resolved, err := repo.ResolveByScope(ctx, "component", "test-component", "test-namespace", dto.Resolution{})
+	if err != nil || resolved != 1 {
+		t.Fatalf("Expected the acknowledged issue to be resolved, got %d, %v", resolved, err)
+	}
+
+	// Moving a resolved issue to any open state reopens it
+	reopened, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateInProgress})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if reopened.ReopenCount != 1 || reopened.ReopenedAt == nil {
+		t.Errorf("Expected the issue to be reopened, got %+v", reopened)
+	}
+}
+
+func TestIssueRepository_CountsDedupOutcomes(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	count := func(outcome metrics.DedupOutcome) uint64 {
+		return metrics.IssueReports.Value(string(models.IssueTypeBuild), string(outcome))
+	}
+	created, merged, reopened := count(metrics.DedupCreated), count(metrics.DedupMerged), count(metrics.DedupReopened)
+
+	req := createTestIssue("Dedup", "test-namespace")
+	issue, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	// Reported again while open
+	if _, err := repo.CreateOrUpdate(ctx, req); err != nil {
+		t.Fatalf("Failed to report issue again: %v", err)
+	}
+	// Reported again without a state once resolved, it stays resolved
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+	if _, err := repo.CreateOrUpdate(ctx, req); err != nil {
+		t.Fatalf("Failed to report issue again: %v", err)
+	}
+	// Reported again as active once resolved
+	req.State = models.IssueStateActive
+	if _, err := repo.Create(ctx, req); err != nil {
+		t.Fatalf("Failed to reopen issue: %v", err)
+	}
+
+	if got := count(metrics.DedupCreated) - created; got != 1 {
+		t.Errorf("Expected 1 created report, got %d", got)
+	}
+	if got := count(metrics.DedupMerged) - merged; got != 2 {
+		t.Errorf("Expected 2 merged reports, got %d", got)
+	}
+	if got := count(metrics.DedupReopened) - reopened; got != 1 {
+		t.Errorf("Expected 1 reopened report, got %d", got)
+	}
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+const (
+	// similarCandidatesLimit is how many of the most recent issues sharing a
+	// word of the title or the resource of an issue are ranked
+	similarCandidatesLimit = 200
+	// minSimilarityScore is the score below which issues aren't similar
+	minSimilarityScore = 0.3
+	// maxSimilarityTerms is how many words of the title select the candidates
+	maxSimilarityTerms = 8
+	// minSimilarityTermLength is the length under which words of the title
+	// don't select candidates, leaving out most stop words
+	minSimilarityTermLength = 4
+)
+
+// SimilarIssue is an issue similar to another one, with its similarity score.
+// The score is the trigram similarity of their titles and descriptions, from 0
+// to 1, raised when their scopes share attributes.
+type SimilarIssue struct {
+	Issue models.Issue `json:"issue"`
+	Score float64      `json:"score"`
+}
+
+// FindSimilar finds the issues of the namespace of an issue similar to it.
+// Issues of other namespaces may belong to other tenants, so they're never
+// considered. Issues sharing a word of its title or its resource are ranked
+// by the trigram similarity of their title and description, as pg_trgm
+// computes it, and the attributes their scopes share. The similarity is
+// computed here rather than by pg_trgm, which needs an extension managed
+// databases don't always allow, and SQLite doesn't have.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issue: The issue, with its scope
+//   - limit: The maximum number of issues
+//
+// Returns:
+//   - []SimilarIssue: The similar issues, the most similar first
+//   - error: Database error or nil
+func (i *issueRepository) FindSimilar(ctx context.Context, issue models.Issue, limit int) ([]SimilarIssue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	// Candidates share a word of the title or the resource of the issue
+	conditions := []string{"(similar_scopes.resource_type = ? AND similar_scopes.resource_name = ?)"}
+	args := []any{issue.Scope.ResourceType, issue.Scope.ResourceName}
+	for _, term := range similarityTerms(issue.Title) {
+		conditions = append(conditions, "LOWER(issues.title) LIKE ?", "LOWER(issues.description) LIKE ?")
+		args = append(args, "%"+term+"%", "%"+term+"%")
+	}
+
+	var candidates []models.Issue
+	err := i.db.WithContext(ctx).Model(&models.Issue{}).
+		Preload("Scope").
+		Preload("Labels", orderLabels).
+		Joins("JOIN issue_scopes AS similar_scopes ON issues.scope_id = similar_scopes.id").
+		Where("issues.namespace = ?", issue.Namespace).
+		Where("issues.id <> ?", issue.ID).
+		Where(strings.Join(conditions, " OR "), args...).
+		Order("issues.detected_at DESC").
+		Limit(similarCandidatesLimit).
+		Find(&candidates).Error
+	if err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("issue_id", issue.ID).Error("Failed to find similar issues")
+		return nil, fmt.Errorf("failed to find similar issues: %w", err)
+	}
+
+	trigrams := textTrigrams(issue.Title + " " + issue.Description)
+	similar := []SimilarIssue{}
+	for _, candidate := range candidates {
+		score := trigramSimilarity(trigrams, textTrigrams(candidate.Title+" "+candidate.Description))
+		if candidate.Scope.ResourceType == issue.Scope.ResourceType {
+			score += 0.1
+			if candidate.Scope.ResourceName == issue.Scope.ResourceName {
+				score += 0.2
+			}
+		}
+		if candidate.Scope.ResourceNamespace == issue.Scope.ResourceNamespace {
+			score += 0.1
+		}
+		if score >= minSimilarityScore {
+			similar = append(similar, SimilarIssue{Issue: candidate, Score: math.Round(score*100) / 100})
+		}
+	}
+
+	// Candidates are the most recent first, which stays the order of equal scores
+	sort.SliceStable(similar, func(a, b int) bool {
+		return similar[a].Score > similar[b].Score
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
+
+// similarityTerms returns the first distinct words of a text long enough to
+// select similar issues, in lower case
+func similarityTerms(text string) []string {
+	var terms []string
+	for _, word := range textWords(text) {
+		if len([]rune(word)) >= minSimilarityTermLength && !slices.Contains(terms, word) {
+			terms = append(terms, word)
+			if len(terms) == maxSimilarityTerms {
+				break
+			}
+		}
+	}
+	return terms
+}
+
+// textWords splits a text in lower case words of letters and digits
+func textWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// textTrigrams returns the trigrams of the words of a text, each word padded
+// with two spaces before it and one after it, as pg_trgm does
+func textTrigrams(text string) map[string]struct{} {
+	trigrams := map[string]struct{}{}
+	for _, word := range textWords(text) {
+		padded := []rune("  " + word + " ")
+		for n := 0; n+3 <= len(padded); n++ {
+			trigrams[string(padded[n:n+3])] = struct{}{}
+		}
+	}
+	return trigrams
+}
+
+// trigramSimilarity returns how many trigrams two sets share out of all their
+// trigrams, from 0 to 1
+func trigramSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+func TestIssueRepository_FindSimilar(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	create := func(title, description, namespace, resource string, issueType models.IssueType) *models.Issue {
+		req := createTestIssue(title, namespace)
+		req.Description = description
+		req.Scope.ResourceName = resource
+		req.IssueType = issueType
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		return issue
+	}
+
+	issue := create("Build failed: go mod download timeout", "Fetching modules timed out", "team-a", "api", models.IssueTypeBuild)
+	// Same failure of another component, the duplicate to discover
+	duplicate := create("Build failed: go mod download timeout", "Fetching modules timed out", "team-a", "frontend", models.IssueTypeBuild)
+	// Another failure of the same resource
+	sameResource := create("Unit tests failed", "Tests of the api component failed", "team-a", "api", models.IssueTypeTest)
+	// Shares a word of the title but little else
+	weak := createTestIssue("Release blocked by download quota", "team-a")
+	weak.Description = "Quota exceeded"
+	weak.IssueType = models.IssueTypeRelease
+	weak.Scope.ResourceType = "application"
+	if _, err := repo.Create(ctx, weak); err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	// Unrelated
+	create("Certificate expired", "The TLS certificate expired", "team-a", "gateway", models.IssueTypeBuild)
+	// Same failures in other namespaces, which may belong to other tenants
+	create("Build failed: go mod download timeout", "Fetching modules timed out", "team-b", "api", models.IssueTypeBuild)
+	create("Unit tests failed", "Tests of the api component failed", "team-c", "api", models.IssueTypeTest)
+
+	full, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	similar, err := repo.FindSimilar(ctx, *full, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if len(similar) != 2 {
+		t.Fatalf("Expected 2 similar issues, got %+v", similar)
+	}
+	if similar[0].Issue.ID != duplicate.ID || similar[1].Issue.ID != sameResource.ID {
+		t.Errorf("Expected the duplicate then the issue of the same resource, got %s (%.2f) and %s (%.2f)",
+			similar[0].Issue.Title, similar[0].Score, similar[1].Issue.Title, similar[1].Score)
+	}
+	if similar[0].Score <= similar[1].Score || similar[0].Issue.Scope.ResourceName != "frontend" {
+		t.Errorf("Unexpected similar issue %+v", similar[0])
+	}
+
+	if similar, _ := repo.FindSimilar(ctx, *full, 1); len(similar) != 1 || similar[0].Issue.ID != duplicate.ID {
+		t.Errorf("Expected the most similar issue only, got %+v", similar)
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"timeout", "timeout", 1},
+		{"Timeout!", "timeout", 1},
+		{"timeout", "", 0},
+		// "cat" has the trigrams "  c", " ca", "cat" and "at ", sharing 2 of the 6 trigrams of both
+		{"cat", "car", 2.0 / 6.0},
+	}
+
+	for _, tc := range testCases {
+		if got := trigramSimilarity(textTrigrams(tc.a), textTrigrams(tc.b)); got != tc.expected {
+			t.Errorf("Expected the similarity of %q and %q to be %f, got %f", tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+// Fields issues can be counted by with CountGroupedBy
+const (
+	CountByNamespace = "namespace"
+	CountBySeverity  = "severity"
+	CountByPriority  = "priority"
+	CountByType      = "issue_type"
+	CountByState     = "state"
+	CountByAssignee  = "assignee"
+	CountByResource  = "resource"
+)
+
+// ValidCountBy lists the fields issues can be counted by
+var ValidCountBy = []string{
+	CountByNamespace, CountBySeverity, CountByPriority, CountByType,
+	CountByState, CountByAssignee, CountByResource,
+}
+
+// GroupCount is the number of issues sharing a value of the counted field
+type GroupCount struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// ResourceReopens is the number of times the issues of a resource were reopened
+type ResourceReopens struct {
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Reopens      int64  `json:"reopens"`
+}
+
+// ResolutionStats summarizes how long resolved issues took to be resolved,
+// from their detection to their resolution
+type ResolutionStats struct {
+	Count   int64         `json:"count"`
+	Average time.Duration `json:"average"`
+	Min     time.Duration `json:"min"`
+	Max     time.Duration `json:"max"`
+}
+
+// CountByFilters counts the issues matching filters. Pagination and sorting
+// filters are ignored.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: The filters selecting the issues to count
+//
+// Returns:
+//   - int64: The number of issues
+//   - error: Database error or nil
+func (i *issueRepository) CountByFilters(ctx context.Context, filters IssueQueryFilters) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	var count int64
+	query := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters)
+	if err := query.Count(&count).Error; err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to count issues")
+		return 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+	return count, nil
+}
+
+// CountGroupedBy counts the issues matching filters for each value of a
+// field. Issues are grouped by "type/name" of their scope's resource when
+// counting by resource.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: The filters selecting the issues to count
+//   - field: One of ValidCountBy
+//
+// Returns:
+//   - []GroupCount: The counts, largest first, then ordered by key
+//   - error: Invalid field, database error or nil
+func (i *issueRepository) CountGroupedBy(ctx context.Context, filters IssueQueryFilters, field string) ([]GroupCount, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	if !slices.Contains(ValidCountBy, field) {
+		return nil, fmt.Errorf("cannot count issues by %q, valid fields are %v", field, ValidCountBy)
+	}
+
+	query := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters)
+
+	key := "issues." + field
+	if field == CountByResource {
+		// Aliased so it doesn't clash with the join of the resource filters
+		query = query.Joins("JOIN issue_scopes AS counted_scopes ON issues.scope_id = counted_scopes.id")
+		key = "counted_scopes.resource_type || '/' || counted_scopes.resource_name"
+	}
+
+	key = "COALESCE(" + key + ", '')"
+	counts := []GroupCount{}
+	err := query.
+		Select(key + " AS key, COUNT(*) AS count").
+		Group(key).
+		Order("count DESC").
+		Order("key").
+		Scan(&counts).Error
+	if err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("field", field).Error("Failed to count issues by field")
+		return nil, fmt.Errorf("failed to count issues by %s: %w", field, err)
+	}
+	return counts, nil
+}
+
+// CountReopensByResource counts how many times the issues matching filters
+// were reopened, for each resource of their scopes, to find the flapping
+// resources.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: The filters selecting the issues, e.g. ReopenedSince
+//   - limit: The maximum number of resources, 0 for all of them
+//
+// Returns:
+//   - []ResourceReopens: The resources whose issues were reopened, the most reopened first
+//   - error: Database error or nil
+func (i *issueRepository) CountReopensByResource(ctx context.Context, filters IssueQueryFilters, limit int) ([]ResourceReopens, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	// Aliased so it doesn't clash with the join of the resource filters
+	query := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters).
+		Joins("JOIN issue_scopes AS reopened_scopes ON issues.scope_id = reopened_scopes.id").
+		Where("issues.reopen_count > 0").
+		Select("reopened_scopes.resource_type AS resource_type, reopened_scopes.resource_name AS resource_name, SUM(issues.reopen_count) AS reopens").
+		Group("reopened_scopes.resource_type, reopened_scopes.resource_name").
+		Order("reopens DESC").
+		Order("resource_type").
+		Order("resource_name")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	reopens := []ResourceReopens{}
+	if err := query.Scan(&reopens).Error; err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to count reopens by resource")
+		return nil, fmt.Errorf("failed to count reopens by resource: %w", err)
+	}
+	return reopens, nil
+}
+
+// ResolutionTimes summarizes the resolution times of the resolved issues
+// matching filters.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: The filters selecting the issues
+//
+// Returns:
+//   - *ResolutionStats: The resolution times, all zero if no issue was resolved
+//   - error: Database error or nil
+func (i *issueRepository) ResolutionTimes(ctx context.Context, filters IssueQueryFilters) (*ResolutionStats, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	// Seconds between detection and resolution
+	seconds := "EXTRACT(EPOCH FROM (resolved_at - detected_at))"
+	if i.db.Dialector.Name() == "sqlite" {
+		seconds = "(julianday(resolved_at) - julianday(detected_at)) * 86400"
+	}
+
+	var row struct {
+		Count   int64
+		Average *float64
+		Min     *float64
+		Max     *float64
+	}
+	err := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters).
+		Where("resolved_at IS NOT NULL").
+		Select(fmt.Sprintf("COUNT(*) AS count, AVG(%[1]s) AS average, MIN(%[1]s) AS min, MAX(%[1]s) AS max", seconds)).
+		Scan(&row).Error
+	if err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).Error("Failed to compute resolution times")
+		return nil, fmt.Errorf("failed to compute resolution times: %w", err)
+	}
+
+	return &ResolutionStats{
+		Count:   row.Count,
+		Average: secondsToDuration(row.Average),
+		Min:     secondsToDuration(row.Min),
+		Max:     secondsToDuration(row.Max),
+	}, nil
+}
+
+// secondsToDuration converts an aggregated number of seconds to a duration
+// rounded to the second, NULL aggregates being zero
+func secondsToDuration(seconds *float64) time.Duration {
+	if seconds == nil {
+		return 0
+	}
+	return time.Duration(*seconds * float64(time.Second)).Round(time.Second)
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+func TestIssueRepository_CountByFilters(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for _, namespace := range []string{"team-a", "team-a", "team-b"} {
+		req := createTestIssue("Count Test", namespace)
+		req.Scope.ResourceName = "component-" + namespace + "-" + time.Now().Format(time.RFC3339Nano)
+		if _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filters  IssueQueryFilters
+		expected int64
+	}{
+		{name: "all issues", filters: IssueQueryFilters{}, expected: 3},
+		{name: "namespace", filters: IssueQueryFilters{Namespace: "team-a"}, expected: 2},
+		{name: "resource", filters: IssueQueryFilters{ResourceType: "component"}, expected: 3},
+		{name: "pagination is ignored", filters: IssueQueryFilters{Limit: 1, Offset: 1}, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := repo.CountByFilters(ctx, tt.filters)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected %d issues, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_CountGroupedBy(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issues := []struct {
+		resource string
+		severity models.Severity
+	}{
+		{"api", models.SeverityCritical},
+		{"api-db", models.SeverityMajor},
+		{"ui", models.SeverityMajor},
+	}
+	for _, issue := range issues {
+		req := createTestIssue("Group Test", "team-a")
+		req.Scope.ResourceName = issue.resource
+		req.Severity = issue.severity
+		if _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filters  IssueQueryFilters
+		field    string
+		expected []GroupCount
+	}{
+		{
+			name:     "severity",
+			field:    CountBySeverity,
+			expected: []GroupCount{{Key: "major", Count: 2}, {Key: "critical", Count: 1}},
+		},
+		{
+			name:     "resource",
+			field:    CountByResource,
+			expected: []GroupCount{{Key: "component/api", Count: 1}, {Key: "component/api-db", Count: 1}, {Key: "component/ui", Count: 1}},
+		},
+		{
+			name:     "resource with a resource filter",
+			filters:  IssueQueryFilters{ResourceName: "ui"},
+			field:    CountByResource,
+			expected: []GroupCount{{Key: "component/ui", Count: 1}},
+		},
+		{
+			name:     "unset values",
+			field:    CountByAssignee,
+			expected: []GroupCount{{Key: "", Count: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, err := repo.CountGroupedBy(ctx, tt.filters, tt.field)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if !slices.Equal(counts, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, counts)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_CountGroupedBy_InvalidField(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	if _, err := repo.CountGroupedBy(ctx, IssueQueryFilters{}, "title; DROP TABLE issues"); err == nil {
+		t.Error("Expected an error for an invalid field, got nil")
+	}
+}
+
+func TestIssueRepository_ResolutionTimes(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	stats, err := repo.ResolutionTimes(ctx, IssueQueryFilters{})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if *stats != (ResolutionStats{}) {
+		t.Errorf("Expected empty stats without resolved issues, got %+v", stats)
+	}
+
+	detectedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for n, duration := range []time.Duration{time.Hour, 3 * time.Hour, 0} {
+		req := createTestIssue("Resolution Test", "team-a")
+		req.Scope.ResourceName = []string{"api", "ui", "db"}[n]
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+
+		// The last issue stays active
+		updates := map[string]any{"detected_at": detectedAt}
+		if duration > 0 {
+			updates["state"] = models.IssueStateResolved
+			updates["resolved_at"] = detectedAt.Add(duration)
+		}
+		if err := db.Model(&models.Issue{}).Where("id = ?", issue.ID).Updates(updates).Error; err != nil {
+			t.Fatalf("Failed to update test issue: %v", err)
+		}
+	}
+
+	stats, err = repo.ResolutionTimes(ctx, IssueQueryFilters{Namespace: "team-a"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expected := ResolutionStats{Count: 2, Average: 2 * time.Hour, Min: time.Hour, Max: 3 * time.Hour}
+	if *stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *stats)
+	}
+}
+
+func TestIssueRepository_CountReopensByResource(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// The issue of api flaps twice, the one of ui once, and the one of db never
+	for resource, reopens := range map[string]int{"api": 2, "ui": 1, "db": 0} {
+		req := createTestIssue("Reopen Test", "team-a")
+		req.Scope.ResourceName = resource
+		issue, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		for range reopens {
+			for _, state := range []models.IssueState{models.IssueStateResolved, models.IssueStateActive} {
+				if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: state}); err != nil {
+					t.Fatalf("Failed to update test issue: %v", err)
+				}
+			}
+		}
+	}
+
+	reopens, err := repo.CountReopensByResource(ctx, IssueQueryFilters{Namespace: "team-a"}, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expected := []ResourceReopens{
+		{ResourceType: "component", ResourceName: "api", Reopens: 2},
+		{ResourceType: "component", ResourceName: "ui", Reopens: 1},
+	}
+	if !slices.Equal(reopens, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, reopens)
+	}
+
+	if reopens, _ := repo.CountReopensByResource(ctx, IssueQueryFilters{Namespace: "team-a"}, 1); len(reopens) != 1 {
+		t.Errorf("Expected the most reopened resource only, got %+v", reopens)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if reopens, _ := repo.CountReopensByResource(ctx, IssueQueryFilters{ReopenedSince: &future}, 0); len(reopens) != 0 {
+		t.Errorf("Expected no resources reopened since %s, got %+v", future, reopens)
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"gorm.io/gorm"
+)
+
+// FindScope finds an issue scope using its ID, along with its issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the scope
+//
+// Returns:
+//   - *models.IssueScope: The scope if found, nil if not
+//   - error: Database error or nil
+func (i *issueRepository) FindScope(ctx context.Context, id string) (*models.IssueScope, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	var scope models.IssueScope
+	if err := i.db.WithContext(ctx).Preload("Issue").First(&scope, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("scope_id", id).Error("Failed to find issue scope")
+		return nil, fmt.Errorf("failed to find issue scope: %w", err)
+	}
+	return &scope, nil
+}
+
+// FindByResource finds the issues of a namespace scoped to a resource which
+// were detected, resolved or reopened since a time, to draw the timeline of
+// the resource.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace of the issues
+//   - scope: The resource, its type, name and namespace
+//   - since: The start of the timeline
+//   - limit: The maximum number of issues, the most recently detected ones being kept
+//
+// Returns:
+//   - []models.Issue: The issues found, the most recently detected first
+//   - error: Database error or nil
+func (i *issueRepository) FindByResource(ctx context.Context, namespace string, scope models.IssueScope, since time.Time, limit int) ([]models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	issues := []models.Issue{}
+	err := i.db.WithContext(ctx).
+		Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
+		Where("issues.namespace = ?", namespace).
+		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ? AND issue_scopes.resource_namespace = ?",
+			scope.ResourceType, scope.ResourceName, scope.ResourceNamespace).
+		Where("issues.detected_at >= ? OR issues.resolved_at >= ? OR issues.reopened_at >= ?", since, since, since).
+		Order("issues.detected_at DESC").
+		Limit(limit).
+		Find(&issues).Error
+	if err != nil {
+		logging.FromContext(ctx, i.logger).WithError(err).WithField("scope_id", scope.ID).Error("Failed to find issues by resource")
+		return nil, fmt.Errorf("failed to find issues by resource: %w", err)
+	}
+	return issues, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type issueWatchRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewIssueWatchRepository creates a new IssueWatch repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - IssueWatchRepository
+func NewIssueWatchRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) IssueWatchRepository {
+	return &issueWatchRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Watch makes a user watch an issue. Watching an issue again keeps the
+// existing watch.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - user: The user
+//
+// Returns:
+//   - *models.IssueWatch: The watch
+//   - error: Database error or nil
+func (w *issueWatchRepository) Watch(ctx context.Context, issueID, user string) (*models.IssueWatch, error) {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	err := w.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&models.IssueWatch{IssueID: issueID, User: user}).Error
+	if err != nil {
+		logging.FromContext(ctx, w.logger).WithError(err).WithField("issue_id", issueID).Error("failed to watch issue")
+		return nil, fmt.Errorf("failed to watch issue: %w", err)
+	}
+
+	var watch models.IssueWatch
+	if err := w.db.WithContext(ctx).Where("issue_id = ? AND \"user\" = ?", issueID, user).First(&watch).Error; err != nil {
+		return nil, fmt.Errorf("failed to load issue watch: %w", err)
+	}
+	return &watch, nil
+}
+
+// Unwatch makes a user stop watching an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - user: The user
+//
+// Returns:
+//   - error: "issue watch not found" if the user doesn't watch the issue, database error or nil
+func (w *issueWatchRepository) Unwatch(ctx context.Context, issueID, user string) error {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	result := w.db.WithContext(ctx).Where("issue_id = ? AND \"user\" = ?", issueID, user).Delete(&models.IssueWatch{})
+	if result.Error != nil {
+		logging.FromContext(ctx, w.logger).WithError(result.Error).WithField("issue_id", issueID).Error("failed to unwatch issue")
+		return fmt.Errorf("failed to unwatch issue: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError("issue watch not found")
+	}
+	return nil
+}
+
+// FindWatchedIssues finds the issues a user watches, the most recently
+// updated first.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - user: The user
+//
+// Returns:
+//   - []models.Issue: The watched issues
+//   - error: Database error or nil
+func (w *issueWatchRepository) FindWatchedIssues(ctx context.Context, user string) ([]models.Issue, error) {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	var issues []models.Issue
+	err := w.db.WithContext(ctx).
+		Preload("Scope").
+		Preload("Links").
+		Preload("Labels", orderLabels).
+		Joins("JOIN issue_watches ON issue_watches.issue_id = issues.id").
+		Where("issue_watches.\"user\" = ?", user).
+		Order("issues.updated_at DESC").
+		Find(&issues).Error
+	if err != nil {
+		logging.FromContext(ctx, w.logger).WithError(err).Error("failed to find watched issues")
+		return nil, fmt.Errorf("failed to find watched issues: %w", err)
+	}
+	return issues, nil
+}
+
+// FindWatchers finds the users watching an issue, e.g. to notify them of its
+// changes.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - []string: The users watching the issue
+//   - error: Database error or nil
+func (w *issueWatchRepository) FindWatchers(ctx context.Context, issueID string) ([]string, error) {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	var users []string
+	err := w.db.WithContext(ctx).
+		Model(&models.IssueWatch{}).
+		Where("issue_id = ?", issueID).
+		Order("\"user\"").
+		Pluck("user", &users).Error
+	if err != nil {
+		logging.FromContext(ctx, w.logger).WithError(err).WithField("issue_id", issueID).Error("failed to find issue watchers")
+		return nil, fmt.Errorf("failed to find issue watchers: %w", err)
+	}
+	return users, nil
+}
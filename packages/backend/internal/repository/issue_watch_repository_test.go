@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestIssueWatchRepository_Watch(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewIssueWatchRepository(db, logrus.New(), 0)
+
+	issue, err := issues.Create(ctx, createTestIssue("Watched Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	watch, err := repo.Watch(ctx, issue.ID, "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if watch.IssueID != issue.ID || watch.User != "alice" || watch.CreatedAt.IsZero() {
+		t.Errorf("Unexpected watch %+v", watch)
+	}
+
+	// Watching again keeps the existing watch
+	again, err := repo.Watch(ctx, issue.ID, "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !again.CreatedAt.Equal(watch.CreatedAt) {
+		t.Errorf("Expected the existing watch, got %+v", again)
+	}
+
+	if _, err := repo.Watch(ctx, issue.ID, "bob"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	watchers, err := repo.FindWatchers(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(watchers) != 2 || watchers[0] != "alice" || watchers[1] != "bob" {
+		t.Errorf("Expected watchers alice and bob, got %v", watchers)
+	}
+}
+
+func TestIssueWatchRepository_FindWatchedIssues(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewIssueWatchRepository(db, logrus.New(), 0)
+
+	watched, err := issues.Create(ctx, createTestIssue("Watched Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := issues.Create(ctx, createTestIssue("Other Issue", "test-namespace")); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := repo.Watch(ctx, watched.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	found, err := repo.FindWatchedIssues(ctx, "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(found) != 1 || found[0].ID != watched.ID || found[0].Scope.ResourceName == "" {
+		t.Errorf("Expected the watched issue along with its scope, got %+v", found)
+	}
+
+	if found, _ := repo.FindWatchedIssues(ctx, "bob"); len(found) != 0 {
+		t.Errorf("Expected no watched issues, got %d", len(found))
+	}
+}
+
+func TestIssueWatchRepository_Unwatch(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewIssueWatchRepository(db, logrus.New(), 0)
+
+	issue, err := issues.Create(ctx, createTestIssue("Watched Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := repo.Watch(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if err := repo.Unwatch(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := repo.Unwatch(ctx, issue.ID, "alice"); err == nil || err.Error() != "issue watch not found" {
+		t.Errorf("Expected issue watch not found, got %v", err)
+	}
+
+	// Deleting an issue deletes its watches
+	if _, err := repo.Watch(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := issues.Delete(ctx, issue.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if watchers, _ := repo.FindWatchers(ctx, issue.ID); len(watchers) != 0 {
+		t.Errorf("Expected the watches to be deleted, got %v", watchers)
+	}
+}
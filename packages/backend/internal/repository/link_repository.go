@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type linkRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewLinkRepository creates a new Link repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - LinkRepository
+func NewLinkRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) LinkRepository {
+	return &linkRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// WithTx returns a repository running its queries in a database transaction
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//
+// Returns:
+//   - LinkRepository
+func (l *linkRepository) WithTx(tx *gorm.DB) LinkRepository {
+	return &linkRepository{
+		db:           tx,
+		logger:       l.logger,
+		queryTimeout: l.queryTimeout,
+	}
+}
+
+// CreateBatch creates links for an issue in a single insert.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue the links belong to
+//   - links: The links to create, their IssueID is set to issueID
+//
+// Returns:
+//   - error: Database error or nil
+func (l *linkRepository) CreateBatch(ctx context.Context, issueID string, links []models.Link) error {
+	ctx, cancel := withQueryTimeout(ctx, l.queryTimeout)
+	defer cancel()
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	for n := range links {
+		links[n].IssueID = issueID
+	}
+	if err := l.db.WithContext(ctx).Omit(clause.Associations).Create(&links).Error; err != nil {
+		logging.FromContext(ctx, l.logger).WithError(err).WithField("issue_id", issueID).Error("failed to create links")
+		return fmt.Errorf("failed to create links: %w", err)
+	}
+	return nil
+}
+
+// DeleteByIssueID deletes all the links of an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - error: Database error or nil
+func (l *linkRepository) DeleteByIssueID(ctx context.Context, issueID string) error {
+	ctx, cancel := withQueryTimeout(ctx, l.queryTimeout)
+	defer cancel()
+
+	if err := l.db.WithContext(ctx).Where("issue_id = ?", issueID).Delete(&models.Link{}).Error; err != nil {
+		logging.FromContext(ctx, l.logger).WithError(err).WithField("issue_id", issueID).Error("failed to delete links")
+		return fmt.Errorf("failed to delete links: %w", err)
+	}
+	return nil
+}
+
+// FindByIssueID finds the links of an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - []models.Link: The links of the issue
+//   - error: Database error or nil
+func (l *linkRepository) FindByIssueID(ctx context.Context, issueID string) ([]models.Link, error) {
+	ctx, cancel := withQueryTimeout(ctx, l.queryTimeout)
+	defer cancel()
+
+	links := []models.Link{}
+	if err := l.db.WithContext(ctx).Where("issue_id = ?", issueID).Find(&links).Error; err != nil {
+		logging.FromContext(ctx, l.logger).WithError(err).WithField("issue_id", issueID).Error("failed to find links")
+		return nil, fmt.Errorf("failed to find links: %w", err)
+	}
+	return links, nil
+}
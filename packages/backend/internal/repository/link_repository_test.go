@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupLinkTestScenario sets up a link repository along with an issue to attach links to
+func setupLinkTestScenario(t *testing.T) (context.Context, *gorm.DB, LinkRepository, string) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	ctx := context.Background()
+
+	issue, err := NewIssueRepository(db, logger, 0).Create(ctx, createTestIssue("Link Test", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	return ctx, db, NewLinkRepository(db, logger, 0), issue.ID
+}
+
+func TestLinkRepository_CreateBatch(t *testing.T) {
+	ctx, _, repo, issueID := setupLinkTestScenario(t)
+
+	links := []models.Link{
+		{Title: "Logs", URL: "https://konflux.test/logs"},
+		{Title: "Pipeline Run", URL: "https://konflux.test/pipelineruns/abc"},
+	}
+	if err := repo.CreateBatch(ctx, issueID, links); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	found, err := repo.FindByIssueID(ctx, issueID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	// The test issue is created with one link
+	if len(found) != 3 {
+		t.Fatalf("Expected 3 links, got %d", len(found))
+	}
+	for _, link := range found {
+		if link.ID == "" || link.IssueID != issueID {
+			t.Errorf("Expected link with an ID for issue %s, got %+v", issueID, link)
+		}
+	}
+}
+
+func TestLinkRepository_CreateBatch_Empty(t *testing.T) {
+	ctx, _, repo, issueID := setupLinkTestScenario(t)
+
+	if err := repo.CreateBatch(ctx, issueID, nil); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+}
+
+func TestLinkRepository_DeleteByIssueID(t *testing.T) {
+	ctx, _, repo, issueID := setupLinkTestScenario(t)
+
+	if err := repo.DeleteByIssueID(ctx, issueID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	found, err := repo.FindByIssueID(ctx, issueID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Expected no links after delete, got %d", len(found))
+	}
+}
+
+func TestLinkRepository_WithTx(t *testing.T) {
+	ctx, db, repo, issueID := setupLinkTestScenario(t)
+
+	errRollback := errors.New("rollback")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repo.WithTx(tx)
+		if err := txRepo.DeleteByIssueID(ctx, issueID); err != nil {
+			return err
+		}
+		if found, err := txRepo.FindByIssueID(ctx, issueID); err != nil || len(found) != 0 {
+			t.Errorf("Expected no links in the transaction, got %d (%v)", len(found), err)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Expected the transaction to be rolled back, got %v", err)
+	}
+
+	// The delete was rolled back with the transaction
+	found, err := repo.FindByIssueID(ctx, issueID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(found) != 1 {
+		t.Errorf("Expected 1 link after the rollback, got %d", len(found))
+	}
+}
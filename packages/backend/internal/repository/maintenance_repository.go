@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maintenanceModels are the models whose tables are maintained, in the order
+// of their migrations
+var maintenanceModels = []any{
+	&models.IssueScope{},
+	&models.Issue{},
+	&models.Link{},
+	&models.Label{},
+	&models.ExternalRef{},
+	&models.NamespaceSettings{},
+	&models.IssueWatch{},
+	&models.SavedView{},
+	&models.TriageRule{},
+	&models.TriageEvent{},
+	&models.WebhookEvent{},
+	&models.RelatedIssue{},
+}
+
+// scopeDedupKey is the SQL expression of the deduplication key of an issue
+// derived from its scope, see models.ScopeDedupKey
+const scopeDedupKey = "(SELECT issue_scopes.resource_type || '/' || issue_scopes.resource_name FROM issue_scopes WHERE issue_scopes.id = issues.scope_id)"
+
+type maintenanceRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+// NewMaintenanceRepository creates a new Maintenance repository, running the
+// maintenance tasks of the database. Tasks aren't bounded by the query
+// timeout, rebuilding the indexes of large tables taking minutes.
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - MaintenanceRepository
+func NewMaintenanceRepository(db *gorm.DB, logger *logrus.Logger) MaintenanceRepository {
+	return &maintenanceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Tables returns the tables of the models of the API, in the order of their
+// migrations.
+//
+// Returns:
+//   - []string: The names of the tables
+//   - error: A model can't be parsed
+func (m *maintenanceRepository) Tables() ([]string, error) {
+	tables := make([]string, 0, len(maintenanceModels))
+	for _, model := range maintenanceModels {
+		stmt := &gorm.Statement{DB: m.db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		tables = append(tables, stmt.Schema.Table)
+	}
+	return tables, nil
+}
+
+// Reindex rebuilds the indexes of a table.
+//
+// Parameters:
+//   - ctx: Context for cancellations
+//   - table: The table, see Tables
+//
+// Returns:
+//   - error: Database error or nil
+func (m *maintenanceRepository) Reindex(ctx context.Context, table string) error {
+	statement := "REINDEX ?"
+	if m.db.Dialector.Name() == "postgres" {
+		statement = "REINDEX TABLE ?"
+	}
+	return m.exec(ctx, "reindex", table, statement, clause.Table{Name: table})
+}
+
+// Vacuum reclaims the space of the deleted rows of a table and refreshes its
+// statistics. SQLite only vacuums whole databases.
+//
+// Parameters:
+//   - ctx: Context for cancellations
+//   - table: The table, see Tables
+//
+// Returns:
+//   - error: Database error or nil
+func (m *maintenanceRepository) Vacuum(ctx context.Context, table string) error {
+	if m.db.Dialector.Name() != "postgres" {
+		return m.exec(ctx, "vacuum", table, "VACUUM")
+	}
+	// VACUUM can't run in a transaction, which Exec doesn't open
+	return m.exec(ctx, "vacuum", table, "VACUUM (ANALYZE) ?", clause.Table{Name: table})
+}
+
+// Analyze refreshes the statistics of a table used by the query planner.
+//
+// Parameters:
+//   - ctx: Context for cancellations
+//   - table: The table, see Tables
+//
+// Returns:
+//   - error: Database error or nil
+func (m *maintenanceRepository) Analyze(ctx context.Context, table string) error {
+	return m.exec(ctx, "analyze", table, "ANALYZE ?", clause.Table{Name: table})
+}
+
+// exec runs the maintenance statement of a task on a table
+func (m *maintenanceRepository) exec(ctx context.Context, task, table, statement string, args ...any) error {
+	if err := m.db.WithContext(ctx).Exec(statement, args...).Error; err != nil {
+		logging.FromContext(ctx, m.logger).WithError(err).WithFields(logrus.Fields{"task": task, "table": table}).Error("failed to maintain table")
+		return fmt.Errorf("failed to %s table %s: %w", task, table, err)
+	}
+	return nil
+}
+
+// RecomputeDedupKeys recomputes the deduplication keys of the issues from
+// their scope, e.g. after a scope was fixed by hand. Issues whose key is up
+// to date, or derived from their fingerprint, are left untouched, and the
+// update times of issues aren't changed.
+//
+// Parameters:
+//   - ctx: Context for cancellations
+//
+// Returns:
+//   - int64: The number of issues whose key was out of date
+//   - error: Database error, e.g. two open issues getting the same key, or nil
+func (m *maintenanceRepository) RecomputeDedupKeys(ctx context.Context) (int64, error) {
+	result := m.db.WithContext(ctx).
+		Model(&models.Issue{}).
+		Where("fingerprint = '' AND dedup_key <> "+scopeDedupKey).
+		UpdateColumn("dedup_key", gorm.Expr(scopeDedupKey))
+	if result.Error != nil {
+		logging.FromContext(ctx, m.logger).WithError(result.Error).Error("failed to recompute deduplication keys")
+		return 0, fmt.Errorf("failed to recompute deduplication keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
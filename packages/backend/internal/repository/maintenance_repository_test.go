@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMaintenanceRepository_Tables(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewMaintenanceRepository(db, logrus.New())
+
+	tables, err := repo.Tables()
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	for _, table := range []string{"issues", "issue_scopes", "links", "webhook_events"} {
+		if !slices.Contains(tables, table) {
+			t.Errorf("Expected table %s, got %v", table, tables)
+		}
+	}
+
+	// Every task runs on every table
+	for _, table := range tables {
+		if err := repo.Reindex(ctx, table); err != nil {
+			t.Errorf("Unexpected error reindexing %s, got %v", table, err)
+		}
+		if err := repo.Analyze(ctx, table); err != nil {
+			t.Errorf("Unexpected error analyzing %s, got %v", table, err)
+		}
+		if err := repo.Vacuum(ctx, table); err != nil {
+			t.Errorf("Unexpected error vacuuming %s, got %v", table, err)
+		}
+	}
+
+	if err := repo.Reindex(ctx, "unknown_table"); err == nil {
+		t.Error("Expected an error reindexing an unknown table")
+	}
+}
+
+func TestMaintenanceRepository_RecomputeDedupKeys(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewMaintenanceRepository(db, logrus.New())
+
+	drifted, err := issues.Create(ctx, createTestIssue("Drifted issue", "team-alpha"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := issues.Create(ctx, createTestIssue("Up to date issue", "team-beta")); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// A scope fixed by hand, without updating the key of its issue
+	if err := db.Model(&models.IssueScope{}).Where("id = ?", drifted.ScopeID).Update("resource_name", "renamed-component").Error; err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	recomputed, err := repo.RecomputeDedupKeys(ctx)
+	if err != nil || recomputed != 1 {
+		t.Fatalf("Expected 1 key recomputed, got %d, %v", recomputed, err)
+	}
+	var issue models.Issue
+	db.First(&issue, "id = ?", drifted.ID)
+	if expected := models.ScopeDedupKey("component", "renamed-component"); issue.DedupKey != expected {
+		t.Errorf("Expected key %q, got %q", expected, issue.DedupKey)
+	}
+	if !issue.UpdatedAt.Equal(drifted.UpdatedAt) {
+		t.Errorf("Expected the update time to be kept, got %v", issue.UpdatedAt)
+	}
+
+	// Keys of fingerprinted issues aren't derived from their scope
+	req := createTestIssue("Fingerprinted issue", "team-gamma")
+	req.Fingerprint = "flaky/TestLogin"
+	if _, err := issues.Create(ctx, req); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Keys are up to date
+	if recomputed, _ := repo.RecomputeDedupKeys(ctx); recomputed != 0 {
+		t.Errorf("Expected no keys recomputed, got %d", recomputed)
+	}
+}
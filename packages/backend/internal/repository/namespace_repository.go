@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type namespaceRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewNamespaceRepository creates a new Namespace repository, operating on all
+// the records of namespaces at once
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - NamespaceRepository
+func NewNamespaceRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) NamespaceRepository {
+	return &namespaceRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// CountIssues counts the issues of a namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace
+//   - activeOnly: Whether to only count the open issues, see models.IssueState.Open
+//
+// Returns:
+//   - int64: The number of issues
+//   - error: Database error or nil
+func (n *namespaceRepository) CountIssues(ctx context.Context, namespace string, activeOnly bool) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	query := n.db.WithContext(ctx).Model(&models.Issue{}).Where("namespace = ?", namespace)
+	if activeOnly {
+		query = query.Where("state <> ?", models.IssueStateResolved)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		logging.FromContext(ctx, n.logger).WithError(err).WithField("namespace", namespace).Error("failed to count namespace issues")
+		return 0, fmt.Errorf("failed to count namespace issues: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteIssues deletes a batch of issues of a namespace, so that namespaces
+// with many issues are deleted in several short transactions rather than a
+// long one. The issues are deleted by deleting their scopes, the foreign keys
+// cascading the delete to the issues and their links, labels, external
+// references, related issue relationships, watches and triage events.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace
+//   - limit: How many issues to delete at most
+//
+// Returns:
+//   - int64: The number of issues deleted, 0 when the namespace has none left
+//   - error: Database error or nil
+func (n *namespaceRepository) DeleteIssues(ctx context.Context, namespace string, limit int) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	scopeIDs := n.db.Model(&models.Issue{}).Select("scope_id").Where("namespace = ?", namespace).Limit(limit)
+	result := n.db.WithContext(ctx).Where("id IN (?)", scopeIDs).Delete(&models.IssueScope{})
+	if result.Error != nil {
+		logging.FromContext(ctx, n.logger).WithError(result.Error).WithField("namespace", namespace).Error("failed to delete namespace issues")
+		return 0, fmt.Errorf("failed to delete namespace issues: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ResolveIssues resolves a batch of open issues of a namespace, see DeleteIssues.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace
+//   - resolution: Why and by whom the issues are resolved
+//   - limit: How many issues to resolve at most
+//
+// Returns:
+//   - int64: The number of issues resolved, 0 when the namespace has no open issues left
+//   - error: Database error or nil
+func (n *namespaceRepository) ResolveIssues(ctx context.Context, namespace string, resolution dto.Resolution, limit int) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	ids := n.db.Model(&models.Issue{}).Select("id").
+		Where("namespace = ? AND state <> ?", namespace, models.IssueStateResolved).
+		Limit(limit)
+	result := n.db.WithContext(ctx).
+		Model(&models.Issue{}).
+		Where("id IN (?)", ids).
+		Updates(map[string]any{
+			"state":             models.IssueStateResolved,
+			"resolved_at":       &now,
+			"resolution_reason": resolution.Reason,
+			"resolved_by":       resolution.ResolvedBy,
+			"updated_at":        now,
+		})
+	if result.Error != nil {
+		logging.FromContext(ctx, n.logger).WithError(result.Error).WithField("namespace", namespace).Error("failed to resolve namespace issues")
+		return 0, fmt.Errorf("failed to resolve namespace issues: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteData deletes the settings, saved views and triage rules of a
+// namespace, and its webhook events when withEvents is set, in a transaction.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace
+//   - withEvents: Whether to delete the webhook events of the namespace too
+//
+// Returns:
+//   - *dto.NamespaceData: The number of records deleted
+//   - error: Database error or nil
+func (n *namespaceRepository) DeleteData(ctx context.Context, namespace string, withEvents bool) (*dto.NamespaceData, error) {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	var deleted dto.NamespaceData
+	err := n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		type table struct {
+			model any
+			count *int64
+		}
+		tables := []table{
+			{&models.NamespaceSettings{}, &deleted.Settings},
+			{&models.SavedView{}, &deleted.SavedViews},
+			{&models.TriageRule{}, &deleted.TriageRules},
+		}
+		if withEvents {
+			tables = append(tables, table{&models.WebhookEvent{}, &deleted.WebhookEvents})
+		}
+		for _, table := range tables {
+			result := tx.Where("namespace = ?", namespace).Delete(table.model)
+			if result.Error != nil {
+				return result.Error
+			}
+			*table.count = result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		logging.FromContext(ctx, n.logger).WithError(err).WithField("namespace", namespace).Error("failed to delete namespace data")
+		return nil, fmt.Errorf("failed to delete namespace data: %w", err)
+	}
+	return &deleted, nil
+}
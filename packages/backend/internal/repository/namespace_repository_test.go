@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestNamespaceRepository_DeleteIssues(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewNamespaceRepository(db, logrus.New(), 0)
+
+	// Issues of different types, which aren't duplicates of each other
+	var offboarded *models.Issue
+	for _, issueType := range []models.IssueType{models.IssueTypeBuild, models.IssueTypeTest, models.IssueTypeRelease} {
+		req := createTestIssue("Offboarded issue", "team-alpha")
+		req.IssueType = issueType
+		issue, err := issues.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		offboarded = issue
+	}
+	kept, err := issues.Create(ctx, createTestIssue("Kept issue", "team-beta"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if count, err := repo.CountIssues(ctx, "team-alpha", false); err != nil || count != 3 {
+		t.Fatalf("Expected 3 issues, got %d, %v", count, err)
+	}
+
+	// The issues are deleted in batches
+	deleted, err := repo.DeleteIssues(ctx, "team-alpha", 2)
+	if err != nil || deleted != 2 {
+		t.Fatalf("Expected 2 issues deleted, got %d, %v", deleted, err)
+	}
+	if deleted, _ := repo.DeleteIssues(ctx, "team-alpha", 2); deleted != 1 {
+		t.Errorf("Expected the last issue deleted, got %d", deleted)
+	}
+	if deleted, _ := repo.DeleteIssues(ctx, "team-alpha", 2); deleted != 0 {
+		t.Errorf("Expected no issues left, got %d", deleted)
+	}
+
+	// Deleting the scopes cascades to the issues and their links
+	var count int64
+	db.Model(&models.Issue{}).Where("namespace = ?", "team-alpha").Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the issues to be deleted, got %d", count)
+	}
+	db.Model(&models.Link{}).Where("issue_id = ?", offboarded.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the links to be deleted, got %d", count)
+	}
+	if found, _ := issues.FindByID(ctx, kept.ID); found == nil {
+		t.Error("Expected the issue of the other namespace to be kept")
+	}
+}
+
+func TestNamespaceRepository_ResolveIssues(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewNamespaceRepository(db, logrus.New(), 0)
+
+	issue, err := issues.Create(ctx, createTestIssue("Archived issue", "team-alpha"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := issues.Create(ctx, createTestIssue("Kept issue", "team-beta")); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if count, _ := repo.CountIssues(ctx, "team-alpha", true); count != 1 {
+		t.Fatalf("Expected 1 active issue, got %d", count)
+	}
+	resolution := dto.Resolution{Reason: "Namespace offboarded", ResolvedBy: "admin"}
+	if resolved, err := repo.ResolveIssues(ctx, "team-alpha", resolution, 10); err != nil || resolved != 1 {
+		t.Fatalf("Expected 1 issue resolved, got %d, %v", resolved, err)
+	}
+	if count, _ := repo.CountIssues(ctx, "team-alpha", true); count != 0 {
+		t.Errorf("Expected no active issues, got %d", count)
+	}
+	if count, _ := repo.CountIssues(ctx, "team-beta", true); count != 1 {
+		t.Errorf("Expected the issue of the other namespace to stay active, got %d", count)
+	}
+
+	found, _ := issues.FindByID(ctx, issue.ID)
+	if found.State != models.IssueStateResolved || found.ResolvedAt == nil || found.ResolvedBy != "admin" || found.ResolutionReason != "Namespace offboarded" {
+		t.Errorf("Expected the issue to be resolved, got %+v", found)
+	}
+}
+
+func TestNamespaceRepository_DeleteData(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewNamespaceRepository(db, logrus.New(), 0)
+
+	for _, namespace := range []string{"team-alpha", "team-beta"} {
+		records := []any{
+			&models.NamespaceSettings{Namespace: namespace, RetentionDays: 30},
+			&models.SavedView{Namespace: namespace, Name: "critical", Query: "severity=critical"},
+			&models.TriageRule{Namespace: namespace, Name: "assign", Assignee: "alice"},
+			&models.WebhookEvent{Source: "pipeline-failure", Namespace: namespace, SignatureStatus: models.WebhookSignatureUnchecked},
+		}
+		for _, record := range records {
+			if err := db.Create(record).Error; err != nil {
+				t.Fatalf("Failed to create %T: %v", record, err)
+			}
+		}
+	}
+	// Personal views don't belong to a namespace
+	if err := db.Create(&models.SavedView{User: "alice", Name: "mine", Query: "assignee=alice"}).Error; err != nil {
+		t.Fatalf("Failed to create view: %v", err)
+	}
+
+	deleted, err := repo.DeleteData(ctx, "team-alpha", false)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if *deleted != (dto.NamespaceData{Settings: 1, SavedViews: 1, TriageRules: 1}) {
+		t.Errorf("Expected the configuration to be deleted and the events kept, got %+v", deleted)
+	}
+	deleted, _ = repo.DeleteData(ctx, "team-alpha", true)
+	if *deleted != (dto.NamespaceData{WebhookEvents: 1}) {
+		t.Errorf("Expected the events to be deleted, got %+v", deleted)
+	}
+
+	var count int64
+	db.Model(&models.SavedView{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected the views of team-beta and alice to be kept, got %d", count)
+	}
+	db.Model(&models.WebhookEvent{}).Where("namespace = ?", "team-beta").Count(&count)
+	if count != 1 {
+		t.Errorf("Expected the events of team-beta to be kept, got %d", count)
+	}
+}
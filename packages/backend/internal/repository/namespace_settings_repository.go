@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type namespaceSettingsRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewNamespaceSettingsRepository creates a new NamespaceSettings repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - NamespaceSettingsRepository
+func NewNamespaceSettingsRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) NamespaceSettingsRepository {
+	return &namespaceSettingsRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Find finds the settings of a namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace
+//
+// Returns:
+//   - *models.NamespaceSettings: The settings of the namespace, nil if it has none
+//   - error: Database error or nil
+func (n *namespaceSettingsRepository) Find(ctx context.Context, namespace string) (*models.NamespaceSettings, error) {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	var settings models.NamespaceSettings
+	if err := n.db.WithContext(ctx).Where("namespace = ?", namespace).First(&settings).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logging.FromContext(ctx, n.logger).WithError(err).WithField("namespace", namespace).Error("failed to find namespace settings")
+		return nil, fmt.Errorf("failed to find namespace settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// Save creates the settings of a namespace, or replaces them if it has some.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - settings: The settings, for the namespace settings.Namespace
+//
+// Returns:
+//   - *models.NamespaceSettings: The saved settings
+//   - error: Database error or nil
+func (n *namespaceSettingsRepository) Save(ctx context.Context, settings models.NamespaceSettings) (*models.NamespaceSettings, error) {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	// Replacing the settings keeps when they were created
+	err := n.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "namespace"}},
+		UpdateAll: true,
+	}).Create(&settings).Error
+	if err != nil {
+		logging.FromContext(ctx, n.logger).WithError(err).WithField("namespace", settings.Namespace).Error("failed to save namespace settings")
+		return nil, fmt.Errorf("failed to save namespace settings: %w", err)
+	}
+
+	var saved models.NamespaceSettings
+	if err := n.db.WithContext(ctx).Where("namespace = ?", settings.Namespace).First(&saved).Error; err != nil {
+		return nil, fmt.Errorf("failed to load saved namespace settings: %w", err)
+	}
+	return &saved, nil
+}
+
+// Delete deletes the settings of a namespace, resetting them to the defaults.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace
+//
+// Returns:
+//   - error: "namespace settings not found" if the namespace has no settings, database error or nil
+func (n *namespaceSettingsRepository) Delete(ctx context.Context, namespace string) error {
+	ctx, cancel := withQueryTimeout(ctx, n.queryTimeout)
+	defer cancel()
+
+	result := n.db.WithContext(ctx).Where("namespace = ?", namespace).Delete(&models.NamespaceSettings{})
+	if result.Error != nil {
+		logging.FromContext(ctx, n.logger).WithError(result.Error).WithField("namespace", namespace).Error("failed to delete namespace settings")
+		return fmt.Errorf("failed to delete namespace settings: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError("namespace settings not found")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+func setupNamespaceSettingsTestScenario(t *testing.T) (context.Context, NamespaceSettingsRepository) {
+	db := testhelpers.SetupTestDB(t)
+	return context.Background(), NewNamespaceSettingsRepository(db, logrus.New(), 0)
+}
+
+func TestNamespaceSettingsRepository_Find_None(t *testing.T) {
+	ctx, repo := setupNamespaceSettingsTestScenario(t)
+
+	settings, err := repo.Find(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if settings != nil {
+		t.Errorf("Expected no settings, got %+v", settings)
+	}
+}
+
+func TestNamespaceSettingsRepository_Save(t *testing.T) {
+	ctx, repo := setupNamespaceSettingsTestScenario(t)
+
+	created, err := repo.Save(ctx, models.NamespaceSettings{
+		Namespace:        "test-namespace",
+		RetentionDays:    30,
+		DedupWindowHours: 24,
+		SLATargets:       models.SLATargets{CriticalHours: 4},
+		Notifications:    models.NotificationDefaults{Channel: "#alerts", MinSeverity: models.SeverityMajor},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if created.RetentionDays != 30 || created.SLATargets.CriticalHours != 4 || created.Notifications.Channel != "#alerts" {
+		t.Errorf("Unexpected settings %+v", created)
+	}
+
+	// Saving again replaces the settings
+	time.Sleep(10 * time.Millisecond)
+	updated, err := repo.Save(ctx, models.NamespaceSettings{Namespace: "test-namespace", RetentionDays: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.RetentionDays != 7 || updated.DedupWindowHours != 0 || updated.Notifications.Channel != "" {
+		t.Errorf("Expected the settings to be replaced, got %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("Expected the creation time to be kept, got %v instead of %v", updated.CreatedAt, created.CreatedAt)
+	}
+
+	found, err := repo.Find(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found == nil || found.RetentionDays != 7 {
+		t.Errorf("Expected the saved settings, got %+v", found)
+	}
+}
+
+func TestNamespaceSettingsRepository_Delete(t *testing.T) {
+	ctx, repo := setupNamespaceSettingsTestScenario(t)
+
+	if _, err := repo.Save(ctx, models.NamespaceSettings{Namespace: "test-namespace", RetentionDays: 30}); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+
+	if err := repo.Delete(ctx, "test-namespace"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if settings, _ := repo.Find(ctx, "test-namespace"); settings != nil {
+		t.Errorf("Expected the settings to be deleted, got %+v", settings)
+	}
+
+	if err := repo.Delete(ctx, "test-namespace"); err == nil || err.Error() != "namespace settings not found" {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
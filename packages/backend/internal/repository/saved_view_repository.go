@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type savedViewRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewSavedViewRepository creates a new SavedView repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - SavedViewRepository
+func NewSavedViewRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) SavedViewRepository {
+	return &savedViewRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Find finds a view by name. Views shared in a namespace have no user, and
+// personal views no namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace sharing the view
+//   - user: The user owning the view
+//   - name: The name of the view
+//
+// Returns:
+//   - *models.SavedView: The view, nil if there's none
+//   - error: Database error or nil
+func (v *savedViewRepository) Find(ctx context.Context, namespace, user, name string) (*models.SavedView, error) {
+	ctx, cancel := withQueryTimeout(ctx, v.queryTimeout)
+	defer cancel()
+
+	var view models.SavedView
+	err := v.db.WithContext(ctx).
+		Where("namespace = ? AND \"user\" = ? AND name = ?", namespace, user, name).
+		First(&view).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logging.FromContext(ctx, v.logger).WithError(err).WithField("view", name).Error("failed to find saved view")
+		return nil, fmt.Errorf("failed to find saved view: %w", err)
+	}
+	return &view, nil
+}
+
+// FindAll finds the views shared in a namespace, or the personal views of a
+// user, ordered by name.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace sharing the views
+//   - user: The user owning the views
+//
+// Returns:
+//   - []models.SavedView: The views
+//   - error: Database error or nil
+func (v *savedViewRepository) FindAll(ctx context.Context, namespace, user string) ([]models.SavedView, error) {
+	ctx, cancel := withQueryTimeout(ctx, v.queryTimeout)
+	defer cancel()
+
+	var views []models.SavedView
+	err := v.db.WithContext(ctx).
+		Where("namespace = ? AND \"user\" = ?", namespace, user).
+		Order("name").
+		Find(&views).Error
+	if err != nil {
+		logging.FromContext(ctx, v.logger).WithError(err).Error("failed to find saved views")
+		return nil, fmt.Errorf("failed to find saved views: %w", err)
+	}
+	return views, nil
+}
+
+// Save creates a view, or replaces its query if it exists.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - view: The view
+//
+// Returns:
+//   - *models.SavedView: The saved view
+//   - error: Database error or nil
+func (v *savedViewRepository) Save(ctx context.Context, view models.SavedView) (*models.SavedView, error) {
+	ctx, cancel := withQueryTimeout(ctx, v.queryTimeout)
+	defer cancel()
+
+	// Replacing the view keeps when it was created
+	err := v.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "namespace"}, {Name: "user"}, {Name: "name"}},
+		UpdateAll: true,
+	}).Create(&view).Error
+	if err != nil {
+		logging.FromContext(ctx, v.logger).WithError(err).WithField("view", view.Name).Error("failed to save view")
+		return nil, fmt.Errorf("failed to save view: %w", err)
+	}
+
+	var saved models.SavedView
+	err = v.db.WithContext(ctx).
+		Where("namespace = ? AND \"user\" = ? AND name = ?", view.Namespace, view.User, view.Name).
+		First(&saved).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved view: %w", err)
+	}
+	return &saved, nil
+}
+
+// Delete deletes a view.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace sharing the view
+//   - user: The user owning the view
+//   - name: The name of the view
+//
+// Returns:
+//   - error: "saved view not found" if there's no such view, database error or nil
+func (v *savedViewRepository) Delete(ctx context.Context, namespace, user, name string) error {
+	ctx, cancel := withQueryTimeout(ctx, v.queryTimeout)
+	defer cancel()
+
+	result := v.db.WithContext(ctx).
+		Where("namespace = ? AND \"user\" = ? AND name = ?", namespace, user, name).
+		Delete(&models.SavedView{})
+	if result.Error != nil {
+		logging.FromContext(ctx, v.logger).WithError(result.Error).WithField("view", name).Error("failed to delete saved view")
+		return fmt.Errorf("failed to delete saved view: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError("saved view not found")
+	}
+	return nil
+}
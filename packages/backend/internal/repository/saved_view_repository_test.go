@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestSavedViewRepository_Save(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewSavedViewRepository(db, logrus.New(), 0)
+
+	view, err := repo.Save(ctx, models.SavedView{Namespace: "team-alpha", Name: "critical", Query: "severity=critical"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if view.Query != "severity=critical" || view.CreatedAt.IsZero() {
+		t.Errorf("Unexpected view %+v", view)
+	}
+
+	// Saving it again replaces its query and keeps when it was created
+	replaced, err := repo.Save(ctx, models.SavedView{Namespace: "team-alpha", Name: "critical", Query: "severity=critical&issueType=build"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if replaced.Query != "severity=critical&issueType=build" || !replaced.CreatedAt.Equal(view.CreatedAt) {
+		t.Errorf("Expected the query to be replaced, got %+v", replaced)
+	}
+
+	// A personal view of the same name is another view
+	if _, err := repo.Save(ctx, models.SavedView{User: "alice", Name: "critical", Query: "severity=major"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	found, err := repo.Find(ctx, "team-alpha", "", "critical")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found == nil || found.Query != "severity=critical&issueType=build" {
+		t.Errorf("Expected the view of the namespace, got %+v", found)
+	}
+
+	missing, err := repo.Find(ctx, "team-beta", "", "critical")
+	if err != nil || missing != nil {
+		t.Errorf("Expected no view, got %+v and %v", missing, err)
+	}
+}
+
+func TestSavedViewRepository_FindAll(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewSavedViewRepository(db, logrus.New(), 0)
+
+	for _, view := range []models.SavedView{
+		{Namespace: "team-alpha", Name: "open", Query: "state=ACTIVE"},
+		{Namespace: "team-alpha", Name: "critical", Query: "severity=critical"},
+		{Namespace: "team-beta", Name: "critical", Query: "severity=critical"},
+		{User: "alice", Name: "mine", Query: "assignee=alice"},
+	} {
+		if _, err := repo.Save(ctx, view); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	views, err := repo.FindAll(ctx, "team-alpha", "")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(views) != 2 || views[0].Name != "critical" || views[1].Name != "open" {
+		t.Errorf("Expected the views of team-alpha by name, got %+v", views)
+	}
+
+	views, err = repo.FindAll(ctx, "", "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(views) != 1 || views[0].Name != "mine" {
+		t.Errorf("Expected the views of alice, got %+v", views)
+	}
+}
+
+func TestSavedViewRepository_Delete(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewSavedViewRepository(db, logrus.New(), 0)
+
+	if _, err := repo.Save(ctx, models.SavedView{User: "alice", Name: "mine", Query: "assignee=alice"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Only the owner's view is deleted
+	if err := repo.Delete(ctx, "", "bob", "mine"); err == nil || err.Error() != "saved view not found" {
+		t.Errorf("Expected 'saved view not found', got %v", err)
+	}
+	if err := repo.Delete(ctx, "", "alice", "mine"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if view, _ := repo.Find(ctx, "", "alice", "mine"); view != nil {
+		t.Errorf("Expected the view to be deleted, got %+v", view)
+	}
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type triageRuleRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewTriageRuleRepository creates a new TriageRule repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - TriageRuleRepository
+func NewTriageRuleRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) TriageRuleRepository {
+	return &triageRuleRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// FindAll finds the triage rules of a namespace, in the order they're evaluated.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace of the rules
+//
+// Returns:
+//   - []models.TriageRule: The rules, ordered by position then name
+//   - error: Database error or nil
+func (t *triageRuleRepository) FindAll(ctx context.Context, namespace string) ([]models.TriageRule, error) {
+	ctx, cancel := withQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+
+	var rules []models.TriageRule
+	err := t.db.WithContext(ctx).
+		Where("namespace = ?", namespace).
+		Order("position, name").
+		Find(&rules).Error
+	if err != nil {
+		logging.FromContext(ctx, t.logger).WithError(err).WithField("namespace", namespace).Error("failed to find triage rules")
+		return nil, fmt.Errorf("failed to find triage rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Save creates a triage rule, or replaces it if it exists.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - rule: The rule
+//
+// Returns:
+//   - *models.TriageRule: The saved rule
+//   - error: Database error or nil
+func (t *triageRuleRepository) Save(ctx context.Context, rule models.TriageRule) (*models.TriageRule, error) {
+	ctx, cancel := withQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+
+	// Replacing the rule keeps when it was created
+	err := t.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "namespace"}, {Name: "name"}},
+		UpdateAll: true,
+	}).Create(&rule).Error
+	if err != nil {
+		logging.FromContext(ctx, t.logger).WithError(err).WithField("rule", rule.Name).Error("failed to save triage rule")
+		return nil, fmt.Errorf("failed to save triage rule: %w", err)
+	}
+
+	var saved models.TriageRule
+	err = t.db.WithContext(ctx).
+		Where("namespace = ? AND name = ?", rule.Namespace, rule.Name).
+		First(&saved).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved triage rule: %w", err)
+	}
+	return &saved, nil
+}
+
+// Delete deletes a triage rule. The events of the rule are kept.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace of the rule
+//   - name: The name of the rule
+//
+// Returns:
+//   - error: "triage rule not found" if there's no such rule, database error or nil
+func (t *triageRuleRepository) Delete(ctx context.Context, namespace, name string) error {
+	ctx, cancel := withQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+
+	result := t.db.WithContext(ctx).
+		Where("namespace = ? AND name = ?", namespace, name).
+		Delete(&models.TriageRule{})
+	if result.Error != nil {
+		logging.FromContext(ctx, t.logger).WithError(result.Error).WithField("rule", name).Error("failed to delete triage rule")
+		return fmt.Errorf("failed to delete triage rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError("triage rule not found")
+	}
+	return nil
+}
+
+// RecordEvents records that triage rules fired on issues.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - events: The events, nothing is recorded when empty
+//
+// Returns:
+//   - error: Database error or nil
+func (t *triageRuleRepository) RecordEvents(ctx context.Context, events []models.TriageEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+
+	if err := t.db.WithContext(ctx).Create(&events).Error; err != nil {
+		logging.FromContext(ctx, t.logger).WithError(err).Error("failed to record triage events")
+		return fmt.Errorf("failed to record triage events: %w", err)
+	}
+	return nil
+}
+
+// FindEvents finds the triage rules that fired on an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - []models.TriageEvent: The events, the oldest first, then ordered by rule name
+//   - error: Database error or nil
+func (t *triageRuleRepository) FindEvents(ctx context.Context, issueID string) ([]models.TriageEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+
+	var events []models.TriageEvent
+	err := t.db.WithContext(ctx).
+		Where("issue_id = ?", issueID).
+		Order("created_at, rule_name").
+		Find(&events).Error
+	if err != nil {
+		logging.FromContext(ctx, t.logger).WithError(err).WithField("issue_id", issueID).Error("failed to find triage events")
+		return nil, fmt.Errorf("failed to find triage events: %w", err)
+	}
+	return events, nil
+}
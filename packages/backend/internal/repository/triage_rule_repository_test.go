@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestTriageRuleRepository_SaveAndFindAll(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewTriageRuleRepository(db, logrus.New(), 0)
+
+	for _, rule := range []models.TriageRule{
+		{Namespace: "team-alpha", Name: "builds", Position: 2, ResourceType: "component", Assignee: "alice"},
+		{Namespace: "team-alpha", Name: "timeouts", Position: 1, TitlePattern: "(?i)timeout", Labels: map[string]string{"kind": "flaky"}},
+		{Namespace: "team-alpha", Name: "all", Position: 2, Priority: models.PriorityP3},
+		{Namespace: "team-beta", Name: "builds", Severity: models.SeverityCritical},
+	} {
+		if _, err := repo.Save(ctx, rule); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	rules, err := repo.FindAll(ctx, "team-alpha")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(rules) != 3 || rules[0].Name != "timeouts" || rules[1].Name != "all" || rules[2].Name != "builds" {
+		t.Fatalf("Expected the rules of team-alpha by position then name, got %+v", rules)
+	}
+	if rules[0].Labels["kind"] != "flaky" {
+		t.Errorf("Expected the labels of the rule, got %+v", rules[0].Labels)
+	}
+
+	// Saving a rule again replaces it and keeps when it was created
+	replaced, err := repo.Save(ctx, models.TriageRule{Namespace: "team-alpha", Name: "builds", Assignee: "bob"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if replaced.Assignee != "bob" || replaced.ResourceType != "" || !replaced.CreatedAt.Equal(rules[2].CreatedAt) {
+		t.Errorf("Expected the rule to be replaced, got %+v", replaced)
+	}
+}
+
+func TestTriageRuleRepository_Delete(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewTriageRuleRepository(db, logrus.New(), 0)
+
+	if _, err := repo.Save(ctx, models.TriageRule{Namespace: "team-alpha", Name: "builds", Assignee: "alice"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, "team-beta", "builds"); err == nil || err.Error() != "triage rule not found" {
+		t.Errorf("Expected 'triage rule not found', got %v", err)
+	}
+	if err := repo.Delete(ctx, "team-alpha", "builds"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if rules, _ := repo.FindAll(ctx, "team-alpha"); len(rules) != 0 {
+		t.Errorf("Expected the rule to be deleted, got %+v", rules)
+	}
+}
+
+func TestTriageRuleRepository_Events(t *testing.T) {
+	ctx, db, issues := setupTestScenario(t, SetupOptions{})
+	repo := NewTriageRuleRepository(db, logrus.New(), 0)
+
+	issue, err := issues.Create(ctx, createTestIssue("Triaged Issue", "team-alpha"))
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	if err := repo.RecordEvents(ctx, nil); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	err = repo.RecordEvents(ctx, []models.TriageEvent{
+		{IssueID: issue.ID, RuleName: "builds", Changes: map[string]string{"assignee": "alice"}},
+		{IssueID: issue.ID, RuleName: "timeouts", Changes: map[string]string{"label.kind": "flaky"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	events, err := repo.FindEvents(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(events) != 2 || events[0].ID == "" || events[0].CreatedAt.IsZero() {
+		t.Fatalf("Expected the events of the issue, got %+v", events)
+	}
+	if events[0].RuleName != "builds" || events[0].Changes["assignee"] != "alice" ||
+		events[1].RuleName != "timeouts" || events[1].Changes["label.kind"] != "flaky" {
+		t.Errorf("Unexpected events %+v", events)
+	}
+
+	// Deleting the issue deletes its events
+	if err := issues.Delete(ctx, issue.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if events, _ := repo.FindEvents(ctx, issue.ID); len(events) != 0 {
+		t.Errorf("Expected the events to be deleted, got %+v", events)
+	}
+}
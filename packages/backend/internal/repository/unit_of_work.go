@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/metrics"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Repositories gives access to the repositories of a unit of work. They all
+// run their queries in the transaction of the unit of work.
+type Repositories struct {
+	Issues      IssueRepository
+	Links       LinkRepository
+	TriageRules TriageRuleRepository
+	Settings    NamespaceSettingsRepository
+}
+
+// UnitOfWork runs operations spanning several repositories atomically
+type UnitOfWork interface {
+	// Do runs fn in a database transaction, committing it when fn succeeds
+	// and rolling it back when fn returns an error or panics.
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type unitOfWork struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+	decorators   []IssueRepositoryDecorator
+}
+
+// NewUnitOfWork creates a new UnitOfWork
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long a unit of work may take before it's cancelled, 0 for no limit
+//   - decorators: Decorators of the issue repository of the unit of work, see DecorateIssueRepository
+//
+// Returns:
+//   - UnitOfWork
+func NewUnitOfWork(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration, decorators ...IssueRepositoryDecorator) UnitOfWork {
+	return &unitOfWork{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+		decorators:   decorators,
+	}
+}
+
+// Do runs fn in a database transaction. Repository methods opening their own
+// transaction run in a savepoint of it instead.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - fn: The operations to run, using the repositories it's passed
+//
+// Returns:
+//   - error: The error returned by fn, database error or nil
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos Repositories) error) error {
+	ctx, cancel := withQueryTimeout(ctx, u.queryTimeout)
+	defer cancel()
+
+	// Metrics of units of work rolled back, e.g. dry runs, aren't counted
+	deferred := &metrics.Deferred{}
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		links := NewLinkRepository(tx, u.logger, u.queryTimeout)
+		issues := &issueRepository{
+			db:           tx,
+			links:        links,
+			logger:       u.logger,
+			queryTimeout: u.queryTimeout,
+			deferred:     deferred,
+		}
+		return fn(Repositories{
+			Issues:      DecorateIssueRepository(issues, u.decorators...),
+			Links:       links,
+			TriageRules: NewTriageRuleRepository(tx, u.logger, u.queryTimeout),
+			Settings:    NewNamespaceSettingsRepository(tx, u.logger, u.queryTimeout),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	deferred.Commit()
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/metrics"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupUnitOfWork sets up a unit of work on a test database
+func setupUnitOfWork(t *testing.T) (context.Context, *gorm.DB, UnitOfWork) {
+	db := testhelpers.SetupTestDB(t)
+	return context.Background(), db, NewUnitOfWork(db, logrus.New(), 0)
+}
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	ctx, db, uow := setupUnitOfWork(t)
+
+	err := uow.Do(ctx, func(repos Repositories) error {
+		issue, err := repos.Issues.Create(ctx, createTestIssue("Unit of Work", "test-namespace"))
+		if err != nil {
+			return err
+		}
+		return repos.Links.CreateBatch(ctx, issue.ID, []models.Link{{Title: "Logs", URL: "https://konflux.test/logs"}})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	var issueCount, linkCount int64
+	db.Model(&models.Issue{}).Count(&issueCount)
+	db.Model(&models.Link{}).Count(&linkCount)
+	if issueCount != 1 || linkCount != 2 {
+		t.Errorf("Expected 1 issue and 2 links, got %d issues and %d links", issueCount, linkCount)
+	}
+}
+
+func TestUnitOfWork_Rollback(t *testing.T) {
+	ctx, db, uow := setupUnitOfWork(t)
+
+	errFailed := errors.New("failed")
+	err := uow.Do(ctx, func(repos Repositories) error {
+		// Create opens its own transaction, it must be rolled back as well
+		if _, err := repos.Issues.Create(ctx, createTestIssue("Unit of Work", "test-namespace")); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the error of the unit of work, got %v", err)
+	}
+
+	var issueCount, scopeCount, linkCount int64
+	db.Model(&models.Issue{}).Count(&issueCount)
+	db.Model(&models.IssueScope{}).Count(&scopeCount)
+	db.Model(&models.Link{}).Count(&linkCount)
+	if issueCount != 0 || scopeCount != 0 || linkCount != 0 {
+		t.Errorf("Expected nothing to be saved, got %d issues, %d scopes and %d links", issueCount, scopeCount, linkCount)
+	}
+}
+
+func TestUnitOfWork_RollbackOnPanic(t *testing.T) {
+	ctx, db, uow := setupUnitOfWork(t)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the panic to be propagated")
+			}
+		}()
+		_ = uow.Do(ctx, func(repos Repositories) error {
+			if _, err := repos.Issues.Create(ctx, createTestIssue("Unit of Work", "test-namespace")); err != nil {
+				return err
+			}
+			panic("unexpected")
+		})
+	}()
+
+	var issueCount int64
+	db.Model(&models.Issue{}).Count(&issueCount)
+	if issueCount != 0 {
+		t.Errorf("Expected no issues, got %d", issueCount)
+	}
+}
+
+func TestUnitOfWork_Decorators(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	ctx := context.Background()
+
+	var calls []string
+	uow := NewUnitOfWork(db, logrus.New(), 0, WithInterceptors(recordCalls("uow", &calls)))
+
+	err := uow.Do(ctx, func(repos Repositories) error {
+		_, err := repos.Issues.Create(ctx, createTestIssue("Unit of Work", "test-namespace"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "uow:Create" {
+		t.Errorf("Expected the issue repository of the unit of work to be decorated, got calls %v", calls)
+	}
+}
+
+func TestUnitOfWork_CountsMetricsOnCommit(t *testing.T) {
+	ctx, _, uow := setupUnitOfWork(t)
+
+	count := func() uint64 {
+		return metrics.IssueReports.Value(string(models.IssueTypeBuild), string(metrics.DedupCreated))
+	}
+	before := count()
+
+	errFailed := errors.New("failed")
+	err := uow.Do(ctx, func(repos Repositories) error {
+		if _, err := repos.Issues.Create(ctx, createTestIssue("Unit of Work", "test-namespace")); err != nil {
+			return err
+		}
+		if count() != before {
+			t.Error("Expected the report to be counted once the unit of work commits")
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the error of the unit of work, got %v", err)
+	}
+	if got := count() - before; got != 0 {
+		t.Errorf("Expected the reports of units of work rolled back not to be counted, got %d", got)
+	}
+
+	err = uow.Do(ctx, func(repos Repositories) error {
+		_, err := repos.Issues.Create(ctx, createTestIssue("Unit of Work", "test-namespace"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if got := count() - before; got != 1 {
+		t.Errorf("Expected 1 created report, got %d", got)
+	}
+}
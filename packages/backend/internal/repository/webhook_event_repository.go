@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// WebhookEventFilters filters the webhook events, empty fields matching all events
+type WebhookEventFilters struct {
+	Source    string
+	Namespace string
+	IssueID   string
+	Limit     int
+}
+
+type webhookEventRepository struct {
+	db           *gorm.DB
+	logger       *logrus.Logger
+	queryTimeout time.Duration
+}
+
+// NewWebhookEventRepository creates a new WebhookEvent repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//   - queryTimeout: How long an operation may take before it's cancelled, 0 for no limit
+//
+// Returns:
+//   - WebhookEventRepository
+func NewWebhookEventRepository(db *gorm.DB, logger *logrus.Logger, queryTimeout time.Duration) WebhookEventRepository {
+	return &webhookEventRepository{
+		db:           db,
+		logger:       logger,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Create records a webhook event.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - event: The event, received now when its ReceivedAt is zero
+//
+// Returns:
+//   - *models.WebhookEvent: The recorded event
+//   - error: Database error or nil
+func (w *webhookEventRepository) Create(ctx context.Context, event models.WebhookEvent) (*models.WebhookEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	if event.ReceivedAt.IsZero() {
+		event.ReceivedAt = time.Now()
+	}
+	if err := w.db.WithContext(ctx).Create(&event).Error; err != nil {
+		logging.FromContext(ctx, w.logger).WithError(err).WithField("source", event.Source).Error("failed to record webhook event")
+		return nil, fmt.Errorf("failed to record webhook event: %w", err)
+	}
+	return &event, nil
+}
+
+// FindAll finds the webhook events matching filters.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: The filters, see WebhookEventFilters
+//
+// Returns:
+//   - []models.WebhookEvent: The events, the most recent first
+//   - error: Database error or nil
+func (w *webhookEventRepository) FindAll(ctx context.Context, filters WebhookEventFilters) ([]models.WebhookEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	query := w.db.WithContext(ctx).Model(&models.WebhookEvent{})
+	if filters.Source != "" {
+		query = query.Where("source = ?", filters.Source)
+	}
+	if filters.Namespace != "" {
+		query = query.Where("namespace = ?", filters.Namespace)
+	}
+	if filters.IssueID != "" {
+		query = query.Where("issue_id = ?", filters.IssueID)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	var events []models.WebhookEvent
+	if err := query.Order("received_at DESC, id").Find(&events).Error; err != nil {
+		logging.FromContext(ctx, w.logger).WithError(err).Error("failed to find webhook events")
+		return nil, fmt.Errorf("failed to find webhook events: %w", err)
+	}
+	return events, nil
+}
+
+// FindByID finds a webhook event.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the event
+//
+// Returns:
+//   - *models.WebhookEvent: The event, nil if there's no such event
+//   - error: Database error or nil
+func (w *webhookEventRepository) FindByID(ctx context.Context, id string) (*models.WebhookEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx, w.queryTimeout)
+	defer cancel()
+
+	var event models.WebhookEvent
+	err := w.db.WithContext(ctx).Where("id = ?", id).First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logging.FromContext(ctx, w.logger).WithError(err).WithField("event_id", id).Error("failed to find webhook event")
+		return nil, fmt.Errorf("failed to find webhook event: %w", err)
+	}
+	return &event, nil
+}
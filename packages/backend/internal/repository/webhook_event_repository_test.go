@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestWebhookEventRepository_CreateAndFind(t *testing.T) {
+	ctx, db, _ := setupTestScenario(t, SetupOptions{})
+	repo := NewWebhookEventRepository(db, logrus.New(), 0)
+
+	issueID := "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f"
+	now := time.Now()
+	for _, event := range []models.WebhookEvent{
+		{Source: "pipeline-failure", Namespace: "team-alpha", Payload: json.RawMessage(`{"pipelineName":"build"}`), IssueID: &issueID, ReceivedAt: now.Add(-time.Hour)},
+		{Source: "pipeline-success", Namespace: "team-alpha", Payload: json.RawMessage(`{"pipelineName":"build"}`), ReceivedAt: now},
+		{Source: "pipeline-failure", Namespace: "team-beta", Payload: json.RawMessage(`{"pipelineName":"test"}`), ReceivedAt: now.Add(-2 * time.Hour)},
+	} {
+		event.SignatureStatus = models.WebhookSignatureUnchecked
+		if _, err := repo.Create(ctx, event); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	events, err := repo.FindAll(ctx, WebhookEventFilters{})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(events) != 3 || events[0].Source != "pipeline-success" || events[2].Namespace != "team-beta" {
+		t.Fatalf("Expected all events, the most recent first, got %+v", events)
+	}
+
+	events, _ = repo.FindAll(ctx, WebhookEventFilters{Source: "pipeline-failure", Namespace: "team-alpha"})
+	if len(events) != 1 || events[0].IssueID == nil || *events[0].IssueID != issueID {
+		t.Fatalf("Expected the failure of team-alpha, got %+v", events)
+	}
+	if string(events[0].Payload) != `{"pipelineName":"build"}` {
+		t.Errorf("Expected the payload, got %s", events[0].Payload)
+	}
+	if events, _ := repo.FindAll(ctx, WebhookEventFilters{IssueID: issueID}); len(events) != 1 {
+		t.Errorf("Expected the event of the issue, got %+v", events)
+	}
+	if events, _ := repo.FindAll(ctx, WebhookEventFilters{Limit: 2}); len(events) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(events))
+	}
+
+	found, err := repo.FindByID(ctx, events[0].ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found == nil || found.ID != events[0].ID || found.SignatureStatus != models.WebhookSignatureUnchecked {
+		t.Errorf("Expected the event, got %+v", found)
+	}
+	if missing, err := repo.FindByID(ctx, "00000000-0000-0000-0000-000000000000"); missing != nil || err != nil {
+		t.Errorf("Expected no event, got %+v, %v", missing, err)
+	}
+}
@@ -1,43 +1,54 @@
 package seed
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/konflux-ci/kite/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Options configures SeedData
+type Options struct {
+	// Upsert refreshes the sample issues already seeded, matched by title and
+	// scope, rather than skipping seeding when the database has issues
+	Upsert bool
+}
+
 // SeedData seeds the database with sample data
-func SeedData(db *gorm.DB) error {
-	// Check if data already exists
-	var count int64
-	if err := db.Model(&models.Issue{}).Count(&count).Error; err != nil {
-		return fmt.Errorf("failed to check existing data: %w", err)
-	}
+func SeedData(db *gorm.DB, opts Options) error {
+	if !opts.Upsert {
+		// Check if data already exists
+		var count int64
+		if err := db.Model(&models.Issue{}).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check existing data: %w", err)
+		}
 
-	if count > 0 {
-		fmt.Printf("Database already has %d issues, skipping seed\n", count)
-		return nil
+		if count > 0 {
+			fmt.Printf("Database already has %d issues, skipping seed (use --upsert to refresh the sample data, or --reset to replace all the data)\n", count)
+			return nil
+		}
 	}
 
 	// Seed in transaction
 	return db.Transaction(func(tx *gorm.DB) error {
-		// Create scopes first and get their generated IDs
-		scopes, err := seedIssueScopes(tx)
+		issues, err := seedIssues(tx)
 		if err != nil {
-			return fmt.Errorf("failed to seed issue scopes: %w", err)
+			return fmt.Errorf("failed to seed issues: %w", err)
 		}
 
-		if err := seedIssues(tx, scopes); err != nil {
-			return fmt.Errorf("failed to seed issues: %w", err)
+		// Refreshed issues get their links and relationships seeded again
+		if err := clearRelationships(tx, issues); err != nil {
+			return fmt.Errorf("failed to clear links and related issues: %w", err)
 		}
 
-		if err := seedLinks(tx); err != nil {
+		if err := seedLinks(tx, issues); err != nil {
 			return fmt.Errorf("failed to seed links: %w", err)
 		}
 
-		if err := seedRelatedIssues(tx); err != nil {
+		if err := seedRelatedIssues(tx, issues); err != nil {
 			return fmt.Errorf("failed to seed related issues: %w", err)
 		}
 
@@ -46,7 +57,20 @@ func SeedData(db *gorm.DB) error {
 	})
 }
 
-func seedIssueScopes(tx *gorm.DB) (map[string]string, error) {
+// Reset deletes all the issues, along with their scopes, links, labels,
+// external references, relationships, watches and triage events. The tables
+// referencing the issues are truncated too, since Postgres refuses to
+// truncate tables referenced by tables left untruncated.
+func Reset(db *gorm.DB) error {
+	if err := db.Exec("TRUNCATE TABLE related_issues, external_refs, labels, links, issue_watches, triage_events, issues, issue_scopes CASCADE").Error; err != nil {
+		return fmt.Errorf("failed to reset issues: %w", err)
+	}
+	fmt.Println("Database reset successfully")
+	return nil
+}
+
+// sampleScopes returns the scopes of the sample issues, by logical name
+func sampleScopes() map[string]models.IssueScope {
 	scopes := []models.IssueScope{
 		{
 			ResourceType:      "component",
@@ -110,33 +134,25 @@ func seedIssueScopes(tx *gorm.DB) (map[string]string, error) {
 		},
 	}
 
-	// Create scopes and let GORM generate UUIDs
-	if err := tx.Create(&scopes).Error; err != nil {
-		return nil, err
-	}
-
-	// Return a map of logical names to generated UUIDs for reference
-	scopeMap := map[string]string{
-		"scope-failed-build-frontend":             scopes[0].ID,
-		"scope-failed-test-api":                   scopes[1].ID,
-		"scope-release-failed-production":         scopes[2].ID,
-		"scope-dependency-update-needed-frontend": scopes[3].ID,
-		"scope-pipeline-outdated":                 scopes[4].ID,
-		"scope-failed-pipeline-run":               scopes[5].ID,
-		"scope-test-flaky-mobile":                 scopes[6].ID,
-		"scope-outdated-dependency-database":      scopes[7].ID,
-		"scope-build-warning-logging":             scopes[8].ID,
-		"scope-database-connection-timeout":       scopes[9].ID,
-		"scope-permission-config-incorrect":       scopes[10].ID,
-		"scope-resource-quota-exceeded":           scopes[11].ID,
+	return map[string]models.IssueScope{
+		"scope-failed-build-frontend":             scopes[0],
+		"scope-failed-test-api":                   scopes[1],
+		"scope-release-failed-production":         scopes[2],
+		"scope-dependency-update-needed-frontend": scopes[3],
+		"scope-pipeline-outdated":                 scopes[4],
+		"scope-failed-pipeline-run":               scopes[5],
+		"scope-test-flaky-mobile":                 scopes[6],
+		"scope-outdated-dependency-database":      scopes[7],
+		"scope-build-warning-logging":             scopes[8],
+		"scope-database-connection-timeout":       scopes[9],
+		"scope-permission-config-incorrect":       scopes[10],
+		"scope-resource-quota-exceeded":           scopes[11],
 	}
-
-	return scopeMap, nil
 }
 
-func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
-	now := time.Now()
-	issues := []models.Issue{
+// sampleIssues returns the sample issues, their ScopeID being the logical name of their scope
+func sampleIssues() []models.Issue {
+	return []models.Issue{
 		{
 			Title:       "Frontend build failed due to dependency conflict",
 			Description: "The build process for the frontend component failed because of conflicting versions of React dependencies",
@@ -145,7 +161,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 30, 15, 45, 30, 0, time.UTC),
 			Namespace:   "team-alpha",
-			ScopeID:     scopeMap["scope-failed-build-frontend"],
+			ScopeID:     "scope-failed-build-frontend",
 		},
 		{
 			Title:       "API integration tests failing on database connection",
@@ -155,7 +171,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 5, 1, 9, 15, 22, 0, time.UTC),
 			Namespace:   "team-alpha",
-			ScopeID:     scopeMap["scope-failed-test-api"],
+			ScopeID:     "scope-failed-test-api",
 		},
 		{
 			Title:       "Production release failed during deployment",
@@ -165,7 +181,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 29, 18, 30, 45, 0, time.UTC),
 			Namespace:   "team-beta",
-			ScopeID:     scopeMap["scope-release-failed-production"],
+			ScopeID:     "scope-release-failed-production",
 		},
 		{
 			Title:       "Frontend dependency updates available",
@@ -175,7 +191,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 28, 14, 20, 10, 0, time.UTC),
 			Namespace:   "team-alpha",
-			ScopeID:     scopeMap["scope-dependency-update-needed-frontend"],
+			ScopeID:     "scope-dependency-update-needed-frontend",
 		},
 		{
 			Title:       "Pipeline tasks using deprecated API versions",
@@ -185,7 +201,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 25, 11, 10, 30, 0, time.UTC),
 			Namespace:   "team-gamma",
-			ScopeID:     scopeMap["scope-pipeline-outdated"],
+			ScopeID:     "scope-pipeline-outdated",
 		},
 		{
 			Title:       "Pipeline run failed during deployment stage",
@@ -195,7 +211,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 30, 16, 45, 20, 0, time.UTC),
 			Namespace:   "team-delta",
-			ScopeID:     scopeMap["scope-failed-pipeline-run"],
+			ScopeID:     "scope-failed-pipeline-run",
 		},
 		{
 			Title:       "Mobile app tests showing intermittent failures",
@@ -206,7 +222,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			DetectedAt:  time.Date(2025, 4, 28, 10, 25, 15, 0, time.UTC),
 			ResolvedAt:  &[]time.Time{time.Date(2025, 4, 29, 14, 35, 40, 0, time.UTC)}[0],
 			Namespace:   "team-alpha",
-			ScopeID:     scopeMap["scope-test-flaky-mobile"],
+			ScopeID:     "scope-test-flaky-mobile",
 		},
 		{
 			Title:       "Database client library needs security update",
@@ -217,7 +233,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			DetectedAt:  time.Date(2025, 4, 25, 9, 20, 30, 0, time.UTC),
 			ResolvedAt:  &[]time.Time{time.Date(2025, 4, 30, 13, 40, 15, 0, time.UTC)}[0],
 			Namespace:   "team-beta",
-			ScopeID:     scopeMap["scope-outdated-dependency-database"],
+			ScopeID:     "scope-outdated-dependency-database",
 		},
 		{
 			Title:       "Build warnings in logging component",
@@ -227,7 +243,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 27, 15, 30, 45, 0, time.UTC),
 			Namespace:   "team-gamma",
-			ScopeID:     scopeMap["scope-build-warning-logging"],
+			ScopeID:     "scope-build-warning-logging",
 		},
 		{
 			Title:       "Database connection timeouts affecting multiple components",
@@ -237,7 +253,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 5, 1, 8, 10, 25, 0, time.UTC),
 			Namespace:   "team-alpha",
-			ScopeID:     scopeMap["scope-database-connection-timeout"],
+			ScopeID:     "scope-database-connection-timeout",
 		},
 		{
 			Title:       "Incorrect permission configuration for deployment service account",
@@ -247,7 +263,7 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 30, 15, 30, 10, 0, time.UTC),
 			Namespace:   "team-delta",
-			ScopeID:     scopeMap["scope-permission-config-incorrect"],
+			ScopeID:     "scope-permission-config-incorrect",
 		},
 		{
 			Title:       "Namespace resource quota exceeded during deployment",
@@ -257,26 +273,69 @@ func seedIssues(tx *gorm.DB, scopeMap map[string]string) error {
 			State:       models.IssueStateActive,
 			DetectedAt:  time.Date(2025, 4, 29, 18, 15, 30, 0, time.UTC),
 			Namespace:   "team-beta",
-			ScopeID:     scopeMap["scope-resource-quota-exceeded"],
+			ScopeID:     "scope-resource-quota-exceeded",
 		},
 	}
+}
 
-	// Set timestamps and let GORM generate UUIDs for issues
-	for i := range issues {
-		issues[i].CreatedAt = now
-		issues[i].UpdatedAt = now
+// seedIssues creates the sample issues along with their scopes, or updates
+// the sample issues matching their title and scope
+func seedIssues(tx *gorm.DB) ([]models.Issue, error) {
+	now := time.Now()
+	scopes := sampleScopes()
+	issues := sampleIssues()
+
+	for n := range issues {
+		issue := &issues[n]
+		scope := scopes[issue.ScopeID]
+		issue.DedupKey = models.ScopeDedupKey(scope.ResourceType, scope.ResourceName)
+		issue.UpdatedAt = now
+
+		var existing models.Issue
+		err := tx.Where("title = ? AND scope_id IN (?)", issue.Title,
+			tx.Model(&models.IssueScope{}).Select("id").Where("resource_type = ? AND resource_name = ? AND resource_namespace = ?",
+				scope.ResourceType, scope.ResourceName, scope.ResourceNamespace),
+		).First(&existing).Error
+		switch {
+		case err == nil:
+			issue.ID = existing.ID
+			issue.ScopeID = existing.ScopeID
+			issue.CreatedAt = existing.CreatedAt
+			if err := tx.Omit(clause.Associations).Save(issue).Error; err != nil {
+				return nil, err
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(&scope).Error; err != nil {
+				return nil, err
+			}
+			issue.ScopeID = scope.ID
+			issue.CreatedAt = now
+			if err := tx.Omit(clause.Associations).Create(issue).Error; err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
 	}
 
-	return tx.Create(&issues).Error
+	return issues, nil
 }
 
-func seedLinks(tx *gorm.DB) error {
-	// First, get the issue IDs by querying the database
-	var issues []models.Issue
-	if err := tx.Find(&issues).Error; err != nil {
+// clearRelationships deletes the links of the sample issues and the
+// relationships between them
+func clearRelationships(tx *gorm.DB, issues []models.Issue) error {
+	ids := make([]string, len(issues))
+	for n, issue := range issues {
+		ids[n] = issue.ID
+	}
+
+	if err := tx.Where("issue_id IN ?", ids).Delete(&models.Link{}).Error; err != nil {
 		return err
 	}
+	return tx.Where("source_id IN ? AND target_id IN ?", ids, ids).Delete(&models.RelatedIssue{}).Error
+}
 
+func seedLinks(tx *gorm.DB, issues []models.Issue) error {
 	// Create a map of issue titles to IDs for easier linking
 	issueMap := make(map[string]string)
 	for _, issue := range issues {
@@ -368,13 +427,7 @@ func seedLinks(tx *gorm.DB) error {
 	return tx.Create(&links).Error
 }
 
-func seedRelatedIssues(tx *gorm.DB) error {
-	// Get issues by querying the database
-	var issues []models.Issue
-	if err := tx.Find(&issues).Error; err != nil {
-		return err
-	}
-
+func seedRelatedIssues(tx *gorm.DB, issues []models.Issue) error {
 	// Create a map of issue titles to IDs
 	issueMap := make(map[string]string)
 	for _, issue := range issues {
@@ -0,0 +1,50 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+)
+
+func TestReset_Postgres(t *testing.T) {
+	db := testhelpers.SetupPostgresTestDB(t)
+
+	if err := SeedData(db, Options{}); err != nil {
+		t.Fatalf("Failed to seed data: %v", err)
+	}
+	// Watches and triage events reference the issues too
+	var issue models.Issue
+	if err := db.First(&issue).Error; err != nil {
+		t.Fatalf("Failed to find a seeded issue: %v", err)
+	}
+	if err := db.Create(&models.IssueWatch{IssueID: issue.ID, User: "alice"}).Error; err != nil {
+		t.Fatalf("Failed to watch issue: %v", err)
+	}
+	if err := db.Create(&models.TriageEvent{IssueID: issue.ID, RuleName: "label-builds", Changes: map[string]string{"label.team": "build"}}).Error; err != nil {
+		t.Fatalf("Failed to create triage event: %v", err)
+	}
+
+	if err := Reset(db); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	for _, table := range []string{"issues", "issue_scopes", "links", "related_issues", "labels", "external_refs", "issue_watches", "triage_events"} {
+		var count int64
+		if err := db.Table(table).Count(&count).Error; err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected %s to be empty after the reset, got %d rows", table, count)
+		}
+	}
+
+	// The sample data is seeded again after a reset
+	if err := SeedData(db, Options{}); err != nil {
+		t.Fatalf("Failed to seed data after the reset: %v", err)
+	}
+	var count int64
+	if err := db.Model(&models.Issue{}).Count(&count).Error; err != nil || count == 0 {
+		t.Errorf("Expected the sample issues to be seeded again, got %d issues (%v)", count, err)
+	}
+}
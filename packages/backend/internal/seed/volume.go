@@ -0,0 +1,197 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/konflux-ci/kite/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// volumeBatchSize is how many issues are created per transaction
+const volumeBatchSize = 500
+
+// VolumeOptions configures the synthetic issues of SeedVolume
+type VolumeOptions struct {
+	Count      int // How many issues to generate
+	Namespaces int // How many namespaces the issues are spread across
+	Days       int // How many days back the issues are detected
+}
+
+// The severities of the synthetic issues and their weights, most issues being minor
+var (
+	severities      = []any{models.SeverityInfo, models.SeverityMinor, models.SeverityMajor, models.SeverityCritical}
+	severityWeights = []float32{20, 40, 30, 10}
+)
+
+// resolvedRatio is the share of the synthetic issues that are resolved
+const resolvedRatio = 0.7
+
+var resourceTypes = []string{"component", "application", "pipelinerun", "workspace"}
+
+var issueTypes = []models.IssueType{
+	models.IssueTypeBuild,
+	models.IssueTypeTest,
+	models.IssueTypeRelease,
+	models.IssueTypeDependency,
+	models.IssueTypePipeline,
+}
+
+// SeedVolume seeds the database with synthetic issues, along with their
+// scopes and links, for load testing. The issues are spread across the
+// namespaces load-test-1 to load-test-N, detected over the last days, and
+// most of them are resolved. Unlike SeedData, it seeds databases that already
+// have issues.
+func SeedVolume(db *gorm.DB, opts VolumeOptions) error {
+	if opts.Count <= 0 || opts.Namespaces <= 0 || opts.Days <= 0 {
+		return errors.New("count, namespaces and days must be positive")
+	}
+
+	g := &volumeGenerator{
+		faker: gofakeit.New(0),
+		opts:  opts,
+		now:   time.Now().UTC(),
+		// Seeding again generates other resources, as there may be a
+		// single active issue per resource and issue type
+		runID: uuid.New().String()[:8],
+	}
+
+	for start := 0; start < opts.Count; start += volumeBatchSize {
+		size := min(volumeBatchSize, opts.Count-start)
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return g.seedBatch(tx, start, size)
+		}); err != nil {
+			return fmt.Errorf("failed to seed issues %d to %d: %w", start, start+size, err)
+		}
+		fmt.Printf("Seeded %d/%d issues\n", start+size, opts.Count)
+	}
+	return nil
+}
+
+type volumeGenerator struct {
+	faker *gofakeit.Faker
+	opts  VolumeOptions
+	now   time.Time
+	runID string
+}
+
+// seedBatch creates size issues, numbered from start
+func (g *volumeGenerator) seedBatch(tx *gorm.DB, start, size int) error {
+	scopes := make([]models.IssueScope, 0, size)
+	issues := make([]models.Issue, 0, size)
+	var links []models.Link
+
+	for n := start; n < start+size; n++ {
+		scope, issue := g.issue(n)
+		scopes = append(scopes, scope)
+		issues = append(issues, issue)
+		links = append(links, g.links(issue)...)
+	}
+
+	if err := tx.Create(&scopes).Error; err != nil {
+		return fmt.Errorf("failed to create scopes: %w", err)
+	}
+	if err := tx.Omit(clause.Associations).Create(&issues).Error; err != nil {
+		return fmt.Errorf("failed to create issues: %w", err)
+	}
+	if len(links) > 0 {
+		if err := tx.Omit(clause.Associations).Create(&links).Error; err != nil {
+			return fmt.Errorf("failed to create links: %w", err)
+		}
+	}
+	return nil
+}
+
+// issue generates the n-th issue, along with its scope
+func (g *volumeGenerator) issue(n int) (models.IssueScope, models.Issue) {
+	f := g.faker
+	namespace := fmt.Sprintf("load-test-%d", n%g.opts.Namespaces+1)
+	resourceType := resourceTypes[f.IntRange(0, len(resourceTypes)-1)]
+	resourceName := fmt.Sprintf("%s-%s-%d", slug(f.AppName()), g.runID, n)
+	issueType := issueTypes[f.IntRange(0, len(issueTypes)-1)]
+
+	scope := models.IssueScope{
+		ID:                uuid.New().String(),
+		ResourceType:      resourceType,
+		ResourceName:      resourceName,
+		ResourceNamespace: namespace,
+	}
+
+	detectedAt := f.DateRange(g.now.AddDate(0, 0, -g.opts.Days), g.now)
+	issue := models.Issue{
+		ID:          uuid.New().String(),
+		Title:       g.title(issueType, resourceName),
+		Description: f.HackerPhrase(),
+		Details:     f.Sentence(),
+		Severity:    g.severity(),
+		IssueType:   issueType,
+		State:       models.IssueStateActive,
+		DetectedAt:  detectedAt,
+		Namespace:   namespace,
+		DedupKey:    models.ScopeDedupKey(resourceType, resourceName),
+		ScopeID:     scope.ID,
+	}
+
+	if f.Float64() < resolvedRatio {
+		// Issues are resolved within three days, and not in the future
+		resolvedAt := f.DateRange(detectedAt, detectedAt.Add(72*time.Hour))
+		if resolvedAt.After(g.now) {
+			resolvedAt = g.now
+		}
+		issue.State = models.IssueStateResolved
+		issue.ResolvedAt = &resolvedAt
+	}
+
+	return scope, issue
+}
+
+// title generates the title of an issue of a resource
+func (g *volumeGenerator) title(issueType models.IssueType, resourceName string) string {
+	f := g.faker
+	switch issueType {
+	case models.IssueTypeBuild:
+		return fmt.Sprintf("Build of %s failed to %s the %s", resourceName, f.HackerVerb(), f.HackerNoun())
+	case models.IssueTypeTest:
+		return fmt.Sprintf("%s tests failing on the %s %s", resourceName, f.HackerAdjective(), f.HackerNoun())
+	case models.IssueTypeRelease:
+		return fmt.Sprintf("Release of %s blocked by the %s", resourceName, f.HackerNoun())
+	case models.IssueTypeDependency:
+		return fmt.Sprintf("%s dependency of %s is outdated", f.AppName(), resourceName)
+	default:
+		return fmt.Sprintf("Pipeline run %s failed while %s the %s", resourceName, f.HackeringVerb(), f.HackerNoun())
+	}
+}
+
+// severity draws a severity according to severityWeights
+func (g *volumeGenerator) severity() models.Severity {
+	severity, err := g.faker.Weighted(severities, severityWeights)
+	if err != nil {
+		return models.SeverityMinor
+	}
+	return severity.(models.Severity)
+}
+
+// links generates up to two links of an issue
+func (g *volumeGenerator) links(issue models.Issue) []models.Link {
+	f := g.faker
+	links := make([]models.Link, f.IntRange(0, 2))
+	for n := range links {
+		links[n] = models.Link{
+			ID:      uuid.New().String(),
+			Title:   f.HackerAbbreviation() + " logs",
+			URL:     f.URL(),
+			IssueID: issue.ID,
+		}
+	}
+	return links
+}
+
+// slug turns a name into a resource name, e.g. "Blue Bird" into "blue-bird"
+func slug(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dashboardListLimit is how many issues, resources or breaches each list of the dashboard holds
+	dashboardListLimit = 10
+	// flappingWindow is how far back reopens count towards flapping resources
+	flappingWindow = 7 * 24 * time.Hour
+	// statusSLAWindow is how far back resolutions count towards the SLAs of status pages
+	statusSLAWindow = 30 * 24 * time.Hour
+)
+
+// severities are the severities of issues, the most severe first
+var severities = []models.Severity{models.SeverityCritical, models.SeverityMajor, models.SeverityMinor, models.SeverityInfo}
+
+type DashboardService struct {
+	issues   repository.IssueRepository             // Repository instance
+	settings repository.NamespaceSettingsRepository // Repository of the SLA targets
+	logger   *logrus.Logger                         // Logging instance
+}
+
+func NewDashboardService(issues repository.IssueRepository, settings repository.NamespaceSettingsRepository, logger *logrus.Logger) *DashboardService {
+	return &DashboardService{
+		issues:   issues,
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// GetDashboard returns the overview of the issues of a namespace: its active
+// issues by severity, its most recent issues, the resources whose issues were
+// reopened the most in the last week, and the active issues past their SLA target
+func (s *DashboardService) GetDashboard(ctx context.Context, namespace string) (*dto.DashboardResponse, error) {
+	dashboard := &dto.DashboardResponse{
+		Namespace:         namespace,
+		FlappingResources: []dto.FlappingResource{},
+		SLABreaches:       []dto.SLABreach{},
+	}
+
+	var err error
+	dashboard.ActiveBySeverity, dashboard.ActiveTotal, err = s.countActive(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.RecentIssues, _, err = s.issues.FindAll(ctx, repository.IssueQueryFilters{Namespace: namespace, Limit: dashboardListLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-flappingWindow)
+	reopens, err := s.issues.CountReopensByResource(ctx, repository.IssueQueryFilters{Namespace: namespace, ReopenedSince: &since}, dashboardListLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range reopens {
+		dashboard.FlappingResources = append(dashboard.FlappingResources, dto.FlappingResource(resource))
+	}
+
+	if err := s.findSLABreaches(ctx, namespace, dashboard); err != nil {
+		return nil, err
+	}
+	return dashboard, nil
+}
+
+// countActive counts the active issues of a namespace by severity, every
+// severity being counted, along with their total
+func (s *DashboardService) countActive(ctx context.Context, namespace string) (map[models.Severity]int64, int64, error) {
+	counts, err := s.issues.CountGroupedBy(ctx, repository.IssueQueryFilters{Namespace: namespace, Unresolved: true}, repository.CountBySeverity)
+	if err != nil {
+		return nil, 0, err
+	}
+	bySeverity := map[models.Severity]int64{}
+	for _, severity := range severities {
+		bySeverity[severity] = 0
+	}
+	var total int64
+	for _, count := range counts {
+		bySeverity[models.Severity(count.Key)] = count.Count
+		total += count.Count
+	}
+	return bySeverity, total, nil
+}
+
+// findSLABreaches adds the active issues of a namespace past the SLA target of
+// their severity to the dashboard, the most recently breached first
+func (s *DashboardService) findSLABreaches(ctx context.Context, namespace string, dashboard *dto.DashboardResponse) error {
+	settings, err := s.settings.Find(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		return nil
+	}
+
+	now := time.Now()
+	sla := settings.SLATargets
+	for severity, hours := range map[models.Severity]int{
+		models.SeverityCritical: sla.CriticalHours,
+		models.SeverityMajor:    sla.MajorHours,
+		models.SeverityMinor:    sla.MinorHours,
+		models.SeverityInfo:     sla.InfoHours,
+	} {
+		if hours <= 0 {
+			continue
+		}
+		target := time.Duration(hours) * time.Hour
+		deadline := now.Add(-target)
+		issues, total, err := s.issues.FindAll(ctx, repository.IssueQueryFilters{
+			Namespace:      namespace,
+			Unresolved:     true,
+			Severity:       &severity,
+			DetectedBefore: &deadline,
+			Limit:          dashboardListLimit,
+		})
+		if err != nil {
+			return err
+		}
+		dashboard.SLABreachesTotal += total
+		for _, issue := range issues {
+			dashboard.SLABreaches = append(dashboard.SLABreaches, dto.SLABreach{
+				Issue:       issue,
+				TargetHours: hours,
+				BreachedAt:  issue.DetectedAt.Add(target),
+			})
+		}
+	}
+
+	slices.SortFunc(dashboard.SLABreaches, func(a, b dto.SLABreach) int {
+		return b.BreachedAt.Compare(a.BreachedAt)
+	})
+	if len(dashboard.SLABreaches) > dashboardListLimit {
+		dashboard.SLABreaches = dashboard.SLABreaches[:dashboardListLimit]
+	}
+	return nil
+}
+
+// GetStatus returns the summary of the issues of a namespace backing its
+// external status page: its active issues by severity, its most recent
+// critical issues, and how its issues met their SLA targets over the last 30
+// days. It doesn't expose the details of the issues.
+func (s *DashboardService) GetStatus(ctx context.Context, namespace string) (*dto.StatusResponse, error) {
+	status := &dto.StatusResponse{
+		Namespace:      namespace,
+		RecentCritical: []dto.StatusIssue{},
+		SLAs:           []dto.SLAUptime{},
+		SLAWindowDays:  int(statusSLAWindow / (24 * time.Hour)),
+		GeneratedAt:    time.Now(),
+	}
+
+	var err error
+	status.ActiveBySeverity, status.ActiveTotal, err = s.countActive(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	critical := models.SeverityCritical
+	recent, _, err := s.issues.FindAll(ctx, repository.IssueQueryFilters{
+		Namespace: namespace,
+		Severity:  &critical,
+		Limit:     dashboardListLimit,
+		Fields:    []string{"title", "state", "detectedAt", "resolvedAt"},
+		SkipTotal: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range recent {
+		status.RecentCritical = append(status.RecentCritical, dto.StatusIssue{
+			Title:      issue.Title,
+			State:      issue.State,
+			DetectedAt: issue.DetectedAt,
+			ResolvedAt: issue.ResolvedAt,
+		})
+	}
+
+	settings, err := s.settings.Find(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return status, nil
+	}
+	targets := map[models.Severity]int{
+		models.SeverityCritical: settings.SLATargets.CriticalHours,
+		models.SeverityMajor:    settings.SLATargets.MajorHours,
+		models.SeverityMinor:    settings.SLATargets.MinorHours,
+		models.SeverityInfo:     settings.SLATargets.InfoHours,
+	}
+	for _, severity := range severities {
+		hours := targets[severity]
+		if hours <= 0 {
+			continue
+		}
+		uptime, err := s.slaUptime(ctx, namespace, severity, hours, status.GeneratedAt)
+		if err != nil {
+			return nil, err
+		}
+		status.SLAs = append(status.SLAs, uptime)
+	}
+	return status, nil
+}
+
+// slaUptime counts the issues of a severity of a namespace that met its SLA
+// target of hours, among those resolved within the SLA window and those still
+// active past the target
+func (s *DashboardService) slaUptime(ctx context.Context, namespace string, severity models.Severity, hours int, now time.Time) (dto.SLAUptime, error) {
+	uptime := dto.SLAUptime{Severity: severity, TargetHours: hours, Uptime: 100}
+	target := time.Duration(hours) * time.Hour
+
+	since := now.Add(-statusSLAWindow)
+	err := repository.StreamAll(ctx, s.issues, repository.IssueQueryFilters{
+		Namespace:     namespace,
+		Severity:      &severity,
+		ResolvedSince: &since,
+		Fields:        []string{"detectedAt", "resolvedAt"},
+	}, streamBatchSize, func(issues []models.Issue) error {
+		for _, issue := range issues {
+			if issue.ResolvedAt != nil && issue.ResolvedAt.Sub(issue.DetectedAt) <= target {
+				uptime.Met++
+			} else {
+				uptime.Missed++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.SLAUptime{}, err
+	}
+
+	deadline := now.Add(-target)
+	breaching, err := s.issues.CountByFilters(ctx, repository.IssueQueryFilters{
+		Namespace:      namespace,
+		Unresolved:     true,
+		Severity:       &severity,
+		DetectedBefore: &deadline,
+	})
+	if err != nil {
+		return dto.SLAUptime{}, err
+	}
+	uptime.Missed += breaching
+
+	if total := uptime.Met + uptime.Missed; total > 0 {
+		uptime.Uptime = math.Round(float64(uptime.Met)/float64(total)*10000) / 100
+	}
+	return uptime, nil
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+func TestDashboardService_GetDashboard(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	settings := repository.NewNamespaceSettingsRepository(db, logger, 0)
+	service := NewDashboardService(issues, settings, logger)
+
+	create := func(resource string, severity models.Severity, age time.Duration) *models.Issue {
+		issue, err := issues.Create(ctx, dto.CreateIssueRequest{
+			Title:       "Dashboard Issue " + resource,
+			Description: "Testing the dashboard",
+			Severity:    severity,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "test-namespace",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      resource,
+				ResourceNamespace: "test-namespace",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if err := db.Model(issue).Update("detected_at", time.Now().Add(-age)).Error; err != nil {
+			t.Fatalf("Failed to age issue: %v", err)
+		}
+		return issue
+	}
+
+	create("fresh", models.SeverityCritical, time.Hour)
+	overdue := create("overdue", models.SeverityCritical, 5*time.Hour)
+	create("minor", models.SeverityMinor, 48*time.Hour)
+	flapping := create("flapping", models.SeverityMajor, time.Hour)
+	for _, state := range []models.IssueState{models.IssueStateResolved, models.IssueStateActive} {
+		if _, err := issues.Update(ctx, flapping.ID, dto.UpdateIssueRequest{State: state}); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+	if _, err := settings.Save(ctx, models.NamespaceSettings{
+		Namespace:  "test-namespace",
+		SLATargets: models.SLATargets{CriticalHours: 4},
+	}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	dashboard, err := service.GetDashboard(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if dashboard.ActiveTotal != 4 || dashboard.ActiveBySeverity[models.SeverityCritical] != 2 ||
+		dashboard.ActiveBySeverity[models.SeverityInfo] != 0 {
+		t.Errorf("Unexpected active counts %d, %+v", dashboard.ActiveTotal, dashboard.ActiveBySeverity)
+	}
+	if len(dashboard.RecentIssues) != 4 {
+		t.Errorf("Expected 4 recent issues, got %d", len(dashboard.RecentIssues))
+	}
+	if len(dashboard.FlappingResources) != 1 || dashboard.FlappingResources[0].ResourceName != "flapping" ||
+		dashboard.FlappingResources[0].Reopens != 1 {
+		t.Errorf("Expected the flapping resource, got %+v", dashboard.FlappingResources)
+	}
+	// Minor issues have no SLA target
+	if dashboard.SLABreachesTotal != 1 || len(dashboard.SLABreaches) != 1 || dashboard.SLABreaches[0].Issue.ID != overdue.ID ||
+		dashboard.SLABreaches[0].TargetHours != 4 {
+		t.Errorf("Expected the overdue issue to breach its SLA, got %+v", dashboard.SLABreaches)
+	}
+}
+
+func TestDashboardService_GetDashboard_Empty(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	service := NewDashboardService(issues, repository.NewNamespaceSettingsRepository(db, logger, 0), logger)
+
+	dashboard, err := service.GetDashboard(ctx, "empty-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if dashboard.ActiveTotal != 0 || len(dashboard.ActiveBySeverity) != 4 || dashboard.FlappingResources == nil ||
+		dashboard.SLABreaches == nil {
+		t.Errorf("Expected an empty dashboard, got %+v", dashboard)
+	}
+}
+
+func TestDashboardService_GetStatus(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	settings := repository.NewNamespaceSettingsRepository(db, logger, 0)
+	service := NewDashboardService(issues, settings, logger)
+
+	// create creates an issue detected age ago, resolved after resolvedAfter unless 0
+	create := func(resource string, severity models.Severity, age, resolvedAfter time.Duration) {
+		issue, err := issues.Create(ctx, dto.CreateIssueRequest{
+			Title:       "Status Issue " + resource,
+			Description: "Testing the status page",
+			Severity:    severity,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "test-namespace",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      resource,
+				ResourceNamespace: "test-namespace",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		detectedAt := time.Now().Add(-age)
+		updates := map[string]any{"detected_at": detectedAt}
+		if resolvedAfter > 0 {
+			updates["state"] = models.IssueStateResolved
+			updates["resolved_at"] = detectedAt.Add(resolvedAfter)
+		}
+		if err := db.Model(issue).Updates(updates).Error; err != nil {
+			t.Fatalf("Failed to age issue: %v", err)
+		}
+	}
+
+	create("met", models.SeverityCritical, 48*time.Hour, time.Hour)
+	create("missed", models.SeverityCritical, 48*time.Hour, 6*time.Hour)
+	create("breaching", models.SeverityCritical, 5*time.Hour, 0)
+	create("fresh", models.SeverityCritical, time.Hour, 0)
+	create("old", models.SeverityCritical, 60*24*time.Hour, time.Hour)
+	create("minor", models.SeverityMinor, time.Hour, 0)
+	if _, err := settings.Save(ctx, models.NamespaceSettings{
+		Namespace:  "test-namespace",
+		SLATargets: models.SLATargets{CriticalHours: 4},
+	}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	status, err := service.GetStatus(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if status.ActiveTotal != 3 || status.ActiveBySeverity[models.SeverityCritical] != 2 || status.ActiveBySeverity[models.SeverityMinor] != 1 {
+		t.Errorf("Unexpected active counts %d, %+v", status.ActiveTotal, status.ActiveBySeverity)
+	}
+	if len(status.RecentCritical) != 5 || status.RecentCritical[0].Title != "Status Issue fresh" {
+		t.Errorf("Expected the critical issues, the most recent first, got %+v", status.RecentCritical)
+	}
+	// The issue resolved before the window doesn't count, the breaching one misses the target
+	if len(status.SLAs) != 1 || status.SLAs[0].Met != 1 || status.SLAs[0].Missed != 2 || status.SLAs[0].Uptime != 33.33 {
+		t.Errorf("Unexpected SLAs %+v", status.SLAs)
+	}
+	if status.SLAWindowDays != 30 {
+		t.Errorf("Expected a window of 30 days, got %d", status.SLAWindowDays)
+	}
+}
+
+func TestDashboardService_GetStatus_WithoutSettings(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	service := NewDashboardService(issues, repository.NewNamespaceSettingsRepository(db, logger, 0), logger)
+
+	status, err := service.GetStatus(ctx, "empty-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if status.ActiveTotal != 0 || len(status.ActiveBySeverity) != 4 || status.RecentCritical == nil || status.SLAs == nil || len(status.SLAs) != 0 {
+		t.Errorf("Expected an empty status, got %+v", status)
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
@@ -12,16 +13,115 @@ import (
 // This allows us to mock it for testing
 type IssueServiceInterface interface {
 	FindIssues(ctx context.Context, filters repository.IssueQueryFilters) (*dto.IssueResponse, error)
+	FindIssuesGrouped(ctx context.Context, filters repository.IssueQueryFilters, groupBy string) (*dto.GroupedIssuesResponse, error)
+	StreamIssues(ctx context.Context, filters repository.IssueQueryFilters, fn func(issues []models.Issue) error) error
 	FindIssueByID(ctx context.Context, id string) (*models.Issue, error)
+	FindSimilarIssues(ctx context.Context, issue models.Issue, limit int) ([]repository.SimilarIssue, error)
 	CreateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error)
 	UpdateIssue(ctx context.Context, id string, req dto.UpdateIssueRequest) (*models.Issue, error)
 	DeleteIssue(ctx context.Context, id string) error
 	FindDuplicateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error)
-	ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error)
-	AddRelatedIssue(ctx context.Context, sourceID, targetID string) error
+	CheckDuplicateIssue(ctx context.Context, req dto.CreateIssueRequest) (*DuplicateCheckResult, error)
+	ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string, resolution dto.Resolution) (int64, error)
+	AddRelatedIssue(ctx context.Context, sourceID, targetID string, relationType models.RelationType) error
 	RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error
+	FindRelatedIssues(ctx context.Context, id, namespace string) ([]dto.RelatedIssueEntry, error)
+	FindActiveEffects(ctx context.Context, id string) ([]string, error)
+	AddExternalRef(ctx context.Context, issueID string, req dto.CreateExternalRefRequest) (*models.ExternalRef, error)
+	RemoveExternalRef(ctx context.Context, issueID, refID string) error
+	AcknowledgeIssue(ctx context.Context, id, namespace, acknowledgedBy string) (*models.Issue, error)
+	SnoozeIssue(ctx context.Context, id, namespace string, until *time.Time, snoozedBy string) (*models.Issue, error)
 	CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error)
+	ProcessWebhookBatch(ctx context.Context, items []dto.WebhookBatchItem) ([]dto.WebhookBatchItemResult, error)
+	DryRunIssue(ctx context.Context, req dto.CreateIssueRequest, source models.IssueSource) (*dto.DryRunResult, error)
+	DryRunWebhookBatch(ctx context.Context, items []dto.WebhookBatchItem) ([]dto.WebhookBatchItemResult, error)
 }
 
 // Compile-time interface check to verify that IssueService implements the interface
 var _ IssueServiceInterface = (*IssueService)(nil)
+
+// NamespaceSettingsServiceInterface defines what a namespace settings service should do
+type NamespaceSettingsServiceInterface interface {
+	GetSettings(ctx context.Context, namespace string) (*models.NamespaceSettings, error)
+	UpdateSettings(ctx context.Context, namespace string, req dto.UpdateNamespaceSettingsRequest) (*models.NamespaceSettings, error)
+	ResetSettings(ctx context.Context, namespace string) error
+}
+
+var _ NamespaceSettingsServiceInterface = (*NamespaceSettingsService)(nil)
+
+// IssueWatchServiceInterface defines what an issue watch service should do
+type IssueWatchServiceInterface interface {
+	WatchIssue(ctx context.Context, issueID, namespace, user string) (*models.IssueWatch, error)
+	UnwatchIssue(ctx context.Context, issueID, user string) error
+	FindWatchedIssues(ctx context.Context, user string) ([]models.Issue, error)
+}
+
+var _ IssueWatchServiceInterface = (*IssueWatchService)(nil)
+
+// SavedViewServiceInterface defines what a saved view service should do
+type SavedViewServiceInterface interface {
+	GetViews(ctx context.Context, namespace, user string) ([]models.SavedView, error)
+	SaveView(ctx context.Context, namespace, user, name, query string) (*models.SavedView, error)
+	DeleteView(ctx context.Context, namespace, user, name string) error
+	ResolveView(ctx context.Context, name, namespace, user string) (*models.SavedView, error)
+}
+
+var _ SavedViewServiceInterface = (*SavedViewService)(nil)
+
+// DashboardServiceInterface defines what a dashboard service should do
+type DashboardServiceInterface interface {
+	GetDashboard(ctx context.Context, namespace string) (*dto.DashboardResponse, error)
+	GetStatus(ctx context.Context, namespace string) (*dto.StatusResponse, error)
+}
+
+var _ DashboardServiceInterface = (*DashboardService)(nil)
+
+// TriageRuleServiceInterface defines what a triage rule service should do
+type TriageRuleServiceInterface interface {
+	GetRules(ctx context.Context, namespace string) ([]models.TriageRule, error)
+	SaveRule(ctx context.Context, namespace, name string, req dto.SaveTriageRuleRequest) (*models.TriageRule, error)
+	DeleteRule(ctx context.Context, namespace, name string) error
+	FindEvents(ctx context.Context, issueID, namespace string) ([]models.TriageEvent, error)
+}
+
+var _ TriageRuleServiceInterface = (*TriageRuleService)(nil)
+
+// WebhookEventServiceInterface defines what a webhook event service should do
+type WebhookEventServiceInterface interface {
+	RecordEvent(ctx context.Context, source, namespace string, payload []byte, signature models.WebhookSignatureStatus, issueID *string) (*models.WebhookEvent, error)
+	GetEvents(ctx context.Context, filters repository.WebhookEventFilters) ([]models.WebhookEvent, error)
+	GetEvent(ctx context.Context, id string) (*models.WebhookEvent, error)
+	GetPayload(ctx context.Context, event *models.WebhookEvent) ([]byte, error)
+}
+
+var _ WebhookEventServiceInterface = (*WebhookEventService)(nil)
+
+// IssueActivityServiceInterface defines what an issue activity service should do
+type IssueActivityServiceInterface interface {
+	GetActivity(ctx context.Context, issueID, namespace string) ([]dto.ActivityEntry, error)
+}
+
+var _ IssueActivityServiceInterface = (*IssueActivityService)(nil)
+
+// ScopeTimelineServiceInterface defines what a scope timeline service should do
+type ScopeTimelineServiceInterface interface {
+	GetTimeline(ctx context.Context, scopeID, namespace string, since time.Time) (*dto.ScopeTimeline, error)
+}
+
+var _ ScopeTimelineServiceInterface = (*ScopeTimelineService)(nil)
+
+// NamespaceOffboardingServiceInterface defines what a namespace offboarding service should do
+type NamespaceOffboardingServiceInterface interface {
+	StartOffboarding(ctx context.Context, namespace string, mode dto.OffboardingMode, user string) (*dto.OffboardingJob, error)
+	GetOffboardingJob(ctx context.Context, id string) (*dto.OffboardingJob, error)
+}
+
+var _ NamespaceOffboardingServiceInterface = (*NamespaceOffboardingService)(nil)
+
+// MaintenanceServiceInterface defines what a database maintenance service should do
+type MaintenanceServiceInterface interface {
+	StartMaintenance(ctx context.Context, task dto.MaintenanceTask, user string) (*dto.MaintenanceJob, error)
+	GetMaintenanceJob(ctx context.Context, id string) (*dto.MaintenanceJob, error)
+}
+
+var _ MaintenanceServiceInterface = (*MaintenanceService)(nil)
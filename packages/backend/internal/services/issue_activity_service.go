@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// activityReportsLimit is how many of the most recent webhook reports of an
+// issue its activity feed holds
+const activityReportsLimit = 100
+
+type IssueActivityService struct {
+	issues   repository.IssueRepository        // Repository instance
+	triage   repository.TriageRuleRepository   // Repository of the triage events
+	webhooks repository.WebhookEventRepository // Repository of the webhook reports
+	logger   *logrus.Logger                    // Logging instance
+}
+
+func NewIssueActivityService(issues repository.IssueRepository, triage repository.TriageRuleRepository, webhooks repository.WebhookEventRepository, logger *logrus.Logger) *IssueActivityService {
+	return &IssueActivityService{
+		issues:   issues,
+		triage:   triage,
+		webhooks: webhooks,
+		logger:   logger,
+	}
+}
+
+// GetActivity returns the activity feed of an issue, the oldest entry first:
+// its detection, the webhooks reporting it, the triage rules changing it, the
+// references to other trackers added to it, and its last resolution and reopen.
+// Issues of other namespaces than namespace, unless empty, are denied.
+func (s *IssueActivityService) GetActivity(ctx context.Context, issueID, namespace string) ([]dto.ActivityEntry, error) {
+	issue, err := s.issues.FindByID(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+
+	activity := []dto.ActivityEntry{{
+		Type:      dto.ActivityDetected,
+		Timestamp: issue.DetectedAt,
+		Summary:   "Issue detected",
+	}}
+
+	reports, err := s.webhooks.FindAll(ctx, repository.WebhookEventFilters{IssueID: issueID, Limit: activityReportsLimit})
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		activity = append(activity, dto.ActivityEntry{
+			Type:      dto.ActivityReported,
+			Timestamp: report.ReceivedAt,
+			Actor:     report.Source,
+			Summary:   fmt.Sprintf("Reported by the %s webhook", report.Source),
+			Details:   map[string]string{"webhookEventId": report.ID},
+		})
+	}
+
+	triageEvents, err := s.triage.FindEvents(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range triageEvents {
+		activity = append(activity, dto.ActivityEntry{
+			Type:      dto.ActivityTriaged,
+			Timestamp: event.CreatedAt,
+			Actor:     event.RuleName,
+			Summary:   fmt.Sprintf("Triaged by rule %s", event.RuleName),
+			Details:   event.Changes,
+		})
+	}
+
+	for _, ref := range issue.ExternalRefs {
+		activity = append(activity, dto.ActivityEntry{
+			Type:      dto.ActivityExternalRef,
+			Timestamp: ref.CreatedAt,
+			Summary:   fmt.Sprintf("Tracked in %s as %s", ref.System, ref.Key),
+			Details:   map[string]string{"system": ref.System, "key": ref.Key, "url": ref.URL},
+		})
+	}
+
+	if issue.AcknowledgedAt != nil {
+		activity = append(activity, dto.ActivityEntry{
+			Type:      dto.ActivityAcknowledged,
+			Timestamp: *issue.AcknowledgedAt,
+			Actor:     issue.AcknowledgedBy,
+			Summary:   "Issue acknowledged",
+		})
+	}
+	if issue.ReopenedAt != nil {
+		activity = append(activity, dto.ActivityEntry{
+			Type:      dto.ActivityReopened,
+			Timestamp: *issue.ReopenedAt,
+			Summary:   "Issue reopened",
+			Details:   map[string]string{"reopenCount": strconv.Itoa(issue.ReopenCount)},
+		})
+	}
+	// Reopened issues keep their last resolution, preceding the reopen
+	if issue.ResolvedAt != nil {
+		entry := dto.ActivityEntry{
+			Type:      dto.ActivityResolved,
+			Timestamp: *issue.ResolvedAt,
+			Actor:     issue.ResolvedBy,
+			Summary:   "Issue resolved",
+		}
+		if issue.ResolutionReason != "" {
+			entry.Details = map[string]string{"reason": issue.ResolutionReason}
+		}
+		activity = append(activity, entry)
+	}
+
+	// Entries of the same time keep their order, the detection first
+	slices.SortStableFunc(activity, func(a, b dto.ActivityEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return activity, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+func TestIssueActivityService_GetActivity(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	triage := repository.NewTriageRuleRepository(db, logger, 0)
+	webhooks := repository.NewWebhookEventRepository(db, logger, 0)
+	service := NewIssueActivityService(issues, triage, webhooks, logger)
+
+	issue, err := issues.Create(ctx, dto.CreateIssueRequest{
+		Title:       "Activity Issue",
+		Description: "Testing the activity feed",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "test-namespace",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      "activity",
+			ResourceNamespace: "test-namespace",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := db.Model(issue).Update("detected_at", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("Failed to age issue: %v", err)
+	}
+
+	if err := triage.RecordEvents(ctx, []models.TriageEvent{
+		{IssueID: issue.ID, RuleName: "builds", Changes: map[string]string{"assignee": "alice"}},
+	}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := webhooks.Create(ctx, models.WebhookEvent{
+		Source:          "pipeline-failure",
+		Namespace:       "test-namespace",
+		Payload:         []byte(`{}`),
+		SignatureStatus: models.WebhookSignatureUnchecked,
+		IssueID:         &issue.ID,
+	}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := issues.AddExternalRef(ctx, issue.ID, models.ExternalRef{System: "jira", Key: "KFLUXBUGS-1", URL: "https://jira.example.com/browse/KFLUXBUGS-1"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	for _, state := range []models.IssueState{models.IssueStateResolved, models.IssueStateActive} {
+		if _, err := issues.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: state}); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	if _, err := service.GetActivity(ctx, issue.ID, "other-namespace"); err == nil || err.Error() != "access denied to this namespace" {
+		t.Errorf("Expected access denied to this namespace, got %v", err)
+	}
+	if _, err := service.GetActivity(ctx, "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f", ""); err == nil || err.Error() != "issue not found" {
+		t.Errorf("Expected issue not found, got %v", err)
+	}
+
+	activity, err := service.GetActivity(ctx, issue.ID, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expected := []dto.ActivityType{
+		dto.ActivityDetected, dto.ActivityTriaged, dto.ActivityReported, dto.ActivityExternalRef,
+		dto.ActivityResolved, dto.ActivityReopened,
+	}
+	if len(activity) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), activity)
+	}
+	for i, entry := range activity {
+		if entry.Type != expected[i] {
+			t.Errorf("Expected entry %d to be %s, got %+v", i, expected[i], entry)
+		}
+		if i > 0 && entry.Timestamp.Before(activity[i-1].Timestamp) {
+			t.Errorf("Expected the entries in chronological order, got %+v", activity)
+		}
+	}
+	if activity[1].Actor != "builds" || activity[1].Details["assignee"] != "alice" {
+		t.Errorf("Unexpected triage entry %+v", activity[1])
+	}
+	if activity[3].Details["key"] != "KFLUXBUGS-1" || activity[5].Details["reopenCount"] != "1" {
+		t.Errorf("Unexpected entries %+v", activity)
+	}
+}
@@ -2,42 +2,76 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
 	"github.com/konflux-ci/kite/internal/models"
 	"github.com/konflux-ci/kite/internal/repository"
 	"github.com/sirupsen/logrus"
 )
 
 type IssueService struct {
-	repo   repository.IssueRepository // Repository instance
-	logger *logrus.Logger             // Logging instance
+	repo          repository.IssueRepository             // Repository instance
+	uow           repository.UnitOfWork                  // Runs operations spanning several repository calls atomically
+	settings      repository.NamespaceSettingsRepository // Settings holding the workflows of the namespaces, nil for the default workflow
+	linkTemplates []models.LinkTemplate                  // Default link templates of the issues of all the namespaces
+	logger        *logrus.Logger                         // Logging instance
 }
 
 type IssueQueryFilters struct {
-	Namespace    string
-	Severity     *models.Severity
-	IssueType    *models.IssueType
-	State        *models.IssueState
-	ResourceType string
-	ResourceName string
-	Search       string
-	Limit        int
-	Offset       int
+	Namespace         string
+	Severity          *models.Severity
+	IssueType         *models.IssueType
+	State             *models.IssueState
+	ResourceType      string
+	ResourceName      string
+	ResourceNamespace string
+	Search            string
+	Limit             int
+	Offset            int
 }
 
+// DuplicateCheckResult tells whether creating an issue would update an
+// existing one, see CheckDuplicateIssue
 type DuplicateCheckResult struct {
-	IsDuplicate   bool
-	ExistingIssue *models.Issue
+	IsDuplicate   bool          `json:"isDuplicate"`
+	ExistingIssue *models.Issue `json:"existingIssue,omitempty"`
 }
 
-func NewIssueService(repo repository.IssueRepository, logger *logrus.Logger) *IssueService {
+func NewIssueService(repo repository.IssueRepository, uow repository.UnitOfWork, settings repository.NamespaceSettingsRepository, linkTemplates []models.LinkTemplate, logger *logrus.Logger) *IssueService {
 	return &IssueService{
-		repo:   repo,
-		logger: logger,
+		repo:          repo,
+		uow:           uow,
+		settings:      settings,
+		linkTemplates: linkTemplates,
+		logger:        logger,
 	}
 }
 
+// TransitionError is returned when the workflow of the namespace of an issue
+// doesn't allow moving the issue to a state
+type TransitionError struct {
+	From models.IssueState
+	To   models.IssueState
+	// Allowed are the states the issue may move to
+	Allowed []models.IssueState
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("state transition from %s to %s not allowed", e.From, e.To)
+}
+
+// Unwrap makes transition errors of the repository.ErrConflict kind
+func (e *TransitionError) Unwrap() error {
+	return repository.ErrConflict
+}
+
 // CheckForDuplicateIssue checks if a similar issue already exists
 func (s *IssueService) FindDuplicateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
 	issueFound, err := s.repo.FindDuplicate(ctx, req)
@@ -47,15 +81,22 @@ func (s *IssueService) FindDuplicateIssue(ctx context.Context, req dto.CreateIss
 	return issueFound, nil
 }
 
+// CheckDuplicateIssue checks whether creating an issue would merge it into
+// an existing issue of the same namespace, issue type and scope, without
+// creating it
+func (s *IssueService) CheckDuplicateIssue(ctx context.Context, req dto.CreateIssueRequest) (*DuplicateCheckResult, error) {
+	existing, err := s.FindDuplicateIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &DuplicateCheckResult{IsDuplicate: existing != nil, ExistingIssue: existing}, nil
+}
+
 // CreateOrUpdateIssue creates an issue if a duplicate is not found and updates the record if it is.
 //
 // NOTE: This method is mainly used for webhook endpoints.
 func (s *IssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
-	issue, err := s.repo.CreateOrUpdate(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return issue, nil
+	return s.triageAndSave(ctx, req, models.IssueSourceWebhook, saveIssue(ctx, models.IssueSourceWebhook))
 }
 
 // FindIssues retrieves issues with optional filters
@@ -65,14 +106,101 @@ func (s *IssueService) FindIssues(ctx context.Context, filters repository.IssueQ
 		return nil, err
 	}
 
-	return &dto.IssueResponse{
+	response := &dto.IssueResponse{
 		Data:   issues,
-		Total:  total,
 		Limit:  filters.Limit,
 		Offset: filters.Offset,
+	}
+	hasNextPage := int64(filters.Offset+len(issues)) < total
+	if filters.SkipTotal {
+		// Without a count, the repository loads an extra issue when there's a next page
+		hasNextPage = filters.Limit > 0 && len(issues) > filters.Limit
+		if hasNextPage {
+			response.Data = issues[:filters.Limit]
+		}
+	} else {
+		response.Total = &total
+	}
+	if nextOffset := filters.Offset + len(response.Data); len(response.Data) > 0 && hasNextPage {
+		response.HasNextPage = true
+		response.NextOffset = &nextOffset
+	}
+	return response, nil
+}
+
+// streamBatchSize is how many issues are loaded per query when streaming them
+const streamBatchSize = 500
+
+// StreamIssues finds all the issues matching filters, the most recently
+// detected first, passing them to fn batch by batch rather than loading them
+// all at once, see repository.StreamAll. Pagination and sorting are ignored.
+func (s *IssueService) StreamIssues(ctx context.Context, filters repository.IssueQueryFilters, fn func(issues []models.Issue) error) error {
+	return repository.StreamAll(ctx, s.repo, filters, streamBatchSize, fn)
+}
+
+// Fields issues can be grouped by
+const (
+	GroupByResource = "resource"
+	GroupBySeverity = "severity"
+	GroupByType     = "type"
+)
+
+// ValidGroupBy lists the fields issues can be grouped by
+var ValidGroupBy = []string{GroupByResource, GroupBySeverity, GroupByType}
+
+// FindIssuesGrouped retrieves issues with optional filters, grouped by their
+// resource, type or severity.
+//
+// Filters (including pagination) apply to the issues before they're grouped.
+// Severity groups are ordered from most to least severe, other groups by key.
+func (s *IssueService) FindIssuesGrouped(ctx context.Context, filters repository.IssueQueryFilters, groupBy string) (*dto.GroupedIssuesResponse, error) {
+	issues, total, err := s.repo.FindAll(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []dto.IssueGroup{}
+	index := map[string]int{}
+	for _, issue := range issues {
+		key := issueGroupKey(issue, groupBy)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, dto.IssueGroup{Key: key})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+		groups[i].Count++
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		if groupBy == GroupBySeverity {
+			return models.Severity(groups[a].Key).Rank() > models.Severity(groups[b].Key).Rank()
+		}
+		return groups[a].Key < groups[b].Key
+	})
+
+	return &dto.GroupedIssuesResponse{
+		GroupBy: groupBy,
+		Groups:  groups,
+		Total:   total,
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
 	}, nil
 }
 
+// issueGroupKey returns the key of the group an issue belongs to
+func issueGroupKey(issue models.Issue, groupBy string) string {
+	switch groupBy {
+	case GroupBySeverity:
+		return string(issue.Severity)
+	case GroupByType:
+		return string(issue.IssueType)
+	default:
+		return fmt.Sprintf("%s/%s", issue.Scope.ResourceType, issue.Scope.ResourceName)
+	}
+}
+
 // FindIssueByID retrieves a single issue by ID
 func (s *IssueService) FindIssueByID(ctx context.Context, id string) (*models.Issue, error) {
 	issue, err := s.repo.FindByID(ctx, id)
@@ -82,17 +210,276 @@ func (s *IssueService) FindIssueByID(ctx context.Context, id string) (*models.Is
 	return issue, nil
 }
 
+// FindSimilarIssues ranks the issues of the namespace of an issue similar to it, the most similar first
+func (s *IssueService) FindSimilarIssues(ctx context.Context, issue models.Issue, limit int) ([]repository.SimilarIssue, error) {
+	return s.repo.FindSimilar(ctx, issue, limit)
+}
+
 // CreateIssue creates a new issue if a duplicate is not found and updates the record if it is.
 func (s *IssueService) CreateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
-	issue, err := s.repo.Create(ctx, req)
+	return s.triageAndSave(ctx, req, models.IssueSourceAPI, saveIssue(ctx, models.IssueSourceAPI))
+}
+
+// triageAndSave applies the triage rules of the namespace of an issue reported
+// from source to it before saving it with save. The rules are applied to
+// reports of existing issues too, so that they keep the fields set by the
+// rules, but their events are only recorded when the issue is created.
+// Webhooks reporting an existing issue again don't lower its severity when
+// the namespace only allows escalating it.
+func (s *IssueService) triageAndSave(ctx context.Context, req dto.CreateIssueRequest, source models.IssueSource, save func(issues repository.IssueRepository, req dto.CreateIssueRequest) (*models.Issue, error)) (*models.Issue, error) {
+	var issue *models.Issue
+	var events []models.TriageEvent
+	err := s.uow.Do(ctx, func(repos repository.Repositories) error {
+		var err error
+		issue, events, err = s.triageAndSaveIn(ctx, repos, req, source, save)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	s.logTriageEvents(ctx, issue, events)
 	return issue, nil
 }
 
+// triageAndSaveIn triages and saves an issue as triageAndSave does, in the
+// transaction of repos, returning the triage events recorded
+func (s *IssueService) triageAndSaveIn(ctx context.Context, repos repository.Repositories, req dto.CreateIssueRequest, source models.IssueSource, save func(issues repository.IssueRepository, req dto.CreateIssueRequest) (*models.Issue, error)) (*models.Issue, []models.TriageEvent, error) {
+	rules, err := repos.TriageRules.FindAll(ctx, req.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := triageIssue(rules, &req, source)
+	settings, err := repos.Settings.Find(ctx, req.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Links = s.expandLinkTemplates(settings, req)
+	// Webhooks may only raise the severity of the issues they report again
+	escalationOnly := source == models.IssueSourceWebhook && settings != nil && settings.SeverityEscalationOnly
+	if len(events) == 0 && !escalationOnly {
+		issue, err := save(repos.Issues, req)
+		return issue, nil, err
+	}
+
+	existing, err := repos.Issues.FindDuplicate(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if escalationOnly && existing != nil && existing.Severity.Rank() > req.Severity.Rank() {
+		req.Severity = existing.Severity
+	}
+	issue, err := save(repos.Issues, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		return issue, nil, nil
+	}
+	for i := range events {
+		events[i].IssueID = issue.ID
+	}
+	return issue, events, repos.TriageRules.RecordEvents(ctx, events)
+}
+
+// expandLinkTemplates returns the links of an issue along with those of the
+// link templates of its namespace and of the default templates matching it,
+// see models.LinkTemplate. Links with the title of a link already there are
+// left out, so that the links of the report take precedence over those of the
+// namespace, and those of the namespace over the defaults. Templates missing
+// the value of a variable are left out too.
+func (s *IssueService) expandLinkTemplates(settings *models.NamespaceSettings, req dto.CreateIssueRequest) []dto.CreateLinkRequest {
+	var templates []models.LinkTemplate
+	if settings != nil {
+		templates = settings.LinkTemplates
+	}
+	templates = append(slices.Clip(templates), s.linkTemplates...)
+	if len(templates) == 0 {
+		return req.Links
+	}
+
+	variables := map[string]string{
+		"namespace":         req.Namespace,
+		"issueType":         string(req.IssueType),
+		"severity":          string(req.Severity),
+		"resourceType":      req.Scope.ResourceType,
+		"resourceName":      req.Scope.ResourceName,
+		"resourceNamespace": req.Scope.ResourceNamespace,
+	}
+	for key, value := range req.Labels {
+		variables["label."+key] = value
+	}
+	maps.Copy(variables, req.LinkVariables)
+
+	links := slices.Clone(req.Links)
+	for _, template := range templates {
+		taken := slices.ContainsFunc(links, func(link dto.CreateLinkRequest) bool { return link.Title == template.Title })
+		if taken || !template.Matches(req.IssueType, req.Scope.ResourceType) {
+			continue
+		}
+		if url, ok := template.Expand(variables); ok {
+			links = append(links, dto.CreateLinkRequest{Title: template.Title, URL: url})
+		}
+	}
+	return links
+}
+
+// logTriageEvents logs the triage rules that fired on an issue
+func (s *IssueService) logTriageEvents(ctx context.Context, issue *models.Issue, events []models.TriageEvent) {
+	for _, event := range events {
+		logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{"issue_id": issue.ID, "rule": event.RuleName}).Info("Triage rule fired")
+	}
+}
+
+// ProcessWebhookBatch processes the operations of a batch of webhooks in
+// order, in one transaction: either all of them succeed, or none is applied.
+// Reported issues are triaged and deduplicated as CreateOrUpdateIssue does,
+// and resolutions resolve the open issues of their scope as
+// ResolveIssuesByScope does.
+func (s *IssueService) ProcessWebhookBatch(ctx context.Context, items []dto.WebhookBatchItem) ([]dto.WebhookBatchItemResult, error) {
+	var results []dto.WebhookBatchItemResult
+	var events [][]models.TriageEvent
+	err := s.uow.Do(ctx, func(repos repository.Repositories) error {
+		var err error
+		results, events, err = s.processWebhookBatchIn(ctx, repos, items, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		if result.Issue != nil {
+			s.logTriageEvents(ctx, result.Issue, events[i])
+		}
+	}
+	return results, nil
+}
+
+// DryRunWebhookBatch processes a batch of webhooks as ProcessWebhookBatch
+// does, rolling the transaction back afterwards. The results tell what each
+// operation would do, the operations seeing the changes of the previous ones.
+func (s *IssueService) DryRunWebhookBatch(ctx context.Context, items []dto.WebhookBatchItem) ([]dto.WebhookBatchItemResult, error) {
+	var results []dto.WebhookBatchItemResult
+	err := s.uow.Do(ctx, func(repos repository.Repositories) error {
+		var err error
+		results, _, err = s.processWebhookBatchIn(ctx, repos, items, true)
+		if err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return results, nil
+}
+
+// processWebhookBatchIn processes a batch of webhooks as ProcessWebhookBatch
+// does, in the transaction of repos, returning the triage events recorded for
+// each operation. Dry runs set the action of the issues reported.
+func (s *IssueService) processWebhookBatchIn(ctx context.Context, repos repository.Repositories, items []dto.WebhookBatchItem, dryRun bool) ([]dto.WebhookBatchItemResult, [][]models.TriageEvent, error) {
+	results := make([]dto.WebhookBatchItemResult, len(items))
+	events := make([][]models.TriageEvent, len(items))
+	for i, item := range items {
+		if item.Issue != nil && dryRun {
+			result, err := s.dryRunIn(ctx, repos, *item.Issue, models.IssueSourceWebhook)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to process item %d: %w", i, err)
+			}
+			results[i].Issue = result.Issue
+			results[i].Action = result.Action
+			continue
+		}
+		if item.Issue != nil {
+			issue, itemEvents, err := s.triageAndSaveIn(ctx, repos, *item.Issue, models.IssueSourceWebhook, saveIssue(ctx, models.IssueSourceWebhook))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to process item %d: %w", i, err)
+			}
+			results[i].Issue = issue
+			events[i] = itemEvents
+			continue
+		}
+
+		scope := item.ResolveScope
+		resolved, err := repos.Issues.ResolveByScope(ctx, scope.ResourceType, scope.ResourceName, scope.ResourceNamespace, item.Resolution)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to process item %d: %w", i, err)
+		}
+		results[i].Resolved = resolved
+	}
+	return results, events, nil
+}
+
+// errDryRun rolls back the transactions of dry runs
+var errDryRun = errors.New("dry run")
+
+// DryRunIssue tells what reporting an issue from source would do, without
+// saving it: creating it, or updating or reopening its duplicate. The issue is
+// triaged and saved as CreateIssue or CreateOrUpdateIssue would, in a
+// transaction rolled back afterwards.
+func (s *IssueService) DryRunIssue(ctx context.Context, req dto.CreateIssueRequest, source models.IssueSource) (*dto.DryRunResult, error) {
+	var result *dto.DryRunResult
+	err := s.uow.Do(ctx, func(repos repository.Repositories) error {
+		var err error
+		result, err = s.dryRunIn(ctx, repos, req, source)
+		if err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// dryRunIn triages and saves an issue in the transaction of repos, which
+// must be rolled back, telling what was done
+func (s *IssueService) dryRunIn(ctx context.Context, repos repository.Repositories, req dto.CreateIssueRequest, source models.IssueSource) (*dto.DryRunResult, error) {
+	existing, err := repos.Issues.FindDuplicate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	issue, events, err := s.triageAndSaveIn(ctx, repos, req, source, saveIssue(ctx, source))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.DryRunResult{DryRun: true, Action: dto.DryRunCreate, Issue: issue, ExistingIssue: existing}
+	if existing != nil {
+		result.Action = dto.DryRunUpdate
+		if existing.State == models.IssueStateResolved && issue.State.Open() {
+			result.Action = dto.DryRunReopen
+		}
+	}
+	for _, event := range events {
+		result.TriageRules = append(result.TriageRules, event.RuleName)
+	}
+	return result, nil
+}
+
+// saveIssue returns how issues reported from source are saved: those of the
+// API with Create, and those of webhooks with CreateOrUpdate
+func saveIssue(ctx context.Context, source models.IssueSource) func(issues repository.IssueRepository, req dto.CreateIssueRequest) (*models.Issue, error) {
+	if source == models.IssueSourceAPI {
+		return func(issues repository.IssueRepository, req dto.CreateIssueRequest) (*models.Issue, error) {
+			return issues.Create(ctx, req)
+		}
+	}
+	return func(issues repository.IssueRepository, req dto.CreateIssueRequest) (*models.Issue, error) {
+		return issues.CreateOrUpdate(ctx, req)
+	}
+}
+
 // UpdateIssue updates and existing issue
 func (s *IssueService) UpdateIssue(ctx context.Context, id string, req dto.UpdateIssueRequest) (*models.Issue, error) {
+	if req.State != "" {
+		if err := s.checkTransition(ctx, id, req.State); err != nil {
+			return nil, err
+		}
+	}
+
 	issue, err := s.repo.Update(ctx, id, req)
 	if err != nil {
 		return nil, err
@@ -100,23 +487,69 @@ func (s *IssueService) UpdateIssue(ctx context.Context, id string, req dto.Updat
 	return issue, nil
 }
 
-// DeleteIssue deletes an issue and related entities
-func (s *IssueService) DeleteIssue(ctx context.Context, id string) error {
-	err := s.repo.Delete(ctx, id)
+// checkTransition checks the workflow of the namespace of an issue allows
+// moving the issue to a state, returning a *TransitionError if it doesn't
+func (s *IssueService) checkTransition(ctx context.Context, id string, to models.IssueState) error {
+	issue, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if issue == nil {
+		return repository.NotFoundError("issue not found")
+	}
+
+	workflow, err := s.workflow(ctx, issue.Namespace)
+	if err != nil {
+		return err
+	}
+	// Issues in a state the workflow no longer has may move to any of its states
+	if !workflow.HasState(issue.State) && workflow.HasState(to) {
+		return nil
+	}
+	if !workflow.HasState(to) || !workflow.Allows(issue.State, to) {
+		return &TransitionError{From: issue.State, To: to, Allowed: workflow.Transitions[issue.State]}
+	}
 	return nil
 }
 
-// AddRelatedIsue creates a relationship between two issues
-func (s *IssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID string) error {
-	if err := s.repo.AddRelatedIssue(ctx, sourceID, targetID); err != nil {
+// workflow returns the workflow of a namespace, the default workflow if it has none
+func (s *IssueService) workflow(ctx context.Context, namespace string) (models.Workflow, error) {
+	if s.settings == nil {
+		return models.DefaultWorkflow(), nil
+	}
+	settings, err := s.settings.Find(ctx, namespace)
+	if err != nil {
+		return models.Workflow{}, err
+	}
+	if settings == nil || settings.Workflow == nil {
+		return models.DefaultWorkflow(), nil
+	}
+	return *settings.Workflow, nil
+}
+
+// DeleteIssue deletes an issue and related entities
+func (s *IssueService) DeleteIssue(ctx context.Context, id string) error {
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// AddRelatedIsue creates a relationship between two issues. Checking the
+// issues exist and aren't related yet happens in the same transaction as
+// creating the relationship.
+func (s *IssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID string, relationType models.RelationType) error {
+	return s.uow.Do(ctx, func(repos repository.Repositories) error {
+		return repos.Issues.AddRelatedIssue(ctx, sourceID, targetID, relationType)
+	})
+}
+
+// FindActiveEffects finds the IDs of the active issues caused by an issue
+func (s *IssueService) FindActiveEffects(ctx context.Context, id string) ([]string, error) {
+	return s.repo.FindActiveEffects(ctx, id)
+}
+
 // RemoveRelatedIssue removes a relationship between issues
 func (s *IssueService) RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error {
 	if err := s.repo.RemoveRelatedIssue(ctx, sourceID, targetID); err != nil {
@@ -125,9 +558,134 @@ func (s *IssueService) RemoveRelatedIssue(ctx context.Context, sourceID, targetI
 	return nil
 }
 
-// ResolveIssuesByScope resolves all active issues for a given scope
-func (s *IssueService) ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error) {
-	count, err := s.repo.ResolveByScope(ctx, resourceType, resourceName, namespace)
+// FindRelatedIssues returns the relationships of an issue in both directions,
+// those of the issue first, each with a summary of the other issue, the most
+// recently detected first. Issues of other namespaces than namespace, unless
+// empty, are denied.
+func (s *IssueService) FindRelatedIssues(ctx context.Context, id, namespace string) ([]dto.RelatedIssueEntry, error) {
+	issue, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+
+	outgoing := make([]dto.RelatedIssueEntry, 0, len(issue.RelatedFrom))
+	for _, relation := range issue.RelatedFrom {
+		outgoing = append(outgoing, relatedIssueEntry(relation, dto.RelationOutgoing, relation.Target))
+	}
+	incoming := make([]dto.RelatedIssueEntry, 0, len(issue.RelatedTo))
+	for _, relation := range issue.RelatedTo {
+		incoming = append(incoming, relatedIssueEntry(relation, dto.RelationIncoming, relation.Source))
+	}
+	mostRecent := func(a, b dto.RelatedIssueEntry) int {
+		return b.Issue.DetectedAt.Compare(a.Issue.DetectedAt)
+	}
+	slices.SortStableFunc(outgoing, mostRecent)
+	slices.SortStableFunc(incoming, mostRecent)
+	return append(outgoing, incoming...), nil
+}
+
+// relatedIssueEntry flattens a relationship seen from one of its issues,
+// other being the other issue
+func relatedIssueEntry(relation models.RelatedIssue, direction dto.RelationDirection, other models.Issue) dto.RelatedIssueEntry {
+	return dto.RelatedIssueEntry{
+		RelationID: relation.ID,
+		Type:       relation.Type,
+		Direction:  direction,
+		Issue: dto.RelatedIssueSummary{
+			ID:           other.ID,
+			Title:        other.Title,
+			Severity:     other.Severity,
+			IssueType:    other.IssueType,
+			State:        other.State,
+			Namespace:    other.Namespace,
+			ResourceType: other.Scope.ResourceType,
+			ResourceName: other.Scope.ResourceName,
+			DetectedAt:   other.DetectedAt,
+			ResolvedAt:   other.ResolvedAt,
+		},
+	}
+}
+
+// AddExternalRef references an issue tracked in another system from an issue
+func (s *IssueService) AddExternalRef(ctx context.Context, issueID string, req dto.CreateExternalRefRequest) (*models.ExternalRef, error) {
+	return s.repo.AddExternalRef(ctx, issueID, models.ExternalRef{
+		System: req.System,
+		Key:    req.Key,
+		URL:    req.URL,
+	})
+}
+
+// RemoveExternalRef removes an external reference from an issue
+func (s *IssueService) RemoveExternalRef(ctx context.Context, issueID, refID string) error {
+	return s.repo.RemoveExternalRef(ctx, issueID, refID)
+}
+
+// AcknowledgeIssue records who acknowledged an open issue and when, checking
+// the issue is in namespace, when set. Active issues move to the ACKNOWLEDGED
+// state when the workflow of their namespace allows it. Issues acknowledged
+// already are returned unchanged, resolved issues can't be acknowledged.
+func (s *IssueService) AcknowledgeIssue(ctx context.Context, id, namespace, acknowledgedBy string) (*models.Issue, error) {
+	issue, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+	if issue.State == models.IssueStateResolved {
+		return nil, repository.ConflictError("issue is resolved")
+	}
+	if issue.AcknowledgedAt != nil {
+		return issue, nil
+	}
+
+	var state models.IssueState
+	if issue.State == models.IssueStateActive {
+		workflow, err := s.workflow(ctx, issue.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if workflow.HasState(models.IssueStateAcknowledged) && workflow.Allows(issue.State, models.IssueStateAcknowledged) {
+			state = models.IssueStateAcknowledged
+		}
+	}
+
+	return s.repo.Acknowledge(ctx, id, acknowledgedBy, state)
+}
+
+// SnoozeIssue records until when an open issue is snoozed and by whom, or
+// wakes it when until is nil, checking the issue is in namespace, when set.
+// Resolved issues can't be snoozed.
+func (s *IssueService) SnoozeIssue(ctx context.Context, id, namespace string, until *time.Time, snoozedBy string) (*models.Issue, error) {
+	issue, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+	if issue.State == models.IssueStateResolved {
+		return nil, repository.ConflictError("issue is resolved")
+	}
+
+	return s.repo.Snooze(ctx, id, until, snoozedBy)
+}
+
+// ResolveIssuesByScope resolves all active issues for a given scope, recording why and by whom
+func (s *IssueService) ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string, resolution dto.Resolution) (int64, error) {
+	count, err := s.repo.ResolveByScope(ctx, resourceType, resourceName, namespace, resolution)
 	if err != nil {
 		return 0, nil
 	}
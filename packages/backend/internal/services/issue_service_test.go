@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
@@ -16,7 +18,7 @@ import (
 func setupServiceDependents(t *testing.T) (context.Context, *logrus.Logger, repository.IssueRepository, *gorm.DB) {
 	db := testhelpers.SetupTestDB(t)
 	logger := logrus.New()
-	repo := repository.NewIssueRepository(db, logger)
+	repo := repository.NewIssueRepository(db, logger, 0)
 	ctx := context.Background()
 
 	return ctx, logger, repo, db
@@ -24,7 +26,7 @@ func setupServiceDependents(t *testing.T) (context.Context, *logrus.Logger, repo
 
 func createTestService(t *testing.T) (*IssueService, context.Context, *gorm.DB) {
 	ctx, logger, repo, db := setupServiceDependents(t)
-	return NewIssueService(repo, logger), ctx, db
+	return NewIssueService(repo, repository.NewUnitOfWork(db, logger, 0), repository.NewNamespaceSettingsRepository(db, logger, 0), nil, logger), ctx, db
 }
 
 func TestIssueService_CreateIssue(t *testing.T) {
@@ -236,6 +238,176 @@ func TestIssueService_FindIssue_WithFilters(t *testing.T) {
 	}
 }
 
+func TestIssueService_FindIssues_NextPage(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	for _, name := range []string{"api", "ui", "db"} {
+		_, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Build failed: " + name,
+			Description: "The build of " + name + " failed",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      name,
+				ResourceNamespace: "team-alpha",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	response, err := service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha", Limit: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !response.HasNextPage || response.NextOffset == nil || *response.NextOffset != 2 {
+		t.Errorf("Expected a next page at offset 2, got hasNextPage=%v nextOffset=%v", response.HasNextPage, response.NextOffset)
+	}
+
+	// Last page
+	response, err = service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha", Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.HasNextPage || response.NextOffset != nil {
+		t.Errorf("Expected no next page, got hasNextPage=%v nextOffset=%v", response.HasNextPage, response.NextOffset)
+	}
+
+	// Without counting the issues
+	response, err = service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha", Limit: 2, SkipTotal: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.Data) != 2 || response.Total != nil {
+		t.Errorf("Expected 2 issues and no total, got %d issues and total %v", len(response.Data), response.Total)
+	}
+	if !response.HasNextPage || response.NextOffset == nil || *response.NextOffset != 2 {
+		t.Errorf("Expected a next page at offset 2, got hasNextPage=%v nextOffset=%v", response.HasNextPage, response.NextOffset)
+	}
+
+	response, err = service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha", Limit: 2, Offset: 2, SkipTotal: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.Data) != 1 || response.HasNextPage {
+		t.Errorf("Expected the last issue and no next page, got %d issues and hasNextPage=%v", len(response.Data), response.HasNextPage)
+	}
+}
+
+func TestIssueService_FindIssuesGrouped(t *testing.T) {
+	// Setup
+	service, ctx, _ := createTestService(t)
+
+	req := []dto.CreateIssueRequest{
+		{
+			Title:       "Frontend build failed",
+			Description: "Testing grouped issues",
+			Severity:    models.SeverityMinor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      "frontend",
+				ResourceNamespace: "team-alpha",
+			},
+		},
+		{
+			Title:       "Frontend tests failed",
+			Description: "Testing grouped issues",
+			Severity:    models.SeverityCritical,
+			IssueType:   models.IssueTypeTest,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      "frontend",
+				ResourceNamespace: "team-alpha",
+			},
+		},
+		{
+			Title:       "Backend build failed",
+			Description: "Testing grouped issues",
+			Severity:    models.SeverityMinor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      "backend",
+				ResourceNamespace: "team-alpha",
+			},
+		},
+	}
+
+	for _, issueReq := range req {
+		_, err := service.CreateIssue(ctx, issueReq)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	filters := repository.IssueQueryFilters{Namespace: "team-alpha"}
+
+	// Group by resource, ordered by key
+	response, err := service.FindIssuesGrouped(ctx, filters, GroupByResource)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Total != 3 {
+		t.Errorf("Expected total 3, got %d", response.Total)
+	}
+	if len(response.Groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(response.Groups))
+	}
+	if response.Groups[0].Key != "component/backend" || response.Groups[0].Count != 1 {
+		t.Errorf("Expected 1 issue in component/backend, got %d in %s", response.Groups[0].Count, response.Groups[0].Key)
+	}
+	if response.Groups[1].Key != "component/frontend" || len(response.Groups[1].Issues) != 2 {
+		t.Errorf("Expected 2 issues in component/frontend, got %d in %s", len(response.Groups[1].Issues), response.Groups[1].Key)
+	}
+
+	// Group by severity, most severe first
+	response, err = service.FindIssuesGrouped(ctx, filters, GroupBySeverity)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.Groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(response.Groups))
+	}
+	if response.Groups[0].Key != string(models.SeverityCritical) {
+		t.Errorf("Expected critical group first, got %s", response.Groups[0].Key)
+	}
+	if response.Groups[1].Key != string(models.SeverityMinor) || response.Groups[1].Count != 2 {
+		t.Errorf("Expected 2 issues in minor group, got %d in %s", response.Groups[1].Count, response.Groups[1].Key)
+	}
+
+	// Group by type
+	response, err = service.FindIssuesGrouped(ctx, filters, GroupByType)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.Groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(response.Groups))
+	}
+	if response.Groups[0].Key != string(models.IssueTypeBuild) || response.Groups[0].Count != 2 {
+		t.Errorf("Expected 2 issues in build group, got %d in %s", response.Groups[0].Count, response.Groups[0].Key)
+	}
+
+	// No matching issues returns no groups
+	response, err = service.FindIssuesGrouped(ctx, repository.IssueQueryFilters{Namespace: "void"}, GroupByResource)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.Groups) != 0 {
+		t.Errorf("Expected 0 groups, got %d", len(response.Groups))
+	}
+}
+
 func TestIssueService_ResolveIssuesByScope(t *testing.T) {
 	// Setup
 	service, ctx, _ := createTestService(t)
@@ -286,7 +458,8 @@ func TestIssueService_ResolveIssuesByScope(t *testing.T) {
 	}
 
 	// Should resolve two issues
-	count, err := service.ResolveIssuesByScope(ctx, "component", "test-component", "team-gamma")
+	resolution := dto.Resolution{Reason: "Rolled back the faulty commit", ResolvedBy: "alice"}
+	count, err := service.ResolveIssuesByScope(ctx, "component", "test-component", "team-gamma", resolution)
 	if err != nil {
 		t.Errorf("unexpected error, got %v", err)
 	}
@@ -295,8 +468,22 @@ func TestIssueService_ResolveIssuesByScope(t *testing.T) {
 		t.Errorf("expected 2 issues resolved, got %d", count)
 	}
 
+	resolved := models.IssueStateResolved
+	response, err := service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-gamma", State: &resolved})
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+	if len(response.Data) != 2 {
+		t.Errorf("expected 2 resolved issues, got %d", len(response.Data))
+	}
+	for _, issue := range response.Data {
+		if issue.ResolutionReason != resolution.Reason || issue.ResolvedBy != resolution.ResolvedBy {
+			t.Errorf("expected the resolution %+v, got reason %q by %q", resolution, issue.ResolutionReason, issue.ResolvedBy)
+		}
+	}
+
 	// Should resolve 1 issue
-	count, err = service.ResolveIssuesByScope(ctx, "release", "release-xyz", "team-alpha")
+	count, err = service.ResolveIssuesByScope(ctx, "release", "release-xyz", "team-alpha", dto.Resolution{})
 	if err != nil {
 		t.Errorf("unexpected error, got %v", err)
 	}
@@ -306,7 +493,7 @@ func TestIssueService_ResolveIssuesByScope(t *testing.T) {
 	}
 
 	// Should resolve non, returning 0
-	count, err = service.ResolveIssuesByScope(ctx, "void", "void", "void")
+	count, err = service.ResolveIssuesByScope(ctx, "void", "void", "void", dto.Resolution{})
 	if err != nil {
 		t.Errorf("unexpected error, got %v", err)
 	}
@@ -353,3 +540,708 @@ func TestIssueService_CheckForDuplicates(t *testing.T) {
 		t.Errorf("expected issue with id '%s', got '%s'", foundIssue.ID, issue.ID)
 	}
 }
+
+func TestIssueService_AddRelatedIssue(t *testing.T) {
+	service, ctx, db := createTestService(t)
+
+	var ids []string
+	for _, name := range []string{"frontend", "backend"} {
+		issue, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Test Service Related " + name,
+			Description: "Testing service layer",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "test-service-namespace",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      name,
+				ResourceNamespace: "test-service-namespace",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	if err := service.AddRelatedIssue(ctx, ids[0], ids[1], models.RelationTypeRelated); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := service.AddRelatedIssue(ctx, ids[1], ids[0], models.RelationTypeRelated); err == nil {
+		t.Error("Expected an error relating the issues again, got nil")
+	}
+	if err := service.AddRelatedIssue(ctx, ids[0], "does-not-exist", models.RelationTypeRelated); err == nil {
+		t.Error("Expected an error relating a missing issue, got nil")
+	}
+
+	var count int64
+	db.Model(&models.RelatedIssue{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 relationship, got %d", count)
+	}
+}
+
+func TestIssueService_FindRelatedIssues(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	ids := map[string]string{}
+	for _, name := range []string{"outage", "frontend", "backend"} {
+		issue, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Test Service Related " + name,
+			Description: "Testing service layer",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "test-service-namespace",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      name,
+				ResourceNamespace: "test-service-namespace",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids[name] = issue.ID
+	}
+	if err := service.AddRelatedIssue(ctx, ids["frontend"], ids["outage"], models.RelationTypeCausedBy); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := service.AddRelatedIssue(ctx, ids["frontend"], ids["backend"], models.RelationTypeRelated); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Both directions are listed, from the issue and to it
+	related, err := service.FindRelatedIssues(ctx, ids["backend"], "test-service-namespace")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(related) != 1 || related[0].Direction != dto.RelationIncoming || related[0].Issue.ID != ids["frontend"] ||
+		related[0].Type != models.RelationTypeRelated || related[0].Issue.ResourceName != "frontend" {
+		t.Errorf("Expected the incoming relationship of frontend, got %+v", related)
+	}
+
+	related, err = service.FindRelatedIssues(ctx, ids["frontend"], "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(related) != 2 {
+		t.Fatalf("Expected 2 relationships, got %+v", related)
+	}
+	for _, entry := range related {
+		if entry.Direction != dto.RelationOutgoing || entry.RelationID == "" {
+			t.Errorf("Expected outgoing relationships, got %+v", entry)
+		}
+		if entry.Issue.ID == ids["outage"] && entry.Type != models.RelationTypeCausedBy {
+			t.Errorf("Expected frontend to be caused by the outage, got %s", entry.Type)
+		}
+	}
+
+	if _, err := service.FindRelatedIssues(ctx, "does-not-exist", ""); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if _, err := service.FindRelatedIssues(ctx, ids["frontend"], "other-namespace"); !errors.Is(err, repository.ErrForbidden) {
+		t.Errorf("Expected a forbidden error, got %v", err)
+	}
+}
+
+func TestIssueService_UpdateIssue_Workflow(t *testing.T) {
+	service, ctx, db := createTestService(t)
+	settings := repository.NewNamespaceSettingsRepository(db, logrus.New(), 0)
+
+	create := func(namespace string) *models.Issue {
+		issue, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Workflow issue",
+			Description: "Testing state transitions",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   namespace,
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      "frontend",
+				ResourceNamespace: namespace,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue
+	}
+	transition := func(issue *models.Issue, state models.IssueState) error {
+		_, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{State: state})
+		return err
+	}
+
+	// The default workflow goes through the built-in states
+	issue := create("default-workflow")
+	for _, state := range []models.IssueState{models.IssueStateAcknowledged, models.IssueStateInProgress, models.IssueStateResolved} {
+		if err := transition(issue, state); err != nil {
+			t.Fatalf("Expected the transition to %s, got %v", state, err)
+		}
+	}
+	var transitionErr *TransitionError
+	err := transition(issue, models.IssueStateInProgress)
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected a transition error, got %v", err)
+	}
+	if transitionErr.From != models.IssueStateResolved || len(transitionErr.Allowed) != 1 || transitionErr.Allowed[0] != models.IssueStateActive {
+		t.Errorf("Expected resolved issues to be reopened only, got %+v", transitionErr)
+	}
+
+	// Namespaces define their own states
+	if _, err := settings.Save(ctx, models.NamespaceSettings{
+		Namespace: "custom-workflow",
+		Workflow: &models.Workflow{
+			States: []models.IssueState{"WAITING_ON_VENDOR"},
+			Transitions: map[models.IssueState][]models.IssueState{
+				models.IssueStateActive: {"WAITING_ON_VENDOR"},
+				"WAITING_ON_VENDOR":     {models.IssueStateResolved},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+	issue = create("custom-workflow")
+	if err := transition(issue, models.IssueStateAcknowledged); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected the built-in states of other workflows to be refused, got %v", err)
+	}
+	if err := transition(issue, models.IssueStateResolved); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected issues to wait on the vendor before being resolved, got %v", err)
+	}
+	if err := transition(issue, "WAITING_ON_VENDOR"); err != nil {
+		t.Fatalf("Expected the transition to the custom state, got %v", err)
+	}
+	if err := transition(issue, models.IssueStateResolved); err != nil {
+		t.Errorf("Expected the transition to RESOLVED, got %v", err)
+	}
+
+	// Issues in a state their workflow no longer has move to any of its states
+	issue = create("custom-workflow")
+	if err := db.Model(issue).Update("state", models.IssueStateInProgress).Error; err != nil {
+		t.Fatalf("Failed to update state: %v", err)
+	}
+	if err := transition(issue, "WAITING_ON_VENDOR"); err != nil {
+		t.Errorf("Expected the issue to move to the workflow, got %v", err)
+	}
+}
+
+func TestIssueService_AcknowledgeIssue(t *testing.T) {
+	service, ctx, db := createTestService(t)
+	settings := repository.NewNamespaceSettingsRepository(db, logrus.New(), 0)
+
+	create := func(namespace string) *models.Issue {
+		issue, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Unacknowledged issue",
+			Description: "Nobody looked at it yet",
+			Severity:    models.SeverityCritical,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   namespace,
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      "frontend",
+				ResourceNamespace: namespace,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue
+	}
+
+	// Active issues move to ACKNOWLEDGED
+	issue := create("default-workflow")
+	acknowledged, err := service.AcknowledgeIssue(ctx, issue.ID, "", "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if acknowledged.State != models.IssueStateAcknowledged || acknowledged.AcknowledgedBy != "alice" || acknowledged.AcknowledgedAt == nil {
+		t.Errorf("Expected the issue acknowledged by alice, got %+v", acknowledged)
+	}
+
+	// The first acknowledgement is kept
+	again, err := service.AcknowledgeIssue(ctx, issue.ID, "", "bob")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if again.AcknowledgedBy != "alice" || !again.AcknowledgedAt.Equal(*acknowledged.AcknowledgedAt) {
+		t.Errorf("Expected the first acknowledgement to be kept, got %q at %v", again.AcknowledgedBy, again.AcknowledgedAt)
+	}
+
+	if _, err := service.AcknowledgeIssue(ctx, issue.ID, "other-namespace", "alice"); err == nil || err.Error() != "access denied to this namespace" {
+		t.Errorf("Expected access to be denied, got %v", err)
+	}
+	if _, err := service.AcknowledgeIssue(ctx, "missing", "", "alice"); err == nil || err.Error() != "issue not found" {
+		t.Errorf("Expected the issue not to be found, got %v", err)
+	}
+
+	// Reopened issues are to be acknowledged again
+	if _, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+	if _, err := service.AcknowledgeIssue(ctx, issue.ID, "", "alice"); err == nil || err.Error() != "issue is resolved" {
+		t.Errorf("Expected resolved issues not to be acknowledged, got %v", err)
+	}
+	reopened, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateActive})
+	if err != nil {
+		t.Fatalf("Failed to reopen issue: %v", err)
+	}
+	if reopened.AcknowledgedAt != nil || reopened.AcknowledgedBy != "" {
+		t.Errorf("Expected the acknowledgement to be cleared, got %q at %v", reopened.AcknowledgedBy, reopened.AcknowledgedAt)
+	}
+
+	// Issues of workflows without ACKNOWLEDGED are acknowledged in their state
+	if _, err := settings.Save(ctx, models.NamespaceSettings{
+		Namespace: "custom-workflow",
+		Workflow: &models.Workflow{
+			Transitions: map[models.IssueState][]models.IssueState{
+				models.IssueStateActive: {models.IssueStateResolved},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+	issue = create("custom-workflow")
+	acknowledged, err = service.AcknowledgeIssue(ctx, issue.ID, "custom-workflow", "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if acknowledged.State != models.IssueStateActive || acknowledged.AcknowledgedBy != "alice" {
+		t.Errorf("Expected the issue acknowledged while active, got %s by %q", acknowledged.State, acknowledged.AcknowledgedBy)
+	}
+}
+
+func TestIssueService_SnoozeIssue(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	issue, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+		Title:       "Flaky test",
+		Description: "Fails once in a while",
+		Severity:    models.SeverityMinor,
+		IssueType:   models.IssueTypeTest,
+		Namespace:   "team-alpha",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      "frontend",
+			ResourceNamespace: "team-alpha",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	until := time.Now().Add(4 * time.Hour)
+	snoozed, err := service.SnoozeIssue(ctx, issue.ID, "team-alpha", &until, "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if snoozed.SnoozedUntil == nil || !snoozed.SnoozedUntil.Equal(until) || snoozed.SnoozedBy != "alice" {
+		t.Errorf("Expected the issue snoozed by alice until %v, got %q until %v", until, snoozed.SnoozedBy, snoozed.SnoozedUntil)
+	}
+
+	if _, err := service.SnoozeIssue(ctx, issue.ID, "other-namespace", &until, "alice"); err == nil || err.Error() != "access denied to this namespace" {
+		t.Errorf("Expected access to be denied, got %v", err)
+	}
+	if _, err := service.SnoozeIssue(ctx, "missing", "", &until, "alice"); err == nil || err.Error() != "issue not found" {
+		t.Errorf("Expected the issue not to be found, got %v", err)
+	}
+
+	woken, err := service.SnoozeIssue(ctx, issue.ID, "", nil, "")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if woken.SnoozedUntil != nil || woken.SnoozedBy != "" {
+		t.Errorf("Expected the issue to be woken, got %q until %v", woken.SnoozedBy, woken.SnoozedUntil)
+	}
+
+	// Reopened issues are woken
+	if _, err := service.SnoozeIssue(ctx, issue.ID, "", &until, "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+	if _, err := service.SnoozeIssue(ctx, issue.ID, "", &until, "alice"); err == nil || err.Error() != "issue is resolved" {
+		t.Errorf("Expected resolved issues not to be snoozed, got %v", err)
+	}
+	reopened, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateActive})
+	if err != nil {
+		t.Fatalf("Failed to reopen issue: %v", err)
+	}
+	if reopened.SnoozedUntil != nil || reopened.SnoozedBy != "" {
+		t.Errorf("Expected the snooze to be cleared, got %q until %v", reopened.SnoozedBy, reopened.SnoozedUntil)
+	}
+}
+
+func TestIssueService_ProcessWebhookBatch(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	failure := func(pipeline string, severity models.Severity) dto.WebhookBatchItem {
+		return dto.WebhookBatchItem{Issue: &dto.CreateIssueRequest{
+			Title:       "Pipeline run failed: " + pipeline,
+			Description: "The pipeline run failed",
+			Severity:    severity,
+			IssueType:   models.IssueTypePipeline,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "pipelinerun",
+				ResourceName:      pipeline,
+				ResourceNamespace: "team-alpha",
+			},
+		}}
+	}
+	success := func(pipeline string) dto.WebhookBatchItem {
+		return dto.WebhookBatchItem{
+			ResolveScope: &dto.ScopeReqBody{ResourceType: "pipelinerun", ResourceName: pipeline, ResourceNamespace: "team-alpha"},
+			Resolution:   dto.Resolution{Reason: "Pipeline succeeded", ResolvedBy: "pipeline-success-webhook"},
+		}
+	}
+
+	// The webhooks are processed in order, the success resolving the failure before it
+	results, err := service.ProcessWebhookBatch(ctx, []dto.WebhookBatchItem{
+		failure("frontend-build", models.SeverityMajor),
+		failure("backend-build", models.SeverityMajor),
+		success("frontend-build"),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(results) != 3 || results[0].Issue == nil || results[1].Issue == nil || results[2].Resolved != 1 {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	resolved, err := service.FindIssueByID(ctx, results[0].Issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.State != models.IssueStateResolved {
+		t.Errorf("Expected the frontend issue to be resolved, got %s", resolved.State)
+	}
+
+	// Batches failing part way aren't applied at all
+	_, err = service.ProcessWebhookBatch(ctx, []dto.WebhookBatchItem{
+		success("backend-build"),
+		failure("docs-build", models.Severity("bogus")),
+	})
+	if err == nil {
+		t.Fatal("Expected the batch to fail")
+	}
+	backend, err := service.FindIssueByID(ctx, results[1].Issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if backend.State != models.IssueStateActive {
+		t.Errorf("Expected the resolution of the failed batch to be rolled back, got %s", backend.State)
+	}
+	response, err := service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha", ResourceName: "docs-build"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(response.Data) != 0 {
+		t.Errorf("Expected no docs issue, got %+v", response.Data)
+	}
+}
+
+func TestIssueService_CheckDuplicateIssue(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	req := dto.CreateIssueRequest{
+		Title:       "Build failed",
+		Description: "The build failed",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-alpha",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      "frontend",
+			ResourceNamespace: "team-alpha",
+		},
+	}
+
+	result, err := service.CheckDuplicateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if result.IsDuplicate || result.ExistingIssue != nil {
+		t.Errorf("Expected no duplicate, got %+v", result)
+	}
+
+	existing, err := service.CreateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	req.Title = "Build failed again"
+	result, err = service.CheckDuplicateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !result.IsDuplicate || result.ExistingIssue == nil || result.ExistingIssue.ID != existing.ID {
+		t.Errorf("Expected the existing issue, got %+v", result)
+	}
+}
+
+func TestIssueService_DryRunIssue(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	req := dto.CreateIssueRequest{
+		Title:       "Pipeline run failed: frontend-build",
+		Description: "The pipeline run failed",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypePipeline,
+		Namespace:   "team-alpha",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "pipelinerun",
+			ResourceName:      "frontend-build",
+			ResourceNamespace: "team-alpha",
+		},
+	}
+	countIssues := func() int {
+		response, err := service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha"})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return len(response.Data)
+	}
+
+	result, err := service.DryRunIssue(ctx, req, models.IssueSourceWebhook)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !result.DryRun || result.Action != dto.DryRunCreate || result.Issue == nil || result.ExistingIssue != nil {
+		t.Errorf("Expected the issue to be created, got %+v", result)
+	}
+	if count := countIssues(); count != 0 {
+		t.Fatalf("Expected the dry run to save nothing, got %d issues", count)
+	}
+
+	existing, err := service.CreateOrUpdateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	req.Severity = models.SeverityCritical
+	result, err = service.DryRunIssue(ctx, req, models.IssueSourceAPI)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if result.Action != dto.DryRunUpdate || result.ExistingIssue == nil || result.ExistingIssue.ID != existing.ID {
+		t.Fatalf("Expected the existing issue to be updated, got %+v", result)
+	}
+	if result.Issue.Severity != models.SeverityCritical || result.ExistingIssue.Severity != models.SeverityMajor {
+		t.Errorf("Expected the issue as it would be saved and as it is, got %s and %s", result.Issue.Severity, result.ExistingIssue.Severity)
+	}
+	unchanged, err := service.FindIssueByID(ctx, existing.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if unchanged.Severity != models.SeverityMajor {
+		t.Errorf("Expected the dry run to leave the issue unchanged, got %s", unchanged.Severity)
+	}
+
+	if _, err := service.ResolveIssuesByScope(ctx, "pipelinerun", "frontend-build", "team-alpha", dto.Resolution{Reason: "Fixed"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	result, err = service.DryRunIssue(ctx, req, models.IssueSourceWebhook)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if result.Action != dto.DryRunUpdate || result.Issue.State != models.IssueStateResolved {
+		t.Errorf("Expected the resolved issue to be updated, got %s", result.Action)
+	}
+	req.State = models.IssueStateActive
+	result, err = service.DryRunIssue(ctx, req, models.IssueSourceWebhook)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if result.Action != dto.DryRunReopen {
+		t.Errorf("Expected the resolved issue to be reopened, got %s", result.Action)
+	}
+}
+
+func TestIssueService_DryRunWebhookBatch(t *testing.T) {
+	service, ctx, _ := createTestService(t)
+
+	req := dto.CreateIssueRequest{
+		Title:       "Pipeline run failed: frontend-build",
+		Description: "The pipeline run failed",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypePipeline,
+		Namespace:   "team-alpha",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "pipelinerun",
+			ResourceName:      "frontend-build",
+			ResourceNamespace: "team-alpha",
+		},
+	}
+	reopen := req
+	reopen.State = models.IssueStateActive
+	success := dto.WebhookBatchItem{
+		ResolveScope: &dto.ScopeReqBody{ResourceType: "pipelinerun", ResourceName: "frontend-build", ResourceNamespace: "team-alpha"},
+		Resolution:   dto.Resolution{Reason: "Pipeline succeeded", ResolvedBy: "pipeline-success-webhook"},
+	}
+
+	// The operations see the changes of the previous ones
+	results, err := service.DryRunWebhookBatch(ctx, []dto.WebhookBatchItem{{Issue: &req}, success, {Issue: &reopen}})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(results) != 3 || results[0].Action != dto.DryRunCreate || results[1].Resolved != 1 || results[2].Action != dto.DryRunReopen {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+
+	response, err := service.FindIssues(ctx, repository.IssueQueryFilters{Namespace: "team-alpha"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(response.Data) != 0 {
+		t.Errorf("Expected the dry run to save nothing, got %+v", response.Data)
+	}
+}
+
+func TestIssueService_SeverityEscalationOnly(t *testing.T) {
+	service, ctx, db := createTestService(t)
+	settings := repository.NewNamespaceSettingsRepository(db, logrus.New(), 0)
+
+	report := func(namespace string, severity models.Severity) *models.Issue {
+		issue, err := service.CreateOrUpdateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Escalation issue",
+			Description: "Testing severity escalation",
+			Severity:    severity,
+			IssueType:   models.IssueTypePipeline,
+			Namespace:   namespace,
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "pipeline",
+				ResourceName:      "frontend-build",
+				ResourceNamespace: namespace,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue
+	}
+
+	// Webhooks set the severity of the issues they report again by default
+	issue := report("any-severity", models.SeverityMajor)
+	if _, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{Severity: models.SeverityCritical}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue = report("any-severity", models.SeverityMinor); issue.Severity != models.SeverityMinor {
+		t.Errorf("Expected the severity to be lowered, got %s", issue.Severity)
+	}
+
+	if _, err := settings.Save(ctx, models.NamespaceSettings{Namespace: "escalation-only", SeverityEscalationOnly: true}); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+	issue = report("escalation-only", models.SeverityMajor)
+	if _, err := service.UpdateIssue(ctx, issue.ID, dto.UpdateIssueRequest{Severity: models.SeverityCritical}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Webhooks don't lower the severity a human raised
+	if issue = report("escalation-only", models.SeverityMinor); issue.Severity != models.SeverityCritical {
+		t.Errorf("Expected the severity to be kept, got %s", issue.Severity)
+	}
+
+	// Manual API calls aren't restricted
+	issue, err := service.CreateIssue(ctx, dto.CreateIssueRequest{
+		Title:       "Escalation issue",
+		Description: "Testing severity escalation",
+		Severity:    models.SeverityInfo,
+		IssueType:   models.IssueTypePipeline,
+		Namespace:   "escalation-only",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "pipeline",
+			ResourceName:      "frontend-build",
+			ResourceNamespace: "escalation-only",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue.Severity != models.SeverityInfo {
+		t.Errorf("Expected the severity to be lowered by the API, got %s", issue.Severity)
+	}
+
+	// Webhooks still escalate
+	if issue = report("escalation-only", models.SeverityMajor); issue.Severity != models.SeverityMajor {
+		t.Errorf("Expected the severity to be raised, got %s", issue.Severity)
+	}
+}
+
+func TestIssueService_LinkTemplates(t *testing.T) {
+	ctx, logger, repo, db := setupServiceDependents(t)
+	settings := repository.NewNamespaceSettingsRepository(db, logger, 0)
+	defaults := []models.LinkTemplate{
+		{Title: "Pipeline Run Logs", URL: "https://konflux.test/logs/pipelineruns/{{runId}}", IssueType: models.IssueTypePipeline},
+	}
+	service := NewIssueService(repo, repository.NewUnitOfWork(db, logger, 0), settings, defaults, logger)
+
+	report := func(namespace string, links []dto.CreateLinkRequest, variables map[string]string) []models.Link {
+		issue, err := service.CreateOrUpdateIssue(ctx, dto.CreateIssueRequest{
+			Title:       "Pipeline run failed: frontend-build",
+			Description: "Testing link templates",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypePipeline,
+			Namespace:   namespace,
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "pipelinerun",
+				ResourceName:      "frontend-build",
+				ResourceNamespace: namespace,
+			},
+			Links:         links,
+			Labels:        map[string]string{"team": "ui"},
+			LinkVariables: variables,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		issue, err = service.FindIssueByID(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue.Links
+	}
+	urls := func(links []models.Link) map[string]string {
+		result := map[string]string{}
+		for _, link := range links {
+			result[link.Title] = link.URL
+		}
+		return result
+	}
+
+	// Namespaces without templates get the default ones
+	links := urls(report("default-links", nil, map[string]string{"runId": "run-1"}))
+	if len(links) != 1 || links["Pipeline Run Logs"] != "https://konflux.test/logs/pipelineruns/run-1" {
+		t.Errorf("Expected the default logs link, got %v", links)
+	}
+	// Links of the report take precedence
+	links = urls(report("default-links", []dto.CreateLinkRequest{{Title: "Pipeline Run Logs", URL: "https://ci.test/run-2"}}, map[string]string{"runId": "run-2"}))
+	if len(links) != 1 || links["Pipeline Run Logs"] != "https://ci.test/run-2" {
+		t.Errorf("Expected the logs link of the report, got %v", links)
+	}
+
+	if _, err := settings.Save(ctx, models.NamespaceSettings{
+		Namespace: "custom-links",
+		LinkTemplates: []models.LinkTemplate{
+			{Title: "Pipeline Run Logs", URL: "https://console.test/ns/{{namespace}}/pipelineruns/{{runId}}/logs"},
+			{Title: "Team dashboard", URL: "https://dashboards.test/{{label.team}}/{{resourceName}}"},
+			{Title: "Build logs", URL: "https://console.test/builds/{{runId}}", IssueType: models.IssueTypeBuild},
+			{Title: "Release", URL: "https://console.test/releases/{{releaseId}}"},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+
+	// The templates of the namespace override the defaults, templates of
+	// other issue types or missing variables are left out
+	links = urls(report("custom-links", nil, map[string]string{"runId": "run-3"}))
+	expected := map[string]string{
+		"Pipeline Run Logs": "https://console.test/ns/custom-links/pipelineruns/run-3/logs",
+		"Team dashboard":    "https://dashboards.test/ui/frontend-build",
+	}
+	if len(links) != len(expected) {
+		t.Errorf("Expected links %v, got %v", expected, links)
+	}
+	for title, url := range expected {
+		if links[title] != url {
+			t.Errorf("Expected link %s to %s, got %q", title, url, links[title])
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type IssueWatchService struct {
+	issues  repository.IssueRepository      // Repository of the watched issues
+	watches repository.IssueWatchRepository // Repository instance
+	logger  *logrus.Logger                  // Logging instance
+}
+
+func NewIssueWatchService(issues repository.IssueRepository, watches repository.IssueWatchRepository, logger *logrus.Logger) *IssueWatchService {
+	return &IssueWatchService{
+		issues:  issues,
+		watches: watches,
+		logger:  logger,
+	}
+}
+
+// WatchIssue makes a user watch an issue, returning "issue not found" if it
+// doesn't exist, and "access denied to this namespace" if it isn't an issue of
+// namespace, when set
+func (s *IssueWatchService) WatchIssue(ctx context.Context, issueID, namespace, user string) (*models.IssueWatch, error) {
+	issue, err := s.issues.FindByID(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+
+	watch, err := s.watches.Watch(ctx, issueID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{"issue_id": issueID, "user": user}).Info("Watched issue")
+	return watch, nil
+}
+
+// UnwatchIssue makes a user stop watching an issue
+func (s *IssueWatchService) UnwatchIssue(ctx context.Context, issueID, user string) error {
+	return s.watches.Unwatch(ctx, issueID, user)
+}
+
+// FindWatchedIssues returns the issues a user watches, the most recently updated first
+func (s *IssueWatchService) FindWatchedIssues(ctx context.Context, user string) ([]models.Issue, error) {
+	return s.watches.FindWatchedIssues(ctx, user)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+func TestIssueWatchService_WatchIssue(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	service := NewIssueWatchService(issues, repository.NewIssueWatchRepository(db, logger, 0), logger)
+
+	issue, err := issues.Create(ctx, dto.CreateIssueRequest{
+		Title:       "Watched Issue",
+		Description: "Testing watches",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "test-namespace",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      "watched-component",
+			ResourceNamespace: "test-namespace",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if _, err := service.WatchIssue(ctx, issue.ID, "other-namespace", "alice"); err == nil || err.Error() != "access denied to this namespace" {
+		t.Errorf("Expected access denied to this namespace, got %v", err)
+	}
+	if _, err := service.WatchIssue(ctx, issue.ID, "test-namespace", "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	watched, err := service.FindWatchedIssues(ctx, "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(watched) != 1 || watched[0].ID != issue.ID {
+		t.Errorf("Expected the watched issue, got %+v", watched)
+	}
+
+	if err := service.UnwatchIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if watched, _ := service.FindWatchedIssues(ctx, "alice"); len(watched) != 0 {
+		t.Errorf("Expected no watched issues, got %d", len(watched))
+	}
+}
+
+func TestIssueWatchService_WatchIssue_NotFound(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	service := NewIssueWatchService(issues, repository.NewIssueWatchRepository(db, logger, 0), logger)
+
+	if _, err := service.WatchIssue(ctx, "00000000-0000-0000-0000-000000000000", "", "alice"); err == nil || err.Error() != "issue not found" {
+		t.Errorf("Expected issue not found, got %v", err)
+	}
+}
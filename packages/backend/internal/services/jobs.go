@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/workers"
+)
+
+// finishedJobRetention is how long the background jobs of the admin
+// endpoints are kept once finished, for their outcome to be read
+const finishedJobRetention = 24 * time.Hour
+
+// errJobInterrupted is the error of the jobs stopped by the server shutting down
+var errJobInterrupted = errors.New("interrupted by the server shutdown, start it again to resume")
+
+// startJob runs a job of the admin endpoints in the background, as a worker
+// of group, so that the server waits for it to stop before closing the
+// database. The job outlives the request starting it, keeping the values of
+// ctx, e.g. its request ID, and must stop between two steps once stop is
+// closed. Its context is cancelled once the shutdown times out.
+func startJob(group *workers.Group, ctx context.Context, name string, job func(ctx context.Context, stop <-chan struct{})) {
+	values := context.WithoutCancel(ctx)
+	group.Go(name, func(workerCtx context.Context, stop <-chan struct{}) {
+		jobCtx, cancel := context.WithCancel(values)
+		defer cancel()
+		defer context.AfterFunc(workerCtx, cancel)()
+		job(jobCtx, stop)
+	})
+}
+
+// stopping reports whether stop is closed
+func stopping(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// pruneFinishedJobs removes the jobs finished before finishedJobRetention,
+// the mutex guarding jobs being held
+func pruneFinishedJobs[J any](jobs map[string]*J, finishedAt func(*J) *time.Time) {
+	before := time.Now().Add(-finishedJobRetention)
+	for id, job := range jobs {
+		if finished := finishedAt(job); finished != nil && finished.Before(before) {
+			delete(jobs, id)
+		}
+	}
+}
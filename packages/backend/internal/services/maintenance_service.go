@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/workers"
+	"github.com/sirupsen/logrus"
+)
+
+type MaintenanceService struct {
+	repo    repository.MaintenanceRepository // Repository instance
+	workers *workers.Group                   // Background workers running the jobs
+	logger  *logrus.Logger                   // Logging instance
+
+	mu sync.Mutex
+	// jobs by ID. They're kept in memory until finishedJobRetention after
+	// they finish, a task interrupted by a restart is run again by starting
+	// it again.
+	jobs map[string]*dto.MaintenanceJob
+}
+
+func NewMaintenanceService(repo repository.MaintenanceRepository, workers *workers.Group, logger *logrus.Logger) *MaintenanceService {
+	return &MaintenanceService{
+		repo:    repo,
+		workers: workers,
+		logger:  logger,
+		jobs:    make(map[string]*dto.MaintenanceJob),
+	}
+}
+
+// StartMaintenance starts a maintenance task in the background, returning the
+// job tracking its progress. Reindexing, vacuuming and analyzing process the
+// tables one at a time. Recomputing updates the denormalized columns of
+// issues that are out of date. One maintenance task runs at a time, tasks
+// competing for the same locks.
+func (s *MaintenanceService) StartMaintenance(ctx context.Context, task dto.MaintenanceTask, user string) (*dto.MaintenanceJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruneFinishedJobs(s.jobs, func(job *dto.MaintenanceJob) *time.Time { return job.FinishedAt })
+	for _, job := range s.jobs {
+		if job.Status == dto.MaintenanceStatusRunning {
+			return nil, repository.ConflictError("maintenance job already in progress")
+		}
+	}
+
+	job := &dto.MaintenanceJob{
+		ID:          uuid.New().String(),
+		Task:        task,
+		Status:      dto.MaintenanceStatusRunning,
+		RequestedBy: user,
+		StartedAt:   time.Now(),
+	}
+	if task != dto.MaintenanceTaskRecompute {
+		tables, err := s.repo.Tables()
+		if err != nil {
+			return nil, err
+		}
+		job.Tables = tables
+	}
+	s.jobs[job.ID] = job
+	logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"task":   task,
+		"tables": len(job.Tables),
+	}).Info("Started maintenance")
+
+	startJob(s.workers, ctx, "maintenance-"+job.ID, func(ctx context.Context, stop <-chan struct{}) {
+		s.run(ctx, stop, job)
+	})
+
+	return s.snapshot(job), nil
+}
+
+// GetMaintenanceJob returns the progress of a maintenance job
+func (s *MaintenanceService) GetMaintenanceJob(ctx context.Context, id string) (*dto.MaintenanceJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruneFinishedJobs(s.jobs, func(job *dto.MaintenanceJob) *time.Time { return job.FinishedAt })
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, repository.NotFoundError("maintenance job not found")
+	}
+	return s.snapshot(job), nil
+}
+
+// snapshot copies job, s.mu being held
+func (s *MaintenanceService) snapshot(job *dto.MaintenanceJob) *dto.MaintenanceJob {
+	snapshot := *job
+	snapshot.Tables = slices.Clone(job.Tables)
+	return &snapshot
+}
+
+// run runs the task of job, recording the progress in job. It stops between
+// tables once stop is closed.
+func (s *MaintenanceService) run(ctx context.Context, stop <-chan struct{}, job *dto.MaintenanceJob) {
+	if job.Task == dto.MaintenanceTaskRecompute {
+		recomputed, err := s.repo.RecomputeDedupKeys(ctx)
+		s.mu.Lock()
+		job.Recomputed = recomputed
+		s.mu.Unlock()
+		s.finish(ctx, job, err)
+		return
+	}
+
+	maintain := map[dto.MaintenanceTask]func(ctx context.Context, table string) error{
+		dto.MaintenanceTaskReindex: s.repo.Reindex,
+		dto.MaintenanceTaskVacuum:  s.repo.Vacuum,
+		dto.MaintenanceTaskAnalyze: s.repo.Analyze,
+	}[job.Task]
+	for _, table := range job.Tables {
+		if stopping(stop) {
+			s.finish(ctx, job, errJobInterrupted)
+			return
+		}
+		if err := maintain(ctx, table); err != nil {
+			s.finish(ctx, job, err)
+			return
+		}
+		s.mu.Lock()
+		job.TablesProcessed++
+		s.mu.Unlock()
+	}
+	s.finish(ctx, job, nil)
+}
+
+// finish records the outcome of job
+func (s *MaintenanceService) finish(ctx context.Context, job *dto.MaintenanceJob, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	job.FinishedAt = &now
+	logger := logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"task":       job.Task,
+		"processed":  job.TablesProcessed,
+		"recomputed": job.Recomputed,
+		"duration":   now.Sub(job.StartedAt).String(),
+	})
+	if err != nil {
+		job.Status = dto.MaintenanceStatusFailed
+		job.Error = err.Error()
+		logger.WithError(err).Error("Maintenance failed")
+		return
+	}
+	job.Status = dto.MaintenanceStatusSucceeded
+	logger.Info("Finished maintenance")
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/konflux-ci/kite/internal/workers"
+	"github.com/sirupsen/logrus"
+)
+
+// waitForMaintenance waits for a maintenance job to finish, returning its last progress
+func waitForMaintenance(t *testing.T, service *MaintenanceService, id string) *dto.MaintenanceJob {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := service.GetMaintenanceJob(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if job.Status != dto.MaintenanceStatusRunning {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the maintenance to finish, got %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMaintenanceService_StartMaintenance(t *testing.T) {
+	// The jobs run in their own goroutine, sharing the database
+	db := testhelpers.SetupConcurrentTestDB(t)
+	logger := logrus.New()
+	issues := repository.NewIssueRepository(db, logger, 0)
+	service := NewMaintenanceService(repository.NewMaintenanceRepository(db, logger), workers.NewGroup(logger), logger)
+	ctx := context.Background()
+
+	// The tasks processing tables process all of them
+	for _, task := range []dto.MaintenanceTask{dto.MaintenanceTaskReindex, dto.MaintenanceTaskAnalyze, dto.MaintenanceTaskVacuum} {
+		job, err := service.StartMaintenance(ctx, task, "admin")
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if job.Status != dto.MaintenanceStatusRunning || job.Task != task || job.RequestedBy != "admin" || len(job.Tables) == 0 {
+			t.Errorf("Expected a running %s job, got %+v", task, job)
+		}
+		job = waitForMaintenance(t, service, job.ID)
+		if job.Status != dto.MaintenanceStatusSucceeded || job.TablesProcessed != len(job.Tables) || job.FinishedAt == nil {
+			t.Errorf("Expected the %s job to process all tables, got %+v", task, job)
+		}
+	}
+
+	// Recomputing fixes the keys out of date
+	issue, err := issues.Create(ctx, dto.CreateIssueRequest{
+		Title:       "Drifted issue",
+		Description: "Testing maintenance",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-alpha",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      "frontend",
+			ResourceNamespace: "team-alpha",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := db.Model(&models.Issue{}).Where("id = ?", issue.ID).UpdateColumn("dedup_key", "stale").Error; err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	job, err := service.StartMaintenance(ctx, dto.MaintenanceTaskRecompute, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	job = waitForMaintenance(t, service, job.ID)
+	if job.Status != dto.MaintenanceStatusSucceeded || job.Recomputed != 1 || len(job.Tables) != 0 {
+		t.Errorf("Expected 1 issue recomputed, got %+v", job)
+	}
+
+	if _, err := service.GetMaintenanceJob(ctx, "00000000-0000-0000-0000-000000000000"); err == nil || err.Error() != "maintenance job not found" {
+		t.Errorf("Expected the job not to be found, got %v", err)
+	}
+}
+
+func TestMaintenanceService_StartMaintenance_InProgress(t *testing.T) {
+	service := NewMaintenanceService(nil, workers.NewGroup(logrus.New()), logrus.New())
+	service.jobs["running"] = &dto.MaintenanceJob{ID: "running", Task: dto.MaintenanceTaskVacuum, Status: dto.MaintenanceStatusRunning}
+
+	if _, err := service.StartMaintenance(context.Background(), dto.MaintenanceTaskReindex, "admin"); err == nil || err.Error() != "maintenance job already in progress" {
+		t.Errorf("Expected the maintenance to be refused, got %v", err)
+	}
+}
+
+func TestMaintenanceService_StartMaintenance_Shutdown(t *testing.T) {
+	db := testhelpers.SetupConcurrentTestDB(t)
+	logger := logrus.New()
+	group := workers.NewGroup(logger)
+	service := NewMaintenanceService(repository.NewMaintenanceRepository(db, logger), group, logger)
+	ctx := context.Background()
+
+	// Jobs run as background workers, stopping between tables when the server shuts down
+	group.Stop()
+	job, err := service.StartMaintenance(ctx, dto.MaintenanceTaskAnalyze, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := group.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Expected the job to stop, got %v", err)
+	}
+
+	job, err = service.GetMaintenanceJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if job.Status != dto.MaintenanceStatusFailed || job.TablesProcessed != 0 || job.Error != errJobInterrupted.Error() {
+		t.Errorf("Expected the job to be interrupted, got %+v", job)
+	}
+}
+
+func TestMaintenanceService_PrunesFinishedJobs(t *testing.T) {
+	service := NewMaintenanceService(nil, workers.NewGroup(logrus.New()), logrus.New())
+	longAgo := time.Now().Add(-finishedJobRetention - time.Minute)
+	recently := time.Now().Add(-time.Minute)
+	service.jobs["old"] = &dto.MaintenanceJob{ID: "old", Status: dto.MaintenanceStatusFailed, FinishedAt: &longAgo}
+	service.jobs["recent"] = &dto.MaintenanceJob{ID: "recent", Status: dto.MaintenanceStatusSucceeded, FinishedAt: &recently}
+
+	if _, err := service.GetMaintenanceJob(context.Background(), "old"); err == nil {
+		t.Errorf("Expected the job finished before the retention to be removed")
+	}
+	if _, err := service.GetMaintenanceJob(context.Background(), "recent"); err != nil {
+		t.Errorf("Expected the recent job to be kept, got %v", err)
+	}
+}
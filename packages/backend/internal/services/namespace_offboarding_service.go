@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/workers"
+	"github.com/sirupsen/logrus"
+)
+
+// offboardingBatchSize is how many issues are deleted or resolved per transaction
+const offboardingBatchSize = 500
+
+// offboardingReason is the resolution reason of the issues of archived namespaces
+const offboardingReason = "Namespace offboarded"
+
+type NamespaceOffboardingService struct {
+	repo    repository.NamespaceRepository // Repository instance
+	workers *workers.Group                 // Background workers running the jobs
+	logger  *logrus.Logger                 // Logging instance
+
+	mu sync.Mutex
+	// jobs by ID. They're kept in memory until finishedJobRetention after
+	// they finish, an offboarding interrupted by a restart is resumed by
+	// offboarding the namespace again.
+	jobs map[string]*dto.OffboardingJob
+}
+
+func NewNamespaceOffboardingService(repo repository.NamespaceRepository, workers *workers.Group, logger *logrus.Logger) *NamespaceOffboardingService {
+	return &NamespaceOffboardingService{
+		repo:    repo,
+		workers: workers,
+		logger:  logger,
+		jobs:    make(map[string]*dto.OffboardingJob),
+	}
+}
+
+// StartOffboarding starts offboarding a namespace in the background, returning
+// the job tracking its progress. Purging deletes all the issues of the
+// namespace, with their scopes, links, labels and other records, and its
+// webhook events. Archiving resolves its active issues and keeps them. Both
+// delete the settings, shared saved views and triage rules of the namespace.
+// A namespace is offboarded by one job at a time.
+func (s *NamespaceOffboardingService) StartOffboarding(ctx context.Context, namespace string, mode dto.OffboardingMode, user string) (*dto.OffboardingJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruneFinishedJobs(s.jobs, func(job *dto.OffboardingJob) *time.Time { return job.FinishedAt })
+	for _, job := range s.jobs {
+		if job.Namespace == namespace && job.Status == dto.OffboardingStatusRunning {
+			return nil, repository.ConflictError("namespace offboarding already in progress")
+		}
+	}
+
+	issues, err := s.repo.CountIssues(ctx, namespace, mode == dto.OffboardingModeArchive)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &dto.OffboardingJob{
+		ID:          uuid.New().String(),
+		Namespace:   namespace,
+		Mode:        mode,
+		Status:      dto.OffboardingStatusRunning,
+		RequestedBy: user,
+		Issues:      issues,
+		StartedAt:   time.Now(),
+	}
+	s.jobs[job.ID] = job
+	logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{
+		"job_id":    job.ID,
+		"namespace": namespace,
+		"mode":      mode,
+		"issues":    issues,
+	}).Info("Started namespace offboarding")
+
+	startJob(s.workers, ctx, "offboarding-"+job.ID, func(ctx context.Context, stop <-chan struct{}) {
+		s.run(ctx, stop, job)
+	})
+
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// GetOffboardingJob returns the progress of an offboarding job
+func (s *NamespaceOffboardingService) GetOffboardingJob(ctx context.Context, id string) (*dto.OffboardingJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruneFinishedJobs(s.jobs, func(job *dto.OffboardingJob) *time.Time { return job.FinishedAt })
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, repository.NotFoundError("offboarding job not found")
+	}
+	snapshot := *job
+	if job.Deleted != nil {
+		deleted := *job.Deleted
+		snapshot.Deleted = &deleted
+	}
+	return &snapshot, nil
+}
+
+// run processes the issues of the namespace of job in batches, recording the
+// progress in job, then deletes the rest of the namespace. It stops between
+// batches once stop is closed.
+func (s *NamespaceOffboardingService) run(ctx context.Context, stop <-chan struct{}, job *dto.OffboardingJob) {
+	purge := job.Mode == dto.OffboardingModePurge
+	resolution := dto.Resolution{Reason: offboardingReason, ResolvedBy: job.RequestedBy}
+	for {
+		if stopping(stop) {
+			s.finish(ctx, job, nil, errJobInterrupted)
+			return
+		}
+		var processed int64
+		var err error
+		if purge {
+			processed, err = s.repo.DeleteIssues(ctx, job.Namespace, offboardingBatchSize)
+		} else {
+			processed, err = s.repo.ResolveIssues(ctx, job.Namespace, resolution, offboardingBatchSize)
+		}
+		if err != nil {
+			s.finish(ctx, job, nil, err)
+			return
+		}
+		if processed == 0 {
+			break
+		}
+
+		s.mu.Lock()
+		job.IssuesProcessed += processed
+		// Issues reported while the job runs are processed too
+		job.Issues = max(job.Issues, job.IssuesProcessed)
+		s.mu.Unlock()
+	}
+
+	deleted, err := s.repo.DeleteData(ctx, job.Namespace, purge)
+	s.finish(ctx, job, deleted, err)
+}
+
+// finish records the outcome of job
+func (s *NamespaceOffboardingService) finish(ctx context.Context, job *dto.OffboardingJob, deleted *dto.NamespaceData, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	job.FinishedAt = &now
+	logger := logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{
+		"job_id":    job.ID,
+		"namespace": job.Namespace,
+		"mode":      job.Mode,
+		"processed": job.IssuesProcessed,
+	})
+	if err != nil {
+		job.Status = dto.OffboardingStatusFailed
+		job.Error = err.Error()
+		logger.WithError(err).Error("Namespace offboarding failed")
+		return
+	}
+	job.Status = dto.OffboardingStatusSucceeded
+	job.Deleted = deleted
+	logger.Info("Offboarded namespace")
+}
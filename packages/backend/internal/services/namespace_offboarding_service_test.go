@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/konflux-ci/kite/internal/workers"
+	"github.com/sirupsen/logrus"
+)
+
+// waitForOffboarding waits for an offboarding job to finish, returning its last progress
+func waitForOffboarding(t *testing.T, service *NamespaceOffboardingService, id string) *dto.OffboardingJob {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := service.GetOffboardingJob(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if job.Status != dto.OffboardingStatusRunning {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the offboarding to finish, got %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNamespaceOffboardingService_StartOffboarding(t *testing.T) {
+	// The jobs run in their own goroutine, sharing the database
+	db := testhelpers.SetupConcurrentTestDB(t)
+	logger := logrus.New()
+	issues := repository.NewIssueRepository(db, logger, 0)
+	service := NewNamespaceOffboardingService(repository.NewNamespaceRepository(db, logger, 0), workers.NewGroup(logger), logger)
+	ctx := context.Background()
+
+	// Issues of different types, which aren't duplicates of each other
+	for _, issueType := range []models.IssueType{models.IssueTypeBuild, models.IssueTypeTest} {
+		if _, err := issues.Create(ctx, dto.CreateIssueRequest{
+			Title:       "Offboarded issue",
+			Description: "Testing namespace offboarding",
+			Severity:    models.SeverityMajor,
+			IssueType:   issueType,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      "frontend",
+				ResourceNamespace: "team-alpha",
+			},
+		}); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+	if err := db.Create(&models.NamespaceSettings{Namespace: "team-alpha", RetentionDays: 30}).Error; err != nil {
+		t.Fatalf("Failed to create settings: %v", err)
+	}
+
+	// Archiving resolves the issues and deletes the configuration
+	job, err := service.StartOffboarding(ctx, "team-alpha", dto.OffboardingModeArchive, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if job.Status != dto.OffboardingStatusRunning || job.Issues != 2 || job.RequestedBy != "admin" {
+		t.Errorf("Expected a running job archiving 2 issues, got %+v", job)
+	}
+	job = waitForOffboarding(t, service, job.ID)
+	if job.Status != dto.OffboardingStatusSucceeded || job.IssuesProcessed != 2 || job.FinishedAt == nil {
+		t.Fatalf("Expected the 2 issues to be archived, got %+v", job)
+	}
+	if job.Deleted == nil || job.Deleted.Settings != 1 {
+		t.Errorf("Expected the settings to be deleted, got %+v", job.Deleted)
+	}
+	var count int64
+	db.Model(&models.Issue{}).Where("namespace = ? AND state = ?", "team-alpha", models.IssueStateResolved).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected the issues to be kept resolved, got %d", count)
+	}
+
+	// Purging deletes the issues
+	job, err = service.StartOffboarding(ctx, "team-alpha", dto.OffboardingModePurge, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	job = waitForOffboarding(t, service, job.ID)
+	if job.Status != dto.OffboardingStatusSucceeded || job.Issues != 2 || job.IssuesProcessed != 2 {
+		t.Fatalf("Expected the 2 issues to be purged, got %+v", job)
+	}
+	db.Model(&models.Issue{}).Where("namespace = ?", "team-alpha").Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the issues to be deleted, got %d", count)
+	}
+
+	if _, err := service.GetOffboardingJob(ctx, "00000000-0000-0000-0000-000000000000"); err == nil || err.Error() != "offboarding job not found" {
+		t.Errorf("Expected the job not to be found, got %v", err)
+	}
+}
+
+func TestNamespaceOffboardingService_StartOffboarding_InProgress(t *testing.T) {
+	service := NewNamespaceOffboardingService(nil, workers.NewGroup(logrus.New()), logrus.New())
+	service.jobs["running"] = &dto.OffboardingJob{ID: "running", Namespace: "team-alpha", Status: dto.OffboardingStatusRunning}
+
+	if _, err := service.StartOffboarding(context.Background(), "team-alpha", dto.OffboardingModePurge, "admin"); err == nil || err.Error() != "namespace offboarding already in progress" {
+		t.Errorf("Expected the offboarding to be refused, got %v", err)
+	}
+}
+
+func TestNamespaceOffboardingService_StartOffboarding_Shutdown(t *testing.T) {
+	db := testhelpers.SetupConcurrentTestDB(t)
+	logger := logrus.New()
+	group := workers.NewGroup(logger)
+	service := NewNamespaceOffboardingService(repository.NewNamespaceRepository(db, logger, 0), group, logger)
+	ctx := context.Background()
+
+	// Jobs run as background workers, stopping between batches when the server shuts down
+	group.Stop()
+	job, err := service.StartOffboarding(ctx, "team-alpha", dto.OffboardingModePurge, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := group.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Expected the job to stop, got %v", err)
+	}
+
+	job, err = service.GetOffboardingJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if job.Status != dto.OffboardingStatusFailed || job.Error != errJobInterrupted.Error() {
+		t.Errorf("Expected the job to be interrupted, got %+v", job)
+	}
+}
+
+func TestNamespaceOffboardingService_PrunesFinishedJobs(t *testing.T) {
+	service := NewNamespaceOffboardingService(nil, workers.NewGroup(logrus.New()), logrus.New())
+	longAgo := time.Now().Add(-finishedJobRetention - time.Minute)
+	recently := time.Now().Add(-time.Minute)
+	service.jobs["old"] = &dto.OffboardingJob{ID: "old", Status: dto.OffboardingStatusSucceeded, FinishedAt: &longAgo}
+	service.jobs["recent"] = &dto.OffboardingJob{ID: "recent", Status: dto.OffboardingStatusSucceeded, FinishedAt: &recently}
+	service.jobs["running"] = &dto.OffboardingJob{ID: "running", Status: dto.OffboardingStatusRunning}
+
+	if _, err := service.GetOffboardingJob(context.Background(), "old"); err == nil {
+		t.Errorf("Expected the job finished before the retention to be removed")
+	}
+	for _, id := range []string{"recent", "running"} {
+		if _, err := service.GetOffboardingJob(context.Background(), id); err != nil {
+			t.Errorf("Expected job %s to be kept, got %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type NamespaceSettingsService struct {
+	repo   repository.NamespaceSettingsRepository // Repository instance
+	logger *logrus.Logger                         // Logging instance
+}
+
+func NewNamespaceSettingsService(repo repository.NamespaceSettingsRepository, logger *logrus.Logger) *NamespaceSettingsService {
+	return &NamespaceSettingsService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetSettings returns the settings of a namespace, the defaults if it has
+// none. The workflow is the default workflow when the namespace has none.
+func (s *NamespaceSettingsService) GetSettings(ctx context.Context, namespace string) (*models.NamespaceSettings, error) {
+	settings, err := s.repo.Find(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &models.NamespaceSettings{Namespace: namespace}
+	}
+	if settings.Workflow == nil {
+		workflow := models.DefaultWorkflow()
+		settings.Workflow = &workflow
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the settings of a namespace
+func (s *NamespaceSettingsService) UpdateSettings(ctx context.Context, namespace string, req dto.UpdateNamespaceSettingsRequest) (*models.NamespaceSettings, error) {
+	settings, err := s.repo.Save(ctx, models.NamespaceSettings{
+		Namespace:              namespace,
+		RetentionDays:          req.RetentionDays,
+		DedupWindowHours:       req.DedupWindowHours,
+		SeverityEscalationOnly: req.SeverityEscalationOnly,
+		SLATargets:             req.SLATargets,
+		Notifications:          req.Notifications,
+		Workflow:               req.Workflow,
+		LinkTemplates:          req.LinkTemplates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx, s.logger).WithField("namespace", namespace).Info("Updated namespace settings")
+	return settings, nil
+}
+
+// ResetSettings resets the settings of a namespace to the defaults
+func (s *NamespaceSettingsService) ResetSettings(ctx context.Context, namespace string) error {
+	return s.repo.Delete(ctx, namespace)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+func createTestNamespaceSettingsService(t *testing.T) (*NamespaceSettingsService, context.Context) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	return NewNamespaceSettingsService(repository.NewNamespaceSettingsRepository(db, logger, 0), logger), context.Background()
+}
+
+func TestNamespaceSettingsService_GetSettings(t *testing.T) {
+	service, ctx := createTestNamespaceSettingsService(t)
+
+	// Namespaces without settings get the defaults
+	settings, err := service.GetSettings(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if settings.Namespace != "test-namespace" || settings.RetentionDays != 0 || settings.Notifications.Channel != "" {
+		t.Errorf("Expected the default settings, got %+v", settings)
+	}
+	if settings.Workflow == nil || len(settings.Workflow.States) != 2 {
+		t.Errorf("Expected the default workflow, got %+v", settings.Workflow)
+	}
+
+	if _, err := service.UpdateSettings(ctx, "test-namespace", dto.UpdateNamespaceSettingsRequest{
+		RetentionDays: 90,
+		SLATargets:    models.SLATargets{CriticalHours: 4, MajorHours: 24},
+	}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	settings, err = service.GetSettings(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if settings.RetentionDays != 90 || settings.SLATargets.MajorHours != 24 {
+		t.Errorf("Expected the updated settings, got %+v", settings)
+	}
+
+	// Resetting the settings restores the defaults
+	if err := service.ResetSettings(ctx, "test-namespace"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	settings, err = service.GetSettings(ctx, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if settings.RetentionDays != 0 {
+		t.Errorf("Expected the default settings, got %+v", settings)
+	}
+}
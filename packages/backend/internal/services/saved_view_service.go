@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type SavedViewService struct {
+	repo   repository.SavedViewRepository // Repository instance
+	logger *logrus.Logger                 // Logging instance
+}
+
+func NewSavedViewService(repo repository.SavedViewRepository, logger *logrus.Logger) *SavedViewService {
+	return &SavedViewService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetViews returns the views shared in a namespace, or the personal views of a user
+func (s *SavedViewService) GetViews(ctx context.Context, namespace, user string) ([]models.SavedView, error) {
+	return s.repo.FindAll(ctx, namespace, user)
+}
+
+// SaveView creates a view shared in a namespace, or personal to a user, or
+// replaces its query if it exists
+func (s *SavedViewService) SaveView(ctx context.Context, namespace, user, name, query string) (*models.SavedView, error) {
+	view, err := s.repo.Save(ctx, models.SavedView{
+		Namespace: namespace,
+		User:      user,
+		Name:      name,
+		Query:     query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{"namespace": namespace, "user": user, "view": name}).Info("Saved view")
+	return view, nil
+}
+
+// DeleteView deletes a view shared in a namespace, or personal to a user
+func (s *SavedViewService) DeleteView(ctx context.Context, namespace, user, name string) error {
+	return s.repo.Delete(ctx, namespace, user, name)
+}
+
+// ResolveView finds the view a request refers to by name, the personal view
+// of user if any, else the view shared in namespace. Returns "saved view not
+// found" if neither exists.
+func (s *SavedViewService) ResolveView(ctx context.Context, name, namespace, user string) (*models.SavedView, error) {
+	if user != "" {
+		view, err := s.repo.Find(ctx, "", user, name)
+		if err != nil || view != nil {
+			return view, err
+		}
+	}
+	if namespace != "" {
+		view, err := s.repo.Find(ctx, namespace, "", name)
+		if err != nil || view != nil {
+			return view, err
+		}
+	}
+	return nil, repository.NotFoundError("saved view not found")
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+func TestSavedViewService_ResolveView(t *testing.T) {
+	ctx, logger, _, db := setupServiceDependents(t)
+	service := NewSavedViewService(repository.NewSavedViewRepository(db, logger, 0), logger)
+
+	if _, err := service.SaveView(ctx, "team-alpha", "", "critical", "severity=critical"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := service.SaveView(ctx, "", "alice", "critical", "severity=critical&assignee=alice"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// The personal view takes precedence over the view of the namespace
+	view, err := service.ResolveView(ctx, "critical", "team-alpha", "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if view.User != "alice" {
+		t.Errorf("Expected the view of alice, got %+v", view)
+	}
+
+	view, err = service.ResolveView(ctx, "critical", "team-alpha", "bob")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if view.Namespace != "team-alpha" {
+		t.Errorf("Expected the view of team-alpha, got %+v", view)
+	}
+
+	if _, err := service.ResolveView(ctx, "critical", "team-beta", ""); err == nil || err.Error() != "saved view not found" {
+		t.Errorf("Expected 'saved view not found', got %v", err)
+	}
+}
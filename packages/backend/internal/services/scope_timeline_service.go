@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// timelineIssuesLimit is how many of the most recently detected issues of a
+// resource its timeline holds
+const timelineIssuesLimit = 1000
+
+type ScopeTimelineService struct {
+	issues repository.IssueRepository // Repository instance
+	logger *logrus.Logger             // Logging instance
+}
+
+func NewScopeTimelineService(issues repository.IssueRepository, logger *logrus.Logger) *ScopeTimelineService {
+	return &ScopeTimelineService{
+		issues: issues,
+		logger: logger,
+	}
+}
+
+// GetTimeline returns the timeline of the resource of an issue scope since a
+// time: the detections, last resolutions and last reopenings of the issues of
+// the namespace of the scope's issue scoped to the same resource. Scopes of
+// other namespaces than namespace, unless empty, are denied.
+func (s *ScopeTimelineService) GetTimeline(ctx context.Context, scopeID, namespace string, since time.Time) (*dto.ScopeTimeline, error) {
+	scope, err := s.issues.FindScope(ctx, scopeID)
+	if err != nil {
+		return nil, err
+	}
+	if scope == nil || scope.Issue == nil {
+		return nil, repository.NotFoundError("scope not found")
+	}
+	if namespace != "" && scope.Issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+
+	issues, err := s.issues.FindByResource(ctx, scope.Issue.Namespace, *scope, since, timelineIssuesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []dto.TimelineEvent{}
+	add := func(eventType dto.ActivityType, at *time.Time, issue models.Issue) {
+		if at != nil && !at.Before(since) {
+			events = append(events, dto.TimelineEvent{
+				Type:      eventType,
+				Timestamp: *at,
+				IssueID:   issue.ID,
+				Title:     issue.Title,
+				IssueType: issue.IssueType,
+				Severity:  issue.Severity,
+			})
+		}
+	}
+	for _, issue := range issues {
+		add(dto.ActivityDetected, &issue.DetectedAt, issue)
+		add(dto.ActivityReopened, issue.ReopenedAt, issue)
+		add(dto.ActivityResolved, issue.ResolvedAt, issue)
+	}
+	slices.SortStableFunc(events, func(a, b dto.TimelineEvent) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	// The scope is returned without its issue, the events telling about it
+	scope.Issue = nil
+	return &dto.ScopeTimeline{
+		Scope:  *scope,
+		Since:  since,
+		Events: events,
+		Total:  len(events),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+func TestScopeTimelineService_GetTimeline(t *testing.T) {
+	ctx, logger, issues, db := setupServiceDependents(t)
+	service := NewScopeTimelineService(issues, logger)
+
+	// Issues of different types, which aren't duplicates of each other
+	create := func(title string, issueType models.IssueType, namespace, resourceName string, detectedAt time.Time) *models.Issue {
+		issue, err := issues.Create(ctx, dto.CreateIssueRequest{
+			Title:       title,
+			Description: "Testing the scope timeline",
+			Severity:    models.SeverityMajor,
+			IssueType:   issueType,
+			Namespace:   namespace,
+			DetectedAt:  detectedAt,
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "component",
+				ResourceName:      resourceName,
+				ResourceNamespace: namespace,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue
+	}
+	resolve := func(issue *models.Issue, resolvedAt time.Time) {
+		if err := db.Model(issue).Updates(map[string]any{"state": models.IssueStateResolved, "resolved_at": resolvedAt}).Error; err != nil {
+			t.Fatalf("Failed to resolve issue: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	old := create("Old failure", models.IssueTypeTest, "test-namespace", "frontend", now.AddDate(0, 0, -200))
+	resolve(old, now.AddDate(0, 0, -199))
+	first := create("First failure", models.IssueTypeBuild, "test-namespace", "frontend", now.AddDate(0, 0, -10))
+	resolve(first, now.AddDate(0, 0, -9))
+	second := create("Second failure", models.IssueTypeRelease, "test-namespace", "frontend", now.AddDate(0, 0, -1))
+	create("Other component", models.IssueTypeBuild, "test-namespace", "backend", now.AddDate(0, 0, -1))
+	create("Other namespace", models.IssueTypeBuild, "other-namespace", "frontend", now.AddDate(0, 0, -1))
+
+	if _, err := service.GetTimeline(ctx, second.ScopeID, "other-namespace", now.AddDate(0, 0, -90)); err == nil || err.Error() != "access denied to this namespace" {
+		t.Errorf("Expected access to be denied, got %v", err)
+	}
+	if _, err := service.GetTimeline(ctx, "00000000-0000-0000-0000-000000000000", "", now.AddDate(0, 0, -90)); err == nil || err.Error() != "scope not found" {
+		t.Errorf("Expected the scope not to be found, got %v", err)
+	}
+
+	timeline, err := service.GetTimeline(ctx, second.ScopeID, "test-namespace", now.AddDate(0, 0, -90))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if timeline.Scope.ResourceName != "frontend" || timeline.Scope.Issue != nil {
+		t.Errorf("Expected the scope without its issue, got %+v", timeline.Scope)
+	}
+
+	expected := []struct {
+		eventType dto.ActivityType
+		issueID   string
+	}{
+		{dto.ActivityDetected, first.ID},
+		{dto.ActivityResolved, first.ID},
+		{dto.ActivityDetected, second.ID},
+	}
+	if timeline.Total != len(expected) || len(timeline.Events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), timeline.Events)
+	}
+	for i, event := range timeline.Events {
+		if event.Type != expected[i].eventType || event.IssueID != expected[i].issueID {
+			t.Errorf("Expected event %d to be %s of %s, got %s of %s", i, expected[i].eventType, expected[i].issueID, event.Type, event.IssueID)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"maps"
+	"regexp"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type TriageRuleService struct {
+	issues repository.IssueRepository      // Repository of the triaged issues
+	repo   repository.TriageRuleRepository // Repository instance
+	logger *logrus.Logger                  // Logging instance
+}
+
+func NewTriageRuleService(issues repository.IssueRepository, repo repository.TriageRuleRepository, logger *logrus.Logger) *TriageRuleService {
+	return &TriageRuleService{
+		issues: issues,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetRules returns the triage rules of a namespace, in the order they're evaluated
+func (s *TriageRuleService) GetRules(ctx context.Context, namespace string) ([]models.TriageRule, error) {
+	return s.repo.FindAll(ctx, namespace)
+}
+
+// SaveRule creates a triage rule of a namespace, or replaces it if it exists
+func (s *TriageRuleService) SaveRule(ctx context.Context, namespace, name string, req dto.SaveTriageRuleRequest) (*models.TriageRule, error) {
+	rule, err := s.repo.Save(ctx, models.TriageRule{
+		Namespace:    namespace,
+		Name:         name,
+		Position:     req.Position,
+		TitlePattern: req.TitlePattern,
+		ResourceType: req.ResourceType,
+		ResourceName: req.ResourceName,
+		Source:       req.Source,
+		Labels:       req.Labels,
+		Assignee:     req.Assignee,
+		Priority:     req.Priority,
+		Severity:     req.Severity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx, s.logger).WithFields(logrus.Fields{"namespace": namespace, "rule": name}).Info("Saved triage rule")
+	return rule, nil
+}
+
+// DeleteRule deletes a triage rule of a namespace
+func (s *TriageRuleService) DeleteRule(ctx context.Context, namespace, name string) error {
+	return s.repo.Delete(ctx, namespace, name)
+}
+
+// FindEvents returns the triage rules that fired on an issue, returning
+// "issue not found" if it doesn't exist, and "access denied to this namespace"
+// if it isn't an issue of namespace, when set
+func (s *TriageRuleService) FindEvents(ctx context.Context, issueID, namespace string) ([]models.TriageEvent, error) {
+	issue, err := s.issues.FindByID(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.NotFoundError("issue not found")
+	}
+	if namespace != "" && issue.Namespace != namespace {
+		return nil, repository.ForbiddenError("access denied to this namespace")
+	}
+	return s.repo.FindEvents(ctx, issueID)
+}
+
+// triageIssue applies the rules matching an issue reported from source to the
+// request creating it, returning an event without issue ID for each rule that
+// fired. Rules are applied in order, later rules overriding earlier ones.
+func triageIssue(rules []models.TriageRule, req *dto.CreateIssueRequest, source models.IssueSource) []models.TriageEvent {
+	var events []models.TriageEvent
+	labelsCloned := false
+	for _, rule := range rules {
+		if !triageRuleMatches(rule, *req, source) {
+			continue
+		}
+
+		changes := map[string]string{}
+		if len(rule.Labels) > 0 && !labelsCloned {
+			// The labels of the request belong to the caller
+			req.Labels = maps.Clone(req.Labels)
+			if req.Labels == nil {
+				req.Labels = map[string]string{}
+			}
+			labelsCloned = true
+		}
+		for key, value := range rule.Labels {
+			if req.Labels[key] != value {
+				req.Labels[key] = value
+				changes["label."+key] = value
+			}
+		}
+		if rule.Assignee != "" && rule.Assignee != req.Assignee {
+			req.Assignee = rule.Assignee
+			changes["assignee"] = rule.Assignee
+		}
+		if rule.Priority != "" && rule.Priority != req.Priority {
+			req.Priority = rule.Priority
+			changes["priority"] = string(rule.Priority)
+		}
+		if rule.Severity != "" && rule.Severity != req.Severity {
+			req.Severity = rule.Severity
+			changes["severity"] = string(rule.Severity)
+		}
+		events = append(events, models.TriageEvent{RuleName: rule.Name, Changes: changes})
+	}
+	return events
+}
+
+// triageRuleMatches returns whether all the conditions set on a rule match an issue
+func triageRuleMatches(rule models.TriageRule, req dto.CreateIssueRequest, source models.IssueSource) bool {
+	if rule.Source != "" && rule.Source != source {
+		return false
+	}
+	if rule.ResourceType != "" && rule.ResourceType != req.Scope.ResourceType {
+		return false
+	}
+	if rule.ResourceName != "" && rule.ResourceName != req.Scope.ResourceName {
+		return false
+	}
+	if rule.TitlePattern != "" {
+		// Patterns are validated when rules are saved
+		matched, err := regexp.MatchString(rule.TitlePattern, req.Title)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+func TestTriageIssue(t *testing.T) {
+	rules := []models.TriageRule{
+		{Name: "timeouts", TitlePattern: "(?i)timed? ?out", Labels: map[string]string{"kind": "flaky"}, Priority: models.PriorityP3},
+		{Name: "webhooks", Source: models.IssueSourceWebhook, Assignee: "bot"},
+		{Name: "api", ResourceType: "component", ResourceName: "api", Severity: models.SeverityCritical, Priority: models.PriorityP1},
+		{Name: "unchanged", Labels: map[string]string{"team": "build"}},
+	}
+	labels := map[string]string{"team": "build"}
+	req := dto.CreateIssueRequest{
+		Title:    "Build timed out",
+		Severity: models.SeverityMajor,
+		Labels:   labels,
+		Scope:    dto.ScopeReqBody{ResourceType: "component", ResourceName: "api"},
+	}
+
+	events := triageIssue(rules, &req, models.IssueSourceAPI)
+
+	if len(events) != 3 || events[0].RuleName != "timeouts" || events[1].RuleName != "api" || events[2].RuleName != "unchanged" {
+		t.Fatalf("Expected the matching rules to fire in order, got %+v", events)
+	}
+	// Later rules override earlier ones
+	if req.Priority != models.PriorityP1 || req.Severity != models.SeverityCritical || req.Assignee != "" {
+		t.Errorf("Unexpected triaged request %+v", req)
+	}
+	if req.Labels["kind"] != "flaky" || req.Labels["team"] != "build" {
+		t.Errorf("Expected the labels of the rules to be added, got %+v", req.Labels)
+	}
+	if len(labels) != 1 {
+		t.Errorf("Expected the labels of the caller to be left unchanged, got %+v", labels)
+	}
+	if events[1].Changes["priority"] != "P1" || events[1].Changes["severity"] != "critical" || len(events[2].Changes) != 0 {
+		t.Errorf("Unexpected changes %+v", events)
+	}
+}
+
+func TestIssueService_CreateIssue_Triage(t *testing.T) {
+	service, ctx, db := createTestService(t)
+	rules := NewTriageRuleService(service.repo, repository.NewTriageRuleRepository(db, service.logger, 0), service.logger)
+
+	if _, err := rules.SaveRule(ctx, "test-namespace", "builds", dto.SaveTriageRuleRequest{
+		ResourceType: "component",
+		Assignee:     "alice",
+		Severity:     models.SeverityCritical,
+	}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	req := dto.CreateIssueRequest{
+		Title:       "Triaged Issue",
+		Description: "Testing triage rules",
+		Severity:    models.SeverityMinor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "test-namespace",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      "triaged-component",
+			ResourceNamespace: "test-namespace",
+		},
+	}
+	issue, err := service.CreateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue.Assignee != "alice" || issue.Severity != models.SeverityCritical {
+		t.Errorf("Expected the issue to be triaged, got %+v", issue)
+	}
+
+	if _, err := rules.FindEvents(ctx, issue.ID, "other-namespace"); err == nil || err.Error() != "access denied to this namespace" {
+		t.Errorf("Expected access denied to this namespace, got %v", err)
+	}
+	events, err := rules.FindEvents(ctx, issue.ID, "test-namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(events) != 1 || events[0].RuleName != "builds" || events[0].Changes["assignee"] != "alice" {
+		t.Fatalf("Expected the rule to be recorded, got %+v", events)
+	}
+
+	// Reports of the existing issue keep the fields set by the rules, without recording them again
+	updated, err := service.CreateOrUpdateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.ID != issue.ID || updated.Severity != models.SeverityCritical {
+		t.Errorf("Expected the existing issue to stay triaged, got %+v", updated)
+	}
+	if events, _ := rules.FindEvents(ctx, issue.ID, ""); len(events) != 1 {
+		t.Errorf("Expected no new events, got %+v", events)
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/konflux-ci/kite/internal/logging"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// PayloadStorage stores the payloads too large for database rows, see blob.Client
+type PayloadStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	PresignGet(key string) string
+}
+
+type WebhookEventService struct {
+	repo    repository.WebhookEventRepository // Repository instance
+	storage PayloadStorage                    // Storage of the large payloads, nil to keep them in the database
+	// offloadThreshold is the size in bytes over which payloads go to the storage
+	offloadThreshold int
+	logger           *logrus.Logger // Logging instance
+}
+
+func NewWebhookEventService(repo repository.WebhookEventRepository, storage PayloadStorage, offloadThreshold int, logger *logrus.Logger) *WebhookEventService {
+	return &WebhookEventService{
+		repo:             repo,
+		storage:          storage,
+		offloadThreshold: offloadThreshold,
+		logger:           logger,
+	}
+}
+
+// RecordEvent records a webhook accepted by the API, with its JSON payload
+// and the issue it created or updated, if any. Payloads over the offload
+// threshold are stored in the payload storage, when there's one, and kept in
+// the database when the storage fails.
+func (s *WebhookEventService) RecordEvent(ctx context.Context, source, namespace string, payload []byte, signature models.WebhookSignatureStatus, issueID *string) (*models.WebhookEvent, error) {
+	event := models.WebhookEvent{
+		ID:              uuid.New().String(),
+		Source:          source,
+		Namespace:       namespace,
+		Payload:         json.RawMessage(payload),
+		SignatureStatus: signature,
+		IssueID:         issueID,
+	}
+
+	if s.storage != nil && len(payload) > s.offloadThreshold {
+		key := fmt.Sprintf("webhook-events/%s/%s.json", namespace, event.ID)
+		if err := s.storage.Put(ctx, key, payload, "application/json"); err != nil {
+			logging.FromContext(ctx, s.logger).WithError(err).WithField("event_id", event.ID).Warn("Failed to store webhook payload, keeping it in the database")
+		} else {
+			event.Payload = nil
+			event.PayloadKey = key
+		}
+	}
+
+	created, err := s.repo.Create(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	s.presignPayload(created)
+	return created, nil
+}
+
+// GetEvents returns the webhook events matching filters, the most recent first
+func (s *WebhookEventService) GetEvents(ctx context.Context, filters repository.WebhookEventFilters) ([]models.WebhookEvent, error) {
+	events, err := s.repo.FindAll(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		s.presignPayload(&events[i])
+	}
+	return events, nil
+}
+
+// GetEvent returns a webhook event, nil if there's no such event
+func (s *WebhookEventService) GetEvent(ctx context.Context, id string) (*models.WebhookEvent, error) {
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil || event == nil {
+		return event, err
+	}
+	s.presignPayload(event)
+	return event, nil
+}
+
+// GetPayload returns the payload of a webhook event, fetching it from the
+// payload storage when it's stored there
+func (s *WebhookEventService) GetPayload(ctx context.Context, event *models.WebhookEvent) ([]byte, error) {
+	if event.PayloadKey == "" {
+		return event.Payload, nil
+	}
+	if s.storage == nil {
+		return nil, fmt.Errorf("payload of webhook event %s is in the blob storage, which isn't configured", event.ID)
+	}
+	return s.storage.Get(ctx, event.PayloadKey)
+}
+
+// presignPayload sets the URL downloading the payload of an event from the
+// payload storage, when it's stored there
+func (s *WebhookEventService) presignPayload(event *models.WebhookEvent) {
+	if event.PayloadKey != "" && s.storage != nil {
+		event.PayloadURL = s.storage.PresignGet(event.PayloadKey)
+	}
+}
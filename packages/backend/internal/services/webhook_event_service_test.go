@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+func TestWebhookEventService_RecordEvent(t *testing.T) {
+	ctx, logger, _, db := setupServiceDependents(t)
+	service := NewWebhookEventService(repository.NewWebhookEventRepository(db, logger, 0), nil, 0, logger)
+
+	payload := []byte(`{"pipelineName": "build", "namespace": "team-alpha", "failureReason": "OOMKilled"}`)
+	issueID := "7d5b8e5a-5c1e-4b8a-9f0e-2d6a1c3b4e5f"
+	recorded, err := service.RecordEvent(ctx, "pipeline-failure", "team-alpha", payload, models.WebhookSignatureValid, &issueID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if recorded.ID == "" || recorded.ReceivedAt.IsZero() {
+		t.Errorf("Expected the event to get an ID and a reception time, got %+v", recorded)
+	}
+
+	event, err := service.GetEvent(ctx, recorded.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if event == nil || event.SignatureStatus != models.WebhookSignatureValid || *event.IssueID != issueID {
+		t.Fatalf("Expected the recorded event, got %+v", event)
+	}
+	// The payload is kept for replays, as JSON rather than byte for byte
+	var fields map[string]string
+	if err := json.Unmarshal(event.Payload, &fields); err != nil || fields["failureReason"] != "OOMKilled" {
+		t.Errorf("Expected the payload, got %s", event.Payload)
+	}
+
+	if events, _ := service.GetEvents(ctx, repository.WebhookEventFilters{Namespace: "team-beta"}); len(events) != 0 {
+		t.Errorf("Expected no events of team-beta, got %+v", events)
+	}
+}
+
+// memoryStorage is a PayloadStorage keeping the payloads in memory
+type memoryStorage struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (m *memoryStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.objects[key] = data
+	return nil
+}
+
+func (m *memoryStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	return m.objects[key], nil
+}
+
+func (m *memoryStorage) PresignGet(key string) string {
+	return "https://blobs.example.com/kite/" + key + "?X-Amz-Signature=signature"
+}
+
+func TestWebhookEventService_RecordEvent_Offload(t *testing.T) {
+	ctx, logger, _, db := setupServiceDependents(t)
+	storage := &memoryStorage{objects: map[string][]byte{}}
+	service := NewWebhookEventService(repository.NewWebhookEventRepository(db, logger, 0), storage, 32, logger)
+
+	small, err := service.RecordEvent(ctx, "pipeline-success", "team-alpha", []byte(`{"pipelineName": "build"}`), models.WebhookSignatureValid, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	payload := []byte(`{"pipelineName": "build", "namespace": "team-alpha", "failureReason": "OOMKilled"}`)
+	large, err := service.RecordEvent(ctx, "pipeline-failure", "team-alpha", payload, models.WebhookSignatureValid, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Payloads under the threshold stay in the database
+	if event, _ := service.GetEvent(ctx, small.ID); event.PayloadKey != "" || event.PayloadURL != "" || len(event.Payload) == 0 {
+		t.Errorf("Expected the payload in the database, got %+v", event)
+	}
+
+	event, err := service.GetEvent(ctx, large.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if event.PayloadKey != "webhook-events/team-alpha/"+large.ID+".json" || len(event.Payload) != 0 {
+		t.Fatalf("Expected the payload in the storage, got %+v", event)
+	}
+	if event.PayloadURL != storage.PresignGet(event.PayloadKey) {
+		t.Errorf("Expected a presigned URL of the payload, got %q", event.PayloadURL)
+	}
+	if stored, err := service.GetPayload(ctx, event); err != nil || string(stored) != string(payload) {
+		t.Errorf("Expected the stored payload, got %s, %v", stored, err)
+	}
+
+	// Payloads are kept in the database when the storage fails
+	storage.err = errors.New("storage unavailable")
+	kept, err := service.RecordEvent(ctx, "pipeline-failure", "team-alpha", payload, models.WebhookSignatureValid, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if event, _ := service.GetEvent(ctx, kept.ID); event.PayloadKey != "" || len(event.Payload) == 0 {
+		t.Errorf("Expected the payload in the database, got %+v", event)
+	}
+}
@@ -0,0 +1,262 @@
+// Package startup checks the server can serve traffic before it does: the
+// schema of the database is up to date, its indexes exist, and the optional
+// services it depends on are reachable.
+package startup
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Check is a startup check. The server is ready once its critical checks
+// pass, the others only being reported.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// Result is the outcome of the last run of a startup check
+type Result struct {
+	Name     string `json:"name"`
+	Critical bool   `json:"critical"`
+	Passed   bool   `json:"passed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Checker runs the startup checks until the critical ones pass, see Run
+type Checker struct {
+	checks   []Check
+	interval time.Duration
+	timeout  time.Duration
+	logger   *logrus.Logger
+
+	ready   atomic.Bool
+	mu      sync.Mutex
+	results []Result
+}
+
+// NewChecker creates a new Checker
+//
+// Parameters:
+//   - checks: The startup checks
+//   - interval: How long to wait before running the checks again while critical checks fail
+//   - timeout: How long a check may take before it fails
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - *Checker
+func NewChecker(checks []Check, interval, timeout time.Duration, logger *logrus.Logger) *Checker {
+	return &Checker{
+		checks:   checks,
+		interval: interval,
+		timeout:  timeout,
+		logger:   logger,
+	}
+}
+
+// Ready reports whether the critical checks passed
+func (c *Checker) Ready() bool {
+	return c.ready.Load()
+}
+
+// Results returns the outcome of the last run of the checks, empty until they
+// first ran
+func (c *Checker) Results() []Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.results)
+}
+
+// Run runs the checks every interval until the critical ones pass, e.g. until
+// the migrations are applied, or stop is closed. It's a workers.Worker.
+func (c *Checker) Run(ctx context.Context, stop <-chan struct{}) {
+	for attempt := 1; ; attempt++ {
+		if c.runOnce(ctx, attempt) {
+			return
+		}
+		select {
+		case <-stop:
+			return
+		case <-time.After(c.interval):
+		}
+	}
+}
+
+// runOnce runs the checks, returning whether the critical ones passed
+func (c *Checker) runOnce(ctx context.Context, attempt int) bool {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			results[i] = Result{Name: check.Name, Critical: check.Critical, Passed: true}
+			if err := check.Run(checkCtx); err != nil {
+				results[i].Passed = false
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	c.results = results
+	c.mu.Unlock()
+
+	ready := true
+	for _, result := range results {
+		if result.Passed {
+			continue
+		}
+		logger := c.logger.WithFields(logrus.Fields{"check": result.Name, "attempt": attempt, "error": result.Error})
+		if result.Critical {
+			ready = false
+			logger.Error("Critical startup check failed, not serving traffic")
+		} else {
+			logger.Warn("Startup check failed")
+		}
+	}
+	if ready {
+		c.ready.Store(true)
+		c.logger.WithField("attempt", attempt).Info("Startup checks passed, serving traffic")
+	}
+	return ready
+}
+
+// createIndexPattern and dropIndexPattern match the statements creating and
+// dropping indexes in the migrations, capturing the name of the index
+var (
+	createIndexPattern = regexp.MustCompile(`(?im)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?"(\w+)"`)
+	dropIndexPattern   = regexp.MustCompile(`(?im)^\s*DROP\s+INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+EXISTS\s+)?(?:"\w+"\.)?"(\w+)"`)
+)
+
+// Migrations describes the migrations the server expects to be applied
+type Migrations struct {
+	// Version is the version of the last migration
+	Version string
+	// Indexes are the names of the indexes the migrations create and don't drop
+	Indexes []string
+}
+
+// ParseMigrations reads the Atlas migrations of a directory, named
+// <version>_<name>.sql and applied in the order of their version
+//
+// Parameters:
+//   - migrations: The directory of the migrations, e.g. migrations.FS
+//
+// Returns:
+//   - *Migrations
+//   - error: The directory can't be read or has no migrations
+func ParseMigrations(migrations fs.FS) (*Migrations, error) {
+	files, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no migrations found")
+	}
+	slices.Sort(files)
+
+	var indexes []string
+	for _, file := range files {
+		content, err := fs.ReadFile(migrations, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+		// Statements are matched in the order they're written, an index being
+		// dropped and created again within a migration
+		statements := append(createIndexPattern.FindAllSubmatchIndex(content, -1), dropIndexPattern.FindAllSubmatchIndex(content, -1)...)
+		slices.SortFunc(statements, func(a, b []int) int { return a[0] - b[0] })
+		for _, statement := range statements {
+			name := string(content[statement[2]:statement[3]])
+			indexes = slices.DeleteFunc(indexes, func(index string) bool { return index == name })
+			if createIndexPattern.Match(content[statement[0]:statement[1]]) {
+				indexes = append(indexes, name)
+			}
+		}
+	}
+	slices.Sort(indexes)
+
+	version, _, _ := strings.Cut(files[len(files)-1], "_")
+	return &Migrations{Version: version, Indexes: indexes}, nil
+}
+
+// SchemaCheck returns the check that the migrations are applied to the
+// database, according to the revisions table of Atlas. Databases with newer
+// migrations pass, e.g. while a new version of the server is being rolled out.
+//
+// Parameters:
+//   - db: The database
+//   - migrations: The migrations the server expects, see ParseMigrations
+//
+// Returns:
+//   - func(ctx context.Context) error: The check, see Check.Run
+func SchemaCheck(db *gorm.DB, migrations *Migrations) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var revision struct {
+			Version string
+			Applied int
+			Total   int
+		}
+		err := db.WithContext(ctx).
+			Raw("SELECT version, applied, total FROM atlas_schema_revisions.atlas_schema_revisions ORDER BY version DESC LIMIT 1").
+			Scan(&revision).Error
+		if err != nil {
+			return fmt.Errorf("failed to read the schema version, are the migrations applied? %w", err)
+		}
+		if revision.Version == "" {
+			return fmt.Errorf("no migrations applied, expected version %s", migrations.Version)
+		}
+		if revision.Applied < revision.Total {
+			return fmt.Errorf("migration %s partially applied: %d of %d statements", revision.Version, revision.Applied, revision.Total)
+		}
+		if revision.Version < migrations.Version {
+			return fmt.Errorf("schema at version %s, expected version %s: apply the pending migrations", revision.Version, migrations.Version)
+		}
+		return nil
+	}
+}
+
+// IndexCheck returns the check that the indexes created by the migrations
+// exist in the public schema of the database
+//
+// Parameters:
+//   - db: The database
+//   - migrations: The migrations the server expects, see ParseMigrations
+//
+// Returns:
+//   - func(ctx context.Context) error: The check, see Check.Run
+func IndexCheck(db *gorm.DB, migrations *Migrations) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var existing []string
+		err := db.WithContext(ctx).
+			Raw("SELECT indexname FROM pg_indexes WHERE schemaname = 'public' AND indexname IN ?", migrations.Indexes).
+			Scan(&existing).Error
+		if err != nil {
+			return fmt.Errorf("failed to list indexes: %w", err)
+		}
+		var missing []string
+		for _, index := range migrations.Indexes {
+			if !slices.Contains(existing, index) {
+				missing = append(missing, index)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing indexes: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
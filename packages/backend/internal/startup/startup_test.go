@@ -0,0 +1,156 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/konflux-ci/kite/migrations"
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseMigrations(t *testing.T) {
+	dir := fstest.MapFS{
+		"20250101000000_init.sql": {Data: []byte(`-- Create index "idx_commented" to table: "issues"
+CREATE INDEX "idx_issues_state" ON "public"."issues" ("state");
+CREATE UNIQUE INDEX "idx_issues_dedup" ON "public"."issues" ("namespace");
+`)},
+		"20250102000000_dedup.sql": {Data: []byte(`DROP INDEX "public"."idx_issues_dedup";
+CREATE UNIQUE INDEX "idx_issues_dedup" ON "public"."issues" ("namespace", "issue_type");
+DROP INDEX "public"."idx_issues_state";
+`)},
+		"atlas.sum": {Data: []byte("h1:...")},
+	}
+
+	parsed, err := ParseMigrations(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsed.Version != "20250102000000" {
+		t.Errorf("Expected version 20250102000000, got %s", parsed.Version)
+	}
+	if !slices.Equal(parsed.Indexes, []string{"idx_issues_dedup"}) {
+		t.Errorf("Expected the indexes not dropped, got %v", parsed.Indexes)
+	}
+
+	if _, err := ParseMigrations(fstest.MapFS{}); err == nil {
+		t.Error("Expected an error without migrations")
+	}
+}
+
+func TestParseMigrations_Embedded(t *testing.T) {
+	parsed, err := ParseMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(parsed.Version) != len("20060102150405") {
+		t.Errorf("Expected the version of the last migration, got %q", parsed.Version)
+	}
+	if !slices.Contains(parsed.Indexes, "idx_issues_active_dedup") {
+		t.Errorf("Expected the deduplication index, got %v", parsed.Indexes)
+	}
+}
+
+func TestChecker_Run(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	attempts := 0
+	checker := NewChecker([]Check{
+		{Name: "schema", Critical: true, Run: func(ctx context.Context) error {
+			if attempts++; attempts < 3 {
+				return errors.New("pending migrations")
+			}
+			return nil
+		}},
+		{Name: "jira", Run: func(ctx context.Context) error { return errors.New("unreachable") }},
+	}, time.Millisecond, time.Second, logger)
+
+	if checker.Ready() || len(checker.Results()) != 0 {
+		t.Fatal("Expected the checker not to be ready before the checks ran")
+	}
+	checker.Run(context.Background(), make(chan struct{}))
+
+	if !checker.Ready() || attempts != 3 {
+		t.Errorf("Expected the checker to be ready once the critical checks passed, after %d attempts", attempts)
+	}
+	results := checker.Results()
+	if len(results) != 2 || !results[0].Passed || results[1].Passed || results[1].Error != "unreachable" {
+		t.Errorf("Expected the results of the last run, got %+v", results)
+	}
+}
+
+func TestChecker_Run_Stop(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+	checker := NewChecker([]Check{
+		{Name: "schema", Critical: true, Run: func(ctx context.Context) error { return errors.New("pending migrations") }},
+	}, time.Hour, time.Second, logger)
+
+	stop := make(chan struct{})
+	close(stop)
+	checker.Run(context.Background(), stop)
+
+	if checker.Ready() {
+		t.Error("Expected the checker not to be ready while critical checks fail")
+	}
+}
+
+func TestSchemaAndIndexChecks_Postgres(t *testing.T) {
+	db := testhelpers.SetupPostgresTestDB(t)
+	ctx := context.Background()
+	expected, err := ParseMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := IndexCheck(db, expected)(ctx); err != nil {
+		t.Errorf("Expected the indexes of the migrations to exist, got %v", err)
+	}
+	if err := db.Exec(`DROP INDEX "public"."idx_issues_active_dedup"`).Error; err != nil {
+		t.Fatalf("Failed to drop index: %v", err)
+	}
+	if err := IndexCheck(db, expected)(ctx); err == nil || !strings.Contains(err.Error(), "idx_issues_active_dedup") {
+		t.Errorf("Expected the missing index to be reported, got %v", err)
+	}
+
+	// The test database has the migrations applied without Atlas
+	schemaCheck := SchemaCheck(db, expected)
+	if err := schemaCheck(ctx); err == nil {
+		t.Error("Expected an error without the revisions of Atlas")
+	}
+	if err := db.Exec(`CREATE SCHEMA atlas_schema_revisions;
+CREATE TABLE atlas_schema_revisions.atlas_schema_revisions (version text PRIMARY KEY, applied bigint NOT NULL, total bigint NOT NULL)`).Error; err != nil {
+		t.Fatalf("Failed to create the revisions table: %v", err)
+	}
+	tests := []struct {
+		name    string
+		version string
+		applied int
+		total   int
+		wantErr bool
+	}{
+		{name: "pending migrations", version: "20250101000000", applied: 1, total: 1, wantErr: true},
+		{name: "partially applied", version: expected.Version, applied: 1, total: 2, wantErr: true},
+		{name: "up to date", version: expected.Version, applied: 2, total: 2},
+		{name: "newer schema", version: "99991231000000", applied: 1, total: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.Exec("DELETE FROM atlas_schema_revisions.atlas_schema_revisions").Error; err != nil {
+				t.Fatalf("Failed to clear revisions: %v", err)
+			}
+			if err := db.Exec("INSERT INTO atlas_schema_revisions.atlas_schema_revisions VALUES (?, ?, ?)", tt.version, tt.applied, tt.total).Error; err != nil {
+				t.Fatalf("Failed to insert revision: %v", err)
+			}
+			if err := schemaCheck(ctx); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -17,8 +17,8 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	// Mark as a test helper for better error reporting
 	t.Helper()
 
-	// Use SQLite in-memory DB for tests
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	// Use SQLite in-memory DB for tests, enforcing foreign keys like Postgres
+	db, err := gorm.Open(sqlite.Open("file::memory:?_foreign_keys=on"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to created test database: %v", err)
 	}
@@ -28,6 +28,14 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&models.IssueScope{},
 		&models.Issue{},
 		&models.Link{},
+		&models.Label{},
+		&models.ExternalRef{},
+		&models.NamespaceSettings{},
+		&models.IssueWatch{},
+		&models.SavedView{},
+		&models.TriageRule{},
+		&models.TriageEvent{},
+		&models.WebhookEvent{},
 		&models.RelatedIssue{},
 	)
 
@@ -61,7 +69,7 @@ func SetupConcurrentTestDB(t *testing.T) *gorm.DB {
 	// Use shared cache mode for concurrent access
 	// This ensures that all connections share the same in-memory database.
 	// Without this, each goroutine gets its own isolated DB instance.
-	dsn := "file::memory:?cache=shared"
+	dsn := "file::memory:?cache=shared&_foreign_keys=on"
 	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to create test database: %v", err)
@@ -101,6 +109,14 @@ func SetupConcurrentTestDB(t *testing.T) *gorm.DB {
 		&models.IssueScope{},
 		&models.Issue{},
 		&models.Link{},
+		&models.Label{},
+		&models.ExternalRef{},
+		&models.NamespaceSettings{},
+		&models.IssueWatch{},
+		&models.SavedView{},
+		&models.TriageRule{},
+		&models.TriageEvent{},
+		&models.WebhookEvent{},
 		&models.RelatedIssue{},
 	)
 
@@ -0,0 +1,64 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// The build information of the server. They're set at build time with
+// -ldflags "-X github.com/konflux-ci/kite/internal/version.Version=<version>",
+// and likewise for Commit and BuildDate.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// startedAt is when the server started, to report its uptime
+var startedAt = time.Now().UTC()
+
+// Info describes the running server
+type Info struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	BuildDate string    `json:"buildDate"`
+	GoVersion string    `json:"goVersion"`
+	Platform  string    `json:"platform"`
+	StartedAt time.Time `json:"startedAt"`
+	// Uptime is how long the server has been running, in seconds
+	Uptime float64 `json:"uptimeSeconds"`
+}
+
+// Get returns the build and runtime information of the server. The commit and
+// build date not set at build time are taken from the version control
+// information Go stamps into binaries, when available.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		StartedAt: startedAt,
+		Uptime:    time.Since(startedAt).Seconds(),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	return info
+}
@@ -0,0 +1,114 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Worker does work in the background until stop is closed, e.g. syncing
+// issues every interval. The work in progress when stop is closed should be
+// completed, using ctx, which is only cancelled once the shutdown times out.
+type Worker func(ctx context.Context, stop <-chan struct{})
+
+// Group runs the background workers of the server, and drains them when it
+// shuts down, so that their writes in progress aren't cut short.
+type Group struct {
+	logger *logrus.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	once   sync.Once
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewGroup creates a new Group
+//
+// Parameters:
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - *Group
+func NewGroup(logger *logrus.Logger) *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+		stop:    make(chan struct{}),
+		running: make(map[string]bool),
+	}
+}
+
+// Go runs a worker in the background until the group shuts down
+//
+// Parameters:
+//   - name: The name of the worker, reported when it doesn't stop in time
+//   - worker: The worker
+func (g *Group) Go(name string, worker Worker) {
+	g.mu.Lock()
+	g.running[name] = true
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			g.mu.Lock()
+			delete(g.running, name)
+			g.mu.Unlock()
+		}()
+
+		worker(g.ctx, g.stop)
+		g.logger.WithField("worker", name).Info("Stopped worker")
+	}()
+}
+
+// Stop stops the workers from taking new work, without waiting for them, see
+// Shutdown
+func (g *Group) Stop() {
+	g.once.Do(func() { close(g.stop) })
+}
+
+// Shutdown stops the workers from taking new work, and waits for them to
+// complete the work in progress. The work still in progress when ctx is done
+// is cancelled.
+//
+// Parameters:
+//   - ctx: Context bounding how long to wait for the workers
+//
+// Returns:
+//   - error: Workers didn't stop before ctx was done
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		g.cancel()
+		return nil
+	case <-ctx.Done():
+		g.cancel()
+
+		g.mu.Lock()
+		names := make([]string, 0, len(g.running))
+		for name := range g.running {
+			names = append(names, name)
+		}
+		g.mu.Unlock()
+		sort.Strings(names)
+		return fmt.Errorf("workers still running, their work in progress was cancelled: %s", strings.Join(names, ", "))
+	}
+}
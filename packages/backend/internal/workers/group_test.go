@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestGroup_Shutdown(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	group := NewGroup(logger)
+
+	started := make(chan struct{})
+	var completed atomic.Bool
+	group.Go("sync", func(ctx context.Context, stop <-chan struct{}) {
+		close(started)
+		<-stop
+		// Work in progress when stopping, which isn't cancelled
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(20 * time.Millisecond):
+			completed.Store(true)
+		}
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := group.Shutdown(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !completed.Load() {
+		t.Error("Expected the work in progress to be completed")
+	}
+}
+
+func TestGroup_ShutdownTimeout(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	group := NewGroup(logger)
+
+	cancelled := make(chan struct{})
+	group.Go("slow", func(ctx context.Context, stop <-chan struct{}) {
+		// Ignores stop, as a sync taking too long would
+		<-ctx.Done()
+		close(cancelled)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := group.Shutdown(ctx)
+	if err == nil || !strings.Contains(err.Error(), "slow") {
+		t.Errorf("Expected an error naming the worker still running, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the work in progress to be cancelled")
+	}
+}
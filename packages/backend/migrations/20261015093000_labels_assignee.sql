@@ -0,0 +1,15 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "assignee" text NULL;
+-- Create index "idx_issues_assignee" to table: "issues"
+CREATE INDEX "idx_issues_assignee" ON "public"."issues" ("assignee");
+-- Create "labels" table
+CREATE TABLE "public"."labels" (
+ "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+ "key" text NOT NULL,
+ "value" text NOT NULL,
+ "issue_id" uuid NOT NULL,
+ PRIMARY KEY ("id"),
+ CONSTRAINT "fk_issues_labels" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_labels_issue_key" to table: "labels"
+CREATE UNIQUE INDEX "idx_labels_issue_key" ON "public"."labels" ("key", "issue_id");
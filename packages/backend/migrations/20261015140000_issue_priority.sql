@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "priority" character varying(2) NULL;
+-- Create index "idx_issues_priority" to table: "issues"
+CREATE INDEX "idx_issues_priority" ON "public"."issues" ("priority");
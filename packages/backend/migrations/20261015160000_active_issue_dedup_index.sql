@@ -0,0 +1,18 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "dedup_key" text NOT NULL DEFAULT '';
+-- Backfill "dedup_key" from the issue scopes
+UPDATE "public"."issues" SET "dedup_key" = "s"."resource_type" || '/' || "s"."resource_name"
+FROM "public"."issue_scopes" AS "s"
+WHERE "s"."id" = "issues"."scope_id";
+-- Resolve all but the most recently detected of any duplicate ACTIVE issues, so the unique index can be created
+UPDATE "public"."issues" SET "state" = 'RESOLVED', "resolved_at" = now(), "updated_at" = now()
+WHERE "id" IN (
+ SELECT "id" FROM (
+  SELECT "id", row_number() OVER (PARTITION BY "namespace", "issue_type", "dedup_key" ORDER BY "detected_at" DESC) AS "rn"
+  FROM "public"."issues"
+  WHERE "state" = 'ACTIVE'
+ ) AS "ranked"
+ WHERE "ranked"."rn" > 1
+);
+-- Create index "idx_issues_active_dedup" to table: "issues"
+CREATE UNIQUE INDEX "idx_issues_active_dedup" ON "public"."issues" ("namespace", "issue_type", "dedup_key") WHERE ((state)::text = 'ACTIVE'::text);
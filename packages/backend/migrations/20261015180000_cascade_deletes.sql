@@ -0,0 +1,8 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" DROP CONSTRAINT "fk_issue_scopes_issue", ADD CONSTRAINT "fk_issue_scopes_issue" FOREIGN KEY ("scope_id") REFERENCES "public"."issue_scopes" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Modify "labels" table
+ALTER TABLE "public"."labels" DROP CONSTRAINT "fk_issues_labels", ADD CONSTRAINT "fk_issues_labels" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Modify "links" table
+ALTER TABLE "public"."links" DROP CONSTRAINT "fk_issues_links", ADD CONSTRAINT "fk_issues_links" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Modify "related_issues" table
+ALTER TABLE "public"."related_issues" DROP CONSTRAINT "fk_issues_related_from", ADD CONSTRAINT "fk_issues_related_from" FOREIGN KEY ("source_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, DROP CONSTRAINT "fk_issues_related_to", ADD CONSTRAINT "fk_issues_related_to" FOREIGN KEY ("target_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
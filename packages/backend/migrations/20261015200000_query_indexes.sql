@@ -0,0 +1,6 @@
+-- Create index "idx_issues_issue_type" to table: "issues"
+CREATE INDEX "idx_issues_issue_type" ON "public"."issues" ("issue_type");
+-- Create index "idx_issues_namespace_state_detected" to table: "issues"
+CREATE INDEX "idx_issues_namespace_state_detected" ON "public"."issues" ("namespace", "state", "detected_at");
+-- Create index "idx_issues_severity" to table: "issues"
+CREATE INDEX "idx_issues_severity" ON "public"."issues" ("severity");
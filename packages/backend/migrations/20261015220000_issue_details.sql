@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "details" text NOT NULL DEFAULT '';
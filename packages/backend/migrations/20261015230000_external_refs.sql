@@ -0,0 +1,13 @@
+-- Create "external_refs" table
+CREATE TABLE "public"."external_refs" (
+ "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+ "system" text NOT NULL,
+ "key" text NOT NULL,
+ "url" text NOT NULL,
+ "issue_id" uuid NOT NULL,
+ "created_at" timestamptz NULL,
+ PRIMARY KEY ("id"),
+ CONSTRAINT "fk_issues_external_refs" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_external_refs_issue_system_key" to table: "external_refs"
+CREATE UNIQUE INDEX "idx_external_refs_issue_system_key" ON "public"."external_refs" ("issue_id", "system", "key");
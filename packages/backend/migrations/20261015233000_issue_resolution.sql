@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "resolution_reason" text NOT NULL DEFAULT '', ADD COLUMN "resolved_by" text NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Modify "related_issues" table
+ALTER TABLE "public"."related_issues" ADD COLUMN "type" character varying(20) NOT NULL DEFAULT 'related';
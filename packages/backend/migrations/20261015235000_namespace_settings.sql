@@ -0,0 +1,15 @@
+-- Create "namespace_settings" table
+CREATE TABLE "public"."namespace_settings" (
+ "namespace" text NOT NULL,
+ "retention_days" bigint NOT NULL DEFAULT 0,
+ "dedup_window_hours" bigint NOT NULL DEFAULT 0,
+ "sla_critical_hours" bigint NOT NULL DEFAULT 0,
+ "sla_major_hours" bigint NOT NULL DEFAULT 0,
+ "sla_minor_hours" bigint NOT NULL DEFAULT 0,
+ "sla_info_hours" bigint NOT NULL DEFAULT 0,
+ "notify_channel" text NOT NULL DEFAULT '',
+ "notify_min_severity" character varying(20) NOT NULL DEFAULT '',
+ "created_at" timestamptz NULL,
+ "updated_at" timestamptz NULL,
+ PRIMARY KEY ("namespace")
+);
@@ -0,0 +1,10 @@
+-- Create "issue_watches" table
+CREATE TABLE "public"."issue_watches" (
+ "issue_id" uuid NOT NULL,
+ "user" text NOT NULL,
+ "created_at" timestamptz NULL,
+ PRIMARY KEY ("issue_id", "user"),
+ CONSTRAINT "fk_issue_watches_issue" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_issue_watches_user" to table: "issue_watches"
+CREATE INDEX "idx_issue_watches_user" ON "public"."issue_watches" ("user");
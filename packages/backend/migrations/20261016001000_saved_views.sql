@@ -0,0 +1,10 @@
+-- Create "saved_views" table
+CREATE TABLE "public"."saved_views" (
+ "namespace" text NOT NULL,
+ "user" text NOT NULL,
+ "name" text NOT NULL,
+ "query" text NOT NULL,
+ "created_at" timestamptz NULL,
+ "updated_at" timestamptz NULL,
+ PRIMARY KEY ("namespace", "user", "name")
+);
@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "reopen_count" bigint NOT NULL DEFAULT 0, ADD COLUMN "reopened_at" timestamptz NULL;
@@ -0,0 +1,29 @@
+-- Create "triage_rules" table
+CREATE TABLE "public"."triage_rules" (
+ "namespace" text NOT NULL,
+ "name" text NOT NULL,
+ "position" bigint NOT NULL DEFAULT 0,
+ "title_pattern" text NOT NULL DEFAULT '',
+ "resource_type" text NOT NULL DEFAULT '',
+ "resource_name" text NOT NULL DEFAULT '',
+ "source" character varying(20) NOT NULL DEFAULT '',
+ "labels" jsonb NULL,
+ "assignee" text NOT NULL DEFAULT '',
+ "priority" character varying(2) NOT NULL DEFAULT '',
+ "severity" character varying(20) NOT NULL DEFAULT '',
+ "created_at" timestamptz NULL,
+ "updated_at" timestamptz NULL,
+ PRIMARY KEY ("namespace", "name")
+);
+-- Create "triage_events" table
+CREATE TABLE "public"."triage_events" (
+ "id" uuid NOT NULL,
+ "issue_id" uuid NOT NULL,
+ "rule_name" text NOT NULL,
+ "changes" jsonb NULL,
+ "created_at" timestamptz NULL,
+ PRIMARY KEY ("id"),
+ CONSTRAINT "fk_triage_events_issue" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "idx_triage_events_issue_id" to table: "triage_events"
+CREATE INDEX "idx_triage_events_issue_id" ON "public"."triage_events" ("issue_id");
@@ -0,0 +1,19 @@
+-- Create "webhook_events" table
+CREATE TABLE "public"."webhook_events" (
+ "id" uuid NOT NULL,
+ "source" character varying(50) NOT NULL,
+ "namespace" text NOT NULL,
+ "payload" jsonb NOT NULL,
+ "signature_status" character varying(20) NOT NULL,
+ "issue_id" uuid NULL,
+ "received_at" timestamptz NOT NULL,
+ PRIMARY KEY ("id")
+);
+-- Create index "idx_webhook_events_issue_id" to table: "webhook_events"
+CREATE INDEX "idx_webhook_events_issue_id" ON "public"."webhook_events" ("issue_id");
+-- Create index "idx_webhook_events_namespace" to table: "webhook_events"
+CREATE INDEX "idx_webhook_events_namespace" ON "public"."webhook_events" ("namespace");
+-- Create index "idx_webhook_events_received_at" to table: "webhook_events"
+CREATE INDEX "idx_webhook_events_received_at" ON "public"."webhook_events" ("received_at");
+-- Create index "idx_webhook_events_source" to table: "webhook_events"
+CREATE INDEX "idx_webhook_events_source" ON "public"."webhook_events" ("source");
@@ -0,0 +1,13 @@
+-- Normalize the values stored before they were validated: severities and
+-- states in the wrong case, and the "medium" severity of the operator
+UPDATE "public"."issues" SET "severity" = LOWER("severity") WHERE "severity" <> LOWER("severity");
+UPDATE "public"."issues" SET "severity" = 'minor' WHERE "severity" = 'medium';
+UPDATE "public"."issues" SET "issue_type" = LOWER("issue_type") WHERE "issue_type" <> LOWER("issue_type");
+UPDATE "public"."issues" SET "state" = UPPER("state") WHERE "state" <> UPPER("state");
+UPDATE "public"."issues" SET "priority" = UPPER("priority") WHERE "priority" <> UPPER("priority");
+-- Create check constraints on "issues" table. They aren't validated against
+-- the existing rows, which may hold other invalid values to fix by hand
+-- before running ALTER TABLE "issues" VALIDATE CONSTRAINT.
+ALTER TABLE "public"."issues" ADD CONSTRAINT "chk_issues_severity" CHECK (severity IN ('info', 'minor', 'major', 'critical')) NOT VALID, ADD CONSTRAINT "chk_issues_priority" CHECK (priority IN ('', 'P1', 'P2', 'P3', 'P4')) NOT VALID, ADD CONSTRAINT "chk_issues_issue_type" CHECK (issue_type IN ('build', 'test', 'release', 'dependency', 'pipeline')) NOT VALID, ADD CONSTRAINT "chk_issues_state" CHECK (state IN ('ACTIVE', 'RESOLVED')) NOT VALID;
+-- Create check constraints on "triage_rules" table, whose values were validated
+ALTER TABLE "public"."triage_rules" ADD CONSTRAINT "chk_triage_rules_source" CHECK (source IN ('', 'api', 'webhook')), ADD CONSTRAINT "chk_triage_rules_priority" CHECK (priority IN ('', 'P1', 'P2', 'P3', 'P4')), ADD CONSTRAINT "chk_triage_rules_severity" CHECK (severity IN ('', 'info', 'minor', 'major', 'critical'));
@@ -0,0 +1,2 @@
+-- Modify "webhook_events" table
+ALTER TABLE "public"."webhook_events" ALTER COLUMN "payload" DROP NOT NULL, ADD COLUMN "payload_key" character varying(255) NULL;
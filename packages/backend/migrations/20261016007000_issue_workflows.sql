@@ -0,0 +1,8 @@
+-- Modify "issues" table, namespaces may define states of their own
+ALTER TABLE "public"."issues" DROP CONSTRAINT "chk_issues_state";
+-- Drop index "idx_issues_active_dedup" from table: "issues"
+DROP INDEX "public"."idx_issues_active_dedup";
+-- Create index "idx_issues_active_dedup" to table: "issues", allowing one open issue in any state but RESOLVED
+CREATE UNIQUE INDEX "idx_issues_active_dedup" ON "public"."issues" ("namespace", "issue_type", "dedup_key") WHERE ((state)::text <> 'RESOLVED'::text);
+-- Modify "namespace_settings" table
+ALTER TABLE "public"."namespace_settings" ADD COLUMN "workflow" jsonb NULL;
@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "acknowledged_at" timestamptz NULL, ADD COLUMN "acknowledged_by" text NOT NULL DEFAULT '';
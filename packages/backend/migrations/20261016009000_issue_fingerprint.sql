@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "fingerprint" text NOT NULL DEFAULT '';
+-- Create index "idx_issues_namespace_fingerprint" to table: "issues"
+CREATE INDEX "idx_issues_namespace_fingerprint" ON "public"."issues" ("namespace", "fingerprint") WHERE (fingerprint <> ''::text);
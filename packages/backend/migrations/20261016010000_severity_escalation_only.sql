@@ -0,0 +1,2 @@
+-- Modify "namespace_settings" table
+ALTER TABLE "public"."namespace_settings" ADD COLUMN "severity_escalation_only" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+-- Modify "namespace_settings" table
+ALTER TABLE "public"."namespace_settings" ADD COLUMN "link_templates" jsonb NULL;
@@ -0,0 +1,4 @@
+-- Create index "idx_issues_detected_at" to table: "issues"
+CREATE INDEX "idx_issues_detected_at" ON "public"."issues" ("detected_at");
+-- Create index "idx_issues_resolved_at" to table: "issues"
+CREATE INDEX "idx_issues_resolved_at" ON "public"."issues" ("resolved_at");
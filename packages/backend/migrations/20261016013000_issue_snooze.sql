@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "snoozed_until" timestamptz NULL, ADD COLUMN "snoozed_by" text NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+// Package migrations embeds the Atlas migrations of the database, so that the
+// server can check the schema of the database is up to date at startup. The
+// migrations are applied by Atlas, see the Makefile.
+package migrations
+
+import "embed"
+
+// FS holds the SQL files of the migrations
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ackCmd represents the ack command
+var ackCmd = &cobra.Command{
+	Use:   "ack",
+	Short: "Acknowledge an issue",
+	Long: `Acknowledge an issue, recording who looked at it and when, so that others
+can tell which issues nobody has looked at yet with 'list --unacknowledged'.
+
+Active issues move to the ACKNOWLEDGED state when the workflow of their
+namespace allows it.`,
+	Example: `  konflux-issues ack -n team-alpha -i <issue-id> --acknowledged-by alice
+  konflux-issues list -n team-alpha -s critical --unresolved --unacknowledged`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Acknowledging issue %s in namespace %s...\n", issueID, namespace)
+		issue, err := client.AcknowledgeIssue(issueID, namespace, acknowledgedBy)
+		if err != nil {
+			return fmt.Errorf("error acknowledging issue: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(issueID)
+			return nil
+		}
+		fmt.Printf("Issue %s has been acknowledged by %s.\n", issueID, issue.AcknowledgedBy)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ackCmd)
+
+	ackCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
+	ackCmd.MarkFlagRequired("id")
+	ackCmd.Flags().StringVar(&acknowledgedBy, "acknowledged-by", "", "Who acknowledges the issue (defaults to the user identified by the API)")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var assignTo string
+
+// assignCmd represents the assign command
+var assignCmd = &cobra.Command{
+	Use:   "assign",
+	Short: "Assign an issue to someone",
+	Long: `Assign an issue to someone, replacing its previous assignee, so that the
+issues of each member of the team can be listed with 'list --assignee'.`,
+	Example: `  konflux-issues assign -n team-alpha -i <issue-id> --to alice
+  konflux-issues list -n team-alpha --assignee alice --unresolved`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// The API leaves the assignee unchanged when it's empty
+		if assignTo == "" {
+			return errors.New("--to must not be empty")
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Assigning issue %s in namespace %s...\n", issueID, namespace)
+		issue, err := client.AssignIssue(issueID, namespace, assignTo)
+		if err != nil {
+			return fmt.Errorf("error assigning issue: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(issueID)
+			return nil
+		}
+		fmt.Printf("Issue %s is now assigned to %s.\n", issueID, issue.Assignee)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(assignCmd)
+
+	assignCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
+	assignCmd.MarkFlagRequired("id")
+	assignCmd.Flags().StringVar(&assignTo, "to", "", "Who the issue is assigned to")
+	assignCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/api"
+	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var describeDepth int
+
+// describeCmd represents the describe command
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Describe an issue along with its links, activity and related issues",
+	Long: `Describe an issue, its links, its activity, and the tree of issues related to it.
+
+Related issues are followed up to --depth levels away from the issue,
+giving a one-command overview of an incident.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// Check for issue ID
+		if issueID == "" {
+			return fmt.Errorf("issue ID is required")
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Describing issue %s in namespace %s...\n", issueID, namespace)
+		issue, err := client.GetIssueDetails(issueID, namespace)
+		if err != nil {
+			return err
+		}
+
+		tree := buildIssueTree(client, issue, describeDepth, map[string]bool{})
+		// Servers predating the activity feed describe the issue without it
+		if tree.Activity, err = client.GetIssueActivity(issueID, namespace); err != nil {
+			progressf("Could not fetch the activity of the issue: %v\n", err)
+		}
+
+		// Print issue based on output format
+		if quiet {
+			printIssueTreeIDs(tree)
+		} else if outputFormat == "json" {
+			formatter.PrintIssueTreeJSON(tree)
+		} else if outputFormat == "yaml" {
+			formatter.PrintIssueTreeYAML(tree)
+		} else {
+			formatter.PrintIssueDescription(tree)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+
+	describeCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
+	describeCmd.Flags().IntVar(&describeDepth, "depth", 2, "How many levels of related issues to show")
+	describeCmd.MarkFlagRequired("id")
+}
+
+// buildIssueTree walks the relationships of an issue (in both directions) and
+// returns the tree of related issues, up to depth levels deep.
+//
+// Issues already present in the tree are skipped so cycles terminate.
+// When a related issue can't be fetched (e.g. it lives in a namespace the
+// user can't access), the copy embedded in the relationship is used instead.
+func buildIssueTree(client *api.Client, issue *models.Issue, depth int, visited map[string]bool) *models.IssueTree {
+	tree := &models.IssueTree{Issue: issue}
+	visited[issue.ID] = true
+
+	if depth <= 0 {
+		return tree
+	}
+
+	// Claim direct children first so they aren't repeated deeper in the tree
+	var children []*models.Issue
+	for _, related := range relatedIssues(issue) {
+		if visited[related.ID] {
+			continue
+		}
+		visited[related.ID] = true
+		children = append(children, related)
+	}
+
+	for _, child := range children {
+		// Embedded copies don't include their own relationships, fetch the
+		// full issue if we need to go further down the tree
+		if depth > 1 {
+			if fetched, err := client.GetIssueDetails(child.ID, child.Namespace); err == nil {
+				child = fetched
+			}
+		}
+		tree.Related = append(tree.Related, buildIssueTree(client, child, depth-1, visited))
+	}
+
+	return tree
+}
+
+// relatedIssues returns the issues on the other side of every relationship
+// involving the given issue
+func relatedIssues(issue *models.Issue) []*models.Issue {
+	var related []*models.Issue
+	for _, r := range issue.RelatedFrom {
+		if r.Target != nil {
+			related = append(related, r.Target)
+		}
+	}
+	for _, r := range issue.RelatedTo {
+		if r.Source != nil {
+			related = append(related, r.Source)
+		}
+	}
+	return related
+}
+
+// printIssueTreeIDs prints the ID of every issue in the tree, one per line
+func printIssueTreeIDs(tree *models.IssueTree) {
+	fmt.Println(tree.Issue.ID)
+	for _, child := range tree.Related {
+		printIssueTreeIDs(child)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/config"
+	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	"github.com/konflux-ci/kite/packages/cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// snapshotRetention is how long the snapshots of the issues are kept
+const snapshotRetention = 30 * 24 * time.Hour
+
+var (
+	diffSince string
+	diffLimit int
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the issues new, resolved and still active since a previous snapshot",
+	Long: `Show what changed in the issues of a namespace since --since ago: the issues
+opened since then, those resolved, and those still active.
+
+Every run saves a snapshot of the issues of the namespace, and compares them
+with the latest snapshot taken at least --since ago. Without snapshot that old,
+e.g. on the first run, the issues are compared with when they were detected
+and resolved instead. Snapshots are kept for 30 days.`,
+	Example: `  # Morning standup summary of the last 24 hours
+  konflux-issues diff -n team-alpha --since 24h
+
+  # What changed over the last week
+  konflux-issues diff -n team-alpha --since 1w -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		since, err := parseDuration(diffSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Fetching issues for namespace %s...\n", namespace)
+		now := time.Now()
+		issues, err := client.GetIssues(namespace, map[string]string{"limit": fmt.Sprintf("%d", diffLimit)}, nil)
+		if err != nil {
+			return err
+		}
+
+		diff := diffIssues(now.Add(-since), now, issues)
+
+		switch {
+		case quiet:
+			formatter.PrintIssueIDs(diff.New)
+		case outputFormat == "json":
+			formatter.PrintIssueDiffJSON(&diff)
+		case outputFormat == "yaml":
+			formatter.PrintIssueDiffYAML(&diff)
+		default:
+			formatter.PrintIssueDiff(&diff)
+		}
+		return nil
+	},
+}
+
+// diffIssues compares the issues of the namespace with its latest snapshot
+// taken at or before since, else with when they were detected and resolved,
+// and saves them as a snapshot unless they come from the cache only. The
+// snapshots are best effort, the diff works without them.
+func diffIssues(since, now time.Time, issues []models.Issue) models.IssueDiff {
+	dir, err := config.SnapshotDir()
+	if err != nil {
+		progressf("Could not locate the snapshots: %v\n", err)
+		return snapshot.DiffSince(namespace, since, issues)
+	}
+	store := snapshot.NewStore(dir)
+
+	// Snapshots are kept for snapshotRetention, but for the one diffed against
+	prunedBefore := now.Add(-snapshotRetention)
+	diff := snapshot.DiffSince(namespace, since, issues)
+	if previous, ok := store.Latest(namespace, since); ok {
+		diff = snapshot.Diff(previous, issues)
+		if previous.TakenAt.Before(prunedBefore) {
+			prunedBefore = previous.TakenAt
+		}
+	}
+
+	if !config.GetConfig().CacheOnly {
+		if err := store.Save(snapshot.Snapshot{Namespace: namespace, TakenAt: now, Issues: issues}); err != nil {
+			progressf("Could not save the snapshot of the issues: %v\n", err)
+		}
+		if err := store.Prune(namespace, prunedBefore); err != nil {
+			progressf("Could not remove old snapshots: %v\n", err)
+		}
+	}
+	return diff
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffSince, "since", "24h", "How far back to compare the issues, e.g. 12h, 2d or 1w")
+	diffCmd.Flags().IntVar(&diffLimit, "limit", 1000, "Maximum number of issues fetched, the most recently detected first")
+}
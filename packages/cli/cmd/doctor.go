@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/api"
+	"github.com/konflux-ci/kite/packages/cli/pkg/config"
+	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/konflux-ci/kite/packages/cli/pkg/kube"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	cliversion "github.com/konflux-ci/kite/packages/cli/pkg/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Statuses of a doctor check
+const (
+	checkOK      = "ok"
+	checkWarning = "warning"
+	checkFailed  = "failed"
+	checkSkipped = "skipped"
+)
+
+// knownConfigKeys are the keys the config file may contain
+var knownConfigKeys = []string{"api_url", "timeout", "max_retries", "cache_ttl", "port_forward", "service", "service_namespace"}
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the CLI configuration and connection to the API",
+	Long: `Check the configuration file, API URL, connectivity to the API, server
+health and version, and kubectl namespace detection.
+
+Every failed check is printed along with a suggested fix. The command exits
+with a non-zero status if any check failed.`,
+	Annotations: map[string]string{allowConfigErrorAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checks := runDoctorChecks()
+
+		if !quiet {
+			switch outputFormat {
+			case "json":
+				formatter.PrintDiagnosticsJSON(checks)
+			case "yaml":
+				formatter.PrintDiagnosticsYAML(checks)
+			default:
+				formatter.PrintDiagnostics(checks)
+			}
+		}
+
+		failed := 0
+		for _, check := range checks {
+			if check.Status == checkFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// runDoctorChecks runs every check in order. Checks that depend on the API
+// being reachable are skipped when it isn't.
+func runDoctorChecks() []models.DiagnosticCheck {
+	cfg := config.GetConfig()
+
+	checks := []models.DiagnosticCheck{
+		checkConfigFile(),
+		checkAPIURL(cfg.APIUrl),
+	}
+
+	client := api.New()
+	connectivity, health := checkConnectivity(client, cfg.APIUrl)
+	checks = append(checks, connectivity)
+	if connectivity.Status == checkFailed {
+		checks = append(checks,
+			skippedCheck("Server health", "the API is not reachable"),
+			skippedCheck("Server version", "the API is not reachable"),
+		)
+	} else {
+		checks = append(checks, checkHealth(health), checkVersion(client))
+	}
+
+	return append(checks, checkNamespace())
+}
+
+// checkConfigFile verifies the config file exists, parses, and only contains valid settings
+func checkConfigFile() models.DiagnosticCheck {
+	check := models.DiagnosticCheck{Name: "Config file"}
+
+	configDir, err := config.Dir()
+	if err != nil {
+		check.Status = checkFailed
+		check.Message = fmt.Sprintf("cannot determine home directory: %v", err)
+		check.Fix = "Make sure the HOME environment variable is set"
+		return check
+	}
+	path := filepath.Join(configDir, "config.yaml")
+
+	if configErr != nil {
+		check.Status = checkFailed
+		check.Message = configErr.Error()
+		check.Fix = fmt.Sprintf("Fix the syntax of %s, or run 'konflux-issues config reset' to restore the defaults", path)
+		return check
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%s does not exist, using default settings", path)
+		check.Fix = "Run 'konflux-issues config reset' to create it"
+		return check
+	}
+	if err != nil {
+		check.Status = checkFailed
+		check.Message = fmt.Sprintf("cannot read %s: %v", path, err)
+		check.Fix = fmt.Sprintf("Check the permissions of %s", path)
+		return check
+	}
+
+	var settings map[string]any
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		check.Status = checkFailed
+		check.Message = fmt.Sprintf("%s is not valid YAML: %v", path, err)
+		check.Fix = fmt.Sprintf("Fix the syntax of %s, or run 'konflux-issues config reset' to restore the defaults", path)
+		return check
+	}
+
+	for _, key := range []string{"timeout", "cache_ttl"} {
+		if _, err := time.ParseDuration(viper.GetString(key)); err != nil {
+			check.Status = checkFailed
+			check.Message = fmt.Sprintf("%s is not a valid duration: %q", key, viper.GetString(key))
+			check.Fix = fmt.Sprintf("Set %s to a duration such as 10s or 5m in %s", key, path)
+			return check
+		}
+	}
+
+	var unknown []string
+	for key := range settings {
+		if !slices.Contains(knownConfigKeys, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%s contains unknown settings: %s", path, strings.Join(unknown, ", "))
+		check.Fix = fmt.Sprintf("Remove them, the supported settings are: %s", strings.Join(knownConfigKeys, ", "))
+		return check
+	}
+
+	check.Status = checkOK
+	check.Message = fmt.Sprintf("loaded %s", path)
+	return check
+}
+
+// checkAPIURL verifies the API URL is well formed and its host resolves
+func checkAPIURL(apiURL string) models.DiagnosticCheck {
+	check := models.DiagnosticCheck{Name: "API URL"}
+	fix := "Run 'konflux-issues config set-api-url <url>', e.g. https://kite.example.com/api/v1"
+
+	source := "config file"
+	if os.Getenv("KONFLUX_API_URL") != "" {
+		source = "KONFLUX_API_URL"
+		fix = "Unset or correct the KONFLUX_API_URL environment variable"
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		check.Status = checkFailed
+		check.Message = fmt.Sprintf("%q (from %s) is not a valid http(s) URL", apiURL, source)
+		check.Fix = fix
+		return check
+	}
+
+	if _, err := net.LookupHost(u.Hostname()); err != nil {
+		check.Status = checkFailed
+		check.Message = fmt.Sprintf("cannot resolve host %s: %v", u.Hostname(), err)
+		check.Fix = "Check the host name in the API URL and your DNS settings. " + fix
+		return check
+	}
+
+	if !strings.HasSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v1") {
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("%s (from %s) does not end with /api/v1", apiURL, source)
+		check.Fix = "The API is served under /api/v1. " + fix
+		return check
+	}
+
+	check.Status = checkOK
+	check.Message = fmt.Sprintf("%s (from %s)", apiURL, source)
+	return check
+}
+
+// checkConnectivity verifies the API can be reached, returning its health report if it was
+func checkConnectivity(client *api.Client, apiURL string) (models.DiagnosticCheck, *models.HealthStatus) {
+	check := models.DiagnosticCheck{Name: "Connectivity"}
+
+	start := time.Now()
+	health, err := client.GetHealth()
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	switch api.KindOf(err) {
+	case "":
+		if err != nil {
+			check.Status = checkFailed
+			check.Message = err.Error()
+			return check, nil
+		}
+	case api.ErrorKindNetwork:
+		check.Status = checkFailed
+		check.Message = err.Error()
+		check.Fix = fmt.Sprintf("Make sure the KITE API is running and reachable at %s, or update the URL with 'konflux-issues config set-api-url'. "+
+			"If the API isn't exposed outside the cluster, pass --port-forward to reach it through the KITE service", apiURL)
+		return check, nil
+	default:
+		check.Status = checkFailed
+		check.Message = fmt.Sprintf("%s responded, but not like the KITE API: %v", apiURL, err)
+		check.Fix = "Make sure the API URL points at the KITE API root, e.g. https://kite.example.com/api/v1"
+		return check, nil
+	}
+
+	check.Status = checkOK
+	check.Message = fmt.Sprintf("reached %s in %s", apiURL, elapsed)
+	return check, health
+}
+
+// checkHealth verifies the server and all of its components report being healthy
+func checkHealth(health *models.HealthStatus) models.DiagnosticCheck {
+	check := models.DiagnosticCheck{Name: "Server health"}
+
+	if health.Status == "UP" {
+		check.Status = checkOK
+		check.Message = "all components are up"
+		return check
+	}
+
+	var down []string
+	for name, component := range health.Components {
+		if component.Status != "UP" {
+			down = append(down, fmt.Sprintf("%s (%s)", name, component.Message))
+		}
+	}
+	sort.Strings(down)
+
+	check.Status = checkFailed
+	check.Message = fmt.Sprintf("server reports %s", health.Status)
+	if len(down) > 0 {
+		check.Message += ": " + strings.Join(down, ", ")
+	}
+	check.Fix = "Check the KITE server logs. A failing database component means the server cannot reach its database"
+	return check
+}
+
+// checkVersion verifies the server serves an API version supported by the CLI
+func checkVersion(client *api.Client) models.DiagnosticCheck {
+	check := models.DiagnosticCheck{Name: "Server version"}
+
+	info, err := client.GetVersion()
+	if err != nil {
+		check.Status = checkFailed
+		check.Message = err.Error()
+		check.Fix = "Make sure the server runs a KITE release exposing the /version endpoint"
+		return check
+	}
+
+	check.Message = strings.TrimSpace(fmt.Sprintf("%s %s (API %s)", info.Name, info.Version, serverAPIVersion(info)))
+
+	switch cliversion.CheckAPIVersion(info.APIVersion) {
+	case cliversion.Compatible:
+		check.Status = checkOK
+	case cliversion.ServerNewer:
+		check.Status = checkFailed
+		check.Fix = fmt.Sprintf("Upgrade the CLI, this version (%s) only supports API %s", cliversion.Version, cliversion.APIVersion)
+	default:
+		check.Status = checkFailed
+		check.Fix = fmt.Sprintf("Use a CLI release supporting the server API, this version (%s) only supports API %s", cliversion.Version, cliversion.APIVersion)
+	}
+	return check
+}
+
+// checkNamespace verifies a namespace can be determined for commands that need one
+func checkNamespace() models.DiagnosticCheck {
+	check := models.DiagnosticCheck{Name: "Namespace detection"}
+	fix := "Pass --namespace/-n, or set a namespace for your kubectl context with 'kubectl config set-context --current --namespace=<namespace>'"
+
+	if namespace != "" {
+		check.Status = checkOK
+		check.Message = fmt.Sprintf("using %s from --namespace", namespace)
+		return check
+	}
+
+	opts := kubeOptions()
+	detected, err := kube.CurrentNamespace(opts)
+	if errors.Is(err, kube.ErrNoKubeconfig) {
+		check.Status = checkWarning
+		check.Message = "no kubeconfig found, so the namespace cannot be detected"
+		check.Fix = "Pass --namespace/-n to every command, or point KUBECONFIG (or --kubeconfig) at your kubeconfig"
+		return check
+	}
+	if err != nil {
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("cannot read the kubeconfig: %v", err)
+		check.Fix = fix
+		return check
+	}
+
+	kubeContext, err := kube.CurrentContext(opts)
+	if err != nil {
+		// Running in a pod, the namespace comes from its service account
+		check.Status = checkOK
+		check.Message = fmt.Sprintf("using %s from the in-cluster service account", detected)
+		return check
+	}
+
+	if detected == "default" {
+		check.Status = checkWarning
+		check.Message = fmt.Sprintf("kubeconfig context %s has no namespace, 'default' will be used", kubeContext)
+		check.Fix = fix
+		return check
+	}
+
+	check.Status = checkOK
+	check.Message = fmt.Sprintf("using %s from kubeconfig context %s", detected, kubeContext)
+	return check
+}
+
+// skippedCheck creates a check that was not run
+func skippedCheck(name, reason string) models.DiagnosticCheck {
+	return models.DiagnosticCheck{
+		Name:    name,
+		Status:  checkSkipped,
+		Message: "skipped because " + reason,
+	}
+}
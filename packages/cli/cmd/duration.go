@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseDuration parses a duration the way people write them, Go durations
+// such as 4h or 90m, as well as days and weeks such as 2d or 1w
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.ParseFloat(number, 64)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid duration %q, expected e.g. 30m, 4h, 2d or 1w", value)
+			}
+			return time.Duration(n * float64(unit)), nil
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected e.g. 30m, 4h, 2d or 1w", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/api"
+)
+
+// Exit codes returned by the CLI so scripts can branch on the type of failure
+const (
+	ExitOK           = 0
+	ExitError        = 1 // General or usage error
+	ExitNotFound     = 3 // The requested issue (or resource) does not exist
+	ExitAPIError     = 4 // The API returned an error response
+	ExitNetworkError = 5 // The API could not be reached
+)
+
+// ExitCode maps an error returned by Execute to the process exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	switch api.KindOf(err) {
+	case api.ErrorKindNotFound:
+		return ExitNotFound
+	case api.ErrorKindAPI:
+		return ExitAPIError
+	case api.ErrorKindNetwork:
+		return ExitNetworkError
+	default:
+		return ExitError
+	}
+}
+
+// errorOutput is the structured form of an error printed with -o json
+type errorOutput struct {
+	Error      string `json:"error"`
+	Kind       string `json:"kind"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	ExitCode   int    `json:"exitCode"`
+}
+
+// PrintError writes err to stderr, as JSON if JSON output was requested
+func PrintError(err error) {
+	if err == nil {
+		return
+	}
+
+	if outputFormat != "json" {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+
+	out := errorOutput{
+		Error:    err.Error(),
+		Kind:     string(api.KindOf(err)),
+		ExitCode: ExitCode(err),
+	}
+	if out.Kind == "" {
+		out.Kind = "error"
+	}
+
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) {
+		out.StatusCode = apiErr.StatusCode
+	}
+
+	data, marshalErr := json.MarshalIndent(out, "", " ")
+	if marshalErr != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
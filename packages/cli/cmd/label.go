@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/spf13/cobra"
+)
+
+var removeLabels []string
+
+// labelCmd represents the label command
+var labelCmd = &cobra.Command{
+	Use:   "label [key=value...]",
+	Short: "Set or remove labels of an issue",
+	Long: `Set labels of an issue as key=value arguments, replacing the value of labels
+already set, and remove labels by key with --remove. The other labels of the
+issue are kept.`,
+	Example: `  konflux-issues label -n team-alpha -i <issue-id> team=build-infra tier=frontend
+  konflux-issues label -n team-alpha -i <issue-id> --remove tier
+  konflux-issues list -n team-alpha --label team=build-infra`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		if len(args) == 0 && len(removeLabels) == 0 {
+			return errors.New("no labels to set or remove, pass key=value arguments or --remove")
+		}
+		set := map[string]string{}
+		for _, arg := range args {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid label %q, expected key=value", arg)
+			}
+			set[key] = value
+		}
+		for _, key := range removeLabels {
+			if _, ok := set[key]; ok {
+				return fmt.Errorf("label %q can't be both set and removed", key)
+			}
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Updating the labels of issue %s in namespace %s...\n", issueID, namespace)
+		issue, err := client.UpdateIssueLabels(issueID, namespace, set, removeLabels)
+		if err != nil {
+			return fmt.Errorf("error updating issue labels: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(issueID)
+			return nil
+		}
+		if len(issue.Labels) == 0 {
+			fmt.Printf("Issue %s has no labels anymore.\n", issueID)
+			return nil
+		}
+		fmt.Printf("Issue %s is now labeled %s.\n", issueID, formatter.FormatLabels(issue.Labels))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(labelCmd)
+
+	labelCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
+	labelCmd.MarkFlagRequired("id")
+	labelCmd.Flags().StringArrayVar(&removeLabels, "remove", nil, "Key of a label to remove (can be repeated)")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/api"
+	"github.com/konflux-ci/kite/packages/cli/pkg/config"
+	"github.com/konflux-ci/kite/packages/cli/pkg/kube"
+	"github.com/konflux-ci/kite/packages/cli/pkg/version"
+	"github.com/spf13/cobra"
+	terminal "golang.org/x/term"
+)
+
+// activePortForward is the port-forward requests are routed through, if any
+var activePortForward *kube.PortForward
+
+func init() {
+	cobra.OnFinalize(func() {
+		if activePortForward != nil {
+			activePortForward.Close()
+		}
+	})
+}
+
+// selectClient returns a client for the configured API, or for a
+// port-forward to the KITE service when --port-forward is set. When the
+// configured API can't be reached from an interactive terminal, the user is
+// offered to port-forward instead.
+func selectClient() (*api.Client, error) {
+	cfg := config.GetConfig()
+	if cfg.CacheOnly {
+		return api.New(), nil
+	}
+	if cfg.PortForward {
+		return portForwardClient(cfg)
+	}
+
+	client := api.New()
+	if _, err := client.GetVersion(); api.KindOf(err) == api.ErrorKindNetwork && confirmPortForward(cfg) {
+		return portForwardClient(cfg)
+	}
+	return client, nil
+}
+
+// portForwardClient starts a port-forward to the KITE service and returns a
+// client sending requests through it
+func portForwardClient(cfg config.Config) (*api.Client, error) {
+	pf, err := kube.StartPortForward(kubeOptions(), cfg.ServiceNS, cfg.Service)
+	if err != nil {
+		return nil, fmt.Errorf("cannot port-forward to the KITE service: %w", err)
+	}
+	activePortForward = pf
+	progressf("Forwarding 127.0.0.1:%d to pod %s/%s\n", pf.LocalPort, cfg.ServiceNS, pf.Pod)
+
+	// Keep the path of the configured URL, the service serves the API under the same path
+	path := "/api/" + version.APIVersion
+	if u, err := url.Parse(cfg.APIUrl); err == nil && u.Path != "" {
+		path = u.Path
+	}
+	return api.NewForURL(fmt.Sprintf("http://127.0.0.1:%d%s", pf.LocalPort, path)), nil
+}
+
+// confirmPortForward asks whether to port-forward to the KITE service. It
+// never asks when not running in a terminal or without a kubeconfig context.
+func confirmPortForward(cfg config.Config) bool {
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) || !terminal.IsTerminal(int(os.Stderr.Fd())) {
+		return false
+	}
+	kubeContext, err := kube.CurrentContext(kubeOptions())
+	if err != nil {
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "The API at %s is unreachable. Port-forward to service %s/%s in context %s instead? [y/N] ",
+		cfg.APIUrl, cfg.ServiceNS, cfg.Service, kubeContext)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// prioritizeCmd represents the prioritize command
+var prioritizeCmd = &cobra.Command{
+	Use:   "prioritize <P1|P2|P3|P4>",
+	Short: "Set the priority of an issue",
+	Long: `Set the priority of an issue, P1 being the most urgent.
+
+Severity describes the impact of an issue, while priority records the triage
+decision of the team owning it. Both are set independently.`,
+	Example: `  konflux-issues prioritize -n team-alpha -i <issue-id> P1`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		newPriority := strings.ToUpper(args[0])
+		if !slices.Contains(validPriorities, newPriority) {
+			return fmt.Errorf("invalid priority %q, must be one of: %s", args[0], strings.Join(validPriorities, ", "))
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Setting the priority of issue %s in namespace %s...\n", issueID, namespace)
+		if _, err := client.SetIssuePriority(issueID, namespace, newPriority); err != nil {
+			return fmt.Errorf("error setting issue priority: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(issueID)
+			return nil
+		}
+		fmt.Printf("Issue %s is now %s.\n", issueID, newPriority)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(prioritizeCmd)
+
+	prioritizeCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
+	prioritizeCmd.MarkFlagRequired("id")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFormat string
+	reportSince  string
+	reportTop    int
+	reportLimit  int
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a shareable summary of the issues of a namespace",
+	Long: `Generate a summary of the issues of a namespace over --since, in Markdown to
+paste in chats and docs, or in HTML: the counts of active issues by severity,
+the SLA breaches, the issues opened and resolved over the period and their
+mean time to resolve (MTTR), the active critical issues, the resources with
+the most issues over the period and those whose issues keep being reopened.
+
+The counts of active issues come from the dashboard of the namespace, the
+other figures from the --limit issues detected and resolved over the period.`,
+	Example: `  # Weekly summary to paste in Slack
+  konflux-issues report -n team-alpha --format markdown
+
+  # Monthly summary published as a web page
+  konflux-issues report -n team-alpha --since 30d --format html > report.html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		if !slices.Contains(report.Formats(), reportFormat) {
+			return fmt.Errorf("invalid --format value %q, must be one of: %s", reportFormat, strings.Join(report.Formats(), ", "))
+		}
+		period, err := parseDuration(reportSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		if reportTop < 1 {
+			return fmt.Errorf("invalid --top value %d, must be at least 1", reportTop)
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		since := now.Add(-period)
+		limit := fmt.Sprintf("%d", reportLimit)
+
+		progressf("Fetching the dashboard of namespace %s...\n", namespace)
+		dashboard, err := client.GetDashboard(namespace)
+		if err != nil {
+			return err
+		}
+
+		progressf("Fetching issues for namespace %s...\n", namespace)
+		critical, err := client.GetIssues(namespace, map[string]string{"severity": "critical", "limit": limit}, nil)
+		if err != nil {
+			return err
+		}
+		detected, err := client.GetIssues(namespace, map[string]string{"detectedAfter": since.UTC().Format(time.RFC3339), "limit": limit}, nil)
+		if err != nil {
+			return err
+		}
+		resolved, err := client.GetIssues(namespace, map[string]string{"resolvedAfter": since.UTC().Format(time.RFC3339), "limit": limit}, nil)
+		if err != nil {
+			return err
+		}
+		if len(detected) == reportLimit || len(resolved) == reportLimit {
+			progressf("Only the %d most recently detected issues are reported on, raise --limit to include more\n", reportLimit)
+		}
+
+		r := report.Build(namespace, since, now, report.Data{
+			Dashboard: dashboard,
+			Critical:  critical,
+			Detected:  detected,
+			Resolved:  resolved,
+		}, reportTop)
+		return report.Render(os.Stdout, r, reportFormat)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVar(&reportFormat, "format", report.FormatMarkdown, "Format of the report ("+strings.Join(report.Formats(), ", ")+")")
+	reportCmd.Flags().StringVar(&reportSince, "since", "7d", "Period reported on, e.g. 24h, 7d or 4w")
+	reportCmd.Flags().IntVar(&reportTop, "top", 5, "Number of top offenders listed")
+	reportCmd.Flags().IntVar(&reportLimit, "limit", 1000, "Maximum number of issues fetched for each figure, the most recently detected first")
+}
@@ -2,35 +2,93 @@ package cmd
 
 import (
 	"fmt"
-	"os/exec"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/konflux-ci/kite/packages/cli/pkg/api"
+	"github.com/konflux-ci/kite/packages/cli/pkg/cache"
 	"github.com/konflux-ci/kite/packages/cli/pkg/config"
 	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/konflux-ci/kite/packages/cli/pkg/kube"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	"github.com/konflux-ci/kite/packages/cli/pkg/selector"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile      string
-	namespace    string
-	issueType    string
-	severity     string
-	state        string
-	resourceType string
-	limit        int
-	issueID      string
-	term         string
-	outputFormat string
-	unresolved   bool
+	cfgFile           string
+	namespace         string
+	issueType         string
+	severity          string
+	state             string
+	resourceType      string
+	resourceNamespace string
+	limit             int
+	issueID           string
+	term              string
+	outputFormat      string
+	unresolved        bool
+	unacknowledged    bool
+	hideSnoozed       bool
+	noColor           bool
+	quiet             bool
+	strict            bool
+	groupBy           string
+	kubeconfig        string
+	kubeContext       string
+	assignee          string
+	labels            []string
+	priority          string
+	sortBy            string
+	reason            string
+	resolvedBy        string
+	acknowledgedBy    string
+	view              string
+	selectorExpr      string
+
+	// configErr is the error encountered while initializing the configuration
+	configErr error
 )
 
+// allowConfigErrorAnnotation marks commands that can run with a broken configuration
+const allowConfigErrorAnnotation = "allowConfigError"
+
+// validGroupBy lists the values accepted by --group-by
+var validGroupBy = []string{"resource", "type", "severity"}
+
+// validPriorities lists the values accepted by --priority
+var validPriorities = []string{"P1", "P2", "P3", "P4"}
+
+// validSortBy lists the values accepted by --sort
+var validSortBy = []string{"detectedAt", "priority"}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "konflux-issues",
 	Short: "CLI tool for managing Konflux issues",
 	Long: `A command-line interface for managing Konflux issues.
 This tool allows you to list, filter, and get details about issues in Konflux.`,
+	// Errors are printed by PrintError so they can be formatted as JSON
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Flags and arguments parsed fine, so don't print usage for runtime errors
+		cmd.SilenceUsage = true
+
+		// Respect https://no-color.org/ as well as the --no-color flag
+		if noColor || os.Getenv("NO_COLOR") != "" {
+			color.NoColor = true
+		}
+
+		if configErr != nil && cmd.Annotations[allowConfigErrorAnnotation] != "true" {
+			return fmt.Errorf("error initializing config: %w (run 'konflux-issues doctor' for help)", configErr)
+		}
+		return nil
+	},
 }
 
 // listCmd represents the list command
@@ -39,13 +97,8 @@ var listCmd = &cobra.Command{
 	Short: "List issues for a namespace",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no namespace provided, try to get from kubectl context
-		if namespace == "" {
-			kubectlNamespace, err := getCurrentKubeNamespace()
-			if err == nil {
-				namespace = kubectlNamespace
-			} else {
-				return fmt.Errorf("namespace is required")
-			}
+		if err := requireNamespace(); err != nil {
+			return err
 		}
 
 		// Apply unresolved filter if requested
@@ -53,40 +106,75 @@ var listCmd = &cobra.Command{
 			state = "ACTIVE"
 		}
 
+		if err := validateListFilters(); err != nil {
+			return err
+		}
+		sel, err := parseSelector()
+		if err != nil {
+			return err
+		}
+
 		// Create API client
-		client := api.New()
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
 
 		// Build filters
 		filters := map[string]string{
-			"limit":        fmt.Sprintf("%d", limit),
-			"issueType":    issueType,
-			"severity":     severity,
-			"state":        state,
-			"resourceType": resourceType,
+			"limit":             fmt.Sprintf("%d", limit),
+			"issueType":         issueType,
+			"severity":          severity,
+			"priority":          strings.ToUpper(priority),
+			"state":             state,
+			"resourceType":      resourceType,
+			"resourceNamespace": resourceNamespace,
+			"assignee":          assignee,
+			"sort":              sortBy,
+			"view":              view,
+			"acknowledged":      acknowledgedFilter(),
+			"snoozed":           snoozedFilter(),
 		}
 
-		// Get issues
-		fmt.Printf("Fetching issues for namespace %s...\n", namespace)
-		issues, err := client.GetIssues(namespace, filters)
-		if err != nil {
-			return err
-		}
+		emptyMessage := fmt.Sprintf("No issues found in namespace %s with the specified filters.", namespace)
+
+		// Get issues grouped by resource, type or severity
+		if groupBy != "" {
+			if !slices.Contains(validGroupBy, groupBy) {
+				return fmt.Errorf("invalid --group-by value %q, must be one of: %s", groupBy, strings.Join(validGroupBy, ", "))
+			}
 
-		// Handle empty result
-		if len(issues) == 0 {
-			fmt.Printf("No issues found in namespace %s with the specified filters.\n", namespace)
+			progressf("Fetching issues for namespace %s grouped by %s...\n", namespace, groupBy)
+			if sel != nil {
+				issues, err := getSelectedIssues(client, filters, sel)
+				if err != nil {
+					return err
+				}
+				printIssueGroups(groupIssues(issues, groupBy), emptyMessage)
+				return nil
+			}
+			grouped, err := client.GetIssuesGrouped(namespace, groupBy, filters, labels)
+			if err != nil {
+				return err
+			}
+
+			printIssueGroups(grouped, emptyMessage)
 			return nil
 		}
 
-		// Print issues based on output format
-		if outputFormat == "json" {
-			formatter.PrintIssuesJSON(issues)
-		} else if outputFormat == "yaml" {
-			formatter.PrintIssuesYAML(issues)
+		// Get issues
+		progressf("Fetching issues for namespace %s...\n", namespace)
+		var issues []models.Issue
+		if sel != nil {
+			issues, err = getSelectedIssues(client, filters, sel)
 		} else {
-			formatter.PrintIssuesTable(issues)
+			issues, err = client.GetIssues(namespace, filters, labels)
+		}
+		if err != nil {
+			return err
 		}
 
+		printIssues(issues, emptyMessage)
 		return nil
 	},
 }
@@ -97,13 +185,8 @@ var detailsCmd = &cobra.Command{
 	Short: "Get details for a specific issue",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no namespace provided, try to get from kubectl context
-		if namespace == "" {
-			kubectlNamespace, err := getCurrentKubeNamespace()
-			if err == nil {
-				namespace = kubectlNamespace
-			} else {
-				return fmt.Errorf("namespace is required")
-			}
+		if err := requireNamespace(); err != nil {
+			return err
 		}
 
 		// Check for issue ID
@@ -112,17 +195,22 @@ var detailsCmd = &cobra.Command{
 		}
 
 		// Create API client
-		client := api.New()
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
 
 		// Get issue details
-		fmt.Printf("Fetching details for issue %s in namespace %s...\n", issueID, namespace)
+		progressf("Fetching details for issue %s in namespace %s...\n", issueID, namespace)
 		issue, err := client.GetIssueDetails(issueID, namespace)
 		if err != nil {
 			return err
 		}
 
 		// Print issues based on output format
-		if outputFormat == "json" {
+		if quiet {
+			fmt.Println(issue.ID)
+		} else if outputFormat == "json" {
 			formatter.PrintIssuesDetailsJSON(issue)
 		} else if outputFormat == "yaml" {
 			formatter.PrintIssueDetailsYAML(issue)
@@ -139,13 +227,8 @@ var resolveCmd = &cobra.Command{
 	Short: "Resolve a specific issue",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no namespace provided, try to get from kubectl context
-		if namespace == "" {
-			kubectlNamespace, err := getCurrentKubeNamespace()
-			if err == nil {
-				namespace = kubectlNamespace
-			} else {
-				return fmt.Errorf("namespace is required")
-			}
+		if err := requireNamespace(); err != nil {
+			return err
 		}
 
 		// Check for issue ID
@@ -154,14 +237,20 @@ var resolveCmd = &cobra.Command{
 		}
 
 		// Create API client
-		client := api.New()
-
-		fmt.Printf("Resolving issue %s in namespace %s...\n", issueID, namespace)
-		err := client.ResolveIssue(issueID, namespace)
+		client, err := newClient()
 		if err != nil {
+			return err
+		}
+
+		progressf("Resolving issue %s in namespace %s...\n", issueID, namespace)
+		if err := client.ResolveIssue(issueID, namespace, reason, resolvedBy); err != nil {
 			return fmt.Errorf("error resolving issue: %w", err)
 		}
 
+		if quiet {
+			fmt.Println(issueID)
+			return nil
+		}
 		fmt.Printf("Issue %s has been resolved successfully.\n", issueID)
 		return nil
 	},
@@ -173,29 +262,42 @@ var searchCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no namespace provided, try to get from kubectl context
-		if namespace == "" {
-			kubectlNamespace, err := getCurrentKubeNamespace()
-			if err == nil {
-				namespace = kubectlNamespace
-			} else {
-				return fmt.Errorf("namespace is required")
-			}
+		if err := requireNamespace(); err != nil {
+			return err
 		}
 
 		// Get term from args
 		term := args[0]
 
+		if err := validateListFilters(); err != nil {
+			return err
+		}
+		sel, err := parseSelector()
+		if err != nil {
+			return err
+		}
+
 		// Create API client
-		client := api.New()
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
 
 		// Build filters
 		filters := map[string]string{
-			"limit":        fmt.Sprintf("%d", limit),
-			"issueType":    issueType,
-			"severity":     severity,
-			"state":        state,
-			"resourceType": resourceType,
-			"search":       term,
+			"limit":             fmt.Sprintf("%d", limit),
+			"issueType":         issueType,
+			"severity":          severity,
+			"priority":          strings.ToUpper(priority),
+			"state":             state,
+			"resourceType":      resourceType,
+			"resourceNamespace": resourceNamespace,
+			"assignee":          assignee,
+			"sort":              sortBy,
+			"view":              view,
+			"search":            term,
+			"acknowledged":      acknowledgedFilter(),
+			"snoozed":           snoozedFilter(),
 		}
 
 		// Apply unresolved filter if requested
@@ -204,27 +306,18 @@ var searchCmd = &cobra.Command{
 		}
 
 		// Search for issues
-		fmt.Printf("Searching for issues with term'%s' in namespace %s...\n", term, namespace)
-		issues, err := client.GetIssues(namespace, filters)
+		progressf("Searching for issues with term '%s' in namespace %s...\n", term, namespace)
+		var issues []models.Issue
+		if sel != nil {
+			issues, err = getSelectedIssues(client, filters, sel)
+		} else {
+			issues, err = client.GetIssues(namespace, filters, labels)
+		}
 		if err != nil {
 			return fmt.Errorf("error searching issues: %w", err)
 		}
 
-		// Handle empty result
-		if len(issues) == 0 {
-			fmt.Printf("No issues found for term '%s' in namespace %s.\n", term, namespace)
-			return nil
-		}
-
-		// Print issues based on output format
-		if outputFormat == "json" {
-			formatter.PrintIssuesJSON(issues)
-		} else if outputFormat == "yaml" {
-			formatter.PrintIssuesYAML(issues)
-		} else {
-			formatter.PrintIssuesTable(issues)
-		}
-
+		printIssues(issues, fmt.Sprintf("No issues found for term '%s' in namespace %s.", term, namespace))
 		return nil
 	},
 }
@@ -238,6 +331,11 @@ var configCmd = &cobra.Command{
 		cfg := config.GetConfig()
 		fmt.Println("Current configuration:")
 		fmt.Printf("API URL: %s\n", cfg.APIUrl)
+		fmt.Printf("Timeout: %s\n", cfg.Timeout)
+		fmt.Printf("Max Retries: %d\n", cfg.MaxRetries)
+		fmt.Printf("Cache TTL: %s\n", cfg.CacheTTL)
+		fmt.Printf("Service: %s/%s\n", cfg.ServiceNS, cfg.Service)
+		fmt.Printf("Port-forward: %t\n", cfg.PortForward)
 	},
 }
 
@@ -269,6 +367,28 @@ var resetConfigCmd = &cobra.Command{
 	},
 }
 
+// clearCacheCmd represents the config clear-cache command
+var clearCacheCmd = &cobra.Command{
+	Use:   "clear-cache",
+	Short: "Remove all locally cached API responses",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cacheDir, err := config.CacheDir()
+		if err != nil {
+			return err
+		}
+		if err := cache.New(cacheDir, 0).Clear(); err != nil {
+			return err
+		}
+		fmt.Println("Cache cleared")
+		return nil
+	},
+}
+
+// SetConfigError records an error encountered while initializing the configuration
+func SetConfigError(err error) {
+	configErr = err
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
 	return rootCmd.Execute()
@@ -284,18 +404,44 @@ func init() {
 
 	configCmd.AddCommand(setAPIURLCmd)
 	configCmd.AddCommand(resetConfigCmd)
+	configCmd.AddCommand(clearCacheCmd)
 
 	// Add common flags for all commands
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "Namespace to check")
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file used to detect the namespace (defaults to KUBECONFIG or ~/.kube/config)")
+	rootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "Kubeconfig context used to detect the namespace (defaults to the current context)")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json, yaml)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colorized output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print issue IDs")
+	rootCmd.PersistentFlags().Duration("timeout", config.DefaultTimeout, "Timeout for each API request")
+	rootCmd.PersistentFlags().Int("retries", config.DefaultMaxRetries, "Maximum number of retries for failed API requests")
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Refuse to run against servers with an unsupported API version")
+	rootCmd.PersistentFlags().Bool("cached", false, "Only use locally cached data, never contact the API")
+	rootCmd.PersistentFlags().Bool("port-forward", false, "Reach the API through a port-forward to the KITE service in the cluster")
+
+	// Flags override the values from the config file and environment
+	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("retries"))
+	viper.BindPFlag("cached", rootCmd.PersistentFlags().Lookup("cached"))
+	viper.BindPFlag("port_forward", rootCmd.PersistentFlags().Lookup("port-forward"))
 
 	// Add list command flags
 	listCmd.Flags().StringVarP(&issueType, "type", "t", "", "Filter by issue type")
 	listCmd.Flags().StringVarP(&severity, "severity", "s", "", "Filter by severity")
 	listCmd.Flags().StringVar(&state, "state", "", "Filter by state (ACTIVE or RESOLVED)")
 	listCmd.Flags().StringVarP(&resourceType, "resource-type", "r", "", "Filter by resource type")
+	listCmd.Flags().StringVar(&resourceNamespace, "resource-namespace", "", "Filter by the namespace of the resource, when it differs from the issue's")
 	listCmd.Flags().IntVar(&limit, "limit", 20, "Limit number of results")
 	listCmd.Flags().BoolVar(&unresolved, "unresolved", false, "Show only unresolved issues")
+	listCmd.Flags().BoolVar(&unacknowledged, "unacknowledged", false, "Show only issues nobody acknowledged yet")
+	listCmd.Flags().BoolVar(&hideSnoozed, "hide-snoozed", false, "Hide the issues snoozed until later")
+	listCmd.Flags().StringVar(&groupBy, "group-by", "", "Group issues by resource, type or severity")
+	listCmd.Flags().StringArrayVarP(&labels, "label", "l", nil, "Filter by label as key=value (can be repeated, issues must match all)")
+	listCmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee")
+	listCmd.Flags().StringVar(&priority, "priority", "", "Filter by priority (P1, P2, P3 or P4)")
+	listCmd.Flags().StringVar(&sortBy, "sort", "", "Sort issues by detectedAt (default) or priority")
+	listCmd.Flags().StringVar(&view, "view", "", "Apply the filters of a saved view, overridden by the other flags (see 'konflux-issues views')")
+	listCmd.Flags().StringVar(&selectorExpr, "selector", "", selectorUsage)
 
 	// Add details command flags
 	detailsCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
@@ -304,28 +450,232 @@ func init() {
 	// Add resolve command flags
 	resolveCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
 	resolveCmd.MarkFlagRequired("id")
+	resolveCmd.Flags().StringVar(&reason, "reason", "", "Why the issue is resolved")
+	resolveCmd.Flags().StringVar(&resolvedBy, "resolved-by", "", "Who resolved the issue")
 
 	// Add search command flags
 	searchCmd.Flags().StringVarP(&issueType, "type", "t", "", "Filter by issue type")
 	searchCmd.Flags().StringVarP(&severity, "severity", "s", "", "Filter by severity")
 	searchCmd.Flags().StringVar(&state, "state", "", "Filter by state (ACTIVE or RESOLVED)")
 	searchCmd.Flags().StringVarP(&resourceType, "resource-type", "r", "", "Filter by resource type")
+	searchCmd.Flags().StringVar(&resourceNamespace, "resource-namespace", "", "Filter by the namespace of the resource, when it differs from the issue's")
 	searchCmd.Flags().IntVar(&limit, "limit", 20, "Limit number of results")
 	searchCmd.Flags().BoolVarP(&unresolved, "unresolved", "u", false, "Show only unresolved issues")
+	searchCmd.Flags().BoolVar(&unacknowledged, "unacknowledged", false, "Show only issues nobody acknowledged yet")
+	searchCmd.Flags().BoolVar(&hideSnoozed, "hide-snoozed", false, "Hide the issues snoozed until later")
+	searchCmd.Flags().StringArrayVarP(&labels, "label", "l", nil, "Filter by label as key=value (can be repeated, issues must match all)")
+	searchCmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee")
+	searchCmd.Flags().StringVar(&priority, "priority", "", "Filter by priority (P1, P2, P3 or P4)")
+	searchCmd.Flags().StringVar(&sortBy, "sort", "", "Sort issues by detectedAt (default) or priority")
+	searchCmd.Flags().StringVar(&view, "view", "", "Apply the filters of a saved view, overridden by the other flags (see 'konflux-issues views')")
+	searchCmd.Flags().StringVar(&selectorExpr, "selector", "", selectorUsage)
+}
+
+// progressf prints a progress message to stderr so it never pollutes
+// machine-readable output on stdout. Nothing is printed in quiet mode.
+func progressf(format string, args ...any) {
+	if quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format, args...)
 }
 
-// getCurrentKubeNamespace attempts to get the current namespace from kubectl context
-func getCurrentKubeNamespace() (string, error) {
-	cmd := exec.Command("kubectl", "config", "view", "--minify", "--output", "jsonpath={..namespace}")
-	output, err := cmd.Output()
+// printIssues prints issues using the selected output format.
+// emptyMessage is shown instead of a table when no issues were found.
+func printIssues(issues []models.Issue, emptyMessage string) {
+	if issues == nil {
+		issues = []models.Issue{}
+	}
+
+	switch {
+	case quiet:
+		formatter.PrintIssueIDs(issues)
+	case outputFormat == "json":
+		formatter.PrintIssuesJSON(issues)
+	case outputFormat == "yaml":
+		formatter.PrintIssuesYAML(issues)
+	case len(issues) == 0:
+		fmt.Println(emptyMessage)
+	default:
+		formatter.PrintIssuesTable(issues)
+	}
+}
+
+// newClient creates an API client, routed through a port-forward to the KITE
+// service if needed, after checking the server is compatible with the CLI
+func newClient() (*api.Client, error) {
+	client, err := selectClient()
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if err := checkServerCompatibility(client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// printIssueGroups prints grouped issues using the selected output format.
+// emptyMessage is shown instead of tables when no issues were found.
+func printIssueGroups(grouped *models.GroupedIssuesResponse, emptyMessage string) {
+	if grouped.Groups == nil {
+		grouped.Groups = []models.IssueGroup{}
+	}
+
+	switch {
+	case quiet:
+		for _, group := range grouped.Groups {
+			formatter.PrintIssueIDs(group.Issues)
+		}
+	case outputFormat == "json":
+		formatter.PrintIssueGroupsJSON(grouped)
+	case outputFormat == "yaml":
+		formatter.PrintIssueGroupsYAML(grouped)
+	case len(grouped.Groups) == 0:
+		fmt.Println(emptyMessage)
+	default:
+		formatter.PrintIssueGroups(grouped)
+	}
+}
+
+// selectorUsage is the usage of the --selector flag of list and search
+const selectorUsage = `Filter the issues client-side with an expression, e.g. 'severity in (major,critical) && resource =~ "frontend.*"', --limit counting the matching issues`
+
+// parseSelector parses the --selector expression, nil when none was given
+func parseSelector() (*selector.Selector, error) {
+	if selectorExpr == "" {
+		return nil, nil
+	}
+	return selector.Parse(selectorExpr)
+}
+
+// selectorPageSize is the number of issues fetched by request when paging
+// through the issues for a selector
+const selectorPageSize = 100
+
+// getSelectedIssues pages through the issues matching the API filters,
+// keeping those matching the selector, until --limit of them are found or
+// there are no more issues. The selector so applies before --limit.
+func getSelectedIssues(client *api.Client, filters map[string]string, sel *selector.Selector) ([]models.Issue, error) {
+	page := maps.Clone(filters)
+	page["limit"] = strconv.Itoa(selectorPageSize)
+
+	var selected []models.Issue
+	for offset := 0; ; offset += selectorPageSize {
+		page["offset"] = strconv.Itoa(offset)
+		issues, err := client.GetIssues(namespace, page, labels)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, sel.Filter(issues)...)
+		if limit > 0 && len(selected) >= limit {
+			return selected[:limit], nil
+		}
+		if len(issues) < selectorPageSize {
+			return selected, nil
+		}
+	}
+}
+
+// severityOrder orders the severity groups, most severe first, as the API does
+var severityOrder = []string{"critical", "major", "minor", "info"}
+
+// severityRank returns the rank of a severity in severityOrder, unknown
+// severities last
+func severityRank(severity string) int {
+	if i := slices.Index(severityOrder, severity); i >= 0 {
+		return i
+	}
+	return len(severityOrder)
+}
+
+// groupIssues groups issues by resource, type or severity the way the API
+// does, keeping their order within the groups
+func groupIssues(issues []models.Issue, groupBy string) *models.GroupedIssuesResponse {
+	key := func(issue models.Issue) string {
+		switch groupBy {
+		case "type":
+			return issue.IssueType
+		case "severity":
+			return issue.Severity
+		}
+		return issue.Scope.ResourceType + "/" + issue.Scope.ResourceName
 	}
 
-	namespace := strings.TrimSpace(string(output))
-	if namespace == "" {
-		namespace = "default"
+	byKey := map[string]*models.IssueGroup{}
+	for _, issue := range issues {
+		group, ok := byKey[key(issue)]
+		if !ok {
+			group = &models.IssueGroup{Key: key(issue)}
+			byKey[group.Key] = group
+		}
+		group.Issues = append(group.Issues, issue)
+		group.Count++
 	}
 
-	return namespace, nil
+	grouped := &models.GroupedIssuesResponse{GroupBy: groupBy, Total: int64(len(issues))}
+	for _, group := range byKey {
+		grouped.Groups = append(grouped.Groups, *group)
+	}
+	slices.SortFunc(grouped.Groups, func(a, b models.IssueGroup) int {
+		if groupBy == "severity" {
+			return severityRank(a.Key) - severityRank(b.Key)
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	return grouped
+}
+
+// acknowledgedFilter returns the acknowledged filter of the --unacknowledged flag
+func acknowledgedFilter() string {
+	if unacknowledged {
+		return "false"
+	}
+	return ""
+}
+
+// snoozedFilter returns the snoozed filter of the --hide-snoozed flag
+func snoozedFilter() string {
+	if hideSnoozed {
+		return "false"
+	}
+	return ""
+}
+
+// validateListFilters checks the --label, --priority and --sort values of list and search
+func validateListFilters() error {
+	if priority != "" && !slices.Contains(validPriorities, strings.ToUpper(priority)) {
+		return fmt.Errorf("invalid --priority value %q, must be one of: %s", priority, strings.Join(validPriorities, ", "))
+	}
+	if sortBy != "" && !slices.Contains(validSortBy, sortBy) {
+		return fmt.Errorf("invalid --sort value %q, must be one of: %s", sortBy, strings.Join(validSortBy, ", "))
+	}
+	for _, label := range labels {
+		if key, _, ok := strings.Cut(label, "="); !ok || key == "" {
+			return fmt.Errorf("invalid --label value %q, expected key=value", label)
+		}
+	}
+	return nil
+}
+
+// requireNamespace falls back to the namespace of the current kubeconfig
+// context when no namespace was provided
+func requireNamespace() error {
+	if namespace != "" {
+		return nil
+	}
+
+	kubeNamespace, err := kube.CurrentNamespace(kubeOptions())
+	if err != nil {
+		return fmt.Errorf("namespace is required: pass --namespace or select a kubeconfig context (%w)", err)
+	}
+	namespace = kubeNamespace
+	return nil
+}
+
+// kubeOptions returns the kubeconfig and context selected with --kubeconfig and --context
+func kubeOptions() kube.Options {
+	return kube.Options{
+		Kubeconfig: kubeconfig,
+		Context:    kubeContext,
+	}
 }
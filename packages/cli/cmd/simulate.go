@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	simulatedPipeline string
+	simulatedReason   string
+	simulatedSeverity string
+	simulatedRunID    string
+	simulatedLogsURL  string
+)
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Send simulated pipeline events to the webhook endpoints",
+	Long: `Send well-formed pipeline events to the KITE webhook endpoints, the same way
+a real integration would.
+
+This verifies a KITE deployment end-to-end without having to break a real
+pipeline. Simulate a failure to create an issue, then a success for the same
+pipeline to resolve it.`,
+}
+
+// simulateFailureCmd represents the simulate pipeline-failure command
+var simulateFailureCmd = &cobra.Command{
+	Use:   "pipeline-failure",
+	Short: "Simulate a pipeline failure, creating or updating an issue",
+	Example: `  konflux-issues simulate pipeline-failure --pipeline foo -n team-alpha
+  konflux-issues simulate pipeline-failure --pipeline foo -n team-alpha --reason "Unit tests failed" --severity critical`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Sending pipeline failure for %s in namespace %s...\n", simulatedPipeline, namespace)
+		resp, err := client.SendPipelineFailure(models.PipelineFailurePayload{
+			PipelineName:  simulatedPipeline,
+			Namespace:     namespace,
+			FailureReason: simulatedReason,
+			Severity:      simulatedSeverity,
+			RunID:         simulatedRunID,
+			LogsURL:       simulatedLogsURL,
+		})
+		if err != nil {
+			return fmt.Errorf("error sending pipeline failure: %w", err)
+		}
+
+		printWebhookResponse(resp, func() {
+			fmt.Printf("Pipeline failure for %s was processed.\n", simulatedPipeline)
+			if resp.Issue != nil {
+				formatter.PrintIssueDetails(resp.Issue)
+				fmt.Println()
+			}
+			fmt.Printf("Resolve it with: konflux-issues simulate pipeline-success --pipeline %s -n %s\n", simulatedPipeline, namespace)
+		})
+		return nil
+	},
+}
+
+// simulateSuccessCmd represents the simulate pipeline-success command
+var simulateSuccessCmd = &cobra.Command{
+	Use:     "pipeline-success",
+	Short:   "Simulate a pipeline success, resolving the issues of the pipeline",
+	Example: `  konflux-issues simulate pipeline-success --pipeline foo -n team-alpha`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Sending pipeline success for %s in namespace %s...\n", simulatedPipeline, namespace)
+		resp, err := client.SendPipelineSuccess(models.PipelineSuccessPayload{
+			PipelineName: simulatedPipeline,
+			Namespace:    namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("error sending pipeline success: %w", err)
+		}
+
+		printWebhookResponse(resp, func() {
+			fmt.Println(resp.Message)
+		})
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+	simulateCmd.AddCommand(simulateFailureCmd)
+	simulateCmd.AddCommand(simulateSuccessCmd)
+
+	for _, c := range []*cobra.Command{simulateFailureCmd, simulateSuccessCmd} {
+		c.Flags().StringVarP(&simulatedPipeline, "pipeline", "p", "", "Name of the pipeline")
+		c.MarkFlagRequired("pipeline")
+	}
+
+	simulateFailureCmd.Flags().StringVar(&simulatedReason, "reason", "Simulated failure sent by konflux-issues", "Failure reason")
+	simulateFailureCmd.Flags().StringVarP(&simulatedSeverity, "severity", "s", "", "Issue severity (info, minor, major, critical), defaults to major")
+	simulateFailureCmd.Flags().StringVar(&simulatedRunID, "run-id", "", "Pipeline run ID used to build the logs URL")
+	simulateFailureCmd.Flags().StringVar(&simulatedLogsURL, "logs-url", "", "URL of the pipeline run logs")
+}
+
+// printWebhookResponse prints a webhook response using the selected output
+// format, calling printText for the default text output
+func printWebhookResponse(resp *models.WebhookResponse, printText func()) {
+	switch {
+	case quiet:
+		if resp.Issue != nil {
+			fmt.Println(resp.Issue.ID)
+		}
+	case outputFormat == "json":
+		formatter.PrintWebhookResponseJSON(resp)
+	case outputFormat == "yaml":
+		formatter.PrintWebhookResponseYAML(resp)
+	default:
+		printText()
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxSnooze is how long the API lets issues be snoozed for
+const maxSnooze = 30 * 24 * time.Hour
+
+var (
+	snoozeFor string
+	snoozedBy string
+	wake      bool
+)
+
+// snoozeCmd represents the snooze command
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze",
+	Short: "Snooze an issue for a while",
+	Long: `Snooze an open issue for a while, e.g. while waiting on a fix from another
+team, recording who did, so that 'list --hide-snoozed' leaves it out until
+the snooze ends. Snoozing a snoozed issue replaces its snooze.
+
+Snoozes end after --for, up to 30 days, when the issue is reopened, or when
+it's woken with --wake.`,
+	Example: `  konflux-issues snooze -n team-alpha -i <issue-id> --for 4h
+  konflux-issues snooze -n team-alpha -i <issue-id> --for 2d --snoozed-by alice
+  konflux-issues snooze -n team-alpha -i <issue-id> --wake
+  konflux-issues list -n team-alpha --unresolved --hide-snoozed`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		if wake == (snoozeFor != "") {
+			return fmt.Errorf("either --for or --wake is required")
+		}
+		var period time.Duration
+		if !wake {
+			var err error
+			if period, err = parseDuration(snoozeFor); err != nil {
+				return fmt.Errorf("invalid --for value: %w", err)
+			}
+			if period > maxSnooze {
+				return fmt.Errorf("invalid --for value %q, issues can't be snoozed for more than 30 days", snoozeFor)
+			}
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		if wake {
+			progressf("Waking issue %s in namespace %s...\n", issueID, namespace)
+			if _, err := client.WakeIssue(issueID, namespace); err != nil {
+				return fmt.Errorf("error waking issue: %w", err)
+			}
+			if quiet {
+				fmt.Println(issueID)
+				return nil
+			}
+			fmt.Printf("Issue %s has been woken.\n", issueID)
+			return nil
+		}
+
+		progressf("Snoozing issue %s in namespace %s...\n", issueID, namespace)
+		until := time.Now().Add(period).Truncate(time.Second)
+		issue, err := client.SnoozeIssue(issueID, namespace, until, snoozedBy)
+		if err != nil {
+			return fmt.Errorf("error snoozing issue: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(issueID)
+			return nil
+		}
+		fmt.Printf("Issue %s has been snoozed by %s until %s (%s).\n", issueID, issue.SnoozedBy, until.Format("2006-01-02 15:04:05"), snoozeFor)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+
+	snoozeCmd.Flags().StringVarP(&issueID, "id", "i", "", "Issue ID")
+	snoozeCmd.MarkFlagRequired("id")
+	snoozeCmd.Flags().StringVar(&snoozeFor, "for", "", "How long to snooze the issue for, e.g. 30m, 4h, 2d or 1w")
+	snoozeCmd.Flags().StringVar(&snoozedBy, "snoozed-by", "", "Who snoozes the issue (defaults to the user identified by the API)")
+	snoozeCmd.Flags().BoolVar(&wake, "wake", false, "End the snooze of the issue instead")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/api"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	"github.com/konflux-ci/kite/packages/cli/pkg/version"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var clientOnly bool
+
+// versionOutput is the structured form of the version command output
+type versionOutput struct {
+	ClientVersion string              `json:"clientVersion" yaml:"clientVersion"`
+	APIVersion    string              `json:"apiVersion" yaml:"apiVersion"`
+	Server        *models.VersionInfo `json:"server,omitempty" yaml:"server,omitempty"`
+}
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:         "version",
+	Short:       "Print the CLI and server versions",
+	Annotations: map[string]string{allowConfigErrorAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := versionOutput{
+			ClientVersion: version.Version,
+			APIVersion:    version.APIVersion,
+		}
+
+		var serverErr error
+		if !clientOnly && configErr == nil {
+			out.Server, serverErr = api.New().GetVersion()
+		}
+
+		switch outputFormat {
+		case "json":
+			data, err := json.MarshalIndent(out, "", " ")
+			if err != nil {
+				return fmt.Errorf("error formatting JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		case "yaml":
+			data, err := yaml.Marshal(out)
+			if err != nil {
+				return fmt.Errorf("error formatting YAML: %w", err)
+			}
+			fmt.Print(string(data))
+		default:
+			fmt.Printf("Client Version: %s\n", out.ClientVersion)
+			fmt.Printf("Supported API Version: %s\n", out.APIVersion)
+			if out.Server != nil {
+				fmt.Printf("Server Version: %s\n", out.Server.Version)
+				fmt.Printf("Server API Version: %s\n", serverAPIVersion(out.Server))
+			}
+		}
+
+		if serverErr != nil {
+			return fmt.Errorf("cannot get server version: %w", serverErr)
+		}
+		if out.Server != nil {
+			return checkCompatibility(out.Server)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().BoolVar(&clientOnly, "client", false, "Only print the CLI version, don't contact the server")
+}
+
+// checkServerCompatibility verifies the server serves an API version the CLI
+// supports. Servers whose version can't be retrieved are assumed to be
+// compatible, unless --strict is set.
+func checkServerCompatibility(client *api.Client) error {
+	info, err := client.GetVersion()
+	if err != nil {
+		if strict {
+			return fmt.Errorf("cannot verify the server is compatible: %w", err)
+		}
+		return nil
+	}
+	return checkCompatibility(info)
+}
+
+// checkCompatibility warns about, or with --strict refuses, servers serving
+// an unsupported API version
+func checkCompatibility(info *models.VersionInfo) error {
+	var problem string
+	switch version.CheckAPIVersion(info.APIVersion) {
+	case version.Compatible:
+		return nil
+	case version.ServerNewer:
+		problem = fmt.Sprintf("the server API version %s is newer than the version supported by this CLI (%s), please upgrade the CLI",
+			info.APIVersion, version.APIVersion)
+	case version.ServerOlder:
+		problem = fmt.Sprintf("the server API version %s is older than the version supported by this CLI (%s), please use an older CLI or upgrade the server",
+			info.APIVersion, version.APIVersion)
+	default:
+		problem = fmt.Sprintf("the server reports an unknown API version %q, this CLI supports %s", info.APIVersion, version.APIVersion)
+	}
+
+	if strict {
+		return fmt.Errorf("incompatible server: %s", problem)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s\n", problem)
+	return nil
+}
+
+// serverAPIVersion returns the API version of a server, accounting for
+// servers that predate API versioning
+func serverAPIVersion(info *models.VersionInfo) string {
+	if info.APIVersion == "" {
+		return "v1 (assumed)"
+	}
+	return info.APIVersion
+}
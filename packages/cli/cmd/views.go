@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/formatter"
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// viewsCmd represents the views command
+var viewsCmd = &cobra.Command{
+	Use:   "views",
+	Short: "List the saved views of a namespace",
+	Long: `List the saved views of a namespace.
+
+A view is a named set of filters shared in a namespace, applied to list and
+search with --view instead of typing the same flags again.`,
+	Example: `  konflux-issues views save critical-builds -n team-alpha -t build -s critical --unresolved
+  konflux-issues list -n team-alpha --view critical-builds
+  konflux-issues views -n team-alpha`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		progressf("Fetching views for namespace %s...\n", namespace)
+		views, err := client.GetViews(namespace)
+		if err != nil {
+			return err
+		}
+		if views == nil {
+			views = []models.SavedView{}
+		}
+
+		switch {
+		case quiet:
+			for _, view := range views {
+				fmt.Println(view.Name)
+			}
+		case outputFormat == "json":
+			formatter.PrintViewsJSON(views)
+		case outputFormat == "yaml":
+			formatter.PrintViewsYAML(views)
+		case len(views) == 0:
+			fmt.Printf("No views saved in namespace %s.\n", namespace)
+		default:
+			formatter.PrintViewsTable(views)
+		}
+		return nil
+	},
+}
+
+// saveViewCmd represents the views save command
+var saveViewCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the filters given as flags as a view of the namespace",
+	Long: `Save the filters given as flags as a view of the namespace, replacing the
+filters of the view if it exists.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		if unresolved {
+			state = "ACTIVE"
+		}
+		if err := validateListFilters(); err != nil {
+			return err
+		}
+
+		query := url.Values{}
+		for key, value := range map[string]string{
+			"issueType":         issueType,
+			"severity":          severity,
+			"priority":          strings.ToUpper(priority),
+			"state":             state,
+			"resourceType":      resourceType,
+			"resourceNamespace": resourceNamespace,
+			"assignee":          assignee,
+			"sort":              sortBy,
+			"search":            term,
+			"acknowledged":      acknowledgedFilter(),
+			"snoozed":           snoozedFilter(),
+		} {
+			if value != "" {
+				query.Set(key, value)
+			}
+		}
+		for _, label := range labels {
+			query.Add("label", label)
+		}
+		if len(query) == 0 {
+			return fmt.Errorf("no filters given, pass at least one filter flag")
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		name := args[0]
+		progressf("Saving view %s in namespace %s...\n", name, namespace)
+		if _, err := client.SaveView(namespace, name, query.Encode()); err != nil {
+			return fmt.Errorf("error saving view: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(name)
+			return nil
+		}
+		fmt.Printf("View %s saved, list its issues with: konflux-issues list -n %s --view %s\n", name, namespace, name)
+		return nil
+	},
+}
+
+// deleteViewCmd represents the views delete command
+var deleteViewCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a view of the namespace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// If no namespace provided, try to get from kubectl context
+		if err := requireNamespace(); err != nil {
+			return err
+		}
+
+		// Create API client
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		name := args[0]
+		progressf("Deleting view %s in namespace %s...\n", name, namespace)
+		if err := client.DeleteView(namespace, name); err != nil {
+			return fmt.Errorf("error deleting view: %w", err)
+		}
+
+		if quiet {
+			fmt.Println(name)
+			return nil
+		}
+		fmt.Printf("View %s deleted.\n", name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(viewsCmd)
+	viewsCmd.AddCommand(saveViewCmd)
+	viewsCmd.AddCommand(deleteViewCmd)
+
+	// Add views save command flags, the filters of list and search
+	saveViewCmd.Flags().StringVarP(&issueType, "type", "t", "", "Filter by issue type")
+	saveViewCmd.Flags().StringVarP(&severity, "severity", "s", "", "Filter by severity")
+	saveViewCmd.Flags().StringVar(&state, "state", "", "Filter by state (ACTIVE or RESOLVED)")
+	saveViewCmd.Flags().StringVarP(&resourceType, "resource-type", "r", "", "Filter by resource type")
+	saveViewCmd.Flags().StringVar(&resourceNamespace, "resource-namespace", "", "Filter by the namespace of the resource, when it differs from the issue's")
+	saveViewCmd.Flags().BoolVarP(&unresolved, "unresolved", "u", false, "Show only unresolved issues")
+	saveViewCmd.Flags().BoolVar(&unacknowledged, "unacknowledged", false, "Show only issues nobody acknowledged yet")
+	saveViewCmd.Flags().BoolVar(&hideSnoozed, "hide-snoozed", false, "Hide the issues snoozed until later")
+	saveViewCmd.Flags().StringArrayVarP(&labels, "label", "l", nil, "Filter by label as key=value (can be repeated, issues must match all)")
+	saveViewCmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee")
+	saveViewCmd.Flags().StringVar(&priority, "priority", "", "Filter by priority (P1, P2, P3 or P4)")
+	saveViewCmd.Flags().StringVar(&sortBy, "sort", "", "Sort issues by detectedAt (default) or priority")
+	saveViewCmd.Flags().StringVar(&term, "search", "", "Search for a term in the title and description")
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/konflux-ci/kite/packages/cli/cmd"
@@ -9,14 +8,13 @@ import (
 )
 
 func main() {
-	// Initialize configuration
-	if err := config.InitConfig(); err != nil {
-		fmt.Println("Error initializing config:", err)
-		os.Exit(1)
-	}
+	// Initialize configuration. Errors are reported when a command runs, so
+	// that commands like doctor can still diagnose a broken configuration.
+	cmd.SetConfigError(config.InitConfig())
 
 	// Execute the root command
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		cmd.PrintError(err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/cache"
+)
+
+// get performs a GET request through the local response cache.
+//
+// Fresh cached responses are returned without contacting the API. When the
+// API can't be reached, or fails with a server error, the last cached
+// response is returned instead regardless of its age. In cache-only mode
+// the API is never contacted.
+func (c *Client) get(url string) (*http.Response, error) {
+	var entry *cache.Entry
+	cached := false
+	if c.cache != nil {
+		entry, cached = c.cache.Get(url)
+	}
+
+	if cached && (c.cacheOnly || c.cache.Fresh(entry)) {
+		return cachedResponse(entry), nil
+	}
+	if c.cacheOnly {
+		return nil, newError(ErrorKindCacheMiss, 0, "no cached data available: run the command without --cached first")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+		if !cached {
+			return resp, err
+		}
+		if resp != nil {
+			drainAndClose(resp)
+		}
+		if !c.quietStale {
+			fmt.Fprintf(os.Stderr, "Warning: the API is unavailable, showing cached data from %s ago\n", entry.Age().Round(time.Second))
+		}
+		return cachedResponse(entry), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || c.cache == nil {
+		return resp, nil
+	}
+
+	// Keep a copy of successful responses for later invocations
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	_ = c.cache.Put(url, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// getUncached performs a GET request bypassing the local response cache, for
+// data about to be modified from
+func (c *Client) getUncached(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	return c.do(req)
+}
+
+// clearCache removes all cached responses, e.g. after the API data changed
+func (c *Client) clearCache() {
+	if c.cache != nil {
+		_ = c.cache.Clear()
+	}
+}
+
+// cachedResponse builds a response serving the body of a cache entry
+func cachedResponse(entry *cache.Entry) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(entry.Data)),
+	}
+}
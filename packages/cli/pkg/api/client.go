@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/konflux-ci/kite/packages/cli/pkg/cache"
 	"github.com/konflux-ci/kite/packages/cli/pkg/config"
 	"github.com/konflux-ci/kite/packages/cli/pkg/models"
 )
@@ -16,21 +18,54 @@ import (
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	maxRetries int
+	cache      *cache.Cache
+	cacheOnly  bool
+	// quietStale disables the warning printed when cached data is served
+	// because the API is unavailable
+	quietStale bool
 }
 
-// New creates a new API client
+// New creates a new API client using the configured API URL, timeout and retry count
 func New() *Client {
+	return NewForURL(config.GetConfig().APIUrl)
+}
+
+// NewForURL creates a new API client for the API at baseURL, using the
+// configured timeout and retry count
+func NewForURL(baseURL string) *Client {
 	cfg := config.GetConfig()
-	return &Client{
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultTimeout
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	client := &Client{
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   timeout,
+			Transport: sharedTransport,
 		},
-		baseURL: cfg.APIUrl,
+		baseURL:    baseURL,
+		maxRetries: maxRetries,
+		cacheOnly:  cfg.CacheOnly,
+	}
+
+	// The cache is best effort, the client works without it
+	if cacheDir, err := config.CacheDir(); err == nil {
+		client.cache = cache.New(cacheDir, cfg.CacheTTL)
 	}
+
+	return client
 }
 
-// GetIssues retrieves issues with optional filters
-func (c *Client) GetIssues(namespace string, filters map[string]string) ([]models.Issue, error) {
+// GetIssues retrieves issues with optional filters. Only issues having
+// all the given key=value labels are returned.
+func (c *Client) GetIssues(namespace string, filters map[string]string, labels []string) ([]models.Issue, error) {
 	// Build query parameters
 	params := url.Values{}
 	params.Add("namespace", namespace)
@@ -39,12 +74,15 @@ func (c *Client) GetIssues(namespace string, filters map[string]string) ([]model
 			params.Add(key, value)
 		}
 	}
+	for _, label := range labels {
+		params.Add("label", label)
+	}
 
 	// Make request
 	url := fmt.Sprintf("%s/issues?%s", c.baseURL, params.Encode())
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issues: %w", err)
+		return nil, c.handleRequestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -56,32 +94,75 @@ func (c *Client) GetIssues(namespace string, filters map[string]string) ([]model
 	// Parse response
 	var response models.IssuesResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to parse issues: %w", err)
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse issues: %v", err)
 	}
 
 	return response.Data, nil
 }
 
+// GetIssuesGrouped retrieves issues with optional filters, grouped by resource, type or severity
+func (c *Client) GetIssuesGrouped(namespace, groupBy string, filters map[string]string, labels []string) (*models.GroupedIssuesResponse, error) {
+	// Build query parameters
+	params := url.Values{}
+	params.Add("namespace", namespace)
+	params.Add("groupBy", groupBy)
+	for key, value := range filters {
+		if value != "" {
+			params.Add(key, value)
+		}
+	}
+	for _, label := range labels {
+		params.Add("label", label)
+	}
+
+	// Make request
+	url := fmt.Sprintf("%s/issues/grouped?%s", c.baseURL, params.Encode())
+	resp, err := c.get(url)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	// Check response status
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	// Parse response
+	var response models.GroupedIssuesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse grouped issues: %v", err)
+	}
+
+	return &response, nil
+}
+
 // GetIssueDetails retrieves details for a specific issue
 func (c *Client) GetIssueDetails(id, namespace string) (*models.Issue, error) {
+	return c.issueDetails(id, namespace, c.get)
+}
+
+// issueDetails retrieves details for a specific issue through get, which
+// goes through the cache or not
+func (c *Client) issueDetails(id, namespace string, get func(string) (*http.Response, error)) (*models.Issue, error) {
 	// Build query parameters
 	params := url.Values{}
 	params.Add("namespace", namespace)
 
 	// Make request
 	url := fmt.Sprintf("%s/issues/%s?%s", c.baseURL, id, params.Encode())
-	resp, err := c.httpClient.Get(url)
+	resp, err := get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issue details: %w", err)
+		return nil, c.handleRequestError(err)
 	}
 	defer resp.Body.Close()
 
 	// Handle not found and access denied responses
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("issue with ID %s not found", id)
+		return nil, newError(ErrorKindNotFound, resp.StatusCode, "issue with ID %s not found", id)
 	}
 	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("access denied to namespace %s", namespace)
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
 	}
 
 	// Check other response statuses
@@ -92,28 +173,33 @@ func (c *Client) GetIssueDetails(id, namespace string) (*models.Issue, error) {
 	// Parse response
 	var issue models.Issue
 	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
-		return nil, fmt.Errorf("failed to parse issue details: %w", err)
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse issue details: %v", err)
 	}
 
 	return &issue, nil
 }
 
-// ResolveIssue marks an issue as resolved
-func (c *Client) ResolveIssue(id, namespace string) error {
+// ResolveIssue marks an issue as resolved, recording why and by whom. Both are optional.
+func (c *Client) ResolveIssue(id, namespace, reason, resolvedBy string) error {
 	params := url.Values{}
 	params.Add("namespace", namespace)
 
+	body, err := json.Marshal(map[string]string{"reason": reason, "resolvedBy": resolvedBy})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
 	// Create request
 	url := fmt.Sprintf("%s/issues/%s/resolve?%s", c.baseURL, id, params.Encode())
-	req, err := http.NewRequest(http.MethodPost, url, nil)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return c.handleRequestError(err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return c.handleRequestError(err)
 	}
@@ -121,10 +207,10 @@ func (c *Client) ResolveIssue(id, namespace string) error {
 
 	// Handle not found and access denied responses
 	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("issue with ID %s not found", id)
+		return newError(ErrorKindNotFound, resp.StatusCode, "issue with ID %s not found", id)
 	}
 	if resp.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("access denied to namespace %s", namespace)
+		return newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
 	}
 
 	// Check other response statuses
@@ -132,28 +218,375 @@ func (c *Client) ResolveIssue(id, namespace string) error {
 		return c.handleAPIError(resp)
 	}
 
+	// Cached lists and details may now be out of date
+	c.clearCache()
+
 	return nil
 }
 
+// AcknowledgeIssue acknowledges an issue, recording who did. acknowledgedBy
+// is optional when the API identifies the user of requests.
+func (c *Client) AcknowledgeIssue(id, namespace, acknowledgedBy string) (*models.Issue, error) {
+	return c.changeIssue(http.MethodPost, id, "/ack", namespace, map[string]string{"acknowledgedBy": acknowledgedBy})
+}
+
+// SnoozeIssue snoozes an issue until a time, recording who did. snoozedBy is
+// optional when the API identifies the user of requests.
+func (c *Client) SnoozeIssue(id, namespace string, until time.Time, snoozedBy string) (*models.Issue, error) {
+	return c.changeIssue(http.MethodPost, id, "/snooze", namespace, map[string]string{
+		"until":     until.UTC().Format(time.RFC3339),
+		"snoozedBy": snoozedBy,
+	})
+}
+
+// WakeIssue ends the snooze of an issue
+func (c *Client) WakeIssue(id, namespace string) (*models.Issue, error) {
+	return c.changeIssue(http.MethodDelete, id, "/snooze", namespace, nil)
+}
+
+// SetIssuePriority sets the priority of an issue
+func (c *Client) SetIssuePriority(id, namespace, priority string) (*models.Issue, error) {
+	return c.updateIssue(id, namespace, map[string]string{"priority": priority})
+}
+
+// AssignIssue assigns an issue to someone
+func (c *Client) AssignIssue(id, namespace, assignee string) (*models.Issue, error) {
+	return c.updateIssue(id, namespace, map[string]string{"assignee": assignee})
+}
+
+// UpdateIssueLabels sets and removes labels of an issue, keeping the others.
+// The API replaces all the labels of an issue at once, so they're merged with
+// the labels of the issue fetched from the API, not from the cache.
+func (c *Client) UpdateIssueLabels(id, namespace string, set map[string]string, remove []string) (*models.Issue, error) {
+	issue, err := c.issueDetails(id, namespace, c.getUncached)
+	if err != nil {
+		return nil, err
+	}
+
+	// Non-nil even when empty, for the API to remove the last labels
+	labels := map[string]string{}
+	for _, label := range issue.Labels {
+		labels[label.Key] = label.Value
+	}
+	for _, key := range remove {
+		delete(labels, key)
+	}
+	for key, value := range set {
+		labels[key] = value
+	}
+
+	return c.updateIssue(id, namespace, map[string]any{"labels": labels})
+}
+
+// updateIssue updates the given fields of an issue
+func (c *Client) updateIssue(id, namespace string, fields any) (*models.Issue, error) {
+	return c.changeIssue(http.MethodPut, id, "", namespace, fields)
+}
+
+// changeIssue sends a request changing an issue to the path of the issue,
+// with fields as its JSON body unless nil, and returns the changed issue
+func (c *Client) changeIssue(method, id, path, namespace string, fields any) (*models.Issue, error) {
+	params := url.Values{}
+	params.Add("namespace", namespace)
+
+	var body io.Reader
+	if fields != nil {
+		payload, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	// Create request
+	url := fmt.Sprintf("%s/issues/%s%s?%s", c.baseURL, id, path, params.Encode())
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Make request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	// Handle not found and access denied responses
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, newError(ErrorKindNotFound, resp.StatusCode, "issue with ID %s not found", id)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+
+	// Check other response statuses
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	// Cached lists and details may now be out of date
+	c.clearCache()
+
+	// Parse response
+	var issue models.Issue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse issue: %v", err)
+	}
+
+	return &issue, nil
+}
+
+// GetIssueActivity retrieves the activity feed of an issue, the oldest entry first
+func (c *Client) GetIssueActivity(id, namespace string) ([]models.ActivityEntry, error) {
+	params := url.Values{}
+	params.Add("namespace", namespace)
+
+	url := fmt.Sprintf("%s/issues/%s/activity?%s", c.baseURL, id, params.Encode())
+	resp, err := c.get(url)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, newError(ErrorKindNotFound, resp.StatusCode, "issue with ID %s not found", id)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var response models.ActivityResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse issue activity: %v", err)
+	}
+
+	return response.Data, nil
+}
+
+// GetDashboard retrieves the overview of the issues of a namespace: the
+// counts of active issues, the flapping resources and the SLA breaches
+func (c *Client) GetDashboard(namespace string) (*models.Dashboard, error) {
+	params := url.Values{}
+	params.Add("namespace", namespace)
+
+	url := fmt.Sprintf("%s/dashboard?%s", c.baseURL, params.Encode())
+	resp, err := c.get(url)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var dashboard models.Dashboard
+	if err := json.NewDecoder(resp.Body).Decode(&dashboard); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse dashboard: %v", err)
+	}
+
+	return &dashboard, nil
+}
+
+// GetViews retrieves the views shared in a namespace
+func (c *Client) GetViews(namespace string) ([]models.SavedView, error) {
+	url := fmt.Sprintf("%s/namespaces/%s/views", c.baseURL, url.PathEscape(namespace))
+	resp, err := c.get(url)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var response models.SavedViewsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse views: %v", err)
+	}
+
+	return response.Data, nil
+}
+
+// SaveView saves a view shared in a namespace, replacing its filters if it exists.
+// query holds the filters as query parameters of the issues endpoint.
+func (c *Client) SaveView(namespace, name, query string) (*models.SavedView, error) {
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	// Create request
+	url := fmt.Sprintf("%s/namespaces/%s/views/%s", c.baseURL, url.PathEscape(namespace), url.PathEscape(name))
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// Make request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	// Cached lists of views may now be out of date
+	c.clearCache()
+
+	var view models.SavedView
+	if err := json.NewDecoder(resp.Body).Decode(&view); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse view: %v", err)
+	}
+
+	return &view, nil
+}
+
+// DeleteView deletes a view shared in a namespace
+func (c *Client) DeleteView(namespace, name string) error {
+	url := fmt.Sprintf("%s/namespaces/%s/views/%s", c.baseURL, url.PathEscape(namespace), url.PathEscape(name))
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return newError(ErrorKindNotFound, resp.StatusCode, "view %s not found in namespace %s", name, namespace)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return c.handleAPIError(resp)
+	}
+
+	// Cached lists of views may now be out of date
+	c.clearCache()
+
+	return nil
+}
+
+// SendPipelineFailure posts a pipeline failure event to the webhook endpoint
+func (c *Client) SendPipelineFailure(payload models.PipelineFailurePayload) (*models.WebhookResponse, error) {
+	return c.postWebhook("pipeline-failure", payload.Namespace, payload)
+}
+
+// SendPipelineSuccess posts a pipeline success event to the webhook endpoint
+func (c *Client) SendPipelineSuccess(payload models.PipelineSuccessPayload) (*models.WebhookResponse, error) {
+	return c.postWebhook("pipeline-success", payload.Namespace, payload)
+}
+
+// postWebhook posts a payload to one of the webhook endpoints
+func (c *Client) postWebhook(webhook, namespace string, payload any) (*models.WebhookResponse, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	// The namespace is also passed as a query parameter for the namespace access check
+	params := url.Values{}
+	params.Add("namespace", namespace)
+
+	// Create request
+	url := fmt.Sprintf("%s/webhooks/%s?%s", c.baseURL, webhook, params.Encode())
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// Make request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "access denied to namespace %s", namespace)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, c.handleAPIError(resp)
+	}
+
+	// Cached lists and details may now be out of date
+	c.clearCache()
+
+	var response models.WebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse webhook response: %v", err)
+	}
+
+	return &response, nil
+}
+
 // handleRequestError handles HTTP request errors with improved error messages
 func (c *Client) handleRequestError(err error) error {
 	if err == nil {
 		return nil
 	}
 
+	// Errors created by the client itself are already descriptive
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+
 	// Check for timeout
 	if urlErr, ok := err.(*url.Error); ok && urlErr.Timeout() {
-		return fmt.Errorf("request timed out: please check your network connection and try again")
+		return &Error{
+			Kind:    ErrorKindNetwork,
+			Message: "request timed out: please check your network connection and try again",
+			Err:     err,
+		}
 	}
 
 	// Check for network connectivity issues
-	return fmt.Errorf("network error: %w (please check your connection and API URL configuration)", err)
+	return &Error{
+		Kind:    ErrorKindNetwork,
+		Message: fmt.Sprintf("network error: %v (please check your connection and API URL configuration)", err),
+		Err:     err,
+	}
 }
 
 // handleAPIError handles API error responses with improved error messages
 func (c *Client) handleAPIError(resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
 
+	kind := ErrorKindAPI
+	if resp.StatusCode == http.StatusNotFound {
+		kind = ErrorKindNotFound
+	}
+
 	// Try to parse error as JSON
 	var apiError struct {
 		Error   string `json:"error"`
@@ -162,28 +595,80 @@ func (c *Client) handleAPIError(resp *http.Response) error {
 
 	if err := json.Unmarshal(body, &apiError); err == nil && (apiError.Error != "" || apiError.Message != "") {
 		if apiError.Error != "" {
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiError.Error)
+			return newError(kind, resp.StatusCode, "API error (status %d): %s", resp.StatusCode, apiError.Error)
 		}
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiError.Message)
+		return newError(kind, resp.StatusCode, "API error (status %d): %s", resp.StatusCode, apiError.Message)
 	}
 
 	// Handle different status codes
 	switch resp.StatusCode {
 	case http.StatusUnauthorized:
-		return fmt.Errorf("authentication error: you are not authorized to access this resource")
+		return newError(kind, resp.StatusCode, "authentication error: you are not authorized to access this resource")
 	case http.StatusForbidden:
-		return fmt.Errorf("permission denied: you don't have access to this resource")
+		return newError(kind, resp.StatusCode, "permission denied: you don't have access to this resource")
 	case http.StatusNotFound:
-		return fmt.Errorf("resource not found: please check the URL or parameters")
+		return newError(kind, resp.StatusCode, "resource not found: please check the URL or parameters")
 	case http.StatusTooManyRequests:
-		return fmt.Errorf("rate limit exceeded: please try again later")
+		return newError(kind, resp.StatusCode, "rate limit exceeded: please try again later")
 	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
-		return fmt.Errorf("server error (status %d): the server is currently unavailable, please try again later", resp.StatusCode)
+		return newError(kind, resp.StatusCode, "server error (status %d): the server is currently unavailable, please try again later", resp.StatusCode)
 	default:
 		// Default error message with body if available
 		if len(body) > 0 {
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+			return newError(kind, resp.StatusCode, "API error (status %d): %s", resp.StatusCode, string(body))
 		}
-		return fmt.Errorf("API error (status %d)", resp.StatusCode)
+		return newError(kind, resp.StatusCode, "API error (status %d)", resp.StatusCode)
 	}
 }
+
+// GetVersion retrieves the version information of the API server
+func (c *Client) GetVersion() (*models.VersionInfo, error) {
+	// The version is checked before other requests, so when the API is
+	// down don't make users wait for retries (or read warnings) twice
+	probe := *c
+	probe.maxRetries = 0
+	probe.quietStale = true
+
+	resp, err := probe.get(c.baseURL + "/version")
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var version models.VersionInfo
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse version: %v", err)
+	}
+
+	return &version, nil
+}
+
+// GetHealth retrieves the health report of the API server.
+// A report is returned for unhealthy servers too, as long as one was sent.
+func (c *Client) GetHealth() (*models.HealthStatus, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/health", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, c.handleRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var health models.HealthStatus
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, newError(ErrorKindAPI, resp.StatusCode, "failed to parse health status: %v", err)
+	}
+
+	return &health, nil
+}
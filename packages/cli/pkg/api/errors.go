@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorKind classifies API client failures so callers (and scripts) can branch on them
+type ErrorKind string
+
+const (
+	// ErrorKindNotFound means the requested resource does not exist
+	ErrorKindNotFound ErrorKind = "not_found"
+	// ErrorKindAPI means the API was reached but returned an error response
+	ErrorKindAPI ErrorKind = "api_error"
+	// ErrorKindNetwork means the API could not be reached at all
+	ErrorKindNetwork ErrorKind = "network_error"
+	// ErrorKindCacheMiss means cached data was requested but none is available
+	ErrorKindCacheMiss ErrorKind = "cache_miss"
+)
+
+// Error is the error type returned by the API client
+type Error struct {
+	Kind       ErrorKind
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError creates a new client error of the given kind
+func newError(kind ErrorKind, statusCode int, format string, args ...any) *Error {
+	return &Error{
+		Kind:       kind,
+		StatusCode: statusCode,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+// KindOf returns the kind of a client error, or an empty kind if err
+// did not originate from the API client
+func KindOf(err error) ErrorKind {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// Base delay used for exponential backoff between retries
+	retryBaseDelay = 500 * time.Millisecond
+	// Upper bound for any single wait between retries, including Retry-After
+	retryMaxDelay = 30 * time.Second
+)
+
+// sharedTransport is reused by every client so connections to the API are
+// kept alive across requests instead of being re-established each time.
+var sharedTransport = func() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 10
+	transport.MaxIdleConnsPerHost = 10
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}()
+
+// do sends the request, retrying with backoff when the failure is transient.
+//
+// Idempotent requests are retried on network errors, 429 and 5xx responses.
+// Other requests are only retried when the server explicitly rejected them
+// without processing, with a 429 or a 503 carrying Retry-After, since
+// retrying could apply them twice.
+//
+// If the server returns a Retry-After header, it's honored instead of the
+// computed backoff delay.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		// Requests with a body need a fresh copy of it for each attempt
+		if attempt > 0 && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		resp, err = c.httpClient.Do(req)
+		if attempt >= c.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			drainAndClose(resp)
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// shouldRetry reports whether a request should be retried given its outcome
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := isIdempotent(req.Method)
+
+	if err != nil {
+		return idempotent
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= 500:
+		// A 503 without Retry-After may come from a proxy after the API
+		// started processing the request
+		return idempotent ||
+			resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != ""
+	default:
+		return false
+	}
+}
+
+// isIdempotent reports whether requests using the method can safely be repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the exponential backoff delay (with jitter) for an attempt
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	// Add up to 20% jitter so concurrent clients don't retry in lockstep
+	jitter := time.Duration(rand.Int63n(int64(delay) / 5))
+	return delay + jitter
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date. The result is capped at retryMaxDelay.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay, true
+}
+
+// drainAndClose reads the rest of the response body before closing it,
+// which allows the underlying connection to be reused.
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	_ = resp.Body.Close()
+}
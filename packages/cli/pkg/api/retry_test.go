@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestShouldRetry(t *testing.T) {
+	response := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		resp     *http.Response
+		err      error
+		expected bool
+	}{
+		{name: "GET network error", method: http.MethodGet, err: errors.New("connection reset"), expected: true},
+		{name: "GET 429", method: http.MethodGet, resp: response(http.StatusTooManyRequests, ""), expected: true},
+		{name: "GET 500", method: http.MethodGet, resp: response(http.StatusInternalServerError, ""), expected: true},
+		{name: "GET 503", method: http.MethodGet, resp: response(http.StatusServiceUnavailable, ""), expected: true},
+		{name: "GET 404", method: http.MethodGet, resp: response(http.StatusNotFound, ""), expected: false},
+		{name: "GET 200", method: http.MethodGet, resp: response(http.StatusOK, ""), expected: false},
+		{name: "PUT 502", method: http.MethodPut, resp: response(http.StatusBadGateway, ""), expected: true},
+		{name: "DELETE 503", method: http.MethodDelete, resp: response(http.StatusServiceUnavailable, ""), expected: true},
+		{name: "POST network error", method: http.MethodPost, err: errors.New("connection reset"), expected: false},
+		{name: "POST 429", method: http.MethodPost, resp: response(http.StatusTooManyRequests, ""), expected: true},
+		{name: "POST 429 with Retry-After", method: http.MethodPost, resp: response(http.StatusTooManyRequests, "2"), expected: true},
+		{name: "POST 503 with Retry-After", method: http.MethodPost, resp: response(http.StatusServiceUnavailable, "2"), expected: true},
+		{name: "POST 503", method: http.MethodPost, resp: response(http.StatusServiceUnavailable, ""), expected: false},
+		{name: "POST 500", method: http.MethodPost, resp: response(http.StatusInternalServerError, ""), expected: false},
+		{name: "POST 502 with Retry-After", method: http.MethodPost, resp: response(http.StatusBadGateway, "2"), expected: false},
+		{name: "POST 400", method: http.MethodPost, resp: response(http.StatusBadRequest, ""), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "http://localhost/api/v1/issues", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if retry := shouldRetry(req, tt.resp, tt.err); retry != tt.expected {
+				t.Errorf("Expected retry %v, got %v", tt.expected, retry)
+			}
+		})
+	}
+}
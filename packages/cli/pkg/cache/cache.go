@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores API responses on disk so they can be reused by later
+// invocations, or served when the API is unavailable
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// Entry is a cached API response
+type Entry struct {
+	Key       string          `json:"key"`
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// New creates a cache storing entries in dir. Entries younger than ttl are
+// considered fresh.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// Age returns how long ago the entry was fetched
+func (e *Entry) Age() time.Duration {
+	return time.Since(e.FetchedAt)
+}
+
+// Fresh reports whether the entry is still within the cache TTL
+func (c *Cache) Fresh(e *Entry) bool {
+	return c.ttl > 0 && e.Age() < c.ttl
+}
+
+// Get returns the entry stored for key, regardless of its age
+func (c *Cache) Get(key string) (*Entry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Put stores data (which must be valid JSON) for key
+func (c *Cache) Put(key string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	entry, err := json.Marshal(Entry{
+		Key:       key,
+		FetchedAt: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	// Write to a temporary file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(entry); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every cached entry
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// path returns the file an entry is stored in
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
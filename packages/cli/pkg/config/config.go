@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
@@ -11,24 +12,63 @@ import (
 
 // Global configuration
 type Config struct {
-	APIUrl string `mapstructure:"api_url"`
+	APIUrl      string        `mapstructure:"api_url"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+	MaxRetries  int           `mapstructure:"max_retries"`
+	CacheTTL    time.Duration `mapstructure:"cache_ttl"`
+	CacheOnly   bool          `mapstructure:"cached"`
+	PortForward bool          `mapstructure:"port_forward"`
+	Service     string        `mapstructure:"service"`
+	ServiceNS   string        `mapstructure:"service_namespace"`
 }
 
 // Default configuration values
 const (
-	DefaultAPIURL = "http://localhost:8080/api/v1"
+	DefaultAPIURL     = "http://localhost:8080/api/v1"
+	DefaultTimeout    = 10 * time.Second
+	DefaultMaxRetries = 3
+	DefaultCacheTTL   = 5 * time.Minute
+	DefaultService    = "kite"
+	DefaultServiceNS  = "kite"
 )
 
+// Dir returns the directory holding the configuration file and cache
+func Dir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".konflux-issues"), nil
+}
+
+// CacheDir returns the directory API responses are cached in
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+// SnapshotDir returns the directory the snapshots of the issues of namespaces
+// are saved in, apart from the cache so that clearing it keeps them
+func SnapshotDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snapshots"), nil
+}
+
 // Initializes the configuration
 func InitConfig() error {
-	// Find home directory
-	home, err := homedir.Dir()
+	// Find config directory
+	configDir, err := Dir()
 	if err != nil {
 		return err
 	}
 
 	// Create config directory if it doesn't exist
-	configDir := filepath.Join(home, ".konflux-issues")
 	if _, err := os.Stat(configDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(configDir, 0755); err != nil {
 			return fmt.Errorf("failed to create config directory: %w", err)
@@ -42,6 +82,11 @@ func InitConfig() error {
 
 	// Set default values
 	viper.SetDefault("api_url", DefaultAPIURL)
+	viper.SetDefault("timeout", DefaultTimeout.String())
+	viper.SetDefault("max_retries", DefaultMaxRetries)
+	viper.SetDefault("cache_ttl", DefaultCacheTTL.String())
+	viper.SetDefault("service", DefaultService)
+	viper.SetDefault("service_namespace", DefaultServiceNS)
 
 	// Read the configuration file
 	if err := viper.ReadInConfig(); err != nil {
@@ -66,7 +111,14 @@ func InitConfig() error {
 // GetConfig returns the current configuration
 func GetConfig() Config {
 	return Config{
-		APIUrl: viper.GetString("api_url"),
+		APIUrl:      viper.GetString("api_url"),
+		Timeout:     viper.GetDuration("timeout"),
+		MaxRetries:  viper.GetInt("max_retries"),
+		CacheTTL:    viper.GetDuration("cache_ttl"),
+		CacheOnly:   viper.GetBool("cached"),
+		PortForward: viper.GetBool("port_forward"),
+		Service:     viper.GetString("service"),
+		ServiceNS:   viper.GetString("service_namespace"),
 	}
 }
 
@@ -79,5 +131,10 @@ func SetAPIURL(url string) error {
 // ResetConfig resets the configuration to default values
 func ResetConfig() error {
 	viper.Set("api_url", DefaultAPIURL)
+	viper.Set("timeout", DefaultTimeout.String())
+	viper.Set("max_retries", DefaultMaxRetries)
+	viper.Set("cache_ttl", DefaultCacheTTL.String())
+	viper.Set("service", DefaultService)
+	viper.Set("service_namespace", DefaultServiceNS)
 	return viper.WriteConfig()
 }
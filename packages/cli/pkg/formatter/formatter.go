@@ -55,16 +55,44 @@ func GetStateColor(state string) string {
 
 // PrintIssuesTable prints a table of issues
 func PrintIssuesTable(issues []models.Issue) {
+	renderIssuesTable(issues)
+	fmt.Printf("\nFound %d issue(s)\n", len(issues))
+}
+
+// PrintIssueGroups prints a header for each group of issues, with a table
+// of the issues in the group underneath
+func PrintIssueGroups(grouped *models.GroupedIssuesResponse) {
+	total := 0
+	for _, group := range grouped.Groups {
+		key := group.Key
+		if grouped.GroupBy == "severity" {
+			key = GetSeverityColor(key)
+		}
+
+		fmt.Printf("%s %s (%d issue(s))\n\n", boldColor("▸"), boldColor(key), group.Count)
+		renderIssuesTable(group.Issues)
+		fmt.Println()
+		total += group.Count
+	}
+
+	fmt.Printf("Found %d issue(s) in %d group(s)\n", total, len(grouped.Groups))
+}
+
+// renderIssuesTable renders a table of issues
+func renderIssuesTable(issues []models.Issue) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Title", "Type", "Severity", "State", "Detected"})
+	table.SetHeader([]string{"ID", "Title", "Type", "Severity", "Priority", "State", "Assignee", "Labels", "Detected"})
 
 	// Set minimum width for each column
 	table.SetColMinWidth(0, 36) // ID width (UUID)
 	table.SetColMinWidth(1, 40) // Title width
 	table.SetColMinWidth(2, 12) // Type width
 	table.SetColMinWidth(3, 12) // Severity width
-	table.SetColMinWidth(4, 12) // State width
-	table.SetColMinWidth(5, 30) // Detected width
+	table.SetColMinWidth(4, 8)  // Priority width
+	table.SetColMinWidth(5, 12) // State width
+	table.SetColMinWidth(6, 12) // Assignee width
+	table.SetColMinWidth(7, 20) // Labels width
+	table.SetColMinWidth(8, 30) // Detected width
 
 	table.SetAutoWrapText(true)
 	table.SetRowLine(true)
@@ -92,30 +120,146 @@ func PrintIssuesTable(issues []models.Issue) {
 			issue.Title,
 			issue.IssueType,
 			severityFormatted,
+			issue.Priority,
 			stateFormatted,
+			issue.Assignee,
+			FormatLabels(issue.Labels),
 			detectedAt,
 		})
 	}
 
 	table.Render()
-	fmt.Printf("\nFound %d issue(s)\n", len(issues))
+}
+
+// FormatLabels formats labels as comma separated key=value pairs
+func FormatLabels(labels []models.Label) string {
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, label.Key+"="+label.Value)
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// PrintIssueIDs prints only the ID of each issue, one per line
+func PrintIssueIDs(issues []models.Issue) {
+	for _, issue := range issues {
+		fmt.Println(issue.ID)
+	}
 }
 
 // PrintIssueDetails prints detailed information about an issue
 func PrintIssueDetails(issue *models.Issue) {
+	printIssueInfo(issue)
+
+	if len(issue.RelatedFrom) > 0 {
+		fmt.Println()
+		fmt.Println(boldColor("Related Issues:"))
+		for _, related := range issue.RelatedFrom {
+			if related.Target == nil {
+				continue
+			}
+			if related.Type == "caused-by" {
+				fmt.Printf("• %s: %s (caused by)\n", related.Target.ID, related.Target.Title)
+			} else {
+				fmt.Printf("• %s: %s\n", related.Target.ID, related.Target.Title)
+			}
+		}
+	}
+}
+
+// PrintIssueDescription prints detailed information about an issue
+// followed by the tree of issues related to it
+func PrintIssueDescription(tree *models.IssueTree) {
+	printIssueInfo(tree.Issue)
+	printActivity(tree.Activity)
+
+	fmt.Println()
+	fmt.Println(boldColor("Relationship Tree:"))
+	fmt.Println(formatTreeNode(tree.Issue))
+	if len(tree.Related) == 0 {
+		fmt.Println("└── (no related issues)")
+		return
+	}
+	printTreeChildren(tree.Related, "")
+}
+
+// printActivity prints the activity feed of an issue, one entry per line
+func printActivity(activity []models.ActivityEntry) {
+	if len(activity) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println(boldColor("Activity:"))
+	for _, entry := range activity {
+		line := fmt.Sprintf("  %s  %s", formatTime(entry.Timestamp), entry.Summary)
+		if entry.Actor != "" && !strings.Contains(entry.Summary, entry.Actor) {
+			line += fmt.Sprintf(" (%s)", entry.Actor)
+		}
+		fmt.Println(line)
+	}
+}
+
+// printTreeChildren recursively prints related issues using box-drawing characters
+func printTreeChildren(children []*models.IssueTree, prefix string) {
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s\n", prefix, branch, formatTreeNode(child.Issue))
+		printTreeChildren(child.Related, prefix+indent)
+	}
+}
+
+// formatTreeNode formats a single issue as a line in the relationship tree
+func formatTreeNode(issue *models.Issue) string {
+	return fmt.Sprintf("[%s] %s %s (%s)",
+		GetSeverityColor(issue.Severity),
+		issue.ID,
+		issue.Title,
+		GetStateColor(issue.State),
+	)
+}
+
+// printIssueInfo prints the fields, scope and links of an issue
+func printIssueInfo(issue *models.Issue) {
 	fmt.Println()
 	fmt.Println(boldColor("Issue Details:"))
 	fmt.Printf("%s: %s\n", boldColor("ID"), issue.ID)
 	fmt.Printf("%s: %s\n", boldColor("Title"), issue.Title)
 	fmt.Printf("%s:\n%s\n", boldColor("Description"), issue.Description)
+	if issue.Details != "" {
+		fmt.Printf("%s:\n%s\n", boldColor("Details"), issue.Details)
+	}
 	fmt.Printf("%s: %s\n", boldColor("Type"), issue.IssueType)
 	fmt.Printf("%s: %s\n", boldColor("Severity"), GetSeverityColor(issue.Severity))
+	if issue.Priority != "" {
+		fmt.Printf("%s: %s\n", boldColor("Priority"), issue.Priority)
+	}
 	fmt.Printf("%s: %s\n", boldColor("State"), GetStateColor(issue.State))
 	fmt.Printf("%s: %s\n", boldColor("Detected At"), formatTime(issue.DetectedAt))
 
+	if issue.AcknowledgedAt != nil {
+		fmt.Printf("%s: %s by %s\n", boldColor("Acknowledged At"), formatTime(*issue.AcknowledgedAt), issue.AcknowledgedBy)
+	}
+	if issue.SnoozedUntil != nil && issue.SnoozedUntil.After(time.Now()) {
+		fmt.Printf("%s: %s by %s\n", boldColor("Snoozed Until"), formatTime(*issue.SnoozedUntil), issue.SnoozedBy)
+	}
 	if issue.ResolvedAt != nil {
 		fmt.Printf("%s: %s\n", boldColor("Resolved At"), formatTime(*issue.ResolvedAt))
 	}
+	if issue.ResolvedBy != "" {
+		fmt.Printf("%s: %s\n", boldColor("Resolved By"), issue.ResolvedBy)
+	}
+	if issue.ResolutionReason != "" {
+		fmt.Printf("%s: %s\n", boldColor("Resolution"), issue.ResolutionReason)
+	}
+	if issue.Assignee != "" {
+		fmt.Printf("%s: %s\n", boldColor("Assignee"), issue.Assignee)
+	}
+	if len(issue.Labels) > 0 {
+		fmt.Printf("%s: %s\n", boldColor("Labels"), FormatLabels(issue.Labels))
+	}
 
 	fmt.Println()
 	fmt.Println(boldColor("Scope:"))
@@ -132,13 +276,12 @@ func PrintIssueDetails(issue *models.Issue) {
 		}
 	}
 
-	if len(issue.RelatedFrom) > 0 {
+	if len(issue.ExternalRefs) > 0 {
 		fmt.Println()
-		fmt.Println(boldColor("Related Issues:"))
-		for _, related := range issue.RelatedFrom {
-			if related.Target != nil {
-				fmt.Printf("• %s: %s\n", related.Target.ID, related.Target.Title)
-			}
+		fmt.Println(boldColor("Tracked In:"))
+		for _, ref := range issue.ExternalRefs {
+			blue := color.New(color.FgBlue).SprintFunc()
+			fmt.Printf("• %s %s: %s\n", ref.System, blue(ref.Key), ref.URL)
 		}
 	}
 }
@@ -183,7 +326,206 @@ func PrintIssueDetailsYAML(issue *models.Issue) {
 	fmt.Println(string(data))
 }
 
+// PrintIssueTreeJSON prints an issue and its related issues in JSON format
+func PrintIssueTreeJSON(tree *models.IssueTree) {
+	data, err := json.MarshalIndent(tree, "", " ")
+	if err != nil {
+		fmt.Printf("Error formatting JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintIssueTreeYAML prints an issue and its related issues in YAML format
+func PrintIssueTreeYAML(tree *models.IssueTree) {
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		fmt.Printf("Error formatting YAML: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
 // Helper function to format time
 func formatTime(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")
 }
+
+// PrintDiagnostics prints the results of doctor checks, with a fix for each problem
+func PrintDiagnostics(checks []models.DiagnosticCheck) {
+	counts := map[string]int{}
+	for _, check := range checks {
+		counts[check.Status]++
+
+		var symbol string
+		switch check.Status {
+		case "ok":
+			symbol = successColor("✓")
+		case "warning":
+			symbol = warningColor("!")
+		case "failed":
+			symbol = errorColor("✗")
+		default:
+			symbol = neutralColor("-")
+		}
+
+		fmt.Printf("%s %s: %s\n", symbol, boldColor(check.Name), check.Message)
+		if check.Fix != "" {
+			fmt.Printf("    Fix: %s\n", check.Fix)
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d warnings, %d failed, %d skipped\n",
+		counts["ok"], counts["warning"], counts["failed"], counts["skipped"])
+}
+
+// PrintDiagnosticsJSON prints the results of doctor checks in JSON format
+func PrintDiagnosticsJSON(checks []models.DiagnosticCheck) {
+	data, err := json.MarshalIndent(checks, "", " ")
+	if err != nil {
+		fmt.Printf("Error formatting JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintDiagnosticsYAML prints the results of doctor checks in YAML format
+func PrintDiagnosticsYAML(checks []models.DiagnosticCheck) {
+	data, err := yaml.Marshal(checks)
+	if err != nil {
+		fmt.Printf("Error formatting YAML: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintWebhookResponseJSON prints a webhook response in JSON format
+func PrintWebhookResponseJSON(resp *models.WebhookResponse) {
+	data, err := json.MarshalIndent(resp, "", " ")
+	if err != nil {
+		fmt.Printf("Error formatting JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintWebhookResponseYAML prints a webhook response in YAML format
+func PrintWebhookResponseYAML(resp *models.WebhookResponse) {
+	data, err := yaml.Marshal(resp)
+	if err != nil {
+		fmt.Printf("Error formatting YAML: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintIssueGroupsJSON prints grouped issues in JSON format
+func PrintIssueGroupsJSON(grouped *models.GroupedIssuesResponse) {
+	data, err := json.MarshalIndent(grouped, "", " ")
+	if err != nil {
+		fmt.Printf("Error formatting JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintIssueGroupsYAML prints grouped issues in YAML format
+func PrintIssueGroupsYAML(grouped *models.GroupedIssuesResponse) {
+	data, err := yaml.Marshal(grouped)
+	if err != nil {
+		fmt.Printf("Error formatting YAML: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintIssueDiff prints the issues new, resolved and still active since the
+// baseline of a diff, a table per change
+func PrintIssueDiff(diff *models.IssueDiff) {
+	if diff.BaselineAt != nil {
+		fmt.Printf("Changes in namespace %s since the snapshot of %s\n\n", boldColor(diff.Namespace), formatTime(*diff.BaselineAt))
+	} else {
+		fmt.Printf("Changes in namespace %s since %s, from when the issues were detected and resolved\n\n", boldColor(diff.Namespace), formatTime(diff.Since))
+	}
+
+	for _, section := range []struct {
+		title  string
+		issues []models.Issue
+	}{
+		{errorColor("New"), diff.New},
+		{successColor("Resolved"), diff.Resolved},
+		{warningColor("Still active"), diff.StillActive},
+	} {
+		fmt.Printf("%s %s (%d issue(s))\n\n", boldColor("▸"), boldColor(section.title), len(section.issues))
+		if len(section.issues) > 0 {
+			renderIssuesTable(section.issues)
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("%d new, %d resolved, %d still active\n", len(diff.New), len(diff.Resolved), len(diff.StillActive))
+}
+
+// PrintIssueDiffJSON prints a diff of issues in JSON format
+func PrintIssueDiffJSON(diff *models.IssueDiff) {
+	data, err := json.MarshalIndent(diff, "", " ")
+	if err != nil {
+		fmt.Printf("Error formatting JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintIssueDiffYAML prints a diff of issues in YAML format
+func PrintIssueDiffYAML(diff *models.IssueDiff) {
+	data, err := yaml.Marshal(diff)
+	if err != nil {
+		fmt.Printf("Error formatting YAML: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintViewsTable prints a table of views
+func PrintViewsTable(views []models.SavedView) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Filters", "Updated"})
+
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t")
+	table.SetNoWhiteSpace(true)
+
+	for _, view := range views {
+		table.Append([]string{boldColor(view.Name), view.Query, formatTime(view.UpdatedAt)})
+	}
+
+	table.Render()
+}
+
+// PrintViewsJSON prints views in JSON format
+func PrintViewsJSON(views []models.SavedView) {
+	data, err := json.MarshalIndent(views, "", " ")
+	if err != nil {
+		fmt.Printf("Error formatting JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// PrintViewsYAML prints views in YAML format
+func PrintViewsYAML(views []models.SavedView) {
+	data, err := yaml.Marshal(views)
+	if err != nil {
+		fmt.Printf("Error formatting YAML: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,60 @@
+package kube
+
+import (
+	"errors"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ErrNoKubeconfig is returned when no kubeconfig could be found
+var ErrNoKubeconfig = errors.New("no kubeconfig found")
+
+// Options selects the kubeconfig and context to read, like kubectl's
+// --kubeconfig and --context flags. Empty values use the kubectl defaults:
+// the KUBECONFIG environment variable or ~/.kube/config, and the current context.
+type Options struct {
+	Kubeconfig string
+	Context    string
+}
+
+// clientConfig loads the kubeconfig the same way kubectl does
+func clientConfig(opts Options) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if opts.Kubeconfig != "" {
+		rules.ExplicitPath = opts.Kubeconfig
+	}
+
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.Context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+}
+
+// CurrentNamespace returns the namespace of the selected kubeconfig context.
+//
+// When running inside a pod without a kubeconfig, the namespace of the pod's
+// service account is returned. "default" is returned for contexts that
+// don't set a namespace.
+func CurrentNamespace(opts Options) (string, error) {
+	namespace, _, err := clientConfig(opts).Namespace()
+	if clientcmd.IsEmptyConfig(err) {
+		return "", ErrNoKubeconfig
+	}
+	if err != nil {
+		return "", err
+	}
+	return namespace, nil
+}
+
+// CurrentContext returns the name of the selected kubeconfig context
+func CurrentContext(opts Options) (string, error) {
+	raw, err := clientConfig(opts).RawConfig()
+	if err != nil {
+		return "", err
+	}
+	if opts.Context != "" {
+		return opts.Context, nil
+	}
+	if raw.CurrentContext == "" {
+		return "", ErrNoKubeconfig
+	}
+	return raw.CurrentContext, nil
+}
@@ -0,0 +1,158 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// portForwardTimeout bounds how long establishing a port-forward may take
+const portForwardTimeout = 15 * time.Second
+
+// PortForward is a running port-forward from a local port to a pod backing
+// a service, like 'kubectl port-forward svc/<service>'
+type PortForward struct {
+	// LocalPort is the port on 127.0.0.1 forwarded to the service
+	LocalPort uint16
+	// Pod is the name of the pod the traffic is forwarded to
+	Pod string
+
+	stop chan struct{}
+}
+
+// Close stops the port-forward
+func (p *PortForward) Close() {
+	close(p.stop)
+}
+
+// StartPortForward forwards a random local port to the first port of a
+// service. The traffic goes to one ready pod selected by the service.
+func StartPortForward(opts Options, namespace, service string) (*PortForward, error) {
+	restConfig, err := clientConfig(opts).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), portForwardTimeout)
+	defer cancel()
+
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get service %s/%s: %w", namespace, service, err)
+	}
+	if len(svc.Spec.Ports) == 0 || len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s/%s has no ports or selector to forward to", namespace, service)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the pods of service %s/%s: %w", namespace, service, err)
+	}
+	pod := readyPod(pods.Items)
+	if pod == nil {
+		return nil, fmt.Errorf("service %s/%s has no ready pods", namespace, service)
+	}
+	targetPort, err := podPort(pod, svc.Spec.Ports[0])
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	var errOut bytes.Buffer
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"},
+		[]string{fmt.Sprintf("0:%d", targetPort)}, stop, ready, io.Discard, &errOut)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		failed <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err := <-failed:
+		if msg := strings.TrimSpace(errOut.String()); err == nil && msg != "" {
+			err = fmt.Errorf("%s", msg)
+		}
+		return nil, fmt.Errorf("cannot forward to pod %s/%s: %w", namespace, pod.Name, err)
+	case <-time.After(portForwardTimeout):
+		close(stop)
+		return nil, fmt.Errorf("timed out forwarding to pod %s/%s", namespace, pod.Name)
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stop)
+		return nil, fmt.Errorf("cannot determine the forwarded local port: %v", err)
+	}
+
+	return &PortForward{LocalPort: ports[0].Local, Pod: pod.Name, stop: stop}, nil
+}
+
+// readyPod returns the first running pod whose containers are all ready
+func readyPod(pods []corev1.Pod) *corev1.Pod {
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return pod
+			}
+		}
+	}
+	return nil
+}
+
+// podPort resolves the container port a service port targets on a pod
+func podPort(pod *corev1.Pod, port corev1.ServicePort) (int32, error) {
+	switch {
+	case port.TargetPort.Type == intstr.String:
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == port.TargetPort.StrVal {
+					return containerPort.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no port named %s", pod.Name, port.TargetPort.StrVal)
+	case port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal, nil
+	default:
+		return port.Port, nil
+	}
+}
@@ -6,24 +6,49 @@ type IssuesResponse struct {
 	Data []Issue `json:"data"`
 }
 
+// GroupedIssuesResponse represents issues grouped by resource, type or severity
+type GroupedIssuesResponse struct {
+	GroupBy string       `json:"groupBy"`
+	Groups  []IssueGroup `json:"groups"`
+	Total   int64        `json:"total"`
+}
+
+// IssueGroup represents a group of issues sharing the same resource, type or severity
+type IssueGroup struct {
+	Key    string  `json:"key"`
+	Count  int     `json:"count"`
+	Issues []Issue `json:"issues"`
+}
+
 // Issue represents an issue in Konflux
 type Issue struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Severity    string     `json:"severity"`
-	IssueType   string     `json:"issueType"`
-	State       string     `json:"state"`
-	DetectedAt  time.Time  `json:"detectedAt"`
-	ResolvedAt  *time.Time `json:"resolvedAt"`
-	Namespace   string     `json:"namespace"`
-	ScopeID     string     `json:"scopeId"`
-	Scope       Scope      `json:"scope"`
-	Links       []Link     `json:"links"`
-	RelatedFrom []Related  `json:"relatedFrom"`
-	RelatedTo   []Related  `json:"relatedTo"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
+	ID               string        `json:"id"`
+	Title            string        `json:"title"`
+	Description      string        `json:"description"`
+	Details          string        `json:"details"`
+	Severity         string        `json:"severity"`
+	Priority         string        `json:"priority"`
+	IssueType        string        `json:"issueType"`
+	State            string        `json:"state"`
+	DetectedAt       time.Time     `json:"detectedAt"`
+	ResolvedAt       *time.Time    `json:"resolvedAt"`
+	ResolutionReason string        `json:"resolutionReason"`
+	ResolvedBy       string        `json:"resolvedBy"`
+	AcknowledgedAt   *time.Time    `json:"acknowledgedAt"`
+	AcknowledgedBy   string        `json:"acknowledgedBy"`
+	SnoozedUntil     *time.Time    `json:"snoozedUntil"`
+	SnoozedBy        string        `json:"snoozedBy"`
+	Namespace        string        `json:"namespace"`
+	Assignee         string        `json:"assignee"`
+	ScopeID          string        `json:"scopeId"`
+	Scope            Scope         `json:"scope"`
+	Links            []Link        `json:"links"`
+	Labels           []Label       `json:"labels"`
+	ExternalRefs     []ExternalRef `json:"externalRefs"`
+	RelatedFrom      []Related     `json:"relatedFrom"`
+	RelatedTo        []Related     `json:"relatedTo"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	UpdatedAt        time.Time     `json:"updatedAt"`
 }
 
 // Scope represents the scope of an issue
@@ -42,15 +67,57 @@ type Link struct {
 	IssueID string `json:"issueId"`
 }
 
+// Label represents a key/value label attached to an issue
+type Label struct {
+	ID      string `json:"id"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	IssueID string `json:"issueId"`
+}
+
+// ExternalRef represents an issue tracked in another system, e.g. a Jira ticket
+type ExternalRef struct {
+	ID      string `json:"id"`
+	System  string `json:"system"`
+	Key     string `json:"key"`
+	URL     string `json:"url"`
+	IssueID string `json:"issueId"`
+}
+
 // Related represents a related issue
 type Related struct {
 	ID       string `json:"id"`
 	SourceID string `json:"sourceId"`
 	TargetID string `json:"targetId"`
+	Type     string `json:"type"`
 	Target   *Issue `json:"target,omitempty"`
 	Source   *Issue `json:"source,omitempty"`
 }
 
+// IssueTree represents an issue along with the tree of issues related to it,
+// and the activity feed of the issue at its root
+type IssueTree struct {
+	Issue    *Issue          `json:"issue"`
+	Activity []ActivityEntry `json:"activity,omitempty" yaml:"activity,omitempty"`
+	Related  []*IssueTree    `json:"related,omitempty"`
+}
+
+// ActivityEntry represents an entry of the activity feed of an issue, e.g. its
+// detection, a webhook reporting it again or its resolution
+type ActivityEntry struct {
+	Type      string            `json:"type" yaml:"type"`
+	Timestamp time.Time         `json:"timestamp" yaml:"timestamp"`
+	Actor     string            `json:"actor,omitempty" yaml:"actor,omitempty"`
+	Summary   string            `json:"summary" yaml:"summary"`
+	Details   map[string]string `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// ActivityResponse represents the API response of the activity feed of an issue
+type ActivityResponse struct {
+	Data  []ActivityEntry `json:"data"`
+	Total int             `json:"total"`
+}
+
 // TypeCount represents the count of issues by type
 type TypeCount struct {
 	IssueType string `json:"issueType"`
@@ -62,3 +129,109 @@ type SeverityCount struct {
 	Severity string `json:"severity"`
 	Count    int    `json:"count"`
 }
+
+// VersionInfo represents the version information reported by the API
+type VersionInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+	APIVersion  string `json:"apiVersion"`
+}
+
+// HealthStatus represents the health report of the API and its components
+type HealthStatus struct {
+	Status     string                     `json:"status"`
+	Message    string                     `json:"message"`
+	Timestamp  time.Time                  `json:"timestamp"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// ComponentHealth represents the health of a single API component
+type ComponentHealth struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// DiagnosticCheck represents the result of a single doctor check
+type DiagnosticCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+	Fix     string `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+// PipelineFailurePayload represents the payload of the pipeline failure webhook
+type PipelineFailurePayload struct {
+	PipelineName  string `json:"pipelineName"`
+	Namespace     string `json:"namespace"`
+	FailureReason string `json:"failureReason"`
+	Severity      string `json:"severity,omitempty"`
+	RunID         string `json:"runId,omitempty"`
+	LogsURL       string `json:"logsUrl,omitempty"`
+}
+
+// PipelineSuccessPayload represents the payload of the pipeline success webhook
+type PipelineSuccessPayload struct {
+	PipelineName string `json:"pipelineName"`
+	Namespace    string `json:"namespace"`
+}
+
+// WebhookResponse represents the response of a webhook endpoint
+type WebhookResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Issue   *Issue `json:"issue,omitempty"`
+}
+
+// SavedView represents a named set of issue filters, shared in a namespace or personal to a user
+type SavedView struct {
+	Namespace string    `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	User      string    `json:"user,omitempty" yaml:"user,omitempty"`
+	Name      string    `json:"name" yaml:"name"`
+	Query     string    `json:"query" yaml:"query"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
+}
+
+// SavedViewsResponse represents the API response of a list of views
+type SavedViewsResponse struct {
+	Data []SavedView `json:"data"`
+}
+
+// IssueDiff represents the changes of the issues of a namespace since a
+// baseline, a snapshot of its issues or, without snapshot old enough, when
+// the issues were detected and resolved
+type IssueDiff struct {
+	Namespace   string     `json:"namespace" yaml:"namespace"`
+	Since       time.Time  `json:"since" yaml:"since"`
+	Baseline    string     `json:"baseline" yaml:"baseline"`
+	BaselineAt  *time.Time `json:"baselineAt,omitempty" yaml:"baselineAt,omitempty"`
+	New         []Issue    `json:"new" yaml:"new"`
+	Resolved    []Issue    `json:"resolved" yaml:"resolved"`
+	StillActive []Issue    `json:"stillActive" yaml:"stillActive"`
+}
+
+// Dashboard represents the overview of the issues of a namespace
+type Dashboard struct {
+	Namespace         string             `json:"namespace"`
+	ActiveBySeverity  map[string]int     `json:"activeBySeverity"`
+	ActiveTotal       int                `json:"activeTotal"`
+	RecentIssues      []Issue            `json:"recentIssues"`
+	FlappingResources []FlappingResource `json:"flappingResources"`
+	SLABreaches       []SLABreach        `json:"slaBreaches"`
+	SLABreachesTotal  int                `json:"slaBreachesTotal"`
+}
+
+// FlappingResource represents a resource whose issues keep being reopened
+type FlappingResource struct {
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Reopens      int    `json:"reopens"`
+}
+
+// SLABreach represents an active issue past the SLA target of its severity
+type SLABreach struct {
+	Issue       Issue     `json:"issue"`
+	TargetHours int       `json:"targetHours"`
+	BreachedAt  time.Time `json:"breachedAt"`
+}
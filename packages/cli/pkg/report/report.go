@@ -0,0 +1,328 @@
+// Package report builds shareable summaries of the issues of a namespace over
+// a period, rendered as Markdown, e.g. to paste in chats and docs, or HTML
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+)
+
+// Formats of the reports
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Formats returns the formats reports are rendered in
+func Formats() []string {
+	return []string{FormatMarkdown, FormatHTML}
+}
+
+// maxCriticalIssues caps the critical issues listed in a report
+const maxCriticalIssues = 10
+
+// severities are the severities of the issues, most severe first
+var severities = []string{"critical", "major", "minor", "info"}
+
+// Data is what reports are built from
+type Data struct {
+	// Dashboard is the overview of the issues of the namespace
+	Dashboard *models.Dashboard
+	// Critical are the critical issues of the namespace, the most recently detected first
+	Critical []models.Issue
+	// Detected are the issues detected over the period
+	Detected []models.Issue
+	// Resolved are the issues resolved over the period
+	Resolved []models.Issue
+}
+
+// Report is the summary of the issues of a namespace over a period
+type Report struct {
+	Namespace   string
+	Since       time.Time
+	GeneratedAt time.Time
+
+	// ActiveBySeverity counts the open issues, most severe first
+	ActiveBySeverity []models.SeverityCount
+	ActiveTotal      int
+	SLABreachesTotal int
+
+	// Opened and Resolved count the issues detected and resolved over the period
+	Opened   int
+	Resolved int
+	// MTTR is the mean time to resolve the issues resolved over the period,
+	// from their detection, zero when none was
+	MTTR time.Duration
+
+	// Critical are the open critical issues, the most recently detected
+	// first, up to 10 of CriticalTotal
+	Critical      []models.Issue
+	CriticalTotal int
+
+	// TopOffenders are the resources with the most issues detected over the period
+	TopOffenders []Offender
+	// Flapping are the resources whose issues were reopened the most
+	Flapping []models.FlappingResource
+}
+
+// Offender is a resource and the issues detected for it over the period
+type Offender struct {
+	Resource string
+	Issues   int
+	Open     int
+}
+
+// Build builds the report of a namespace since a time, listing top offenders
+func Build(namespace string, since, now time.Time, data Data, top int) *Report {
+	r := &Report{
+		Namespace:   namespace,
+		Since:       since,
+		GeneratedAt: now,
+		Opened:      len(data.Detected),
+		Resolved:    len(data.Resolved),
+	}
+
+	if data.Dashboard != nil {
+		for _, severity := range severities {
+			r.ActiveBySeverity = append(r.ActiveBySeverity, models.SeverityCount{
+				Severity: severity,
+				Count:    data.Dashboard.ActiveBySeverity[severity],
+			})
+		}
+		r.ActiveTotal = data.Dashboard.ActiveTotal
+		r.SLABreachesTotal = data.Dashboard.SLABreachesTotal
+		r.Flapping = data.Dashboard.FlappingResources
+		if len(r.Flapping) > top {
+			r.Flapping = r.Flapping[:top]
+		}
+	}
+
+	for _, issue := range data.Critical {
+		if !isOpen(issue) {
+			continue
+		}
+		r.CriticalTotal++
+		if len(r.Critical) < maxCriticalIssues {
+			r.Critical = append(r.Critical, issue)
+		}
+	}
+
+	r.MTTR = meanTimeToResolve(data.Resolved)
+	r.TopOffenders = topOffenders(data.Detected, top)
+	return r
+}
+
+// meanTimeToResolve returns the mean time issues took to be resolved
+func meanTimeToResolve(issues []models.Issue) time.Duration {
+	var total time.Duration
+	var count int
+	for _, issue := range issues {
+		if issue.ResolvedAt == nil || issue.ResolvedAt.Before(issue.DetectedAt) {
+			continue
+		}
+		total += issue.ResolvedAt.Sub(issue.DetectedAt)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// topOffenders returns the top resources with the most issues, those with
+// the most open issues first on ties
+func topOffenders(issues []models.Issue, top int) []Offender {
+	byResource := map[string]*Offender{}
+	for _, issue := range issues {
+		resource := Resource(issue)
+		offender, ok := byResource[resource]
+		if !ok {
+			offender = &Offender{Resource: resource}
+			byResource[resource] = offender
+		}
+		offender.Issues++
+		if isOpen(issue) {
+			offender.Open++
+		}
+	}
+
+	offenders := make([]Offender, 0, len(byResource))
+	for _, offender := range byResource {
+		offenders = append(offenders, *offender)
+	}
+	sort.Slice(offenders, func(i, j int) bool {
+		a, b := offenders[i], offenders[j]
+		if a.Issues != b.Issues {
+			return a.Issues > b.Issues
+		}
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		return a.Resource < b.Resource
+	})
+	if len(offenders) > top {
+		offenders = offenders[:top]
+	}
+	return offenders
+}
+
+// Resource returns the resource of an issue, as <resourceType>/<resourceName>
+func Resource(issue models.Issue) string {
+	return issue.Scope.ResourceType + "/" + issue.Scope.ResourceName
+}
+
+// isOpen reports whether an issue is open, in any state but RESOLVED
+func isOpen(issue models.Issue) bool {
+	return issue.State != "RESOLVED"
+}
+
+// FormatDuration formats a duration for humans, to the minute, e.g. 2d 4h or 5h 12m
+func FormatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// Render writes a report in format
+func Render(w io.Writer, r *Report, format string) error {
+	switch format {
+	case FormatMarkdown:
+		return markdownTemplate.Execute(w, r)
+	case FormatHTML:
+		return htmlTemplate.Execute(w, r)
+	}
+	return fmt.Errorf("unknown report format %q, must be one of: %s", format, strings.Join(Formats(), ", "))
+}
+
+var funcs = map[string]any{
+	"duration": FormatDuration,
+	"resource": Resource,
+	"time":     func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+	"md":       escapeMarkdown,
+}
+
+// escapeMarkdown escapes text for the cells of Markdown tables
+func escapeMarkdown(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "<", "&lt;").Replace(s)
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(funcs).Parse(
+	`# Issues report: {{md .Namespace}}
+
+Period: {{time .Since}} to {{time .GeneratedAt}}
+
+## Summary
+
+| Metric | Value |
+| --- | --- |
+| Active issues | {{.ActiveTotal}} |
+{{- range .ActiveBySeverity}}
+| Active {{.Severity}} | {{.Count}} |
+{{- end}}
+| SLA breaches | {{.SLABreachesTotal}} |
+| Opened over the period | {{.Opened}} |
+| Resolved over the period | {{.Resolved}} |
+| MTTR | {{if .Resolved}}{{duration .MTTR}}{{else}}n/a{{end}} |
+
+## Critical issues
+{{if .Critical}}
+| Issue | Resource | State | Detected |
+| --- | --- | --- | --- |
+{{- range .Critical}}
+| {{md .Title}} | {{md (resource .)}} | {{.State}} | {{time .DetectedAt}} |
+{{- end}}
+{{- if gt .CriticalTotal (len .Critical)}}
+
+_and {{.CriticalTotal}} critical issues in total_
+{{- end}}
+{{else}}
+No active critical issues.
+{{end}}
+## Top offenders
+{{if .TopOffenders}}
+| Resource | Issues | Open |
+| --- | --- | --- |
+{{- range .TopOffenders}}
+| {{md .Resource}} | {{.Issues}} | {{.Open}} |
+{{- end}}
+{{else}}
+No issues detected over the period.
+{{end}}
+{{- if .Flapping}}
+## Flapping resources
+
+| Resource | Reopens |
+| --- | --- |
+{{- range .Flapping}}
+| {{md .ResourceType}}/{{md .ResourceName}} | {{.Reopens}} |
+{{- end}}
+{{end}}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(
+	`<h1>Issues report: {{.Namespace}}</h1>
+<p>Period: {{time .Since}} to {{time .GeneratedAt}}</p>
+<h2>Summary</h2>
+<table>
+<tr><th>Metric</th><th>Value</th></tr>
+<tr><td>Active issues</td><td>{{.ActiveTotal}}</td></tr>
+{{- range .ActiveBySeverity}}
+<tr><td>Active {{.Severity}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+<tr><td>SLA breaches</td><td>{{.SLABreachesTotal}}</td></tr>
+<tr><td>Opened over the period</td><td>{{.Opened}}</td></tr>
+<tr><td>Resolved over the period</td><td>{{.Resolved}}</td></tr>
+<tr><td>MTTR</td><td>{{if .Resolved}}{{duration .MTTR}}{{else}}n/a{{end}}</td></tr>
+</table>
+<h2>Critical issues</h2>
+{{- if .Critical}}
+<table>
+<tr><th>Issue</th><th>Resource</th><th>State</th><th>Detected</th></tr>
+{{- range .Critical}}
+<tr><td>{{.Title}}{{with .Description}}<br><small>{{.}}</small>{{end}}</td><td>{{resource .}}</td><td>{{.State}}</td><td>{{time .DetectedAt}}</td></tr>
+{{- end}}
+</table>
+{{- if gt .CriticalTotal (len .Critical)}}
+<p><em>and {{.CriticalTotal}} critical issues in total</em></p>
+{{- end}}
+{{- else}}
+<p>No active critical issues.</p>
+{{- end}}
+<h2>Top offenders</h2>
+{{- if .TopOffenders}}
+<table>
+<tr><th>Resource</th><th>Issues</th><th>Open</th></tr>
+{{- range .TopOffenders}}
+<tr><td>{{.Resource}}</td><td>{{.Issues}}</td><td>{{.Open}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No issues detected over the period.</p>
+{{- end}}
+{{- if .Flapping}}
+<h2>Flapping resources</h2>
+<table>
+<tr><th>Resource</th><th>Reopens</th></tr>
+{{- range .Flapping}}
+<tr><td>{{.ResourceType}}/{{.ResourceName}}</td><td>{{.Reopens}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+`))
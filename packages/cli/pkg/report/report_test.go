@@ -0,0 +1,82 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+)
+
+// hostileReport builds a report whose issues have titles, descriptions and
+// resources with markup in them
+func hostileReport() *Report {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	critical := models.Issue{
+		ID:          "critical-1",
+		Title:       `<script>alert("title")</script> & "quoted" | piped`,
+		Description: `<img src=x onerror='alert(1)'> failed & retried`,
+		Severity:    "critical",
+		State:       "ACTIVE",
+		DetectedAt:  now.Add(-time.Hour),
+		Scope:       models.Scope{ResourceType: "component", ResourceName: "<b>frontend</b>"},
+	}
+	data := Data{
+		Dashboard: &models.Dashboard{Namespace: "team-alpha", ActiveBySeverity: map[string]int{"critical": 1}, ActiveTotal: 1},
+		Critical:  []models.Issue{critical},
+		Detected:  []models.Issue{critical},
+	}
+	return Build("team-alpha", now.Add(-7*24*time.Hour), now, data, 5)
+}
+
+func TestRender_HTMLEscaping(t *testing.T) {
+	var out bytes.Buffer
+	if err := Render(&out, hostileReport(), FormatHTML); err != nil {
+		t.Fatalf("Failed to render report: %v", err)
+	}
+	html := out.String()
+
+	for _, raw := range []string{"<script>", "<img", "<b>", `"title"`, "& retried"} {
+		if strings.Contains(html, raw) {
+			t.Errorf("Expected %q to be escaped, got:\n%s", raw, html)
+		}
+	}
+	for _, escaped := range []string{
+		"&lt;script&gt;alert(&#34;title&#34;)&lt;/script&gt; &amp; &#34;quoted&#34; | piped",
+		"<small>&lt;img src=x onerror=&#39;alert(1)&#39;&gt; failed &amp; retried</small>",
+		"component/&lt;b&gt;frontend&lt;/b&gt;",
+	} {
+		if !strings.Contains(html, escaped) {
+			t.Errorf("Expected %q in the report, got:\n%s", escaped, html)
+		}
+	}
+}
+
+func TestRender_MarkdownEscaping(t *testing.T) {
+	var out bytes.Buffer
+	if err := Render(&out, hostileReport(), FormatMarkdown); err != nil {
+		t.Fatalf("Failed to render report: %v", err)
+	}
+	markdown := out.String()
+
+	if strings.Contains(markdown, "<script>") || strings.Contains(markdown, "<b>") {
+		t.Errorf("Expected the markup to be escaped, got:\n%s", markdown)
+	}
+	for _, escaped := range []string{
+		`| &lt;script>alert("title")&lt;/script> & "quoted" \| piped | component/&lt;b>frontend&lt;/b> | ACTIVE |`,
+		`| component/&lt;b>frontend&lt;/b> | 1 | 1 |`,
+	} {
+		if !strings.Contains(markdown, escaped) {
+			t.Errorf("Expected %q in the report, got:\n%s", escaped, markdown)
+		}
+	}
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	var out bytes.Buffer
+	err := Render(&out, hostileReport(), "pdf")
+	if err == nil || !strings.Contains(err.Error(), `unknown report format "pdf"`) {
+		t.Errorf("Expected an unknown format error, got %v", err)
+	}
+}
@@ -0,0 +1,416 @@
+// Package selector implements the expressions of --selector, filtering issues
+// client-side on combinations of fields the API filters don't support, e.g.
+//
+//	severity in (major,critical) && resource =~ "frontend.*"
+//
+// Comparisons are combined with && and ||, negated with ! and grouped with
+// parentheses. The operators are == and != for equality, =~ and !~ for
+// regular expressions matching the whole value, and in and notin for lists.
+// A field alone checks it exists: labels the issue has, other fields not
+// empty, e.g. label.team or !assignee. Values are quoted strings, or words of
+// letters, digits and -_.:/ characters.
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+)
+
+// fields are the fields of an issue selectors compare, label.<key> being the
+// value of the label key
+var fields = map[string]func(models.Issue) string{
+	"id":                func(i models.Issue) string { return i.ID },
+	"title":             func(i models.Issue) string { return i.Title },
+	"description":       func(i models.Issue) string { return i.Description },
+	"severity":          func(i models.Issue) string { return i.Severity },
+	"priority":          func(i models.Issue) string { return i.Priority },
+	"type":              func(i models.Issue) string { return i.IssueType },
+	"state":             func(i models.Issue) string { return i.State },
+	"namespace":         func(i models.Issue) string { return i.Namespace },
+	"assignee":          func(i models.Issue) string { return i.Assignee },
+	"resource":          func(i models.Issue) string { return i.Scope.ResourceName },
+	"resourceType":      func(i models.Issue) string { return i.Scope.ResourceType },
+	"resourceNamespace": func(i models.Issue) string { return i.Scope.ResourceNamespace },
+	"acknowledgedBy":    func(i models.Issue) string { return i.AcknowledgedBy },
+}
+
+// Fields returns the fields selectors compare, sorted
+func Fields() []string {
+	names := make([]string, 0, len(fields)+1)
+	for name := range fields {
+		names = append(names, name)
+	}
+	names = append(names, "label.<key>")
+	sort.Strings(names)
+	return names
+}
+
+// Selector matches the issues of an expression
+type Selector struct {
+	expression string
+	root       node
+}
+
+// Parse parses a selector expression
+func Parse(expression string) (*Selector, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", expression, err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", expression, err)
+	}
+	return &Selector{expression: expression, root: root}, nil
+}
+
+// String returns the expression of the selector
+func (s *Selector) String() string {
+	return s.expression
+}
+
+// Matches reports whether an issue matches the selector
+func (s *Selector) Matches(issue models.Issue) bool {
+	return s.root.matches(issue)
+}
+
+// Filter returns the issues matching the selector
+func (s *Selector) Filter(issues []models.Issue) []models.Issue {
+	matching := make([]models.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if s.Matches(issue) {
+			matching = append(matching, issue)
+		}
+	}
+	return matching
+}
+
+// node is a node of the tree of an expression
+type node interface {
+	matches(models.Issue) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) matches(issue models.Issue) bool {
+	return n.left.matches(issue) && n.right.matches(issue)
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) matches(issue models.Issue) bool {
+	return n.left.matches(issue) || n.right.matches(issue)
+}
+
+// existence checks a field exists in the issues
+type existence struct {
+	exists func(models.Issue) bool
+}
+
+func (e existence) matches(issue models.Issue) bool {
+	return e.exists(issue)
+}
+
+type notNode struct{ operand node }
+
+func (n notNode) matches(issue models.Issue) bool {
+	return !n.operand.matches(issue)
+}
+
+// comparison compares a field of the issues with values
+type comparison struct {
+	field   func(models.Issue) string
+	compare func(string) bool
+}
+
+func (c comparison) matches(issue models.Issue) bool {
+	return c.compare(c.field(issue))
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("%q", t.value)
+	}
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+// operators are the operators of the expressions, longest first
+var operators = []string{"&&", "||", "==", "!=", "=~", "!~", "!"}
+
+// isWordRune reports whether r may be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.:/", r)
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ","})
+			i++
+		case r == '"' || r == '\'':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				value.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value.String()})
+			i = j + 1
+		case isWordRune(r):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[i:j])})
+			i = j
+		default:
+			rest := string(runes[i:])
+			operator := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(rest, candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: operator})
+			i += len([]rune(operator))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+// parser parses the tokens of an expression, && binding tighter than ||
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(operator string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.value == operator
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ')', got %s", t)
+		}
+		return expression, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected a field, got %s", t)
+	}
+	field, err := lookupField(t.value)
+	if err != nil {
+		return nil, err
+	}
+
+	// A field alone, ending the expression or its operand, checks it exists
+	if next := p.peek(); next.kind == tokenEOF || next.kind == tokenRParen ||
+		next.kind == tokenOperator && (next.value == "&&" || next.value == "||") {
+		return existence{lookupExistence(t.value, field)}, nil
+	}
+
+	operator := p.next()
+	switch {
+	case operator.kind == tokenOperator && (operator.value == "==" || operator.value == "!="):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		equal := operator.value == "=="
+		return comparison{field, func(v string) bool { return (v == value) == equal }}, nil
+
+	case operator.kind == tokenOperator && (operator.value == "=~" || operator.value == "!~"):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", value, err)
+		}
+		match := operator.value == "=~"
+		return comparison{field, func(v string) bool { return re.MatchString(v) == match }}, nil
+
+	case operator.kind == tokenWord && (operator.value == "in" || operator.value == "notin"):
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		in := operator.value == "in"
+		return comparison{field, func(v string) bool { return slices.Contains(values, v) == in }}, nil
+	}
+	return nil, fmt.Errorf("expected an operator after %s, got %s", t, operator)
+}
+
+func (p *parser) parseValue() (string, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return "", fmt.Errorf("expected a value, got %s", t)
+	}
+	return t.value, nil
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if t := p.next(); t.kind != tokenLParen {
+		return nil, fmt.Errorf("expected '(' to start a list, got %s", t)
+	}
+	var values []string
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch t := p.next(); t.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return values, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or ')' in list, got %s", t)
+		}
+	}
+}
+
+// lookupField returns the accessor of a field, a label when prefixed with label.
+func lookupField(name string) (func(models.Issue) string, error) {
+	if key, ok := strings.CutPrefix(name, "label."); ok && key != "" {
+		return func(issue models.Issue) string {
+			for _, label := range issue.Labels {
+				if label.Key == key {
+					return label.Value
+				}
+			}
+			return ""
+		}, nil
+	}
+	if field, ok := fields[name]; ok {
+		return field, nil
+	}
+	return nil, fmt.Errorf("unknown field %q, must be one of: %s", name, strings.Join(Fields(), ", "))
+}
+
+// lookupExistence returns whether the issues have a field: a label when
+// prefixed with label., whatever its value, or a value for the other fields
+func lookupExistence(name string, field func(models.Issue) string) func(models.Issue) bool {
+	if key, ok := strings.CutPrefix(name, "label."); ok {
+		return func(issue models.Issue) bool {
+			return slices.ContainsFunc(issue.Labels, func(label models.Label) bool { return label.Key == key })
+		}
+	}
+	return func(issue models.Issue) bool { return field(issue) != "" }
+}
@@ -0,0 +1,184 @@
+package selector
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+)
+
+// testIssues are the issues the selectors of the tests match
+var testIssues = []models.Issue{
+	{
+		ID:        "build-frontend",
+		Title:     "Build failed: frontend",
+		Severity:  "critical",
+		IssueType: "build",
+		State:     "ACTIVE",
+		Assignee:  "alice",
+		Scope:     models.Scope{ResourceType: "component", ResourceName: "frontend-app"},
+		Labels:    []models.Label{{Key: "team", Value: "build-infra"}},
+	},
+	{
+		ID:        "test-frontend",
+		Title:     "Flaky test: it's \"sometimes\" failing",
+		Severity:  "major",
+		IssueType: "test",
+		State:     "RESOLVED",
+		Scope:     models.Scope{ResourceType: "component", ResourceName: "frontend-tests"},
+		Labels:    []models.Label{{Key: "tier", Value: ""}},
+	},
+	{
+		ID:        "release-backend",
+		Title:     "Release blocked",
+		Severity:  "minor",
+		IssueType: "release",
+		State:     "ACTIVE",
+		Assignee:  "bob",
+		Scope:     models.Scope{ResourceType: "application", ResourceName: "backend"},
+		Labels:    []models.Label{{Key: "team", Value: "release"}},
+	},
+}
+
+// selectedIDs returns the IDs of the test issues a selector matches
+func selectedIDs(s *Selector) []string {
+	var ids []string
+	for _, issue := range s.Filter(testIssues) {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestParse_Matches(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{name: "equal", expression: "severity == critical", expected: []string{"build-frontend"}},
+		{name: "not equal", expression: "state != RESOLVED", expected: []string{"build-frontend", "release-backend"}},
+		{name: "not equal empty", expression: `assignee != ""`, expected: []string{"build-frontend", "release-backend"}},
+		{name: "in", expression: "severity in (major, critical)", expected: []string{"build-frontend", "test-frontend"}},
+		{name: "in single value", expression: "type in (release)", expected: []string{"release-backend"}},
+		{name: "not in", expression: "type notin (test,release)", expected: []string{"build-frontend"}},
+		{name: "regular expression", expression: `resource =~ "frontend.*"`, expected: []string{"build-frontend", "test-frontend"}},
+		{name: "regular expression matching the whole value", expression: "resource =~ frontend", expected: nil},
+		{name: "regular expression alternatives anchored", expression: `resource =~ "front|backend"`, expected: []string{"release-backend"}},
+		{name: "not matching", expression: `title !~ ".*(Flaky|blocked).*"`, expected: []string{"build-frontend"}},
+		{name: "label", expression: "label.team == build-infra", expected: []string{"build-frontend"}},
+		{name: "missing label is empty", expression: `label.team == ""`, expected: []string{"test-frontend"}},
+
+		// A field alone checks it exists
+		{name: "label exists", expression: "label.team", expected: []string{"build-frontend", "release-backend"}},
+		{name: "label with empty value exists", expression: "label.tier", expected: []string{"test-frontend"}},
+		{name: "label doesn't exist", expression: "!label.team", expected: []string{"test-frontend"}},
+		{name: "field set", expression: "assignee", expected: []string{"build-frontend", "release-backend"}},
+		{name: "field empty", expression: "!assignee", expected: []string{"test-frontend"}},
+		{name: "existence combined", expression: "label.team && !(assignee) || label.tier", expected: []string{"test-frontend"}},
+
+		// && binds tighter than ||, ! tighter than both
+		{name: "and before or", expression: "severity == minor || severity == critical && state == RESOLVED", expected: []string{"release-backend"}},
+		{name: "or before and", expression: "(severity == minor || severity == critical) && state == ACTIVE", expected: []string{"build-frontend", "release-backend"}},
+		{name: "not before and", expression: "!state == RESOLVED && type != build", expected: []string{"release-backend"}},
+		{name: "not of group", expression: "!(state == RESOLVED || type == build)", expected: []string{"release-backend"}},
+		{name: "double negation", expression: "!!(severity == major)", expected: []string{"test-frontend"}},
+		{name: "left to right", expression: "type == build || type == test || type == release", expected: []string{"build-frontend", "test-frontend", "release-backend"}},
+
+		// Quoting
+		{name: "double quotes", expression: `title == "Release blocked"`, expected: []string{"release-backend"}},
+		{name: "single quotes", expression: `title == 'Release blocked'`, expected: []string{"release-backend"}},
+		{name: "escaped quotes", expression: `title == "Flaky test: it's \"sometimes\" failing"`, expected: []string{"test-frontend"}},
+		{name: "other quotes unescaped", expression: `title == 'Flaky test: it\'s "sometimes" failing'`, expected: []string{"test-frontend"}},
+		{name: "operators quoted", expression: `title =~ ".*&&.*" || title == "||"`, expected: nil},
+		{name: "quoted in list", expression: `title in ("Release blocked", 'Build failed: frontend')`, expected: []string{"build-frontend", "release-backend"}},
+		{name: "words with punctuation", expression: "resource == frontend-app && resourceType == component", expected: []string{"build-frontend"}},
+		{name: "no spaces", expression: "severity==major||severity==minor", expected: []string{"test-frontend", "release-backend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if ids := selectedIDs(s); !slices.Equal(ids, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, ids)
+			}
+			if s.String() != tt.expression {
+				t.Errorf("Expected the expression %q, got %q", tt.expression, s.String())
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{name: "empty", expression: "", expected: "expected a field, got end of expression"},
+		{name: "blank", expression: "   ", expected: "expected a field, got end of expression"},
+		{name: "unknown field", expression: "owner == alice", expected: `unknown field "owner"`},
+		{name: "label without key", expression: "label. == x", expected: `unknown field "label."`},
+		{name: "missing value", expression: "severity ==", expected: "expected a value, got end of expression"},
+		{name: "missing operator", expression: "severity major", expected: "expected an operator after 'severity', got 'major'"},
+		{name: "unknown operator", expression: "severity >= major", expected: `unexpected character '>'`},
+		{name: "tripled operator", expression: "severity === major", expected: "unexpected character '=' at position 11"},
+		{name: "value as field", expression: `"severity" == major`, expected: `expected a field, got "severity"`},
+		{name: "list without parentheses", expression: "severity in major", expected: "expected '(' to start a list, got 'major'"},
+		{name: "unterminated list", expression: "severity in (major", expected: "expected ',' or ')' in list, got end of expression"},
+		{name: "trailing comma", expression: "severity in (major,)", expected: "expected a value, got ')'"},
+		{name: "empty list", expression: "severity in ()", expected: "expected a value, got ')'"},
+		{name: "unclosed group", expression: "(severity == major", expected: "expected ')', got end of expression"},
+		{name: "unopened group", expression: "severity == major)", expected: "unexpected ')'"},
+		{name: "unterminated string", expression: `title == "Build failed`, expected: "unterminated string at position 9"},
+		{name: "unterminated escape", expression: `title == "Build failed\`, expected: "unterminated string at position 9"},
+		{name: "leading and", expression: "&& severity == major", expected: "expected a field, got '&&'"},
+		{name: "trailing or", expression: "severity == major ||", expected: "expected a field, got end of expression"},
+		{name: "missing and", expression: "severity == major type == build", expected: "unexpected 'type'"},
+		{name: "dangling not", expression: "!", expected: "expected a field, got end of expression"},
+		{name: "invalid regular expression", expression: `title =~ "("`, expected: "invalid regular expression"},
+		{name: "regular expression quoting the anchors", expression: `title =~ "\\Qfrontend"`, expected: "invalid regular expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expression)
+			if err == nil {
+				t.Fatalf("Expected an error, got selector %q", s)
+			}
+			if !strings.HasPrefix(err.Error(), "invalid selector") || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an invalid selector error with %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestParse_Prefixes(t *testing.T) {
+	// Expressions cut anywhere, e.g. while typed, parse or fail without panicking
+	for _, expression := range []string{
+		`severity in (major,critical) && resource =~ "frontend.*"`,
+		`!(state == RESOLVED || label.team) && title !~ 'it\'s (flaky|slow)'`,
+	} {
+		runes := []rune(expression)
+		for i := range runes {
+			if s, err := Parse(string(runes[:i])); err == nil {
+				selectedIDs(s)
+			}
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields := Fields()
+	if !slices.IsSorted(fields) {
+		t.Errorf("Expected sorted fields, got %v", fields)
+	}
+	for _, field := range []string{"severity", "resource", "label.<key>"} {
+		if !slices.Contains(fields, field) {
+			t.Errorf("Expected the field %s, got %v", field, fields)
+		}
+	}
+}
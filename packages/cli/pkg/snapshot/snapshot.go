@@ -0,0 +1,202 @@
+// Package snapshot saves the issues of namespaces on disk, to tell what
+// changed in a namespace since a previous snapshot
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+)
+
+// Baselines of the diffs
+const (
+	// BaselineSnapshot diffs against a snapshot of the issues
+	BaselineSnapshot = "snapshot"
+	// BaselineTimestamps diffs against when the issues were detected and resolved
+	BaselineTimestamps = "timestamps"
+)
+
+// Snapshot is the state of the issues of a namespace at a point in time
+type Snapshot struct {
+	Namespace string         `json:"namespace"`
+	TakenAt   time.Time      `json:"takenAt"`
+	Issues    []models.Issue `json:"issues"`
+}
+
+// Store saves snapshots in a directory, a file per snapshot named after the
+// time it was taken in the directory of its namespace
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store saving snapshots in dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save saves a snapshot
+func (s *Store) Save(snapshot Snapshot) error {
+	dir := s.namespaceDir(snapshot.Namespace)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial snapshot
+	tmp, err := os.CreateTemp(dir, ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	name := strconv.FormatInt(snapshot.TakenAt.UnixNano(), 10) + ".json"
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the latest snapshot of a namespace taken at or before t
+func (s *Store) Latest(namespace string, t time.Time) (*Snapshot, bool) {
+	times := s.times(namespace)
+	for i := len(times) - 1; i >= 0; i-- {
+		if times[i].After(t) {
+			continue
+		}
+		data, err := os.ReadFile(s.path(namespace, times[i]))
+		if err != nil {
+			continue
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			continue
+		}
+		return &snapshot, true
+	}
+	return nil, false
+}
+
+// Prune removes the snapshots of a namespace taken before t
+func (s *Store) Prune(namespace string, t time.Time) error {
+	for _, takenAt := range s.times(namespace) {
+		if !takenAt.Before(t) {
+			break
+		}
+		if err := os.Remove(s.path(namespace, takenAt)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// times returns when the snapshots of a namespace were taken, oldest first
+func (s *Store) times(namespace string) []time.Time {
+	entries, err := os.ReadDir(s.namespaceDir(namespace))
+	if err != nil {
+		return nil
+	}
+	var times []time.Time
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if nanos, err := strconv.ParseInt(name, 10, 64); err == nil {
+			times = append(times, time.Unix(0, nanos))
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+func (s *Store) namespaceDir(namespace string) string {
+	return filepath.Join(s.dir, filepath.Base(namespace))
+}
+
+func (s *Store) path(namespace string, takenAt time.Time) string {
+	return filepath.Join(s.namespaceDir(namespace), strconv.FormatInt(takenAt.UnixNano(), 10)+".json")
+}
+
+// Diff compares the issues of a namespace with those of a previous snapshot:
+// the open issues which weren't open in the snapshot are new, those open in
+// the snapshot which aren't anymore are resolved, and the others still active.
+// Issues missing from current, e.g. deleted, are resolved as of the snapshot.
+func Diff(previous *Snapshot, current []models.Issue) models.IssueDiff {
+	takenAt := previous.TakenAt
+	diff := models.IssueDiff{
+		Namespace:  previous.Namespace,
+		Since:      takenAt,
+		Baseline:   BaselineSnapshot,
+		BaselineAt: &takenAt,
+	}
+
+	wasOpen := map[string]bool{}
+	for _, issue := range previous.Issues {
+		wasOpen[issue.ID] = isOpen(issue)
+	}
+	seen := map[string]bool{}
+	for _, issue := range current {
+		seen[issue.ID] = true
+		switch {
+		case isOpen(issue) && wasOpen[issue.ID]:
+			diff.StillActive = append(diff.StillActive, issue)
+		case isOpen(issue):
+			diff.New = append(diff.New, issue)
+		case wasOpen[issue.ID]:
+			diff.Resolved = append(diff.Resolved, issue)
+		}
+	}
+	for _, issue := range previous.Issues {
+		if !seen[issue.ID] && wasOpen[issue.ID] {
+			diff.Resolved = append(diff.Resolved, issue)
+		}
+	}
+	return diff
+}
+
+// DiffSince tells what changed in the issues of a namespace since a time from
+// when they were detected and resolved, without snapshot old enough. Issues
+// detected and resolved since then are neither new nor resolved.
+func DiffSince(namespace string, since time.Time, current []models.Issue) models.IssueDiff {
+	diff := models.IssueDiff{
+		Namespace: namespace,
+		Since:     since,
+		Baseline:  BaselineTimestamps,
+	}
+	for _, issue := range current {
+		detectedSince := !issue.DetectedAt.Before(since)
+		switch {
+		case isOpen(issue) && detectedSince:
+			diff.New = append(diff.New, issue)
+		case isOpen(issue):
+			diff.StillActive = append(diff.StillActive, issue)
+		case !detectedSince && issue.ResolvedAt != nil && !issue.ResolvedAt.Before(since):
+			diff.Resolved = append(diff.Resolved, issue)
+		}
+	}
+	return diff
+}
+
+// isOpen reports whether an issue is open, in any state but RESOLVED
+func isOpen(issue models.Issue) bool {
+	return issue.State != "RESOLVED"
+}
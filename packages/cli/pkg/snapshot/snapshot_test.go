@@ -0,0 +1,233 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/cli/pkg/models"
+)
+
+// ids returns the IDs of issues
+func ids(issues []models.Issue) []string {
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestDiff(t *testing.T) {
+	takenAt := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	previous := &Snapshot{
+		Namespace: "team-alpha",
+		TakenAt:   takenAt,
+		Issues: []models.Issue{
+			{ID: "still-active", State: "ACTIVE", Severity: "minor"},
+			{ID: "changed", State: "ACTIVE", Severity: "minor", Title: "Build failed"},
+			{ID: "resolved", State: "ACTIVE"},
+			{ID: "deleted", State: "ACTIVE"},
+			{ID: "reopened", State: "RESOLVED"},
+			{ID: "still-resolved", State: "RESOLVED"},
+			{ID: "deleted-resolved", State: "RESOLVED"},
+		},
+	}
+	current := []models.Issue{
+		{ID: "still-active", State: "ACTIVE", Severity: "minor"},
+		{ID: "changed", State: "PROCESSING", Severity: "critical", Title: "Build failed again"},
+		{ID: "resolved", State: "RESOLVED"},
+		{ID: "reopened", State: "ACTIVE"},
+		{ID: "still-resolved", State: "RESOLVED"},
+		{ID: "added", State: "ACTIVE"},
+		{ID: "added-resolved", State: "RESOLVED"},
+	}
+
+	diff := Diff(previous, current)
+
+	if diff.Namespace != "team-alpha" || !diff.Since.Equal(takenAt) {
+		t.Errorf("Expected the namespace and time of the snapshot, got %s since %s", diff.Namespace, diff.Since)
+	}
+	if diff.Baseline != BaselineSnapshot || diff.BaselineAt == nil || !diff.BaselineAt.Equal(takenAt) {
+		t.Errorf("Expected the snapshot baseline at %s, got %s at %v", takenAt, diff.Baseline, diff.BaselineAt)
+	}
+	if expected := []string{"reopened", "added"}; !slices.Equal(ids(diff.New), expected) {
+		t.Errorf("Expected new issues %v, got %v", expected, ids(diff.New))
+	}
+	if expected := []string{"resolved", "deleted"}; !slices.Equal(ids(diff.Resolved), expected) {
+		t.Errorf("Expected resolved issues %v, got %v", expected, ids(diff.Resolved))
+	}
+	if expected := []string{"still-active", "changed"}; !slices.Equal(ids(diff.StillActive), expected) {
+		t.Errorf("Expected still active issues %v, got %v", expected, ids(diff.StillActive))
+	}
+
+	// Changed issues are shown as they are now
+	changed := diff.StillActive[1]
+	if changed.State != "PROCESSING" || changed.Severity != "critical" || changed.Title != "Build failed again" {
+		t.Errorf("Expected the current values of the changed issue, got %+v", changed)
+	}
+}
+
+func TestDiff_EmptySnapshot(t *testing.T) {
+	previous := &Snapshot{Namespace: "team-alpha", TakenAt: time.Now()}
+	current := []models.Issue{{ID: "added", State: "ACTIVE"}, {ID: "old", State: "RESOLVED"}}
+
+	diff := Diff(previous, current)
+
+	if expected := []string{"added"}; !slices.Equal(ids(diff.New), expected) {
+		t.Errorf("Expected new issues %v, got %v", expected, ids(diff.New))
+	}
+	if len(diff.Resolved) != 0 || len(diff.StillActive) != 0 {
+		t.Errorf("Expected no resolved nor still active issues, got %v and %v", ids(diff.Resolved), ids(diff.StillActive))
+	}
+}
+
+func TestDiffSince(t *testing.T) {
+	since := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	before := since.Add(-time.Hour)
+	after := since.Add(time.Hour)
+	current := []models.Issue{
+		{ID: "added", State: "ACTIVE", DetectedAt: after},
+		{ID: "still-active", State: "ACTIVE", DetectedAt: before},
+		{ID: "resolved", State: "RESOLVED", DetectedAt: before, ResolvedAt: &after},
+		{ID: "resolved-before", State: "RESOLVED", DetectedAt: before, ResolvedAt: &before},
+		{ID: "added-resolved", State: "RESOLVED", DetectedAt: since, ResolvedAt: &after},
+	}
+
+	diff := DiffSince("team-alpha", since, current)
+
+	if diff.Baseline != BaselineTimestamps || diff.BaselineAt != nil {
+		t.Errorf("Expected the timestamps baseline, got %s at %v", diff.Baseline, diff.BaselineAt)
+	}
+	if expected := []string{"added"}; !slices.Equal(ids(diff.New), expected) {
+		t.Errorf("Expected new issues %v, got %v", expected, ids(diff.New))
+	}
+	if expected := []string{"resolved"}; !slices.Equal(ids(diff.Resolved), expected) {
+		t.Errorf("Expected resolved issues %v, got %v", expected, ids(diff.Resolved))
+	}
+	if expected := []string{"still-active"}; !slices.Equal(ids(diff.StillActive), expected) {
+		t.Errorf("Expected still active issues %v, got %v", expected, ids(diff.StillActive))
+	}
+}
+
+func TestStore_SaveAndLatest(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Now()
+	for i, age := range []time.Duration{48 * time.Hour, 24 * time.Hour, time.Hour} {
+		snapshot := Snapshot{
+			Namespace: "team-alpha",
+			TakenAt:   now.Add(-age),
+			Issues:    []models.Issue{{ID: string(rune('a' + i)), State: "ACTIVE"}},
+		}
+		if err := store.Save(snapshot); err != nil {
+			t.Fatalf("Failed to save snapshot: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected string
+	}{
+		{name: "latest", at: now, expected: "c"},
+		{name: "taken at or before", at: now.Add(-24 * time.Hour), expected: "b"},
+		{name: "oldest", at: now.Add(-30 * time.Hour), expected: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, ok := store.Latest("team-alpha", tt.at)
+			if !ok {
+				t.Fatal("Expected a snapshot")
+			}
+			if snapshot.Namespace != "team-alpha" || len(snapshot.Issues) != 1 || snapshot.Issues[0].ID != tt.expected {
+				t.Errorf("Expected the snapshot with issue %s, got %+v", tt.expected, snapshot)
+			}
+		})
+	}
+}
+
+func TestStore_LatestMissing(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	if err := store.Save(Snapshot{Namespace: "team-alpha", TakenAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		store     *Store
+		namespace string
+		at        time.Time
+	}{
+		{name: "no snapshot of the namespace", store: store, namespace: "team-beta", at: time.Now()},
+		{name: "no snapshot old enough", store: store, namespace: "team-alpha", at: time.Now().Add(-time.Hour)},
+		{name: "no snapshot directory", store: NewStore(filepath.Join(dir, "missing")), namespace: "team-alpha", at: time.Now()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if snapshot, ok := tt.store.Latest(tt.namespace, tt.at); ok {
+				t.Errorf("Expected no snapshot, got %+v", snapshot)
+			}
+		})
+	}
+}
+
+func TestStore_LatestCorrupt(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Now()
+	if err := store.Save(Snapshot{Namespace: "team-alpha", TakenAt: now.Add(-48 * time.Hour), Issues: []models.Issue{{ID: "valid"}}}); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+
+	// Later snapshots which can't be read are skipped for the latest valid one
+	for i, data := range []string{"", "not json", `{"namespace": "team-alpha", "issues": [`} {
+		path := store.path("team-alpha", now.Add(-time.Duration(i+1)*time.Hour))
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatalf("Failed to write corrupt snapshot: %v", err)
+		}
+	}
+	// As are files which aren't snapshots
+	for _, name := range []string{"notes.txt", "latest.json", ".snapshot-123"} {
+		if err := os.WriteFile(filepath.Join(store.namespaceDir("team-alpha"), name), []byte("{}"), 0600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	snapshot, ok := store.Latest("team-alpha", now)
+	if !ok {
+		t.Fatal("Expected the valid snapshot")
+	}
+	if len(snapshot.Issues) != 1 || snapshot.Issues[0].ID != "valid" {
+		t.Errorf("Expected the valid snapshot, got %+v", snapshot)
+	}
+
+	// Without valid snapshot, there's none
+	if err := store.Prune("team-alpha", now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("Failed to prune snapshots: %v", err)
+	}
+	if snapshot, ok := store.Latest("team-alpha", now); ok {
+		t.Errorf("Expected no snapshot, got %+v", snapshot)
+	}
+}
+
+func TestStore_Prune(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Now()
+	for _, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, time.Hour} {
+		if err := store.Save(Snapshot{Namespace: "team-alpha", TakenAt: now.Add(-age)}); err != nil {
+			t.Fatalf("Failed to save snapshot: %v", err)
+		}
+	}
+
+	if err := store.Prune("team-alpha", now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("Failed to prune snapshots: %v", err)
+	}
+
+	if times := store.times("team-alpha"); len(times) != 1 || !times[0].Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected only the latest snapshot left, got %v", times)
+	}
+	if err := store.Prune("team-beta", now); err != nil {
+		t.Errorf("Expected pruning a namespace without snapshot to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the CLI. It's set at build time with
+// -ldflags "-X github.com/konflux-ci/kite/packages/cli/pkg/version.Version=<version>"
+var Version = "dev"
+
+// APIVersion is the version of the KITE API supported by the CLI
+const APIVersion = "v1"
+
+// Compatibility describes how the API version of a server relates to the supported one
+type Compatibility int
+
+const (
+	// Compatible means the server serves the supported API version
+	Compatible Compatibility = iota
+	// ServerOlder means the server serves an older API version than the supported one
+	ServerOlder
+	// ServerNewer means the server serves a newer API version than the supported one
+	ServerNewer
+	// Unknown means the API version reported by the server couldn't be understood
+	Unknown
+)
+
+// CheckAPIVersion compares the API version reported by a server with the
+// supported one. Servers that predate API versioning don't report one, and
+// are assumed to serve v1.
+func CheckAPIVersion(serverAPIVersion string) Compatibility {
+	if serverAPIVersion == "" {
+		serverAPIVersion = "v1"
+	}
+
+	server, ok := parseAPIVersion(serverAPIVersion)
+	if !ok {
+		return Unknown
+	}
+	supported, _ := parseAPIVersion(APIVersion)
+
+	switch {
+	case server < supported:
+		return ServerOlder
+	case server > supported:
+		return ServerNewer
+	default:
+		return Compatible
+	}
+}
+
+// parseAPIVersion returns the number of an API version such as "v1"
+func parseAPIVersion(apiVersion string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(apiVersion, "v"))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -21,19 +21,21 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
-	"strconv"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/konflux-ci/kite/packages/operator/internal/clients"
+	"github.com/konflux-ci/kite/packages/operator/internal/config"
 	"github.com/konflux-ci/kite/packages/operator/internal/controller"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -65,10 +67,7 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
-	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
-	// Kite specific configs
-	var kiteApiURL string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -84,15 +83,8 @@ func main() {
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
-	// Extract from ENV vars
-	enableHTTP2ENV, _ := strconv.ParseBool(getEnvOrDefault("ENABLE_HTTP2", "true"))
-	flag.BoolVar(&enableHTTP2, "enable-http2", enableHTTP2ENV,
-		"If set, HTTP/2 will be enabled for requests")
-	// Kite specific configuration
-	flag.StringVar(&kiteApiURL,
-		"kite-api-url",
-		getEnvOrDefault("KITE_API_URL", "http://localhost:8080"),
-		"KITE API Base URL")
+	// Kite specific configuration, from flags or ENV vars
+	cfg := config.BindFlags(flag.CommandLine)
 
 	opts := zap.Options{
 		Development: true,
@@ -102,13 +94,18 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := cfg.Complete(); err != nil {
+		setupLog.Error(err, "invalid configuration")
+		os.Exit(1)
+	}
+
 	// Setup logrus logger
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
 	logger.SetFormatter(&logrus.JSONFormatter{})
 
 	logger.WithFields(logrus.Fields{
-		"kite_api_url": kiteApiURL,
+		"kite_api_url": cfg.KiteAPIURL,
 		"metrics_addr": metricsAddr,
 		"probe_addr":   probeAddr,
 	}).Info("Starting KITE Bridge Operator")
@@ -124,7 +121,7 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
-	if !enableHTTP2 {
+	if !cfg.EnableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
@@ -208,6 +205,7 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "46951979.konflux.dev",
+		Cache:                  cacheOptions(cfg.Namespaces),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -226,13 +224,33 @@ func main() {
 	}
 
 	// Create KITE client
-	kiteClient := clients.NewKiteClient(kiteApiURL, logger)
+	kiteClient := clients.NewKiteClientWithOptions(cfg.KiteAPIURL, logger, cfg.Client)
+
+	var stats *controller.ReportStats
+	if cfg.Stats.ConfigMap != "" && cfg.Stats.Namespace != "" {
+		stats = controller.NewReportStats(mgr.GetClient(), mgr.GetAPIReader(),
+			types.NamespacedName{Name: cfg.Stats.ConfigMap, Namespace: cfg.Stats.Namespace}, cfg.Stats.Interval, logger)
+	} else {
+		setupLog.Info("Reporting statistics disabled, no ConfigMap or namespace to save them")
+	}
+
+	// Shared by the controllers, for a root failure to be reported once
+	var coordinator *controller.FailureCoordinator
+	if cfg.DedupWindow > 0 {
+		coordinator = controller.NewFailureCoordinator(cfg.DedupWindow)
+	}
 
 	if err := (&controller.PipelineRunReconciler{
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		KiteClient: kiteClient,
-		Logger:     logger,
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		KiteClient:         kiteClient,
+		Logger:             logger,
+		Namespaces:         cfg.Namespaces,
+		SeverityPolicy:     cfg.Severity,
+		ResolveStaleIssues: cfg.ResolveStaleIssues,
+		Stats:              stats,
+		StuckPolicy:        cfg.Stuck,
+		Coordinator:        coordinator,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PipelineRun")
 		os.Exit(1)
@@ -271,9 +289,15 @@ func main() {
 	}
 }
 
-func getEnvOrDefault(value, defaultValue string) string {
-	if value := os.Getenv(value); value != "" {
-		return value
+// cacheOptions restricts the cache of the manager to the watched namespaces,
+// caching all of them when none is configured
+func cacheOptions(namespaces []string) cache.Options {
+	if len(namespaces) == 0 {
+		return cache.Options{}
+	}
+	defaultNamespaces := make(map[string]cache.Config, len(namespaces))
+	for _, namespace := range namespaces {
+		defaultNamespaces[namespace] = cache.Config{}
 	}
-	return defaultValue
+	return cache.Options{DefaultNamespaces: defaultNamespaces}
 }
@@ -21,9 +21,11 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -33,13 +35,26 @@ import (
 type KiteWebhookClient interface {
 	ReportPipelineFailure(ctx context.Context, payload PipelineFailurePayload) error
 	ReportPipelineSuccess(ctx context.Context, payload PipelineSuccessPayload) error
+	ListActivePipelineIssues(ctx context.Context, namespace string) ([]PipelineIssue, error)
+	ReportPipelineRunStuck(ctx context.Context, payload PipelineRunStuckPayload) error
+	ResolvePipelineRunStuck(ctx context.Context, payload PipelineRunStuckPayload) error
 }
 type KiteClient struct {
 	baseURL    string
 	httpClient *http.Client
 	logger     *logrus.Logger
+	options    KiteClientOptions
 }
 
+// Severities of the issues reported to KITE. They must be severities of the
+// backend, see models.Severity in packages/backend, which rejects other values.
+const (
+	SeverityInfo     = "info"
+	SeverityMinor    = "minor"
+	SeverityMajor    = "major"
+	SeverityCritical = "critical"
+)
+
 // TODO - These payload structs should probably be exported from Kite service package?
 type PipelineFailurePayload struct {
 	PipelineName  string `json:"pipelineName"`
@@ -48,6 +63,21 @@ type PipelineFailurePayload struct {
 	RunID         string `json:"runId,omitempty"`
 	LogsURL       string `json:"logsUrl,omitempty"`
 	Severity      string `json:"severity,omitempty"`
+	// DetectedAt is when the pipeline failed, so retried reports keep the time of the failure
+	DetectedAt *time.Time `json:"detectedAt,omitempty"`
+	// Fingerprint groups the failures into an issue instead of the pipeline
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// The git metadata of the run, linking the issue to the commit and pull request
+	RepoURL        string `json:"repoUrl,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	PullRequest    string `json:"pullRequest,omitempty"`
+	CommitURL      string `json:"commitUrl,omitempty"`
+	PullRequestURL string `json:"pullRequestUrl,omitempty"`
+	// The artifacts built by the run, correlating the issue with the image
+	ImageURL    string            `json:"imageUrl,omitempty"`
+	ImageDigest string            `json:"imageDigest,omitempty"`
+	SBOMURL     string            `json:"sbomUrl,omitempty"`
+	Artifacts   map[string]string `json:"artifacts,omitempty"`
 }
 
 type PipelineSuccessPayload struct {
@@ -55,36 +85,159 @@ type PipelineSuccessPayload struct {
 	Namespace    string `json:"namespace"`
 }
 
-// NewKiteClient returns a new client that interacts with the KITE api
-func NewKiteClient(baseURL string, logger *logrus.Logger) *KiteClient {
-	// Check if we should skip TLS verification (for local development ONLY)
-	skipTLS := false
-	if val := os.Getenv("ENABLE_HTTP2"); val != "" {
-		if enableHTTP2, err := strconv.ParseBool(val); err == nil {
-			skipTLS = !enableHTTP2
+// PipelineRunStuckPayload describes a PipelineRun stuck pending or running.
+// Its issue is scoped to the pipeline, as its failures are, but grouped by the
+// run and its phase, so that it is resolved on its own when the run progresses.
+type PipelineRunStuckPayload struct {
+	PipelineName string
+	Namespace    string
+	RunName      string
+	// Phase is "pending" or "running"
+	Phase string
+	// Reason explains why the run is stuck, e.g. since when and its condition
+	Reason   string
+	Severity string
+}
+
+// Fingerprint returns the fingerprint grouping the reports of the stuck run
+func (p PipelineRunStuckPayload) Fingerprint() string {
+	return fmt.Sprintf("pipelinerun-stuck/%s/%s", p.RunName, p.Phase)
+}
+
+// PipelineIssue is an issue reported to KITE for the failures of a pipeline
+type PipelineIssue struct {
+	ID string
+	// PipelineName is the name of the pipeline, as normalized by KITE
+	PipelineName string
+}
+
+// BackpressureError is returned when KITE refuses a request because it is rate
+// limiting or shedding load, with a 429 or a 503. RetryAfter is how long KITE
+// asked to wait before retrying, from its Retry-After header, 0 when it didn't.
+type BackpressureError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *BackpressureError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("error, Status code %d returned, retry after %s", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("error, Status code %d returned", e.StatusCode)
+}
+
+// RetryAfter returns how long KITE asked to wait before retrying the request
+// which failed with err, false when err isn't a BackpressureError with a Retry-After
+func RetryAfter(err error) (time.Duration, bool) {
+	var backpressure *BackpressureError
+	if errors.As(err, &backpressure) && backpressure.RetryAfter > 0 {
+		return backpressure.RetryAfter, true
+	}
+	return 0, false
+}
+
+// statusError returns the error of a response of KITE with an unexpected status,
+// a BackpressureError for the responses refused under load
+func statusError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return &BackpressureError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 		}
 	}
+	return fmt.Errorf("error, Status code %d returned", resp.StatusCode)
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or an
+// HTTP date, returning 0 when it is missing, invalid or in the past
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(0, time.Duration(seconds)*time.Second)
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(0, date.Sub(now))
+	}
+	return 0
+}
+
+// pipelineIssuesPageSize is how many issues ListActivePipelineIssues loads per request
+const pipelineIssuesPageSize = 100
+
+// KiteClientOptions tune the HTTP client of KITE, e.g. for large clusters
+// reporting many PipelineRuns or for restricted networks.
+type KiteClientOptions struct {
+	// Timeout is the timeout of each attempt of a request
+	Timeout time.Duration
+	// MaxIdleConns is how many idle connections are kept, 0 for no limit
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is how many idle connections to KITE are kept
+	MaxIdleConnsPerHost int
+	// ProxyURL is the proxy of the requests, those of the environment
+	// (HTTPS_PROXY, HTTP_PROXY and NO_PROXY) being used when nil
+	ProxyURL *url.URL
+	// MaxRetries is how many times failed requests are retried, 0 not to retry
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled on each retry
+	RetryBackoff time.Duration
+	// RetryBudget caps the time spent waiting to retry a request, retries which
+	// would exceed it being given up, 0 for no cap
+	RetryBudget time.Duration
+	// InsecureSkipVerify skips the verification of the TLS certificate of KITE,
+	// for local development ONLY
+	InsecureSkipVerify bool
+}
+
+// DefaultKiteClientOptions returns the options of the client unless configured otherwise
+func DefaultKiteClientOptions() KiteClientOptions {
+	return KiteClientOptions{
+		Timeout:             30 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		MaxRetries:          0,
+		RetryBackoff:        time.Second,
+		RetryBudget:         30 * time.Second,
+	}
+}
 
+// NewKiteClient returns a new client that interacts with the KITE api
+func NewKiteClient(baseURL string, logger *logrus.Logger) *KiteClient {
+	return NewKiteClientWithOptions(baseURL, logger, DefaultKiteClientOptions())
+}
+
+// NewKiteClientWithOptions returns a new client that interacts with the KITE api, tuned with options
+func NewKiteClientWithOptions(baseURL string, logger *logrus.Logger, options KiteClientOptions) *KiteClient {
 	// Create HTTP client with TLS configurations
 	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: skipTLS,
+			InsecureSkipVerify: options.InsecureSkipVerify,
 		},
+		MaxIdleConns:        options.MaxIdleConns,
+		MaxIdleConnsPerHost: options.MaxIdleConnsPerHost,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if options.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(options.ProxyURL)
 	}
 
 	httpClient := &http.Client{
-		Timeout:   30 * time.Second,
+		Timeout:   options.Timeout,
 		Transport: transport,
 	}
 
-	if skipTLS {
-		logger.Warn("TLS certificate verification is disabled for KITE client (ENABLE_HTTP2=false)")
+	if options.InsecureSkipVerify {
+		logger.Warn("TLS certificate verification is disabled for KITE client")
 	}
 
 	return &KiteClient{
 		baseURL:    baseURL,
 		logger:     logger,
 		httpClient: httpClient,
+		options:    options,
 	}
 }
 
@@ -100,6 +253,117 @@ func (k *KiteClient) ReportPipelineSuccess(ctx context.Context, payload Pipeline
 	return k.sendWebhook(ctx, url, payload, "pipeline-success")
 }
 
+// ReportPipelineRunStuck reports a stuck PipelineRun with KITE's webhook endpoint for pipeline failures
+func (k *KiteClient) ReportPipelineRunStuck(ctx context.Context, payload PipelineRunStuckPayload) error {
+	return k.ReportPipelineFailure(ctx, PipelineFailurePayload{
+		PipelineName:  payload.PipelineName,
+		Namespace:     payload.Namespace,
+		FailureReason: payload.Reason,
+		Severity:      payload.Severity,
+		Fingerprint:   payload.Fingerprint(),
+	})
+}
+
+// ResolvePipelineRunStuck resolves the ACTIVE issues of a stuck PipelineRun
+// which progressed, found by their fingerprint
+func (k *KiteClient) ResolvePipelineRunStuck(ctx context.Context, payload PipelineRunStuckPayload) error {
+	query := url.Values{
+		"namespace":    {payload.Namespace},
+		"state":        {"ACTIVE"},
+		"fingerprint":  {payload.Fingerprint()},
+		"fields":       {"id"},
+		"includeTotal": {"false"},
+	}
+	var page struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := k.getJSON(ctx, k.baseURL+"/api/v1/issues?"+query.Encode(), &page); err != nil {
+		return err
+	}
+
+	resolution := map[string]string{
+		"reason":     fmt.Sprintf("PipelineRun %s is no longer %s", payload.RunName, payload.Phase),
+		"resolvedBy": "kite-bridge-operator",
+	}
+	for _, issue := range page.Data {
+		resolveURL := fmt.Sprintf("%s/api/v1/issues/%s/resolve?namespace=%s", k.baseURL, url.PathEscape(issue.ID), url.QueryEscape(payload.Namespace))
+		if err := k.sendWebhook(ctx, resolveURL, resolution, "pipeline-run-progressed"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListActivePipelineIssues lists the ACTIVE issues of the pipelines of a
+// namespace, those scoped to a pipelinerun, page by page
+func (k *KiteClient) ListActivePipelineIssues(ctx context.Context, namespace string) ([]PipelineIssue, error) {
+	var issues []PipelineIssue
+	offset := 0
+	for {
+		query := url.Values{
+			"namespace":    {namespace},
+			"state":        {"ACTIVE"},
+			"resourceType": {"pipelinerun"},
+			"fields":       {"id,scope"},
+			"includeTotal": {"false"},
+			"limit":        {strconv.Itoa(pipelineIssuesPageSize)},
+			"offset":       {strconv.Itoa(offset)},
+		}
+		var page struct {
+			Data []struct {
+				ID    string `json:"id"`
+				Scope struct {
+					ResourceName string `json:"resourceName"`
+				} `json:"scope"`
+			} `json:"data"`
+			HasNextPage bool `json:"hasNextPage"`
+			NextOffset  int  `json:"nextOffset"`
+		}
+		if err := k.getJSON(ctx, k.baseURL+"/api/v1/issues?"+query.Encode(), &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Data {
+			issues = append(issues, PipelineIssue{ID: issue.ID, PipelineName: issue.Scope.ResourceName})
+		}
+		if !page.HasNextPage || page.NextOffset <= offset {
+			return issues, nil
+		}
+		offset = page.NextOffset
+	}
+}
+
+// getJSON is a helper function that fetches and decodes the JSON responses of KITE
+func (k *KiteClient) getJSON(ctx context.Context, url string, response interface{}) error {
+	resp, err := k.do(ctx, "list", func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		k.logger.WithError(err).Error("Failed to send request to KITE")
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			k.logger.WithError(cerr).Error("Failed to close body of the response")
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		k.logger.WithField("status_code", resp.StatusCode).Errorf("KITE API returned status %d", resp.StatusCode)
+		return statusError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
 // sendWebhook is a helper function that sends HTTP requests to KITE
 func (k *KiteClient) sendWebhook(ctx context.Context, url string, payload interface{}, operation string) error {
 	jsonData, err := json.Marshal(payload)
@@ -107,21 +371,21 @@ func (k *KiteClient) sendWebhook(ctx context.Context, url string, payload interf
 		return fmt.Errorf("failed to marshal payload: %v", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		k.logger.WithError(err).Error("Failed to create HTTP request")
-		return fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
 	k.logger.WithFields(logrus.Fields{
 		"url":       url,
 		"operation": operation,
 		"payload":   string(jsonData),
 	}).Debug("Sending request to KITE")
 
-	resp, err := k.httpClient.Do(req)
+	resp, err := k.do(ctx, operation, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			k.logger.WithError(err).Error("Failed to create HTTP request")
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
 		k.logger.WithError(err).Error("Failed to send request to KITE")
 		return fmt.Errorf("failed to send request: %w", err)
@@ -138,7 +402,7 @@ func (k *KiteClient) sendWebhook(ctx context.Context, url string, payload interf
 			"status_code": resp.StatusCode,
 			"operation":   operation,
 		}).Errorf("KITE API returned status %d", resp.StatusCode)
-		return fmt.Errorf("error, Status code %d returned", resp.StatusCode)
+		return statusError(resp)
 	}
 
 	k.logger.WithFields(logrus.Fields{
@@ -148,3 +412,78 @@ func (k *KiteClient) sendWebhook(ctx context.Context, url string, payload interf
 
 	return nil
 }
+
+// maxRetryWait caps the wait before retrying a request, KITE asking to
+// retry after longer with Retry-After
+var maxRetryWait = time.Minute
+
+// do sends the request created by newRequest, retrying it as the options of
+// the client allow when it fails to be sent or when KITE answers with a 429,
+// 502, 503 or 504. Requests which aren't idempotent, e.g. the POSTs of the
+// webhooks, are only retried when KITE refused them without processing them,
+// with a 429 or a 503 with Retry-After. Retries wait for the Retry-After of
+// KITE, up to maxRetryWait, else for the backoff of the options, doubled on
+// each retry. The response of the last attempt is returned, whatever its status.
+func (k *KiteClient) do(ctx context.Context, operation string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	backoff := k.options.RetryBackoff
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := k.httpClient.Do(req)
+		if attempt >= k.options.MaxRetries || ctx.Err() != nil || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := backoff
+		if err == nil {
+			if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); retryAfter > 0 {
+				wait = min(retryAfter, maxRetryWait)
+			}
+		}
+		if k.options.RetryBudget > 0 && waited+wait > k.options.RetryBudget {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		k.logger.WithFields(logrus.Fields{
+			"operation": operation,
+			"attempt":   attempt + 1,
+			"wait":      wait.String(),
+		}).Warn("Retrying request to KITE")
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("failed to send request: %w", ctx.Err())
+		case <-timer.C:
+		}
+		waited += wait
+		backoff *= 2
+	}
+}
+
+// retryable tells whether a request which got resp, or failed with err, is
+// worth retrying
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		// A 503 without Retry-After may come from a proxy after KITE started
+		// processing the request
+		return idempotent || resp.Header.Get("Retry-After") != ""
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// response is a response of the test server of KITE
+type response struct {
+	status     int
+	retryAfter string
+}
+
+// newTestServer starts a test server of KITE answering with responses in
+// turn, the last one once they are exhausted, and counting the requests
+func newTestServer(t *testing.T, responses ...response) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		resp := responses[min(n, len(responses))-1]
+		if resp.retryAfter != "" {
+			w.Header().Set("Retry-After", resp.retryAfter)
+		}
+		w.WriteHeader(resp.status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// newTestClient returns a client of KITE retrying as options allow, and the
+// hook of its logger to read the retries from
+func newTestClient(baseURL string, options KiteClientOptions) (*KiteClient, *logtest.Hook) {
+	logger, hook := logtest.NewNullLogger()
+	return NewKiteClientWithOptions(baseURL, logger, options), hook
+}
+
+// retryWaits returns the waits of the retries logged to hook
+func retryWaits(hook *logtest.Hook) []string {
+	var waits []string
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Retrying request to KITE" {
+			waits = append(waits, entry.Data["wait"].(string))
+		}
+	}
+	return waits
+}
+
+// send sends a request with method to url through the retries of client,
+// returning the status of the last attempt
+func send(ctx context.Context, client *KiteClient, method, url string) (int, error) {
+	resp, err := client.do(ctx, "test", func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, method, url, nil)
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestKiteClient_Retries(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		responses        []response
+		expectedStatus   int
+		expectedRequests int32
+	}{
+		{name: "GET 429 then success", method: http.MethodGet, responses: []response{{status: 429}, {status: 200}}, expectedStatus: 200, expectedRequests: 2},
+		{name: "POST 429 then success", method: http.MethodPost, responses: []response{{status: 429}, {status: 200}}, expectedStatus: 200, expectedRequests: 2},
+		{name: "GET 502 then success", method: http.MethodGet, responses: []response{{status: 502}, {status: 200}}, expectedStatus: 200, expectedRequests: 2},
+		{name: "GET 503 then success", method: http.MethodGet, responses: []response{{status: 503}, {status: 200}}, expectedStatus: 200, expectedRequests: 2},
+		{name: "GET 504 then success", method: http.MethodGet, responses: []response{{status: 504}, {status: 200}}, expectedStatus: 200, expectedRequests: 2},
+		{name: "POST 503 with Retry-After then success", method: http.MethodPost, responses: []response{{status: 503, retryAfter: "0"}, {status: 200}}, expectedStatus: 200, expectedRequests: 2},
+		{name: "POST 500 not retried", method: http.MethodPost, responses: []response{{status: 500}, {status: 200}}, expectedStatus: 500, expectedRequests: 1},
+		{name: "POST 502 not retried", method: http.MethodPost, responses: []response{{status: 502}, {status: 200}}, expectedStatus: 502, expectedRequests: 1},
+		{name: "POST 503 without Retry-After not retried", method: http.MethodPost, responses: []response{{status: 503}, {status: 200}}, expectedStatus: 503, expectedRequests: 1},
+		{name: "GET 500 not retried", method: http.MethodGet, responses: []response{{status: 500}, {status: 200}}, expectedStatus: 500, expectedRequests: 1},
+		{name: "GET 404 not retried", method: http.MethodGet, responses: []response{{status: 404}, {status: 200}}, expectedStatus: 404, expectedRequests: 1},
+		{name: "retries exhausted", method: http.MethodGet, responses: []response{{status: 503}}, expectedStatus: 503, expectedRequests: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newTestServer(t, tt.responses...)
+			client, _ := newTestClient(server.URL, KiteClientOptions{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+			status, err := send(context.Background(), client, tt.method, server.URL)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if status != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, status)
+			}
+			if requests.Load() != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, requests.Load())
+			}
+		})
+	}
+}
+
+func TestKiteClient_RetriesSendErrors(t *testing.T) {
+	// Nothing listens on the address of a closed server
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	for method, expectedRetries := range map[string]int{http.MethodGet: 2, http.MethodPost: 0} {
+		client, hook := newTestClient(server.URL, KiteClientOptions{MaxRetries: 2, RetryBackoff: time.Millisecond})
+		if _, err := send(context.Background(), client, method, server.URL); err == nil {
+			t.Errorf("Expected the %s to fail", method)
+		}
+		if retries := len(retryWaits(hook)); retries != expectedRetries {
+			t.Errorf("Expected the %s to be retried %d times, got %d", method, expectedRetries, retries)
+		}
+	}
+}
+
+func TestKiteClient_RetriesReports(t *testing.T) {
+	server, requests := newTestServer(t, response{status: 429, retryAfter: "0"}, response{status: 200})
+	client, _ := newTestClient(server.URL, KiteClientOptions{MaxRetries: 1, RetryBackoff: time.Millisecond})
+
+	if err := client.ReportPipelineFailure(context.Background(), PipelineFailurePayload{PipelineName: "build", Namespace: "team-alpha"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected the report to be sent again, got %d requests", requests.Load())
+	}
+
+	// Once the retries are exhausted, the backpressure of KITE is returned
+	server, _ = newTestServer(t, response{status: 429, retryAfter: "120"})
+	client, _ = newTestClient(server.URL, KiteClientOptions{})
+	err := client.ReportPipelineFailure(context.Background(), PipelineFailurePayload{PipelineName: "build", Namespace: "team-alpha"})
+	if retryAfter, ok := RetryAfter(err); !ok || retryAfter != 2*time.Minute {
+		t.Errorf("Expected to retry after 2m, got %v", err)
+	}
+}
+
+func TestKiteClient_RetryAfter(t *testing.T) {
+	defer func(wait time.Duration) { maxRetryWait = wait }(maxRetryWait)
+	maxRetryWait = 1100 * time.Millisecond
+
+	tests := []struct {
+		name         string
+		retryAfter   string
+		expectedWait string
+	}{
+		{name: "seconds", retryAfter: "1", expectedWait: "1s"},
+		{name: "seconds capped", retryAfter: "3600", expectedWait: "1.1s"},
+		{name: "HTTP date capped", retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), expectedWait: "1.1s"},
+		{name: "invalid, backoff instead", retryAfter: "soon", expectedWait: "10ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t, response{status: 429, retryAfter: tt.retryAfter}, response{status: 200})
+			client, hook := newTestClient(server.URL, KiteClientOptions{MaxRetries: 1, RetryBackoff: 10 * time.Millisecond})
+
+			status, err := send(context.Background(), client, http.MethodPost, server.URL)
+			if err != nil || status != 200 {
+				t.Fatalf("Expected the retry to succeed, got %d (%v)", status, err)
+			}
+			if waits := retryWaits(hook); !slices.Equal(waits, []string{tt.expectedWait}) {
+				t.Errorf("Expected to wait %s, got %v", tt.expectedWait, waits)
+			}
+		})
+	}
+}
+
+func TestKiteClient_RetryBudget(t *testing.T) {
+	server, requests := newTestServer(t, response{status: 503})
+	client, hook := newTestClient(server.URL, KiteClientOptions{MaxRetries: 10, RetryBackoff: 10 * time.Millisecond, RetryBudget: 50 * time.Millisecond})
+
+	status, err := send(context.Background(), client, http.MethodGet, server.URL)
+	if err != nil || status != 503 {
+		t.Fatalf("Expected the last response, got %d (%v)", status, err)
+	}
+	// The third retry, after 40ms, would exceed the budget
+	if waits := retryWaits(hook); !slices.Equal(waits, []string{"10ms", "20ms"}) {
+		t.Errorf("Expected 2 retries within the budget, got %v", waits)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests.Load())
+	}
+
+	// Retry-After beyond the budget gives up right away
+	server, requests = newTestServer(t, response{status: 429, retryAfter: "1"})
+	client, _ = newTestClient(server.URL, KiteClientOptions{MaxRetries: 10, RetryBackoff: time.Millisecond, RetryBudget: 50 * time.Millisecond})
+	start := time.Now()
+	if status, err := send(context.Background(), client, http.MethodGet, server.URL); err != nil || status != 429 {
+		t.Fatalf("Expected the 429, got %d (%v)", status, err)
+	}
+	if requests.Load() != 1 || time.Since(start) >= time.Second {
+		t.Errorf("Expected no retry, got %d requests in %s", requests.Load(), time.Since(start))
+	}
+}
+
+func TestKiteClient_RetryCancelled(t *testing.T) {
+	server, requests := newTestServer(t, response{status: 503})
+	client, _ := newTestClient(server.URL, KiteClientOptions{MaxRetries: 3, RetryBackoff: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	_, err := send(ctx, client, http.MethodGet, server.URL)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the request to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected to stop waiting once cancelled, waited %s", elapsed)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected no retry, got %d requests", requests.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{header: "", expected: 0},
+		{header: "30", expected: 30 * time.Second},
+		{header: "-5", expected: 0},
+		{header: now.Add(90 * time.Second).Format(http.TimeFormat), expected: 90 * time.Second},
+		{header: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0},
+		{header: "tomorrow", expected: 0},
+	}
+	for _, tt := range tests {
+		if wait := parseRetryAfter(tt.header, now); wait != tt.expected {
+			t.Errorf("Expected %q to wait %s, got %s", tt.header, tt.expected, wait)
+		}
+	}
+}
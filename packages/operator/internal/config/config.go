@@ -0,0 +1,230 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config holds the runtime configuration of the operator. Every
+// setting is a flag defaulting to an environment variable, so that it can be
+// set from the command line as well as from the env of a Deployment, e.g. from
+// Helm values or the ConfigMap generated by kustomize in config/manager.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/packages/operator/internal/clients"
+	"github.com/konflux-ci/kite/packages/operator/internal/controller"
+)
+
+// Config is the runtime configuration of the operator
+type Config struct {
+	// EnableHTTP2 enables HTTP/2 for the metrics and webhook servers
+	EnableHTTP2 bool
+	// Namespaces are the namespaces whose PipelineRuns are watched, all when empty
+	Namespaces []string
+	// KiteAPIURL is the base URL of the KITE API
+	KiteAPIURL string
+	// Client tunes the HTTP client of KITE
+	Client clients.KiteClientOptions
+	// ResolveStaleIssues resolves the stale issues of pipelines on startup
+	ResolveStaleIssues bool
+	// Stats configures the reporting statistics
+	Stats StatsConfig
+	// Severity escalates the severity of long or retried failed runs
+	Severity controller.SeverityPolicy
+	// Stuck tells when runs pending or running are reported stuck
+	Stuck controller.StuckPolicy
+	// DedupWindow is how long the failures of a root failure already reported
+	// are suppressed, see controller.FailureCoordinator, 0 not to suppress any
+	DedupWindow time.Duration
+
+	// The flags parsed by Complete
+	namespaces string
+	proxyURL   string
+	// envErrors are the errors of the environment variables with invalid
+	// values, returned by Complete
+	envErrors []error
+}
+
+// StatsConfig configures the reporting statistics, see controller.ReportStats
+type StatsConfig struct {
+	// ConfigMap is the name of the ConfigMap saving them, empty not to save them
+	ConfigMap string
+	// Namespace is the namespace of the ConfigMap
+	Namespace string
+	// Interval is the interval between their saves
+	Interval time.Duration
+}
+
+// BindFlags defines the flags of the configuration in fs, defaulting to their
+// environment variables, else to the defaults of the operator. The returned
+// configuration is filled once fs is parsed and Complete is called.
+func BindFlags(fs *flag.FlagSet) *Config {
+	cfg := &Config{
+		Client:   clients.DefaultKiteClientOptions(),
+		Severity: controller.DefaultSeverityPolicy(),
+		Stuck:    controller.DefaultStuckPolicy(),
+	}
+
+	enableHTTP2 := cfg.getEnvBoolOrDefault("ENABLE_HTTP2", true)
+	fs.BoolVar(&cfg.EnableHTTP2, "enable-http2", enableHTTP2,
+		"If set, HTTP/2 will be enabled for requests")
+	fs.StringVar(&cfg.namespaces, "namespaces", os.Getenv("KITE_NAMESPACES"),
+		"Comma separated namespaces whose PipelineRuns are watched, all when empty")
+
+	// KITE client
+	fs.StringVar(&cfg.KiteAPIURL, "kite-api-url", getEnvOrDefault("KITE_API_URL", "http://localhost:8080"),
+		"KITE API Base URL")
+	// TLS verification used to be disabled along with HTTP/2 for local development
+	fs.BoolVar(&cfg.Client.InsecureSkipVerify, "kite-insecure-skip-verify",
+		cfg.getEnvBoolOrDefault("KITE_CLIENT_INSECURE_SKIP_VERIFY", !enableHTTP2),
+		"If set, the TLS certificate of KITE isn't verified, for local development ONLY")
+	fs.DurationVar(&cfg.Client.Timeout, "kite-timeout",
+		cfg.getEnvDurationOrDefault("KITE_CLIENT_TIMEOUT", cfg.Client.Timeout),
+		"Timeout of each attempt of the requests to KITE")
+	fs.IntVar(&cfg.Client.MaxIdleConns, "kite-max-idle-conns",
+		cfg.getEnvIntOrDefault("KITE_CLIENT_MAX_IDLE_CONNS", cfg.Client.MaxIdleConns),
+		"Maximum number of idle connections kept by the KITE client, 0 for no limit")
+	fs.IntVar(&cfg.Client.MaxIdleConnsPerHost, "kite-max-idle-conns-per-host",
+		cfg.getEnvIntOrDefault("KITE_CLIENT_MAX_IDLE_CONNS_PER_HOST", cfg.Client.MaxIdleConnsPerHost),
+		"Maximum number of idle connections to KITE kept by the KITE client")
+	fs.StringVar(&cfg.proxyURL, "kite-proxy-url", os.Getenv("KITE_CLIENT_PROXY_URL"),
+		"Proxy of the requests to KITE, HTTPS_PROXY, HTTP_PROXY and NO_PROXY being used when empty")
+	fs.IntVar(&cfg.Client.MaxRetries, "kite-max-retries",
+		cfg.getEnvIntOrDefault("KITE_CLIENT_MAX_RETRIES", cfg.Client.MaxRetries),
+		"How many times failed requests to KITE are retried before requeuing, 0 not to retry")
+	fs.DurationVar(&cfg.Client.RetryBackoff, "kite-retry-backoff",
+		cfg.getEnvDurationOrDefault("KITE_CLIENT_RETRY_BACKOFF", cfg.Client.RetryBackoff),
+		"Wait before the first retry of a request to KITE, doubled on each retry")
+	fs.DurationVar(&cfg.Client.RetryBudget, "kite-retry-budget",
+		cfg.getEnvDurationOrDefault("KITE_CLIENT_RETRY_BUDGET", cfg.Client.RetryBudget),
+		"Maximum time spent waiting to retry a request to KITE, 0 for no limit")
+
+	// Reconciliation
+	fs.BoolVar(&cfg.ResolveStaleIssues, "resolve-stale-issues", cfg.getEnvBoolOrDefault("KITE_RESOLVE_STALE_ISSUES", true),
+		"If set, the ACTIVE issues of the pipelines which succeeded or have no PipelineRun anymore are resolved on startup")
+	fs.StringVar(&cfg.Stats.ConfigMap, "stats-configmap", getEnvOrDefault("KITE_STATS_CONFIGMAP", "kite-operator-stats"),
+		"Name of the ConfigMap saving the reporting statistics per namespace, empty not to save them")
+	fs.StringVar(&cfg.Stats.Namespace, "stats-namespace", getEnvOrDefault("KITE_STATS_NAMESPACE", os.Getenv("POD_NAMESPACE")),
+		"Namespace of the ConfigMap saving the reporting statistics, the namespace of the operator by default")
+	fs.DurationVar(&cfg.Stats.Interval, "stats-interval", cfg.getEnvDurationOrDefault("KITE_STATS_INTERVAL", time.Minute),
+		"Interval between the saves of the reporting statistics")
+
+	// Severity rules
+	fs.DurationVar(&cfg.Severity.MajorDuration, "severity-major-duration",
+		cfg.getEnvDurationOrDefault("KITE_SEVERITY_MAJOR_DURATION", cfg.Severity.MajorDuration),
+		"Duration from which failed PipelineRuns are at least major, 0 to disable")
+	fs.DurationVar(&cfg.Severity.CriticalDuration, "severity-critical-duration",
+		cfg.getEnvDurationOrDefault("KITE_SEVERITY_CRITICAL_DURATION", cfg.Severity.CriticalDuration),
+		"Duration from which failed PipelineRuns are critical, 0 to disable")
+	fs.IntVar(&cfg.Severity.MajorRetries, "severity-major-retries",
+		cfg.getEnvIntOrDefault("KITE_SEVERITY_MAJOR_RETRIES", cfg.Severity.MajorRetries),
+		"Retries of a task from which failed PipelineRuns are at least major, 0 to disable")
+	fs.IntVar(&cfg.Severity.CriticalRetries, "severity-critical-retries",
+		cfg.getEnvIntOrDefault("KITE_SEVERITY_CRITICAL_RETRIES", cfg.Severity.CriticalRetries),
+		"Retries of a task from which failed PipelineRuns are critical, 0 to disable")
+
+	// Stuck runs
+	fs.DurationVar(&cfg.Stuck.PendingAfter, "stuck-pending-after",
+		cfg.getEnvDurationOrDefault("KITE_STUCK_PENDING_AFTER", cfg.Stuck.PendingAfter),
+		"Duration from which pending PipelineRuns are reported stuck, 0 to disable")
+	fs.DurationVar(&cfg.Stuck.RunningAfter, "stuck-running-after",
+		cfg.getEnvDurationOrDefault("KITE_STUCK_RUNNING_AFTER", cfg.Stuck.RunningAfter),
+		"Duration from which running PipelineRuns are reported stuck, 0 to disable")
+
+	// Duplicate suppression
+	fs.DurationVar(&cfg.DedupWindow, "dedup-window", cfg.getEnvDurationOrDefault("KITE_DEDUP_WINDOW", time.Hour),
+		"How long the failures of a Release or PipelineRun already reported are suppressed, 0 to disable")
+
+	return cfg
+}
+
+// Complete parses the flags of the configuration which aren't plain values,
+// and validates it, once its flag set is parsed
+func (c *Config) Complete() error {
+	if len(c.envErrors) > 0 {
+		return errors.Join(c.envErrors...)
+	}
+
+	c.Namespaces = nil
+	for _, namespace := range strings.Split(c.namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			c.Namespaces = append(c.Namespaces, namespace)
+		}
+	}
+
+	c.Client.ProxyURL = nil
+	if c.proxyURL != "" {
+		proxyURL, err := url.Parse(c.proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid KITE proxy URL: %w", err)
+		}
+		c.Client.ProxyURL = proxyURL
+	}
+
+	if _, err := url.ParseRequestURI(c.KiteAPIURL); err != nil {
+		return fmt.Errorf("invalid KITE API URL: %w", err)
+	}
+	if c.Client.Timeout < 0 || c.Client.MaxRetries < 0 || c.Client.RetryBackoff < 0 || c.Client.RetryBudget < 0 {
+		return fmt.Errorf("the KITE client settings can't be negative")
+	}
+	if c.DedupWindow < 0 {
+		return fmt.Errorf("the duplicate suppression window can't be negative")
+	}
+	if c.Stats.ConfigMap != "" && c.Stats.Interval <= 0 {
+		return fmt.Errorf("the interval of the reporting statistics must be positive")
+	}
+	return nil
+}
+
+func getEnvOrDefault(value, defaultValue string) string {
+	if value := os.Getenv(value); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func (c *Config) getEnvBoolOrDefault(name string, defaultValue bool) bool {
+	return getEnvValueOrDefault(c, name, defaultValue, strconv.ParseBool)
+}
+
+func (c *Config) getEnvDurationOrDefault(name string, defaultValue time.Duration) time.Duration {
+	return getEnvValueOrDefault(c, name, defaultValue, time.ParseDuration)
+}
+
+func (c *Config) getEnvIntOrDefault(name string, defaultValue int) int {
+	return getEnvValueOrDefault(c, name, defaultValue, strconv.Atoi)
+}
+
+// getEnvValueOrDefault parses the value of an environment variable, recording
+// the error of invalid values for Complete to return it
+func getEnvValueOrDefault[T any](c *Config, name string, defaultValue T, parse func(string) (T, error)) T {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := parse(value)
+	if err != nil {
+		c.envErrors = append(c.envErrors, fmt.Errorf("invalid %s %q: %w", name, value, err))
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// load binds the configuration to a flag set with env set, then parses args
+// and completes the configuration
+func load(t *testing.T, env map[string]string, args ...string) (*Config, error) {
+	t.Helper()
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+	fs := flag.NewFlagSet("operator", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg := BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Complete()
+}
+
+func TestConfig_Precedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.KiteAPIURL != "http://localhost:8080" || cfg.Client.Timeout != 30*time.Second || cfg.Client.MaxRetries != 0 {
+					t.Errorf("Expected the default KITE client, got %s and %+v", cfg.KiteAPIURL, cfg.Client)
+				}
+				if !cfg.EnableHTTP2 || cfg.Client.InsecureSkipVerify || cfg.Client.ProxyURL != nil {
+					t.Errorf("Expected HTTP/2 and TLS verification without proxy, got %+v", cfg)
+				}
+				if cfg.Namespaces != nil || cfg.DedupWindow != time.Hour || cfg.Stats.Interval != time.Minute {
+					t.Errorf("Expected all namespaces, a 1h dedup window and stats saved every minute, got %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "environment over defaults",
+			env: map[string]string{
+				"KITE_API_URL":            "https://kite.example.com",
+				"KITE_CLIENT_TIMEOUT":     "5s",
+				"KITE_CLIENT_MAX_RETRIES": "3",
+				"KITE_DEDUP_WINDOW":       "0s",
+				"KITE_NAMESPACES":         " team-a, ,team-b ",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.KiteAPIURL != "https://kite.example.com" || cfg.Client.Timeout != 5*time.Second || cfg.Client.MaxRetries != 3 {
+					t.Errorf("Expected the KITE client of the environment, got %s and %+v", cfg.KiteAPIURL, cfg.Client)
+				}
+				if cfg.DedupWindow != 0 {
+					t.Errorf("Expected no dedup window, got %s", cfg.DedupWindow)
+				}
+				if !slices.Equal(cfg.Namespaces, []string{"team-a", "team-b"}) {
+					t.Errorf("Expected the namespaces team-a and team-b, got %q", cfg.Namespaces)
+				}
+			},
+		},
+		{
+			name: "flags over environment",
+			env:  map[string]string{"KITE_CLIENT_TIMEOUT": "5s", "KITE_NAMESPACES": "team-a", "KITE_RESOLVE_STALE_ISSUES": "true"},
+			args: []string{"--kite-timeout=10s", "--namespaces=team-c", "--resolve-stale-issues=false"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Client.Timeout != 10*time.Second || !slices.Equal(cfg.Namespaces, []string{"team-c"}) || cfg.ResolveStaleIssues {
+					t.Errorf("Expected the settings of the flags, got %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "insecure without HTTP/2",
+			env:  map[string]string{"ENABLE_HTTP2": "false"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.EnableHTTP2 || !cfg.Client.InsecureSkipVerify {
+					t.Errorf("Expected TLS verification disabled along with HTTP/2, got %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "verified without HTTP/2",
+			env:  map[string]string{"ENABLE_HTTP2": "false", "KITE_CLIENT_INSECURE_SKIP_VERIFY": "false"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Client.InsecureSkipVerify {
+					t.Error("Expected TLS verification")
+				}
+			},
+		},
+		{
+			name: "stats in the namespace of the pod",
+			env:  map[string]string{"POD_NAMESPACE": "kite-system"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Stats.Namespace != "kite-system" || cfg.Stats.ConfigMap != "kite-operator-stats" {
+					t.Errorf("Expected the stats in kite-system, got %+v", cfg.Stats)
+				}
+			},
+		},
+		{
+			name: "proxy",
+			args: []string{"--kite-proxy-url=http://proxy.corp:3128"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Client.ProxyURL == nil || cfg.Client.ProxyURL.Host != "proxy.corp:3128" {
+					t.Errorf("Expected the proxy proxy.corp:3128, got %v", cfg.Client.ProxyURL)
+				}
+			},
+		},
+		{
+			name: "stats not saved",
+			args: []string{"--stats-configmap=", "--stats-interval=0"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Stats.ConfigMap != "" {
+					t.Errorf("Expected the stats not to be saved, got %+v", cfg.Stats)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(t, tt.env, tt.args...)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		expected string
+	}{
+		{name: "API URL", args: []string{"--kite-api-url=kite"}, expected: "invalid KITE API URL"},
+		{name: "proxy URL", args: []string{"--kite-proxy-url=:3128"}, expected: "invalid KITE proxy URL"},
+		{name: "negative timeout", args: []string{"--kite-timeout=-1s"}, expected: "the KITE client settings can't be negative"},
+		{name: "negative retries", args: []string{"--kite-max-retries=-1"}, expected: "the KITE client settings can't be negative"},
+		{name: "negative backoff", args: []string{"--kite-retry-backoff=-1s"}, expected: "the KITE client settings can't be negative"},
+		{name: "negative budget", args: []string{"--kite-retry-budget=-1s"}, expected: "the KITE client settings can't be negative"},
+		{name: "negative dedup window", args: []string{"--dedup-window=-1m"}, expected: "the duplicate suppression window can't be negative"},
+		{name: "stats interval", args: []string{"--stats-interval=0"}, expected: "the interval of the reporting statistics must be positive"},
+		{name: "flag value", args: []string{"--kite-timeout=soon"}, expected: `invalid value "soon" for flag -kite-timeout`},
+		{name: "environment duration", env: map[string]string{"KITE_CLIENT_TIMEOUT": "soon"}, expected: `invalid KITE_CLIENT_TIMEOUT "soon"`},
+		{name: "environment int", env: map[string]string{"KITE_CLIENT_MAX_RETRIES": "three"}, expected: `invalid KITE_CLIENT_MAX_RETRIES "three"`},
+		{name: "environment bool", env: map[string]string{"ENABLE_HTTP2": "maybe"}, expected: `invalid ENABLE_HTTP2 "maybe"`},
+		{name: "environment overridden by flag", env: map[string]string{"KITE_DEDUP_WINDOW": "1 hour"}, args: []string{"--dedup-window=1h"}, expected: `invalid KITE_DEDUP_WINDOW "1 hour"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(t, tt.env, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error with %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
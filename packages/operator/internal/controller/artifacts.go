@@ -0,0 +1,113 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// Results of the build pipelines of Konflux and type hints of Tekton Chains
+const (
+	ImageURLResult    = "IMAGE_URL"
+	ImageDigestResult = "IMAGE_DIGEST"
+	SBOMURLResult     = "SBOM_BLOB_URL"
+	// Results named <name>_ARTIFACT are artifacts, e.g. SOURCE_ARTIFACT
+	artifactSuffix = "_ARTIFACT"
+	// Results named <name>_ARTIFACT_OUTPUTS are artifacts with a uri and a digest
+	artifactOutputsSuffix = "_ARTIFACT_OUTPUTS"
+)
+
+// buildArtifacts are the artifacts built by a build PipelineRun, for its
+// issue to be correlated with the image it built
+type buildArtifacts struct {
+	ImageURL    string
+	ImageDigest string
+	SBOMURL     string
+	// Artifacts are the other artifacts, by result name
+	Artifacts map[string]string
+}
+
+// getBuildArtifacts returns the artifacts built by a build PipelineRun, empty
+// for other runs. Failed runs may have no results when the tasks the
+// pipeline results refer to failed, so the results of the TaskRuns which
+// succeeded complete those of the run.
+func (r *PipelineRunReconciler) getBuildArtifacts(ctx context.Context, pr *v1.PipelineRun) buildArtifacts {
+	var artifacts buildArtifacts
+	if pr.Labels["pipelines.appstudio.openshift.io/type"] != "build" {
+		return artifacts
+	}
+
+	for _, result := range pr.Status.Results {
+		artifacts.add(result.Name, result.Value)
+	}
+	for _, childRef := range pr.Status.ChildReferences {
+		if artifacts.ImageURL != "" && artifacts.SBOMURL != "" {
+			break
+		}
+		if childRef.Kind != "TaskRun" || childRef.Name == "" {
+			continue
+		}
+		if status := r.getTaskRunStatus(ctx, childRef.Name, pr.Namespace); status != nil {
+			for _, result := range status.Results {
+				artifacts.add(result.Name, result.Value)
+			}
+		}
+	}
+	return artifacts
+}
+
+// add adds the value of a result when it's an artifact not known yet
+func (a *buildArtifacts) add(name string, value v1.ResultValue) {
+	switch name {
+	case ImageURLResult:
+		a.ImageURL = firstNonEmpty(a.ImageURL, strings.TrimSpace(value.StringVal))
+	case ImageDigestResult:
+		a.ImageDigest = firstNonEmpty(a.ImageDigest, strings.TrimSpace(value.StringVal))
+	case SBOMURLResult:
+		a.SBOMURL = firstNonEmpty(a.SBOMURL, strings.TrimSpace(value.StringVal))
+	default:
+		var artifact string
+		switch {
+		case strings.HasSuffix(name, artifactSuffix) && value.Type == v1.ParamTypeString:
+			artifact = strings.TrimSpace(value.StringVal)
+		case strings.HasSuffix(name, artifactOutputsSuffix) && value.Type == v1.ParamTypeObject:
+			artifact = value.ObjectVal["uri"]
+			if digest := value.ObjectVal["digest"]; artifact != "" && digest != "" {
+				artifact += "@" + digest
+			}
+		}
+		if artifact == "" || a.Artifacts[name] != "" {
+			return
+		}
+		if a.Artifacts == nil {
+			a.Artifacts = map[string]string{}
+		}
+		a.Artifacts[name] = artifact
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
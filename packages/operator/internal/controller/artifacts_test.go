@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+var _ = Describe("Build Artifacts", func() {
+	var reconciler *PipelineRunReconciler
+
+	BeforeEach(func() {
+		reconciler = &PipelineRunReconciler{Client: k8sClient, Logger: logrus.New()}
+	})
+
+	It("should extract the image, SBOM and artifacts of build runs", func() {
+		pr := NewPipelineRunBuilder("frontend-on-push-x8f2k", KiteBridgeOperatorNamespace).
+			WithLabels(map[string]string{"pipelines.appstudio.openshift.io/type": "build"}).
+			Build()
+		pr.Status.Results = []v1.PipelineRunResult{
+			{Name: ImageURLResult, Value: *v1.NewStructuredValues("quay.io/org/frontend:4f3a9c1")},
+			{Name: ImageDigestResult, Value: *v1.NewStructuredValues("sha256:abc123")},
+			{Name: SBOMURLResult, Value: *v1.NewStructuredValues("quay.io/org/frontend@sha256:def456")},
+			{Name: "SOURCE_ARTIFACT", Value: *v1.NewStructuredValues("oci:quay.io/org/frontend@sha256:0a1b")},
+			{Name: "IMAGE_ARTIFACT_OUTPUTS", Value: *v1.NewObject(map[string]string{
+				"uri":    "quay.io/org/frontend",
+				"digest": "sha256:abc123",
+			})},
+			{Name: "CHAINS-GIT_URL", Value: *v1.NewStructuredValues("https://github.com/org/frontend")},
+		}
+
+		Expect(reconciler.getBuildArtifacts(ctx, pr)).To(Equal(buildArtifacts{
+			ImageURL:    "quay.io/org/frontend:4f3a9c1",
+			ImageDigest: "sha256:abc123",
+			SBOMURL:     "quay.io/org/frontend@sha256:def456",
+			Artifacts: map[string]string{
+				"SOURCE_ARTIFACT":        "oci:quay.io/org/frontend@sha256:0a1b",
+				"IMAGE_ARTIFACT_OUTPUTS": "quay.io/org/frontend@sha256:abc123",
+			},
+		}))
+	})
+
+	It("should have no artifacts for other runs", func() {
+		pr := NewPipelineRunBuilder("integration-test-x8f2k", KiteBridgeOperatorNamespace).
+			WithLabels(map[string]string{"pipelines.appstudio.openshift.io/type": "test"}).
+			Build()
+		pr.Status.Results = []v1.PipelineRunResult{
+			{Name: ImageURLResult, Value: *v1.NewStructuredValues("quay.io/org/frontend:4f3a9c1")},
+		}
+
+		Expect(reconciler.getBuildArtifacts(ctx, pr)).To(Equal(buildArtifacts{}))
+	})
+})
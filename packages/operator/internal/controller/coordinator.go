@@ -0,0 +1,134 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Labels set by the Konflux release service on the PipelineRuns of a Release
+const (
+	ReleaseNameLabel      = "release.appstudio.openshift.io/name"
+	ReleaseNamespaceLabel = "release.appstudio.openshift.io/namespace"
+)
+
+// maxOwnerDepth caps the owners walked up to find the root of a failure
+const maxOwnerDepth = 5
+
+// FailureCoordinator suppresses the failures reported by the controllers of
+// the operator for a root failure already reported, e.g. the PipelineRuns of
+// a failed Release, so that one failure doesn't produce an issue per object.
+// Failures are fingerprinted by their root, see RootFingerprint, and the
+// first failure of a root claims it for window: the controllers skip the
+// failures of roots claimed by others. It is shared by the controllers of the
+// manager, a nil FailureCoordinator suppressing nothing.
+type FailureCoordinator struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	claims map[string]claim
+}
+
+// claim is the reporter of the failure of a root, until expiry
+type claim struct {
+	reporter string
+	expiry   time.Time
+}
+
+// NewFailureCoordinator creates a coordinator suppressing the failures of a
+// root for window after the first one
+func NewFailureCoordinator(window time.Duration) *FailureCoordinator {
+	return &FailureCoordinator{window: window, now: time.Now, claims: map[string]claim{}}
+}
+
+// Claim claims the failure of the root of fingerprint for reporter, an object
+// of a controller, returning false and the reporter which claimed it when
+// another one did, in which case the failure must be suppressed. Reporters may
+// claim their root again, e.g. when their report is retried.
+func (c *FailureCoordinator) Claim(fingerprint, reporter string) (bool, string) {
+	if c == nil || fingerprint == "" {
+		return true, ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, existing := range c.claims {
+		if now.After(existing.expiry) {
+			delete(c.claims, key)
+		}
+	}
+	if existing, ok := c.claims[fingerprint]; ok && existing.reporter != reporter {
+		return false, existing.reporter
+	}
+	c.claims[fingerprint] = claim{reporter: reporter, expiry: now.Add(c.window)}
+	return true, ""
+}
+
+// Release gives up the claim of reporter on a root, e.g. when its report
+// failed for good, for other reporters to report the failure
+func (c *FailureCoordinator) Release(fingerprint, reporter string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.claims[fingerprint]; ok && existing.reporter == reporter {
+		delete(c.claims, fingerprint)
+	}
+}
+
+// RootFingerprint returns the fingerprint of the root failure of a
+// PipelineRun: its Release when the release service created it, else its
+// topmost owner, walking up the PipelineRuns owning it through the cache of
+// the informers, else the run itself.
+func RootFingerprint(ctx context.Context, reader client.Reader, pr *v1.PipelineRun) string {
+	if release := pr.Labels[ReleaseNameLabel]; release != "" {
+		namespace := pr.Labels[ReleaseNamespaceLabel]
+		if namespace == "" {
+			namespace = pr.Namespace
+		}
+		return fmt.Sprintf("Release/%s/%s", namespace, release)
+	}
+
+	current := pr
+	for range maxOwnerDepth {
+		owner := metav1.GetControllerOf(current)
+		if owner == nil {
+			break
+		}
+		if owner.Kind != "PipelineRun" {
+			return fmt.Sprintf("%s/%s/%s", owner.Kind, current.Namespace, owner.Name)
+		}
+		var parent v1.PipelineRun
+		if err := reader.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: current.Namespace}, &parent); err != nil {
+			// The parent is gone, it is the root nevertheless
+			return fmt.Sprintf("PipelineRun/%s/%s", current.Namespace, owner.Name)
+		}
+		current = &parent
+	}
+	return fmt.Sprintf("PipelineRun/%s/%s", current.Namespace, current.Name)
+}
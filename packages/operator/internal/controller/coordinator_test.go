@@ -0,0 +1,125 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knative "knative.dev/pkg/apis/duck/v1"
+)
+
+var _ = Describe("Failure Coordinator", func() {
+	It("should let the first reporter of a root claim it for the window", func() {
+		now := time.Now()
+		coordinator := NewFailureCoordinator(time.Hour)
+		coordinator.now = func() time.Time { return now }
+
+		claimed, _ := coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/tenant")
+		Expect(claimed).To(BeTrue())
+		claimed, by := coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/managed")
+		Expect(claimed).To(BeFalse())
+		Expect(by).To(Equal("PipelineRun/team-a/tenant"))
+		claimed, _ = coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/tenant")
+		Expect(claimed).To(BeTrue(), "reporters may claim their root again")
+
+		now = now.Add(2 * time.Hour)
+		claimed, _ = coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/managed")
+		Expect(claimed).To(BeTrue(), "claims expire after the window")
+	})
+
+	It("should let other reporters claim a root given up", func() {
+		coordinator := NewFailureCoordinator(time.Hour)
+		coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/tenant")
+		coordinator.Release("Release/team-a/release-1", "PipelineRun/team-a/tenant")
+
+		claimed, _ := coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/managed")
+		Expect(claimed).To(BeTrue())
+	})
+
+	It("should suppress nothing when nil", func() {
+		var coordinator *FailureCoordinator
+		claimed, _ := coordinator.Claim("Release/team-a/release-1", "PipelineRun/team-a/managed")
+		Expect(claimed).To(BeTrue())
+	})
+
+	It("should fingerprint the runs of a Release by their Release", func() {
+		pr := NewPipelineRunBuilder("managed-x8f2k", "managed-team").
+			WithLabels(map[string]string{
+				ReleaseNameLabel:      "release-1",
+				ReleaseNamespaceLabel: "team-a",
+			}).
+			Build()
+		Expect(RootFingerprint(ctx, k8sClient, pr)).To(Equal("Release/team-a/release-1"))
+	})
+
+	It("should fingerprint runs by the PipelineRuns owning them", func() {
+		parent := NewPipelineRunBuilder("parent-run", KiteBridgeOperatorNamespace).Build()
+		Expect(k8sClient.Create(ctx, parent)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, parent)).To(Succeed())
+		})
+
+		child := NewPipelineRunBuilder("child-run", KiteBridgeOperatorNamespace).Build()
+		controller := true
+		child.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "PipelineRun",
+			Name:       parent.Name,
+			UID:        parent.UID,
+			Controller: &controller,
+		}}
+		Expect(RootFingerprint(ctx, k8sClient, child)).To(Equal("PipelineRun/" + KiteBridgeOperatorNamespace + "/parent-run"))
+
+		standalone := NewPipelineRunBuilder("standalone-run", KiteBridgeOperatorNamespace).Build()
+		Expect(RootFingerprint(ctx, k8sClient, standalone)).To(Equal("PipelineRun/" + KiteBridgeOperatorNamespace + "/standalone-run"))
+	})
+
+	It("should report a failed Release once", func() {
+		mockKiteClient := &MockKiteClient{}
+		reconciler := &PipelineRunReconciler{
+			Client:      k8sClient,
+			KiteClient:  mockKiteClient,
+			Logger:      logrus.New(),
+			Coordinator: NewFailureCoordinator(time.Hour),
+		}
+
+		// failedRun returns a failed PipelineRun of the Release release-1
+		failedRun := func(name string) *v1.PipelineRun {
+			return NewPipelineRunBuilder(name, KiteBridgeOperatorNamespace).
+				WithLabels(map[string]string{ReleaseNameLabel: "release-1"}).
+				WithConditions(knative.Conditions{{
+					Type:    RunCompleted,
+					Status:  RunFailed,
+					Message: "Tasks Completed: 1 (Failed: 1)",
+				}}).
+				WithCompletionTime(metav1.Now()).
+				Build()
+		}
+
+		for _, name := range []string{"tenant-run", "managed-run"} {
+			_, err := reconciler.handlePipelineRunFailure(ctx, failedRun(name))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(mockKiteClient.FailureReports).To(HaveLen(1))
+		Expect(mockKiteClient.FailureReports[0].FailureReason).To(Equal("Tasks Completed: 1 (Failed: 1)"))
+	})
+})
@@ -25,10 +25,12 @@ import (
 	clients "github.com/konflux-ci/kite/packages/operator/internal/clients"
 	"github.com/sirupsen/logrus"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // PipelineRunReconciler reconciles a PipelineRun object
@@ -37,6 +39,20 @@ type PipelineRunReconciler struct {
 	Scheme     *runtime.Scheme
 	KiteClient clients.KiteWebhookClient
 	Logger     *logrus.Logger
+	// Namespaces are the namespaces watched, all when empty
+	Namespaces []string
+	// SeverityPolicy escalates the severity of long or retried failed runs
+	SeverityPolicy SeverityPolicy
+	// ResolveStaleIssues resolves the stale issues of pipelines on startup, see resolveStaleIssues
+	ResolveStaleIssues bool
+	// Stats keeps the reporting statistics per namespace, nil not to keep them
+	Stats *ReportStats
+	// StuckPolicy tells when runs pending or running are reported stuck
+	StuckPolicy StuckPolicy
+	stuckRuns   stuckRuns
+	// Coordinator suppresses the failures whose root failure is already
+	// reported, shared with the other controllers, nil not to suppress any
+	Coordinator *FailureCoordinator
 }
 
 const (
@@ -44,9 +60,12 @@ const (
 	RunPassed       = "True"
 	RunFailed       = "False"
 	RetryWaitPeriod = time.Minute * 2
+	// MaxRetryAfter caps the waits KITE asks for before retrying reports
+	MaxRetryAfter = time.Minute * 30
 )
 
 // +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch
+// +kubebuilder:rbac:groups=tekton.dev,resources=taskruns,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -61,17 +80,25 @@ func (r *PipelineRunReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	var pipelineRun v1.PipelineRun
 	if err := r.Get(ctx, req.NamespacedName, &pipelineRun); err != nil {
 		// In the Reconcile path the only expected error on a Get is "NotFound".
-		// In this case the Pipeline was deleted, so do nothing.
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		// In this case the Pipeline was deleted, so only resolve its issue if it was stuck.
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.resolveStuckIssue(ctx, req.NamespacedName)
+		}
+		return ctrl.Result{}, err
 	}
 
-	// Lets only process completed PipelineRuns
+	// Runs not yet completed are only reported when stuck
 	if pipelineRun.Status.CompletionTime == nil {
 		r.Logger.WithFields(logrus.Fields{
 			"pipeline_run": pipelineRun.Name,
 			"namespace":    pipelineRun.Namespace,
-		}).Debug("PipelineRun not yet completed, skipping")
-		return ctrl.Result{}, nil
+		}).Debug("PipelineRun not yet completed, checking whether it is stuck")
+		return r.handleIncompleteRun(ctx, &pipelineRun)
+	}
+
+	// Completed runs are no longer stuck
+	if err := r.resolveStuckIssue(ctx, req.NamespacedName); err != nil {
+		return r.retryResult(&pipelineRun, err, fmt.Errorf("failed to resolve stuck pipeline run from controller"))
 	}
 
 	// Determine status of PipelineRun
@@ -94,26 +121,60 @@ func (r *PipelineRunReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.handlePipelineRunSuccess(ctx, &pipelineRun)
 	default:
 		logEntry.Debugf("Ignoring PipelineRun with status: %s", status)
+		r.Stats.RecordSkipped(pipelineRun.Namespace)
 		return ctrl.Result{}, nil
 	}
 }
 
 // handlePipelineFailure takes the failed PipelineRun and sends a pipeline-failure request to KITE, creating an issue
 func (r *PipelineRunReconciler) handlePipelineRunFailure(ctx context.Context, pr *v1.PipelineRun) (ctrl.Result, error) {
+	// Only the first failure of a root, e.g. of a Release, is reported
+	reporter := fmt.Sprintf("PipelineRun/%s/%s", pr.Namespace, pr.Name)
+	var root string
+	if r.Coordinator != nil {
+		root = RootFingerprint(ctx, r.Client, pr)
+	}
+	if claimed, by := r.Coordinator.Claim(root, reporter); !claimed {
+		r.Logger.WithFields(logrus.Fields{
+			"pipeline_run": pr.Name,
+			"namespace":    pr.Namespace,
+			"root":         root,
+			"reported_by":  by,
+			"operation":    "pipeline-failure",
+		}).Info("Suppressing pipeline failure, its root failure is already reported")
+		r.Stats.RecordSuppressed(pr.Namespace)
+		return ctrl.Result{}, nil
+	}
+
 	failureReason := r.getFailureReason(ctx, pr)
 	pipelineName := r.getPipelineName(pr)
+	vcs := getVCSMetadata(pr)
+	artifacts := r.getBuildArtifacts(ctx, pr)
 
 	// Payload sent to KITE (/api/v1/webhooks/pipeline-failure)
 	payload := clients.PipelineFailurePayload{
-		PipelineName:  pipelineName,
-		Namespace:     pr.Namespace,
-		FailureReason: failureReason,
-		RunID:         string(pr.UID),
-		Severity:      r.determineSeverity(pr),
+		PipelineName:   pipelineName,
+		Namespace:      pr.Namespace,
+		FailureReason:  failureReason,
+		RunID:          string(pr.UID),
+		Severity:       r.escalateSeverity(ctx, pr, r.determineSeverity(pr)),
+		DetectedAt:     &pr.Status.CompletionTime.Time,
+		RepoURL:        vcs.RepoURL,
+		Revision:       vcs.Revision,
+		PullRequest:    vcs.PullRequest,
+		CommitURL:      vcs.CommitURL,
+		PullRequestURL: vcs.PullRequestURL,
+		ImageURL:       artifacts.ImageURL,
+		ImageDigest:    artifacts.ImageDigest,
+		SBOMURL:        artifacts.SBOMURL,
+		Artifacts:      artifacts.Artifacts,
 	}
 
 	// In the event of failure, retry in x minutes
 	if err := r.KiteClient.ReportPipelineFailure(ctx, payload); err != nil {
+		// Another failure of the root may be reported meanwhile
+		r.Coordinator.Release(root, reporter)
+		r.Stats.RecordFailure(pr.Namespace)
 		r.Logger.WithError(err).WithFields(logrus.Fields{
 			"id":           pr.UID,
 			"pipeline_run": pr.Name,
@@ -121,8 +182,8 @@ func (r *PipelineRunReconciler) handlePipelineRunFailure(ctx context.Context, pr
 			"operation":    "pipeline-failure",
 		}).Error("An error occurred when reporting a pipeline failure from controller.")
 
-		// Try again in 2 minutes...
-		return ctrl.Result{RequeueAfter: RetryWaitPeriod}, fmt.Errorf("failed to report pipeline failure from controller")
+		// Try again in 2 minutes, or when KITE asked to
+		return r.retryResult(pr, err, fmt.Errorf("failed to report pipeline failure from controller"))
 	}
 
 	r.Logger.WithFields(logrus.Fields{
@@ -130,6 +191,7 @@ func (r *PipelineRunReconciler) handlePipelineRunFailure(ctx context.Context, pr
 		"id":           pr.UID,
 		"operation":    "pipeline-failure",
 	}).Info("Successfully reported pipeline failure to KITE")
+	r.Stats.RecordReport(pr.Namespace)
 
 	return ctrl.Result{}, nil
 }
@@ -146,14 +208,15 @@ func (r *PipelineRunReconciler) handlePipelineRunSuccess(ctx context.Context, pr
 
 	// In the event of failure, retry in x minutes
 	if err := r.KiteClient.ReportPipelineSuccess(ctx, payload); err != nil {
+		r.Stats.RecordFailure(pr.Namespace)
 		r.Logger.WithError(err).WithFields(logrus.Fields{
 			"id":           pr.UID,
 			"pipeline_run": pr.Name,
 			"namespace":    pr.Namespace,
 			"operation":    "pipeline-success",
 		}).Error("An error occurred when reporting a successful pipeline from controller.")
-		// Retry in 2 minutes...
-		return ctrl.Result{RequeueAfter: RetryWaitPeriod}, fmt.Errorf("failed to report pipeline success from controller")
+		// Retry in 2 minutes, or when KITE asked to
+		return r.retryResult(pr, err, fmt.Errorf("failed to report pipeline success from controller"))
 	}
 
 	r.Logger.WithFields(logrus.Fields{
@@ -161,12 +224,43 @@ func (r *PipelineRunReconciler) handlePipelineRunSuccess(ctx context.Context, pr
 		"id":           pr.UID,
 		"operation":    "pipeline-success",
 	}).Info("Successfully reported pipeline success to KITE")
+	r.Stats.RecordReport(pr.Namespace)
 
 	return ctrl.Result{}, nil
 }
 
+// retryResult returns the result of a reconciliation whose report to KITE
+// failed with reportErr, retried after RetryWaitPeriod with err. When KITE is
+// rate limiting or shedding load and tells when to retry with a Retry-After,
+// the PipelineRun is requeued after it instead, capped to MaxRetryAfter, and
+// without error for controller-runtime not to retry it sooner with its backoff.
+func (r *PipelineRunReconciler) retryResult(pr *v1.PipelineRun, reportErr, err error) (ctrl.Result, error) {
+	retryAfter, ok := clients.RetryAfter(reportErr)
+	if !ok {
+		return ctrl.Result{RequeueAfter: RetryWaitPeriod}, err
+	}
+	retryAfter = min(retryAfter, MaxRetryAfter)
+	r.Logger.WithFields(logrus.Fields{
+		"pipeline_run": pr.Name,
+		"namespace":    pr.Namespace,
+		"retry_after":  retryAfter.String(),
+	}).Warn("KITE is under load, retrying when it asked to")
+	return ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *PipelineRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Stale issues are resolved once the caches are synced, by the leader only
+	if r.ResolveStaleIssues {
+		if err := mgr.Add(manager.RunnableFunc(r.resolveStaleIssues)); err != nil {
+			return err
+		}
+	}
+	if r.Stats != nil {
+		if err := mgr.Add(r.Stats); err != nil {
+			return err
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
 		For(&v1.PipelineRun{}).
@@ -347,14 +441,15 @@ func (r *PipelineRunReconciler) getTaskRunFailureReason(status *v1.TaskRunStatus
 }
 
 // determineSeverity uses a best-guess approach at determining the severity
-// of a failed PipelineRun.
+// of a failed PipelineRun, one of the clients.Severity constants. It's
+// escalated for long or retried runs, see escalateSeverity.
 func (r *PipelineRunReconciler) determineSeverity(pr *v1.PipelineRun) string {
 	// Name checks
 
 	// Check for indicators that this is for production
 	if strings.Contains(pr.Name, "prod") ||
 		strings.Contains(pr.Name, "production") {
-		return "major"
+		return clients.SeverityMajor
 	}
 
 	// Label checks
@@ -362,19 +457,19 @@ func (r *PipelineRunReconciler) determineSeverity(pr *v1.PipelineRun) string {
 	// Check if this is a release
 	if serviceType, exists := pr.Labels["appstudio.openshift.io/service"]; exists {
 		if serviceType == "release" {
-			return "critical"
+			return clients.SeverityCritical
 		}
 	}
 
 	// Builds or Tests
 	if prType, exists := pr.Labels["pipelines.appstudio.openshift.io/type"]; exists {
 		if prType == "build" || prType == "test" {
-			return "medium"
+			return clients.SeverityMinor
 		}
 	}
 
-	// TODO - figure out what a "low" severity would be.
+	// TODO - figure out what an "info" severity would be.
 
 	// Default
-	return "major"
+	return clients.SeverityMajor
 }
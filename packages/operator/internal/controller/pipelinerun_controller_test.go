@@ -18,7 +18,9 @@ package controller
 
 import (
 	"bytes"
+	"time"
 
+	clients "github.com/konflux-ci/kite/packages/operator/internal/clients"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
@@ -163,6 +165,25 @@ var _ = Describe("PipelineRun Controller", func() {
 			// We should still have some record of attempting to call KITE
 			Expect(mockKiteClient.FailureReports).To(HaveLen(1))
 		})
+
+		It("should retry when KITE asks to under load", func() {
+			mockKiteClient.ReportError = &clients.BackpressureError{StatusCode: 429, RetryAfter: 30 * time.Second}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: lookupKey,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+
+			// Without Retry-After, the usual retry applies
+			mockKiteClient.ReportError = &clients.BackpressureError{StatusCode: 503}
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: lookupKey,
+			})
+
+			Expect(err).To(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(RetryWaitPeriod))
+		})
 	})
 
 	Context("When a PipelineRun succeeds", func() {
@@ -302,11 +323,11 @@ var _ = Describe("PipelineRun Controller", func() {
 			pr.Labels = make(map[string]string)
 			pr.Labels["pipelines.appstudio.openshift.io/type"] = "build"
 			priority = reconciler.determineSeverity(pr)
-			Expect(priority).To(Equal("medium"))
+			Expect(priority).To(Equal("minor"))
 			// Test
 			pr.Labels["pipelines.appstudio.openshift.io/type"] = "test"
 			priority = reconciler.determineSeverity(pr)
-			Expect(priority).To(Equal("medium"))
+			Expect(priority).To(Equal("minor"))
 
 			// Default
 			pr.Labels = nil
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	clients "github.com/konflux-ci/kite/packages/operator/internal/clients"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// SeverityPolicy escalates the severity of the failed PipelineRuns which ran
+// long or were retried, e.g. a pipeline failing after 3 retries and 2 hours
+// being more severe than an instant configuration error. Thresholds of 0 are
+// disabled, the zero SeverityPolicy never escalating.
+type SeverityPolicy struct {
+	// MajorDuration is the duration from which failed runs are at least major
+	MajorDuration time.Duration
+	// CriticalDuration is the duration from which failed runs are critical
+	CriticalDuration time.Duration
+	// MajorRetries is how many retries of a task make failed runs at least major
+	MajorRetries int
+	// CriticalRetries is how many retries of a task make failed runs critical
+	CriticalRetries int
+}
+
+// DefaultSeverityPolicy returns the policy of the operator unless configured otherwise
+func DefaultSeverityPolicy() SeverityPolicy {
+	return SeverityPolicy{
+		MajorDuration:    30 * time.Minute,
+		CriticalDuration: 2 * time.Hour,
+		MajorRetries:     1,
+		CriticalRetries:  3,
+	}
+}
+
+// severityRanks orders the severities, from the least severe
+var severityRanks = map[string]int{
+	clients.SeverityInfo:     0,
+	clients.SeverityMinor:    1,
+	clients.SeverityMajor:    2,
+	clients.SeverityCritical: 3,
+}
+
+// moreSevere returns the more severe of two severities
+func moreSevere(a, b string) string {
+	if severityRanks[b] > severityRanks[a] {
+		return b
+	}
+	return a
+}
+
+// forDuration returns the severity of failed runs which ran for duration,
+// info when it doesn't escalate them
+func (p SeverityPolicy) forDuration(duration time.Duration) string {
+	switch {
+	case p.CriticalDuration > 0 && duration >= p.CriticalDuration:
+		return clients.SeverityCritical
+	case p.MajorDuration > 0 && duration >= p.MajorDuration:
+		return clients.SeverityMajor
+	}
+	return clients.SeverityInfo
+}
+
+// forRetries returns the severity of failed runs with a task retried retries
+// times, info when it doesn't escalate them
+func (p SeverityPolicy) forRetries(retries int) string {
+	switch {
+	case p.CriticalRetries > 0 && retries >= p.CriticalRetries:
+		return clients.SeverityCritical
+	case p.MajorRetries > 0 && retries >= p.MajorRetries:
+		return clients.SeverityMajor
+	}
+	return clients.SeverityInfo
+}
+
+// escalateSeverity raises the severity of a failed PipelineRun according to
+// its duration and the retries of its tasks, see SeverityPolicy
+func (r *PipelineRunReconciler) escalateSeverity(ctx context.Context, pr *v1.PipelineRun, severity string) string {
+	policy := r.SeverityPolicy
+	escalated := severity
+	if pr.Status.StartTime != nil && pr.Status.CompletionTime != nil {
+		escalated = moreSevere(escalated, policy.forDuration(pr.Status.CompletionTime.Sub(pr.Status.StartTime.Time)))
+	}
+	if policy.MajorRetries > 0 || policy.CriticalRetries > 0 {
+		escalated = moreSevere(escalated, policy.forRetries(r.getMaxTaskRetries(ctx, pr)))
+	}
+
+	if escalated != severity {
+		r.Logger.WithFields(logrus.Fields{
+			"pipeline_run": pr.Name,
+			"namespace":    pr.Namespace,
+			"severity":     escalated,
+		}).Debugf("Escalated severity from %s", severity)
+	}
+	return escalated
+}
+
+// getMaxTaskRetries returns how many times the most retried TaskRun of a
+// PipelineRun was retried, TaskRuns that can't be fetched being skipped
+func (r *PipelineRunReconciler) getMaxTaskRetries(ctx context.Context, pr *v1.PipelineRun) int {
+	retries := 0
+	for _, childRef := range pr.Status.ChildReferences {
+		if childRef.Kind != "TaskRun" || childRef.Name == "" {
+			continue
+		}
+		if status := r.getTaskRunStatus(ctx, childRef.Name, pr.Namespace); status != nil {
+			retries = max(retries, len(status.RetriesStatus))
+		}
+	}
+	return retries
+}
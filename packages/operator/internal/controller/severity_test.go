@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Severity Policy", func() {
+	policy := DefaultSeverityPolicy()
+
+	It("should escalate failures by duration", func() {
+		Expect(policy.forDuration(time.Minute)).To(Equal("info"))
+		Expect(policy.forDuration(45 * time.Minute)).To(Equal("major"))
+		Expect(policy.forDuration(3 * time.Hour)).To(Equal("critical"))
+	})
+
+	It("should escalate failures by retries", func() {
+		Expect(policy.forRetries(0)).To(Equal("info"))
+		Expect(policy.forRetries(1)).To(Equal("major"))
+		Expect(policy.forRetries(3)).To(Equal("critical"))
+	})
+
+	It("should not escalate with disabled thresholds", func() {
+		Expect(SeverityPolicy{}.forDuration(24 * time.Hour)).To(Equal("info"))
+		Expect(SeverityPolicy{}.forRetries(10)).To(Equal("info"))
+	})
+
+	It("should escalate long failed runs, never lowering their severity", func() {
+		reconciler := &PipelineRunReconciler{Logger: logrus.New(), SeverityPolicy: policy}
+		completed := metav1.Now()
+		started := metav1.NewTime(completed.Add(-3 * time.Hour))
+		pr := &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "long-build"},
+			Status: v1.PipelineRunStatus{
+				PipelineRunStatusFields: v1.PipelineRunStatusFields{StartTime: &started, CompletionTime: &completed},
+			},
+		}
+		Expect(reconciler.escalateSeverity(ctx, pr, "minor")).To(Equal("critical"))
+
+		started = metav1.NewTime(completed.Add(-10 * time.Second))
+		Expect(reconciler.escalateSeverity(ctx, pr, "minor")).To(Equal("minor"))
+		Expect(reconciler.escalateSeverity(ctx, pr, "critical")).To(Equal("critical"))
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	clients "github.com/konflux-ci/kite/packages/operator/internal/clients"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// resolveStaleIssues resolves the ACTIVE issues of the pipelines whose last
+// PipelineRun succeeded, or which have no PipelineRun anymore, in every
+// watched namespace. It heals the drift accumulated while the operator or KITE were
+// down, the outcome of the runs completed meanwhile never being reported.
+// Failures are logged, namespaces failing being skipped, so that they never
+// keep the operator from starting.
+func (r *PipelineRunReconciler) resolveStaleIssues(ctx context.Context) error {
+	namespaces := r.Namespaces
+	if len(namespaces) == 0 {
+		var list corev1.NamespaceList
+		if err := r.List(ctx, &list); err != nil {
+			r.Logger.WithError(err).Error("Failed to list namespaces, stale issues not resolved")
+			return nil
+		}
+		for _, namespace := range list.Items {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+
+	resolved := 0
+	for _, namespace := range namespaces {
+		count, err := r.resolveStaleNamespaceIssues(ctx, namespace)
+		if err != nil {
+			r.Logger.WithError(err).WithField("namespace", namespace).Error("Failed to resolve stale issues")
+		}
+		resolved += count
+	}
+
+	r.Logger.WithField("resolved", resolved).Info("Resolved stale pipeline issues")
+	return nil
+}
+
+// resolveStaleNamespaceIssues resolves the stale issues of a namespace, see
+// resolveStaleIssues, returning how many pipelines got their issues resolved
+func (r *PipelineRunReconciler) resolveStaleNamespaceIssues(ctx context.Context, namespace string) (int, error) {
+	issues, err := r.KiteClient.ListActivePipelineIssues(ctx, namespace)
+	if err != nil || len(issues) == 0 {
+		return 0, err
+	}
+
+	var pipelineRuns v1.PipelineRunList
+	if err := r.List(ctx, &pipelineRuns, client.InNamespace(namespace)); err != nil {
+		return 0, err
+	}
+	// The last completed run of each pipeline, and the pipelines with runs
+	lastRuns := map[string]*v1.PipelineRun{}
+	pipelines := map[string]bool{}
+	for i := range pipelineRuns.Items {
+		pr := &pipelineRuns.Items[i]
+		name := r.getPipelineName(pr)
+		pipelines[name] = true
+		if pr.Status.CompletionTime == nil {
+			continue
+		}
+		if last, ok := lastRuns[name]; !ok || pr.Status.CompletionTime.After(last.Status.CompletionTime.Time) {
+			lastRuns[name] = pr
+		}
+	}
+
+	resolved := 0
+	for _, issue := range issues {
+		reason := r.staleReason(issue, pipelines, lastRuns)
+		if reason == "" {
+			continue
+		}
+		payload := clients.PipelineSuccessPayload{PipelineName: issue.PipelineName, Namespace: namespace}
+		if err := r.KiteClient.ReportPipelineSuccess(ctx, payload); err != nil {
+			r.Stats.RecordFailure(namespace)
+			return resolved, err
+		}
+		r.Stats.RecordReport(namespace)
+		r.Logger.WithFields(logrus.Fields{
+			"id":        issue.ID,
+			"pipeline":  issue.PipelineName,
+			"namespace": namespace,
+			"operation": "pipeline-success",
+		}).Infof("Resolved stale issue, %s", reason)
+		resolved++
+	}
+	return resolved, nil
+}
+
+// staleReason returns why the issue of a pipeline is stale, empty when it isn't.
+// KITE normalizes the names of pipelines, so pipelines are only considered gone
+// when no pipeline has a name starting with the name of the issue either.
+func (r *PipelineRunReconciler) staleReason(issue clients.PipelineIssue, pipelines map[string]bool, lastRuns map[string]*v1.PipelineRun) string {
+	if last, ok := lastRuns[issue.PipelineName]; ok {
+		if r.getPipelineRunStatus(last) == "succeeded" {
+			return "the last PipelineRun of the pipeline succeeded"
+		}
+		return ""
+	}
+	for name := range pipelines {
+		if strings.HasPrefix(name, issue.PipelineName) {
+			return ""
+		}
+	}
+	return "the pipeline has no PipelineRun anymore"
+}
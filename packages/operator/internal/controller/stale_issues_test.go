@@ -0,0 +1,71 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	clients "github.com/konflux-ci/kite/packages/operator/internal/clients"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knative "knative.dev/pkg/apis"
+)
+
+var _ = Describe("Stale Issues", func() {
+	var reconciler *PipelineRunReconciler
+
+	// completedRun returns a PipelineRun of pipeline completed at completion
+	completedRun := func(pipeline string, succeeded bool, completion time.Time) *v1.PipelineRun {
+		status := corev1.ConditionFalse
+		if succeeded {
+			status = corev1.ConditionTrue
+		}
+		completed := metav1.NewTime(completion)
+		pr := &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: pipeline + "-run"},
+			Spec:       v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: pipeline}},
+		}
+		pr.Status.Conditions = []knative.Condition{{Type: RunCompleted, Status: status}}
+		pr.Status.CompletionTime = &completed
+		return pr
+	}
+
+	BeforeEach(func() {
+		reconciler = &PipelineRunReconciler{Logger: logrus.New()}
+	})
+
+	It("should resolve the issues of pipelines whose last run succeeded", func() {
+		now := time.Now()
+		lastRuns := map[string]*v1.PipelineRun{"build": completedRun("build", true, now)}
+		pipelines := map[string]bool{"build": true}
+		Expect(reconciler.staleReason(clients.PipelineIssue{PipelineName: "build"}, pipelines, lastRuns)).NotTo(BeEmpty())
+
+		lastRuns["build"] = completedRun("build", false, now)
+		Expect(reconciler.staleReason(clients.PipelineIssue{PipelineName: "build"}, pipelines, lastRuns)).To(BeEmpty())
+	})
+
+	It("should resolve the issues of pipelines without runs", func() {
+		pipelines := map[string]bool{"build-abc12": true}
+		Expect(reconciler.staleReason(clients.PipelineIssue{PipelineName: "deploy"}, pipelines, nil)).NotTo(BeEmpty())
+		// Normalized names and running pipelines are kept
+		Expect(reconciler.staleReason(clients.PipelineIssue{PipelineName: "build"}, pipelines, nil)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// NamespaceStats are the reporting statistics of the PipelineRuns of a namespace
+type NamespaceStats struct {
+	// ReportsSent is how many failures and successes were reported to KITE
+	ReportsSent int `json:"reportsSent"`
+	// ReportFailures is how many reports to KITE failed, to be retried
+	ReportFailures int `json:"reportFailures"`
+	// SkippedRuns is how many completed PipelineRuns had an unknown status
+	SkippedRuns int `json:"skippedRuns"`
+	// SuppressedReports is how many failures weren't reported, their root failure being reported
+	SuppressedReports int `json:"suppressedReports"`
+	// LastContact is when a report to KITE last succeeded
+	LastContact *metav1.Time `json:"lastContact,omitempty"`
+}
+
+// ReportStats keeps the reporting statistics of the operator per namespace,
+// and saves them periodically in a ConfigMap, with a key per namespace holding
+// its NamespaceStats as JSON, so that cluster admins can audit the operator
+// without going through its logs. The statistics survive restarts, those of
+// the ConfigMap being loaded on start. A nil ReportStats records nothing.
+type ReportStats struct {
+	client   client.Client
+	reader   client.Reader
+	key      types.NamespacedName
+	interval time.Duration
+	logger   *logrus.Logger
+
+	mu    sync.Mutex
+	stats map[string]*NamespaceStats
+	dirty bool
+}
+
+// NewReportStats creates the statistics saved in the ConfigMap key every
+// interval. The ConfigMap is read with reader, not to cache every ConfigMap
+// of the cluster, and written with c.
+func NewReportStats(c client.Client, reader client.Reader, key types.NamespacedName, interval time.Duration, logger *logrus.Logger) *ReportStats {
+	return &ReportStats{
+		client:   c,
+		reader:   reader,
+		key:      key,
+		interval: interval,
+		logger:   logger,
+		stats:    map[string]*NamespaceStats{},
+	}
+}
+
+// RecordReport records a report to KITE sent for a namespace
+func (s *ReportStats) RecordReport(namespace string) {
+	s.record(namespace, func(stats *NamespaceStats) {
+		now := metav1.Now()
+		stats.ReportsSent++
+		stats.LastContact = &now
+	})
+}
+
+// RecordFailure records a report to KITE which failed for a namespace
+func (s *ReportStats) RecordFailure(namespace string) {
+	s.record(namespace, func(stats *NamespaceStats) { stats.ReportFailures++ })
+}
+
+// RecordSkipped records a completed PipelineRun of a namespace which wasn't reported
+func (s *ReportStats) RecordSkipped(namespace string) {
+	s.record(namespace, func(stats *NamespaceStats) { stats.SkippedRuns++ })
+}
+
+// RecordSuppressed records a failure of a namespace suppressed by the FailureCoordinator
+func (s *ReportStats) RecordSuppressed(namespace string) {
+	s.record(namespace, func(stats *NamespaceStats) { stats.SuppressedReports++ })
+}
+
+// record updates the statistics of a namespace
+func (s *ReportStats) record(namespace string, update func(*NamespaceStats)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats, ok := s.stats[namespace]
+	if !ok {
+		stats = &NamespaceStats{}
+		s.stats[namespace] = stats
+	}
+	update(stats)
+	s.dirty = true
+}
+
+// Get returns the statistics of a namespace
+func (s *ReportStats) Get(namespace string) NamespaceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stats, ok := s.stats[namespace]; ok {
+		return *stats
+	}
+	return NamespaceStats{}
+}
+
+// Start loads the statistics of the ConfigMap, then saves them every interval
+// until ctx is done, saving them a last time. It implements manager.Runnable,
+// the statistics being saved by the leader only.
+func (s *ReportStats) Start(ctx context.Context) error {
+	if err := s.load(ctx); err != nil {
+		s.logger.WithError(err).Error("Failed to load the reporting statistics, starting from scratch")
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// The manager's context is done, save with a context of our own
+			saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := s.Save(saveCtx); err != nil {
+				s.logger.WithError(err).Error("Failed to save the reporting statistics")
+			}
+			return nil
+		case <-ticker.C:
+			if err := s.Save(ctx); err != nil {
+				s.logger.WithError(err).Error("Failed to save the reporting statistics")
+			}
+		}
+	}
+}
+
+// load adds the statistics of the ConfigMap to those recorded since the start
+func (s *ReportStats) load(ctx context.Context) error {
+	var configMap corev1.ConfigMap
+	if err := s.reader.Get(ctx, s.key, &configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for namespace, data := range configMap.Data {
+		var saved NamespaceStats
+		if err := json.Unmarshal([]byte(data), &saved); err != nil {
+			s.logger.WithError(err).WithField("namespace", namespace).Warn("Ignoring invalid reporting statistics")
+			continue
+		}
+		stats, ok := s.stats[namespace]
+		if !ok {
+			s.stats[namespace] = &saved
+			continue
+		}
+		stats.ReportsSent += saved.ReportsSent
+		stats.ReportFailures += saved.ReportFailures
+		stats.SkippedRuns += saved.SkippedRuns
+		stats.SuppressedReports += saved.SuppressedReports
+		if stats.LastContact == nil {
+			stats.LastContact = saved.LastContact
+		}
+	}
+	return nil
+}
+
+// Save writes the statistics in the ConfigMap, creating it when missing,
+// unless nothing was recorded since they were last saved
+func (s *ReportStats) Save(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	data := make(map[string]string, len(s.stats))
+	for namespace, stats := range s.stats {
+		encoded, err := json.Marshal(stats)
+		if err != nil {
+			s.mu.Unlock()
+			return err
+		}
+		data[namespace] = string(encoded)
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	err := s.write(ctx, data)
+	if err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// write replaces the data of the ConfigMap
+func (s *ReportStats) write(ctx context.Context, data map[string]string) error {
+	var configMap corev1.ConfigMap
+	err := s.reader.Get(ctx, s.key, &configMap)
+	if errors.IsNotFound(err) {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.key.Name,
+				Namespace: s.key.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": "operator"},
+			},
+			Data: data,
+		}
+		return s.client.Create(ctx, &configMap)
+	}
+	if err != nil {
+		return err
+	}
+	configMap.Data = data
+	return s.client.Update(ctx, &configMap)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Report Stats", func() {
+	key := types.NamespacedName{Name: "kite-operator-stats", Namespace: KiteBridgeOperatorNamespace}
+
+	AfterEach(func() {
+		var configMap corev1.ConfigMap
+		if err := k8sClient.Get(ctx, key, &configMap); err == nil {
+			Expect(k8sClient.Delete(ctx, &configMap)).To(Succeed())
+		}
+	})
+
+	It("should ignore records without stats", func() {
+		var stats *ReportStats
+		Expect(func() { stats.RecordReport("team-a") }).NotTo(Panic())
+	})
+
+	It("should save the statistics per namespace and load them back", func() {
+		stats := NewReportStats(k8sClient, k8sClient, key, time.Minute, logrus.New())
+		stats.RecordReport("team-a")
+		stats.RecordReport("team-a")
+		stats.RecordFailure("team-a")
+		stats.RecordSkipped("team-b")
+		Expect(stats.Save(ctx)).To(Succeed())
+
+		var configMap corev1.ConfigMap
+		Expect(k8sClient.Get(ctx, key, &configMap)).To(Succeed())
+		var saved NamespaceStats
+		Expect(json.Unmarshal([]byte(configMap.Data["team-a"]), &saved)).To(Succeed())
+		Expect(saved.ReportsSent).To(Equal(2))
+		Expect(saved.ReportFailures).To(Equal(1))
+		Expect(saved.LastContact).NotTo(BeNil())
+
+		// A restarted operator adds its statistics to the saved ones
+		restarted := NewReportStats(k8sClient, k8sClient, key, time.Minute, logrus.New())
+		restarted.RecordReport("team-a")
+		Expect(restarted.load(ctx)).To(Succeed())
+		Expect(restarted.Get("team-a").ReportsSent).To(Equal(3))
+		Expect(restarted.Get("team-b").SkippedRuns).To(Equal(1))
+	})
+})
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	clients "github.com/konflux-ci/kite/packages/operator/internal/clients"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Phases of the PipelineRuns which aren't completed
+const (
+	PhasePending = "pending"
+	PhaseRunning = "running"
+)
+
+// StuckPolicy tells when PipelineRuns are stuck, e.g. pending for lack of
+// quota or because an admission webhook keeps rejecting their pods, a failure
+// mode the reports of completed runs miss. Thresholds of 0 are disabled.
+type StuckPolicy struct {
+	// PendingAfter is how long runs can be pending, from their creation
+	PendingAfter time.Duration
+	// RunningAfter is how long runs can be running, from their start
+	RunningAfter time.Duration
+}
+
+// DefaultStuckPolicy returns the policy of the operator unless configured otherwise
+func DefaultStuckPolicy() StuckPolicy {
+	return StuckPolicy{
+		PendingAfter: 30 * time.Minute,
+		RunningAfter: 6 * time.Hour,
+	}
+}
+
+// stuckRuns remembers the PipelineRuns reported stuck, with the phase they
+// were stuck in, to resolve their issues once they progress
+type stuckRuns struct {
+	mu     sync.Mutex
+	phases map[types.NamespacedName]string
+}
+
+// get returns the phase a run was reported stuck in, empty when it wasn't
+func (s *stuckRuns) get(key types.NamespacedName) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.phases[key]
+}
+
+// set remembers the phase a run was reported stuck in, forgetting it when empty
+func (s *stuckRuns) set(key types.NamespacedName, phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if phase == "" {
+		delete(s.phases, key)
+		return
+	}
+	if s.phases == nil {
+		s.phases = map[types.NamespacedName]string{}
+	}
+	s.phases[key] = phase
+}
+
+// runPhase returns the phase of a PipelineRun which isn't completed, and since when it is in it
+func runPhase(pr *v1.PipelineRun) (string, time.Time) {
+	if pr.Spec.Status == v1.PipelineRunSpecStatusPending || pr.Status.StartTime == nil {
+		return PhasePending, pr.CreationTimestamp.Time
+	}
+	return PhaseRunning, pr.Status.StartTime.Time
+}
+
+// handleIncompleteRun reports the PipelineRuns which aren't completed once
+// they are stuck in their phase, see StuckPolicy, requeuing them until then,
+// and resolves their issue when they progress to another phase
+func (r *PipelineRunReconciler) handleIncompleteRun(ctx context.Context, pr *v1.PipelineRun) (ctrl.Result, error) {
+	key := types.NamespacedName{Name: pr.Name, Namespace: pr.Namespace}
+	phase, since := runPhase(pr)
+	if reported := r.stuckRuns.get(key); reported != "" && reported != phase {
+		if err := r.resolveStuckRun(ctx, key, reported); err != nil {
+			return r.retryResult(pr, err, fmt.Errorf("failed to resolve stuck pipeline run from controller"))
+		}
+	}
+
+	threshold := r.StuckPolicy.PendingAfter
+	if phase == PhaseRunning {
+		threshold = r.StuckPolicy.RunningAfter
+	}
+	if threshold <= 0 || r.stuckRuns.get(key) == phase {
+		return ctrl.Result{}, nil
+	}
+	stuckFor := time.Since(since)
+	if stuckFor < threshold {
+		// Check again once it would be stuck, unless it progresses meanwhile
+		return ctrl.Result{RequeueAfter: threshold - stuckFor}, nil
+	}
+
+	payload := clients.PipelineRunStuckPayload{
+		PipelineName: r.getPipelineName(pr),
+		Namespace:    pr.Namespace,
+		RunName:      pr.Name,
+		Phase:        phase,
+		Reason:       stuckReason(pr, phase, stuckFor),
+		Severity:     clients.SeverityMajor,
+	}
+	if err := r.KiteClient.ReportPipelineRunStuck(ctx, payload); err != nil {
+		r.Stats.RecordFailure(pr.Namespace)
+		r.Logger.WithError(err).WithFields(logrus.Fields{
+			"pipeline_run": pr.Name,
+			"namespace":    pr.Namespace,
+			"operation":    "pipeline-run-stuck",
+		}).Error("An error occurred when reporting a stuck pipeline run from controller.")
+		return r.retryResult(pr, err, fmt.Errorf("failed to report stuck pipeline run from controller"))
+	}
+	r.Stats.RecordReport(pr.Namespace)
+	r.stuckRuns.set(key, phase)
+
+	r.Logger.WithFields(logrus.Fields{
+		"pipeline_run": pr.Name,
+		"namespace":    pr.Namespace,
+		"phase":        phase,
+		"operation":    "pipeline-run-stuck",
+	}).Info("Successfully reported stuck pipeline run to KITE")
+	return ctrl.Result{}, nil
+}
+
+// resolveStuckIssue resolves the issue of a PipelineRun reported stuck which
+// completed or was deleted, doing nothing for the other runs
+func (r *PipelineRunReconciler) resolveStuckIssue(ctx context.Context, key types.NamespacedName) error {
+	phase := r.stuckRuns.get(key)
+	if phase == "" {
+		return nil
+	}
+	return r.resolveStuckRun(ctx, key, phase)
+}
+
+// resolveStuckRun resolves the issue of a PipelineRun which was stuck in phase
+func (r *PipelineRunReconciler) resolveStuckRun(ctx context.Context, key types.NamespacedName, phase string) error {
+	payload := clients.PipelineRunStuckPayload{
+		Namespace: key.Namespace,
+		RunName:   key.Name,
+		Phase:     phase,
+	}
+	if err := r.KiteClient.ResolvePipelineRunStuck(ctx, payload); err != nil {
+		r.Stats.RecordFailure(key.Namespace)
+		r.Logger.WithError(err).WithFields(logrus.Fields{
+			"pipeline_run": key.Name,
+			"namespace":    key.Namespace,
+			"operation":    "pipeline-run-progressed",
+		}).Error("An error occurred when resolving a stuck pipeline run from controller.")
+		return err
+	}
+	r.Stats.RecordReport(key.Namespace)
+	r.stuckRuns.set(key, "")
+
+	r.Logger.WithFields(logrus.Fields{
+		"pipeline_run": key.Name,
+		"namespace":    key.Namespace,
+		"phase":        phase,
+		"operation":    "pipeline-run-progressed",
+	}).Info("Successfully resolved stuck pipeline run with KITE")
+	return nil
+}
+
+// stuckReason explains why a PipelineRun is stuck, with the message of its
+// condition when it has one, e.g. why its pods can't be created
+func stuckReason(pr *v1.PipelineRun, phase string, stuckFor time.Duration) string {
+	reason := fmt.Sprintf("PipelineRun %s has been %s for %s", pr.Name, phase, stuckFor.Round(time.Minute))
+	for _, condition := range pr.Status.Conditions {
+		if condition.Type == RunCompleted && condition.Message != "" {
+			reason += ": " + condition.Message
+		}
+	}
+	return reason
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Stuck PipelineRuns", func() {
+	var (
+		mockKiteClient *MockKiteClient
+		reconciler     *PipelineRunReconciler
+	)
+
+	// pendingRun returns a PipelineRun created age ago which didn't start
+	pendingRun := func(age time.Duration) *v1.PipelineRun {
+		return &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "build-pending",
+				Namespace:         KiteBridgeOperatorNamespace,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+			Spec: v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "build"}},
+		}
+	}
+
+	BeforeEach(func() {
+		mockKiteClient = &MockKiteClient{}
+		reconciler = &PipelineRunReconciler{
+			KiteClient:  mockKiteClient,
+			Logger:      logrus.New(),
+			StuckPolicy: DefaultStuckPolicy(),
+		}
+	})
+
+	It("should requeue runs until they are stuck", func() {
+		result, err := reconciler.handleIncompleteRun(ctx, pendingRun(10*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", 20*time.Minute, time.Minute))
+		Expect(mockKiteClient.StuckReports).To(BeEmpty())
+	})
+
+	It("should report stuck runs once, and resolve them when they progress", func() {
+		pr := pendingRun(time.Hour)
+		_, err := reconciler.handleIncompleteRun(ctx, pr)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reconciler.handleIncompleteRun(ctx, pr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKiteClient.StuckReports).To(HaveLen(1))
+		Expect(mockKiteClient.StuckReports[0].Phase).To(Equal(PhasePending))
+		Expect(mockKiteClient.StuckReports[0].PipelineName).To(Equal("build"))
+
+		started := metav1.Now()
+		pr.Status.StartTime = &started
+		result, err := reconciler.handleIncompleteRun(ctx, pr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockKiteClient.StuckResolutions).To(HaveLen(1))
+		Expect(mockKiteClient.StuckResolutions[0].Phase).To(Equal(PhasePending))
+		Expect(result.RequeueAfter).To(BeNumerically("~", 6*time.Hour, time.Minute))
+	})
+
+	It("should resolve stuck runs which were deleted", func() {
+		_, err := reconciler.handleIncompleteRun(ctx, pendingRun(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		key := types.NamespacedName{Name: "build-pending", Namespace: KiteBridgeOperatorNamespace}
+		Expect(reconciler.resolveStuckIssue(ctx, key)).To(Succeed())
+		Expect(reconciler.resolveStuckIssue(ctx, key)).To(Succeed())
+		Expect(mockKiteClient.StuckResolutions).To(HaveLen(1))
+	})
+
+	It("should not report runs with disabled thresholds", func() {
+		reconciler.StuckPolicy = StuckPolicy{}
+		result, err := reconciler.handleIncompleteRun(ctx, pendingRun(24*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(mockKiteClient.StuckReports).To(BeEmpty())
+	})
+})
@@ -103,7 +103,14 @@ func createNamespace(name string) {
 type MockKiteClient struct {
 	FailureReports []clients.PipelineFailurePayload
 	SuccessReports []clients.PipelineSuccessPayload
-	ShouldFail     bool
+	StuckReports   []clients.PipelineRunStuckPayload
+	// StuckResolutions are the stuck runs whose issue was resolved
+	StuckResolutions []clients.PipelineRunStuckPayload
+	// ActiveIssues are the active issues of pipelines, by namespace
+	ActiveIssues map[string][]clients.PipelineIssue
+	ShouldFail   bool
+	// ReportError, when set, is returned by the reports instead of their usual error
+	ReportError error
 }
 
 // Ensure we're implementing the interface
@@ -111,6 +118,9 @@ var _ clients.KiteWebhookClient = (*MockKiteClient)(nil)
 
 func (m *MockKiteClient) ReportPipelineFailure(ctx context.Context, payload clients.PipelineFailurePayload) error {
 	m.FailureReports = append(m.FailureReports, payload)
+	if m.ReportError != nil {
+		return m.ReportError
+	}
 	if m.ShouldFail {
 		return fmt.Errorf("Failed to report pipeline failure")
 	}
@@ -119,8 +129,34 @@ func (m *MockKiteClient) ReportPipelineFailure(ctx context.Context, payload clie
 
 func (m *MockKiteClient) ReportPipelineSuccess(ctx context.Context, payload clients.PipelineSuccessPayload) error {
 	m.SuccessReports = append(m.SuccessReports, payload)
+	if m.ReportError != nil {
+		return m.ReportError
+	}
 	if m.ShouldFail {
 		return fmt.Errorf("failed to report pipeline success")
 	}
 	return nil
 }
+
+func (m *MockKiteClient) ListActivePipelineIssues(ctx context.Context, namespace string) ([]clients.PipelineIssue, error) {
+	if m.ShouldFail {
+		return nil, fmt.Errorf("failed to list active pipeline issues")
+	}
+	return m.ActiveIssues[namespace], nil
+}
+
+func (m *MockKiteClient) ReportPipelineRunStuck(ctx context.Context, payload clients.PipelineRunStuckPayload) error {
+	m.StuckReports = append(m.StuckReports, payload)
+	if m.ShouldFail {
+		return fmt.Errorf("failed to report stuck pipeline run")
+	}
+	return nil
+}
+
+func (m *MockKiteClient) ResolvePipelineRunStuck(ctx context.Context, payload clients.PipelineRunStuckPayload) error {
+	m.StuckResolutions = append(m.StuckResolutions, payload)
+	if m.ShouldFail {
+		return fmt.Errorf("failed to resolve stuck pipeline run")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// Annotations and labels set by Pipelines as Code on the PipelineRuns it creates
+const (
+	PACRepoURLKey     = "pipelinesascode.tekton.dev/repo-url"
+	PACRevisionKey    = "pipelinesascode.tekton.dev/sha"
+	PACCommitURLKey   = "pipelinesascode.tekton.dev/sha-url"
+	PACPullRequestKey = "pipelinesascode.tekton.dev/pull-request"
+	PACGitProviderKey = "pipelinesascode.tekton.dev/git-provider"
+)
+
+// vcsMetadata is the git metadata of a PipelineRun, linking its issue to the
+// commit and pull request which broke the pipeline
+type vcsMetadata struct {
+	RepoURL        string
+	Revision       string
+	PullRequest    string
+	CommitURL      string
+	PullRequestURL string
+}
+
+// getVCSMetadata returns the git metadata of a PipelineRun created by Pipelines
+// as Code, empty for other runs. The URLs Pipelines as Code doesn't set are
+// built from the repository for the git providers it supports.
+func getVCSMetadata(pr *v1.PipelineRun) vcsMetadata {
+	metadata := vcsMetadata{
+		RepoURL:     strings.TrimSuffix(pacValue(pr, PACRepoURLKey), "/"),
+		Revision:    pacValue(pr, PACRevisionKey),
+		PullRequest: pacValue(pr, PACPullRequestKey),
+		CommitURL:   pacValue(pr, PACCommitURLKey),
+	}
+	if metadata.RepoURL == "" {
+		return metadata
+	}
+
+	commitPath, pullRequestPath := providerPaths(pacValue(pr, PACGitProviderKey), metadata.RepoURL)
+	if metadata.CommitURL == "" && metadata.Revision != "" && commitPath != "" {
+		metadata.CommitURL = metadata.RepoURL + commitPath + metadata.Revision
+	}
+	if metadata.PullRequest != "" && pullRequestPath != "" {
+		metadata.PullRequestURL = metadata.RepoURL + pullRequestPath + metadata.PullRequest
+	}
+	return metadata
+}
+
+// pacValue returns the value of an annotation of Pipelines as Code, else of
+// the label of the same key
+func pacValue(pr *v1.PipelineRun, key string) string {
+	if value := pr.Annotations[key]; value != "" {
+		return value
+	}
+	return pr.Labels[key]
+}
+
+// providerPaths returns the paths of the commits and pull requests in the
+// repositories of a git provider, empty for unknown providers. Runs without
+// provider are guessed to be from GitHub or GitLab from their repository.
+func providerPaths(provider, repoURL string) (string, string) {
+	if provider == "" {
+		switch {
+		case strings.Contains(repoURL, "github"):
+			provider = "github"
+		case strings.Contains(repoURL, "gitlab"):
+			provider = "gitlab"
+		}
+	}
+	switch provider {
+	case "github":
+		return "/commit/", "/pull/"
+	case "gitlab":
+		return "/-/commit/", "/-/merge_requests/"
+	case "gitea", "forgejo":
+		return "/commit/", "/pulls/"
+	case "bitbucket-cloud", "bitbucket-datacenter":
+		return "/commits/", "/pull-requests/"
+	}
+	return "", ""
+}
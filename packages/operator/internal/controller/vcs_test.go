@@ -0,0 +1,62 @@
+/*
+Copyright 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VCS Metadata", func() {
+	It("should build the URLs Pipelines as Code doesn't set", func() {
+		pr := NewPipelineRunBuilder("frontend-on-pull-request-x8f2k", KiteBridgeOperatorNamespace).
+			WithAnnotations(map[string]string{
+				PACRepoURLKey:     "https://gitlab.com/org/frontend/",
+				PACRevisionKey:    "4f3a9c1",
+				PACGitProviderKey: "gitlab",
+			}).
+			WithLabels(map[string]string{PACPullRequestKey: "42"}).
+			Build()
+
+		Expect(getVCSMetadata(pr)).To(Equal(vcsMetadata{
+			RepoURL:        "https://gitlab.com/org/frontend",
+			Revision:       "4f3a9c1",
+			PullRequest:    "42",
+			CommitURL:      "https://gitlab.com/org/frontend/-/commit/4f3a9c1",
+			PullRequestURL: "https://gitlab.com/org/frontend/-/merge_requests/42",
+		}))
+	})
+
+	It("should prefer the commit URL of Pipelines as Code", func() {
+		pr := NewPipelineRunBuilder("frontend-on-push-x8f2k", KiteBridgeOperatorNamespace).
+			WithAnnotations(map[string]string{
+				PACRepoURLKey:   "https://github.com/org/frontend",
+				PACRevisionKey:  "4f3a9c1",
+				PACCommitURLKey: "https://github.com/org/frontend/commit/4f3a9c1abcdef",
+			}).
+			Build()
+
+		metadata := getVCSMetadata(pr)
+		Expect(metadata.CommitURL).To(Equal("https://github.com/org/frontend/commit/4f3a9c1abcdef"))
+		Expect(metadata.PullRequestURL).To(BeEmpty())
+	})
+
+	It("should have no metadata for other runs", func() {
+		pr := NewPipelineRunBuilder("manual-run", KiteBridgeOperatorNamespace).Build()
+		Expect(getVCSMetadata(pr)).To(Equal(vcsMetadata{}))
+	})
+})